@@ -2,27 +2,36 @@
 package background
 
 import (
+	"errors"
+	"fmt"
 	"log"
 	"time"
 
 	"PeopleFlow/backend/model"
-	"PeopleFlow/backend/repository"
 	"PeopleFlow/backend/service"
 )
 
-// Worker repräsentiert einen Hintergrundprozess für regelmäßige Aufgaben
+// schedulerInterval bestimmt, wie oft der Worker nach fälligen Jobs sucht
+const schedulerInterval = 30 * time.Second
+
+// Worker repräsentiert einen Hintergrundprozess für regelmäßige Aufgaben.
+// Die Aufgaben sind als Jobs mit eigenem Zeitplan registriert; ob ein fälliger Job
+// auf dieser Instanz läuft, entscheidet die Sperre im JobService.
 type Worker struct {
-	stopChan chan struct{}
-	running  bool
-	lastEmailReport time.Time
+	stopChan   chan struct{}
+	running    bool
+	jobService *service.JobService
 }
 
-// NewWorker erstellt einen neuen Worker
+// NewWorker erstellt einen neuen Worker und registriert die Hintergrundjobs
 func NewWorker() *Worker {
-	return &Worker{
-		stopChan: make(chan struct{}),
-		running:  false,
+	w := &Worker{
+		stopChan:   make(chan struct{}),
+		running:    false,
+		jobService: service.NewJobService(),
 	}
+	w.registerJobs()
+	return w
 }
 
 // Start startet den Worker
@@ -45,27 +54,21 @@ func (w *Worker) Stop() {
 	w.stopChan <- struct{}{}
 }
 
-// run führt die regelmäßigen Aufgaben aus
+// run startet regelmäßig alle fälligen Jobs
 func (w *Worker) run() {
-	// Timer für regelmäßige Aufgaben (alle 5 Minuten)
-	ticker := time.NewTicker(5 * time.Minute)
-	defer ticker.Stop()
-
-	// Timer für wöchentliche E-Mail-Berichte (jeden Freitag um 17:00)
-	emailTicker := time.NewTicker(1 * time.Hour)
-	defer emailTicker.Stop()
+	if err := w.jobService.SyncJobs(time.Now()); err != nil {
+		log.Printf("Error registering background jobs: %v", err)
+	}
 
-	// Sofort erste Synchronisierung durchführen
-	w.performSynchronization()
+	ticker := time.NewTicker(schedulerInterval)
+	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ticker.C:
-			// Synchronisierungsaufgaben ausführen
-			w.performSynchronization()
-		case <-emailTicker.C:
-			// Prüfen, ob wöchentliche E-Mail-Berichte gesendet werden sollen
-			w.checkWeeklyEmailReports()
+			if _, err := w.jobService.RunDue(time.Now()); err != nil {
+				log.Printf("Error running due background jobs: %v", err)
+			}
 		case <-w.stopChan:
 			log.Println("Background worker stopped")
 			return
@@ -73,189 +76,237 @@ func (w *Worker) run() {
 	}
 }
 
-// performSynchronization führt Synchronisierungsaufgaben aus
-func (w *Worker) performSynchronization() {
-	log.Println("Performing background synchronization tasks...")
+// registerJobs registriert alle Hintergrundjobs mit ihrem Standard-Zeitplan
+func (w *Worker) registerJobs() {
+	jobs := []service.JobDefinition{
+		{
+			Name:            "overtime_recalculation",
+			Label:           "Überstunden neu berechnen",
+			Description:     "Überstundensalden aller Mitarbeiter aus den Zeiteinträgen neu berechnen",
+			DefaultSchedule: "0 2 * * *",
+			Run:             w.recalculateOvertime,
+		},
+		{
+			Name:            "report_subscriptions",
+			Label:           "Berichte per E-Mail",
+			Description:     "Fällige Berichte aus den Abonnements der Benutzer versenden",
+			DefaultSchedule: "0 * * * *",
+			Run:             w.sendReportSubscriptions,
+		},
+		{
+			Name:            "chat_absence_digest",
+			Label:           "Abwesenheitsübersicht (Slack/Teams)",
+			Description:     "Tägliche Übersicht der Abwesenheiten an die Chat-Kanäle senden",
+			DefaultSchedule: "0 7 * * *",
+			SkipMissed:      true,
+			Run:             w.sendAbsenceDigest,
+		},
+		{
+			Name:            "chat_conversations_due",
+			Label:           "Gespräche der Woche (Slack/Teams)",
+			Description:     "Die in dieser Woche anstehenden Mitarbeitergespräche an die Chat-Kanäle senden",
+			DefaultSchedule: "0 8 * * 1",
+			SkipMissed:      true,
+			Run:             w.sendConversationsDue,
+		},
+		{
+			Name:            "daily_notifications",
+			Label:           "Tägliche Erinnerungen",
+			Description:     "Erinnerungen an anstehende Mitarbeitergespräche und ablaufende Dokumente erzeugen",
+			DefaultSchedule: "0 6 * * *",
+			Run:             w.checkDailyNotifications,
+		},
+		{
+			Name:            "email_outbox",
+			Label:           "E-Mail-Postausgang",
+			Description:     "Wartende E-Mails und fällige Wiederholungen aus dem Postausgang senden",
+			DefaultSchedule: "* * * * *",
+			Timeout:         10 * time.Minute,
+			Run:             w.processEmailOutbox,
+		},
+		{
+			Name:            "webhook_retries",
+			Label:           "Webhook-Wiederholungen",
+			Description:     "Fehlgeschlagene Webhook-Zustellungen erneut zustellen",
+			DefaultSchedule: "* * * * *",
+			Timeout:         10 * time.Minute,
+			Run:             w.retryWebhookDeliveries,
+		},
+		{
+			Name:            "labor_cost_snapshots",
+			Label:           "Personalkosten festschreiben",
+			Description:     "Wirksame Gehaltsänderungen übernehmen und die Personalkosten des Vormonats speichern",
+			DefaultSchedule: "15 4 * * *",
+			Run:             w.snapshotLaborCosts,
+		},
+		{
+			Name:            "cleanup",
+			Label:           "Aufräumen",
+			Description:     "Gesendete E-Mails und Job-Läufe nach Ablauf der Aufbewahrungsfrist löschen",
+			DefaultSchedule: "30 3 * * *",
+			Run:             w.cleanup,
+		},
+	}
 
-	// Timebutler-Synchronisierung
-	timebutlerService := service.NewTimebutlerService()
-	if connected := timebutlerService.IsConnected(); connected {
-		log.Println("Synchronizing Timebutler data...")
+	// Je registriertem Integrationsanbieter ein Synchronisierungsjob
+	for _, provider := range service.Integrations() {
+		info := provider.Info()
+		jobs = append(jobs, service.JobDefinition{
+			Name:            info.SyncJobName(),
+			Label:           info.Name + "-Synchronisierung",
+			Description:     fmt.Sprintf("%s aus %s übernehmen", info.Description, info.Name),
+			DefaultSchedule: info.DefaultSchedule,
+			Run:             w.syncIntegration(provider),
+		})
+	}
 
-		// Benutzer synchronisieren
-		if count, err := timebutlerService.SyncTimebutlerUsers(); err != nil {
-			log.Printf("Error synchronizing Timebutler users: %v", err)
-		} else {
-			log.Printf("Synchronized %d Timebutler users", count)
+	for _, job := range jobs {
+		if err := service.RegisterJob(job); err != nil {
+			log.Printf("Error registering background job: %v", err)
 		}
+	}
+}
 
-		// Aktuelle Urlaubsansprüche synchronisieren
-		currentYear := time.Now().Format("2006")
-		if count, err := timebutlerService.SyncHolidayEntitlements(currentYear); err != nil {
-			log.Printf("Error synchronizing Timebutler holiday entitlements: %v", err)
-		} else {
-			log.Printf("Synchronized %d Timebutler holiday entitlements", count)
+// syncIntegration gibt einen Job zurück, der alle Bereiche eines Anbieters nacheinander
+// synchronisiert; ein fehlgeschlagener Bereich hält die übrigen nicht auf. Der Lauf wird als
+// Synchronisierung gespeichert und startet nicht, solange eine andere für den Anbieter läuft.
+func (w *Worker) syncIntegration(provider service.IntegrationProvider) func() (string, error) {
+	return func() (string, error) {
+		info := provider.Info()
+		if !provider.IsConnected() {
+			return info.Name + " ist nicht verbunden", nil
 		}
-
-		// Abwesenheiten synchronisieren
-		if count, err := timebutlerService.SyncTimebutlerAbsences(currentYear); err != nil {
-			log.Printf("Error synchronizing Timebutler absences: %v", err)
-		} else {
-			log.Printf("Synchronized %d Timebutler absences", count)
+		if !provider.AutoSyncEnabled() {
+			return "Auto-Sync ist deaktiviert", nil
 		}
-	}
 
-	// 123erfasst-Synchronisierung
-	erfasst123Service := service.NewErfasst123Service()
-	if connected := erfasst123Service.IsConnected(); connected {
-		// Nur synchronisieren, wenn Auto-Sync aktiviert ist
-		autoSync, err := erfasst123Service.IsAutoSyncEnabled()
-		if err != nil || !autoSync {
-			if err != nil {
-				log.Printf("Error checking 123erfasst auto-sync setting: %v", err)
-			}
-			return
+		job, err := service.NewSyncJobService().RunScheduled(info.Type)
+		if errors.Is(err, service.ErrSyncAlreadyRunning) {
+			return "Übersprungen, es läuft bereits eine Synchronisierung", nil
 		}
-
-		log.Println("Synchronizing 123erfasst data...")
-
-		// Startdatum für die Synchronisierung abrufen
-		syncStartDate, err := erfasst123Service.GetSyncStartDate()
 		if err != nil {
-			log.Printf("Error getting 123erfasst sync start date: %v", err)
-			return
+			err = w.syncFailure(info.Name, model.SyncAll.GetLabel(), err)
 		}
+		if job == nil {
+			return "", err
+		}
+		return job.Summary, err
+	}
+}
 
-		// Aktuelles Datum für das Ende der Synchronisierung
-		now := time.Now()
-		endDate := now.Format("2006-01-02")
+// syncFailure meldet einen Synchronisationsfehler an Slack/Teams und als Benachrichtigung
+// an Admins und gibt ihn mit dem betroffenen Schritt für die Laufhistorie zurück
+func (w *Worker) syncFailure(integration, step string, err error) error {
+	service.NewChatNotificationService().NotifySyncFailure(integration, step, err)
+	service.NewNotificationService().NotifySyncFailure(integration, step, err)
+	return fmt.Errorf("%s: %w", step, err)
+}
 
-		// Mitarbeiter synchronisieren
-		if count, err := erfasst123Service.SyncErfasst123Employees(); err != nil {
-			log.Printf("Error synchronizing 123erfasst employees: %v", err)
-		} else {
-			log.Printf("Synchronized %d 123erfasst employees", count)
-		}
+// recalculateOvertime berechnet die Überstunden aller Mitarbeiter neu
+func (w *Worker) recalculateOvertime() (string, error) {
+	if err := service.NewTimeAccountService().RecalculateAllEmployeeOvertimes(); err != nil {
+		return "", err
+	}
+	return "Überstunden neu berechnet", nil
+}
 
-		// Projekte synchronisieren
-		if count, err := erfasst123Service.SyncErfasst123Projects(syncStartDate, endDate); err != nil {
-			log.Printf("Error synchronizing 123erfasst projects: %v", err)
-		} else {
-			log.Printf("Synchronized %d 123erfasst projects", count)
-		}
+// sendReportSubscriptions versendet fällige Berichte aus den Abonnements der Benutzer
+func (w *Worker) sendReportSubscriptions() (string, error) {
+	sent, failed, err := service.NewReportService().SendDue(time.Now())
+	if err != nil {
+		return "", err
+	}
+	summary := fmt.Sprintf("%d Berichte versendet", sent)
+	if failed > 0 {
+		return summary, fmt.Errorf("%d Berichte konnten nicht erstellt werden", failed)
+	}
+	return summary, nil
+}
 
-		// Zeiteinträge synchronisieren
-		if count, err := erfasst123Service.SyncErfasst123TimeEntries(syncStartDate, endDate); err != nil {
-			log.Printf("Error synchronizing 123erfasst time entries: %v", err)
-		} else {
-			log.Printf("Synchronized %d 123erfasst time entries", count)
-		}
+// sendAbsenceDigest sendet die Abwesenheitsübersicht des Tages an Slack/Teams
+func (w *Worker) sendAbsenceDigest() (string, error) {
+	if err := service.NewChatNotificationService().SendAbsenceDigest(time.Now()); err != nil {
+		return "", err
 	}
+	return "Abwesenheitsübersicht gesendet", nil
+}
 
-	log.Println("Background synchronization tasks completed")
+// sendConversationsDue sendet die Mitarbeitergespräche der laufenden Woche an Slack/Teams
+func (w *Worker) sendConversationsDue() (string, error) {
+	now := time.Now()
+	weekStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if err := service.NewChatNotificationService().SendConversationsDue(weekStart); err != nil {
+		return "", err
+	}
+	return "Gespräche der Woche gesendet", nil
 }
 
-// checkWeeklyEmailReports prüft, ob wöchentliche E-Mail-Berichte gesendet werden sollen
-func (w *Worker) checkWeeklyEmailReports() {
+// checkDailyNotifications erzeugt Erinnerungen an anstehende Mitarbeitergespräche
+// und ablaufende Dokumente
+func (w *Worker) checkDailyNotifications() (string, error) {
 	now := time.Now()
-	
-	// Nur Freitags um 17:00 Uhr
-	if now.Weekday() != time.Friday || now.Hour() != 17 {
-		return
+	notificationService := service.NewNotificationService()
+
+	var errs []error
+	conversations, err := notificationService.CheckUpcomingConversations(now)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("conversation reminders: %w", err))
 	}
-	
-	// Prüfen, ob bereits heute ein Bericht gesendet wurde
-	if w.lastEmailReport.Year() == now.Year() && 
-	   w.lastEmailReport.YearDay() == now.YearDay() {
-		return
+	documents, err := notificationService.CheckExpiringDocuments(now)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("document expiry notifications: %w", err))
 	}
-	
-	log.Println("Sending weekly email reports...")
-	
-	// E-Mail-Service erstellen
-	emailService := service.NewEmailService()
-	if !emailService.IsEmailConfigured() {
-		log.Println("Email service not configured, skipping weekly reports")
-		return
+
+	return fmt.Sprintf("%d Gesprächserinnerungen, %d Dokument-Hinweise erstellt", conversations, documents), errors.Join(errs...)
+}
+
+// snapshotLaborCosts übernimmt wirksam gewordene Gehaltsänderungen und speichert die
+// Personalkosten des Vormonats, falls sie noch nicht festgeschrieben sind
+func (w *Worker) snapshotLaborCosts() (string, error) {
+	now := time.Now()
+	costService := service.NewCostService()
+
+	updated, err := costService.SyncCurrentSalaries(now)
+	if err != nil {
+		return "", fmt.Errorf("salary sync: %w", err)
 	}
-	
-	// Mitarbeiter-Repository
-	employeeRepo := repository.NewEmployeeRepository()
-	employees, _, err := employeeRepo.FindAll(0, 1000, "lastName", 1) // Get up to 1000 employees
+	snapshot, err := costService.SnapshotPreviousMonth(now)
 	if err != nil {
-		log.Printf("Error getting employees for weekly reports: %v", err)
-		return
+		return "", fmt.Errorf("labor cost snapshot: %w", err)
 	}
-	
-	// Aktivitäts-Repository
-	activityRepo := repository.NewActivityRepository()
-	
-	// Wochenstart und -ende berechnen (Montag bis Sonntag)
-	weekStart := now.AddDate(0, 0, -int(now.Weekday())+1) // Letzter Montag
-	weekStart = time.Date(weekStart.Year(), weekStart.Month(), weekStart.Day(), 0, 0, 0, 0, weekStart.Location())
-	weekEnd := weekStart.AddDate(0, 0, 6) // Sonntag
-	weekEnd = time.Date(weekEnd.Year(), weekEnd.Month(), weekEnd.Day(), 23, 59, 59, 0, weekEnd.Location())
-	
-	sentCount := 0
-	errorCount := 0
-	
-	// Für jeden Mitarbeiter einen Bericht senden
-	for _, employee := range employees {
-		if employee.Email == "" {
-			log.Printf("Skipping employee %s %s - no email address", employee.FirstName, employee.LastName)
-			continue
-		}
-		
-		// Aktivitäten der Woche für diesen Mitarbeiter abrufen
-		activities, err := activityRepo.GetActivitiesForEmployeeInDateRange(employee.ID, weekStart, weekEnd)
-		if err != nil {
-			log.Printf("Error getting activities for employee %s %s: %v", employee.FirstName, employee.LastName, err)
-			errorCount++
-			continue
-		}
-		
-		// Gesamtarbeitszeit berechnen (vereinfacht - basierend auf Aktivitäten)
-		totalHours := w.calculateTotalHours(activities)
-		
-		// E-Mail senden
-		err = emailService.SendWeeklyReport(employee, weekStart, weekEnd, totalHours, activities)
-		if err != nil {
-			log.Printf("Error sending weekly report to %s %s (%s): %v", 
-				employee.FirstName, employee.LastName, employee.Email, err)
-			errorCount++
-		} else {
-			log.Printf("Weekly report sent to %s %s (%s)", 
-				employee.FirstName, employee.LastName, employee.Email)
-			sentCount++
-		}
+	if snapshot == nil {
+		return fmt.Sprintf("%d Gehälter aktualisiert", updated), nil
 	}
-	
-	// Letzten E-Mail-Bericht-Zeitpunkt aktualisieren
-	w.lastEmailReport = now
-	
-	log.Printf("Weekly email reports completed: %d sent, %d errors", sentCount, errorCount)
+	return fmt.Sprintf("%d Gehälter aktualisiert, Personalkosten %s gespeichert", updated, snapshot.Month), nil
+}
+
+// processEmailOutbox sendet wartende E-Mails und fällige Wiederholungen aus dem Postausgang
+func (w *Worker) processEmailOutbox() (string, error) {
+	count, err := service.NewEmailService().ProcessOutbox()
+	return fmt.Sprintf("%d E-Mails verarbeitet", count), err
 }
 
-// calculateTotalHours berechnet die Gesamtarbeitszeit basierend auf Aktivitäten
-func (w *Worker) calculateTotalHours(activities []model.Activity) float64 {
-	// Vereinfachte Berechnung - zählt Arbeitstage * 8 Stunden
-	// In einer realen Implementierung würde man echte Zeiterfassungsdaten verwenden
-	workDays := 0
-	
-	// Eindeutige Arbeitstage zählen
-	workDayMap := make(map[string]bool)
-	for _, activity := range activities {
-		// Verwende alle Aktivitäten als Arbeitsnachweis (da ActivityTypeTimeEntry nicht existiert)
-		dateKey := activity.Timestamp.Format("2006-01-02")
-		workDayMap[dateKey] = true
+// retryWebhookDeliveries stellt fällige Webhook-Zustellungen erneut zu
+func (w *Worker) retryWebhookDeliveries() (string, error) {
+	count, err := service.NewWebhookService().ProcessDueDeliveries()
+	return fmt.Sprintf("%d Zustellungen wiederholt", count), err
+}
+
+// cleanup löscht gesendete E-Mails und alte Job-Läufe nach Ablauf der Aufbewahrungsfrist
+func (w *Worker) cleanup() (string, error) {
+	var errs []error
+	emails, err := service.NewEmailService().CleanupOutbox()
+	if err != nil {
+		errs = append(errs, fmt.Errorf("email outbox: %w", err))
 	}
-	
-	workDays = len(workDayMap)
-	
-	// Wenn keine Zeiteinträge vorhanden sind, Standard-Arbeitswoche annehmen (5 Tage)
-	if workDays == 0 {
-		workDays = 5
+	runs, err := w.jobService.CleanupRuns()
+	if err != nil {
+		errs = append(errs, fmt.Errorf("job runs: %w", err))
 	}
-	
-	return float64(workDays) * 8.0 // 8 Stunden pro Arbeitstag
+	syncJobs, err := service.NewSyncJobService().Cleanup()
+	if err != nil {
+		errs = append(errs, fmt.Errorf("sync jobs: %w", err))
+	}
+
+	return fmt.Sprintf("%d E-Mails, %d Job-Läufe und %d Synchronisierungen gelöscht", emails, runs, syncJobs), errors.Join(errs...)
 }
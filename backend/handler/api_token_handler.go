@@ -0,0 +1,254 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+	"PeopleFlow/backend/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APITokenHandler verwaltet persönliche und Service-Account-Tokens
+type APITokenHandler struct {
+	tokenService *service.APITokenService
+}
+
+// NewAPITokenHandler erstellt einen neuen APITokenHandler
+func NewAPITokenHandler() *APITokenHandler {
+	return &APITokenHandler{
+		tokenService: service.NewAPITokenService(),
+	}
+}
+
+// ListMyTokens gibt die persönlichen Tokens des angemeldeten Benutzers zurück
+func (h *APITokenHandler) ListMyTokens(c *gin.Context) {
+	user, ok := sessionUser(c)
+	if !ok {
+		return
+	}
+
+	tokens, err := h.tokenService.ListForUser(user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Fehler beim Abrufen der Tokens: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    tokenResponses(tokens),
+	})
+}
+
+// CreateMyToken erstellt ein persönliches Token für den angemeldeten Benutzer
+func (h *APITokenHandler) CreateMyToken(c *gin.Context) {
+	user, ok := sessionUser(c)
+	if !ok {
+		return
+	}
+
+	validityDays, err := parseValidityDays(c.PostForm("expiresInDays"))
+	if err != nil {
+		tokenErrors.respond(c, err)
+		return
+	}
+
+	plainToken, token, err := h.tokenService.CreatePersonalToken(user, c.PostForm("name"), c.PostFormArray("scopes"), validityDays)
+	if err != nil {
+		tokenErrors.respond(c, err)
+		return
+	}
+
+	logTokenActivity(user, token, "API-Token \""+token.Name+"\" erstellt")
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Token erstellt. Bitte kopieren Sie es jetzt, es wird nicht erneut angezeigt.",
+		"data": gin.H{
+			"token":   plainToken,
+			"details": tokenResponse(token),
+		},
+	})
+}
+
+// RevokeMyToken widerruft ein persönliches Token des angemeldeten Benutzers
+func (h *APITokenHandler) RevokeMyToken(c *gin.Context) {
+	user, ok := sessionUser(c)
+	if !ok {
+		return
+	}
+
+	token, err := h.tokenService.RevokeOwn(user, c.Param("id"))
+	if err != nil {
+		tokenErrors.respond(c, err)
+		return
+	}
+
+	logTokenActivity(user, token, "API-Token \""+token.Name+"\" widerrufen")
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Token widerrufen",
+	})
+}
+
+// ListAllTokens gibt alle Tokens zurück (nur für Admins)
+func (h *APITokenHandler) ListAllTokens(c *gin.Context) {
+	if _, ok := sessionUser(c); !ok {
+		return
+	}
+
+	tokens, err := h.tokenService.ListAll()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Fehler beim Abrufen der Tokens: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    tokenResponses(tokens),
+	})
+}
+
+// CreateServiceToken erstellt ein Service-Account-Token (nur für Admins)
+func (h *APITokenHandler) CreateServiceToken(c *gin.Context) {
+	admin, ok := sessionUser(c)
+	if !ok {
+		return
+	}
+
+	validityDays, err := parseValidityDays(c.PostForm("expiresInDays"))
+	if err != nil {
+		tokenErrors.respond(c, err)
+		return
+	}
+
+	plainToken, token, err := h.tokenService.CreateServiceToken(admin, c.PostForm("name"), model.UserRole(c.PostForm("role")), c.PostFormArray("scopes"), validityDays)
+	if err != nil {
+		tokenErrors.respond(c, err)
+		return
+	}
+
+	logTokenActivity(admin, token, "Service-Account-Token \""+token.Name+"\" erstellt")
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Token erstellt. Bitte kopieren Sie es jetzt, es wird nicht erneut angezeigt.",
+		"data": gin.H{
+			"token":   plainToken,
+			"details": tokenResponse(token),
+		},
+	})
+}
+
+// RevokeToken widerruft ein beliebiges Token (nur für Admins)
+func (h *APITokenHandler) RevokeToken(c *gin.Context) {
+	admin, ok := sessionUser(c)
+	if !ok {
+		return
+	}
+
+	token, err := h.tokenService.Revoke(admin, c.Param("id"))
+	if err != nil {
+		tokenErrors.respond(c, err)
+		return
+	}
+
+	logTokenActivity(admin, token, "API-Token \""+token.Name+"\" durch Administrator widerrufen")
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Token widerrufen",
+	})
+}
+
+// sessionUser gibt den angemeldeten Benutzer zurück. Tokens dürfen nur mit einer
+// Browser-Sitzung verwaltet werden, damit ein kompromittiertes Token sich nicht selbst vermehren kann.
+func sessionUser(c *gin.Context) (*model.User, bool) {
+	if _, viaToken := c.Get("apiToken"); viaToken {
+		c.JSON(http.StatusForbidden, gin.H{
+			"success": false,
+			"error":   "API-Tokens können nur über die Weboberfläche verwaltet werden",
+		})
+		return nil, false
+	}
+
+	user, _ := c.Get("user")
+	return user.(*model.User), true
+}
+
+// parseValidityDays liest die Gültigkeitsdauer in Tagen (leer = Standard)
+func parseValidityDays(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 1 {
+		return 0, service.ErrAPITokenValidity
+	}
+	return days, nil
+}
+
+// tokenErrors übersetzt Token-Fehler in JSON-Antworten
+var tokenErrors = errorResponse{
+	fallback: "Fehler bei der Token-Verwaltung",
+	mappings: []errorMapping{
+		{errs: []error{repository.ErrAPITokenNotFound, repository.ErrInvalidID}, status: http.StatusNotFound, message: "Token nicht gefunden"},
+		{errs: []error{service.ErrAPITokenForbidden}, status: http.StatusForbidden, message: "Sie dürfen dieses Token nicht verwalten"},
+		{errs: []error{model.ErrTokenNameRequired}, status: http.StatusBadRequest, message: "Bitte geben Sie einen Namen für das Token ein"},
+		{errs: []error{model.ErrInvalidTokenScope}, status: http.StatusBadRequest, message: "Ungültige Berechtigungen: Wählen Sie mindestens einen Scope (read, write, admin). Der admin-Scope ist nur für Administratoren verfügbar."},
+		{errs: []error{model.ErrInvalidTokenType, model.ErrInvalidRole}, status: http.StatusBadRequest, message: "Ungültige Rolle für das Service-Account-Token"},
+		{errs: []error{service.ErrAPITokenValidity}, status: http.StatusBadRequest, message: "Die Gültigkeit muss zwischen 1 und " + strconv.Itoa(model.MaxAPITokenValidityDays) + " Tagen liegen"},
+	},
+}
+
+// logTokenActivity protokolliert Änderungen an API-Tokens
+func logTokenActivity(user *model.User, token *model.APIToken, description string) {
+	activityRepo := repository.NewActivityRepository()
+	_, _ = activityRepo.LogActivity(
+		model.ActivityTypeUserUpdated,
+		user.ID,
+		user.FirstName+" "+user.LastName,
+		token.ID,
+		"api_token",
+		token.Name,
+		description,
+	)
+}
+
+// tokenResponse bereitet ein Token für die Anzeige auf
+func tokenResponse(token *model.APIToken) gin.H {
+	return gin.H{
+		"id":         token.ID.Hex(),
+		"name":       token.Name,
+		"type":       token.Type,
+		"prefix":     token.Prefix,
+		"userName":   token.UserName,
+		"role":       token.Role,
+		"scopes":     token.Scopes,
+		"expiresAt":  token.ExpiresAt,
+		"lastUsedAt": token.LastUsedAt,
+		"lastUsedIp": token.LastUsedIP,
+		"revokedAt":  token.RevokedAt,
+		"revokedBy":  token.RevokedBy,
+		"createdAt":  token.CreatedAt,
+		"status":     token.GetStatus(),
+	}
+}
+
+// tokenResponses bereitet eine Liste von Tokens für die Anzeige auf
+func tokenResponses(tokens []*model.APIToken) []gin.H {
+	result := make([]gin.H, 0, len(tokens))
+	for _, token := range tokens {
+		result = append(result, tokenResponse(token))
+	}
+	return result
+}
@@ -0,0 +1,355 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+	"PeopleFlow/backend/service"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Fehlercodes der REST-API v1
+const (
+	APIErrorBadRequest   = "bad_request"
+	APIErrorValidation   = "validation_failed"
+	APIErrorUnauthorized = "unauthorized"
+	APIErrorForbidden    = "forbidden"
+	APIErrorNotFound     = "not_found"
+	APIErrorConflict     = "conflict"
+	APIErrorInternal     = "internal_error"
+)
+
+// Pagination-Grenzen der REST-API v1
+const (
+	APIDefaultPageSize = 25
+	APIMaxPageSize     = 200
+)
+
+// APIV1Handler stellt die versionierte REST-API unter /api/v1 bereit
+type APIV1Handler struct {
+	employeeRepo   *repository.EmployeeRepository
+	userRepo       *repository.UserRepository
+	adjustmentRepo *repository.OvertimeAdjustmentRepository
+	settingsRepo   *repository.SystemSettingsRepository
+	fileService    *service.FileService
+}
+
+// NewAPIV1Handler erstellt einen neuen APIV1Handler
+func NewAPIV1Handler() *APIV1Handler {
+	return &APIV1Handler{
+		employeeRepo:   repository.NewEmployeeRepository(),
+		userRepo:       repository.NewUserRepository(),
+		adjustmentRepo: repository.NewOvertimeAdjustmentRepository(),
+		settingsRepo:   repository.NewSystemSettingsRepository(),
+		fileService:    service.NewFileService(),
+	}
+}
+
+// errAPIForbidden signalisiert fehlende Berechtigungen innerhalb der API-Handler
+var errAPIForbidden = errors.New("forbidden")
+
+// APIError ist der einheitliche Fehlerinhalt der REST-API v1
+type APIError struct {
+	Code    string            `json:"code"`
+	Message string            `json:"message"`
+	Fields  map[string]string `json:"fields,omitempty"`
+}
+
+// APIMeta enthält die Pagination-Informationen einer Listenantwort
+type APIMeta struct {
+	Page       int    `json:"page"`
+	PageSize   int    `json:"pageSize"`
+	Total      int64  `json:"total"`
+	TotalPages int    `json:"totalPages"`
+	Sort       string `json:"sort,omitempty"`
+}
+
+// apiPage beschreibt die angeforderte Seite einer Liste
+type apiPage struct {
+	Page     int
+	PageSize int
+}
+
+// Skip gibt die Anzahl zu überspringender Einträge zurück
+func (p apiPage) Skip() int64 {
+	return int64((p.Page - 1) * p.PageSize)
+}
+
+// Bounds gibt Start- und Endindex der Seite innerhalb einer In-Memory-Liste zurück
+func (p apiPage) Bounds(length int) (int, int) {
+	start := int(p.Skip())
+	if start > length {
+		start = length
+	}
+	end := start + p.PageSize
+	if end > length {
+		end = length
+	}
+	return start, end
+}
+
+// apiSort beschreibt die angeforderte Sortierung
+type apiSort struct {
+	Key   string // Name des Feldes in der API
+	Field string // Name des Feldes in der Datenbank
+	Order int    // 1 = aufsteigend, -1 = absteigend
+}
+
+// String gibt die Sortierung im Format der Query zurück (z.B. "-hireDate")
+func (s apiSort) String() string {
+	if s.Order < 0 {
+		return "-" + s.Key
+	}
+	return s.Key
+}
+
+// parseAPIPage liest page und pageSize aus der Query
+func parseAPIPage(c *gin.Context) (apiPage, error) {
+	page := apiPage{Page: 1, PageSize: APIDefaultPageSize}
+
+	if value := c.Query("page"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return page, fmt.Errorf("page muss eine positive Zahl sein")
+		}
+		page.Page = n
+	}
+	if value := c.Query("pageSize"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > APIMaxPageSize {
+			return page, fmt.Errorf("pageSize muss zwischen 1 und %d liegen", APIMaxPageSize)
+		}
+		page.PageSize = n
+	}
+	return page, nil
+}
+
+// parseAPISort liest den sort-Parameter ("feld" oder "-feld") anhand der erlaubten Felder
+func parseAPISort(c *gin.Context, allowed map[string]string, defaultSort string) (apiSort, error) {
+	value := c.DefaultQuery("sort", defaultSort)
+	order := 1
+	if strings.HasPrefix(value, "-") {
+		order = -1
+		value = strings.TrimPrefix(value, "-")
+	}
+
+	field, ok := allowed[value]
+	if !ok {
+		keys := make([]string, 0, len(allowed))
+		for key := range allowed {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		return apiSort{}, fmt.Errorf("sort unterstützt nur: %s", strings.Join(keys, ", "))
+	}
+	return apiSort{Key: value, Field: field, Order: order}, nil
+}
+
+// parseAPIDate akzeptiert Datumsangaben als YYYY-MM-DD oder RFC 3339
+func parseAPIDate(value string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// parseAPIDateRange liest die optionalen Query-Parameter from und to
+func parseAPIDateRange(c *gin.Context) (from, to time.Time, err error) {
+	if value := c.Query("from"); value != "" {
+		if from, err = parseAPIDate(value); err != nil {
+			return from, to, fmt.Errorf("from ist kein gültiges Datum")
+		}
+	}
+	if value := c.Query("to"); value != "" {
+		if to, err = parseAPIDate(value); err != nil {
+			return from, to, fmt.Errorf("to ist kein gültiges Datum")
+		}
+		// Ein reines Datum schließt den ganzen Tag ein
+		if len(value) == len("2006-01-02") {
+			to = to.Add(24*time.Hour - time.Nanosecond)
+		}
+	}
+	return from, to, nil
+}
+
+// overlapsRange prüft, ob ein Zeitraum den Filterzeitraum schneidet (Nullwerte = offen)
+func overlapsRange(start, end, from, to time.Time) bool {
+	if end.IsZero() {
+		end = start
+	}
+	if !from.IsZero() && end.Before(from) {
+		return false
+	}
+	if !to.IsZero() && start.After(to) {
+		return false
+	}
+	return true
+}
+
+// respondAPI sendet eine erfolgreiche Antwort im einheitlichen Format
+func respondAPI(c *gin.Context, status int, data interface{}) {
+	if status == http.StatusNoContent {
+		c.Status(status)
+		return
+	}
+	c.JSON(status, gin.H{
+		"success": true,
+		"data":    data,
+	})
+}
+
+// respondAPIList sendet eine Listenantwort mit Pagination-Metadaten
+func respondAPIList(c *gin.Context, data interface{}, page apiPage, total int64, sort apiSort) {
+	totalPages := 0
+	if total > 0 {
+		totalPages = int(math.Ceil(float64(total) / float64(page.PageSize)))
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    data,
+		"meta": APIMeta{
+			Page:       page.Page,
+			PageSize:   page.PageSize,
+			Total:      total,
+			TotalPages: totalPages,
+			Sort:       sort.String(),
+		},
+	})
+}
+
+// respondAPIError sendet eine Fehlerantwort im einheitlichen Format
+func respondAPIError(c *gin.Context, status int, code, message string) {
+	c.AbortWithStatusJSON(status, gin.H{
+		"success": false,
+		"error": APIError{
+			Code:    code,
+			Message: message,
+		},
+	})
+}
+
+// respondAPIValidation sendet einen Validierungsfehler mit Angaben zu einzelnen Feldern
+func respondAPIValidation(c *gin.Context, message string, fields map[string]string) {
+	c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{
+		"success": false,
+		"error": APIError{
+			Code:    APIErrorValidation,
+			Message: message,
+			Fields:  fields,
+		},
+	})
+}
+
+// respondAPIRepositoryError übersetzt Repository-Fehler in HTTP-Statuscodes
+func respondAPIRepositoryError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, errAPIForbidden):
+		respondAPIError(c, http.StatusForbidden, APIErrorForbidden, "Keine Berechtigung für diese Ressource")
+	case errors.Is(err, repository.ErrInvalidID):
+		respondAPIError(c, http.StatusBadRequest, APIErrorBadRequest, "Ungültige ID")
+	case errors.Is(err, repository.ErrNotFound),
+		errors.Is(err, repository.ErrEmployeeNotFound),
+		errors.Is(err, repository.ErrUserNotFound),
+		errors.Is(err, repository.ErrAdjustmentNotFound):
+		respondAPIError(c, http.StatusNotFound, APIErrorNotFound, "Ressource nicht gefunden")
+	case errors.Is(err, repository.ErrEmployeeIDTaken),
+		errors.Is(err, repository.ErrEmailTaken),
+		errors.Is(err, repository.ErrDuplicateKey),
+		errors.Is(err, repository.ErrDuplicateEntry),
+		errors.Is(err, repository.ErrAlreadyProcessed):
+		respondAPIError(c, http.StatusConflict, APIErrorConflict, err.Error())
+	case errors.Is(err, repository.ErrValidation),
+		errors.Is(err, repository.ErrInvalidEmployeeData),
+		errors.Is(err, repository.ErrInvalidVacationDays),
+		errors.Is(err, repository.ErrInvalidOvertimeData),
+		errors.Is(err, repository.ErrInvalidEmail),
+		errors.Is(err, repository.ErrInvalidPassword),
+		errors.Is(err, repository.ErrInvalidAdjustmentType),
+		errors.Is(err, repository.ErrInvalidAdjustmentData),
+		errors.Is(err, repository.ErrInvalidStatus),
+		errors.Is(err, repository.ErrInvalidSystemSettings),
+		errors.Is(err, repository.ErrInvalidState),
+		errors.Is(err, repository.ErrInvalidNotificationSettings),
+		errors.Is(err, model.ErrInvalidRole),
+		errors.Is(err, model.ErrInvalidStatus):
+		respondAPIValidation(c, err.Error(), nil)
+	default:
+		respondAPIError(c, http.StatusInternalServerError, APIErrorInternal, "Interner Serverfehler")
+	}
+}
+
+// bindAPIJSON liest den JSON-Body einer Anfrage
+func bindAPIJSON(c *gin.Context, target interface{}) bool {
+	if err := c.ShouldBindJSON(target); err != nil {
+		respondAPIError(c, http.StatusBadRequest, APIErrorBadRequest, "Ungültiger JSON-Body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// RequireRoles beschränkt eine Route der REST-API v1 auf die angegebenen Rollen
+func (h *APIV1Handler) RequireRoles(roles ...model.UserRole) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !apiCurrentUser(c).HasRole(roles...) {
+			respondAPIError(c, http.StatusForbidden, APIErrorForbidden, "Keine Berechtigung für diese Ressource")
+			return
+		}
+		c.Next()
+	}
+}
+
+// NotFound beantwortet unbekannte Pfade der REST-API v1
+func (h *APIV1Handler) NotFound(c *gin.Context) {
+	respondAPIError(c, http.StatusNotFound, APIErrorNotFound, "Unbekannter API-Endpunkt: "+c.Request.Method+" "+c.Request.URL.Path)
+}
+
+// apiCurrentUser gibt den authentifizierten Benutzer zurück
+func apiCurrentUser(c *gin.Context) *model.User {
+	user, _ := c.Get("user")
+	return user.(*model.User)
+}
+
+// apiIsStaff prüft, ob der Benutzer Personaldaten anderer Mitarbeiter bearbeiten darf
+func apiIsStaff(user *model.User) bool {
+	return user.HasRole(model.RoleAdmin, model.RoleManager, model.RoleHR)
+}
+
+// apiCanViewSalary prüft, ob der Benutzer Gehaltsdaten sehen darf (wie SalaryViewMiddleware)
+func apiCanViewSalary(user *model.User) bool {
+	return user.HasRole(model.RoleAdmin, model.RoleManager)
+}
+
+// apiCanAccessEmployee prüft, ob der Benutzer auf die Daten eines Mitarbeiters zugreifen darf
+func apiCanAccessEmployee(user *model.User, employeeID primitive.ObjectID) bool {
+	if apiIsStaff(user) {
+		return true
+	}
+	return user.EmployeeID != nil && *user.EmployeeID == employeeID
+}
+
+// parseObjectIDs wandelt eine kommagetrennte Liste von IDs um
+func parseObjectIDs(value string) ([]primitive.ObjectID, error) {
+	var ids []primitive.ObjectID
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := primitive.ObjectIDFromHex(part)
+		if err != nil {
+			return nil, fmt.Errorf("ungültige ID: %s", part)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
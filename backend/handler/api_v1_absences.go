@@ -0,0 +1,355 @@
+package handler
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"PeopleFlow/backend/model"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// absenceSortFields sind die sortierbaren Felder der Abwesenheitsliste
+var absenceSortFields = map[string]string{
+	"startDate":    "startDate",
+	"endDate":      "endDate",
+	"days":         "days",
+	"type":         "type",
+	"status":       "status",
+	"employeeName": "employeeName",
+}
+
+// APIAbsence ist die Darstellung einer Abwesenheit in der REST-API v1
+type APIAbsence struct {
+	ID           string        `json:"id"`
+	EmployeeID   string        `json:"employeeId"`
+	EmployeeName string        `json:"employeeName"`
+	Type         string        `json:"type"`
+	StartDate    time.Time     `json:"startDate"`
+	EndDate      time.Time     `json:"endDate"`
+	Days         float64       `json:"days"`
+	Status       string        `json:"status"`
+	ApproverName string        `json:"approverName,omitempty"`
+	Reason       string        `json:"reason"`
+	Notes        string        `json:"notes"`
+	Documents    []APIDocument `json:"documents"`
+}
+
+// APIAbsenceInput beschreibt eine neue Abwesenheit
+type APIAbsenceInput struct {
+	Type      string `json:"type"`
+	StartDate string `json:"startDate"`
+	EndDate   string `json:"endDate"`
+	Reason    string `json:"reason"`
+	Notes     string `json:"notes"`
+}
+
+// APIAbsenceStatusInput beschreibt eine Statusänderung einer Abwesenheit
+type APIAbsenceStatusInput struct {
+	Status string `json:"status"` // approved, rejected, cancelled
+}
+
+// newAPIAbsence wandelt eine Abwesenheit in die API-Darstellung um
+func newAPIAbsence(employee *model.Employee, absence model.Absence) APIAbsence {
+	return APIAbsence{
+		ID:           absence.ID.Hex(),
+		EmployeeID:   employee.ID.Hex(),
+		EmployeeName: employee.FirstName + " " + employee.LastName,
+		Type:         absence.Type,
+		StartDate:    absence.StartDate,
+		EndDate:      absence.EndDate,
+		Days:         absence.Days,
+		Status:       absence.Status,
+		ApproverName: absence.ApproverName,
+		Reason:       absence.Reason,
+		Notes:        absence.Notes,
+		Documents:    newAPIDocuments(employee, absence.Documents),
+	}
+}
+
+// ListAbsences gibt die Abwesenheiten aller zugänglichen Mitarbeiter zurück.
+// Query: page, pageSize, sort, employeeId, department, type, status, from, to
+func (h *APIV1Handler) ListAbsences(c *gin.Context) {
+	employees, ok := h.listAccessibleEmployees(c)
+	if !ok {
+		return
+	}
+	h.respondAbsences(c, employees)
+}
+
+// ListEmployeeAbsences gibt die Abwesenheiten eines Mitarbeiters zurück
+func (h *APIV1Handler) ListEmployeeAbsences(c *gin.Context) {
+	employee, ok := h.loadEmployee(c, false)
+	if !ok {
+		return
+	}
+	h.respondAbsences(c, []*model.Employee{employee})
+}
+
+// respondAbsences filtert, sortiert und paginiert die Abwesenheiten der Mitarbeiter
+func (h *APIV1Handler) respondAbsences(c *gin.Context, employees []*model.Employee) {
+	page, err := parseAPIPage(c)
+	if err != nil {
+		respondAPIError(c, http.StatusBadRequest, APIErrorBadRequest, err.Error())
+		return
+	}
+	sorting, err := parseAPISort(c, absenceSortFields, "-startDate")
+	if err != nil {
+		respondAPIError(c, http.StatusBadRequest, APIErrorBadRequest, err.Error())
+		return
+	}
+	from, to, err := parseAPIDateRange(c)
+	if err != nil {
+		respondAPIError(c, http.StatusBadRequest, APIErrorBadRequest, err.Error())
+		return
+	}
+	absenceType := c.Query("type")
+	status := c.Query("status")
+
+	absences := []APIAbsence{}
+	for _, employee := range employees {
+		for _, absence := range employee.Absences {
+			if absenceType != "" && absence.Type != absenceType {
+				continue
+			}
+			if status != "" && absence.Status != status {
+				continue
+			}
+			if !overlapsRange(absence.StartDate, absence.EndDate, from, to) {
+				continue
+			}
+			absences = append(absences, newAPIAbsence(employee, absence))
+		}
+	}
+
+	sort.SliceStable(absences, func(i, j int) bool {
+		a, b := absences[i], absences[j]
+		var less, equal bool
+		switch sorting.Field {
+		case "endDate":
+			less, equal = a.EndDate.Before(b.EndDate), a.EndDate.Equal(b.EndDate)
+		case "days":
+			less, equal = a.Days < b.Days, a.Days == b.Days
+		case "type":
+			less, equal = a.Type < b.Type, a.Type == b.Type
+		case "status":
+			less, equal = a.Status < b.Status, a.Status == b.Status
+		case "employeeName":
+			less, equal = a.EmployeeName < b.EmployeeName, a.EmployeeName == b.EmployeeName
+		default:
+			less, equal = a.StartDate.Before(b.StartDate), a.StartDate.Equal(b.StartDate)
+		}
+		if equal {
+			return false
+		}
+		return less == (sorting.Order > 0)
+	})
+
+	start, end := page.Bounds(len(absences))
+	respondAPIList(c, absences[start:end], page, int64(len(absences)), sorting)
+}
+
+// CreateAbsence legt eine Abwesenheit für einen Mitarbeiter an.
+// Mitarbeiter können für sich selbst Anträge stellen; Admins und HR genehmigen direkt.
+func (h *APIV1Handler) CreateAbsence(c *gin.Context) {
+	user := apiCurrentUser(c)
+
+	employee, ok := h.loadEmployee(c, false)
+	if !ok {
+		return
+	}
+
+	var input APIAbsenceInput
+	if !bindAPIJSON(c, &input) {
+		return
+	}
+
+	fields := map[string]string{}
+	switch input.Type {
+	case "vacation", "sick", "special":
+	default:
+		fields["type"] = "muss vacation, sick oder special sein"
+	}
+	startDate, err := parseAPIDate(input.StartDate)
+	if err != nil {
+		fields["startDate"] = "muss ein Datum im Format YYYY-MM-DD sein"
+	}
+	endDate := startDate
+	if input.EndDate != "" {
+		if endDate, err = parseAPIDate(input.EndDate); err != nil {
+			fields["endDate"] = "muss ein Datum im Format YYYY-MM-DD sein"
+		}
+	}
+	if _, invalid := fields["startDate"]; !invalid && endDate.Before(startDate) {
+		fields["endDate"] = "darf nicht vor startDate liegen"
+	}
+	if len(fields) > 0 {
+		respondAPIValidation(c, "Ungültige Abwesenheit", fields)
+		return
+	}
+
+	absence := model.Absence{
+		ID:        primitive.NewObjectID(),
+		Type:      input.Type,
+		StartDate: startDate,
+		EndDate:   endDate,
+		Days:      endDate.Sub(startDate).Hours()/24 + 1,
+		Status:    "requested",
+		Reason:    strings.TrimSpace(input.Reason),
+		Notes:     strings.TrimSpace(input.Notes),
+		Documents: []model.Document{},
+	}
+
+	// Wie in der Weboberfläche genehmigen Admins und HR direkt
+	if user.HasRole(model.RoleAdmin, model.RoleHR) {
+		approveAbsence(employee, &absence, user)
+	}
+
+	employee.Absences = append(employee.Absences, absence)
+	if err := h.employeeRepo.Update(employee); err != nil {
+		respondAPIRepositoryError(c, err)
+		return
+	}
+
+	activityType := model.ActivityTypeVacationRequested
+	if absence.Status == "approved" {
+		activityType = model.ActivityTypeVacationApproved
+	}
+	logAPIEmployeeActivity(user, employee, activityType, "Abwesenheit über die API angelegt: "+getAbsenceTypeDisplay(absence.Type))
+
+	respondAPI(c, http.StatusCreated, newAPIAbsence(employee, absence))
+}
+
+// UpdateAbsenceStatus genehmigt, lehnt ab oder storniert eine Abwesenheit.
+// Genehmigen und Ablehnen ist Admins und Managern vorbehalten, stornieren darf auch der Mitarbeiter selbst.
+func (h *APIV1Handler) UpdateAbsenceStatus(c *gin.Context) {
+	user := apiCurrentUser(c)
+
+	employee, ok := h.loadEmployee(c, false)
+	if !ok {
+		return
+	}
+	index, ok := findAbsenceIndex(c, employee)
+	if !ok {
+		return
+	}
+
+	var input APIAbsenceStatusInput
+	if !bindAPIJSON(c, &input) {
+		return
+	}
+
+	absence := &employee.Absences[index]
+	switch input.Status {
+	case "approved", "rejected":
+		if !user.HasRole(model.RoleAdmin, model.RoleManager) {
+			respondAPIError(c, http.StatusForbidden, APIErrorForbidden, "Nur Admins und Manager dürfen Abwesenheiten genehmigen oder ablehnen")
+			return
+		}
+		if absence.Status != "requested" {
+			respondAPIError(c, http.StatusConflict, APIErrorConflict, "Die Abwesenheit wurde bereits bearbeitet")
+			return
+		}
+		if input.Status == "approved" {
+			approveAbsence(employee, absence, user)
+		} else {
+			absence.Status = "rejected"
+			absence.ApprovedBy = user.ID
+			absence.ApproverName = user.FirstName + " " + user.LastName
+		}
+	case "cancelled":
+		if absence.Status == "cancelled" || absence.Status == "rejected" {
+			respondAPIError(c, http.StatusConflict, APIErrorConflict, "Die Abwesenheit kann nicht mehr storniert werden")
+			return
+		}
+		// Bereits abgezogene Urlaubstage zurückgeben
+		if absence.Type == "vacation" && absence.Status == "approved" {
+			employee.RemainingVacation += int(absence.Days)
+		}
+		absence.Status = "cancelled"
+	default:
+		respondAPIValidation(c, "Ungültiger Status", map[string]string{
+			"status": "muss approved, rejected oder cancelled sein",
+		})
+		return
+	}
+
+	if err := h.employeeRepo.Update(employee); err != nil {
+		respondAPIRepositoryError(c, err)
+		return
+	}
+
+	activityType := model.ActivityTypeEmployeeUpdated
+	switch absence.Status {
+	case "approved":
+		activityType = model.ActivityTypeVacationApproved
+	case "rejected":
+		activityType = model.ActivityTypeVacationRejected
+	}
+	logAPIEmployeeActivity(user, employee, activityType, "Abwesenheitsstatus über die API geändert: "+absence.Status)
+
+	respondAPI(c, http.StatusOK, newAPIAbsence(employee, *absence))
+}
+
+// DeleteAbsence löscht eine Abwesenheit samt Dokumenten
+func (h *APIV1Handler) DeleteAbsence(c *gin.Context) {
+	user := apiCurrentUser(c)
+
+	employee, ok := h.loadEmployee(c, true)
+	if !ok {
+		return
+	}
+	index, ok := findAbsenceIndex(c, employee)
+	if !ok {
+		return
+	}
+
+	absence := employee.Absences[index]
+	if absence.Type == "vacation" && absence.Status == "approved" {
+		employee.RemainingVacation += int(absence.Days)
+	}
+	employee.Absences = append(employee.Absences[:index], employee.Absences[index+1:]...)
+
+	if err := h.employeeRepo.Update(employee); err != nil {
+		respondAPIRepositoryError(c, err)
+		return
+	}
+	h.deleteDocumentFiles(absence.Documents)
+
+	logAPIEmployeeActivity(user, employee, model.ActivityTypeEmployeeUpdated, "Abwesenheit über die API gelöscht: "+getAbsenceTypeDisplay(absence.Type))
+
+	respondAPI(c, http.StatusNoContent, nil)
+}
+
+// approveAbsence genehmigt eine Abwesenheit und zieht bei Urlaub die Tage ab
+func approveAbsence(employee *model.Employee, absence *model.Absence, approver *model.User) {
+	absence.Status = "approved"
+	absence.ApprovedBy = approver.ID
+	absence.ApproverName = approver.FirstName + " " + approver.LastName
+
+	if absence.Type == "vacation" {
+		employee.RemainingVacation -= int(absence.Days)
+		if employee.RemainingVacation < 0 {
+			employee.RemainingVacation = 0
+		}
+	}
+}
+
+// findAbsenceIndex sucht die Abwesenheit aus dem Pfadparameter :absenceId
+func findAbsenceIndex(c *gin.Context, employee *model.Employee) (int, bool) {
+	absenceID, err := primitive.ObjectIDFromHex(c.Param("absenceId"))
+	if err != nil {
+		respondAPIError(c, http.StatusBadRequest, APIErrorBadRequest, "Ungültige Abwesenheits-ID")
+		return -1, false
+	}
+	for i := range employee.Absences {
+		if employee.Absences[i].ID == absenceID {
+			return i, true
+		}
+	}
+	respondAPIError(c, http.StatusNotFound, APIErrorNotFound, "Abwesenheit nicht gefunden")
+	return -1, false
+}
@@ -0,0 +1,589 @@
+package handler
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"PeopleFlow/backend/model"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// conversationSortFields sind die sortierbaren Felder der Gesprächsliste
+var conversationSortFields = map[string]string{
+	"date":         "date",
+	"title":        "title",
+	"status":       "status",
+	"employeeName": "employeeName",
+}
+
+// APITraining ist die Darstellung einer Weiterbildung in der REST-API v1
+type APITraining struct {
+	model.Training
+	Documents []APIDocument `json:"documents"`
+}
+
+// APIEvaluation ist die Darstellung einer Leistungsbeurteilung in der REST-API v1
+type APIEvaluation struct {
+	model.Evaluation
+	Documents []APIDocument `json:"documents"`
+}
+
+// APIConversation ist die Darstellung eines Mitarbeitergesprächs in der REST-API v1
+type APIConversation struct {
+	model.Conversation
+	EmployeeID   string `json:"employeeId"`
+	EmployeeName string `json:"employeeName"`
+}
+
+// APITrainingInput beschreibt die änderbaren Felder einer Weiterbildung
+type APITrainingInput struct {
+	Title       *string `json:"title"`
+	Description *string `json:"description"`
+	StartDate   *string `json:"startDate"`
+	EndDate     *string `json:"endDate"`
+	Provider    *string `json:"provider"`
+	Certificate *string `json:"certificate"`
+	Status      *string `json:"status"` // planned, ongoing, completed
+	Notes       *string `json:"notes"`
+}
+
+// APIEvaluationInput beschreibt die änderbaren Felder einer Leistungsbeurteilung
+type APIEvaluationInput struct {
+	Title            *string `json:"title"`
+	Date             *string `json:"date"`
+	OverallRating    *int    `json:"overallRating"` // 1-5
+	Strengths        *string `json:"strengths"`
+	AreasToImprove   *string `json:"areasToImprove"`
+	Comments         *string `json:"comments"`
+	EmployeeComments *string `json:"employeeComments"`
+}
+
+// APIConversationInput beschreibt die änderbaren Felder eines Mitarbeitergesprächs
+type APIConversationInput struct {
+	Title       *string `json:"title"`
+	Date        *string `json:"date"`
+	Description *string `json:"description"`
+	Status      *string `json:"status"` // planned, completed
+	Notes       *string `json:"notes"`
+}
+
+// applyAPIString übernimmt einen optionalen Text
+func applyAPIString(target *string, value *string) {
+	if value != nil {
+		*target = strings.TrimSpace(*value)
+	}
+}
+
+// applyAPIDate übernimmt ein optionales Datum und trägt Fehler in fields ein
+func applyAPIDate(fields map[string]string, name string, target *time.Time, value *string) {
+	if value == nil {
+		return
+	}
+	parsed, err := parseAPIDate(*value)
+	if err != nil {
+		fields[name] = "muss ein Datum im Format YYYY-MM-DD sein"
+		return
+	}
+	*target = parsed
+}
+
+// applyAPIStatus übernimmt einen optionalen Status aus einer festen Liste
+func applyAPIStatus(fields map[string]string, target *string, value *string, allowed ...string) {
+	if value == nil {
+		return
+	}
+	for _, status := range allowed {
+		if *value == status {
+			*target = status
+			return
+		}
+	}
+	fields["status"] = "muss " + strings.Join(allowed, ", ") + " sein"
+}
+
+// Apply überträgt die gesetzten Felder auf die Weiterbildung
+func (in *APITrainingInput) Apply(training *model.Training) map[string]string {
+	fields := map[string]string{}
+	applyAPIString(&training.Title, in.Title)
+	applyAPIString(&training.Description, in.Description)
+	applyAPIString(&training.Provider, in.Provider)
+	applyAPIString(&training.Certificate, in.Certificate)
+	applyAPIString(&training.Notes, in.Notes)
+	applyAPIDate(fields, "startDate", &training.StartDate, in.StartDate)
+	applyAPIDate(fields, "endDate", &training.EndDate, in.EndDate)
+	applyAPIStatus(fields, &training.Status, in.Status, "planned", "ongoing", "completed")
+
+	if training.Title == "" {
+		fields["title"] = "ist erforderlich"
+	}
+	if !training.EndDate.IsZero() && training.EndDate.Before(training.StartDate) {
+		fields["endDate"] = "darf nicht vor startDate liegen"
+	}
+	return fields
+}
+
+// Apply überträgt die gesetzten Felder auf die Leistungsbeurteilung
+func (in *APIEvaluationInput) Apply(evaluation *model.Evaluation) map[string]string {
+	fields := map[string]string{}
+	applyAPIString(&evaluation.Title, in.Title)
+	applyAPIString(&evaluation.Strengths, in.Strengths)
+	applyAPIString(&evaluation.AreasToImprove, in.AreasToImprove)
+	applyAPIString(&evaluation.Comments, in.Comments)
+	applyAPIString(&evaluation.EmployeeComments, in.EmployeeComments)
+	applyAPIDate(fields, "date", &evaluation.Date, in.Date)
+	if in.OverallRating != nil {
+		evaluation.OverallRating = *in.OverallRating
+	}
+
+	if evaluation.Title == "" {
+		fields["title"] = "ist erforderlich"
+	}
+	if evaluation.OverallRating < 1 || evaluation.OverallRating > 5 {
+		fields["overallRating"] = "muss zwischen 1 und 5 liegen"
+	}
+	return fields
+}
+
+// Apply überträgt die gesetzten Felder auf das Mitarbeitergespräch
+func (in *APIConversationInput) Apply(conversation *model.Conversation) map[string]string {
+	fields := map[string]string{}
+	applyAPIString(&conversation.Title, in.Title)
+	applyAPIString(&conversation.Description, in.Description)
+	applyAPIString(&conversation.Notes, in.Notes)
+	applyAPIDate(fields, "date", &conversation.Date, in.Date)
+	applyAPIStatus(fields, &conversation.Status, in.Status, "planned", "completed")
+
+	if conversation.Title == "" {
+		fields["title"] = "ist erforderlich"
+	}
+	if conversation.Date.IsZero() {
+		fields["date"] = "ist erforderlich"
+	}
+	return fields
+}
+
+// findEmbeddedIndex sucht den Eintrag aus dem Pfadparameter param anhand seiner ID
+func findEmbeddedIndex(c *gin.Context, param string, ids []primitive.ObjectID, label string) (int, bool) {
+	id, err := primitive.ObjectIDFromHex(c.Param(param))
+	if err != nil {
+		respondAPIError(c, http.StatusBadRequest, APIErrorBadRequest, "Ungültige ID")
+		return -1, false
+	}
+	for i := range ids {
+		if ids[i] == id {
+			return i, true
+		}
+	}
+	respondAPIError(c, http.StatusNotFound, APIErrorNotFound, label+" nicht gefunden")
+	return -1, false
+}
+
+// ---- Weiterbildungen ----
+
+// ListEmployeeTrainings gibt die Weiterbildungen eines Mitarbeiters zurück
+func (h *APIV1Handler) ListEmployeeTrainings(c *gin.Context) {
+	employee, ok := h.loadEmployee(c, false)
+	if !ok {
+		return
+	}
+	result := make([]APITraining, 0, len(employee.Trainings))
+	for _, training := range employee.Trainings {
+		result = append(result, APITraining{Training: training, Documents: newAPIDocuments(employee, training.Documents)})
+	}
+	respondAPI(c, http.StatusOK, result)
+}
+
+// CreateEmployeeTraining legt eine Weiterbildung an
+func (h *APIV1Handler) CreateEmployeeTraining(c *gin.Context) {
+	employee, ok := h.loadEmployee(c, true)
+	if !ok {
+		return
+	}
+
+	var input APITrainingInput
+	if !bindAPIJSON(c, &input) {
+		return
+	}
+
+	training := model.Training{ID: primitive.NewObjectID(), Status: "planned", Documents: []model.Document{}}
+	if fields := input.Apply(&training); len(fields) > 0 {
+		respondAPIValidation(c, "Ungültige Weiterbildung", fields)
+		return
+	}
+
+	employee.Trainings = append(employee.Trainings, training)
+	if !h.saveEmbedded(c, employee, "Weiterbildung über die API angelegt: "+training.Title) {
+		return
+	}
+
+	respondAPI(c, http.StatusCreated, APITraining{Training: training, Documents: []APIDocument{}})
+}
+
+// UpdateEmployeeTraining aktualisiert eine Weiterbildung (PATCH-Semantik)
+func (h *APIV1Handler) UpdateEmployeeTraining(c *gin.Context) {
+	employee, ok := h.loadEmployee(c, true)
+	if !ok {
+		return
+	}
+	ids := make([]primitive.ObjectID, len(employee.Trainings))
+	for i := range employee.Trainings {
+		ids[i] = employee.Trainings[i].ID
+	}
+	index, ok := findEmbeddedIndex(c, "trainingId", ids, "Weiterbildung")
+	if !ok {
+		return
+	}
+
+	var input APITrainingInput
+	if !bindAPIJSON(c, &input) {
+		return
+	}
+
+	training := employee.Trainings[index]
+	if fields := input.Apply(&training); len(fields) > 0 {
+		respondAPIValidation(c, "Ungültige Weiterbildung", fields)
+		return
+	}
+
+	employee.Trainings[index] = training
+	if !h.saveEmbedded(c, employee, "Weiterbildung über die API aktualisiert: "+training.Title) {
+		return
+	}
+
+	respondAPI(c, http.StatusOK, APITraining{Training: training, Documents: newAPIDocuments(employee, training.Documents)})
+}
+
+// DeleteEmployeeTraining löscht eine Weiterbildung samt Dokumenten
+func (h *APIV1Handler) DeleteEmployeeTraining(c *gin.Context) {
+	employee, ok := h.loadEmployee(c, true)
+	if !ok {
+		return
+	}
+	ids := make([]primitive.ObjectID, len(employee.Trainings))
+	for i := range employee.Trainings {
+		ids[i] = employee.Trainings[i].ID
+	}
+	index, ok := findEmbeddedIndex(c, "trainingId", ids, "Weiterbildung")
+	if !ok {
+		return
+	}
+
+	training := employee.Trainings[index]
+	employee.Trainings = append(employee.Trainings[:index], employee.Trainings[index+1:]...)
+	if !h.saveEmbedded(c, employee, "Weiterbildung über die API gelöscht: "+training.Title) {
+		return
+	}
+	h.deleteDocumentFiles(training.Documents)
+
+	respondAPI(c, http.StatusNoContent, nil)
+}
+
+// ---- Leistungsbeurteilungen ----
+
+// ListEmployeeEvaluations gibt die Leistungsbeurteilungen eines Mitarbeiters zurück
+func (h *APIV1Handler) ListEmployeeEvaluations(c *gin.Context) {
+	employee, ok := h.loadEmployee(c, false)
+	if !ok {
+		return
+	}
+	result := make([]APIEvaluation, 0, len(employee.Evaluations))
+	for _, evaluation := range employee.Evaluations {
+		result = append(result, APIEvaluation{Evaluation: evaluation, Documents: newAPIDocuments(employee, evaluation.Documents)})
+	}
+	respondAPI(c, http.StatusOK, result)
+}
+
+// CreateEmployeeEvaluation legt eine Leistungsbeurteilung an; Beurteiler ist der aktuelle Benutzer
+func (h *APIV1Handler) CreateEmployeeEvaluation(c *gin.Context) {
+	user := apiCurrentUser(c)
+
+	employee, ok := h.loadEmployee(c, true)
+	if !ok {
+		return
+	}
+
+	var input APIEvaluationInput
+	if !bindAPIJSON(c, &input) {
+		return
+	}
+
+	evaluation := model.Evaluation{
+		ID:            primitive.NewObjectID(),
+		Date:          time.Now(),
+		EvaluatorID:   user.ID,
+		EvaluatorName: user.FirstName + " " + user.LastName,
+		Documents:     []model.Document{},
+	}
+	if fields := input.Apply(&evaluation); len(fields) > 0 {
+		respondAPIValidation(c, "Ungültige Leistungsbeurteilung", fields)
+		return
+	}
+
+	employee.Evaluations = append(employee.Evaluations, evaluation)
+	if !h.saveEmbedded(c, employee, "Leistungsbeurteilung über die API angelegt: "+evaluation.Title) {
+		return
+	}
+
+	respondAPI(c, http.StatusCreated, APIEvaluation{Evaluation: evaluation, Documents: []APIDocument{}})
+}
+
+// UpdateEmployeeEvaluation aktualisiert eine Leistungsbeurteilung (PATCH-Semantik)
+func (h *APIV1Handler) UpdateEmployeeEvaluation(c *gin.Context) {
+	employee, ok := h.loadEmployee(c, true)
+	if !ok {
+		return
+	}
+	ids := make([]primitive.ObjectID, len(employee.Evaluations))
+	for i := range employee.Evaluations {
+		ids[i] = employee.Evaluations[i].ID
+	}
+	index, ok := findEmbeddedIndex(c, "evaluationId", ids, "Leistungsbeurteilung")
+	if !ok {
+		return
+	}
+
+	var input APIEvaluationInput
+	if !bindAPIJSON(c, &input) {
+		return
+	}
+
+	evaluation := employee.Evaluations[index]
+	if fields := input.Apply(&evaluation); len(fields) > 0 {
+		respondAPIValidation(c, "Ungültige Leistungsbeurteilung", fields)
+		return
+	}
+
+	employee.Evaluations[index] = evaluation
+	if !h.saveEmbedded(c, employee, "Leistungsbeurteilung über die API aktualisiert: "+evaluation.Title) {
+		return
+	}
+
+	respondAPI(c, http.StatusOK, APIEvaluation{Evaluation: evaluation, Documents: newAPIDocuments(employee, evaluation.Documents)})
+}
+
+// DeleteEmployeeEvaluation löscht eine Leistungsbeurteilung samt Dokumenten
+func (h *APIV1Handler) DeleteEmployeeEvaluation(c *gin.Context) {
+	employee, ok := h.loadEmployee(c, true)
+	if !ok {
+		return
+	}
+	ids := make([]primitive.ObjectID, len(employee.Evaluations))
+	for i := range employee.Evaluations {
+		ids[i] = employee.Evaluations[i].ID
+	}
+	index, ok := findEmbeddedIndex(c, "evaluationId", ids, "Leistungsbeurteilung")
+	if !ok {
+		return
+	}
+
+	evaluation := employee.Evaluations[index]
+	employee.Evaluations = append(employee.Evaluations[:index], employee.Evaluations[index+1:]...)
+	if !h.saveEmbedded(c, employee, "Leistungsbeurteilung über die API gelöscht: "+evaluation.Title) {
+		return
+	}
+	h.deleteDocumentFiles(evaluation.Documents)
+
+	respondAPI(c, http.StatusNoContent, nil)
+}
+
+// ---- Mitarbeitergespräche ----
+
+// ListConversations gibt die Gespräche aller zugänglichen Mitarbeiter zurück.
+// Query: page, pageSize, sort, employeeId, department, status, from, to
+func (h *APIV1Handler) ListConversations(c *gin.Context) {
+	employees, ok := h.listAccessibleEmployees(c)
+	if !ok {
+		return
+	}
+	respondConversations(c, employees)
+}
+
+// ListEmployeeConversations gibt die Gespräche eines Mitarbeiters zurück
+func (h *APIV1Handler) ListEmployeeConversations(c *gin.Context) {
+	employee, ok := h.loadEmployee(c, false)
+	if !ok {
+		return
+	}
+	respondConversations(c, []*model.Employee{employee})
+}
+
+// respondConversations filtert, sortiert und paginiert die Gespräche der Mitarbeiter
+func respondConversations(c *gin.Context, employees []*model.Employee) {
+	page, err := parseAPIPage(c)
+	if err != nil {
+		respondAPIError(c, http.StatusBadRequest, APIErrorBadRequest, err.Error())
+		return
+	}
+	sorting, err := parseAPISort(c, conversationSortFields, "date")
+	if err != nil {
+		respondAPIError(c, http.StatusBadRequest, APIErrorBadRequest, err.Error())
+		return
+	}
+	from, to, err := parseAPIDateRange(c)
+	if err != nil {
+		respondAPIError(c, http.StatusBadRequest, APIErrorBadRequest, err.Error())
+		return
+	}
+	status := c.Query("status")
+
+	conversations := []APIConversation{}
+	for _, employee := range employees {
+		for _, conversation := range employee.Conversations {
+			if status != "" && conversation.Status != status {
+				continue
+			}
+			if !overlapsRange(conversation.Date, conversation.Date, from, to) {
+				continue
+			}
+			conversations = append(conversations, APIConversation{
+				Conversation: conversation,
+				EmployeeID:   employee.ID.Hex(),
+				EmployeeName: employee.FirstName + " " + employee.LastName,
+			})
+		}
+	}
+
+	sort.SliceStable(conversations, func(i, j int) bool {
+		a, b := conversations[i], conversations[j]
+		var less, equal bool
+		switch sorting.Field {
+		case "title":
+			less, equal = a.Title < b.Title, a.Title == b.Title
+		case "status":
+			less, equal = a.Status < b.Status, a.Status == b.Status
+		case "employeeName":
+			less, equal = a.EmployeeName < b.EmployeeName, a.EmployeeName == b.EmployeeName
+		default:
+			less, equal = a.Date.Before(b.Date), a.Date.Equal(b.Date)
+		}
+		if equal {
+			return false
+		}
+		return less == (sorting.Order > 0)
+	})
+
+	start, end := page.Bounds(len(conversations))
+	respondAPIList(c, conversations[start:end], page, int64(len(conversations)), sorting)
+}
+
+// CreateEmployeeConversation legt ein Mitarbeitergespräch an
+func (h *APIV1Handler) CreateEmployeeConversation(c *gin.Context) {
+	user := apiCurrentUser(c)
+
+	employee, ok := h.loadEmployee(c, true)
+	if !ok {
+		return
+	}
+
+	var input APIConversationInput
+	if !bindAPIJSON(c, &input) {
+		return
+	}
+
+	now := time.Now()
+	conversation := model.Conversation{ID: primitive.NewObjectID(), Status: "planned", CreatedAt: now, UpdatedAt: now}
+	if fields := input.Apply(&conversation); len(fields) > 0 {
+		respondAPIValidation(c, "Ungültiges Gespräch", fields)
+		return
+	}
+
+	employee.Conversations = append(employee.Conversations, conversation)
+	if err := h.employeeRepo.Update(employee); err != nil {
+		respondAPIRepositoryError(c, err)
+		return
+	}
+	logAPIEmployeeActivity(user, employee, model.ActivityTypeConversationAdded, "Gespräch über die API geplant: "+conversation.Title)
+
+	respondAPI(c, http.StatusCreated, APIConversation{
+		Conversation: conversation,
+		EmployeeID:   employee.ID.Hex(),
+		EmployeeName: employee.FirstName + " " + employee.LastName,
+	})
+}
+
+// UpdateEmployeeConversation aktualisiert ein Mitarbeitergespräch (PATCH-Semantik)
+func (h *APIV1Handler) UpdateEmployeeConversation(c *gin.Context) {
+	user := apiCurrentUser(c)
+
+	employee, ok := h.loadEmployee(c, true)
+	if !ok {
+		return
+	}
+	ids := make([]primitive.ObjectID, len(employee.Conversations))
+	for i := range employee.Conversations {
+		ids[i] = employee.Conversations[i].ID
+	}
+	index, ok := findEmbeddedIndex(c, "conversationId", ids, "Gespräch")
+	if !ok {
+		return
+	}
+
+	var input APIConversationInput
+	if !bindAPIJSON(c, &input) {
+		return
+	}
+
+	conversation := employee.Conversations[index]
+	previousStatus := conversation.Status
+	if fields := input.Apply(&conversation); len(fields) > 0 {
+		respondAPIValidation(c, "Ungültiges Gespräch", fields)
+		return
+	}
+	conversation.UpdatedAt = time.Now()
+
+	employee.Conversations[index] = conversation
+	if err := h.employeeRepo.Update(employee); err != nil {
+		respondAPIRepositoryError(c, err)
+		return
+	}
+
+	activityType := model.ActivityTypeConversationUpdated
+	if previousStatus != "completed" && conversation.Status == "completed" {
+		activityType = model.ActivityTypeConversationCompleted
+	}
+	logAPIEmployeeActivity(user, employee, activityType, "Gespräch über die API aktualisiert: "+conversation.Title)
+
+	respondAPI(c, http.StatusOK, APIConversation{
+		Conversation: conversation,
+		EmployeeID:   employee.ID.Hex(),
+		EmployeeName: employee.FirstName + " " + employee.LastName,
+	})
+}
+
+// DeleteEmployeeConversation löscht ein Mitarbeitergespräch
+func (h *APIV1Handler) DeleteEmployeeConversation(c *gin.Context) {
+	employee, ok := h.loadEmployee(c, true)
+	if !ok {
+		return
+	}
+	ids := make([]primitive.ObjectID, len(employee.Conversations))
+	for i := range employee.Conversations {
+		ids[i] = employee.Conversations[i].ID
+	}
+	index, ok := findEmbeddedIndex(c, "conversationId", ids, "Gespräch")
+	if !ok {
+		return
+	}
+
+	conversation := employee.Conversations[index]
+	employee.Conversations = append(employee.Conversations[:index], employee.Conversations[index+1:]...)
+	if !h.saveEmbedded(c, employee, "Gespräch über die API gelöscht: "+conversation.Title) {
+		return
+	}
+
+	respondAPI(c, http.StatusNoContent, nil)
+}
+
+// saveEmbedded speichert Änderungen an eingebetteten Daten und protokolliert sie
+func (h *APIV1Handler) saveEmbedded(c *gin.Context, employee *model.Employee, description string) bool {
+	if err := h.employeeRepo.Update(employee); err != nil {
+		respondAPIRepositoryError(c, err)
+		return false
+	}
+	logAPIEmployeeActivity(apiCurrentUser(c), employee, model.ActivityTypeEmployeeUpdated, description)
+	return true
+}
@@ -0,0 +1,229 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"PeopleFlow/backend/model"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// APIDocument ist die Darstellung eines Dokuments in der REST-API v1 (ohne Dateipfad)
+type APIDocument struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	FileName    string    `json:"fileName"`
+	FileType    string    `json:"fileType"`
+	Description string    `json:"description"`
+	Category    string    `json:"category"`
+	FileSize    int64     `json:"fileSize"`
+	UploadDate  time.Time  `json:"uploadDate"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
+	DownloadURL string     `json:"downloadUrl"`
+}
+
+// newAPIDocuments wandelt Dokumente eines Mitarbeiters in die API-Darstellung um
+func newAPIDocuments(employee *model.Employee, documents []model.Document) []APIDocument {
+	result := make([]APIDocument, 0, len(documents))
+	for _, document := range documents {
+		result = append(result, APIDocument{
+			ID:          document.ID.Hex(),
+			Name:        document.Name,
+			FileName:    document.FileName,
+			FileType:    document.FileType,
+			Description: document.Description,
+			Category:    document.Category,
+			FileSize:    document.FileSize,
+			UploadDate:  document.UploadDate,
+			ExpiresAt:   document.ExpiresAt,
+			DownloadURL: "/api/v1/employees/" + employee.ID.Hex() + "/documents/" + document.ID.Hex() + "/download",
+		})
+	}
+	return result
+}
+
+// ListEmployeeDocuments gibt die Dokumente der Personalakte zurück.
+// Query: category ("application" für Bewerbungsunterlagen)
+func (h *APIV1Handler) ListEmployeeDocuments(c *gin.Context) {
+	employee, ok := h.loadEmployee(c, false)
+	if !ok {
+		return
+	}
+
+	documents := employee.Documents
+	if c.Query("category") == "application" {
+		documents = employee.ApplicationDocuments
+	} else if category := c.Query("category"); category != "" {
+		documents = nil
+		for _, document := range employee.Documents {
+			if document.Category == category {
+				documents = append(documents, document)
+			}
+		}
+	}
+
+	respondAPI(c, http.StatusOK, newAPIDocuments(employee, documents))
+}
+
+// UploadEmployeeDocument lädt ein Dokument in die Personalakte hoch (multipart/form-data).
+// Felder: file, name, description, category
+func (h *APIV1Handler) UploadEmployeeDocument(c *gin.Context) {
+	user := apiCurrentUser(c)
+
+	employee, ok := h.loadEmployee(c, true)
+	if !ok {
+		return
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		respondAPIValidation(c, "Keine Datei hochgeladen", map[string]string{"file": "ist erforderlich"})
+		return
+	}
+
+	name := strings.TrimSpace(c.PostForm("name"))
+	if name == "" {
+		name = file.Filename
+	}
+	category := c.PostForm("category")
+
+	var expiresAt *time.Time
+	if value := c.PostForm("expiresAt"); value != "" {
+		parsed, err := parseAPIDate(value)
+		if err != nil {
+			respondAPIValidation(c, "Ungültiges Ablaufdatum", map[string]string{"expiresAt": "muss ein Datum (YYYY-MM-DD) sein"})
+			return
+		}
+		expiresAt = &parsed
+	}
+
+	document, err := h.fileService.UploadFile(file, name, c.PostForm("description"), category, user.ID)
+	if err != nil {
+		respondAPIError(c, http.StatusInternalServerError, APIErrorInternal, "Fehler beim Speichern der Datei")
+		return
+	}
+	document.ExpiresAt = expiresAt
+
+	if category == "application" {
+		employee.ApplicationDocuments = append(employee.ApplicationDocuments, *document)
+	} else {
+		employee.Documents = append(employee.Documents, *document)
+	}
+
+	if err := h.employeeRepo.Update(employee); err != nil {
+		h.deleteDocumentFiles([]model.Document{*document})
+		respondAPIRepositoryError(c, err)
+		return
+	}
+
+	logAPIEmployeeActivity(user, employee, model.ActivityTypeDocumentUploaded, "Dokument über die API hochgeladen: "+document.Name)
+
+	respondAPI(c, http.StatusCreated, newAPIDocuments(employee, []model.Document{*document})[0])
+}
+
+// DownloadEmployeeDocument liefert die Datei eines Dokuments aus, auch aus Trainings,
+// Beurteilungen und Abwesenheiten
+func (h *APIV1Handler) DownloadEmployeeDocument(c *gin.Context) {
+	employee, ok := h.loadEmployee(c, false)
+	if !ok {
+		return
+	}
+
+	document, ok := findEmployeeDocument(c, employee)
+	if !ok {
+		return
+	}
+
+	c.FileAttachment(document.FilePath, document.FileName)
+}
+
+// DeleteEmployeeDocument löscht ein Dokument der Personalakte
+func (h *APIV1Handler) DeleteEmployeeDocument(c *gin.Context) {
+	user := apiCurrentUser(c)
+
+	employee, ok := h.loadEmployee(c, true)
+	if !ok {
+		return
+	}
+
+	documentID, err := primitive.ObjectIDFromHex(c.Param("documentId"))
+	if err != nil {
+		respondAPIError(c, http.StatusBadRequest, APIErrorBadRequest, "Ungültige Dokument-ID")
+		return
+	}
+
+	var removed *model.Document
+	employee.Documents, removed = removeDocument(employee.Documents, documentID)
+	if removed == nil {
+		employee.ApplicationDocuments, removed = removeDocument(employee.ApplicationDocuments, documentID)
+	}
+	if removed == nil {
+		respondAPIError(c, http.StatusNotFound, APIErrorNotFound, "Dokument nicht gefunden")
+		return
+	}
+
+	if err := h.employeeRepo.Update(employee); err != nil {
+		respondAPIRepositoryError(c, err)
+		return
+	}
+	h.deleteDocumentFiles([]model.Document{*removed})
+
+	logAPIEmployeeActivity(user, employee, model.ActivityTypeEmployeeUpdated, "Dokument über die API gelöscht: "+removed.Name)
+
+	respondAPI(c, http.StatusNoContent, nil)
+}
+
+// deleteDocumentFiles entfernt die Dateien von Dokumenten aus dem Dateisystem
+func (h *APIV1Handler) deleteDocumentFiles(documents []model.Document) {
+	for _, document := range documents {
+		if err := h.fileService.DeleteFile(document.FilePath); err != nil {
+			log.Printf("Warnung: Konnte Dokument %s nicht löschen: %v", document.FilePath, err)
+		}
+	}
+}
+
+// removeDocument entfernt ein Dokument aus der Liste und gibt es zurück
+func removeDocument(documents []model.Document, documentID primitive.ObjectID) ([]model.Document, *model.Document) {
+	for i := range documents {
+		if documents[i].ID == documentID {
+			removed := documents[i]
+			return append(documents[:i], documents[i+1:]...), &removed
+		}
+	}
+	return documents, nil
+}
+
+// findEmployeeDocument sucht das Dokument aus dem Pfadparameter :documentId in allen Bereichen der Personalakte
+func findEmployeeDocument(c *gin.Context, employee *model.Employee) (*model.Document, bool) {
+	documentID, err := primitive.ObjectIDFromHex(c.Param("documentId"))
+	if err != nil {
+		respondAPIError(c, http.StatusBadRequest, APIErrorBadRequest, "Ungültige Dokument-ID")
+		return nil, false
+	}
+
+	groups := [][]model.Document{employee.Documents, employee.ApplicationDocuments}
+	for _, training := range employee.Trainings {
+		groups = append(groups, training.Documents)
+	}
+	for _, evaluation := range employee.Evaluations {
+		groups = append(groups, evaluation.Documents)
+	}
+	for _, absence := range employee.Absences {
+		groups = append(groups, absence.Documents)
+	}
+
+	for _, documents := range groups {
+		for i := range documents {
+			if documents[i].ID == documentID {
+				return &documents[i], true
+			}
+		}
+	}
+
+	respondAPIError(c, http.StatusNotFound, APIErrorNotFound, "Dokument nicht gefunden")
+	return nil, false
+}
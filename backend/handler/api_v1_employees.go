@@ -0,0 +1,494 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// employeeSortFields sind die sortierbaren Felder der Mitarbeiterliste
+var employeeSortFields = map[string]string{
+	"lastName":   "lastName",
+	"firstName":  "firstName",
+	"employeeId": "employeeId",
+	"department": "department",
+	"position":   "position",
+	"status":     "status",
+	"hireDate":   "hireDate",
+	"createdAt":  "createdAt",
+	"updatedAt":  "updatedAt",
+}
+
+// APIEmployee ist die Darstellung eines Mitarbeiters in der REST-API v1
+type APIEmployee struct {
+	ID                   string                `json:"id"`
+	EmployeeID           string                `json:"employeeId"`
+	FirstName            string                `json:"firstName"`
+	LastName             string                `json:"lastName"`
+	Email                string                `json:"email"`
+	Phone                string                `json:"phone"`
+	InternalPhone        string                `json:"internalPhone"`
+	InternalExtension    string                `json:"internalExtension"`
+	Address              string                `json:"address"`
+	DateOfBirth          *time.Time            `json:"dateOfBirth,omitempty"`
+	HireDate             *time.Time            `json:"hireDate,omitempty"`
+	Position             string                `json:"position"`
+	Department           string                `json:"department"`
+	Location             string                `json:"location"`
+	ManagerID            string                `json:"managerId,omitempty"`
+	Status               string                `json:"status"`
+	WorkingHoursPerWeek  float64               `json:"workingHoursPerWeek"`
+	WorkingDaysPerWeek   int                   `json:"workingDaysPerWeek"`
+	WorkTimeModel        string                `json:"workTimeModel"`
+	FlexibleWorkingHours bool                  `json:"flexibleWorkingHours"`
+	CoreWorkingTimeStart string                `json:"coreWorkingTimeStart"`
+	CoreWorkingTimeEnd   string                `json:"coreWorkingTimeEnd"`
+	OvertimeBalance      float64               `json:"overtimeBalance"`
+	VacationDays         int                   `json:"vacationDays"`
+	RemainingVacation    int                   `json:"remainingVacation"`
+	EmergencyName        string                `json:"emergencyName"`
+	EmergencyPhone       string                `json:"emergencyPhone"`
+	Notes                string                `json:"notes"`
+	HasProfileImage      bool                  `json:"hasProfileImage"`
+	TimebutlerUserID     string                `json:"timebutlerUserId,omitempty"`
+	Erfasst123ID         string                `json:"erfasst123Id,omitempty"`
+	Financial            *APIEmployeeFinancial `json:"financial,omitempty"` // Nur für Admins und Manager
+	CreatedAt            time.Time             `json:"createdAt"`
+	UpdatedAt            time.Time             `json:"updatedAt"`
+}
+
+// APIEmployeeFinancial enthält die vertraulichen Finanzdaten eines Mitarbeiters
+type APIEmployeeFinancial struct {
+	Salary          float64 `json:"salary"`
+	BankAccount     string  `json:"bankAccount"`
+	TaxID           string  `json:"taxId"`
+	SocialSecID     string  `json:"socialSecId"`
+	HealthInsurance string  `json:"healthInsurance"`
+}
+
+// APIEmployeeInput beschreibt die änderbaren Felder beim Anlegen und Aktualisieren.
+// Nicht gesetzte Felder (null) bleiben bei PATCH unverändert.
+type APIEmployeeInput struct {
+	EmployeeID           *string  `json:"employeeId"`
+	FirstName            *string  `json:"firstName"`
+	LastName             *string  `json:"lastName"`
+	Email                *string  `json:"email"`
+	Phone                *string  `json:"phone"`
+	InternalPhone        *string  `json:"internalPhone"`
+	InternalExtension    *string  `json:"internalExtension"`
+	Address              *string  `json:"address"`
+	DateOfBirth          *string  `json:"dateOfBirth"`
+	HireDate             *string  `json:"hireDate"`
+	Position             *string  `json:"position"`
+	Department           *string  `json:"department"`
+	Location             *string  `json:"location"`
+	ManagerID            *string  `json:"managerId"`
+	Status               *string  `json:"status"`
+	WorkingHoursPerWeek  *float64 `json:"workingHoursPerWeek"`
+	WorkingDaysPerWeek   *int     `json:"workingDaysPerWeek"`
+	WorkTimeModel        *string  `json:"workTimeModel"`
+	FlexibleWorkingHours *bool    `json:"flexibleWorkingHours"`
+	CoreWorkingTimeStart *string  `json:"coreWorkingTimeStart"`
+	CoreWorkingTimeEnd   *string  `json:"coreWorkingTimeEnd"`
+	VacationDays         *int     `json:"vacationDays"`
+	RemainingVacation    *int     `json:"remainingVacation"`
+	EmergencyName        *string  `json:"emergencyName"`
+	EmergencyPhone       *string  `json:"emergencyPhone"`
+	Notes                *string  `json:"notes"`
+	Salary               *float64 `json:"salary"`
+	BankAccount          *string  `json:"bankAccount"`
+	TaxID                *string  `json:"taxId"`
+	SocialSecID          *string  `json:"socialSecId"`
+	HealthInsurance      *string  `json:"healthInsurance"`
+}
+
+// newAPIEmployee wandelt einen Mitarbeiter in die API-Darstellung um
+func newAPIEmployee(employee *model.Employee, includeFinancial bool) APIEmployee {
+	result := APIEmployee{
+		ID:                   employee.ID.Hex(),
+		EmployeeID:           employee.EmployeeID,
+		FirstName:            employee.FirstName,
+		LastName:             employee.LastName,
+		Email:                employee.Email,
+		Phone:                employee.Phone,
+		InternalPhone:        employee.InternalPhone,
+		InternalExtension:    employee.InternalExtension,
+		Address:              employee.Address,
+		Position:             employee.Position,
+		Department:           string(employee.Department),
+		Location:             employee.Location,
+		Status:               string(employee.Status),
+		WorkingHoursPerWeek:  employee.WorkingHoursPerWeek,
+		WorkingDaysPerWeek:   employee.WorkingDaysPerWeek,
+		WorkTimeModel:        string(employee.WorkTimeModel),
+		FlexibleWorkingHours: employee.FlexibleWorkingHours,
+		CoreWorkingTimeStart: employee.CoreWorkingTimeStart,
+		CoreWorkingTimeEnd:   employee.CoreWorkingTimeEnd,
+		OvertimeBalance:      employee.OvertimeBalance,
+		VacationDays:         employee.VacationDays,
+		RemainingVacation:    employee.RemainingVacation,
+		EmergencyName:        employee.EmergencyName,
+		EmergencyPhone:       employee.EmergencyPhone,
+		Notes:                employee.Notes,
+		HasProfileImage:      employee.ProfileImage != "",
+		TimebutlerUserID:     employee.TimebutlerUserID,
+		Erfasst123ID:         employee.Erfasst123ID,
+		CreatedAt:            employee.CreatedAt,
+		UpdatedAt:            employee.UpdatedAt,
+	}
+	if !employee.DateOfBirth.IsZero() {
+		dateOfBirth := employee.DateOfBirth
+		result.DateOfBirth = &dateOfBirth
+	}
+	if !employee.HireDate.IsZero() {
+		hireDate := employee.HireDate
+		result.HireDate = &hireDate
+	}
+	if !employee.ManagerID.IsZero() {
+		result.ManagerID = employee.ManagerID.Hex()
+	}
+	if includeFinancial {
+		result.Financial = &APIEmployeeFinancial{
+			Salary:          employee.Salary,
+			BankAccount:     employee.BankAccount,
+			TaxID:           employee.TaxID,
+			SocialSecID:     employee.SocialSecID,
+			HealthInsurance: employee.HealthInsurance,
+		}
+	}
+	return result
+}
+
+// Apply überträgt die gesetzten Felder auf den Mitarbeiter und gibt Feldfehler zurück
+func (in *APIEmployeeInput) Apply(employee *model.Employee, canEditFinancial bool) map[string]string {
+	fields := map[string]string{}
+
+	setString := func(target *string, value *string) {
+		if value != nil {
+			*target = strings.TrimSpace(*value)
+		}
+	}
+	setDate := func(name string, target *time.Time, value *string) {
+		if value == nil {
+			return
+		}
+		if *value == "" {
+			*target = time.Time{}
+			return
+		}
+		parsed, err := parseAPIDate(*value)
+		if err != nil {
+			fields[name] = "muss ein Datum im Format YYYY-MM-DD sein"
+			return
+		}
+		*target = parsed
+	}
+
+	setString(&employee.EmployeeID, in.EmployeeID)
+	setString(&employee.FirstName, in.FirstName)
+	setString(&employee.LastName, in.LastName)
+	setString(&employee.Email, in.Email)
+	setString(&employee.Phone, in.Phone)
+	setString(&employee.InternalPhone, in.InternalPhone)
+	setString(&employee.InternalExtension, in.InternalExtension)
+	setString(&employee.Address, in.Address)
+	setString(&employee.Position, in.Position)
+	setString(&employee.Location, in.Location)
+	setString(&employee.CoreWorkingTimeStart, in.CoreWorkingTimeStart)
+	setString(&employee.CoreWorkingTimeEnd, in.CoreWorkingTimeEnd)
+	setString(&employee.EmergencyName, in.EmergencyName)
+	setString(&employee.EmergencyPhone, in.EmergencyPhone)
+	setString(&employee.Notes, in.Notes)
+	setDate("dateOfBirth", &employee.DateOfBirth, in.DateOfBirth)
+	setDate("hireDate", &employee.HireDate, in.HireDate)
+
+	if in.Department != nil {
+		employee.Department = model.Department(strings.TrimSpace(*in.Department))
+	}
+	if in.Status != nil {
+		switch status := model.EmployeeStatus(*in.Status); status {
+		case model.EmployeeStatusActive, model.EmployeeStatusInactive, model.EmployeeStatusOnLeave, model.EmployeeStatusRemote:
+			employee.Status = status
+		default:
+			fields["status"] = "muss active, inactive, onleave oder remote sein"
+		}
+	}
+	if in.ManagerID != nil {
+		if *in.ManagerID == "" {
+			employee.ManagerID = primitive.NilObjectID
+		} else if managerID, err := primitive.ObjectIDFromHex(*in.ManagerID); err == nil {
+			employee.ManagerID = managerID
+		} else {
+			fields["managerId"] = "ist keine gültige ID"
+		}
+	}
+	if in.WorkingHoursPerWeek != nil {
+		employee.WorkingHoursPerWeek = *in.WorkingHoursPerWeek
+	}
+	if in.WorkingDaysPerWeek != nil {
+		if *in.WorkingDaysPerWeek < 0 || *in.WorkingDaysPerWeek > 7 {
+			fields["workingDaysPerWeek"] = "muss zwischen 0 und 7 liegen"
+		} else {
+			employee.WorkingDaysPerWeek = *in.WorkingDaysPerWeek
+		}
+	}
+	if in.WorkTimeModel != nil {
+		employee.WorkTimeModel = model.WorkTimeModel(*in.WorkTimeModel)
+	}
+	if in.FlexibleWorkingHours != nil {
+		employee.FlexibleWorkingHours = *in.FlexibleWorkingHours
+	}
+	if in.VacationDays != nil {
+		employee.VacationDays = *in.VacationDays
+	}
+	if in.RemainingVacation != nil {
+		employee.RemainingVacation = *in.RemainingVacation
+	}
+
+	financial := in.Salary != nil || in.BankAccount != nil || in.TaxID != nil || in.SocialSecID != nil || in.HealthInsurance != nil
+	if financial && !canEditFinancial {
+		fields["salary"] = "Finanzdaten dürfen nur von Admins und Managern geändert werden"
+	} else if financial {
+		if in.Salary != nil {
+			if *in.Salary < 0 {
+				fields["salary"] = "darf nicht negativ sein"
+			} else {
+				employee.Salary = *in.Salary
+			}
+		}
+		setString(&employee.BankAccount, in.BankAccount)
+		setString(&employee.TaxID, in.TaxID)
+		setString(&employee.SocialSecID, in.SocialSecID)
+		setString(&employee.HealthInsurance, in.HealthInsurance)
+	}
+
+	return fields
+}
+
+// ListEmployees gibt Mitarbeiter mit Filterung, Sortierung und Pagination zurück.
+// Query: page, pageSize, sort, q, department, status, managerId
+func (h *APIV1Handler) ListEmployees(c *gin.Context) {
+	user := apiCurrentUser(c)
+
+	page, err := parseAPIPage(c)
+	if err != nil {
+		respondAPIError(c, http.StatusBadRequest, APIErrorBadRequest, err.Error())
+		return
+	}
+	sort, err := parseAPISort(c, employeeSortFields, "lastName")
+	if err != nil {
+		respondAPIError(c, http.StatusBadRequest, APIErrorBadRequest, err.Error())
+		return
+	}
+
+	query := repository.EmployeeQuery{
+		Search:     c.Query("q"),
+		Department: c.Query("department"),
+		Status:     c.Query("status"),
+		Skip:       page.Skip(),
+		Limit:      int64(page.PageSize),
+		SortBy:     sort.Field,
+		SortOrder:  sort.Order,
+	}
+	if value := c.Query("managerId"); value != "" {
+		managerID, err := primitive.ObjectIDFromHex(value)
+		if err != nil {
+			respondAPIError(c, http.StatusBadRequest, APIErrorBadRequest, "managerId ist keine gültige ID")
+			return
+		}
+		query.ManagerID = &managerID
+	}
+
+	// Mitarbeiter ohne Personalrolle sehen nur ihren eigenen Datensatz
+	if !apiIsStaff(user) {
+		query.IDs = []primitive.ObjectID{}
+		if user.EmployeeID != nil {
+			query.IDs = append(query.IDs, *user.EmployeeID)
+		}
+	}
+
+	employees, total, err := h.employeeRepo.Search(query)
+	if err != nil {
+		respondAPIRepositoryError(c, err)
+		return
+	}
+
+	includeFinancial := apiCanViewSalary(user)
+	result := make([]APIEmployee, 0, len(employees))
+	for _, employee := range employees {
+		result = append(result, newAPIEmployee(employee, includeFinancial))
+	}
+
+	respondAPIList(c, result, page, total, sort)
+}
+
+// GetEmployee gibt einen einzelnen Mitarbeiter zurück
+func (h *APIV1Handler) GetEmployee(c *gin.Context) {
+	employee, ok := h.loadEmployee(c, false)
+	if !ok {
+		return
+	}
+	respondAPI(c, http.StatusOK, newAPIEmployee(employee, apiCanViewSalary(apiCurrentUser(c))))
+}
+
+// CreateEmployee legt einen neuen Mitarbeiter an
+func (h *APIV1Handler) CreateEmployee(c *gin.Context) {
+	user := apiCurrentUser(c)
+
+	var input APIEmployeeInput
+	if !bindAPIJSON(c, &input) {
+		return
+	}
+
+	employee := &model.Employee{}
+	if fields := input.Apply(employee, apiCanViewSalary(user)); len(fields) > 0 {
+		respondAPIValidation(c, "Ungültige Mitarbeiterdaten", fields)
+		return
+	}
+
+	if err := h.employeeRepo.Create(employee); err != nil {
+		respondAPIRepositoryError(c, err)
+		return
+	}
+
+	logAPIEmployeeActivity(user, employee, model.ActivityTypeEmployeeAdded, "Mitarbeiter über die API angelegt")
+
+	c.Header("Location", "/api/v1/employees/"+employee.ID.Hex())
+	respondAPI(c, http.StatusCreated, newAPIEmployee(employee, apiCanViewSalary(user)))
+}
+
+// UpdateEmployee aktualisiert die übergebenen Felder eines Mitarbeiters (PATCH-Semantik)
+func (h *APIV1Handler) UpdateEmployee(c *gin.Context) {
+	user := apiCurrentUser(c)
+
+	employee, ok := h.loadEmployee(c, true)
+	if !ok {
+		return
+	}
+	before := *employee
+
+	var input APIEmployeeInput
+	if !bindAPIJSON(c, &input) {
+		return
+	}
+
+	if fields := input.Apply(employee, apiCanViewSalary(user)); len(fields) > 0 {
+		respondAPIValidation(c, "Ungültige Mitarbeiterdaten", fields)
+		return
+	}
+	employee.TrackFieldChanges(&before, model.FieldSourcePeopleFlow, time.Now())
+	employee.TrackSalaryChange(&before, user.FirstName+" "+user.LastName, time.Now())
+
+	if err := h.employeeRepo.Update(employee); err != nil {
+		respondAPIRepositoryError(c, err)
+		return
+	}
+
+	logAPIEmployeeActivity(user, employee, model.ActivityTypeEmployeeUpdated, "Mitarbeiter über die API aktualisiert")
+
+	respondAPI(c, http.StatusOK, newAPIEmployee(employee, apiCanViewSalary(user)))
+}
+
+// DeleteEmployee deaktiviert einen Mitarbeiter (wie in der Weboberfläche)
+func (h *APIV1Handler) DeleteEmployee(c *gin.Context) {
+	user := apiCurrentUser(c)
+
+	employee, ok := h.loadEmployee(c, true)
+	if !ok {
+		return
+	}
+
+	if err := h.employeeRepo.Delete(employee.ID.Hex()); err != nil {
+		respondAPIRepositoryError(c, err)
+		return
+	}
+
+	logAPIEmployeeActivity(user, employee, model.ActivityTypeEmployeeDeleted, "Mitarbeiter über die API deaktiviert")
+
+	respondAPI(c, http.StatusNoContent, nil)
+}
+
+// loadEmployee lädt den Mitarbeiter aus dem Pfadparameter :id und prüft die Berechtigung.
+// Mit write=true sind nur Admins, Manager und HR zugelassen.
+func (h *APIV1Handler) loadEmployee(c *gin.Context, write bool) (*model.Employee, bool) {
+	user := apiCurrentUser(c)
+
+	employee, err := h.employeeRepo.FindByID(c.Param("id"))
+	if err != nil {
+		respondAPIRepositoryError(c, err)
+		return nil, false
+	}
+
+	if write && !apiIsStaff(user) {
+		respondAPIError(c, http.StatusForbidden, APIErrorForbidden, "Keine Berechtigung, diesen Mitarbeiter zu ändern")
+		return nil, false
+	}
+	if !apiCanAccessEmployee(user, employee.ID) {
+		// Fremde Datensätze werden wie nicht vorhandene behandelt
+		respondAPIError(c, http.StatusNotFound, APIErrorNotFound, "Ressource nicht gefunden")
+		return nil, false
+	}
+
+	return employee, true
+}
+
+// logAPIEmployeeActivity protokolliert Änderungen an Mitarbeitern über die API
+func logAPIEmployeeActivity(user *model.User, employee *model.Employee, activityType model.ActivityType, description string) {
+	activityRepo := repository.NewActivityRepository()
+	_, _ = activityRepo.LogActivity(
+		activityType,
+		user.ID,
+		user.FirstName+" "+user.LastName,
+		employee.ID,
+		"employee",
+		employee.FirstName+" "+employee.LastName,
+		description,
+	)
+}
+
+// listAccessibleEmployees lädt alle Mitarbeiter, auf deren Unterdaten der Benutzer zugreifen darf.
+// Der optionale Query-Parameter employeeId schränkt auf eine kommagetrennte Liste von IDs ein.
+func (h *APIV1Handler) listAccessibleEmployees(c *gin.Context) ([]*model.Employee, bool) {
+	user := apiCurrentUser(c)
+
+	query := repository.EmployeeQuery{Department: c.Query("department")}
+	if value := c.Query("employeeId"); value != "" {
+		ids, err := parseObjectIDs(value)
+		if err != nil {
+			respondAPIError(c, http.StatusBadRequest, APIErrorBadRequest, "employeeId: "+err.Error())
+			return nil, false
+		}
+		query.IDs = ids
+	}
+
+	if !apiIsStaff(user) {
+		var own []primitive.ObjectID
+		if user.EmployeeID != nil && (query.IDs == nil || containsObjectID(query.IDs, *user.EmployeeID)) {
+			own = append(own, *user.EmployeeID)
+		}
+		query.IDs = append([]primitive.ObjectID{}, own...)
+	}
+
+	employees, _, err := h.employeeRepo.Search(query)
+	if err != nil {
+		respondAPIRepositoryError(c, err)
+		return nil, false
+	}
+	return employees, true
+}
+
+// containsObjectID prüft, ob eine ID in der Liste enthalten ist
+func containsObjectID(ids []primitive.ObjectID, id primitive.ObjectID) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APISettings ist die Darstellung der System-Einstellungen in der REST-API v1
+type APISettings struct {
+	CompanyName         string                `json:"companyName"`
+	CompanyAddress      string                `json:"companyAddress"`
+	Language            string                `json:"language"`
+	State               string                `json:"state"`
+	DefaultWorkingHours float64               `json:"defaultWorkingHours"`
+	DefaultVacationDays int                   `json:"defaultVacationDays"`
+	RequireTwoFactor    bool                  `json:"requireTwoFactor"`
+	Email               *APIEmailSettings     `json:"email,omitempty"`          // Nur für Admins
+	PasswordPolicy      *model.PasswordPolicy `json:"passwordPolicy,omitempty"` // Nur für Admins
+	UpdatedAt           time.Time             `json:"updatedAt"`
+}
+
+// APIEmailSettings enthält die E-Mail-Konfiguration ohne SMTP-Passwort
+type APIEmailSettings struct {
+	Enabled     bool   `json:"enabled"`
+	SMTPHost    string `json:"smtpHost"`
+	SMTPPort    int    `json:"smtpPort"`
+	SMTPUser    string `json:"smtpUser"`
+	HasPassword bool   `json:"hasPassword"`
+	FromEmail   string `json:"fromEmail"`
+	FromName    string `json:"fromName"`
+	UseTLS      bool   `json:"useTLS"`
+}
+
+// APISettingsInput beschreibt die über die API änderbaren Einstellungen
+type APISettingsInput struct {
+	CompanyName         *string  `json:"companyName"`
+	CompanyAddress      *string  `json:"companyAddress"`
+	Language            *string  `json:"language"`
+	State               *string  `json:"state"`
+	DefaultWorkingHours *float64 `json:"defaultWorkingHours"`
+	DefaultVacationDays *int     `json:"defaultVacationDays"`
+	RequireTwoFactor    *bool    `json:"requireTwoFactor"`
+}
+
+// newAPISettings wandelt die Einstellungen in die API-Darstellung um
+func newAPISettings(settings *model.SystemSettings, includeAdmin bool) APISettings {
+	result := APISettings{
+		CompanyName:         settings.CompanyName,
+		CompanyAddress:      settings.CompanyAddress,
+		Language:            settings.Language,
+		State:               settings.State,
+		DefaultWorkingHours: settings.DefaultWorkingHours,
+		DefaultVacationDays: settings.DefaultVacationDays,
+		RequireTwoFactor:    settings.RequireTwoFactor,
+		UpdatedAt:           settings.UpdatedAt,
+	}
+	if includeAdmin {
+		result.PasswordPolicy = settings.GetPasswordPolicy()
+		if email := settings.EmailNotifications; email != nil {
+			result.Email = &APIEmailSettings{
+				Enabled:     email.Enabled,
+				SMTPHost:    email.SMTPHost,
+				SMTPPort:    email.SMTPPort,
+				SMTPUser:    email.SMTPUser,
+				HasPassword: email.SMTPPass != "",
+				FromEmail:   email.FromEmail,
+				FromName:    email.FromName,
+				UseTLS:      email.UseTLS,
+			}
+		}
+	}
+	return result
+}
+
+// GetSettings gibt die System-Einstellungen zurück; vertrauliche Bereiche nur für Admins
+func (h *APIV1Handler) GetSettings(c *gin.Context) {
+	settings, err := h.settingsRepo.GetSettings()
+	if err != nil {
+		respondAPIRepositoryError(c, err)
+		return
+	}
+	respondAPI(c, http.StatusOK, newAPISettings(settings, apiCurrentUser(c).IsAdmin()))
+}
+
+// UpdateSettings aktualisiert die übergebenen Einstellungen (nur für Admins)
+func (h *APIV1Handler) UpdateSettings(c *gin.Context) {
+	user := apiCurrentUser(c)
+
+	var input APISettingsInput
+	if !bindAPIJSON(c, &input) {
+		return
+	}
+
+	settings, err := h.settingsRepo.GetSettings()
+	if err != nil {
+		respondAPIRepositoryError(c, err)
+		return
+	}
+
+	if input.CompanyName != nil {
+		settings.CompanyName = strings.TrimSpace(*input.CompanyName)
+	}
+	if input.CompanyAddress != nil {
+		settings.CompanyAddress = strings.TrimSpace(*input.CompanyAddress)
+	}
+	if input.Language != nil {
+		settings.Language = *input.Language
+	}
+	if input.State != nil {
+		settings.State = *input.State
+	}
+	if input.DefaultWorkingHours != nil {
+		settings.DefaultWorkingHours = *input.DefaultWorkingHours
+	}
+	if input.DefaultVacationDays != nil {
+		settings.DefaultVacationDays = *input.DefaultVacationDays
+	}
+	if input.RequireTwoFactor != nil {
+		settings.RequireTwoFactor = *input.RequireTwoFactor
+	}
+
+	if err := h.settingsRepo.Update(settings); err != nil {
+		respondAPIRepositoryError(c, err)
+		return
+	}
+
+	activityRepo := repository.NewActivityRepository()
+	_, _ = activityRepo.LogActivity(
+		model.ActivityTypeSystemSettingChanged,
+		user.ID,
+		user.FirstName+" "+user.LastName,
+		user.ID,
+		"system",
+		"System-Einstellungen",
+		"System-Einstellungen über die API aktualisiert",
+	)
+
+	respondAPI(c, http.StatusOK, newAPISettings(settings, true))
+}
@@ -0,0 +1,300 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+)
+
+// apiTestResponse bildet den Umschlag der REST-API v1 ab
+type apiTestResponse struct {
+	Success bool            `json:"success"`
+	Data    json.RawMessage `json:"data"`
+	Meta    *APIMeta        `json:"meta"`
+	Error   *APIError       `json:"error"`
+}
+
+// serveAPITest führt eine Anfrage gegen einen einzelnen Handler aus
+func serveAPITest(t *testing.T, target string, user *model.User, handlers ...gin.HandlerFunc) (*httptest.ResponseRecorder, apiTestResponse) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	chain := append([]gin.HandlerFunc{func(c *gin.Context) {
+		if user != nil {
+			c.Set("user", user)
+			c.Set("userRole", string(user.Role))
+		}
+	}}, handlers...)
+	router.GET("/test", chain...)
+
+	req, _ := http.NewRequest(http.MethodGet, target, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var response apiTestResponse
+	if w.Body.Len() > 0 {
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	}
+	return w, response
+}
+
+func TestAPIV1Pagination(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		wantErr  bool
+		wantPage apiPage
+	}{
+		{"defaults", "", false, apiPage{Page: 1, PageSize: APIDefaultPageSize}},
+		{"explicit", "?page=3&pageSize=10", false, apiPage{Page: 3, PageSize: 10}},
+		{"max page size", fmt.Sprintf("?pageSize=%d", APIMaxPageSize), false, apiPage{Page: 1, PageSize: APIMaxPageSize}},
+		{"page zero", "?page=0", true, apiPage{}},
+		{"page not a number", "?page=abc", true, apiPage{}},
+		{"page size too large", fmt.Sprintf("?pageSize=%d", APIMaxPageSize+1), true, apiPage{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var page apiPage
+			var err error
+			serveAPITest(t, "/test"+tt.query, nil, func(c *gin.Context) {
+				page, err = parseAPIPage(c)
+				c.Status(http.StatusNoContent)
+			})
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantPage, page)
+		})
+	}
+
+	t.Run("skip and bounds", func(t *testing.T) {
+		page := apiPage{Page: 3, PageSize: 10}
+		assert.Equal(t, int64(20), page.Skip())
+
+		start, end := page.Bounds(25)
+		assert.Equal(t, 20, start)
+		assert.Equal(t, 25, end)
+
+		start, end = page.Bounds(5)
+		assert.Equal(t, 5, start)
+		assert.Equal(t, 5, end)
+	})
+}
+
+func TestAPIV1Sort(t *testing.T) {
+	allowed := map[string]string{"lastName": "lastName", "hireDate": "hireDate"}
+
+	tests := []struct {
+		name    string
+		query   string
+		want    apiSort
+		wantErr bool
+	}{
+		{"default", "", apiSort{Key: "lastName", Field: "lastName", Order: 1}, false},
+		{"ascending", "?sort=hireDate", apiSort{Key: "hireDate", Field: "hireDate", Order: 1}, false},
+		{"descending", "?sort=-hireDate", apiSort{Key: "hireDate", Field: "hireDate", Order: -1}, false},
+		{"unknown field", "?sort=salary", apiSort{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sorting apiSort
+			var err error
+			serveAPITest(t, "/test"+tt.query, nil, func(c *gin.Context) {
+				sorting, err = parseAPISort(c, allowed, "lastName")
+				c.Status(http.StatusNoContent)
+			})
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, sorting)
+		})
+	}
+
+	assert.Equal(t, "-hireDate", apiSort{Key: "hireDate", Order: -1}.String())
+}
+
+func TestAPIV1DateRange(t *testing.T) {
+	var from, to time.Time
+	var err error
+	serveAPITest(t, "/test?from=2024-03-01&to=2024-03-31", nil, func(c *gin.Context) {
+		from, to, err = parseAPIDateRange(c)
+		c.Status(http.StatusNoContent)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), from)
+	assert.Equal(t, time.Date(2024, 3, 31, 23, 59, 59, 999999999, time.UTC), to)
+
+	// Zeiträume, die den Filter nur teilweise schneiden, werden mitgezählt
+	assert.True(t, overlapsRange(time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC), from, to))
+	assert.True(t, overlapsRange(time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC), time.Time{}, from, to))
+	assert.False(t, overlapsRange(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 4, 2, 0, 0, 0, 0, time.UTC), from, to))
+	assert.True(t, overlapsRange(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Time{}, time.Time{}, time.Time{}))
+
+	serveAPITest(t, "/test?from=01.03.2024", nil, func(c *gin.Context) {
+		_, _, err = parseAPIDateRange(c)
+		c.Status(http.StatusNoContent)
+	})
+	assert.Error(t, err)
+}
+
+func TestAPIV1Envelope(t *testing.T) {
+	t.Run("list with meta", func(t *testing.T) {
+		w, response := serveAPITest(t, "/test", nil, func(c *gin.Context) {
+			respondAPIList(c, []string{"a", "b"}, apiPage{Page: 2, PageSize: 2}, 5, apiSort{Key: "lastName", Order: -1})
+		})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.True(t, response.Success)
+		assert.JSONEq(t, `["a","b"]`, string(response.Data))
+		require.NotNil(t, response.Meta)
+		assert.Equal(t, APIMeta{Page: 2, PageSize: 2, Total: 5, TotalPages: 3, Sort: "-lastName"}, *response.Meta)
+	})
+
+	t.Run("no content", func(t *testing.T) {
+		w, _ := serveAPITest(t, "/test", nil, func(c *gin.Context) {
+			respondAPI(c, http.StatusNoContent, nil)
+		})
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Zero(t, w.Body.Len())
+	})
+
+	t.Run("validation error with fields", func(t *testing.T) {
+		w, response := serveAPITest(t, "/test", nil, func(c *gin.Context) {
+			respondAPIValidation(c, "Ungültige Daten", map[string]string{"email": "ist erforderlich"})
+		})
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.False(t, response.Success)
+		require.NotNil(t, response.Error)
+		assert.Equal(t, APIErrorValidation, response.Error.Code)
+		assert.Equal(t, "ist erforderlich", response.Error.Fields["email"])
+	})
+}
+
+func TestAPIV1RepositoryErrors(t *testing.T) {
+	tests := []struct {
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{repository.ErrEmployeeNotFound, http.StatusNotFound, APIErrorNotFound},
+		{fmt.Errorf("%w: abc", repository.ErrInvalidID), http.StatusBadRequest, APIErrorBadRequest},
+		{repository.ErrEmailTaken, http.StatusConflict, APIErrorConflict},
+		{fmt.Errorf("%w: current status is approved", repository.ErrAlreadyProcessed), http.StatusConflict, APIErrorConflict},
+		{fmt.Errorf("%w: first name", repository.ErrInvalidEmployeeData), http.StatusUnprocessableEntity, APIErrorValidation},
+		{errAPIForbidden, http.StatusForbidden, APIErrorForbidden},
+		{fmt.Errorf("connection refused"), http.StatusInternalServerError, APIErrorInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			w, response := serveAPITest(t, "/test", nil, func(c *gin.Context) {
+				respondAPIRepositoryError(c, tt.err)
+			})
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			require.NotNil(t, response.Error)
+			assert.Equal(t, tt.wantCode, response.Error.Code)
+		})
+	}
+}
+
+func TestAPIV1RoleMiddlewares(t *testing.T) {
+	h := &APIV1Handler{}
+	ok := func(c *gin.Context) { respondAPI(c, http.StatusOK, apiCurrentUser(c).Role) }
+
+	t.Run("require roles", func(t *testing.T) {
+		w, _ := serveAPITest(t, "/test", &model.User{Role: model.RoleHR}, h.RequireRoles(model.RoleAdmin, model.RoleHR), ok)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w, response := serveAPITest(t, "/test", &model.User{Role: model.RoleEmployee}, h.RequireRoles(model.RoleAdmin), ok)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		require.NotNil(t, response.Error)
+		assert.Equal(t, APIErrorForbidden, response.Error.Code)
+	})
+
+}
+
+func TestAPIV1EmployeeAccess(t *testing.T) {
+	ownID := primitive.NewObjectID()
+	otherID := primitive.NewObjectID()
+
+	employee := &model.User{Role: model.RoleEmployee, EmployeeID: &ownID}
+	assert.True(t, apiCanAccessEmployee(employee, ownID))
+	assert.False(t, apiCanAccessEmployee(employee, otherID))
+	assert.False(t, apiCanAccessEmployee(&model.User{Role: model.RoleEmployee}, ownID))
+	assert.True(t, apiCanAccessEmployee(&model.User{Role: model.RoleHR}, otherID))
+
+	// Gehaltsdaten sehen nur Admins und Manager
+	record := &model.Employee{ID: ownID, FirstName: "Anna", Salary: 4200}
+	assert.Nil(t, newAPIEmployee(record, apiCanViewSalary(&model.User{Role: model.RoleHR})).Financial)
+	financial := newAPIEmployee(record, apiCanViewSalary(&model.User{Role: model.RoleManager})).Financial
+	require.NotNil(t, financial)
+	assert.Equal(t, 4200.0, financial.Salary)
+}
+
+func TestAPIV1EmployeeInputApply(t *testing.T) {
+	str := func(s string) *string { return &s }
+	salary := 5000.0
+	days := 9
+
+	t.Run("partial update keeps other fields", func(t *testing.T) {
+		employee := &model.Employee{FirstName: "Anna", LastName: "Schmidt", Position: "Entwicklerin"}
+		input := APIEmployeeInput{Position: str("  Teamleiterin "), HireDate: str("2023-01-15")}
+
+		fields := input.Apply(employee, false)
+		assert.Empty(t, fields)
+		assert.Equal(t, "Anna", employee.FirstName)
+		assert.Equal(t, "Teamleiterin", employee.Position)
+		assert.Equal(t, time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC), employee.HireDate)
+	})
+
+	t.Run("invalid values are reported per field", func(t *testing.T) {
+		employee := &model.Employee{}
+		input := APIEmployeeInput{
+			Status:             str("retired"),
+			ManagerID:          str("not-an-id"),
+			DateOfBirth:        str("15.01.1990"),
+			WorkingDaysPerWeek: &days,
+		}
+
+		fields := input.Apply(employee, true)
+		assert.Contains(t, fields, "status")
+		assert.Contains(t, fields, "managerId")
+		assert.Contains(t, fields, "dateOfBirth")
+		assert.Contains(t, fields, "workingDaysPerWeek")
+	})
+
+	t.Run("financial data requires permission", func(t *testing.T) {
+		employee := &model.Employee{Salary: 3000}
+		input := APIEmployeeInput{Salary: &salary}
+
+		fields := input.Apply(employee, false)
+		assert.Contains(t, fields, "salary")
+		assert.Equal(t, 3000.0, employee.Salary)
+
+		fields = input.Apply(employee, true)
+		assert.Empty(t, fields)
+		assert.Equal(t, 5000.0, employee.Salary)
+	})
+}
@@ -0,0 +1,299 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// timeEntrySortFields sind die sortierbaren Felder der Zeiteintragsliste
+var timeEntrySortFields = map[string]string{
+	"date":         "date",
+	"duration":     "duration",
+	"projectName":  "projectName",
+	"employeeName": "employeeName",
+}
+
+// APITimeEntry ist die Darstellung eines Zeiteintrags in der REST-API v1
+type APITimeEntry struct {
+	ID           string    `json:"id"`
+	EmployeeID   string    `json:"employeeId"`
+	EmployeeName string    `json:"employeeName"`
+	Date         time.Time `json:"date"`
+	StartTime    time.Time `json:"startTime"`
+	EndTime      time.Time `json:"endTime"`
+	Duration     float64   `json:"duration"`
+	ProjectID    string    `json:"projectId"`
+	ProjectName  string    `json:"projectName"`
+	Activity     string    `json:"activity"`
+	WageType     string    `json:"wageType,omitempty"`
+	Description  string    `json:"description,omitempty"`
+	Source       string    `json:"source"`
+}
+
+// APIOvertime fasst den Überstundenstand eines Mitarbeiters zusammen
+type APIOvertime struct {
+	EmployeeID      string                           `json:"employeeId"`
+	OvertimeBalance float64                          `json:"overtimeBalance"`
+	Adjustments     *model.OvertimeAdjustmentSummary `json:"adjustments"`
+}
+
+// APIOvertimeAdjustmentInput beschreibt eine neue Überstunden-Anpassung
+type APIOvertimeAdjustmentInput struct {
+	Type   string  `json:"type"`
+	Hours  float64 `json:"hours"`
+	Reason string  `json:"reason"`
+}
+
+// APIOvertimeStatusInput beschreibt die Genehmigung oder Ablehnung einer Anpassung
+type APIOvertimeStatusInput struct {
+	Status string `json:"status"` // approved, rejected
+}
+
+// ListTimeEntries gibt die Zeiteinträge aller zugänglichen Mitarbeiter zurück.
+// Query: page, pageSize, sort, employeeId, department, projectId, source, from, to
+func (h *APIV1Handler) ListTimeEntries(c *gin.Context) {
+	employees, ok := h.listAccessibleEmployees(c)
+	if !ok {
+		return
+	}
+	respondTimeEntries(c, employees)
+}
+
+// ListEmployeeTimeEntries gibt die Zeiteinträge eines Mitarbeiters zurück
+func (h *APIV1Handler) ListEmployeeTimeEntries(c *gin.Context) {
+	employee, ok := h.loadEmployee(c, false)
+	if !ok {
+		return
+	}
+	respondTimeEntries(c, []*model.Employee{employee})
+}
+
+// respondTimeEntries filtert, sortiert und paginiert die Zeiteinträge der Mitarbeiter
+func respondTimeEntries(c *gin.Context, employees []*model.Employee) {
+	page, err := parseAPIPage(c)
+	if err != nil {
+		respondAPIError(c, http.StatusBadRequest, APIErrorBadRequest, err.Error())
+		return
+	}
+	sorting, err := parseAPISort(c, timeEntrySortFields, "-date")
+	if err != nil {
+		respondAPIError(c, http.StatusBadRequest, APIErrorBadRequest, err.Error())
+		return
+	}
+	from, to, err := parseAPIDateRange(c)
+	if err != nil {
+		respondAPIError(c, http.StatusBadRequest, APIErrorBadRequest, err.Error())
+		return
+	}
+	projectID := c.Query("projectId")
+	source := c.Query("source")
+
+	entries := []APITimeEntry{}
+	for _, employee := range employees {
+		for _, entry := range employee.TimeEntries {
+			if projectID != "" && entry.ProjectID != projectID {
+				continue
+			}
+			if source != "" && entry.Source != source {
+				continue
+			}
+			if !overlapsRange(entry.Date, entry.Date, from, to) {
+				continue
+			}
+			entries = append(entries, APITimeEntry{
+				ID:           entry.ID.Hex(),
+				EmployeeID:   employee.ID.Hex(),
+				EmployeeName: employee.FirstName + " " + employee.LastName,
+				Date:         entry.Date,
+				StartTime:    entry.StartTime,
+				EndTime:      entry.EndTime,
+				Duration:     entry.Duration,
+				ProjectID:    entry.ProjectID,
+				ProjectName:  entry.ProjectName,
+				Activity:     entry.Activity,
+				WageType:     entry.WageType,
+				Description:  entry.Description,
+				Source:       entry.Source,
+			})
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		var less, equal bool
+		switch sorting.Field {
+		case "duration":
+			less, equal = a.Duration < b.Duration, a.Duration == b.Duration
+		case "projectName":
+			less, equal = a.ProjectName < b.ProjectName, a.ProjectName == b.ProjectName
+		case "employeeName":
+			less, equal = a.EmployeeName < b.EmployeeName, a.EmployeeName == b.EmployeeName
+		default:
+			less, equal = a.Date.Before(b.Date), a.Date.Equal(b.Date)
+		}
+		if equal {
+			return false
+		}
+		return less == (sorting.Order > 0)
+	})
+
+	start, end := page.Bounds(len(entries))
+	respondAPIList(c, entries[start:end], page, int64(len(entries)), sorting)
+}
+
+// GetEmployeeOvertime gibt den Überstundenstand und die Summen der Anpassungen zurück
+func (h *APIV1Handler) GetEmployeeOvertime(c *gin.Context) {
+	employee, ok := h.loadEmployee(c, false)
+	if !ok {
+		return
+	}
+
+	summary, err := h.adjustmentRepo.GetSummaryByEmployee(employee.ID.Hex())
+	if err != nil {
+		respondAPIRepositoryError(c, err)
+		return
+	}
+
+	respondAPI(c, http.StatusOK, APIOvertime{
+		EmployeeID:      employee.ID.Hex(),
+		OvertimeBalance: employee.OvertimeBalance,
+		Adjustments:     summary,
+	})
+}
+
+// ListEmployeeOvertimeAdjustments gibt die Überstunden-Anpassungen eines Mitarbeiters zurück (neueste zuerst)
+func (h *APIV1Handler) ListEmployeeOvertimeAdjustments(c *gin.Context) {
+	employee, ok := h.loadEmployee(c, false)
+	if !ok {
+		return
+	}
+
+	page, err := parseAPIPage(c)
+	if err != nil {
+		respondAPIError(c, http.StatusBadRequest, APIErrorBadRequest, err.Error())
+		return
+	}
+
+	adjustments, total, err := h.adjustmentRepo.FindByEmployeeID(employee.ID.Hex(), page.Skip(), int64(page.PageSize))
+	if err != nil {
+		respondAPIRepositoryError(c, err)
+		return
+	}
+	if adjustments == nil {
+		adjustments = []*model.OvertimeAdjustment{}
+	}
+
+	respondAPIList(c, adjustments, page, total, apiSort{Key: "createdAt", Order: -1})
+}
+
+// ListPendingOvertimeAdjustments gibt alle offenen Überstunden-Anpassungen zurück (Admins und Manager)
+func (h *APIV1Handler) ListPendingOvertimeAdjustments(c *gin.Context) {
+	page, err := parseAPIPage(c)
+	if err != nil {
+		respondAPIError(c, http.StatusBadRequest, APIErrorBadRequest, err.Error())
+		return
+	}
+
+	adjustments, total, err := h.adjustmentRepo.FindPending(page.Skip(), int64(page.PageSize))
+	if err != nil {
+		respondAPIRepositoryError(c, err)
+		return
+	}
+	if adjustments == nil {
+		adjustments = []*model.OvertimeAdjustment{}
+	}
+
+	respondAPIList(c, adjustments, page, total, apiSort{Key: "createdAt", Order: 1})
+}
+
+// CreateOvertimeAdjustment reicht eine Überstunden-Anpassung zur Genehmigung ein (Admins, Manager und HR)
+func (h *APIV1Handler) CreateOvertimeAdjustment(c *gin.Context) {
+	user := apiCurrentUser(c)
+
+	employee, ok := h.loadEmployee(c, true)
+	if !ok {
+		return
+	}
+
+	var input APIOvertimeAdjustmentInput
+	if !bindAPIJSON(c, &input) {
+		return
+	}
+
+	fields := map[string]string{}
+	if !model.OvertimeAdjustmentType(input.Type).IsValid() {
+		fields["type"] = "muss manual, correction, carryover oder payout sein"
+	}
+	if input.Hours == 0 {
+		fields["hours"] = "darf nicht 0 sein"
+	}
+	if strings.TrimSpace(input.Reason) == "" {
+		fields["reason"] = "ist erforderlich"
+	}
+	if len(fields) > 0 {
+		respondAPIValidation(c, "Ungültige Überstunden-Anpassung", fields)
+		return
+	}
+
+	adjustment := &model.OvertimeAdjustment{
+		EmployeeID:   employee.ID,
+		Type:         model.OvertimeAdjustmentType(input.Type),
+		Hours:        input.Hours,
+		Reason:       strings.TrimSpace(input.Reason),
+		AdjustedBy:   user.ID,
+		AdjusterName: user.FirstName + " " + user.LastName,
+		Status:       repository.StatusPending,
+	}
+	if err := h.adjustmentRepo.Create(adjustment); err != nil {
+		respondAPIRepositoryError(c, err)
+		return
+	}
+
+	logAPIEmployeeActivity(user, employee, model.ActivityTypeOvertimeAdjusted,
+		fmt.Sprintf("Überstunden-Anpassung über die API eingereicht: %.2f Stunden", adjustment.Hours))
+
+	respondAPI(c, http.StatusCreated, adjustment)
+}
+
+// UpdateOvertimeAdjustmentStatus genehmigt oder lehnt eine offene Anpassung ab (Admins und Manager)
+func (h *APIV1Handler) UpdateOvertimeAdjustmentStatus(c *gin.Context) {
+	user := apiCurrentUser(c)
+	adjustmentID := c.Param("adjustmentId")
+
+	var input APIOvertimeStatusInput
+	if !bindAPIJSON(c, &input) {
+		return
+	}
+	if input.Status != repository.StatusApproved && input.Status != repository.StatusRejected {
+		respondAPIValidation(c, "Ungültiger Status", map[string]string{
+			"status": "muss approved oder rejected sein",
+		})
+		return
+	}
+
+	if err := h.adjustmentRepo.UpdateStatus(adjustmentID, input.Status, user.ID, user.FirstName+" "+user.LastName); err != nil {
+		respondAPIRepositoryError(c, err)
+		return
+	}
+
+	adjustment, err := h.adjustmentRepo.FindByID(adjustmentID)
+	if err != nil {
+		respondAPIRepositoryError(c, err)
+		return
+	}
+
+	if employee, err := h.employeeRepo.FindByID(adjustment.EmployeeID.Hex()); err == nil {
+		logAPIEmployeeActivity(user, employee, model.ActivityTypeOvertimeAdjusted,
+			fmt.Sprintf("Überstunden-Anpassung über die API %s: %.2f Stunden", input.Status, adjustment.Hours))
+	}
+
+	respondAPI(c, http.StatusOK, adjustment)
+}
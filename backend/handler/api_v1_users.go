@@ -0,0 +1,281 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+	"PeopleFlow/backend/service"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// userSortFields sind die sortierbaren Felder der Benutzerliste
+var userSortFields = map[string]string{
+	"lastName":  "lastName",
+	"firstName": "firstName",
+	"email":     "email",
+	"role":      "role",
+	"status":    "status",
+	"lastLogin": "lastLogin",
+	"createdAt": "createdAt",
+}
+
+// APIUser ist die Darstellung eines Benutzers in der REST-API v1
+type APIUser struct {
+	ID               string     `json:"id"`
+	FirstName        string     `json:"firstName"`
+	LastName         string     `json:"lastName"`
+	Email            string     `json:"email"`
+	Role             string     `json:"role"`
+	Status           string     `json:"status"`
+	EmployeeID       string     `json:"employeeId,omitempty"`
+	TwoFactorEnabled bool       `json:"twoFactorEnabled"`
+	LastLogin        *time.Time `json:"lastLogin,omitempty"`
+	CreatedAt        time.Time  `json:"createdAt"`
+	UpdatedAt        time.Time  `json:"updatedAt"`
+}
+
+// APIUserInput beschreibt die änderbaren Felder eines Benutzers
+type APIUserInput struct {
+	FirstName  *string `json:"firstName"`
+	LastName   *string `json:"lastName"`
+	Email      *string `json:"email"`
+	Password   *string `json:"password"` // Nur beim Anlegen
+	Role       *string `json:"role"`
+	Status     *string `json:"status"`
+	EmployeeID *string `json:"employeeId"`
+}
+
+// newAPIUser wandelt einen Benutzer in die API-Darstellung um
+func newAPIUser(user *model.User) APIUser {
+	result := APIUser{
+		ID:               user.ID.Hex(),
+		FirstName:        user.FirstName,
+		LastName:         user.LastName,
+		Email:            user.Email,
+		Role:             string(user.Role),
+		Status:           string(user.Status),
+		TwoFactorEnabled: user.TwoFactorEnabled,
+		LastLogin:        user.LastLogin,
+		CreatedAt:        user.CreatedAt,
+		UpdatedAt:        user.UpdatedAt,
+	}
+	if user.EmployeeID != nil {
+		result.EmployeeID = user.EmployeeID.Hex()
+	}
+	return result
+}
+
+// Apply überträgt die gesetzten Felder auf den Benutzer und gibt Feldfehler zurück
+func (in *APIUserInput) Apply(user *model.User) map[string]string {
+	fields := map[string]string{}
+
+	if in.FirstName != nil {
+		user.FirstName = strings.TrimSpace(*in.FirstName)
+	}
+	if in.LastName != nil {
+		user.LastName = strings.TrimSpace(*in.LastName)
+	}
+	if in.Email != nil {
+		user.Email = strings.TrimSpace(*in.Email)
+	}
+	if in.Role != nil {
+		user.Role = model.UserRole(*in.Role)
+		if err := user.ValidateRole(); err != nil {
+			fields["role"] = "muss admin, manager, hr oder employee sein"
+		}
+	}
+	if in.Status != nil {
+		user.Status = model.UserStatus(*in.Status)
+		if err := user.ValidateStatus(); err != nil {
+			fields["status"] = "ist kein gültiger Status"
+		}
+	}
+	if in.EmployeeID != nil {
+		if *in.EmployeeID == "" {
+			user.EmployeeID = nil
+		} else if employeeID, err := primitive.ObjectIDFromHex(*in.EmployeeID); err == nil {
+			user.EmployeeID = &employeeID
+		} else {
+			fields["employeeId"] = "ist keine gültige ID"
+		}
+	}
+
+	return fields
+}
+
+// GetCurrentUser gibt den authentifizierten Benutzer zurück
+func (h *APIV1Handler) GetCurrentUser(c *gin.Context) {
+	respondAPI(c, http.StatusOK, newAPIUser(apiCurrentUser(c)))
+}
+
+// ListUsers gibt Benutzer mit Filterung, Sortierung und Pagination zurück.
+// Query: page, pageSize, sort, q, role, status
+func (h *APIV1Handler) ListUsers(c *gin.Context) {
+	page, err := parseAPIPage(c)
+	if err != nil {
+		respondAPIError(c, http.StatusBadRequest, APIErrorBadRequest, err.Error())
+		return
+	}
+	sort, err := parseAPISort(c, userSortFields, "-createdAt")
+	if err != nil {
+		respondAPIError(c, http.StatusBadRequest, APIErrorBadRequest, err.Error())
+		return
+	}
+
+	users, total, err := h.userRepo.Search(repository.UserQuery{
+		Search:    c.Query("q"),
+		Role:      c.Query("role"),
+		Status:    c.Query("status"),
+		Skip:      page.Skip(),
+		Limit:     int64(page.PageSize),
+		SortBy:    sort.Field,
+		SortOrder: sort.Order,
+	})
+	if err != nil {
+		respondAPIRepositoryError(c, err)
+		return
+	}
+
+	result := make([]APIUser, 0, len(users))
+	for _, user := range users {
+		result = append(result, newAPIUser(user))
+	}
+
+	respondAPIList(c, result, page, total, sort)
+}
+
+// GetUser gibt einen einzelnen Benutzer zurück
+func (h *APIV1Handler) GetUser(c *gin.Context) {
+	user, err := h.userRepo.FindByID(c.Param("id"))
+	if err != nil {
+		respondAPIRepositoryError(c, err)
+		return
+	}
+	respondAPI(c, http.StatusOK, newAPIUser(user))
+}
+
+// CreateUser legt einen neuen Benutzer an (nur für Admins)
+func (h *APIV1Handler) CreateUser(c *gin.Context) {
+	currentUser := apiCurrentUser(c)
+
+	var input APIUserInput
+	if !bindAPIJSON(c, &input) {
+		return
+	}
+
+	user := &model.User{Role: model.RoleEmployee, Status: model.StatusActive}
+	fields := input.Apply(user)
+	if input.Password == nil || *input.Password == "" {
+		fields["password"] = "ist erforderlich"
+	} else {
+		user.Password = *input.Password
+		if err := h.userRepo.CheckPasswordPolicy(user, user.Password); err != nil {
+			fields["password"] = err.Error()
+		}
+	}
+	if len(fields) > 0 {
+		respondAPIValidation(c, "Ungültige Benutzerdaten", fields)
+		return
+	}
+
+	if err := h.userRepo.Create(user); err != nil {
+		respondAPIRepositoryError(c, err)
+		return
+	}
+
+	logAPIUserActivity(currentUser, user, model.ActivityTypeUserAdded, "Benutzer über die API angelegt")
+
+	c.Header("Location", "/api/v1/users/"+user.ID.Hex())
+	respondAPI(c, http.StatusCreated, newAPIUser(user))
+}
+
+// UpdateUser aktualisiert die übergebenen Felder eines Benutzers (nur für Admins)
+func (h *APIV1Handler) UpdateUser(c *gin.Context) {
+	currentUser := apiCurrentUser(c)
+
+	user, err := h.userRepo.FindByID(c.Param("id"))
+	if err != nil {
+		respondAPIRepositoryError(c, err)
+		return
+	}
+
+	var input APIUserInput
+	if !bindAPIJSON(c, &input) {
+		return
+	}
+	if input.Password != nil {
+		respondAPIValidation(c, "Ungültige Benutzerdaten", map[string]string{
+			"password": "kann über die API nicht geändert werden",
+		})
+		return
+	}
+
+	previousEmployeeID := user.EmployeeID
+	if fields := input.Apply(user); len(fields) > 0 {
+		respondAPIValidation(c, "Ungültige Benutzerdaten", fields)
+		return
+	}
+
+	if err := h.userRepo.Update(user); err != nil {
+		respondAPIRepositoryError(c, err)
+		return
+	}
+	if user.EmployeeID != nil && (previousEmployeeID == nil || *previousEmployeeID != *user.EmployeeID) {
+		if err := h.userRepo.LinkEmployee(user.ID.Hex(), *user.EmployeeID); err != nil {
+			respondAPIRepositoryError(c, err)
+			return
+		}
+	}
+
+	logAPIUserActivity(currentUser, user, model.ActivityTypeUserUpdated, "Benutzer über die API aktualisiert")
+
+	respondAPI(c, http.StatusOK, newAPIUser(user))
+}
+
+// DeleteUser deaktiviert einen Benutzer und widerruft seine API-Tokens (nur für Admins)
+func (h *APIV1Handler) DeleteUser(c *gin.Context) {
+	currentUser := apiCurrentUser(c)
+
+	user, err := h.userRepo.FindByID(c.Param("id"))
+	if err != nil {
+		respondAPIRepositoryError(c, err)
+		return
+	}
+	if user.ID == currentUser.ID {
+		respondAPIError(c, http.StatusConflict, APIErrorConflict, "Sie können Ihr eigenes Konto nicht löschen")
+		return
+	}
+
+	if err := h.userRepo.Delete(user.ID.Hex()); err != nil {
+		respondAPIRepositoryError(c, err)
+		return
+	}
+
+	if err := service.NewAPITokenService().RevokeAllForUser(user.ID, currentUser.FirstName+" "+currentUser.LastName); err != nil {
+		log.Printf("Fehler beim Widerrufen der API-Tokens von Benutzer %s: %v", user.Email, err)
+	}
+
+	logAPIUserActivity(currentUser, user, model.ActivityTypeUserDeleted, "Benutzer über die API gelöscht")
+
+	respondAPI(c, http.StatusNoContent, nil)
+}
+
+// logAPIUserActivity protokolliert Änderungen an Benutzern über die API
+func logAPIUserActivity(currentUser, user *model.User, activityType model.ActivityType, description string) {
+	activityRepo := repository.NewActivityRepository()
+	_, _ = activityRepo.LogActivity(
+		activityType,
+		currentUser.ID,
+		currentUser.FirstName+" "+currentUser.LastName,
+		user.ID,
+		"user",
+		user.FirstName+" "+user.LastName,
+		description,
+	)
+}
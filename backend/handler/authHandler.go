@@ -71,6 +71,15 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
+	// Eingeladene Benutzer müssen ihr Konto zuerst über den Aktivierungslink aktivieren
+	if user.IsInvited() {
+		c.HTML(http.StatusOK, "login.html", gin.H{
+			"error": "Ihr Konto ist noch nicht aktiviert. Bitte verwenden Sie den Link aus Ihrer Einladungs-E-Mail.",
+			"year":  time.Now().Year(),
+		})
+		return
+	}
+
 	// Überprüfen, ob der Benutzer aktiv ist
 	if user.Status != model.StatusActive {
 		c.HTML(http.StatusOK, "login.html", gin.H{
@@ -80,6 +89,31 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
+	// Zwei-Faktor-Authentifizierung prüfen
+	if user.TwoFactorEnabled {
+		otp := c.PostForm("otp")
+		if otp == "" {
+			c.HTML(http.StatusOK, "login.html", gin.H{
+				"otpRequired": true,
+				"loginEmail":  email,
+				"error":       "Bitte geben Sie Ihr Passwort und den Bestätigungscode aus Ihrer Authenticator-App ein",
+				"year":        time.Now().Year(),
+			})
+			return
+		}
+
+		secret, err := utils.DecryptString(user.TwoFactorSecret)
+		if err != nil || !utils.ValidateTOTP(secret, otp) {
+			c.HTML(http.StatusOK, "login.html", gin.H{
+				"otpRequired": true,
+				"loginEmail":  email,
+				"error":       "Ungültiger Bestätigungscode",
+				"year":        time.Now().Year(),
+			})
+			return
+		}
+	}
+
 	// JWT-Token generieren
 	token, err := utils.GenerateJWT(user.ID.Hex(), string(user.Role))
 	if err != nil {
@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"net/http"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+	"PeopleFlow/backend/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ChatChannelHandler verwaltet Slack- und Teams-Kanäle für Benachrichtigungen (nur für Admins)
+type ChatChannelHandler struct {
+	chatService *service.ChatNotificationService
+}
+
+// NewChatChannelHandler erstellt einen neuen ChatChannelHandler
+func NewChatChannelHandler() *ChatChannelHandler {
+	return &ChatChannelHandler{
+		chatService: service.NewChatNotificationService(),
+	}
+}
+
+// ListEvents gibt alle abonnierbaren Ereignisse zurück
+func (h *ChatChannelHandler) ListEvents(c *gin.Context) {
+	events := make([]gin.H, 0)
+	for _, event := range model.ChatEvents() {
+		events = append(events, gin.H{
+			"type":  event,
+			"label": event.GetLabel(),
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    events,
+	})
+}
+
+// ListChannels gibt alle konfigurierten Kanäle zurück
+func (h *ChatChannelHandler) ListChannels(c *gin.Context) {
+	channels, err := h.chatService.ListChannels()
+	if err != nil {
+		chatChannelErrors.respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    channels,
+	})
+}
+
+// CreateChannel legt einen neuen Kanal an
+func (h *ChatChannelHandler) CreateChannel(c *gin.Context) {
+	user := currentWebhookUser(c)
+
+	channel, err := h.chatService.CreateChannel(user, chatChannelInput(c))
+	if err != nil {
+		chatChannelErrors.respond(c, err)
+		return
+	}
+
+	logChatChannelActivity(user, channel, "Chat-Kanal \""+channel.Name+"\" hinzugefügt")
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Chat-Kanal hinzugefügt",
+		"data":    channel,
+	})
+}
+
+// UpdateChannel ändert einen Kanal; eine leere Webhook-URL behält die bisherige
+func (h *ChatChannelHandler) UpdateChannel(c *gin.Context) {
+	user := currentWebhookUser(c)
+
+	channel, err := h.chatService.UpdateChannel(c.Param("id"), chatChannelInput(c))
+	if err != nil {
+		chatChannelErrors.respond(c, err)
+		return
+	}
+
+	logChatChannelActivity(user, channel, "Chat-Kanal \""+channel.Name+"\" aktualisiert")
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Chat-Kanal aktualisiert",
+		"data":    channel,
+	})
+}
+
+// DeleteChannel löscht einen Kanal
+func (h *ChatChannelHandler) DeleteChannel(c *gin.Context) {
+	user := currentWebhookUser(c)
+
+	channel, err := h.chatService.DeleteChannel(c.Param("id"))
+	if err != nil {
+		chatChannelErrors.respond(c, err)
+		return
+	}
+
+	logChatChannelActivity(user, channel, "Chat-Kanal \""+channel.Name+"\" gelöscht")
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Chat-Kanal gelöscht",
+	})
+}
+
+// SendTest schickt eine Testnachricht an einen Kanal
+func (h *ChatChannelHandler) SendTest(c *gin.Context) {
+	if _, err := h.chatService.SendTestMessage(c.Param("id")); err != nil {
+		chatChannelErrors.respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Testnachricht gesendet",
+	})
+}
+
+// chatChannelInput liest die Formularfelder eines Kanals
+func chatChannelInput(c *gin.Context) service.ChatChannelInput {
+	return service.ChatChannelInput{
+		Name:        c.PostForm("name"),
+		Provider:    c.PostForm("provider"),
+		WebhookURL:  c.PostForm("webhookUrl"),
+		Events:      c.PostFormArray("events"),
+		Departments: c.PostFormArray("departments"),
+		Active:      c.PostForm("active") == "true" || c.PostForm("active") == "on",
+	}
+}
+
+// chatChannelErrors übersetzt Fehler der Chat-Kanäle in JSON-Antworten
+var chatChannelErrors = errorResponse{
+	fallback: "Fehler bei der Verwaltung der Chat-Kanäle",
+	mappings: []errorMapping{
+		{errs: []error{repository.ErrChatChannelNotFound, repository.ErrInvalidID}, status: http.StatusNotFound, message: "Chat-Kanal nicht gefunden"},
+		{errs: []error{model.ErrChatChannelNameRequired}, status: http.StatusBadRequest, message: "Bitte geben Sie einen Namen für den Kanal ein"},
+		{errs: []error{model.ErrInvalidChatProvider}, status: http.StatusBadRequest, message: "Bitte wählen Sie Slack oder Microsoft Teams"},
+		{errs: []error{model.ErrInvalidChatWebhookURL}, status: http.StatusBadRequest, message: "Bitte geben Sie eine gültige https-Webhook-URL ein"},
+		{errs: []error{model.ErrInvalidChatEvent}, status: http.StatusBadRequest, message: "Unbekanntes Ereignis"},
+		{errs: []error{service.ErrChatDeliveryFailed}, status: http.StatusBadGateway, message: "Die Nachricht konnte nicht zugestellt werden: %s", detail: true},
+	},
+}
+
+// logChatChannelActivity protokolliert Änderungen an Chat-Kanälen
+func logChatChannelActivity(user *model.User, channel *model.ChatChannel, description string) {
+	activityRepo := repository.NewActivityRepository()
+	_, _ = activityRepo.LogActivity(
+		model.ActivityTypeSystemSettingChanged,
+		user.ID,
+		user.FirstName+" "+user.LastName,
+		channel.ID,
+		"chat_channel",
+		channel.Name,
+		description,
+	)
+}
@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+	"PeopleFlow/backend/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// datevExporter ist der Teil des DatevService, den der Handler benötigt
+type datevExporter interface {
+	Settings() (*model.DatevSettings, error)
+	SaveSettings(settings *model.DatevSettings) error
+	Export(month time.Time) (*model.DatevExport, *model.DatevSettings, error)
+	ParseMonth(value string) (time.Time, error)
+}
+
+// DatevHandler verwaltet die Einstellungen und den Download des DATEV-Lohnexports
+type DatevHandler struct {
+	datevService datevExporter
+}
+
+// NewDatevHandler erstellt einen neuen DatevHandler
+func NewDatevHandler() *DatevHandler {
+	return &DatevHandler{
+		datevService: service.NewDatevService(),
+	}
+}
+
+// GetSettings gibt die Einstellungen des DATEV-Exports zurück
+func (h *DatevHandler) GetSettings(c *gin.Context) {
+	settings, err := h.datevService.Settings()
+	if err != nil {
+		datevErrors.respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    settings,
+	})
+}
+
+// UpdateSettings speichert Berater- und Mandantennummer, Zielprogramm und die Zuordnung der Lohnarten
+func (h *DatevHandler) UpdateSettings(c *gin.Context) {
+	absenceWageTypes, err := model.ParseDatevWageTypeMapping(c.PostForm("absenceWageTypes"))
+	if err != nil {
+		datevErrors.respond(c, err)
+		return
+	}
+	wageTypes, err := model.ParseDatevWageTypeMapping(c.PostForm("wageTypes"))
+	if err != nil {
+		datevErrors.respond(c, err)
+		return
+	}
+
+	settings := &model.DatevSettings{
+		ConsultantNumber:       strings.TrimSpace(c.PostForm("consultantNumber")),
+		ClientNumber:           strings.TrimSpace(c.PostForm("clientNumber")),
+		Target:                 model.DatevTarget(strings.ToUpper(strings.TrimSpace(c.DefaultPostForm("target", string(model.DatevTargetLODAS))))),
+		AbsenceWageTypes:       absenceWageTypes,
+		WageTypes:              wageTypes,
+		OvertimePayoutWageType: strings.TrimSpace(c.PostForm("overtimePayoutWageType")),
+	}
+	if err := h.datevService.SaveSettings(settings); err != nil {
+		datevErrors.respond(c, err)
+		return
+	}
+
+	user, _ := c.Get("user")
+	userModel := user.(*model.User)
+
+	activityRepo := repository.NewActivityRepository()
+	_, _ = activityRepo.LogActivity(
+		model.ActivityTypeSystemSettingChanged,
+		userModel.ID,
+		userModel.FirstName+" "+userModel.LastName,
+		userModel.ID,
+		"system",
+		"DATEV-Export",
+		"Einstellungen des DATEV-Lohnexports aktualisiert",
+	)
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "DATEV-Einstellungen gespeichert",
+		"data":    settings,
+	})
+}
+
+// PreviewExport gibt die Bewegungsdaten eines Monats (?month=YYYY-MM) mit Hinweisen zurück
+func (h *DatevHandler) PreviewExport(c *gin.Context) {
+	month, err := h.datevService.ParseMonth(c.Query("month"))
+	if err != nil {
+		datevErrors.respond(c, err)
+		return
+	}
+
+	export, _, err := h.datevService.Export(month)
+	if err != nil {
+		datevErrors.respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    export,
+	})
+}
+
+// DownloadExport liefert die Importdatei eines Monats (?month=YYYY-MM) im DATEV-ASCII-Format
+func (h *DatevHandler) DownloadExport(c *gin.Context) {
+	month, err := h.datevService.ParseMonth(c.Query("month"))
+	if err != nil {
+		datevErrors.respond(c, err)
+		return
+	}
+
+	export, settings, err := h.datevService.Export(month)
+	if err != nil {
+		datevErrors.respond(c, err)
+		return
+	}
+
+	user, _ := c.Get("user")
+	userModel := user.(*model.User)
+
+	activityRepo := repository.NewActivityRepository()
+	_, _ = activityRepo.LogActivity(
+		model.ActivityTypeSystemSettingChanged,
+		userModel.ID,
+		userModel.FirstName+" "+userModel.LastName,
+		userModel.ID,
+		"system",
+		"DATEV-Export",
+		"DATEV-Lohnexport für "+export.Month.Format("01/2006")+" heruntergeladen",
+	)
+
+	c.Header("Content-Description", "File Transfer")
+	c.Header("Content-Disposition", "attachment; filename="+export.FileName(settings))
+	c.Data(http.StatusOK, "text/plain; charset=utf-8", export.ASCII(settings))
+}
+
+// datevErrors übersetzt Fehler des DATEV-Exports in JSON-Antworten
+var datevErrors = errorResponse{
+	fallback: "Fehler beim DATEV-Export",
+	mappings: []errorMapping{
+		{errs: []error{model.ErrInvalidDatevSettings}, status: http.StatusBadRequest, message: "Ungültige DATEV-Einstellungen: %s", detail: true},
+		{errs: []error{model.ErrInvalidDatevMonth}, status: http.StatusBadRequest, message: "Der Monat muss im Format JJJJ-MM angegeben werden"},
+		{errs: []error{service.ErrDatevNotConfigured}, status: http.StatusBadRequest, message: "Bitte hinterlegen Sie zuerst Berater- und Mandantennummer"},
+	},
+}
@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"PeopleFlow/backend/middleware"
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDatevExporter liefert feste Einstellungen und merkt sich den angefragten Monat
+type fakeDatevExporter struct {
+	settings *model.DatevSettings
+	export   *model.DatevExport
+	month    time.Time
+}
+
+func (f *fakeDatevExporter) Settings() (*model.DatevSettings, error) { return f.settings, nil }
+func (f *fakeDatevExporter) SaveSettings(settings *model.DatevSettings) error {
+	f.settings = settings
+	return nil
+}
+func (f *fakeDatevExporter) Export(month time.Time) (*model.DatevExport, *model.DatevSettings, error) {
+	f.month = month
+	if !f.settings.IsConfigured() {
+		return nil, nil, service.ErrDatevNotConfigured
+	}
+	return f.export, f.settings, nil
+}
+func (f *fakeDatevExporter) ParseMonth(value string) (time.Time, error) {
+	return model.ParseDatevMonth(value, time.Date(2025, time.April, 15, 0, 0, 0, 0, time.UTC))
+}
+
+// newDatevTestRouter registriert die Routen mit denselben Rollen wie der Router
+func newDatevTestRouter(exporter *fakeDatevExporter, role model.UserRole) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	h := &DatevHandler{datevService: exporter}
+
+	router := gin.New()
+	// RoleMiddleware zeigt Browsern die Fehlerseite an
+	router.SetHTMLTemplate(template.Must(template.New("error.html").Parse("{{.message}}")))
+	router.Use(func(c *gin.Context) {
+		c.Set("user", &model.User{Role: role})
+		c.Set("userRole", string(role))
+	})
+	router.GET("/api/payroll/datev/settings", middleware.RoleMiddleware(model.RoleAdmin, model.RoleHR), h.GetSettings)
+	router.GET("/api/payroll/datev/preview", middleware.RoleMiddleware(model.RoleAdmin, model.RoleHR), h.PreviewExport)
+	return router
+}
+
+func newFakeDatevExporter() *fakeDatevExporter {
+	settings := model.DefaultDatevSettings()
+	settings.ConsultantNumber = "1234567"
+	settings.ClientNumber = "12345"
+	return &fakeDatevExporter{
+		settings: settings,
+		export: &model.DatevExport{
+			Month:     time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC),
+			Movements: []model.DatevMovement{{PersonnelNumber: "00001", WageType: "1000", Unit: model.DatevUnitDays, Value: 2}},
+		},
+	}
+}
+
+func TestDatevHandler_RequiresAdminOrHR(t *testing.T) {
+	for _, role := range []model.UserRole{model.RoleEmployee, model.RoleManager} {
+		t.Run(string(role), func(t *testing.T) {
+			exporter := newFakeDatevExporter()
+			w := httptest.NewRecorder()
+			newDatevTestRouter(exporter, role).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/payroll/datev/preview?month=2025-03", nil))
+
+			assert.Equal(t, http.StatusForbidden, w.Code)
+			assert.True(t, exporter.month.IsZero(), "der Export darf nicht erstellt werden")
+		})
+	}
+}
+
+func TestDatevHandler_PreviewExport(t *testing.T) {
+	exporter := newFakeDatevExporter()
+	w := httptest.NewRecorder()
+	newDatevTestRouter(exporter, model.RoleHR).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/payroll/datev/preview?month=2025-03", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC), exporter.month)
+
+	var response struct {
+		Success bool              `json:"success"`
+		Data    model.DatevExport `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.True(t, response.Success)
+	assert.Equal(t, exporter.export.Movements, response.Data.Movements)
+}
+
+func TestDatevHandler_PreviewExportMonth(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		wantStatus  int
+		wantMonth   time.Time
+		wantMessage string
+	}{
+		{"ohne Monat der Vormonat", "", http.StatusOK, time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC), ""},
+		{"angegebener Monat", "?month=2025-01", http.StatusOK, time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), ""},
+		{"falsches Format", "?month=03/2025", http.StatusBadRequest, time.Time{}, "JJJJ-MM"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter := newFakeDatevExporter()
+			w := httptest.NewRecorder()
+			newDatevTestRouter(exporter, model.RoleAdmin).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/payroll/datev/preview"+tt.query, nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantMonth, exporter.month)
+			assert.Contains(t, w.Body.String(), tt.wantMessage)
+		})
+	}
+}
+
+func TestDatevHandler_PreviewExportNotConfigured(t *testing.T) {
+	exporter := newFakeDatevExporter()
+	exporter.settings = model.DefaultDatevSettings()
+
+	w := httptest.NewRecorder()
+	newDatevTestRouter(exporter, model.RoleAdmin).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/payroll/datev/preview?month=2025-03", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "Berater- und Mandantennummer")
+}
+
+func TestDatevHandler_GetSettings(t *testing.T) {
+	exporter := newFakeDatevExporter()
+	w := httptest.NewRecorder()
+	newDatevTestRouter(exporter, model.RoleHR).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/payroll/datev/settings", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Data model.DatevSettings `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "1234567", response.Data.ConsultantNumber)
+	assert.Equal(t, model.DatevTargetLODAS, response.Data.Target)
+}
+
+func TestDatevErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		err         error
+		wantStatus  int
+		wantMessage string
+	}{
+		{fmt.Errorf("%w: Beraternummer muss 4 bis 7 Ziffern haben", model.ErrInvalidDatevSettings), http.StatusBadRequest, "Ungültige DATEV-Einstellungen: Beraternummer"},
+		{fmt.Errorf("%w: Lohn und Gehalt wird nicht unterstützt, bitte LODAS als Zielprogramm wählen", model.ErrInvalidDatevSettings), http.StatusBadRequest, "Lohn und Gehalt wird nicht unterstützt"},
+		{fmt.Errorf("%w: \"03/2025\"", model.ErrInvalidDatevMonth), http.StatusBadRequest, "JJJJ-MM"},
+		{service.ErrDatevNotConfigured, http.StatusBadRequest, "Berater- und Mandantennummer"},
+		{assert.AnError, http.StatusInternalServerError, "Fehler beim DATEV-Export"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			datevErrors.respond(c, tt.err)
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantMessage)
+		})
+	}
+}
@@ -45,6 +45,17 @@ func (h *DocumentHandler) UploadDocument(c *gin.Context) {
 	// Related ID, falls relevant (z.B. für Training, Evaluation, Absence)
 	relatedID := c.PostForm("relatedId")
 
+	// Optionales Ablaufdatum (z.B. Arbeitserlaubnis, Zertifikat)
+	var expiresAt *time.Time
+	if value := c.PostForm("expiresAt"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiges Ablaufdatum"})
+			return
+		}
+		expiresAt = &parsed
+	}
+
 	// Benutzer aus dem Kontext abrufen
 	user, exists := c.Get("user")
 	if !exists {
@@ -73,6 +84,7 @@ func (h *DocumentHandler) UploadDocument(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Hochladen der Datei: " + err.Error()})
 		return
 	}
+	document.ExpiresAt = expiresAt
 
 	// Basierend auf der Kategorie das Dokument dem richtigen Bereich des Mitarbeiters hinzufügen
 	switch category {
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+	"PeopleFlow/backend/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// emailOutboxPageSize ist die Anzahl der E-Mails pro Seite im Postausgang
+const emailOutboxPageSize = 50
+
+// EmailOutboxHandler zeigt den E-Mail-Postausgang an (nur für Admins)
+type EmailOutboxHandler struct {
+	emailService *service.EmailService
+}
+
+// NewEmailOutboxHandler erstellt einen neuen EmailOutboxHandler
+func NewEmailOutboxHandler() *EmailOutboxHandler {
+	return &EmailOutboxHandler{
+		emailService: service.NewEmailService(),
+	}
+}
+
+// ListEmails gibt eine Seite des Postausgangs zurück, optional gefiltert nach Status
+func (h *EmailOutboxHandler) ListEmails(c *gin.Context) {
+	status := model.OutboxEmailStatus(c.Query("status"))
+	if status != "" && !status.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Unbekannter Status",
+		})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+
+	result, err := h.emailService.ListOutbox(status, int64((page-1)*emailOutboxPageSize), emailOutboxPageSize)
+	if err != nil {
+		emailOutboxErrors.respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result.Emails,
+		"counts":  result.Counts,
+		"page":    page,
+		"total":   result.Total,
+	})
+}
+
+// GetEmail gibt eine E-Mail samt Inhalt zurück
+func (h *EmailOutboxHandler) GetEmail(c *gin.Context) {
+	email, err := h.emailService.GetOutboxEmail(c.Param("id"))
+	if err != nil {
+		emailOutboxErrors.respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    email,
+	})
+}
+
+// RetryEmail sendet eine fehlgeschlagene oder wartende E-Mail sofort erneut
+func (h *EmailOutboxHandler) RetryEmail(c *gin.Context) {
+	user := currentWebhookUser(c)
+
+	email, err := h.emailService.RetryOutboxEmail(c.Param("id"))
+	if err != nil {
+		emailOutboxErrors.respond(c, err)
+		return
+	}
+
+	activityRepo := repository.NewActivityRepository()
+	_, _ = activityRepo.LogActivity(
+		model.ActivityTypeSystemSettingChanged,
+		user.ID,
+		user.FirstName+" "+user.LastName,
+		email.ID,
+		"email",
+		email.To,
+		"E-Mail \""+email.Subject+"\" an "+email.To+" erneut gesendet",
+	)
+
+	message := "E-Mail gesendet"
+	if email.Status != model.OutboxEmailSent {
+		message = "Versand fehlgeschlagen, die E-Mail wird erneut versucht: " + email.Error
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": message,
+		"data":    email,
+	})
+}
+
+// emailOutboxErrors übersetzt Fehler des Postausgangs in JSON-Antworten
+var emailOutboxErrors = errorResponse{
+	fallback: "Fehler beim Laden des Postausgangs",
+	mappings: []errorMapping{
+		{errs: []error{repository.ErrOutboxEmailNotFound, repository.ErrInvalidID}, status: http.StatusNotFound, message: "E-Mail nicht gefunden"},
+		{errs: []error{service.ErrOutboxEmailAlreadySent}, status: http.StatusConflict, message: "Die E-Mail wurde bereits gesendet"},
+		{errs: []error{service.ErrOutboxEmailRedacted}, status: http.StatusConflict, message: "Der Inhalt wurde entfernt, da er einen persönlichen Link enthielt. Bitte einen neuen Link anfordern."},
+	},
+}
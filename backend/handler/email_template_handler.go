@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"net/http"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+	"PeopleFlow/backend/service"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// EmailTemplateHandler verwaltet die E-Mail-Vorlagen (nur für Admins)
+type EmailTemplateHandler struct {
+	emailService *service.EmailService
+}
+
+// NewEmailTemplateHandler erstellt einen neuen EmailTemplateHandler
+func NewEmailTemplateHandler() *EmailTemplateHandler {
+	return &EmailTemplateHandler{
+		emailService: service.NewEmailService(),
+	}
+}
+
+// ListTemplates gibt alle Vorlagen je Sprache mit ihrem Anpassungsstatus zurück
+func (h *EmailTemplateHandler) ListTemplates(c *gin.Context) {
+	templates, err := h.emailService.ListTemplates()
+	if err != nil {
+		emailTemplateErrors.respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    templates,
+	})
+}
+
+// GetTemplate gibt eine Vorlage samt Standardfassung und verfügbaren Variablen zurück
+func (h *EmailTemplateHandler) GetTemplate(c *gin.Context) {
+	key := model.EmailTemplateKey(c.Param("key"))
+	language := c.Param("lang")
+
+	emailTemplate, customized, err := h.emailService.GetTemplate(key, language)
+	if err != nil {
+		emailTemplateErrors.respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"key":        key,
+			"label":      key.GetLabel(),
+			"language":   language,
+			"subject":    emailTemplate.Subject,
+			"body":       emailTemplate.Body,
+			"customized": customized,
+			"default":    h.emailService.DefaultTemplate(key, language),
+			"variables":  key.Variables(),
+		},
+	})
+}
+
+// SaveTemplate speichert eine angepasste Vorlage
+func (h *EmailTemplateHandler) SaveTemplate(c *gin.Context) {
+	user := currentWebhookUser(c)
+	key := model.EmailTemplateKey(c.Param("key"))
+
+	emailTemplate, err := h.emailService.SaveTemplate(user, key, c.Param("lang"), c.PostForm("subject"), c.PostForm("body"))
+	if err != nil {
+		emailTemplateErrors.respond(c, err)
+		return
+	}
+
+	logEmailTemplateActivity(user, emailTemplate.ID, key, emailTemplate.Language, "E-Mail-Vorlage \""+key.GetLabel()+"\" ("+emailTemplate.Language+") angepasst")
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "E-Mail-Vorlage gespeichert",
+		"data":    emailTemplate,
+	})
+}
+
+// ResetTemplate verwirft die Anpassung, danach gilt wieder die Standardvorlage
+func (h *EmailTemplateHandler) ResetTemplate(c *gin.Context) {
+	user := currentWebhookUser(c)
+	key := model.EmailTemplateKey(c.Param("key"))
+	language := c.Param("lang")
+
+	if _, _, err := h.emailService.GetTemplate(key, language); err != nil {
+		emailTemplateErrors.respond(c, err)
+		return
+	}
+	if err := h.emailService.ResetTemplate(key, language); err != nil {
+		emailTemplateErrors.respond(c, err)
+		return
+	}
+
+	logEmailTemplateActivity(user, primitive.NilObjectID, key, language, "E-Mail-Vorlage \""+key.GetLabel()+"\" ("+language+") zurückgesetzt")
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Standardvorlage wiederhergestellt",
+	})
+}
+
+// PreviewTemplate rendert einen Entwurf mit Beispieldaten, ohne ihn zu speichern
+func (h *EmailTemplateHandler) PreviewTemplate(c *gin.Context) {
+	key := model.EmailTemplateKey(c.Param("key"))
+
+	preview, err := h.emailService.PreviewTemplate(key, c.Param("lang"), c.PostForm("subject"), c.PostForm("body"))
+	if err != nil {
+		emailTemplateErrors.respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    preview,
+	})
+}
+
+// emailTemplateErrors übersetzt Fehler der Vorlagenverwaltung in JSON-Antworten
+var emailTemplateErrors = errorResponse{
+	fallback: "Fehler bei der Verwaltung der E-Mail-Vorlagen",
+	mappings: []errorMapping{
+		{errs: []error{model.ErrInvalidEmailTemplateKey, model.ErrInvalidEmailLanguage}, status: http.StatusNotFound, message: "E-Mail-Vorlage nicht gefunden"},
+		{errs: []error{repository.ErrEmailTemplateNotFound}, status: http.StatusNotFound, message: "Die Vorlage ist nicht angepasst"},
+		{errs: []error{model.ErrEmailTemplateSubjectRequired}, status: http.StatusBadRequest, message: "Bitte geben Sie einen Betreff ein"},
+		{errs: []error{model.ErrEmailTemplateBodyRequired}, status: http.StatusBadRequest, message: "Bitte geben Sie einen Inhalt ein"},
+		{errs: []error{model.ErrInvalidEmailTemplateSyntax}, status: http.StatusBadRequest, message: "Die Vorlage enthält einen Fehler: %s", detail: true},
+	},
+}
+
+// logEmailTemplateActivity protokolliert Änderungen an E-Mail-Vorlagen
+func logEmailTemplateActivity(user *model.User, id primitive.ObjectID, key model.EmailTemplateKey, language, description string) {
+	activityRepo := repository.NewActivityRepository()
+	_, _ = activityRepo.LogActivity(
+		model.ActivityTypeSystemSettingChanged,
+		user.ID,
+		user.FirstName+" "+user.LastName,
+		id,
+		"email_template",
+		string(key)+"/"+language,
+		description,
+	)
+}
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+	"PeopleFlow/backend/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EmployeeExportHandler exportiert die Mitarbeiterliste als CSV, XLSX oder JSON
+type EmployeeExportHandler struct {
+	employeeRepo *repository.EmployeeRepository
+}
+
+// NewEmployeeExportHandler erstellt einen neuen EmployeeExportHandler
+func NewEmployeeExportHandler() *EmployeeExportHandler {
+	return &EmployeeExportHandler{
+		employeeRepo: repository.NewEmployeeRepository(),
+	}
+}
+
+// GetColumns gibt die Spalten zurück, die der Benutzer exportieren darf
+func (h *EmployeeExportHandler) GetColumns(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    model.AvailableEmployeeExportColumns(canViewFinancial(c)),
+	})
+}
+
+// splitExportList teilt eine kommagetrennte Query-Liste und entfernt leere Einträge
+func splitExportList(value string) []string {
+	var values []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
+	}
+	return values
+}
+
+// ExportEmployees exportiert die gefilterte Mitarbeiterliste mit den gewählten Spalten.
+// Die Filter entsprechen denen der Mitarbeiterübersicht (q, status, department, sort).
+func (h *EmployeeExportHandler) ExportEmployees(c *gin.Context) {
+	format, err := model.ParseExportFormat(c.Query("format"), model.ExportFormatCSV, model.ExportFormatXLSX, model.ExportFormatJSON)
+	if err != nil {
+		employeeExportErrors.respond(c, err)
+		return
+	}
+	columns, err := model.ParseEmployeeExportColumns(c.Query("columns"), canViewFinancial(c))
+	if err != nil {
+		employeeExportErrors.respond(c, err)
+		return
+	}
+
+	employees, _, err := h.employeeRepo.Search(repository.EmployeeQuery{})
+	if err != nil {
+		employeeExportErrors.respond(c, err)
+		return
+	}
+	employees = model.FilterEmployeesForExport(employees, model.EmployeeExportFilter{
+		Search:      c.Query("q"),
+		Statuses:    splitExportList(c.Query("status")),
+		Departments: splitExportList(c.Query("department")),
+		SortBy:      c.Query("sort"),
+	})
+
+	writeEmployeeExport(c, format, columns, employees, time.Now())
+}
+
+// writeEmployeeExport schreibt die Exportdatei im gewählten Format als Download
+func writeEmployeeExport(c *gin.Context, format model.ExportFormat, columns []model.EmployeeExportColumn, employees []*model.Employee, now time.Time) {
+	var content []byte
+	var contentType string
+	var err error
+
+	switch format {
+	case model.ExportFormatJSON:
+		records := make([]map[string]interface{}, len(employees))
+		for i, employee := range employees {
+			records[i] = model.EmployeeExportRecord(employee, columns)
+		}
+		c.Header("Content-Disposition", "attachment; filename="+employeeExportFileName(format, now))
+		c.JSON(http.StatusOK, records)
+		return
+	case model.ExportFormatXLSX:
+		rows := make([][]interface{}, len(employees))
+		for i, employee := range employees {
+			rows[i] = model.EmployeeExportValues(employee, columns)
+		}
+		content, err = utils.WriteXLSX(utils.XLSXSheet{Name: "Mitarbeiter", Header: model.EmployeeExportHeader(columns), Rows: rows})
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		rows := make([][]string, len(employees))
+		for i, employee := range employees {
+			values := model.EmployeeExportValues(employee, columns)
+			rows[i] = make([]string, len(values))
+			for j, value := range values {
+				rows[i][j] = model.FormatExportValue(value)
+			}
+		}
+		content, err = utils.WriteCSV(model.EmployeeExportHeader(columns), rows)
+		contentType = "text/csv; charset=utf-8"
+	}
+	if err != nil {
+		employeeExportErrors.respond(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", "attachment; filename="+employeeExportFileName(format, now))
+	c.Data(http.StatusOK, contentType, content)
+}
+
+// employeeExportFileName gibt den Dateinamen eines Exports zurück, z.B. Mitarbeiter_2024-04-01.csv
+func employeeExportFileName(format model.ExportFormat, now time.Time) string {
+	return fmt.Sprintf("Mitarbeiter_%s.%s", now.Format("2006-01-02"), format)
+}
+
+// employeeExportErrors übersetzt Fehler des Mitarbeiterexports in JSON-Antworten
+var employeeExportErrors = errorResponse{
+	fallback: "Fehler beim Mitarbeiterexport",
+	mappings: []errorMapping{
+		{errs: []error{model.ErrInvalidExportFormat}, status: http.StatusBadRequest, message: "Unbekanntes Exportformat (erlaubt: csv, xlsx, json)"},
+		{errs: []error{model.ErrEmployeeExportColumns}, status: http.StatusBadRequest, message: "Ungültige Spaltenauswahl: %s", detail: true},
+	},
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmployeeExportErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		err         error
+		wantStatus  int
+		wantMessage string
+	}{
+		{fmt.Errorf("%w: \"pdf\"", model.ErrInvalidExportFormat), http.StatusBadRequest, "Unbekanntes Exportformat"},
+		{fmt.Errorf("%w: unbekannte Spalte \"password\"", model.ErrEmployeeExportColumns), http.StatusBadRequest, "Ungültige Spaltenauswahl: unbekannte Spalte"},
+		{assert.AnError, http.StatusInternalServerError, "Fehler beim Mitarbeiterexport"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			employeeExportErrors.respond(c, tt.err)
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantMessage)
+		})
+	}
+}
+
+func TestEmployeeExportHandler_RejectsInvalidRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := &EmployeeExportHandler{}
+
+	tests := []struct {
+		name        string
+		query       string
+		hideSalary  bool
+		wantMessage string
+	}{
+		{"unbekanntes Format", "format=pdf", false, "Unbekanntes Exportformat"},
+		{"unbekannte Spalte", "columns=lastName,password", false, "unbekannte Spalte"},
+		{"Gehalt ohne Berechtigung", "columns=lastName,salary", true, "keine Berechtigung"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/employees/export?"+tt.query, nil)
+			c.Set("hideSalary", tt.hideSalary)
+			h.ExportEmployees(c)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantMessage)
+		})
+	}
+}
+
+func TestEmployeeExportHandler_GetColumns_HidesFinancial(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := &EmployeeExportHandler{}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("hideSalary", true)
+	h.GetColumns(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"key":"overtimeBalance"`)
+	assert.NotContains(t, w.Body.String(), `"key":"bankAccount"`)
+}
+
+func TestWriteEmployeeExport(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	now := time.Date(2024, time.April, 2, 10, 0, 0, 0, time.UTC)
+	employees := []*model.Employee{
+		{EmployeeID: "1001", LastName: "Meyer; Ben", WorkingHoursPerWeek: 38.5, HireDate: time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	columns, err := model.ParseEmployeeExportColumns("employeeId,lastName,workingHoursPerWeek,hireDate", false)
+	require.NoError(t, err)
+
+	t.Run("CSV", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		writeEmployeeExport(c, model.ExportFormatCSV, columns, employees, now)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "attachment; filename=Mitarbeiter_2024-04-02.csv", w.Header().Get("Content-Disposition"))
+		assert.Equal(t, "\xef\xbb\xbfPersonalnummer;Nachname;Wochenstunden;Eintrittsdatum\r\n1001;\"Meyer; Ben\";38,5;01.04.2024\r\n", w.Body.String())
+	})
+
+	t.Run("XLSX", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		writeEmployeeExport(c, model.ExportFormatXLSX, columns, employees, now)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), "spreadsheetml")
+		rows, err := utils.ReadXLSXRows(w.Body.Bytes())
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{
+			{"Personalnummer", "Nachname", "Wochenstunden", "Eintrittsdatum"},
+			{"1001", "Meyer; Ben", "38.5", "45383"},
+		}, rows)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		writeEmployeeExport(c, model.ExportFormatJSON, columns, employees, now)
+
+		assert.Equal(t, "attachment; filename=Mitarbeiter_2024-04-02.json", w.Header().Get("Content-Disposition"))
+		var records []map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &records))
+		assert.Equal(t, []map[string]interface{}{
+			{"employeeId": "1001", "lastName": "Meyer; Ben", "workingHoursPerWeek": 38.5, "hireDate": "2024-04-01"},
+		}, records)
+	})
+}
@@ -236,6 +236,7 @@ func (h *EmployeeHandler) AddEmployee(c *gin.Context) {
 		HireDate:          hireDate,
 		Position:          position,
 		Department:        model.Department(department),
+		Location:          strings.TrimSpace(c.PostForm("location")),
 		ManagerID:         managerID,
 		Status:            model.EmployeeStatusActive,
 
@@ -495,6 +496,7 @@ func (h *EmployeeHandler) GetEmployeeDetails(c *gin.Context) {
 		"formatTimeInLocalZone":     formatTimeInLocalZone,
 		"formatDateInLocalZone":     formatDateInLocalZone,
 		"formatDateTimeInLocalZone": formatDateTimeInLocalZone,
+		"isOwnEmployee":             userModel.EmployeeID != nil && *userModel.EmployeeID == employee.ID,
 	})
 }
 
@@ -512,6 +514,7 @@ func (h *EmployeeHandler) UpdateEmployee(c *gin.Context) {
 		})
 		return
 	}
+	before := *employee
 
 	// Formulardaten abrufen und Mitarbeiter aktualisieren
 	employee.FirstName = c.PostForm("firstName")
@@ -523,6 +526,7 @@ func (h *EmployeeHandler) UpdateEmployee(c *gin.Context) {
 	employee.Address = c.PostForm("address")
 	employee.Position = c.PostForm("position")
 	employee.Department = model.Department(c.PostForm("department"))
+	employee.Location = strings.TrimSpace(c.PostForm("location"))
 	employee.Notes = c.PostForm("notes")
 
 	// Arbeitszeit-Daten aktualisieren
@@ -604,8 +608,14 @@ func (h *EmployeeHandler) UpdateEmployee(c *gin.Context) {
 	employee.EmergencyName = c.PostForm("emergencyName")
 	employee.EmergencyPhone = c.PostForm("emergencyPhone")
 
-	// UpdatedAt aktualisieren
+	currentUser, _ := c.Get("user")
+	currentUserModel := currentUser.(*model.User)
+
+	// UpdatedAt aktualisieren, geänderte synchronisierte Felder PeopleFlow zuordnen und
+	// Gehaltsänderungen in die Gehaltshistorie übernehmen
 	employee.UpdatedAt = time.Now()
+	employee.TrackFieldChanges(&before, model.FieldSourcePeopleFlow, employee.UpdatedAt)
+	employee.TrackSalaryChange(&before, currentUserModel.FirstName+" "+currentUserModel.LastName, employee.UpdatedAt)
 
 	// Mitarbeiter in der Datenbank aktualisieren
 	err = h.employeeRepo.Update(employee)
@@ -619,9 +629,6 @@ func (h *EmployeeHandler) UpdateEmployee(c *gin.Context) {
 	}
 
 	// Aktivität loggen
-	currentUser, _ := c.Get("user")
-	currentUserModel := currentUser.(*model.User)
-
 	activityRepo := repository.NewActivityRepository()
 	_, _ = activityRepo.LogActivity(
 		model.ActivityTypeEmployeeUpdated,
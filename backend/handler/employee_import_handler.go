@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+	"PeopleFlow/backend/service"
+	"PeopleFlow/backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// maxEmployeeImportSize begrenzt die Größe einer Importdatei
+const maxEmployeeImportSize = 5 << 20
+
+// EmployeeImportHandler verarbeitet den Massenimport von Mitarbeitern aus CSV- und XLSX-Dateien
+type EmployeeImportHandler struct {
+	importService *service.EmployeeImportService
+}
+
+// NewEmployeeImportHandler erstellt einen neuen EmployeeImportHandler
+func NewEmployeeImportHandler() *EmployeeImportHandler {
+	return &EmployeeImportHandler{
+		importService: service.NewEmployeeImportService(),
+	}
+}
+
+// canViewFinancial prüft, ob der Benutzer Gehalt und Bankdaten sehen und damit importieren oder exportieren darf
+func canViewFinancial(c *gin.Context) bool {
+	hideSalary, _ := c.Get("hideSalary")
+	return hideSalary != true
+}
+
+// GetFields gibt die importierbaren Felder für die Spaltenzuordnung zurück
+func (h *EmployeeImportHandler) GetFields(c *gin.Context) {
+	allowFinancial := canViewFinancial(c)
+
+	fields := make([]model.EmployeeImportField, 0, len(model.EmployeeImportFields))
+	for _, field := range model.EmployeeImportFields {
+		if field.Financial && !allowFinancial {
+			continue
+		}
+		fields = append(fields, field)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    fields,
+	})
+}
+
+// ImportEmployees prüft eine hochgeladene CSV- oder XLSX-Datei und legt Mitarbeiter an bzw. aktualisiert
+// sie anhand von Personalnummer oder E-Mail. Ohne dryRun=false wird nur ein Probelauf durchgeführt.
+func (h *EmployeeImportHandler) ImportEmployees(c *gin.Context) {
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Keine Datei hochgeladen",
+		})
+		return
+	}
+	if file.Size > maxEmployeeImportSize {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Die Datei ist zu groß (maximal 5 MB)",
+		})
+		return
+	}
+
+	reader, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Datei konnte nicht gelesen werden",
+		})
+		return
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(io.LimitReader(reader, maxEmployeeImportSize))
+	if err != nil {
+		employeeImportErrors.respond(c, err)
+		return
+	}
+
+	records, err := service.ReadEmployeeImportFile(file.Filename, content)
+	if err != nil {
+		employeeImportErrors.respond(c, err)
+		return
+	}
+	mapping, err := model.ParseEmployeeImportMapping(c.PostForm("mapping"))
+	if err != nil {
+		employeeImportErrors.respond(c, err)
+		return
+	}
+	table, err := model.NewEmployeeImportTable(records, mapping)
+	if err != nil {
+		employeeImportErrors.respond(c, err)
+		return
+	}
+
+	options := service.EmployeeImportOptions{
+		DryRun:         c.DefaultPostForm("dryRun", "true") != "false",
+		AllowFinancial: canViewFinancial(c),
+	}
+	report, err := h.importService.Import(table, options)
+	if err != nil {
+		employeeImportErrors.respond(c, err)
+		return
+	}
+
+	message := fmt.Sprintf("Probelauf: %d neu, %d geändert, %d unverändert, %d fehlerhaft",
+		report.Created, report.Updated, report.Unchanged, report.Failed)
+	if !report.DryRun {
+		logEmployeeImportActivities(c, report)
+		message = fmt.Sprintf("%d Mitarbeiter angelegt, %d aktualisiert, %d unverändert, %d Zeilen fehlerhaft",
+			report.Created, report.Updated, report.Unchanged, report.Failed)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": message,
+		"data":    report,
+	})
+}
+
+// logEmployeeImportActivities protokolliert jeden angelegten und aktualisierten Mitarbeiter
+func logEmployeeImportActivities(c *gin.Context, report *model.EmployeeImportReport) {
+	user, _ := c.Get("user")
+	userModel := user.(*model.User)
+
+	activityRepo := repository.NewActivityRepository()
+	for _, row := range report.Rows {
+		var activityType model.ActivityType
+		var description string
+		switch row.Action {
+		case model.EmployeeImportActionCreate:
+			activityType = model.ActivityTypeEmployeeAdded
+			description = "Mitarbeiter per Import angelegt"
+		case model.EmployeeImportActionUpdate:
+			activityType = model.ActivityTypeEmployeeUpdated
+			description = "Mitarbeiter per Import aktualisiert: " + strings.Join(row.Changes, ", ")
+		default:
+			continue
+		}
+
+		employeeID, err := primitive.ObjectIDFromHex(row.ID)
+		if err != nil {
+			continue
+		}
+		_, _ = activityRepo.LogActivity(
+			activityType,
+			userModel.ID,
+			userModel.FirstName+" "+userModel.LastName,
+			employeeID,
+			"employee",
+			row.Name,
+			description,
+		)
+	}
+}
+
+// employeeImportErrors übersetzt Fehler des Mitarbeiterimports in JSON-Antworten
+var employeeImportErrors = errorResponse{
+	fallback: "Fehler beim Mitarbeiterimport",
+	mappings: []errorMapping{
+		{errs: []error{model.ErrEmployeeImportFormat}, status: http.StatusBadRequest, message: "Bitte laden Sie eine CSV- oder XLSX-Datei hoch"},
+		{errs: []error{utils.ErrInvalidSpreadsheet}, status: http.StatusBadRequest, message: "Die Datei konnte nicht gelesen werden: %s", detail: true},
+		{errs: []error{model.ErrEmployeeImportMapping}, status: http.StatusBadRequest, message: "Ungültige Spaltenzuordnung: %s", detail: true},
+		{errs: []error{model.ErrEmployeeImportEmpty}, status: http.StatusBadRequest, message: "Die Datei enthält keine Mitarbeiterdaten"},
+	},
+}
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmployeeImportErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		err         error
+		wantStatus  int
+		wantMessage string
+	}{
+		{fmt.Errorf("%w: liste.xls", model.ErrEmployeeImportFormat), http.StatusBadRequest, "CSV- oder XLSX-Datei"},
+		{fmt.Errorf("%w: zip: not a valid zip file", utils.ErrInvalidSpreadsheet), http.StatusBadRequest, "nicht gelesen werden: zip"},
+		{fmt.Errorf("%w: Personalnummer oder E-Mail muss zugeordnet sein", model.ErrEmployeeImportMapping), http.StatusBadRequest, "Ungültige Spaltenzuordnung: Personalnummer"},
+		{model.ErrEmployeeImportEmpty, http.StatusBadRequest, "keine Mitarbeiterdaten"},
+		{assert.AnError, http.StatusInternalServerError, "Fehler beim Mitarbeiterimport"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			employeeImportErrors.respond(c, tt.err)
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantMessage)
+		})
+	}
+}
+
+func TestEmployeeImportHandler_GetFields_HidesFinancial(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := &EmployeeImportHandler{}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("hideSalary", true)
+	h.GetFields(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"key":"employeeId"`)
+	assert.NotContains(t, w.Body.String(), `"key":"salary"`)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Set("hideSalary", false)
+	h.GetFields(c)
+	assert.Contains(t, w.Body.String(), `"key":"salary"`)
+}
+
+func TestEmployeeImportHandler_RejectsInvalidUploads(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := &EmployeeImportHandler{}
+
+	tests := []struct {
+		name        string
+		fileName    string
+		content     string
+		mapping     string
+		wantMessage string
+	}{
+		{"ohne Datei", "", "", "", "Keine Datei hochgeladen"},
+		{"falsches Format", "liste.pdf", "%PDF", "", "CSV- oder XLSX-Datei"},
+		{"ungültige Zuordnung", "liste.csv", "PNR;Name\n1;Anna\n", "PNR=pnr", "unbekanntes Feld"},
+		{"ohne Schlüsselspalte", "liste.csv", "Vorname;Nachname\nAnna;Schmidt\n", "", "Personalnummer oder E-Mail"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body bytes.Buffer
+			writer := multipart.NewWriter(&body)
+			if tt.fileName != "" {
+				part, err := writer.CreateFormFile("file", tt.fileName)
+				require.NoError(t, err)
+				_, err = part.Write([]byte(tt.content))
+				require.NoError(t, err)
+			}
+			require.NoError(t, writer.WriteField("mapping", tt.mapping))
+			require.NoError(t, writer.Close())
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/api/employees/import", &body)
+			c.Request.Header.Set("Content-Type", writer.FormDataContentType())
+			h.ImportEmployees(c)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantMessage)
+		})
+	}
+}
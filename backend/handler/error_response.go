@@ -0,0 +1,68 @@
+// backend/handler/error_response.go
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// errorMapping ordnet bekannte Fehler einem HTTP-Status und einer Meldung zu
+type errorMapping struct {
+	errs    []error // verglichen mit errors.Is
+	status  int
+	message string
+	// detail: message ist ein Format mit einem %s für den Text hinter dem Fehler,
+	// z.B. die ungültige Spalte aus "invalid export columns: Gehalt"
+	detail bool
+}
+
+// errorResponse übersetzt die Fehler eines Bereichs in JSON-Antworten. Unbekannte Fehler
+// werden mit Status 500 und fallback als Präfix gemeldet.
+type errorResponse struct {
+	fallback string
+	// messageKey ist das Feld der Meldung in der Antwort (Standard: "error")
+	messageKey string
+	mappings   []errorMapping
+}
+
+// respond sendet die Fehlerantwort für err
+func (r errorResponse) respond(c *gin.Context, err error) {
+	status, message := r.resolve(err)
+
+	key := r.messageKey
+	if key == "" {
+		key = "error"
+	}
+	c.JSON(status, gin.H{
+		"success": false,
+		key:       message,
+	})
+}
+
+// resolve bestimmt Status und Meldung anhand der ersten passenden Zuordnung
+func (r errorResponse) resolve(err error) (int, string) {
+	for _, mapping := range r.mappings {
+		for _, target := range mapping.errs {
+			if !errors.Is(err, target) {
+				continue
+			}
+			if !mapping.detail {
+				return mapping.status, mapping.message
+			}
+			return mapping.status, fmt.Sprintf(mapping.message, strings.TrimPrefix(err.Error(), target.Error()+": "))
+		}
+	}
+	return http.StatusInternalServerError, r.fallback + ": " + err.Error()
+}
+
+// with gibt eine Kopie zurück, die zusätzlich die Zuordnungen von other kennt
+// (z.B. die Fehler der Integrationsverwaltung in untergeordneten Bereichen)
+func (r errorResponse) with(other errorResponse) errorResponse {
+	mappings := make([]errorMapping, 0, len(r.mappings)+len(other.mappings))
+	r.mappings = append(append(mappings, r.mappings...), other.mappings...)
+	return r
+}
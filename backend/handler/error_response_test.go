@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorResponse_Respond(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	errMissing := errors.New("missing")
+	errInvalid := errors.New("invalid column")
+	errOther := errors.New("other")
+	errParent := errors.New("parent")
+
+	parent := errorResponse{mappings: []errorMapping{
+		{errs: []error{errParent}, status: http.StatusNotFound, message: "Übergeordnet"},
+	}}
+	responses := errorResponse{
+		fallback: "Fehler beim Test",
+		mappings: []errorMapping{
+			{errs: []error{errMissing, errOther}, status: http.StatusNotFound, message: "Nicht gefunden"},
+			{errs: []error{errInvalid}, status: http.StatusBadRequest, message: "Ungültige Spalte: %s", detail: true},
+		},
+	}.with(parent)
+
+	tests := []struct {
+		name        string
+		err         error
+		wantStatus  int
+		wantMessage string
+	}{
+		{"erster Fehler", errMissing, http.StatusNotFound, "Nicht gefunden"},
+		{"weiterer Fehler derselben Zuordnung", fmt.Errorf("lookup: %w", errOther), http.StatusNotFound, "Nicht gefunden"},
+		{"Detail hinter dem Fehler", fmt.Errorf("%w: Gehalt", errInvalid), http.StatusBadRequest, "Ungültige Spalte: Gehalt"},
+		{"übernommene Zuordnung", errParent, http.StatusNotFound, "Übergeordnet"},
+		{"unbekannter Fehler", errors.New("boom"), http.StatusInternalServerError, "Fehler beim Test: boom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			responses.respond(c, tt.err)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			var body map[string]interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, false, body["success"])
+			assert.Equal(t, tt.wantMessage, body["error"])
+		})
+	}
+
+	// Ältere Bereiche melden den Fehler im Feld "message"
+	responses.messageKey = "message"
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	responses.respond(c, errMissing)
+	assert.JSONEq(t, `{"success":false,"message":"Nicht gefunden"}`, w.Body.String())
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"net/http"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+	"PeopleFlow/backend/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetFieldOwnership gibt die Zuständigkeiten der synchronisierten Felder einer Integration zurück
+func (h *IntegrationHandler) GetFieldOwnership(c *gin.Context) {
+	settings, err := h.fieldOwnership.Settings(c.Param("type"))
+	if err != nil {
+		fieldOwnershipErrors.respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    settings,
+	})
+}
+
+// SetFieldOwnership legt fest, welches System bei einem synchronisierten Feld Vorrang hat
+func (h *IntegrationHandler) SetFieldOwnership(c *gin.Context) {
+	ownership, err := model.ParseFieldOwnership(c.PostForm("ownership"))
+	if err != nil {
+		fieldOwnershipErrors.respond(c, err)
+		return
+	}
+
+	if err := h.fieldOwnership.SetOwnership(c.Param("type"), c.PostForm("field"), ownership, currentWebhookUser(c)); err != nil {
+		fieldOwnershipErrors.respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Zuständigkeit gespeichert: " + ownership.Label(),
+	})
+}
+
+// GetFieldConflicts gibt die Felder zurück, die seit der letzten Synchronisierung in PeopleFlow
+// und im angebundenen System geändert wurden; mit includeResolved=true auch die gelösten
+func (h *IntegrationHandler) GetFieldConflicts(c *gin.Context) {
+	conflicts, err := h.fieldOwnership.Conflicts(c.Param("type"), c.Query("includeResolved") == "true")
+	if err != nil {
+		fieldOwnershipErrors.respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    conflicts,
+	})
+}
+
+// ResolveFieldConflict schließt einen Konflikt ab und speichert den gewählten Wert
+func (h *IntegrationHandler) ResolveFieldConflict(c *gin.Context) {
+	resolution, err := model.ParseFieldOwnership(c.PostForm("resolution"))
+	if err != nil {
+		fieldOwnershipErrors.respond(c, err)
+		return
+	}
+
+	conflict, err := h.fieldOwnership.ResolveConflict(c.Param("id"), resolution, currentWebhookUser(c))
+	if err != nil {
+		fieldOwnershipErrors.respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Konflikt bei " + conflict.EmployeeName + " gelöst",
+		"data":    conflict,
+	})
+}
+
+// fieldOwnershipErrors übersetzt Fehler der Feldzuständigkeiten in JSON-Antworten
+var fieldOwnershipErrors = errorResponse{
+	fallback:   "Fehler bei den Feldzuständigkeiten",
+	messageKey: "message",
+	mappings: []errorMapping{
+		{errs: []error{model.ErrInvalidFieldOwnership}, status: http.StatusBadRequest, message: "Ungültige Zuständigkeit"},
+		{errs: []error{service.ErrSyncedFieldUnknown}, status: http.StatusBadRequest, message: "Dieses Feld wird von der Integration nicht synchronisiert"},
+		{errs: []error{repository.ErrFieldConflictNotFound, repository.ErrInvalidID}, status: http.StatusNotFound, message: "Konflikt nicht gefunden"},
+		{errs: []error{repository.ErrEmployeeNotFound}, status: http.StatusNotFound, message: "Mitarbeiter nicht gefunden"},
+		{errs: []error{repository.ErrFieldConflictResolved}, status: http.StatusConflict, message: "Der Konflikt wurde bereits gelöst"},
+	},
+}.with(integrationErrors)
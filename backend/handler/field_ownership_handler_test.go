@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+	"PeopleFlow/backend/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFieldOwnershipErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		err         error
+		wantStatus  int
+		wantMessage string
+	}{
+		{fmt.Errorf("%w: personio", service.ErrIntegrationUnknown), http.StatusNotFound, "Unbekannte Integration"},
+		{fmt.Errorf("%w: \"newest\"", model.ErrInvalidFieldOwnership), http.StatusBadRequest, "Ungültige Zuständigkeit"},
+		{fmt.Errorf("%w: salary", service.ErrSyncedFieldUnknown), http.StatusBadRequest, "nicht synchronisiert"},
+		{repository.ErrFieldConflictNotFound, http.StatusNotFound, "Konflikt nicht gefunden"},
+		{repository.ErrFieldConflictResolved, http.StatusConflict, "bereits gelöst"},
+		{assert.AnError, http.StatusInternalServerError, "Fehler bei den Feldzuständigkeiten"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			fieldOwnershipErrors.respond(c, tt.err)
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantMessage)
+		})
+	}
+}
+
+func TestFieldOwnershipRoutes_RejectInvalidOwnership(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := &IntegrationHandler{}
+	router := gin.New()
+	router.POST("/api/integrations/:type/field-ownership", h.SetFieldOwnership)
+	router.POST("/api/integrations/conflicts/:id/resolve", h.ResolveFieldConflict)
+
+	requests := []*http.Request{
+		httptest.NewRequest(http.MethodPost, "/api/integrations/timebutler/field-ownership", strings.NewReader("field=phone&ownership=newest")),
+		httptest.NewRequest(http.MethodPost, "/api/integrations/conflicts/abc/resolve", strings.NewReader("")),
+	}
+	for _, req := range requests {
+		t.Run(req.URL.Path, func(t *testing.T) {
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), "Ungültige Zuständigkeit")
+		})
+	}
+}
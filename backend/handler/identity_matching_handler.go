@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"PeopleFlow/backend/repository"
+	"PeopleFlow/backend/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetIdentityMatches gibt den Abgleich zwischen den Benutzern einer Integration und den
+// Mitarbeitern zurück: Zuordnungen, nicht zugeordnete Benutzer mit Vorschlägen und
+// Mitarbeiter ohne Benutzer
+func (h *IntegrationHandler) GetIdentityMatches(c *gin.Context) {
+	report, err := h.identityService.Report(c.Param("type"))
+	if err != nil {
+		identityMatchErrors.respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    report,
+	})
+}
+
+// ConfirmIdentityMatch ordnet einen externen Benutzer einem Mitarbeiter zu oder ersetzt
+// dessen bisherige Zuordnung
+func (h *IntegrationHandler) ConfirmIdentityMatch(c *gin.Context) {
+	externalID := strings.TrimSpace(c.PostForm("externalId"))
+	employeeID := strings.TrimSpace(c.PostForm("employeeId"))
+	if externalID == "" || employeeID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"message": "Externer Benutzer und Mitarbeiter sind erforderlich",
+		})
+		return
+	}
+
+	mapping, err := h.identityService.Confirm(c.Param("type"), externalID, employeeID, currentWebhookUser(c))
+	if err != nil {
+		identityMatchErrors.respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": mapping.ExternalName + " wurde " + mapping.EmployeeName + " zugeordnet",
+		"data":    mapping,
+	})
+}
+
+// RemoveIdentityMatch entfernt die manuelle Zuordnung eines externen Benutzers
+func (h *IntegrationHandler) RemoveIdentityMatch(c *gin.Context) {
+	if err := h.identityService.Remove(c.Param("type"), c.Param("externalId"), currentWebhookUser(c)); err != nil {
+		identityMatchErrors.respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Zuordnung entfernt",
+	})
+}
+
+// CreateEmployeeFromIdentity legt für einen nicht zugeordneten externen Benutzer einen
+// Mitarbeiter an und ordnet ihn zu
+func (h *IntegrationHandler) CreateEmployeeFromIdentity(c *gin.Context) {
+	externalID := strings.TrimSpace(c.PostForm("externalId"))
+	if externalID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"message": "Externer Benutzer ist erforderlich",
+		})
+		return
+	}
+
+	employee, err := h.identityService.CreateEmployee(c.Param("type"), externalID, currentWebhookUser(c))
+	if err != nil {
+		identityMatchErrors.respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"message": "Mitarbeiter " + employee.FirstName + " " + employee.LastName + " angelegt",
+		"data":    employee,
+	})
+}
+
+// identityMatchErrors übersetzt Fehler des Benutzerabgleichs in JSON-Antworten
+var identityMatchErrors = errorResponse{
+	fallback:   "Fehler beim Abgleich der Benutzer",
+	messageKey: "message",
+	mappings: []errorMapping{
+		{errs: []error{service.ErrIdentityMatchingUnsupported}, status: http.StatusBadRequest, message: "Diese Integration unterstützt keine Zuordnung von Benutzern"},
+		{errs: []error{service.ErrExternalIdentityNotFound}, status: http.StatusNotFound, message: "Externer Benutzer nicht gefunden"},
+		{errs: []error{repository.ErrEmployeeNotFound, repository.ErrInvalidID}, status: http.StatusNotFound, message: "Mitarbeiter nicht gefunden"},
+		{errs: []error{repository.ErrIntegrationMappingNotFound}, status: http.StatusNotFound, message: "Für diesen Benutzer gibt es keine manuelle Zuordnung"},
+		{errs: []error{service.ErrIdentityAlreadyMatched}, status: http.StatusConflict, message: "Der Benutzer ist bereits einem Mitarbeiter zugeordnet"},
+		{errs: []error{repository.ErrEmployeeIDTaken}, status: http.StatusConflict, message: "Die Personalnummer ist bereits vergeben, bitte den Benutzer einem bestehenden Mitarbeiter zuordnen"},
+		{errs: []error{repository.ErrInvalidEmployeeData}, status: http.StatusBadRequest, message: "Der Benutzer kann nicht als Mitarbeiter angelegt werden: %s", detail: true},
+	},
+}.with(integrationErrors)
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"PeopleFlow/backend/repository"
+	"PeopleFlow/backend/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdentityMatchErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		err         error
+		wantStatus  int
+		wantMessage string
+	}{
+		{fmt.Errorf("%w: personio", service.ErrIntegrationUnknown), http.StatusNotFound, "Unbekannte Integration"},
+		{fmt.Errorf("%w: awork", service.ErrIdentityMatchingUnsupported), http.StatusBadRequest, "unterstützt keine Zuordnung"},
+		{fmt.Errorf("%w: tb-9", service.ErrExternalIdentityNotFound), http.StatusNotFound, "Externer Benutzer nicht gefunden"},
+		{repository.ErrEmployeeNotFound, http.StatusNotFound, "Mitarbeiter nicht gefunden"},
+		{repository.ErrIntegrationMappingNotFound, http.StatusNotFound, "keine manuelle Zuordnung"},
+		{service.ErrIdentityAlreadyMatched, http.StatusConflict, "bereits einem Mitarbeiter zugeordnet"},
+		{repository.ErrEmployeeIDTaken, http.StatusConflict, "Personalnummer ist bereits vergeben"},
+		{assert.AnError, http.StatusInternalServerError, "Fehler beim Abgleich der Benutzer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			identityMatchErrors.respond(c, tt.err)
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantMessage)
+		})
+	}
+}
+
+func TestIdentityMatchRoutes_RequireFormFields(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := &IntegrationHandler{}
+	router := gin.New()
+	router.POST("/api/integrations/:type/matching", h.ConfirmIdentityMatch)
+	router.POST("/api/integrations/:type/matching/create-employee", h.CreateEmployeeFromIdentity)
+
+	requests := []*http.Request{
+		httptest.NewRequest(http.MethodPost, "/api/integrations/timebutler/matching", strings.NewReader("externalId=tb-1")),
+		httptest.NewRequest(http.MethodPost, "/api/integrations/timebutler/matching/create-employee", strings.NewReader("")),
+	}
+	for _, req := range requests {
+		t.Run(req.URL.Path, func(t *testing.T) {
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), "erforderlich")
+		})
+	}
+}
@@ -15,6 +15,9 @@ import (
 type IntegrationHandler struct {
 	timebutlerService *service.TimebutlerService
 	erfasst123Service *service.Erfasst123Service
+	syncJobService    *service.SyncJobService
+	identityService   *service.IdentityMatchingService
+	fieldOwnership    *service.FieldOwnershipService
 }
 
 // NewIntegrationHandler anpassen
@@ -22,105 +25,127 @@ func NewIntegrationHandler() *IntegrationHandler {
 	return &IntegrationHandler{
 		timebutlerService: service.NewTimebutlerService(),
 		erfasst123Service: service.NewErfasst123Service(),
+		syncJobService:    service.NewSyncJobService(),
+		identityService:   service.NewIdentityMatchingService(),
+		fieldOwnership:    service.NewFieldOwnershipService(),
 	}
 }
 
-// SaveTimebutlerApiKey speichert den API-Schlüssel für Timebutler
-func (h *IntegrationHandler) SaveTimebutlerApiKey(c *gin.Context) {
-	fmt.Println("[DEBUG] SaveTimebutlerApiKey called")
-	
-	apiKey := c.PostForm("timebutler-api")
-	fmt.Printf("[DEBUG] Received API key (length: %d)\n", len(apiKey))
+// GetIntegrationStatus gibt den Status aller registrierten Integrationen zurück, nach Typ geordnet
+func (h *IntegrationHandler) GetIntegrationStatus(c *gin.Context) {
+	statuses := gin.H{}
+	for _, provider := range service.Integrations() {
+		status, err := provider.Status()
+		if err != nil {
+			integrationErrors.respond(c, err)
+			return
+		}
+		statuses[status.Type] = status
+	}
 
-	if apiKey == "" {
-		fmt.Println("[ERROR] API key is empty")
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"message": "API-Schlüssel ist erforderlich",
-		})
+	c.JSON(http.StatusOK, statuses)
+}
+
+// SaveIntegration prüft die Zugangsdaten eines Anbieters und speichert sie.
+// Die Formularfelder entsprechen den Konfigurationsfeldern des Anbieters.
+func (h *IntegrationHandler) SaveIntegration(c *gin.Context) {
+	provider, ok := h.provider(c)
+	if !ok {
 		return
 	}
 
-	fmt.Println("[DEBUG] Calling timebutlerService.SaveApiKey")
-	err := h.timebutlerService.SaveApiKey(apiKey)
-	if err != nil {
-		fmt.Printf("[ERROR] Failed to save API key: %v\n", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"message": "Fehler beim Speichern des API-Schlüssels: " + err.Error(),
-		})
+	values := make(map[string]string)
+	for _, field := range provider.Info().Fields {
+		values[field.Name] = c.PostForm(field.Name)
+	}
+	if err := service.ConfigureIntegration(provider, values); err != nil {
+		integrationErrors.respond(c, err)
 		return
 	}
 
-	fmt.Println("[SUCCESS] API key saved successfully")
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"message": "Timebutler-Integration erfolgreich konfiguriert",
+		"message": provider.Info().Name + "-Integration erfolgreich konfiguriert",
 	})
 }
 
-// GetIntegrationStatus gibt den Status aller Integrationen zurück
-func (h *IntegrationHandler) GetIntegrationStatus(c *gin.Context) {
-	timebutlerConnected := h.timebutlerService.IsConnected()
-	erfasst123Connected := h.erfasst123Service.IsConnected() // Neu hinzugefügt
-
-	// Prüfen, ob ein API-Schlüssel für Timebutler vorhanden ist
-	hasApiKey := false
-	if apiKey, err := h.timebutlerService.GetApiKey(); err == nil && apiKey != "" {
-		hasApiKey = true
+// TestIntegrationConnection testet die gespeicherten Zugangsdaten eines Anbieters
+func (h *IntegrationHandler) TestIntegrationConnection(c *gin.Context) {
+	provider, ok := h.provider(c)
+	if !ok {
+		return
 	}
 
-	// Prüfen, ob Anmeldedaten für 123erfasst vorhanden sind
-	hasErfasst123Credentials := false
-	if email, password, err := h.erfasst123Service.GetCredentials(); err == nil && email != "" && password != "" {
-		hasErfasst123Credentials = true
+	response := gin.H{"connected": true}
+	if err := provider.TestConnection(); err != nil {
+		response["connected"] = false
+		response["message"] = err.Error()
 	}
+	c.JSON(http.StatusOK, response)
+}
 
-	// Status der automatischen Synchronisierung für 123erfasst abrufen
-	autoSync, err := h.erfasst123Service.IsAutoSyncEnabled()
-	if err != nil {
-		autoSync = false
+// RemoveIntegration deaktiviert die Integration eines Anbieters
+func (h *IntegrationHandler) RemoveIntegration(c *gin.Context) {
+	provider, ok := h.provider(c)
+	if !ok {
+		return
 	}
 
-	// Letzte Synchronisierung für 123erfasst abrufen
-	lastSync, err := h.erfasst123Service.GetLastSyncTime()
-	var lastSyncFormatted string
-	if err != nil || lastSync.IsZero() {
-		lastSyncFormatted = "Nie"
-	} else {
-		lastSyncFormatted = lastSync.Format("02.01.2006 15:04:05")
+	if err := service.DisconnectIntegration(provider); err != nil {
+		integrationErrors.respond(c, err)
+		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"timebutler": gin.H{
-			"connected": timebutlerConnected,
-			"name":      "Timebutler",
-			"hasApiKey": hasApiKey,
-		},
-		"123erfasst": gin.H{ // Neu hinzugefügt
-			"connected": erfasst123Connected,
-			"name":      "123erfasst",
-			"hasApiKey": hasErfasst123Credentials,
-			"autoSync":  autoSync,
-			"lastSync":  lastSyncFormatted,
-		},
-		"awork": gin.H{
-			"connected": false,
-			"name":      "AWork",
-			"hasApiKey": false,
-		},
+		"success": true,
+		"message": provider.Info().Name + "-Integration erfolgreich entfernt",
 	})
 }
 
-// TestTimebutlerConnection testet die Verbindung zu Timebutler
-func (h *IntegrationHandler) TestTimebutlerConnection(c *gin.Context) {
-	isConnected := h.timebutlerService.IsConnected()
+// SyncIntegration startet die Synchronisierung eines Bereichs (z.B. users, absences, time_entries);
+// mit dryRun=true als Probelauf, der nur die Änderungsübersicht berechnet
+func (h *IntegrationHandler) SyncIntegration(c *gin.Context) {
+	capability, err := model.ParseSyncCapability(c.Param("capability"))
+	if err != nil {
+		respondSyncJobError(c, nil, err)
+		return
+	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"connected": isConnected,
+	h.startSync(c, capability, model.SyncJobParams{
+		Year:      c.Query("year"),
+		StartDate: c.Query("startDate"),
+		EndDate:   c.Query("endDate"),
+		DryRun:    c.Query("dryRun") == "true",
 	})
 }
 
+// FullSyncIntegration startet die Synchronisierung aller Bereiche eines Anbieters
+func (h *IntegrationHandler) FullSyncIntegration(c *gin.Context) {
+	h.startSync(c, model.SyncAll, model.SyncJobParams{DryRun: c.Query("dryRun") == "true"})
+}
+
+// provider gibt den Anbieter aus dem Pfadparameter :type zurück und antwortet sonst mit 404
+func (h *IntegrationHandler) provider(c *gin.Context) (service.IntegrationProvider, bool) {
+	provider, err := service.GetIntegration(c.Param("type"))
+	if err != nil {
+		integrationErrors.respond(c, err)
+		return nil, false
+	}
+	return provider, true
+}
+
+// integrationErrors übersetzt Fehler der Integrationsverwaltung in JSON-Antworten
+var integrationErrors = errorResponse{
+	fallback:   "Fehler bei der Integration",
+	messageKey: "message",
+	mappings: []errorMapping{
+		{errs: []error{service.ErrIntegrationUnknown}, status: http.StatusNotFound, message: "Unbekannte Integration"},
+		{errs: []error{repository.ErrIntegrationNotFound}, status: http.StatusNotFound, message: "Die Integration ist nicht eingerichtet"},
+		{errs: []error{service.ErrIntegrationFieldMissing}, status: http.StatusBadRequest, message: "%s ist erforderlich", detail: true},
+		{errs: []error{model.ErrInvalidPersonioAttributeMapping}, status: http.StatusBadRequest, message: "Ungültige Attributzuordnung: %s", detail: true},
+	},
+}
+
 // GetIntegrationSettings gibt die Settings für die Integrationsseite zurück
 func (h *IntegrationHandler) GetIntegrationSettings(c *gin.Context) {
 	// Aktuellen Benutzer aus dem Context abrufen
@@ -143,283 +168,23 @@ func (h *IntegrationHandler) GetIntegrationSettings(c *gin.Context) {
 	})
 }
 
-// SyncTimebutlerUsers synchronisiert Timebutler-Benutzer mit PeopleFlow-Mitarbeitern
-func (h *IntegrationHandler) SyncTimebutlerUsers(c *gin.Context) {
-	// Prüfen, ob Timebutler verbunden ist
-	if !h.timebutlerService.IsConnected() {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"message": "Timebutler ist nicht verbunden",
-		})
-		return
-	}
-
-	// Synchronisierung durchführen
-	updatedCount, err := h.timebutlerService.SyncTimebutlerUsers()
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"message": "Fehler bei der Synchronisierung: " + err.Error(),
-		})
-		return
-	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"success":      true,
-		"message":      fmt.Sprintf("%d Mitarbeiter wurden synchronisiert", updatedCount),
-		"updatedCount": updatedCount,
-	})
-}
-
-// SyncTimebutlerAbsences synchronisiert Timebutler-Abwesenheiten mit PeopleFlow-Mitarbeitern
-func (h *IntegrationHandler) SyncTimebutlerAbsences(c *gin.Context) {
-	fmt.Println("[DEBUG] SyncTimebutlerAbsences called")
-	
-	// Prüfen, ob Timebutler verbunden ist
-	isConnected := h.timebutlerService.IsConnected()
-	fmt.Printf("[DEBUG] Timebutler connected: %v\n", isConnected)
-	
-	if !isConnected {
-		fmt.Println("[ERROR] Timebutler is not connected")
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"message": "Timebutler ist nicht verbunden",
-		})
-		return
-	}
-
-	// Jahr aus der Anfrage holen oder aktuelles Jahr verwenden
-	year := c.DefaultQuery("year", fmt.Sprintf("%d", time.Now().Year()))
-	fmt.Printf("[DEBUG] Sync year: %s\n", year)
-	
-	// Start date from request (optional) - for future use
-	startDate := c.DefaultQuery("startDate", "")
-	fmt.Printf("[DEBUG] Start date: %s\n", startDate)
-
-	// Synchronisierung durchführen
-	// TODO: Pass startDate to service method when implemented
-	fmt.Println("[DEBUG] Starting absence synchronization")
-	updatedCount, err := h.timebutlerService.SyncTimebutlerAbsences(year)
-	if err != nil {
-		fmt.Printf("[ERROR] Sync failed: %v\n", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"message": "Fehler bei der Synchronisierung: " + err.Error(),
-		})
-		return
-	}
-
-	fmt.Printf("[SUCCESS] Sync completed, updated %d employees\n", updatedCount)
-	c.JSON(http.StatusOK, gin.H{
-		"success":      true,
-		"message":      fmt.Sprintf("%d Mitarbeiter mit Abwesenheiten wurden synchronisiert", updatedCount),
-		"updatedCount": updatedCount,
-	})
-}
-
-// SyncTimebutlerHolidayEntitlements synchronizes Timebutler holiday entitlements with PeopleFlow employees
-func (h *IntegrationHandler) SyncTimebutlerHolidayEntitlements(c *gin.Context) {
-	// Check if Timebutler is connected
-	if !h.timebutlerService.IsConnected() {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"message": "Timebutler is not connected",
-		})
-		return
-	}
-
-	// Year from request or use current year
-	year := c.DefaultQuery("year", fmt.Sprintf("%d", time.Now().Year()))
-	
-	// Start date from request (optional) - for future use
-	// startDate := c.DefaultQuery("startDate", "")
-
-	// Perform synchronization
-	// TODO: Pass startDate to service method when implemented
-	updatedCount, err := h.timebutlerService.SyncHolidayEntitlements(year)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"message": "Error synchronizing holiday entitlements: " + err.Error(),
-		})
-		return
-	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"success":      true,
-		"message":      fmt.Sprintf("%d employees were updated with holiday entitlements", updatedCount),
-		"updatedCount": updatedCount,
-	})
-}
-
-////////////////////        123Erfasst Integration /////////////////////
-
-// SaveErfasst123Credentials speichert die Anmeldedaten für 123erfasst
-func (h *IntegrationHandler) SaveErfasst123Credentials(c *gin.Context) {
-	email := c.PostForm("erfasst123-email")
-	password := c.PostForm("erfasst123-password")
-	syncStartDate := c.PostForm("erfasst123-sync-start-date")
-
-	if email == "" || password == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"message": "E-Mail und Passwort sind erforderlich",
-		})
-		return
-	}
-
-	err := h.erfasst123Service.SaveCredentials(email, password, syncStartDate)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"message": "Fehler beim Speichern der Anmeldedaten: " + err.Error(),
-		})
-		return
-	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"message": "123erfasst-Integration erfolgreich konfiguriert",
-	})
-}
-
-// TestErfasst123Connection testet die Verbindung zu 123erfasst
-func (h *IntegrationHandler) TestErfasst123Connection(c *gin.Context) {
-	isConnected := h.erfasst123Service.IsConnected()
-
-	c.JSON(http.StatusOK, gin.H{
-		"connected": isConnected,
-	})
-}
-
-// SyncErfasst123Projects synchronizes 123erfasst project data with PeopleFlow employees
-func (h *IntegrationHandler) SyncErfasst123Projects(c *gin.Context) {
-	// Check if 123erfasst is connected
-	if !h.erfasst123Service.IsConnected() {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"message": "123erfasst is not connected",
-		})
-		return
-	}
-
-	// Get date range from request
-	startDate := c.DefaultQuery("startDate", "")
-
-	// If no startDate provided, use saved sync start date
-	if startDate == "" {
-		savedStartDate, err := h.erfasst123Service.GetSyncStartDate()
-		if err != nil {
-			// Fallback to current month
-			now := time.Now()
-			startOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
-			startDate = startOfMonth.Format("2006-01-02")
-		} else {
-			startDate = savedStartDate
-		}
-		fmt.Printf("Verwende gespeichertes Startdatum für Projekte: %s\n", startDate)
-	}
-
-	// End date
-	endDate := c.DefaultQuery("endDate", "")
-	if endDate == "" {
-		endDate = time.Now().Format("2006-01-02")
-	}
-
-	// Perform synchronization
-	updatedCount, err := h.erfasst123Service.SyncErfasst123Projects(startDate, endDate)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"message": "Error synchronizing projects: " + err.Error(),
-		})
-		return
-	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"success":      true,
-		"message":      fmt.Sprintf("%d employees were updated with project assignments", updatedCount),
-		"updatedCount": updatedCount,
-		"dateRange": gin.H{
-			"startDate": startDate,
-			"endDate":   endDate,
-		},
-	})
-}
-
-// RemoveErfasst123Integration entfernt die 123erfasst-Integration
-func (h *IntegrationHandler) RemoveErfasst123Integration(c *gin.Context) {
-	integrationRepo := repository.NewIntegrationRepository()
+// SetTimebutlerAbsencePush aktiviert oder deaktiviert die Übertragung von in PeopleFlow
+// erfassten Abwesenheiten an Timebutler
+func (h *IntegrationHandler) SetTimebutlerAbsencePush(c *gin.Context) {
+	enabled := c.PostForm("enabled") == "true"
 
-	// Integration als inaktiv markieren
-	err := integrationRepo.SetIntegrationStatus("123erfasst", false)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"message": "Fehler beim Entfernen der Integration: " + err.Error(),
-		})
+	if err := h.timebutlerService.SetAbsencePush(enabled); err != nil {
+		integrationErrors.respond(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"message": "123erfasst-Integration erfolgreich entfernt",
+		"message": fmt.Sprintf("Übertragung von Abwesenheiten an Timebutler %s", map[bool]string{true: "aktiviert", false: "deaktiviert"}[enabled]),
 	})
 }
 
-// SyncErfasst123TimeEntries synchronizes 123erfasst time entries with PeopleFlow employees
-func (h *IntegrationHandler) SyncErfasst123TimeEntries(c *gin.Context) {
-	// Check if 123erfasst is connected
-	if !h.erfasst123Service.IsConnected() {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"message": "123erfasst is not connected",
-		})
-		return
-	}
-
-	// HIER DIE ÄNDERUNG: Prüfe ob startDate übergeben wurde
-	startDate := c.DefaultQuery("startDate", "")
-
-	// NEUE ZEILEN: Wenn kein startDate übergeben wurde, hole es aus den Einstellungen
-	if startDate == "" {
-		savedStartDate, err := h.erfasst123Service.GetSyncStartDate()
-		if err != nil {
-			// Fallback auf Beginn des aktuellen Jahres
-			startDate = time.Date(time.Now().Year(), 1, 1, 0, 0, 0, 0, time.UTC).Format("2006-01-02")
-		} else {
-			startDate = savedStartDate
-		}
-		fmt.Printf("Verwende gespeichertes Startdatum: %s\n", startDate)
-	}
-
-	// ÄNDERUNG: EndDate auch prüfen
-	endDate := c.DefaultQuery("endDate", "")
-	if endDate == "" {
-		endDate = time.Now().Format("2006-01-02")
-	}
-
-	// Rest der Funktion bleibt UNVERÄNDERT
-	// Perform synchronization
-	updatedCount, err := h.erfasst123Service.SyncErfasst123TimeEntries(startDate, endDate)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"message": "Error synchronizing time entries: " + err.Error(),
-		})
-		return
-	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"success":      true,
-		"message":      fmt.Sprintf("%d employees were updated with time entries", updatedCount),
-		"updatedCount": updatedCount,
-		"dateRange": gin.H{
-			"startDate": startDate,
-			"endDate":   endDate,
-		},
-	})
-}
+////////////////////        123Erfasst Integration /////////////////////
 
 // GetErfasst123SyncStatus returns the synchronization status for 123erfasst
 func (h *IntegrationHandler) GetErfasst123SyncStatus(c *gin.Context) {
@@ -508,99 +273,6 @@ func (h *IntegrationHandler) SetErfasst123SyncStartDate(c *gin.Context) {
 	})
 }
 
-// TriggerErfasst123FullSync triggers a full synchronization of 123erfasst data
-func (h *IntegrationHandler) TriggerErfasst123FullSync(c *gin.Context) {
-	// Check if 123erfasst is connected
-	if !h.erfasst123Service.IsConnected() {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"message": "123erfasst is not connected",
-		})
-		return
-	}
-
-	// Get sync start date
-	startDate, err := h.erfasst123Service.GetSyncStartDate()
-	if err != nil {
-		startDate = time.Date(time.Now().Year(), 1, 1, 0, 0, 0, 0, time.UTC).Format("2006-01-02")
-	}
-
-	// Current date as end date
-	endDate := time.Now().Format("2006-01-02")
-
-	// Sync employees
-	empCount, err := h.erfasst123Service.SyncErfasst123Employees()
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"message": "Error synchronizing employees: " + err.Error(),
-		})
-		return
-	}
-
-	// Sync projects
-	projCount, err := h.erfasst123Service.SyncErfasst123Projects(startDate, endDate)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"message": "Error synchronizing projects: " + err.Error(),
-		})
-		return
-	}
-
-	// Sync time entries
-	timeCount, err := h.erfasst123Service.SyncErfasst123TimeEntries(startDate, endDate)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"message": "Error synchronizing time entries: " + err.Error(),
-		})
-		return
-	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"message": fmt.Sprintf("Synchronisierung abgeschlossen: %d Mitarbeiter, %d Projekte, %d Zeiteinträge",
-			empCount, projCount, timeCount),
-		"data": gin.H{
-			"employeeCount": empCount,
-			"projectCount":  projCount,
-			"timeCount":     timeCount,
-			"dateRange": gin.H{
-				"startDate": startDate,
-				"endDate":   endDate,
-			},
-		},
-	})
-}
-
-func (h *IntegrationHandler) SyncErfasst123Employees(c *gin.Context) {
-	// Prüfen, ob 123erfasst verbunden ist
-	if !h.erfasst123Service.IsConnected() {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"message": "123erfasst is not connected",
-		})
-		return
-	}
-
-	// Synchronisierung durchführen
-	updatedCount, err := h.erfasst123Service.SyncErfasst123Employees()
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"message": "Error synchronizing employees: " + err.Error(),
-		})
-		return
-	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"success":      true,
-		"message":      fmt.Sprintf("%d employees were updated", updatedCount),
-		"updatedCount": updatedCount,
-	})
-}
-
 // TestErfasst123ProjectAPI testet die Projekt-API von 123erfasst
 func (h *IntegrationHandler) TestErfasst123ProjectAPI(c *gin.Context) {
 	// Prüfen ob 123erfasst verbunden ist
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+	"PeopleFlow/backend/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntegrationErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		err         error
+		wantStatus  int
+		wantMessage string
+	}{
+		{fmt.Errorf("%w: personio", service.ErrIntegrationUnknown), http.StatusNotFound, "Unbekannte Integration"},
+		{repository.ErrIntegrationNotFound, http.StatusNotFound, "nicht eingerichtet"},
+		{fmt.Errorf("%w: API-Schlüssel", service.ErrIntegrationFieldMissing), http.StatusBadRequest, "API-Schlüssel ist erforderlich"},
+		{fmt.Errorf("%w: unbekanntes Feld \"salary\"", model.ErrInvalidPersonioAttributeMapping), http.StatusBadRequest, "Ungültige Attributzuordnung"},
+		{assert.AnError, http.StatusInternalServerError, "Fehler bei der Integration"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			integrationErrors.respond(c, tt.err)
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantMessage)
+		})
+	}
+}
+
+func TestIntegrationRoutes_UnknownTypeReturnsNotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := &IntegrationHandler{}
+	router := gin.New()
+	router.POST("/api/integrations/:type/save", h.SaveIntegration)
+	router.GET("/api/integrations/:type/test", h.TestIntegrationConnection)
+	router.POST("/api/integrations/:type/remove", h.RemoveIntegration)
+
+	requests := []*http.Request{
+		httptest.NewRequest(http.MethodPost, "/api/integrations/unknown/save", strings.NewReader("token=abc")),
+		httptest.NewRequest(http.MethodGet, "/api/integrations/unknown/test", nil),
+		httptest.NewRequest(http.MethodPost, "/api/integrations/unknown/remove", nil),
+	}
+	for _, req := range requests {
+		t.Run(req.Method+" "+req.URL.Path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusNotFound, w.Code)
+			assert.Contains(t, w.Body.String(), "Unbekannte Integration")
+		})
+	}
+}
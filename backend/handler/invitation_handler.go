@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+	"PeopleFlow/backend/service"
+	"PeopleFlow/backend/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// InvitationHandler verwaltet Einladungen und die Aktivierung von Benutzerkonten
+type InvitationHandler struct {
+	invitationService *service.InvitationService
+}
+
+// NewInvitationHandler erstellt einen neuen InvitationHandler
+func NewInvitationHandler() *InvitationHandler {
+	return &InvitationHandler{
+		invitationService: service.NewInvitationService(),
+	}
+}
+
+// ShowActivationForm zeigt das Formular zur Kontoaktivierung an
+func (h *InvitationHandler) ShowActivationForm(c *gin.Context) {
+	token := c.Query("token")
+
+	_, user, err := h.invitationService.ValidateToken(token)
+	if err != nil {
+		c.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"title":   "Ungültiger Aktivierungslink",
+			"message": "Der Link ist ungültig, bereits verwendet oder abgelaufen. Bitte wenden Sie sich an Ihren Administrator.",
+			"year":    time.Now().Year(),
+		})
+		return
+	}
+
+	data := gin.H{
+		"title":             "Konto aktivieren",
+		"token":             token,
+		"firstName":         user.FirstName,
+		"email":             user.Email,
+		"twoFactorRequired": h.invitationService.IsTwoFactorRequired(),
+		"passwordRules":     h.invitationService.PasswordPolicy().Describe(),
+		"year":              time.Now().Year(),
+	}
+
+	// Secret für die optionale bzw. verpflichtende 2FA-Einrichtung vorbereiten
+	secret, err := utils.GenerateTOTPSecret()
+	if err == nil {
+		data["totpSecret"] = secret
+		data["totpURI"] = utils.TOTPProvisioningURI(secret, user.Email, "PeopleFlow")
+	} else {
+		log.Printf("Fehler beim Generieren des 2FA-Secrets: %v", err)
+	}
+
+	c.HTML(http.StatusOK, "activate_account.html", data)
+}
+
+// ActivateAccount verarbeitet die Kontoaktivierung
+func (h *InvitationHandler) ActivateAccount(c *gin.Context) {
+	token := c.PostForm("token")
+	password := c.PostForm("password")
+	confirmPassword := c.PostForm("confirm_password")
+	totpSecret := c.PostForm("totp_secret")
+	otp := c.PostForm("otp")
+
+	if token == "" || password == "" || confirmPassword == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Alle Felder sind erforderlich",
+		})
+		return
+	}
+
+	if password != confirmPassword {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Passwörter stimmen nicht überein",
+		})
+		return
+	}
+
+	// 2FA wird nur eingerichtet, wenn ein Code eingegeben wurde oder sie verpflichtend ist
+	if otp == "" && !h.invitationService.IsTwoFactorRequired() {
+		totpSecret = ""
+	}
+
+	_, err := h.invitationService.AcceptInvitation(token, password, totpSecret, otp)
+	if err != nil {
+		status := http.StatusBadRequest
+		message := "Fehler bei der Aktivierung des Kontos"
+
+		switch {
+		case errors.Is(err, repository.ErrInvitationNotFound),
+			errors.Is(err, repository.ErrInvitationInvalid),
+			errors.Is(err, service.ErrUserNotInvited):
+			message = "Ungültiger oder abgelaufener Aktivierungslink"
+		case errors.Is(err, repository.ErrInvalidPassword):
+			message = passwordPolicyErrorMessage(err, h.invitationService.PasswordPolicy())
+		case errors.Is(err, service.ErrTwoFactorRequired):
+			message = "Zwei-Faktor-Authentifizierung muss eingerichtet werden"
+		case errors.Is(err, service.ErrInvalidTwoFactorOTP):
+			message = "Ungültiger Bestätigungscode"
+		default:
+			log.Printf("Fehler bei der Kontoaktivierung: %v", err)
+			status = http.StatusInternalServerError
+		}
+
+		c.JSON(status, gin.H{
+			"success": false,
+			"error":   message,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Konto erfolgreich aktiviert. Sie können sich jetzt anmelden.",
+	})
+}
+
+// ResendInvitation versendet den Aktivierungslink für einen eingeladenen Benutzer erneut
+func (h *InvitationHandler) ResendInvitation(c *gin.Context) {
+	currentUser, _ := c.Get("user")
+	currentUserModel := currentUser.(*model.User)
+
+	_, err := h.invitationService.ResendInvitation(c.Param("id"), currentUserModel)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, repository.ErrUserNotFound) {
+			status = http.StatusNotFound
+		} else if errors.Is(err, service.ErrUserNotInvited) {
+			status = http.StatusBadRequest
+		}
+
+		c.JSON(status, gin.H{
+			"success": false,
+			"error":   "Einladung konnte nicht versendet werden: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Einladung wurde erneut versendet",
+	})
+}
@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+	"PeopleFlow/backend/service"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// jobRunPageSize ist die Anzahl der Läufe pro Seite in der Laufhistorie
+const jobRunPageSize = 50
+
+// JobHandler verwaltet die Hintergrundjobs (nur für Admins)
+type JobHandler struct {
+	jobService *service.JobService
+}
+
+// NewJobHandler erstellt einen neuen JobHandler
+func NewJobHandler() *JobHandler {
+	return &JobHandler{
+		jobService: service.NewJobService(),
+	}
+}
+
+// ListJobs gibt alle Jobs mit Zeitplan, nächstem Termin und letztem Ergebnis zurück
+func (h *JobHandler) ListJobs(c *gin.Context) {
+	jobs, err := h.jobService.ListJobs()
+	if err != nil {
+		jobErrors.respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    jobs,
+	})
+}
+
+// ListRuns gibt eine Seite der Laufhistorie eines Jobs zurück
+func (h *JobHandler) ListRuns(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+
+	runs, total, err := h.jobService.Runs(c.Param("name"), int64((page-1)*jobRunPageSize), jobRunPageSize)
+	if err != nil {
+		jobErrors.respond(c, err)
+		return
+	}
+	if runs == nil {
+		runs = []*model.JobRun{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    runs,
+		"page":    page,
+		"total":   total,
+	})
+}
+
+// RunJob startet einen Job sofort
+func (h *JobHandler) RunJob(c *gin.Context) {
+	user := currentWebhookUser(c)
+
+	run, err := h.jobService.Trigger(c.Param("name"), user)
+	if err != nil {
+		jobErrors.respond(c, err)
+		return
+	}
+	logJobActivity(user, run.Job, "Job "+run.Job+" manuell gestartet")
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"success": true,
+		"message": "Job gestartet",
+		"data":    run,
+	})
+}
+
+// PauseJob pausiert die planmäßige Ausführung eines Jobs
+func (h *JobHandler) PauseJob(c *gin.Context) {
+	job, err := h.jobService.Pause(c.Param("name"))
+	if err != nil {
+		jobErrors.respond(c, err)
+		return
+	}
+	logJobActivity(currentWebhookUser(c), job.Name, "Job "+job.Name+" pausiert")
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Job pausiert",
+		"data":    job,
+	})
+}
+
+// ResumeJob setzt einen pausierten Job fort
+func (h *JobHandler) ResumeJob(c *gin.Context) {
+	job, err := h.jobService.Resume(c.Param("name"))
+	if err != nil {
+		jobErrors.respond(c, err)
+		return
+	}
+	logJobActivity(currentWebhookUser(c), job.Name, "Job "+job.Name+" fortgesetzt")
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Job fortgesetzt",
+		"data":    job,
+	})
+}
+
+// UpdateJob ändert den Zeitplan eines Jobs; ein leerer Zeitplan stellt den Standard wieder her
+func (h *JobHandler) UpdateJob(c *gin.Context) {
+	job, err := h.jobService.UpdateSchedule(c.Param("name"), c.PostForm("schedule"))
+	if err != nil {
+		jobErrors.respond(c, err)
+		return
+	}
+	logJobActivity(currentWebhookUser(c), job.Name, "Zeitplan von Job "+job.Name+" auf \""+job.Schedule+"\" geändert")
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Zeitplan gespeichert",
+		"data":    job,
+	})
+}
+
+// logJobActivity protokolliert eine Änderung an einem Job
+func logJobActivity(user *model.User, name, description string) {
+	activityRepo := repository.NewActivityRepository()
+	_, _ = activityRepo.LogActivity(
+		model.ActivityTypeSystemSettingChanged,
+		user.ID,
+		user.FirstName+" "+user.LastName,
+		primitive.NilObjectID,
+		"job",
+		name,
+		description,
+	)
+}
+
+// jobErrors übersetzt Fehler der Hintergrundjobs in JSON-Antworten
+var jobErrors = errorResponse{
+	fallback: "Fehler bei den Hintergrundjobs",
+	mappings: []errorMapping{
+		{errs: []error{service.ErrJobUnknown, repository.ErrJobNotFound}, status: http.StatusNotFound, message: "Job nicht gefunden"},
+		{errs: []error{service.ErrJobRunning}, status: http.StatusConflict, message: "Der Job läuft bereits"},
+		{errs: []error{model.ErrInvalidCronExpression}, status: http.StatusBadRequest, message: "Ungültiger Zeitplan: %s", detail: true},
+	},
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+	"PeopleFlow/backend/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobHandler_UnknownJob(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := &JobHandler{jobService: &service.JobService{}}
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user", &model.User{Role: model.RoleAdmin})
+	})
+	router.GET("/api/jobs/:name/runs", h.ListRuns)
+	router.POST("/api/jobs/:name/run", h.RunJob)
+
+	tests := []struct {
+		method string
+		path   string
+	}{
+		{http.MethodGet, "/api/jobs/does_not_exist/runs"},
+		{http.MethodPost, "/api/jobs/does_not_exist/run"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+			assert.Equal(t, http.StatusNotFound, w.Code)
+			assert.Contains(t, w.Body.String(), "Job nicht gefunden")
+		})
+	}
+}
+
+func TestJobErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{service.ErrJobUnknown, http.StatusNotFound},
+		{repository.ErrJobNotFound, http.StatusNotFound},
+		{service.ErrJobRunning, http.StatusConflict},
+		{fmt.Errorf("%w: 61 * * * *", model.ErrInvalidCronExpression), http.StatusBadRequest},
+		{assert.AnError, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			jobErrors.respond(c, tt.err)
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
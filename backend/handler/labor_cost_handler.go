@@ -0,0 +1,330 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+	"PeopleFlow/backend/service"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// maxLaborCostMonths begrenzt den abrufbaren Personalkosten-Verlauf
+const maxLaborCostMonths = 36
+
+// LaborCostHandler verwaltet Gehaltshistorie und Sonderzahlungen der Mitarbeiter und stellt
+// den Verlauf der Personalkosten bereit
+type LaborCostHandler struct {
+	employeeRepo *repository.EmployeeRepository
+	costService  *service.CostService
+}
+
+// salaryHistoryResponse ist die Gehaltshistorie eines Mitarbeiters
+type salaryHistoryResponse struct {
+	Salary        float64              `json:"salary"`
+	SalaryHistory []model.SalaryChange `json:"salaryHistory"`
+	Bonuses       []model.SalaryBonus  `json:"bonuses"`
+}
+
+// NewLaborCostHandler erstellt einen neuen LaborCostHandler
+func NewLaborCostHandler() *LaborCostHandler {
+	return &LaborCostHandler{
+		employeeRepo: repository.NewEmployeeRepository(),
+		costService:  service.NewCostService(),
+	}
+}
+
+// GetSalaryHistory gibt die Gehaltshistorie und Sonderzahlungen eines Mitarbeiters zurück
+func (h *LaborCostHandler) GetSalaryHistory(c *gin.Context) {
+	employee, ok := h.loadEmployee(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    newSalaryHistoryResponse(employee),
+	})
+}
+
+// AddSalaryChange trägt eine Gehaltsänderung mit Stichtag ein
+func (h *LaborCostHandler) AddSalaryChange(c *gin.Context) {
+	change, err := parseSalaryChangeForm(c)
+	if err != nil {
+		laborCostErrors.respond(c, err)
+		return
+	}
+
+	employee, ok := h.loadEmployee(c)
+	if !ok {
+		return
+	}
+
+	user := apiCurrentUser(c)
+	change.CreatedBy = user.FirstName + " " + user.LastName
+	if err := employee.AddSalaryChange(change, time.Now()); err != nil {
+		laborCostErrors.respond(c, err)
+		return
+	}
+
+	h.saveEmployee(c, employee, fmt.Sprintf("Gehaltsänderung ab %s eingetragen", change.EffectiveDate.Format("02.01.2006")), "Gehaltsänderung gespeichert")
+}
+
+// DeleteSalaryChange löscht einen Eintrag der Gehaltshistorie
+func (h *LaborCostHandler) DeleteSalaryChange(c *gin.Context) {
+	entryID, err := primitive.ObjectIDFromHex(c.Param("entryId"))
+	if err != nil {
+		laborCostErrors.respond(c, model.ErrSalaryEntryNotFound)
+		return
+	}
+
+	employee, ok := h.loadEmployee(c)
+	if !ok {
+		return
+	}
+	if err := employee.RemoveSalaryChange(entryID, time.Now()); err != nil {
+		laborCostErrors.respond(c, err)
+		return
+	}
+
+	h.saveEmployee(c, employee, "Eintrag der Gehaltshistorie gelöscht", "Eintrag gelöscht")
+}
+
+// AddBonus trägt eine Sonderzahlung ein
+func (h *LaborCostHandler) AddBonus(c *gin.Context) {
+	bonus, err := parseSalaryBonusForm(c)
+	if err != nil {
+		laborCostErrors.respond(c, err)
+		return
+	}
+
+	employee, ok := h.loadEmployee(c)
+	if !ok {
+		return
+	}
+
+	user := apiCurrentUser(c)
+	bonus.CreatedBy = user.FirstName + " " + user.LastName
+	if err := employee.AddBonus(bonus, time.Now()); err != nil {
+		laborCostErrors.respond(c, err)
+		return
+	}
+
+	h.saveEmployee(c, employee, fmt.Sprintf("Sonderzahlung \"%s\" eingetragen", bonus.Description), "Sonderzahlung gespeichert")
+}
+
+// DeleteBonus löscht eine Sonderzahlung
+func (h *LaborCostHandler) DeleteBonus(c *gin.Context) {
+	bonusID, err := primitive.ObjectIDFromHex(c.Param("bonusId"))
+	if err != nil {
+		laborCostErrors.respond(c, model.ErrSalaryEntryNotFound)
+		return
+	}
+
+	employee, ok := h.loadEmployee(c)
+	if !ok {
+		return
+	}
+	if err := employee.RemoveBonus(bonusID); err != nil {
+		laborCostErrors.respond(c, err)
+		return
+	}
+
+	h.saveEmployee(c, employee, "Sonderzahlung gelöscht", "Sonderzahlung gelöscht")
+}
+
+// GetLaborCosts gibt den monatlichen Verlauf der Personalkosten zurück, optional gefiltert
+// nach Abteilung und Standort
+func (h *LaborCostHandler) GetLaborCosts(c *gin.Context) {
+	months := 12
+	if value := c.Query("months"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > maxLaborCostMonths {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   fmt.Sprintf("months muss zwischen 1 und %d liegen", maxLaborCostMonths),
+			})
+			return
+		}
+		months = parsed
+	}
+
+	trend, err := h.costService.LaborCostTrend(months, c.Query("department"), c.Query("location"), time.Now())
+	if err != nil {
+		laborCostErrors.respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    trend,
+	})
+}
+
+// CreateLaborCostSnapshot berechnet die Personalkosten des Vormonats neu und schreibt sie fest,
+// z.B. nach nachträglich erfassten Gehaltsänderungen. Ältere Monate werden nicht neu berechnet,
+// da ausgeschiedene Mitarbeiter mit ihrem heutigen Status darin fehlen würden.
+func (h *LaborCostHandler) CreateLaborCostSnapshot(c *gin.Context) {
+	now := time.Now().In(timeExportLocation())
+	previous := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).AddDate(0, -1, 0)
+	month, err := time.ParseInLocation("2006-01", strings.TrimSpace(c.PostForm("month")), now.Location())
+	if err != nil || !month.Equal(previous) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Neu festschreiben lässt sich nur der Vormonat (" + previous.Format("2006-01") + ", Format JJJJ-MM), da ältere Monate ausgeschiedene Mitarbeiter nicht mehr enthalten würden",
+		})
+		return
+	}
+
+	snapshot, err := h.costService.SnapshotMonth(month, now)
+	if err != nil {
+		laborCostErrors.respond(c, err)
+		return
+	}
+
+	user := apiCurrentUser(c)
+	activityRepo := repository.NewActivityRepository()
+	_, _ = activityRepo.LogActivity(
+		model.ActivityTypeSystemSettingChanged,
+		user.ID,
+		user.FirstName+" "+user.LastName,
+		primitive.NilObjectID,
+		"labor_costs",
+		"Personalkosten "+month.Format("01/2006"),
+		"Personalkosten "+month.Format("01/2006")+" neu festgeschrieben",
+	)
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Personalkosten " + month.Format("01/2006") + " festgeschrieben",
+		"data":    snapshot,
+	})
+}
+
+// loadEmployee lädt den Mitarbeiter aus der URL
+func (h *LaborCostHandler) loadEmployee(c *gin.Context) (*model.Employee, bool) {
+	employee, err := h.employeeRepo.FindByID(c.Param("id"))
+	if err != nil {
+		laborCostErrors.respond(c, err)
+		return nil, false
+	}
+	return employee, true
+}
+
+// saveEmployee speichert die geänderte Gehaltshistorie, protokolliert die Änderung und gibt
+// die aktualisierte Historie zurück
+func (h *LaborCostHandler) saveEmployee(c *gin.Context, employee *model.Employee, description, message string) {
+	employee.UpdatedAt = time.Now()
+	if err := h.employeeRepo.Update(employee); err != nil {
+		laborCostErrors.respond(c, err)
+		return
+	}
+
+	user := apiCurrentUser(c)
+	activityRepo := repository.NewActivityRepository()
+	_, _ = activityRepo.LogActivity(
+		model.ActivityTypeEmployeeUpdated,
+		user.ID,
+		user.FirstName+" "+user.LastName,
+		employee.ID,
+		"employee",
+		employee.FirstName+" "+employee.LastName,
+		description,
+	)
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": message,
+		"data":    newSalaryHistoryResponse(employee),
+	})
+}
+
+// newSalaryHistoryResponse gibt die Historie neueste zuerst zurück
+func newSalaryHistoryResponse(employee *model.Employee) salaryHistoryResponse {
+	response := salaryHistoryResponse{
+		Salary:        employee.Salary,
+		SalaryHistory: make([]model.SalaryChange, 0, len(employee.SalaryHistory)),
+		Bonuses:       make([]model.SalaryBonus, 0, len(employee.Bonuses)),
+	}
+	for i := len(employee.SalaryHistory) - 1; i >= 0; i-- {
+		response.SalaryHistory = append(response.SalaryHistory, employee.SalaryHistory[i])
+	}
+	for i := len(employee.Bonuses) - 1; i >= 0; i-- {
+		response.Bonuses = append(response.Bonuses, employee.Bonuses[i])
+	}
+	return response
+}
+
+// parseSalaryChangeForm liest eine Gehaltsänderung aus dem Formular. Ohne Angabe gilt der
+// Standard-Arbeitgeberanteil.
+func parseSalaryChangeForm(c *gin.Context) (model.SalaryChange, error) {
+	effectiveDate, err := parseLaborCostDate(c.PostForm("effectiveDate"))
+	if err != nil {
+		return model.SalaryChange{}, fmt.Errorf("%w: Gültig ab ist kein Datum", model.ErrInvalidSalaryChange)
+	}
+	salary, err := model.ParseImportNumber(c.PostForm("monthlySalary"))
+	if err != nil {
+		return model.SalaryChange{}, fmt.Errorf("%w: Monatsgehalt ist keine Zahl", model.ErrInvalidSalaryChange)
+	}
+
+	percent := model.DefaultEmployerContributionPercent
+	if value := strings.TrimSpace(c.PostForm("employerContributionPercent")); value != "" {
+		if percent, err = model.ParseImportNumber(value); err != nil {
+			return model.SalaryChange{}, fmt.Errorf("%w: Arbeitgeberanteil ist keine Zahl", model.ErrInvalidSalaryChange)
+		}
+	}
+
+	change := model.SalaryChange{
+		EffectiveDate:               effectiveDate,
+		MonthlySalary:               salary,
+		EmployerContributionPercent: percent,
+		Reason:                      strings.TrimSpace(c.PostForm("reason")),
+	}
+	return change, change.Validate()
+}
+
+// parseSalaryBonusForm liest eine Sonderzahlung aus dem Formular
+func parseSalaryBonusForm(c *gin.Context) (model.SalaryBonus, error) {
+	paymentDate, err := parseLaborCostDate(c.PostForm("paymentDate"))
+	if err != nil {
+		return model.SalaryBonus{}, fmt.Errorf("%w: Auszahlungsdatum ist kein Datum", model.ErrInvalidSalaryBonus)
+	}
+	amount, err := model.ParseImportNumber(c.PostForm("amount"))
+	if err != nil {
+		return model.SalaryBonus{}, fmt.Errorf("%w: Betrag ist keine Zahl", model.ErrInvalidSalaryBonus)
+	}
+
+	bonus := model.SalaryBonus{
+		PaymentDate: paymentDate,
+		Amount:      amount,
+		Description: strings.TrimSpace(c.PostForm("description")),
+	}
+	return bonus, bonus.Validate()
+}
+
+// parseLaborCostDate liest ein Datum als Tagesbeginn in deutscher Zeit, damit es dem
+// richtigen Kostenmonat zugeordnet wird
+func parseLaborCostDate(value string) (time.Time, error) {
+	parsed, err := model.ParseImportDate(value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(parsed.Year(), parsed.Month(), parsed.Day(), 0, 0, 0, 0, timeExportLocation()), nil
+}
+
+// laborCostErrors übersetzt Fehler der Gehaltshistorie in JSON-Antworten
+var laborCostErrors = errorResponse{
+	fallback: "Fehler bei den Personalkosten",
+	mappings: []errorMapping{
+		{errs: []error{model.ErrInvalidSalaryChange}, status: http.StatusBadRequest, message: "Ungültige Gehaltsänderung: %s", detail: true},
+		{errs: []error{model.ErrInvalidSalaryBonus}, status: http.StatusBadRequest, message: "Ungültige Sonderzahlung: %s", detail: true},
+		{errs: []error{model.ErrSalaryEntryNotFound}, status: http.StatusNotFound, message: "Eintrag nicht gefunden"},
+		{errs: []error{repository.ErrEmployeeNotFound, repository.ErrInvalidID}, status: http.StatusNotFound, message: "Mitarbeiter nicht gefunden"},
+	},
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestLaborCostErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		err         error
+		wantStatus  int
+		wantMessage string
+	}{
+		{fmt.Errorf("%w: Gültig ab fehlt", model.ErrInvalidSalaryChange), http.StatusBadRequest, "Ungültige Gehaltsänderung: Gültig ab fehlt"},
+		{fmt.Errorf("%w: Bezeichnung fehlt", model.ErrInvalidSalaryBonus), http.StatusBadRequest, "Ungültige Sonderzahlung: Bezeichnung fehlt"},
+		{model.ErrSalaryEntryNotFound, http.StatusNotFound, "Eintrag nicht gefunden"},
+		{repository.ErrEmployeeNotFound, http.StatusNotFound, "Mitarbeiter nicht gefunden"},
+		{assert.AnError, http.StatusInternalServerError, "Fehler bei den Personalkosten"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			laborCostErrors.respond(c, tt.err)
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantMessage)
+		})
+	}
+}
+
+func TestLaborCostHandler_RejectsInvalidRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := &LaborCostHandler{}
+	admin := &model.User{ID: primitive.NewObjectID(), Role: model.RoleAdmin}
+	now := time.Now().In(timeExportLocation())
+	currentMonth := now.Format("2006-01")
+	olderMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).AddDate(0, -2, 0).Format("2006-01")
+
+	tests := []struct {
+		name        string
+		method      string
+		target      string
+		form        url.Values
+		params      gin.Params
+		handle      gin.HandlerFunc
+		wantStatus  int
+		wantMessage string
+	}{
+		{"Gehalt ohne Stichtag", http.MethodPost, "/", url.Values{"monthlySalary": {"4000"}}, nil, h.AddSalaryChange, http.StatusBadRequest, "Gültig ab"},
+		{"Gehalt keine Zahl", http.MethodPost, "/", url.Values{"effectiveDate": {"2024-07-01"}, "monthlySalary": {"viel"}}, nil, h.AddSalaryChange, http.StatusBadRequest, "Monatsgehalt"},
+		{"Arbeitgeberanteil zu hoch", http.MethodPost, "/", url.Values{"effectiveDate": {"01.07.2024"}, "monthlySalary": {"4.000,00"}, "employerContributionPercent": {"150"}}, nil, h.AddSalaryChange, http.StatusBadRequest, "zwischen 0 und 100"},
+		{"Sonderzahlung ohne Bezeichnung", http.MethodPost, "/", url.Values{"paymentDate": {"2024-11-30"}, "amount": {"500"}}, nil, h.AddBonus, http.StatusBadRequest, "Bezeichnung"},
+		{"Sonderzahlung ohne Betrag", http.MethodPost, "/", url.Values{"paymentDate": {"2024-11-30"}, "amount": {"0"}, "description": {"Prämie"}}, nil, h.AddBonus, http.StatusBadRequest, "nicht 0"},
+		{"ungültige Eintrags-ID", http.MethodDelete, "/", nil, gin.Params{{Key: "entryId", Value: "abc"}}, h.DeleteSalaryChange, http.StatusNotFound, "Eintrag nicht gefunden"},
+		{"ungültige Sonderzahlungs-ID", http.MethodDelete, "/", nil, gin.Params{{Key: "bonusId", Value: "abc"}}, h.DeleteBonus, http.StatusNotFound, "Eintrag nicht gefunden"},
+		{"zu viele Monate", http.MethodGet, "/?months=120", nil, nil, h.GetLaborCosts, http.StatusBadRequest, "months"},
+		{"laufender Monat", http.MethodPost, "/", url.Values{"month": {currentMonth}}, nil, h.CreateLaborCostSnapshot, http.StatusBadRequest, "nur der Vormonat"},
+		{"älterer Monat", http.MethodPost, "/", url.Values{"month": {olderMonth}}, nil, h.CreateLaborCostSnapshot, http.StatusBadRequest, "nur der Vormonat"},
+		{"ungültiger Monat", http.MethodPost, "/", url.Values{"month": {"07/2024"}}, nil, h.CreateLaborCostSnapshot, http.StatusBadRequest, "JJJJ-MM"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.form.Encode()))
+			c.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			c.Params = tt.params
+			c.Set("user", admin)
+
+			tt.handle(c)
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantMessage)
+		})
+	}
+}
+
+func TestNewSalaryHistoryResponse(t *testing.T) {
+	employee := &model.Employee{Salary: 4000, HireDate: time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)}
+	now := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, employee.AddSalaryChange(model.SalaryChange{EffectiveDate: now, MonthlySalary: 4500, EmployerContributionPercent: 21.5}, now))
+
+	response := newSalaryHistoryResponse(employee)
+	assert.Equal(t, 4500.0, response.Salary)
+	require.Len(t, response.SalaryHistory, 2)
+	assert.Equal(t, 4500.0, response.SalaryHistory[0].MonthlySalary, "neueste Änderung zuerst")
+	assert.NotNil(t, response.Bonuses)
+}
@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+	"PeopleFlow/backend/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// notificationPageSize ist die Anzahl der Benachrichtigungen pro Seite
+const notificationPageSize = 20
+
+// NotificationHandler verwaltet die In-App-Benachrichtigungen des angemeldeten Benutzers
+type NotificationHandler struct {
+	notificationService *service.NotificationService
+}
+
+// NewNotificationHandler erstellt einen neuen NotificationHandler
+func NewNotificationHandler() *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: service.NewNotificationService(),
+	}
+}
+
+// ListNotifications gibt die Benachrichtigungen des Benutzers zurück (?unread=true&page=)
+func (h *NotificationHandler) ListNotifications(c *gin.Context) {
+	user := currentWebhookUser(c)
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+
+	notifications, total, err := h.notificationService.List(user, c.Query("unread") == "true", int64((page-1)*notificationPageSize), notificationPageSize)
+	if err != nil {
+		notificationErrors.respond(c, err)
+		return
+	}
+
+	unread, err := h.notificationService.CountUnread(user)
+	if err != nil {
+		notificationErrors.respond(c, err)
+		return
+	}
+
+	if notifications == nil {
+		notifications = []*model.Notification{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    notifications,
+		"page":    page,
+		"total":   total,
+		"unread":  unread,
+	})
+}
+
+// UnreadCount gibt die Anzahl ungelesener Benachrichtigungen zurück (für das Glockensymbol)
+func (h *NotificationHandler) UnreadCount(c *gin.Context) {
+	unread, err := h.notificationService.CountUnread(currentWebhookUser(c))
+	if err != nil {
+		notificationErrors.respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    gin.H{"unread": unread},
+	})
+}
+
+// MarkRead markiert eine Benachrichtigung als gelesen
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	if err := h.notificationService.MarkRead(currentWebhookUser(c), c.Param("id")); err != nil {
+		notificationErrors.respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Benachrichtigung als gelesen markiert",
+	})
+}
+
+// MarkAllRead markiert alle Benachrichtigungen als gelesen
+func (h *NotificationHandler) MarkAllRead(c *gin.Context) {
+	count, err := h.notificationService.MarkAllRead(currentWebhookUser(c))
+	if err != nil {
+		notificationErrors.respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": strconv.FormatInt(count, 10) + " Benachrichtigungen als gelesen markiert",
+	})
+}
+
+// GetPreferences gibt die Einstellungen je Benachrichtigungsart inklusive Voreinstellungen zurück
+func (h *NotificationHandler) GetPreferences(c *gin.Context) {
+	user := currentWebhookUser(c)
+	preferences := user.NotificationPreferences.Resolved()
+
+	types := make([]gin.H, 0)
+	for _, t := range model.NotificationTypes() {
+		types = append(types, gin.H{
+			"type":  t,
+			"label": t.GetLabel(),
+			"inApp": preferences[t].InApp,
+			"email": preferences[t].Email,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    types,
+	})
+}
+
+// UpdatePreferences speichert die Einstellungen. Die Formularfelder "inApp" und "email"
+// enthalten jeweils die Benachrichtigungsarten, die über diesen Kanal zugestellt werden sollen.
+func (h *NotificationHandler) UpdatePreferences(c *gin.Context) {
+	user := currentWebhookUser(c)
+
+	preferences, err := parseNotificationPreferences(c.PostFormArray("inApp"), c.PostFormArray("email"))
+	if err != nil {
+		notificationErrors.respond(c, err)
+		return
+	}
+
+	if err := h.notificationService.UpdatePreferences(user, preferences); err != nil {
+		notificationErrors.respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Benachrichtigungseinstellungen gespeichert",
+		"data":    preferences,
+	})
+}
+
+// parseNotificationPreferences baut vollständige Einstellungen aus den gewählten Arten je Kanal
+func parseNotificationPreferences(inApp, email []string) (model.NotificationPreferences, error) {
+	preferences := make(model.NotificationPreferences)
+	for _, t := range model.NotificationTypes() {
+		preferences[t] = model.NotificationPreference{}
+	}
+
+	for _, value := range inApp {
+		t := model.NotificationType(value)
+		if !t.IsValid() {
+			return nil, model.ErrInvalidNotificationType
+		}
+		preference := preferences[t]
+		preference.InApp = true
+		preferences[t] = preference
+	}
+	for _, value := range email {
+		t := model.NotificationType(value)
+		if !t.IsValid() {
+			return nil, model.ErrInvalidNotificationType
+		}
+		preference := preferences[t]
+		preference.Email = true
+		preferences[t] = preference
+	}
+	return preferences, nil
+}
+
+// notificationErrors übersetzt Fehler der Benachrichtigungen in JSON-Antworten
+var notificationErrors = errorResponse{
+	fallback: "Fehler bei den Benachrichtigungen",
+	mappings: []errorMapping{
+		{errs: []error{repository.ErrNotificationNotFound, repository.ErrInvalidID}, status: http.StatusNotFound, message: "Benachrichtigung nicht gefunden"},
+		{errs: []error{model.ErrInvalidNotificationType}, status: http.StatusBadRequest, message: "Unbekannte Benachrichtigungsart"},
+	},
+}
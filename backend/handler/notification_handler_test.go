@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"PeopleFlow/backend/model"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestParseNotificationPreferences(t *testing.T) {
+	preferences, err := parseNotificationPreferences(
+		[]string{"approval_requested", "sync_failed"},
+		[]string{"approval_requested", "document_expiring"},
+	)
+	require.NoError(t, err)
+
+	assert.Len(t, preferences, len(model.NotificationTypes()))
+	assert.Equal(t, model.NotificationPreference{InApp: true, Email: true}, preferences[model.NotificationApprovalRequested])
+	assert.Equal(t, model.NotificationPreference{InApp: true}, preferences[model.NotificationSyncFailed])
+	assert.Equal(t, model.NotificationPreference{Email: true}, preferences[model.NotificationDocumentExpiring])
+	assert.Equal(t, model.NotificationPreference{}, preferences[model.NotificationRequestDecided])
+
+	_, err = parseNotificationPreferences([]string{"newsletter"}, nil)
+	assert.ErrorIs(t, err, model.ErrInvalidNotificationType)
+}
+
+func TestNotificationHandler_GetPreferences(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	user := &model.User{
+		ID: primitive.NewObjectID(),
+		NotificationPreferences: model.NotificationPreferences{
+			model.NotificationRequestDecided: {InApp: false, Email: true},
+		},
+	}
+
+	h := &NotificationHandler{}
+	router := gin.New()
+	router.GET("/api/notifications/preferences", func(c *gin.Context) {
+		c.Set("user", user)
+		h.GetPreferences(c)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/notifications/preferences", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Success bool `json:"success"`
+		Data    []struct {
+			Type  model.NotificationType `json:"type"`
+			Label string                 `json:"label"`
+			InApp bool                   `json:"inApp"`
+			Email bool                   `json:"email"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.True(t, response.Success)
+	require.Len(t, response.Data, len(model.NotificationTypes()))
+
+	byType := make(map[model.NotificationType]model.NotificationPreference)
+	for _, entry := range response.Data {
+		assert.NotEmpty(t, entry.Label)
+		byType[entry.Type] = model.NotificationPreference{InApp: entry.InApp, Email: entry.Email}
+	}
+	assert.Equal(t, model.NotificationPreference{Email: true}, byType[model.NotificationRequestDecided])
+	assert.Equal(t, model.DefaultNotificationPreference(model.NotificationSyncFailed), byType[model.NotificationSyncFailed])
+}
@@ -0,0 +1,476 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"PeopleFlow/backend/model"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// OpenAPIVersion ist die Version der ausgelieferten API-Beschreibung
+const OpenAPIVersion = "1.0.0"
+
+// APIRouteDoc beschreibt eine API-Route für die OpenAPI-Spezifikation.
+// Schlüssel in APIRouteDocs ist "METHODE /pfad" wie im Router registriert.
+type APIRouteDoc struct {
+	Summary  string
+	Tag      string
+	Roles    []model.UserRole // Erlaubte Rollen; leer = alle angemeldeten Benutzer
+	Public   bool             // Ohne Anmeldung erreichbar
+	Query    []string         // Query-Parameter
+	Form     []string         // Felder eines Formular-Bodys (application/x-www-form-urlencoded)
+	Files    []string         // Datei-Felder; der Body wird dann als multipart/form-data beschrieben
+	Body     interface{}      // Wert des Typs eines JSON-Bodys
+	Response interface{}      // Wert des Typs im data-Feld der Antwort
+	List     bool             // Paginierte Liste der REST-API v1 (Response ist der Elementtyp)
+	Status   int              // Erfolgsstatus, Standard 200
+	Produces string           // Abweichender Content-Type der Antwort, z.B. text/csv
+}
+
+// OpenAPIHandler liefert die OpenAPI-Spezifikation aller registrierten /api-Routen aus
+type OpenAPIHandler struct {
+	router *gin.Engine
+	once   sync.Once
+	spec   []byte
+	err    error
+}
+
+// NewOpenAPIHandler erstellt einen neuen OpenAPIHandler.
+// Die Spezifikation wird beim ersten Abruf aus den dann registrierten Routen erzeugt.
+func NewOpenAPIHandler(router *gin.Engine) *OpenAPIHandler {
+	return &OpenAPIHandler{router: router}
+}
+
+// GetSpec liefert die OpenAPI-Spezifikation als JSON aus
+func (h *OpenAPIHandler) GetSpec(c *gin.Context) {
+	h.once.Do(func() {
+		h.spec, h.err = json.MarshalIndent(BuildOpenAPISpec(h.router.Routes()), "", "  ")
+	})
+	if h.err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Fehler beim Erzeugen der API-Beschreibung",
+		})
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", h.spec)
+}
+
+// IsDocumentedAPIPath prüft, ob ein Pfad in die OpenAPI-Spezifikation gehört
+func IsDocumentedAPIPath(path string) bool {
+	return path == "/api" || strings.HasPrefix(path, "/api/")
+}
+
+// BuildOpenAPISpec erzeugt ein OpenAPI-3-Dokument für alle /api-Routen.
+// Routen ohne Eintrag in APIRouteDocs werden mit x-undocumented markiert.
+func BuildOpenAPISpec(routes gin.RoutesInfo) map[string]interface{} {
+	schemas := newOpenAPISchemas()
+	paths := map[string]interface{}{}
+	tags := map[string]bool{}
+
+	sorted := append(gin.RoutesInfo{}, routes...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Path != sorted[j].Path {
+			return sorted[i].Path < sorted[j].Path
+		}
+		return sorted[i].Method < sorted[j].Method
+	})
+
+	for _, route := range sorted {
+		if !IsDocumentedAPIPath(route.Path) {
+			continue
+		}
+
+		doc, documented := APIRouteDocs[route.Method+" "+route.Path]
+		operation := buildOpenAPIOperation(route, doc, schemas)
+		if !documented {
+			operation["x-undocumented"] = true
+		}
+		if doc.Tag != "" {
+			tags[doc.Tag] = true
+		}
+
+		path := openAPIPath(route.Path)
+		item, ok := paths[path].(map[string]interface{})
+		if !ok {
+			item = map[string]interface{}{}
+			paths[path] = item
+		}
+		item[strings.ToLower(route.Method)] = operation
+	}
+
+	tagList := make([]map[string]interface{}, 0, len(tags))
+	for _, name := range sortedKeys(tags) {
+		tagList = append(tagList, map[string]interface{}{"name": name})
+	}
+
+	schemas.define("APIError", reflect.TypeOf(APIError{}))
+	schemas.define("APIMeta", reflect.TypeOf(APIMeta{}))
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   "PeopleFlow API",
+			"version": OpenAPIVersion,
+			"description": "HTTP-API von PeopleFlow. Die versionierte REST-API unter /api/v1 verwendet einen " +
+				"einheitlichen Antwortumschlag; die übrigen /api-Routen dienen der Weboberfläche. " +
+				"Authentifizierung per Session-Cookie oder API-Token (Authorization: Bearer pf_...).",
+		},
+		"servers": []map[string]interface{}{{"url": "/"}},
+		"tags":    tagList,
+		"paths":   paths,
+		"components": map[string]interface{}{
+			"schemas": schemas.components,
+			"securitySchemes": map[string]interface{}{
+				"cookieAuth": map[string]interface{}{"type": "apiKey", "in": "cookie", "name": "token"},
+				"bearerAuth": map[string]interface{}{"type": "http", "scheme": "bearer", "description": "API-Token mit Präfix pf_"},
+			},
+		},
+	}
+}
+
+// buildOpenAPIOperation beschreibt eine einzelne Route
+func buildOpenAPIOperation(route gin.RouteInfo, doc APIRouteDoc, schemas *openAPISchemas) map[string]interface{} {
+	v1 := strings.HasPrefix(route.Path, "/api/v1/")
+
+	operation := map[string]interface{}{
+		"operationId": openAPIOperationID(route),
+		"summary":     doc.Summary,
+	}
+	if doc.Summary == "" {
+		operation["summary"] = route.Method + " " + route.Path
+	}
+	if doc.Tag != "" {
+		operation["tags"] = []string{doc.Tag}
+	}
+
+	// Berechtigungen
+	if doc.Public {
+		operation["security"] = []interface{}{}
+	} else {
+		operation["security"] = []map[string][]string{{"cookieAuth": {}}, {"bearerAuth": {}}}
+		roles := make([]string, 0, len(doc.Roles))
+		for _, role := range doc.Roles {
+			roles = append(roles, string(role))
+		}
+		operation["x-roles"] = roles
+		if len(roles) > 0 {
+			operation["description"] = "Erlaubte Rollen: " + strings.Join(roles, ", ")
+		} else {
+			operation["description"] = "Für alle angemeldeten Benutzer"
+		}
+	}
+
+	// Parameter
+	var parameters []map[string]interface{}
+	for _, name := range openAPIPathParams(route.Path) {
+		parameters = append(parameters, map[string]interface{}{
+			"name": name, "in": "path", "required": true, "schema": map[string]interface{}{"type": "string"},
+		})
+	}
+	query := doc.Query
+	if doc.List {
+		query = append([]string{"page", "pageSize", "sort"}, query...)
+	}
+	for _, name := range query {
+		parameters = append(parameters, map[string]interface{}{
+			"name": name, "in": "query", "required": false, "schema": map[string]interface{}{"type": "string"},
+		})
+	}
+	if len(parameters) > 0 {
+		operation["parameters"] = parameters
+	}
+
+	// Request-Body
+	switch {
+	case doc.Body != nil:
+		operation["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{"schema": schemas.schemaFor(reflect.TypeOf(doc.Body))},
+			},
+		}
+	case len(doc.Files) > 0:
+		properties := map[string]interface{}{}
+		for _, name := range doc.Form {
+			properties[name] = map[string]interface{}{"type": "string"}
+		}
+		for _, name := range doc.Files {
+			properties[name] = map[string]interface{}{"type": "string", "format": "binary"}
+		}
+		operation["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content": map[string]interface{}{
+				"multipart/form-data": map[string]interface{}{
+					"schema": map[string]interface{}{"type": "object", "properties": properties, "required": doc.Files},
+				},
+			},
+		}
+	case len(doc.Form) > 0:
+		properties := map[string]interface{}{}
+		for _, name := range doc.Form {
+			properties[name] = map[string]interface{}{"type": "string"}
+		}
+		operation["requestBody"] = map[string]interface{}{
+			"content": map[string]interface{}{
+				"application/x-www-form-urlencoded": map[string]interface{}{
+					"schema": map[string]interface{}{"type": "object", "properties": properties},
+				},
+			},
+		}
+	}
+
+	// Antworten
+	status := doc.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	responses := map[string]interface{}{}
+	success := map[string]interface{}{"description": http.StatusText(status)}
+	switch {
+	case status == http.StatusNoContent:
+	case doc.Produces != "":
+		success["content"] = map[string]interface{}{
+			doc.Produces: map[string]interface{}{"schema": map[string]interface{}{"type": "string", "format": "binary"}},
+		}
+	default:
+		success["content"] = map[string]interface{}{
+			"application/json": map[string]interface{}{"schema": openAPIEnvelope(v1, doc, schemas)},
+		}
+	}
+	responses[fmt.Sprint(status)] = success
+
+	errorSchema := openAPIErrorSchema(v1)
+	addError := func(code int) {
+		responses[fmt.Sprint(code)] = map[string]interface{}{
+			"description": http.StatusText(code),
+			"content":     map[string]interface{}{"application/json": map[string]interface{}{"schema": errorSchema}},
+		}
+	}
+	if !doc.Public {
+		addError(http.StatusUnauthorized)
+		addError(http.StatusForbidden)
+	}
+	if v1 {
+		addError(http.StatusBadRequest)
+		if len(openAPIPathParams(route.Path)) > 0 {
+			addError(http.StatusNotFound)
+		}
+		if doc.Body != nil || len(doc.Files) > 0 {
+			addError(http.StatusUnprocessableEntity)
+		}
+	}
+	addError(http.StatusInternalServerError)
+	operation["responses"] = responses
+
+	return operation
+}
+
+// openAPIEnvelope beschreibt den Antwortumschlag einer erfolgreichen Antwort
+func openAPIEnvelope(v1 bool, doc APIRouteDoc, schemas *openAPISchemas) map[string]interface{} {
+	data := map[string]interface{}{}
+	if doc.Response != nil {
+		data = schemas.schemaFor(reflect.TypeOf(doc.Response))
+	}
+	if doc.List {
+		data = map[string]interface{}{"type": "array", "items": data}
+	}
+
+	properties := map[string]interface{}{
+		"success": map[string]interface{}{"type": "boolean"},
+		"data":    data,
+	}
+	envelope := map[string]interface{}{"type": "object", "properties": properties, "required": []string{"success"}}
+	if doc.List {
+		properties["meta"] = map[string]interface{}{"$ref": "#/components/schemas/APIMeta"}
+	}
+	if !v1 {
+		// Die Routen der Weboberfläche liefern je nach Endpunkt weitere Felder
+		properties["message"] = map[string]interface{}{"type": "string"}
+		envelope["additionalProperties"] = true
+	}
+	return envelope
+}
+
+// openAPIErrorSchema beschreibt eine Fehlerantwort
+func openAPIErrorSchema(v1 bool) map[string]interface{} {
+	errorField := map[string]interface{}{"type": "string"}
+	if v1 {
+		errorField = map[string]interface{}{"$ref": "#/components/schemas/APIError"}
+	}
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"success": map[string]interface{}{"type": "boolean"},
+			"error":   errorField,
+		},
+	}
+}
+
+var openAPIPathParamPattern = regexp.MustCompile(`[:*]([A-Za-z0-9_]+)`)
+
+// openAPIPath wandelt einen Gin-Pfad (/employees/:id) in die OpenAPI-Schreibweise (/employees/{id}) um
+func openAPIPath(path string) string {
+	return openAPIPathParamPattern.ReplaceAllString(path, "{$1}")
+}
+
+// openAPIPathParams gibt die Namen der Pfadparameter zurück
+func openAPIPathParams(path string) []string {
+	var names []string
+	for _, match := range openAPIPathParamPattern.FindAllStringSubmatch(path, -1) {
+		names = append(names, match[1])
+	}
+	return names
+}
+
+// openAPIOperationID leitet eine eindeutige operationId aus Methode und Pfad ab
+func openAPIOperationID(route gin.RouteInfo) string {
+	parts := []string{strings.ToLower(route.Method)}
+	for _, segment := range strings.FieldsFunc(route.Path, func(r rune) bool {
+		return r == '/' || r == '-' || r == '.' || r == '_'
+	}) {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segment = "by" + strings.ToUpper(segment[1:2]) + segment[2:]
+		}
+		parts = append(parts, strings.ToUpper(segment[:1])+segment[1:])
+	}
+	return strings.Join(parts, "")
+}
+
+// openAPISchemas erzeugt JSON-Schemas aus Go-Typen und sammelt benannte Structs als Komponenten
+type openAPISchemas struct {
+	components map[string]interface{}
+	names      map[reflect.Type]string
+}
+
+func newOpenAPISchemas() *openAPISchemas {
+	return &openAPISchemas{
+		components: map[string]interface{}{},
+		names:      map[reflect.Type]string{},
+	}
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	objectIDType = reflect.TypeOf(primitive.ObjectID{})
+)
+
+// define legt eine Komponente für einen Struct-Typ an und gibt ihren Namen zurück
+func (s *openAPISchemas) define(name string, t reflect.Type) string {
+	if existing, ok := s.names[t]; ok {
+		return existing
+	}
+	if _, taken := s.components[name]; taken {
+		name = strings.ReplaceAll(t.PkgPath(), "/", ".") + "." + name
+	}
+	s.names[t] = name
+	s.components[name] = map[string]interface{}{} // Platzhalter für rekursive Typen
+	s.components[name] = s.structSchema(t)
+	return name
+}
+
+// schemaFor gibt das Schema eines Typs zurück
+func (s *openAPISchemas) schemaFor(t reflect.Type) map[string]interface{} {
+	switch t {
+	case timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case objectIDType:
+		return map[string]interface{}{"type": "string", "pattern": "^[0-9a-f]{24}$"}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		schema := s.schemaFor(t.Elem())
+		if _, isRef := schema["$ref"]; isRef {
+			return map[string]interface{}{"allOf": []interface{}{schema}, "nullable": true}
+		}
+		schema["nullable"] = true
+		return schema
+	case reflect.Struct:
+		if t.Name() == "" {
+			return s.structSchema(t)
+		}
+		return map[string]interface{}{"$ref": "#/components/schemas/" + s.define(t.Name(), t)}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "format": "byte"}
+		}
+		return map[string]interface{}{"type": "array", "items": s.schemaFor(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": s.schemaFor(t.Elem())}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return map[string]interface{}{"type": "integer", "format": "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// structSchema beschreibt die JSON-Felder eines Structs; eingebettete Structs werden eingeflacht
+func (s *openAPISchemas) structSchema(t reflect.Type) map[string]interface{} {
+	properties := map[string]interface{}{}
+	s.collectFields(t, properties)
+	return map[string]interface{}{"type": "object", "properties": properties}
+}
+
+func (s *openAPISchemas) collectFields(t reflect.Type, properties map[string]interface{}) {
+	// Felder des äußeren Structs haben wie bei encoding/json Vorrang vor eingebetteten
+	var embedded []reflect.Type
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			embedded = append(embedded, field.Type)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = s.schemaFor(field.Type)
+	}
+
+	for _, inner := range embedded {
+		innerProperties := map[string]interface{}{}
+		s.collectFields(inner, innerProperties)
+		for name, schema := range innerProperties {
+			if _, exists := properties[name]; !exists {
+				properties[name] = schema
+			}
+		}
+	}
+}
+
+// sortedKeys gibt die Schlüssel einer Menge sortiert zurück
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
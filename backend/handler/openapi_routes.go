@@ -0,0 +1,242 @@
+package handler
+
+import (
+	"net/http"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/service"
+)
+
+// Rollenlisten der API-Dokumentation (entsprechen den Middlewares im Router)
+var (
+	docAdmin     = []model.UserRole{model.RoleAdmin}
+	docApprovers = []model.UserRole{model.RoleAdmin, model.RoleManager}
+	docStaff     = []model.UserRole{model.RoleAdmin, model.RoleManager, model.RoleHR}
+	docAdminHR   = []model.UserRole{model.RoleAdmin, model.RoleHR}
+)
+
+// APIRouteDocs dokumentiert alle /api-Routen für die OpenAPI-Spezifikation.
+// Der Contract-Test im Router-Paket schlägt fehl, wenn eine Route hier fehlt
+// oder die Rollen nicht zu den Middlewares im Router passen.
+var APIRouteDocs = map[string]APIRouteDoc{
+	"GET /api/openapi.json": {Summary: "OpenAPI-Spezifikation", Tag: "Meta", Public: true, Response: map[string]interface{}{}},
+
+	// Authentifizierung
+	"POST /api/auth/forgot-password": {Summary: "Passwort-Reset anfordern", Tag: "Auth", Public: true, Form: []string{"email"}},
+	"POST /api/auth/reset-password":  {Summary: "Passwort mit Reset-Token zurücksetzen", Tag: "Auth", Public: true, Form: []string{"token", "password", "confirm_password"}},
+	"POST /api/auth/activate":        {Summary: "Eingeladenes Konto aktivieren", Tag: "Auth", Public: true, Form: []string{"token", "password", "confirm_password", "totp_secret", "otp"}},
+
+	// Benachrichtigungen
+	"GET /api/notifications":              {Summary: "Eigene Benachrichtigungen", Tag: "Benachrichtigungen", Query: []string{"unread", "page"}, Response: []model.Notification{}},
+	"GET /api/notifications/unread-count": {Summary: "Anzahl ungelesener Benachrichtigungen", Tag: "Benachrichtigungen"},
+	"POST /api/notifications/read-all":    {Summary: "Alle Benachrichtigungen als gelesen markieren", Tag: "Benachrichtigungen"},
+	"POST /api/notifications/:id/read":    {Summary: "Benachrichtigung als gelesen markieren", Tag: "Benachrichtigungen"},
+	"GET /api/notifications/preferences":  {Summary: "Benachrichtigungseinstellungen abrufen", Tag: "Benachrichtigungen"},
+	"PUT /api/notifications/preferences":  {Summary: "Benachrichtigungseinstellungen speichern", Tag: "Benachrichtigungen", Form: []string{"inApp", "email"}},
+
+	// Berichte per E-Mail
+	"GET /api/report-subscriptions":           {Summary: "Eigene Berichts-Abonnements", Tag: "Berichte", Response: []model.ReportSubscription{}},
+	"GET /api/report-subscriptions/types":     {Summary: "Abonnierbare Berichtsarten", Tag: "Berichte", Response: []service.ReportTypeInfo{}},
+	"POST /api/report-subscriptions":          {Summary: "Bericht abonnieren", Tag: "Berichte", Form: []string{"type", "frequency", "weekday", "dayOfMonth", "hour", "sections", "department", "active"}, Response: model.ReportSubscription{}},
+	"PUT /api/report-subscriptions/:id":       {Summary: "Berichts-Abonnement ändern", Tag: "Berichte", Form: []string{"frequency", "weekday", "dayOfMonth", "hour", "sections", "department", "active"}, Response: model.ReportSubscription{}},
+	"DELETE /api/report-subscriptions/:id":    {Summary: "Berichts-Abonnement kündigen", Tag: "Berichte"},
+	"POST /api/report-subscriptions/:id/send": {Summary: "Bericht sofort senden", Tag: "Berichte"},
+
+	// API-Tokens
+	"GET /api/tokens":              {Summary: "Eigene API-Tokens auflisten", Tag: "API-Tokens"},
+	"POST /api/tokens":             {Summary: "Persönliches API-Token erstellen", Tag: "API-Tokens", Form: []string{"name", "scopes", "expiresInDays"}},
+	"DELETE /api/tokens/:id":       {Summary: "Eigenes API-Token widerrufen", Tag: "API-Tokens"},
+	"GET /api/admin/tokens":        {Summary: "Alle API-Tokens auflisten", Tag: "API-Tokens", Roles: docAdmin},
+	"POST /api/admin/tokens":       {Summary: "Service-Account-Token erstellen", Tag: "API-Tokens", Roles: docAdmin, Form: []string{"name", "role", "scopes", "expiresInDays"}},
+	"DELETE /api/admin/tokens/:id": {Summary: "API-Token widerrufen", Tag: "API-Tokens", Roles: docAdmin},
+
+	// Webhooks
+	"GET /api/webhooks":                                       {Summary: "Webhook-Endpunkte auflisten", Tag: "Webhooks", Roles: docAdmin, Response: []model.WebhookEndpoint{}},
+	"POST /api/webhooks":                                      {Summary: "Webhook-Endpunkt registrieren", Tag: "Webhooks", Roles: docAdmin, Form: []string{"name", "url", "events"}},
+	"GET /api/webhooks/events":                                {Summary: "Abonnierbare Ereignistypen", Tag: "Webhooks", Roles: docAdmin},
+	"PUT /api/webhooks/:id":                                   {Summary: "Webhook-Endpunkt ändern", Tag: "Webhooks", Roles: docAdmin, Form: []string{"name", "url", "events", "active"}, Response: model.WebhookEndpoint{}},
+	"DELETE /api/webhooks/:id":                                {Summary: "Webhook-Endpunkt löschen", Tag: "Webhooks", Roles: docAdmin},
+	"POST /api/webhooks/:id/rotate-secret":                    {Summary: "Signatur-Secret erneuern", Tag: "Webhooks", Roles: docAdmin},
+	"GET /api/webhooks/:id/deliveries":                        {Summary: "Zustellprotokoll eines Endpunkts", Tag: "Webhooks", Roles: docAdmin, Query: []string{"page"}, Response: []model.WebhookDelivery{}},
+	"POST /api/webhooks/:id/deliveries/:deliveryId/redeliver": {Summary: "Ereignis erneut zustellen", Tag: "Webhooks", Roles: docAdmin, Response: model.WebhookDelivery{}},
+	"GET /api/chat-channels":                                  {Summary: "Slack-/Teams-Kanäle auflisten", Tag: "Chat", Roles: docAdmin, Response: []model.ChatChannel{}},
+	"POST /api/chat-channels":                                 {Summary: "Slack-/Teams-Kanal hinzufügen", Tag: "Chat", Roles: docAdmin, Form: []string{"name", "provider", "webhookUrl", "events", "departments", "active"}, Response: model.ChatChannel{}},
+	"GET /api/chat-channels/events":                           {Summary: "Abonnierbare Chat-Ereignisse", Tag: "Chat", Roles: docAdmin},
+	"PUT /api/chat-channels/:id":                              {Summary: "Slack-/Teams-Kanal ändern", Tag: "Chat", Roles: docAdmin, Form: []string{"name", "provider", "webhookUrl", "events", "departments", "active"}, Response: model.ChatChannel{}},
+	"DELETE /api/chat-channels/:id":                           {Summary: "Slack-/Teams-Kanal löschen", Tag: "Chat", Roles: docAdmin},
+	"POST /api/chat-channels/:id/test":                        {Summary: "Testnachricht senden", Tag: "Chat", Roles: docAdmin},
+
+	// E-Mail-Vorlagen
+	"GET /api/email-templates":                     {Summary: "E-Mail-Vorlagen auflisten", Tag: "E-Mail-Vorlagen", Roles: docAdmin, Response: []service.EmailTemplateInfo{}},
+	"GET /api/email-templates/:key/:lang":          {Summary: "E-Mail-Vorlage mit Variablen abrufen", Tag: "E-Mail-Vorlagen", Roles: docAdmin},
+	"PUT /api/email-templates/:key/:lang":          {Summary: "E-Mail-Vorlage anpassen", Tag: "E-Mail-Vorlagen", Roles: docAdmin, Form: []string{"subject", "body"}, Response: model.EmailTemplate{}},
+	"DELETE /api/email-templates/:key/:lang":       {Summary: "Standardvorlage wiederherstellen", Tag: "E-Mail-Vorlagen", Roles: docAdmin},
+	"POST /api/email-templates/:key/:lang/preview": {Summary: "Vorschau mit Beispieldaten", Tag: "E-Mail-Vorlagen", Roles: docAdmin, Form: []string{"subject", "body"}, Response: service.EmailPreview{}},
+
+	// E-Mail-Postausgang
+	"GET /api/email-outbox":            {Summary: "Postausgang mit Versandstatus", Tag: "E-Mail-Postausgang", Roles: docAdmin, Query: []string{"status", "page"}, Response: []model.OutboxEmail{}},
+	"GET /api/email-outbox/:id":        {Summary: "E-Mail aus dem Postausgang mit Inhalt", Tag: "E-Mail-Postausgang", Roles: docAdmin, Response: model.OutboxEmail{}},
+	"POST /api/email-outbox/:id/retry": {Summary: "E-Mail erneut senden", Tag: "E-Mail-Postausgang", Roles: docAdmin, Response: model.OutboxEmail{}},
+
+	// Hintergrundjobs
+	"GET /api/jobs":               {Summary: "Hintergrundjobs mit Zeitplan und letztem Ergebnis", Tag: "Hintergrundjobs", Roles: docAdmin, Response: []service.JobInfo{}},
+	"PUT /api/jobs/:name":         {Summary: "Zeitplan (Cron-Ausdruck) ändern; leer stellt den Standard wieder her", Tag: "Hintergrundjobs", Roles: docAdmin, Form: []string{"schedule"}, Response: service.JobInfo{}},
+	"GET /api/jobs/:name/runs":    {Summary: "Laufhistorie mit Dauer, Ergebnis und Fehlern", Tag: "Hintergrundjobs", Roles: docAdmin, Query: []string{"page"}, Response: []model.JobRun{}},
+	"POST /api/jobs/:name/run":    {Summary: "Job sofort starten", Tag: "Hintergrundjobs", Roles: docAdmin, Response: model.JobRun{}},
+	"POST /api/jobs/:name/pause":  {Summary: "Planmäßige Ausführung pausieren", Tag: "Hintergrundjobs", Roles: docAdmin, Response: service.JobInfo{}},
+	"POST /api/jobs/:name/resume": {Summary: "Pausierten Job fortsetzen", Tag: "Hintergrundjobs", Roles: docAdmin, Response: service.JobInfo{}},
+
+	// System-Einstellungen (Weboberfläche)
+	"GET /api/settings":                             {Summary: "System-Einstellungen abrufen", Tag: "Einstellungen", Response: model.SystemSettings{}},
+	"POST /api/settings":                            {Summary: "System-Einstellungen speichern", Tag: "Einstellungen", Roles: docAdmin, Form: []string{"companyName", "language", "state", "requireTwoFactor"}, Response: model.SystemSettings{}},
+	"POST /api/settings/company-name":               {Summary: "Firmennamen ändern", Tag: "Einstellungen", Roles: docAdmin, Form: []string{"company-name"}},
+	"POST /api/settings/language":                   {Summary: "Sprache ändern", Tag: "Einstellungen", Roles: docAdmin, Form: []string{"language"}},
+	"POST /api/settings/state":                      {Summary: "Bundesland ändern", Tag: "Einstellungen", Roles: docAdmin, Form: []string{"state"}},
+	"POST /api/settings/email":                      {Summary: "E-Mail-Einstellungen speichern", Tag: "Einstellungen", Roles: docAdmin, Form: []string{"smtp-host", "smtp-port", "smtp-user", "smtp-pass", "from-email", "from-name", "use-tls", "email-enabled"}},
+	"GET /api/settings/email/test":                  {Summary: "Test-E-Mail senden", Tag: "Einstellungen", Roles: docAdmin, Query: []string{"email"}},
+	"GET /api/settings/password-policy":             {Summary: "Passwortrichtlinie abrufen", Tag: "Einstellungen", Roles: docAdmin},
+	"POST /api/settings/password-policy":            {Summary: "Passwortrichtlinie speichern", Tag: "Einstellungen", Roles: docAdmin, Form: []string{"minLength", "requireUppercase", "requireLowercase", "requireDigit", "requireSpecial", "maxAgeDays", "historySize", "checkBreached"}},
+	"POST /api/settings/password-policy/breached":   {Summary: "Liste kompromittierter Passwort-Hashes hochladen", Tag: "Einstellungen", Roles: docAdmin, Files: []string{"file"}},
+	"DELETE /api/settings/password-policy/breached": {Summary: "Hochgeladene Passwort-Hashes löschen", Tag: "Einstellungen", Roles: docAdmin},
+
+	// Feiertage
+	"GET /api/holidays":              {Summary: "Feiertage eines Jahres", Tag: "Feiertage", Query: []string{"year", "state"}},
+	"GET /api/holidays/check":        {Summary: "Prüfen, ob ein Datum ein Feiertag ist", Tag: "Feiertage", Query: []string{"date", "state"}},
+	"GET /api/holidays/working-days": {Summary: "Arbeitstage in einem Zeitraum", Tag: "Feiertage", Query: []string{"startDate", "endDate", "state"}},
+	"GET /api/holidays/current-year": {Summary: "Feiertage des laufenden Jahres", Tag: "Feiertage"},
+
+	// Zeiterfassung, Statistik und Überstunden (Weboberfläche)
+	"GET /api/timetracking/employee/:id":                   {Summary: "Zeiteinträge eines Mitarbeiters", Tag: "Zeiterfassung", Query: []string{"startDate", "endDate", "projectId"}},
+	"POST /api/timetracking/recalculate-overtime":          {Summary: "Überstunden aller Mitarbeiter neu berechnen", Tag: "Zeiterfassung", Roles: docStaff},
+	"GET /api/timetracking/employee/:id/overtime":          {Summary: "Überstunden-Details eines Mitarbeiters", Tag: "Zeiterfassung"},
+	"POST /api/timetracking/employee/:id/overtime":         {Summary: "Überstunden eines Mitarbeiters neu berechnen", Tag: "Zeiterfassung", Roles: docStaff},
+	"POST /api/statistics/filter":                          {Summary: "Gefilterte Statistiken", Tag: "Statistik", Body: FilterParams{}},
+	"POST /api/statistics/extended":                        {Summary: "Erweiterte Statistiken", Tag: "Statistik", Body: FilterParams{}},
+	"POST /api/overtime/recalculate":                       {Summary: "Alle Überstunden neu berechnen", Tag: "Überstunden", Roles: docStaff},
+	"GET /api/overtime/export":                             {Summary: "Überstunden als CSV exportieren", Tag: "Überstunden", Query: []string{"departmentFilter", "balanceFilter"}, Produces: "text/csv"},
+	"GET /api/overtime/employee/:id":                       {Summary: "Überstunden-Details eines Mitarbeiters", Tag: "Überstunden"},
+	"POST /api/overtime/employee/:id/adjustment":           {Summary: "Überstunden-Anpassung einreichen", Tag: "Überstunden", Roles: docStaff, Form: []string{"type", "hours", "reason", "description"}, Response: model.OvertimeAdjustment{}},
+	"GET /api/overtime/employee/:id/adjustments":           {Summary: "Überstunden-Anpassungen eines Mitarbeiters", Tag: "Überstunden", Response: []model.OvertimeAdjustment{}},
+	"POST /api/overtime/adjustments/:adjustmentId/approve": {Summary: "Überstunden-Anpassung genehmigen oder ablehnen", Tag: "Überstunden", Roles: docApprovers, Form: []string{"action"}},
+	"GET /api/overtime/adjustments/pending":                {Summary: "Offene Überstunden-Anpassungen", Tag: "Überstunden", Roles: docApprovers},
+	"DELETE /api/overtime/adjustments/:adjustmentId":       {Summary: "Überstunden-Anpassung löschen", Tag: "Überstunden", Roles: docApprovers},
+
+	// Abwesenheiten (Weboberfläche)
+	"POST /api/absence/request":                        {Summary: "Abwesenheit beantragen", Tag: "Abwesenheiten", Form: []string{"employeeId", "type", "startDate", "endDate", "reason", "notes"}},
+	"POST /api/absence/:employeeId/:absenceId/approve": {Summary: "Abwesenheitsantrag genehmigen", Tag: "Abwesenheiten", Roles: docApprovers},
+	"POST /api/absence/:employeeId/:absenceId/reject":  {Summary: "Abwesenheitsantrag ablehnen", Tag: "Abwesenheiten", Roles: docApprovers},
+
+	// Integrationen
+	"GET /api/integrations/status":                          {Summary: "Status aller Integrationen", Tag: "Integrationen"},
+	"POST /api/integrations/:type/save":                     {Summary: "Zugangsdaten einer Integration speichern", Tag: "Integrationen", Roles: docAdmin, Form: []string{"timebutler-api", "erfasst123-email", "erfasst123-password", "erfasst123-sync-start-date", "personio-client-id", "personio-client-secret", "personio-attribute-mapping"}},
+	"GET /api/integrations/:type/test":                      {Summary: "Verbindung einer Integration testen", Tag: "Integrationen"},
+	"POST /api/integrations/:type/remove":                   {Summary: "Integration entfernen", Tag: "Integrationen", Roles: docAdmin},
+	"POST /api/integrations/:type/sync/:capability":         {Summary: "Bereich einer Integration synchronisieren", Tag: "Integrationen", Roles: docAdminHR, Query: []string{"year", "startDate", "endDate", "dryRun"}, Status: http.StatusAccepted, Response: model.SyncJob{}},
+	"POST /api/integrations/:type/full-sync":                {Summary: "Vollständige Synchronisierung einer Integration starten", Tag: "Integrationen", Roles: docAdminHR, Query: []string{"dryRun"}, Status: http.StatusAccepted, Response: model.SyncJob{}},
+	"GET /api/integrations/:type/matching":                  {Summary: "Abgleich externer Benutzer mit Mitarbeitern", Tag: "Integrationen", Roles: docAdminHR, Response: model.IdentityMatchReport{}},
+	"POST /api/integrations/:type/matching":                 {Summary: "Externen Benutzer einem Mitarbeiter zuordnen", Tag: "Integrationen", Roles: docAdminHR, Form: []string{"externalId", "employeeId"}, Response: model.IntegrationMapping{}},
+	"POST /api/integrations/:type/matching/create-employee": {Summary: "Mitarbeiter aus externem Benutzer anlegen", Tag: "Integrationen", Roles: docAdminHR, Form: []string{"externalId"}, Status: http.StatusCreated, Response: model.Employee{}},
+	"DELETE /api/integrations/:type/matching/:externalId":   {Summary: "Manuelle Zuordnung entfernen", Tag: "Integrationen", Roles: docAdminHR},
+	"GET /api/integrations/:type/field-ownership":           {Summary: "Zuständigkeiten der synchronisierten Felder", Tag: "Integrationen", Roles: docAdminHR, Response: []service.FieldOwnershipSetting{}},
+	"POST /api/integrations/:type/field-ownership":          {Summary: "Zuständigkeit eines synchronisierten Feldes festlegen", Tag: "Integrationen", Roles: docAdmin, Form: []string{"field", "ownership"}},
+	"GET /api/integrations/:type/conflicts":                 {Summary: "Konflikte zwischen PeopleFlow und einer Integration", Tag: "Integrationen", Roles: docAdminHR, Query: []string{"includeResolved"}, Response: []model.FieldConflict{}},
+	"POST /api/integrations/conflicts/:id/resolve":          {Summary: "Konflikt lösen", Tag: "Integrationen", Roles: docAdminHR, Form: []string{"resolution"}, Response: model.FieldConflict{}},
+	"POST /api/integrations/timebutler/set-absence-push":    {Summary: "Übertragung von Abwesenheiten an Timebutler schalten", Tag: "Integrationen", Roles: docAdmin, Form: []string{"enabled"}},
+	"GET /api/integrations/123erfasst/sync-status":          {Summary: "123erfasst-Synchronisationsstatus", Tag: "Integrationen"},
+	"POST /api/integrations/123erfasst/set-auto-sync":       {Summary: "Automatische 123erfasst-Synchronisation schalten", Tag: "Integrationen", Roles: docAdmin, Form: []string{"enabled"}},
+	"POST /api/integrations/123erfasst/set-sync-start-date": {Summary: "Startdatum der 123erfasst-Synchronisation setzen", Tag: "Integrationen", Roles: docAdmin, Form: []string{"startDate"}},
+	"POST /api/integrations/123erfasst/cleanup-duplicates":  {Summary: "Doppelte Zeiteinträge bereinigen", Tag: "Integrationen", Roles: docAdmin},
+	"POST /api/integrations/123erfasst/test-projects":       {Summary: "123erfasst-Projekt-API testen", Tag: "Integrationen", Roles: docAdmin},
+	"GET /api/integrations/sync-jobs":                       {Summary: "Letzte Synchronisierungen", Tag: "Integrationen", Roles: docAdminHR, Response: []model.SyncJob{}},
+	"GET /api/integrations/sync-jobs/:id":                   {Summary: "Fortschritt einer Synchronisierung", Tag: "Integrationen", Roles: docAdminHR, Response: model.SyncJob{}},
+	"POST /api/integrations/sync-jobs/:id/apply":            {Summary: "Probelauf einer Synchronisierung übernehmen", Tag: "Integrationen", Roles: docAdminHR, Status: http.StatusAccepted, Response: model.SyncJob{}},
+	"GET /api/integrations/sync-jobs/:id/events":            {Summary: "Fortschritt einer Synchronisierung als Server-Sent Events", Tag: "Integrationen", Roles: docAdminHR, Produces: "text/event-stream"},
+
+	// DATEV-Lohnexport
+	"GET /api/payroll/datev/settings":  {Summary: "Einstellungen des DATEV-Lohnexports", Tag: "Lohnexport", Roles: docAdminHR, Response: model.DatevSettings{}},
+	"POST /api/payroll/datev/settings": {Summary: "Einstellungen des DATEV-Lohnexports speichern", Tag: "Lohnexport", Roles: docAdmin, Form: []string{"consultantNumber", "clientNumber", "target", "absenceWageTypes", "wageTypes", "overtimePayoutWageType"}, Response: model.DatevSettings{}},
+	"GET /api/payroll/datev/preview":   {Summary: "Bewegungsdaten eines Monats mit Hinweisen", Tag: "Lohnexport", Roles: docAdminHR, Query: []string{"month"}, Response: model.DatevExport{}},
+	"GET /api/payroll/datev/export":    {Summary: "Bewegungsdaten eines Monats im DATEV-ASCII-Format", Tag: "Lohnexport", Roles: docAdminHR, Query: []string{"month"}, Produces: "text/plain"},
+
+	// Mitarbeiterimport
+	"GET /api/employees/import/fields": {Summary: "Importierbare Mitarbeiterfelder für die Spaltenzuordnung", Tag: "Mitarbeiter", Roles: docStaff, Response: []model.EmployeeImportField{}},
+	"POST /api/employees/import":       {Summary: "Mitarbeiter aus CSV oder XLSX importieren (standardmäßig Probelauf)", Tag: "Mitarbeiter", Roles: docStaff, Form: []string{"mapping", "dryRun"}, Files: []string{"file"}, Response: model.EmployeeImportReport{}},
+
+	// Mitarbeiterexport
+	"GET /api/employees/export/columns": {Summary: "Exportierbare Spalten der Mitarbeiterliste", Tag: "Mitarbeiter", Roles: docStaff, Response: []model.EmployeeExportColumn{}},
+	"GET /api/employees/export":         {Summary: "Mitarbeiterliste als CSV, XLSX oder JSON exportieren", Tag: "Mitarbeiter", Roles: docStaff, Query: []string{"format", "columns", "q", "status", "department", "sort"}, Produces: "application/octet-stream"},
+
+	// Stundenzettel
+	"GET /api/timesheets/employees/:id":              {Summary: "Monatlicher Stundenzettel mit Stand der Bestätigungen", Tag: "Zeiterfassung", Query: []string{"month"}, Response: timesheetResponse{}},
+	"GET /api/timesheets/employees/:id/pdf":          {Summary: "Monatlicher Stundenzettel als PDF", Tag: "Zeiterfassung", Query: []string{"month"}, Produces: "application/pdf"},
+	"POST /api/timesheets/employees/:id/acknowledge": {Summary: "Eigenen Stundenzettel eines abgeschlossenen Monats bestätigen", Tag: "Zeiterfassung", Form: []string{"month"}, Response: model.TimesheetStatus{}},
+	"POST /api/timesheets/employees/:id/approve":     {Summary: "Stundenzettel als Vorgesetzter gegenzeichnen", Tag: "Zeiterfassung", Roles: docApprovers, Form: []string{"month"}, Response: model.TimesheetStatus{}},
+	"GET /api/timesheets/department":                 {Summary: "Stundenzettel einer Abteilung als ZIP-Archiv", Tag: "Zeiterfassung", Roles: docStaff, Query: []string{"department", "month"}, Produces: "application/zip"},
+
+	// Gehaltshistorie und Personalkosten
+	"GET /api/employees/:id/salary-history":             {Summary: "Gehaltshistorie und Sonderzahlungen eines Mitarbeiters", Tag: "Personalkosten", Roles: docApprovers, Response: salaryHistoryResponse{}},
+	"POST /api/employees/:id/salary-history":            {Summary: "Gehaltsänderung mit Stichtag eintragen", Tag: "Personalkosten", Roles: docApprovers, Form: []string{"effectiveDate", "monthlySalary", "employerContributionPercent", "reason"}, Response: salaryHistoryResponse{}},
+	"DELETE /api/employees/:id/salary-history/:entryId": {Summary: "Eintrag der Gehaltshistorie löschen", Tag: "Personalkosten", Roles: docApprovers, Response: salaryHistoryResponse{}},
+	"POST /api/employees/:id/bonuses":                   {Summary: "Sonderzahlung eintragen", Tag: "Personalkosten", Roles: docApprovers, Form: []string{"paymentDate", "amount", "description"}, Response: salaryHistoryResponse{}},
+	"DELETE /api/employees/:id/bonuses/:bonusId":        {Summary: "Sonderzahlung löschen", Tag: "Personalkosten", Roles: docApprovers, Response: salaryHistoryResponse{}},
+	"GET /api/labor-costs":                              {Summary: "Monatlicher Verlauf der Personalkosten nach Abteilung und Standort", Tag: "Personalkosten", Roles: docApprovers, Query: []string{"months", "department", "location"}, Response: model.LaborCostTrend{}},
+	"POST /api/labor-costs/snapshots":                   {Summary: "Personalkosten des Vormonats neu festschreiben", Tag: "Personalkosten", Roles: docAdmin, Form: []string{"month"}, Response: model.LaborCostSnapshot{}},
+
+	// AJAX-Endpunkte der Mitarbeiterverwaltung
+	"DELETE /api/employees/:id":   {Summary: "Mitarbeiter löschen (Weboberfläche)", Tag: "Mitarbeiter"},
+	"GET /api/employees/:id/name": {Summary: "Namen eines Mitarbeiters abrufen", Tag: "Mitarbeiter"},
+
+	// REST-API v1: Mitarbeiter
+	"GET /api/v1/employees":        {Summary: "Mitarbeiter auflisten", Tag: "v1 Mitarbeiter", List: true, Query: []string{"q", "department", "status", "managerId"}, Response: APIEmployee{}},
+	"POST /api/v1/employees":       {Summary: "Mitarbeiter anlegen", Tag: "v1 Mitarbeiter", Roles: docStaff, Body: APIEmployeeInput{}, Response: APIEmployee{}, Status: http.StatusCreated},
+	"GET /api/v1/employees/:id":    {Summary: "Mitarbeiter abrufen", Tag: "v1 Mitarbeiter", Response: APIEmployee{}},
+	"PATCH /api/v1/employees/:id":  {Summary: "Mitarbeiter aktualisieren", Tag: "v1 Mitarbeiter", Roles: docStaff, Body: APIEmployeeInput{}, Response: APIEmployee{}},
+	"DELETE /api/v1/employees/:id": {Summary: "Mitarbeiter deaktivieren", Tag: "v1 Mitarbeiter", Roles: docStaff, Status: http.StatusNoContent},
+
+	// REST-API v1: Abwesenheiten
+	"GET /api/v1/absences":                                   {Summary: "Abwesenheiten auflisten", Tag: "v1 Abwesenheiten", List: true, Query: []string{"employeeId", "department", "type", "status", "from", "to"}, Response: APIAbsence{}},
+	"GET /api/v1/employees/:id/absences":                     {Summary: "Abwesenheiten eines Mitarbeiters", Tag: "v1 Abwesenheiten", List: true, Query: []string{"type", "status", "from", "to"}, Response: APIAbsence{}},
+	"POST /api/v1/employees/:id/absences":                    {Summary: "Abwesenheit anlegen", Tag: "v1 Abwesenheiten", Body: APIAbsenceInput{}, Response: APIAbsence{}, Status: http.StatusCreated},
+	"PATCH /api/v1/employees/:id/absences/:absenceId/status": {Summary: "Abwesenheit genehmigen, ablehnen oder stornieren", Tag: "v1 Abwesenheiten", Body: APIAbsenceStatusInput{}, Response: APIAbsence{}},
+	"DELETE /api/v1/employees/:id/absences/:absenceId":       {Summary: "Abwesenheit löschen", Tag: "v1 Abwesenheiten", Roles: docStaff, Status: http.StatusNoContent},
+
+	// REST-API v1: Zeiteinträge und Überstunden
+	"GET /api/v1/time-entries":                                {Summary: "Zeiteinträge auflisten", Tag: "v1 Zeiterfassung", List: true, Query: []string{"employeeId", "department", "projectId", "source", "from", "to"}, Response: APITimeEntry{}},
+	"GET /api/v1/employees/:id/time-entries":                  {Summary: "Zeiteinträge eines Mitarbeiters", Tag: "v1 Zeiterfassung", List: true, Query: []string{"projectId", "source", "from", "to"}, Response: APITimeEntry{}},
+	"GET /api/v1/employees/:id/overtime":                      {Summary: "Überstundenstand eines Mitarbeiters", Tag: "v1 Zeiterfassung", Response: APIOvertime{}},
+	"GET /api/v1/employees/:id/overtime/adjustments":          {Summary: "Überstunden-Anpassungen eines Mitarbeiters", Tag: "v1 Zeiterfassung", List: true, Response: model.OvertimeAdjustment{}},
+	"POST /api/v1/employees/:id/overtime/adjustments":         {Summary: "Überstunden-Anpassung einreichen", Tag: "v1 Zeiterfassung", Roles: docStaff, Body: APIOvertimeAdjustmentInput{}, Response: model.OvertimeAdjustment{}, Status: http.StatusCreated},
+	"GET /api/v1/overtime/adjustments/pending":                {Summary: "Offene Überstunden-Anpassungen", Tag: "v1 Zeiterfassung", Roles: docApprovers, List: true, Response: model.OvertimeAdjustment{}},
+	"PATCH /api/v1/overtime/adjustments/:adjustmentId/status": {Summary: "Überstunden-Anpassung genehmigen oder ablehnen", Tag: "v1 Zeiterfassung", Roles: docApprovers, Body: APIOvertimeStatusInput{}, Response: model.OvertimeAdjustment{}},
+
+	// REST-API v1: Dokumente
+	"GET /api/v1/employees/:id/documents":                      {Summary: "Dokumente der Personalakte", Tag: "v1 Dokumente", Query: []string{"category"}, Response: []APIDocument{}},
+	"POST /api/v1/employees/:id/documents":                     {Summary: "Dokument hochladen", Tag: "v1 Dokumente", Roles: docStaff, Form: []string{"name", "description", "category", "expiresAt"}, Files: []string{"file"}, Response: APIDocument{}, Status: http.StatusCreated},
+	"GET /api/v1/employees/:id/documents/:documentId/download": {Summary: "Dokument herunterladen", Tag: "v1 Dokumente", Produces: "application/octet-stream"},
+	"DELETE /api/v1/employees/:id/documents/:documentId":       {Summary: "Dokument löschen", Tag: "v1 Dokumente", Roles: docStaff, Status: http.StatusNoContent},
+
+	// REST-API v1: Weiterbildungen, Beurteilungen und Gespräche
+	"GET /api/v1/employees/:id/trainings":                        {Summary: "Weiterbildungen eines Mitarbeiters", Tag: "v1 Entwicklung", Response: []APITraining{}},
+	"POST /api/v1/employees/:id/trainings":                       {Summary: "Weiterbildung anlegen", Tag: "v1 Entwicklung", Roles: docStaff, Body: APITrainingInput{}, Response: APITraining{}, Status: http.StatusCreated},
+	"PATCH /api/v1/employees/:id/trainings/:trainingId":          {Summary: "Weiterbildung aktualisieren", Tag: "v1 Entwicklung", Roles: docStaff, Body: APITrainingInput{}, Response: APITraining{}},
+	"DELETE /api/v1/employees/:id/trainings/:trainingId":         {Summary: "Weiterbildung löschen", Tag: "v1 Entwicklung", Roles: docStaff, Status: http.StatusNoContent},
+	"GET /api/v1/employees/:id/evaluations":                      {Summary: "Leistungsbeurteilungen eines Mitarbeiters", Tag: "v1 Entwicklung", Response: []APIEvaluation{}},
+	"POST /api/v1/employees/:id/evaluations":                     {Summary: "Leistungsbeurteilung anlegen", Tag: "v1 Entwicklung", Roles: docStaff, Body: APIEvaluationInput{}, Response: APIEvaluation{}, Status: http.StatusCreated},
+	"PATCH /api/v1/employees/:id/evaluations/:evaluationId":      {Summary: "Leistungsbeurteilung aktualisieren", Tag: "v1 Entwicklung", Roles: docStaff, Body: APIEvaluationInput{}, Response: APIEvaluation{}},
+	"DELETE /api/v1/employees/:id/evaluations/:evaluationId":     {Summary: "Leistungsbeurteilung löschen", Tag: "v1 Entwicklung", Roles: docStaff, Status: http.StatusNoContent},
+	"GET /api/v1/conversations":                                  {Summary: "Mitarbeitergespräche auflisten", Tag: "v1 Entwicklung", List: true, Query: []string{"employeeId", "department", "status", "from", "to"}, Response: APIConversation{}},
+	"GET /api/v1/employees/:id/conversations":                    {Summary: "Gespräche eines Mitarbeiters", Tag: "v1 Entwicklung", List: true, Query: []string{"status", "from", "to"}, Response: APIConversation{}},
+	"POST /api/v1/employees/:id/conversations":                   {Summary: "Gespräch anlegen", Tag: "v1 Entwicklung", Roles: docStaff, Body: APIConversationInput{}, Response: APIConversation{}, Status: http.StatusCreated},
+	"PATCH /api/v1/employees/:id/conversations/:conversationId":  {Summary: "Gespräch aktualisieren", Tag: "v1 Entwicklung", Roles: docStaff, Body: APIConversationInput{}, Response: APIConversation{}},
+	"DELETE /api/v1/employees/:id/conversations/:conversationId": {Summary: "Gespräch löschen", Tag: "v1 Entwicklung", Roles: docStaff, Status: http.StatusNoContent},
+
+	// REST-API v1: Benutzer und Einstellungen
+	"GET /api/v1/users/me":     {Summary: "Angemeldeten Benutzer abrufen", Tag: "v1 Benutzer", Response: APIUser{}},
+	"GET /api/v1/users":        {Summary: "Benutzer auflisten", Tag: "v1 Benutzer", Roles: docApprovers, List: true, Query: []string{"q", "role", "status"}, Response: APIUser{}},
+	"POST /api/v1/users":       {Summary: "Benutzer anlegen", Tag: "v1 Benutzer", Roles: docAdmin, Body: APIUserInput{}, Response: APIUser{}, Status: http.StatusCreated},
+	"GET /api/v1/users/:id":    {Summary: "Benutzer abrufen", Tag: "v1 Benutzer", Roles: docApprovers, Response: APIUser{}},
+	"PATCH /api/v1/users/:id":  {Summary: "Benutzer aktualisieren", Tag: "v1 Benutzer", Roles: docAdmin, Body: APIUserInput{}, Response: APIUser{}},
+	"DELETE /api/v1/users/:id": {Summary: "Benutzer deaktivieren", Tag: "v1 Benutzer", Roles: docAdmin, Status: http.StatusNoContent},
+	"GET /api/v1/settings":     {Summary: "System-Einstellungen abrufen", Tag: "v1 Einstellungen", Response: APISettings{}},
+	"PATCH /api/v1/settings":   {Summary: "System-Einstellungen aktualisieren", Tag: "v1 Einstellungen", Roles: docAdmin, Body: APISettingsInput{}, Response: APISettings{}},
+}
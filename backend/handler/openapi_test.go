@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPIPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"/api/v1/employees", "/api/v1/employees"},
+		{"/api/v1/employees/:id", "/api/v1/employees/{id}"},
+		{"/api/absence/:employeeId/:absenceId/approve", "/api/absence/{employeeId}/{absenceId}/approve"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.expected, openAPIPath(tt.path))
+		})
+	}
+}
+
+func TestBuildOpenAPISpec(t *testing.T) {
+	spec := BuildOpenAPISpec(gin.RoutesInfo{
+		{Method: http.MethodGet, Path: "/api/v1/employees"},
+		{Method: http.MethodPatch, Path: "/api/v1/users/:id"},
+		{Method: http.MethodGet, Path: "/api/undocumented"},
+		{Method: http.MethodGet, Path: "/dashboard"},
+	})
+
+	assert.Equal(t, "3.0.3", spec["openapi"])
+	paths := spec["paths"].(map[string]interface{})
+	assert.NotContains(t, paths, "/dashboard", "Seiten außerhalb von /api gehören nicht in die Spezifikation")
+
+	t.Run("list response references schema", func(t *testing.T) {
+		op := paths["/api/v1/employees"].(map[string]interface{})["get"].(map[string]interface{})
+		data, err := json.Marshal(op)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "#/components/schemas/APIEmployee")
+		assert.Contains(t, string(data), "#/components/schemas/APIMeta")
+		assert.Contains(t, string(data), `"name":"pageSize"`)
+	})
+
+	t.Run("roles and path parameters", func(t *testing.T) {
+		op := paths["/api/v1/users/{id}"].(map[string]interface{})["patch"].(map[string]interface{})
+		assert.Equal(t, []string{"admin"}, op["x-roles"])
+		data, err := json.Marshal(op["parameters"])
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"in":"path"`)
+		assert.NotNil(t, op["requestBody"])
+	})
+
+	t.Run("undocumented route is marked", func(t *testing.T) {
+		op := paths["/api/undocumented"].(map[string]interface{})["get"].(map[string]interface{})
+		assert.Equal(t, true, op["x-undocumented"])
+	})
+
+	t.Run("components exclude hidden fields", func(t *testing.T) {
+		schemas := spec["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+		require.Contains(t, schemas, "APIUser")
+		data, err := json.Marshal(schemas["APIUser"])
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "password")
+	})
+}
+
+func TestOpenAPIHandler_GetSpec(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/openapi.json", NewOpenAPIHandler(router).GetSpec)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/openapi.json", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+
+	var spec map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &spec))
+	paths := spec["paths"].(map[string]interface{})
+	assert.Contains(t, paths, "/api/openapi.json")
+}
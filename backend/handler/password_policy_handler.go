@@ -0,0 +1,212 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PasswordPolicyHandler verwaltet die Passwortrichtlinie und die Liste kompromittierter Passwörter
+type PasswordPolicyHandler struct {
+	settingsRepo *repository.SystemSettingsRepository
+	breachedRepo *repository.BreachedPasswordRepository
+}
+
+// NewPasswordPolicyHandler erstellt einen neuen PasswordPolicyHandler
+func NewPasswordPolicyHandler() *PasswordPolicyHandler {
+	return &PasswordPolicyHandler{
+		settingsRepo: repository.NewSystemSettingsRepository(),
+		breachedRepo: repository.NewBreachedPasswordRepository(),
+	}
+}
+
+// GetPasswordPolicy gibt die aktuelle Passwortrichtlinie zurück
+func (h *PasswordPolicyHandler) GetPasswordPolicy(c *gin.Context) {
+	settings, err := h.settingsRepo.GetSettings()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Fehler beim Abrufen der Einstellungen: " + err.Error(),
+		})
+		return
+	}
+
+	policy := settings.GetPasswordPolicy()
+	uploaded, _ := h.breachedRepo.CountUploaded()
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"policy":         policy,
+			"rules":          policy.Describe(),
+			"bundledHashes":  h.breachedRepo.CountBundled(),
+			"uploadedHashes": uploaded,
+		},
+	})
+}
+
+// UpdatePasswordPolicy aktualisiert die Passwortrichtlinie
+func (h *PasswordPolicyHandler) UpdatePasswordPolicy(c *gin.Context) {
+	settings, err := h.settingsRepo.GetSettings()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Fehler beim Abrufen der Einstellungen: " + err.Error(),
+		})
+		return
+	}
+
+	policy := settings.GetPasswordPolicy()
+
+	if value := c.PostForm("minLength"); value != "" {
+		minLength, err := strconv.Atoi(value)
+		if err != nil || minLength < model.MinPasswordPolicyLength || minLength > model.MaxPasswordPolicyLength {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   "Mindestlänge muss zwischen " + strconv.Itoa(model.MinPasswordPolicyLength) + " und " + strconv.Itoa(model.MaxPasswordPolicyLength) + " liegen",
+			})
+			return
+		}
+		policy.MinLength = minLength
+	}
+
+	if value := c.PostForm("historySize"); value != "" {
+		historySize, err := strconv.Atoi(value)
+		if err != nil || historySize < 0 || historySize > model.MaxPasswordHistorySize {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   "Passwort-Historie muss zwischen 0 und " + strconv.Itoa(model.MaxPasswordHistorySize) + " liegen",
+			})
+			return
+		}
+		policy.HistorySize = historySize
+	}
+
+	if value := c.PostForm("maxAgeDays"); value != "" {
+		maxAgeDays, err := strconv.Atoi(value)
+		if err != nil || maxAgeDays < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   "Maximales Passwortalter muss eine positive Zahl sein",
+			})
+			return
+		}
+		policy.MaxAgeDays = maxAgeDays
+	}
+
+	// Checkboxen werden nur bei aktivierter Option übertragen
+	policy.RequireUppercase = isChecked(c.PostForm("requireUppercase"))
+	policy.RequireLowercase = isChecked(c.PostForm("requireLowercase"))
+	policy.RequireDigit = isChecked(c.PostForm("requireDigit"))
+	policy.RequireSpecial = isChecked(c.PostForm("requireSpecial"))
+	policy.CheckBreached = isChecked(c.PostForm("checkBreached"))
+
+	policy.Normalize()
+	settings.PasswordPolicy = policy
+
+	if err := h.settingsRepo.Update(settings); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Fehler beim Speichern der Passwortrichtlinie: " + err.Error(),
+		})
+		return
+	}
+
+	user, _ := c.Get("user")
+	userModel := user.(*model.User)
+
+	activityRepo := repository.NewActivityRepository()
+	_, _ = activityRepo.LogActivity(
+		model.ActivityTypeSystemSettingChanged,
+		userModel.ID,
+		userModel.FirstName+" "+userModel.LastName,
+		userModel.ID,
+		"system",
+		"Passwortrichtlinie",
+		"Passwortrichtlinie aktualisiert",
+	)
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Passwortrichtlinie erfolgreich gespeichert",
+		"data":    policy,
+	})
+}
+
+// UploadBreachedPasswords importiert eine Liste kompromittierter Passwort-Hashes (SHA-1, "HASH" oder "HASH:COUNT")
+func (h *PasswordPolicyHandler) UploadBreachedPasswords(c *gin.Context) {
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Keine Datei hochgeladen",
+		})
+		return
+	}
+
+	reader, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Datei konnte nicht gelesen werden",
+		})
+		return
+	}
+	defer reader.Close()
+
+	imported, err := h.breachedRepo.Import(reader)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Fehler beim Importieren: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": strconv.Itoa(imported) + " Hashes importiert",
+		"data":    gin.H{"imported": imported},
+	})
+}
+
+// ClearBreachedPasswords entfernt alle hochgeladenen Hashes (die mitgelieferte Liste bleibt aktiv)
+func (h *PasswordPolicyHandler) ClearBreachedPasswords(c *gin.Context) {
+	if err := h.breachedRepo.ClearUploaded(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Fehler beim Entfernen der Hashes: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Hochgeladene Hashes entfernt",
+	})
+}
+
+// passwordPolicyErrorMessage übersetzt Fehler der Passwortrichtlinie in eine Meldung für die Oberfläche
+func passwordPolicyErrorMessage(err error, policy *model.PasswordPolicy) string {
+	switch {
+	case errors.Is(err, model.ErrPasswordReused):
+		return "Das Passwort wurde kürzlich bereits verwendet. Bitte wählen Sie ein anderes Passwort."
+	case errors.Is(err, model.ErrPasswordBreached):
+		return "Dieses Passwort ist in einer Liste kompromittierter Passwörter enthalten. Bitte wählen Sie ein anderes Passwort."
+	case errors.Is(err, model.ErrWeakPassword), errors.Is(err, repository.ErrInvalidPassword):
+		return "Das Passwort erfüllt nicht die Passwortrichtlinie: " + strings.Join(policy.Describe(), ", ")
+	default:
+		return err.Error()
+	}
+}
+
+// isChecked wertet einen Checkbox-Wert aus
+func isChecked(value string) bool {
+	return value == "on" || value == "true" || value == "1"
+}
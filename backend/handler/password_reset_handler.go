@@ -6,8 +6,8 @@ import (
 	"PeopleFlow/backend/service"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"github.com/gin-gonic/gin"
-	"golang.org/x/crypto/bcrypt"
 	"log"
 	"net/http"
 	"time"
@@ -146,7 +146,8 @@ func (h *PasswordResetHandler) ShowPasswordResetForm(c *gin.Context) {
 
 	// Reset-Formular anzeigen
 	c.HTML(http.StatusOK, "password_reset.html", gin.H{
-		"token": token,
+		"token":         token,
+		"passwordRules": h.userRepo.PasswordPolicy().Describe(),
 	})
 }
 
@@ -172,15 +173,6 @@ func (h *PasswordResetHandler) ResetPassword(c *gin.Context) {
 		return
 	}
 
-	// Passwort-Stärke prüfen
-	if len(newPassword) < 8 {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error":   "Passwort muss mindestens 8 Zeichen lang sein",
-		})
-		return
-	}
-
 	// Token validieren
 	resetToken, exists := resetTokens[token]
 	if !exists || resetToken.Used || time.Now().After(resetToken.ExpiresAt) {
@@ -201,21 +193,16 @@ func (h *PasswordResetHandler) ResetPassword(c *gin.Context) {
 		return
 	}
 
-	// Passwort hashen
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
-	if err != nil {
-		log.Printf("Fehler beim Hashen des Passworts: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error":   "Interner Serverfehler",
-		})
-		return
-	}
-
-	// Passwort aktualisieren
-	user.Password = string(hashedPassword)
-	err = h.userRepo.Update(user)
+	// Passwort unter Anwendung der Passwortrichtlinie aktualisieren
+	err = h.userRepo.UpdatePassword(user.ID.Hex(), newPassword)
 	if err != nil {
+		if errors.Is(err, repository.ErrInvalidPassword) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   passwordPolicyErrorMessage(err, h.userRepo.PasswordPolicy()),
+			})
+			return
+		}
 		log.Printf("Fehler beim Aktualisieren des Passworts für Benutzer %s: %v", user.Email, err)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"success": false,
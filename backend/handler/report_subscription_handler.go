@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+	"PeopleFlow/backend/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReportSubscriptionHandler verwaltet die Berichts-Abonnements des angemeldeten Benutzers
+type ReportSubscriptionHandler struct {
+	reportService *service.ReportService
+}
+
+// NewReportSubscriptionHandler erstellt einen neuen ReportSubscriptionHandler
+func NewReportSubscriptionHandler() *ReportSubscriptionHandler {
+	return &ReportSubscriptionHandler{
+		reportService: service.NewReportService(),
+	}
+}
+
+// ListSubscriptions gibt die Abonnements des Benutzers zurück
+func (h *ReportSubscriptionHandler) ListSubscriptions(c *gin.Context) {
+	subscriptions, err := h.reportService.ListSubscriptions(currentWebhookUser(c))
+	if err != nil {
+		reportSubscriptionErrors.respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    subscriptions,
+	})
+}
+
+// ListTypes gibt die Berichtsarten zurück, die der Benutzer abonnieren darf
+func (h *ReportSubscriptionHandler) ListTypes(c *gin.Context) {
+	types := h.reportService.AvailableTypes(currentWebhookUser(c))
+	if types == nil {
+		types = []service.ReportTypeInfo{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    types,
+	})
+}
+
+// CreateSubscription legt ein Abonnement an
+func (h *ReportSubscriptionHandler) CreateSubscription(c *gin.Context) {
+	subscription := reportSubscriptionInput(c)
+	subscription.Type = model.ReportType(c.PostForm("type"))
+
+	if err := h.reportService.CreateSubscription(currentWebhookUser(c), subscription); err != nil {
+		reportSubscriptionErrors.respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Bericht abonniert",
+		"data":    subscription,
+	})
+}
+
+// UpdateSubscription ändert Zeitplan und Inhalt eines Abonnements; die Berichtsart bleibt unverändert
+func (h *ReportSubscriptionHandler) UpdateSubscription(c *gin.Context) {
+	subscription, err := h.reportService.UpdateSubscription(currentWebhookUser(c), c.Param("id"), reportSubscriptionInput(c))
+	if err != nil {
+		reportSubscriptionErrors.respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Abonnement gespeichert",
+		"data":    subscription,
+	})
+}
+
+// DeleteSubscription kündigt ein Abonnement
+func (h *ReportSubscriptionHandler) DeleteSubscription(c *gin.Context) {
+	if err := h.reportService.DeleteSubscription(currentWebhookUser(c), c.Param("id")); err != nil {
+		reportSubscriptionErrors.respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Abonnement gelöscht",
+	})
+}
+
+// SendNow versendet den Bericht sofort an den Benutzer
+func (h *ReportSubscriptionHandler) SendNow(c *gin.Context) {
+	user := currentWebhookUser(c)
+	if err := h.reportService.SendNow(user, c.Param("id")); err != nil {
+		reportSubscriptionErrors.respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Der Bericht wird an " + user.Email + " gesendet",
+	})
+}
+
+// reportSubscriptionInput liest Zeitplan und Inhalt aus dem Formular
+func reportSubscriptionInput(c *gin.Context) *model.ReportSubscription {
+	weekday, _ := strconv.Atoi(c.DefaultPostForm("weekday", "5"))
+	dayOfMonth, _ := strconv.Atoi(c.DefaultPostForm("dayOfMonth", "1"))
+	hour, _ := strconv.Atoi(c.DefaultPostForm("hour", "8"))
+
+	var sections []model.ReportSection
+	for _, section := range c.PostFormArray("sections") {
+		sections = append(sections, model.ReportSection(section))
+	}
+
+	return &model.ReportSubscription{
+		Frequency:  model.ReportFrequency(c.PostForm("frequency")),
+		Weekday:    time.Weekday(weekday),
+		DayOfMonth: dayOfMonth,
+		Hour:       hour,
+		Sections:   sections,
+		Department: model.Department(c.PostForm("department")),
+		Active:     c.DefaultPostForm("active", "true") == "true" || c.PostForm("active") == "on",
+	}
+}
+
+// reportSubscriptionErrors übersetzt Fehler der Berichts-Abonnements in JSON-Antworten
+var reportSubscriptionErrors = errorResponse{
+	fallback: "Fehler bei den Berichts-Abonnements",
+	mappings: []errorMapping{
+		{errs: []error{repository.ErrReportSubscriptionNotFound, repository.ErrInvalidID}, status: http.StatusNotFound, message: "Abonnement nicht gefunden"},
+		{errs: []error{service.ErrReportNotAllowed}, status: http.StatusForbidden, message: "Dieser Bericht steht Ihnen nicht zur Verfügung"},
+		{errs: []error{service.ErrReportNoEmployee}, status: http.StatusBadRequest, message: "Ihr Benutzerkonto ist mit keinem Mitarbeiter verknüpft"},
+		{errs: []error{service.ErrReportNoTeam}, status: http.StatusBadRequest, message: "Ihnen sind keine Mitarbeiter zugeordnet"},
+		{errs: []error{model.ErrInvalidReportType}, status: http.StatusBadRequest, message: "Unbekannte Berichtsart"},
+		{errs: []error{model.ErrInvalidReportFrequency}, status: http.StatusBadRequest, message: "Dieser Versandrhythmus ist für den Bericht nicht möglich"},
+		{errs: []error{model.ErrInvalidReportSchedule}, status: http.StatusBadRequest, message: "Ungültiger Zeitplan: %s", detail: true},
+		{errs: []error{model.ErrInvalidReportSection}, status: http.StatusBadRequest, message: "Unbekannter Abschnitt für diesen Bericht"},
+	},
+}
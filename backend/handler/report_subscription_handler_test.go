@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestReportSubscriptionInput(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	form := url.Values{
+		"frequency":  {"weekly"},
+		"weekday":    {"1"},
+		"hour":       {"7"},
+		"sections":   {"days", "overtime"},
+		"department": {"IT"},
+		"active":     {"false"},
+	}
+
+	var subscription *model.ReportSubscription
+	router := gin.New()
+	router.POST("/", func(c *gin.Context) {
+		subscription = reportSubscriptionInput(c)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	require.NotNil(t, subscription)
+	assert.Equal(t, model.ReportWeekly, subscription.Frequency)
+	assert.Equal(t, time.Monday, subscription.Weekday)
+	assert.Equal(t, 1, subscription.DayOfMonth, "missing day of month defaults to the 1st")
+	assert.Equal(t, 7, subscription.Hour)
+	assert.Equal(t, []model.ReportSection{model.ReportSectionDays, model.ReportSectionOvertime}, subscription.Sections)
+	assert.Equal(t, model.Department("IT"), subscription.Department)
+	assert.False(t, subscription.Active)
+}
+
+func TestReportSubscriptionHandler_ListTypes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	employeeID := primitive.NewObjectID()
+
+	tests := []struct {
+		name string
+		user *model.User
+		want []model.ReportType
+	}{
+		{"Employee", &model.User{Role: model.RoleEmployee, EmployeeID: &employeeID}, []model.ReportType{model.ReportWeeklyTimeSummary, model.ReportMonthlyOvertime}},
+		{"User without employee", &model.User{Role: model.RoleUser}, []model.ReportType{}},
+		{"Manager", &model.User{Role: model.RoleManager, EmployeeID: &employeeID}, []model.ReportType{model.ReportWeeklyTimeSummary, model.ReportMonthlyOvertime, model.ReportTeamDigest}},
+		{"HR without employee", &model.User{Role: model.RoleHR}, []model.ReportType{model.ReportTeamDigest, model.ReportMonthlyAbsences}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &ReportSubscriptionHandler{reportService: &service.ReportService{}}
+			router := gin.New()
+			router.GET("/api/report-subscriptions/types", func(c *gin.Context) {
+				c.Set("user", tt.user)
+				h.ListTypes(c)
+			})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/report-subscriptions/types", nil))
+			require.Equal(t, http.StatusOK, w.Code)
+
+			var response struct {
+				Data []service.ReportTypeInfo `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+
+			types := []model.ReportType{}
+			for _, info := range response.Data {
+				types = append(types, info.Type)
+			}
+			assert.Equal(t, tt.want, types)
+		})
+	}
+}
+
+func TestReportSubscriptionErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{service.ErrReportNotAllowed, http.StatusForbidden},
+		{service.ErrReportNoEmployee, http.StatusBadRequest},
+		{fmt.Errorf("%w: Stunde 25", model.ErrInvalidReportSchedule), http.StatusBadRequest},
+		{fmt.Errorf("%w: projects", model.ErrInvalidReportSection), http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			reportSubscriptionErrors.respond(c, tt.err)
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
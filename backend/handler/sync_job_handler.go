@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+	"PeopleFlow/backend/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// syncJobListLimit ist die Anzahl der zuletzt gestarteten Synchronisierungen in der Übersicht
+	syncJobListLimit = 20
+
+	// syncJobPollInterval bestimmt, wie oft der Event-Stream den Fortschritt abfragt
+	syncJobPollInterval = time.Second
+)
+
+// startSync startet eine Synchronisierung des Anbieters aus dem Pfadparameter :type
+// im Hintergrund und antwortet sofort mit der Job-ID
+func (h *IntegrationHandler) startSync(c *gin.Context, capability model.SyncCapability, params model.SyncJobParams) {
+	job, err := h.syncJobService.Start(c.Param("type"), capability, params, currentWebhookUser(c))
+	if err != nil {
+		respondSyncJobError(c, job, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"success": true,
+		"message": "Synchronisierung gestartet",
+		"jobId":   job.ID.Hex(),
+		"data":    job,
+	})
+}
+
+// ApplySyncPreview übernimmt einen abgeschlossenen Probelauf: dieselbe Synchronisierung wird
+// im Hintergrund ausgeführt und ihre Job-ID sofort zurückgegeben
+func (h *IntegrationHandler) ApplySyncPreview(c *gin.Context) {
+	job, err := h.syncJobService.ApplyPreview(c.Param("id"), currentWebhookUser(c))
+	if err != nil {
+		respondSyncJobError(c, job, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"success": true,
+		"message": "Probelauf wird übernommen",
+		"jobId":   job.ID.Hex(),
+		"data":    job,
+	})
+}
+
+// ListSyncJobs gibt die zuletzt gestarteten Synchronisierungen zurück
+func (h *IntegrationHandler) ListSyncJobs(c *gin.Context) {
+	jobs, err := h.syncJobService.ListRecent(syncJobListLimit)
+	if err != nil {
+		respondSyncJobError(c, nil, err)
+		return
+	}
+	if jobs == nil {
+		jobs = []*model.SyncJob{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    jobs,
+	})
+}
+
+// GetSyncJob gibt Phase, Fortschritt, Warnungen und Ergebnis einer Synchronisierung zurück
+func (h *IntegrationHandler) GetSyncJob(c *gin.Context) {
+	job, err := h.syncJobService.Get(c.Param("id"))
+	if err != nil {
+		respondSyncJobError(c, nil, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    job,
+	})
+}
+
+// StreamSyncJob sendet den Fortschritt einer Synchronisierung als Server-Sent Events,
+// bis sie abgeschlossen ist ("progress" bei jeder Änderung, zum Schluss "done")
+func (h *IntegrationHandler) StreamSyncJob(c *gin.Context) {
+	id := c.Param("id")
+	job, err := h.syncJobService.Get(id)
+	if err != nil {
+		respondSyncJobError(c, nil, err)
+		return
+	}
+
+	// Der Stream läuft länger als das WriteTimeout des Servers
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+
+	ticker := time.NewTicker(syncJobPollInterval)
+	defer ticker.Stop()
+
+	var lastUpdate time.Time
+	c.Stream(func(w io.Writer) bool {
+		if job.IsFinished() {
+			c.SSEvent("done", job)
+			return false
+		}
+		if !job.UpdatedAt.Equal(lastUpdate) {
+			lastUpdate = job.UpdatedAt
+			c.SSEvent("progress", syncJobProgressView(job))
+		}
+
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case <-ticker.C:
+		}
+
+		next, err := h.syncJobService.Get(id)
+		if err != nil {
+			c.SSEvent("error", gin.H{"message": err.Error()})
+			return false
+		}
+		job = next
+		return true
+	})
+}
+
+// syncJobErrors übersetzt Fehler der Synchronisierungen in JSON-Antworten
+var syncJobErrors = errorResponse{
+	fallback:   "Fehler bei der Synchronisierung",
+	messageKey: "message",
+	mappings: []errorMapping{
+		{errs: []error{repository.ErrSyncJobNotFound, repository.ErrInvalidID}, status: http.StatusNotFound, message: "Synchronisierung nicht gefunden"},
+		{errs: []error{service.ErrIntegrationUnknown}, status: http.StatusNotFound, message: "Unbekannte Integration"},
+		{errs: []error{service.ErrSyncNotConnected}, status: http.StatusBadRequest, message: "Die Integration ist nicht verbunden"},
+		{errs: []error{model.ErrInvalidSyncCapability, service.ErrSyncCapabilityUnsupported, service.ErrInvalidSyncDateRange}, status: http.StatusBadRequest, message: "Ungültige Parameter: %s", detail: true},
+		{errs: []error{service.ErrSyncPreviewInvalid}, status: http.StatusBadRequest, message: "Nur abgeschlossene Probeläufe können übernommen werden"},
+		{errs: []error{service.ErrSyncPreviewExpired}, status: http.StatusConflict, message: "Der Probelauf ist abgelaufen, bitte erneut ausführen"},
+	},
+}
+
+// respondSyncJobError sendet die Fehlerantwort; läuft bereits eine Synchronisierung oder wurde
+// der Probelauf schon übernommen, enthält die Antwort die ID der betreffenden Synchronisierung
+func respondSyncJobError(c *gin.Context, running *model.SyncJob, err error) {
+	switch {
+	case errors.Is(err, repository.ErrSyncPreviewApplied):
+		response := gin.H{
+			"success": false,
+			"message": "Der Probelauf wurde bereits übernommen",
+		}
+		if running != nil && running.AppliedJobID != nil {
+			response["jobId"] = running.AppliedJobID.Hex()
+		}
+		c.JSON(http.StatusConflict, response)
+	case errors.Is(err, service.ErrSyncAlreadyRunning):
+		response := gin.H{
+			"success": false,
+			"message": "Für diese Integration läuft bereits eine Synchronisierung",
+		}
+		if running != nil {
+			response["jobId"] = running.ID.Hex()
+			response["data"] = running
+		}
+		c.JSON(http.StatusConflict, response)
+	default:
+		syncJobErrors.respond(c, err)
+	}
+}
+
+// syncJobProgressView lässt die Mitarbeiterdetails der Änderungsübersicht weg, damit
+// Fortschrittsmeldungen klein bleiben; die Details liefern GetSyncJob und das Ereignis "done"
+func syncJobProgressView(job *model.SyncJob) *model.SyncJob {
+	if job.Diff == nil || len(job.Diff.Employees) == 0 {
+		return job
+	}
+	diff := *job.Diff
+	diff.Employees = nil
+	view := *job
+	view.Diff = &diff
+	return &view
+}
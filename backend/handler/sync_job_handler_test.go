@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+	"PeopleFlow/backend/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestRespondSyncJobError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{repository.ErrSyncJobNotFound, http.StatusNotFound},
+		{fmt.Errorf("%w: abc", repository.ErrInvalidID), http.StatusNotFound},
+		{fmt.Errorf("%w: timebutler", service.ErrSyncNotConnected), http.StatusBadRequest},
+		{fmt.Errorf("%w: personio", service.ErrIntegrationUnknown), http.StatusNotFound},
+		{fmt.Errorf("%w: salaries", model.ErrInvalidSyncCapability), http.StatusBadRequest},
+		{fmt.Errorf("%w: Timebutler kann Projekte nicht synchronisieren", service.ErrSyncCapabilityUnsupported), http.StatusBadRequest},
+		{fmt.Errorf("%w: Enddatum liegt vor dem Startdatum", service.ErrInvalidSyncDateRange), http.StatusBadRequest},
+		{service.ErrSyncAlreadyRunning, http.StatusConflict},
+		{service.ErrSyncPreviewInvalid, http.StatusBadRequest},
+		{service.ErrSyncPreviewExpired, http.StatusConflict},
+		{repository.ErrSyncPreviewApplied, http.StatusConflict},
+		{assert.AnError, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			respondSyncJobError(c, nil, tt.err)
+			assert.Equal(t, tt.want, w.Code)
+			assert.Contains(t, w.Body.String(), `"success":false`)
+		})
+	}
+}
+
+func TestRespondSyncJobError_AlreadyRunningReturnsJobID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	running := &model.SyncJob{
+		ID:          primitive.NewObjectID(),
+		Integration: "123erfasst",
+		Capability:  model.SyncAll,
+		Status:      model.SyncJobRunning,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	respondSyncJobError(c, running, service.ErrSyncAlreadyRunning)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), `"jobId":"`+running.ID.Hex()+`"`)
+}
+
+func TestRespondSyncJobError_PreviewAppliedReturnsAppliedJobID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	appliedID := primitive.NewObjectID()
+	preview := &model.SyncJob{
+		ID:           primitive.NewObjectID(),
+		Params:       model.SyncJobParams{DryRun: true},
+		Status:       model.SyncJobCompleted,
+		AppliedJobID: &appliedID,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	respondSyncJobError(c, preview, repository.ErrSyncPreviewApplied)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), `"jobId":"`+appliedID.Hex()+`"`)
+}
+
+func TestSyncJobProgressView_OmitsEmployeeDetails(t *testing.T) {
+	job := &model.SyncJob{
+		ID: primitive.NewObjectID(),
+		Diff: &model.SyncDiff{
+			EmployeesUpdated: 1,
+			Employees:        []model.EmployeeSyncChange{{EmployeeName: "Anna Schmidt"}},
+		},
+	}
+
+	view := syncJobProgressView(job)
+
+	assert.Nil(t, view.Diff.Employees)
+	assert.Equal(t, 1, view.Diff.EmployeesUpdated)
+	assert.Len(t, job.Diff.Employees, 1, "der gespeicherte Job bleibt unverändert")
+}
@@ -63,6 +63,10 @@ func (h *SystemSettingsHandler) UpdateSystemSettings(c *gin.Context) {
 		currentSettings.State = state
 	}
 
+	if requireTwoFactor := c.PostForm("requireTwoFactor"); requireTwoFactor != "" {
+		currentSettings.RequireTwoFactor = requireTwoFactor == "true" || requireTwoFactor == "on"
+	}
+
 	// Timezone field is not currently supported in SystemSettings model
 	// if timezone := c.PostForm("timezone"); timezone != "" {
 	//     currentSettings.Timezone = timezone
@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+	"PeopleFlow/backend/service"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TimesheetHandler stellt monatliche Stundenzettel als PDF bereit und nimmt deren Bestätigungen entgegen
+type TimesheetHandler struct {
+	timesheetService *service.TimesheetService
+}
+
+// timesheetResponse ist der Stundenzettel mit dem Stand seiner Bestätigungen
+type timesheetResponse struct {
+	Timesheet *model.Timesheet      `json:"timesheet"`
+	Status    model.TimesheetStatus `json:"status"`
+}
+
+// NewTimesheetHandler erstellt einen neuen TimesheetHandler
+func NewTimesheetHandler() *TimesheetHandler {
+	return &TimesheetHandler{
+		timesheetService: service.NewTimesheetService(),
+	}
+}
+
+// GetTimesheet gibt den Stundenzettel eines Monats mit dem Stand der Bestätigungen zurück
+func (h *TimesheetHandler) GetTimesheet(c *gin.Context) {
+	employee, month, ok := h.loadTimesheetEmployee(c, c.Query("month"))
+	if !ok {
+		return
+	}
+
+	sheet, ack, err := h.timesheetService.Timesheet(employee, month)
+	if err != nil {
+		timesheetErrors.respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    timesheetResponse{Timesheet: sheet, Status: ack.Status(sheet.Checksum())},
+	})
+}
+
+// DownloadTimesheet liefert den Stundenzettel eines Monats als PDF
+func (h *TimesheetHandler) DownloadTimesheet(c *gin.Context) {
+	employee, month, ok := h.loadTimesheetEmployee(c, c.Query("month"))
+	if !ok {
+		return
+	}
+
+	sheet, ack, err := h.timesheetService.Timesheet(employee, month)
+	if err != nil {
+		timesheetErrors.respond(c, err)
+		return
+	}
+
+	content := service.RenderTimesheetPDF(sheet, ack.Status(sheet.Checksum()), time.Now().In(timeExportLocation()))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", sheet.FileName()))
+	c.Data(http.StatusOK, "application/pdf", content)
+}
+
+// AcknowledgeTimesheet bestätigt den eigenen Stundenzettel eines abgeschlossenen Monats
+func (h *TimesheetHandler) AcknowledgeTimesheet(c *gin.Context) {
+	h.signTimesheet(c, model.TimesheetSignatureEmployee)
+}
+
+// ApproveTimesheet zeichnet den Stundenzettel eines Mitarbeiters als Vorgesetzter gegen
+func (h *TimesheetHandler) ApproveTimesheet(c *gin.Context) {
+	h.signTimesheet(c, model.TimesheetSignatureManager)
+}
+
+// DownloadDepartmentTimesheets liefert die Stundenzettel aller Mitarbeiter einer Abteilung als ZIP-Archiv
+func (h *TimesheetHandler) DownloadDepartmentTimesheets(c *gin.Context) {
+	department := strings.TrimSpace(c.Query("department"))
+	if department == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Bitte wählen Sie eine Abteilung aus",
+		})
+		return
+	}
+
+	month, err := h.timesheetService.ParseMonth(c.Query("month"))
+	if err != nil {
+		timesheetErrors.respond(c, err)
+		return
+	}
+
+	archive, count, err := h.timesheetService.DepartmentArchive(department, month, time.Now().In(timeExportLocation()))
+	if err != nil {
+		timesheetErrors.respond(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", timesheetArchiveName(department, month)))
+	c.Header("X-Timesheet-Count", fmt.Sprintf("%d", count))
+	c.Data(http.StatusOK, "application/zip", archive)
+}
+
+// signTimesheet trägt die Bestätigung des angemeldeten Benutzers in der Rolle role ein
+func (h *TimesheetHandler) signTimesheet(c *gin.Context, role model.TimesheetSignatureRole) {
+	user := apiCurrentUser(c)
+	employee, month, ok := h.loadTimesheetEmployee(c, c.PostForm("month"))
+	if !ok {
+		return
+	}
+	if role == model.TimesheetSignatureEmployee && (user.EmployeeID == nil || *user.EmployeeID != employee.ID) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"success": false,
+			"error":   "Sie können nur Ihren eigenen Stundenzettel bestätigen",
+		})
+		return
+	}
+
+	sheet, ack, err := h.timesheetService.Acknowledge(employee, month, role, user, time.Now())
+	if err != nil {
+		timesheetErrors.respond(c, err)
+		return
+	}
+
+	description := fmt.Sprintf("Stundenzettel %s bestätigt", month.Format("01/2006"))
+	if role == model.TimesheetSignatureManager {
+		description = fmt.Sprintf("Stundenzettel %s als Vorgesetzter gegengezeichnet", month.Format("01/2006"))
+	}
+	activityRepo := repository.NewActivityRepository()
+	_, _ = activityRepo.LogActivity(
+		model.ActivityTypeEmployeeUpdated,
+		user.ID,
+		user.FirstName+" "+user.LastName,
+		employee.ID,
+		"employee",
+		employee.FirstName+" "+employee.LastName,
+		description,
+	)
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Stundenzettel bestätigt",
+		"data":    ack.Status(sheet.Checksum()),
+	})
+}
+
+// loadTimesheetEmployee prüft Berechtigung und Monat und lädt den Mitarbeiter aus der URL
+func (h *TimesheetHandler) loadTimesheetEmployee(c *gin.Context, monthValue string) (*model.Employee, time.Time, bool) {
+	employeeID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Ungültige Mitarbeiter-ID",
+		})
+		return nil, time.Time{}, false
+	}
+	if !apiCanAccessEmployee(apiCurrentUser(c), employeeID) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"success": false,
+			"error":   "Keine Berechtigung für diesen Stundenzettel",
+		})
+		return nil, time.Time{}, false
+	}
+
+	month, err := h.timesheetService.ParseMonth(monthValue)
+	if err != nil {
+		timesheetErrors.respond(c, err)
+		return nil, time.Time{}, false
+	}
+
+	employee, err := h.timesheetService.FindEmployee(employeeID)
+	if err != nil {
+		timesheetErrors.respond(c, err)
+		return nil, time.Time{}, false
+	}
+	return employee, month, true
+}
+
+// timesheetArchiveName gibt den Dateinamen des Abteilungsarchivs zurück, z.B. Stundenzettel_IT_2024-04.zip
+func timesheetArchiveName(department string, month time.Time) string {
+	return fmt.Sprintf("Stundenzettel_%s_%s.zip", strings.ReplaceAll(department, " ", "_"), month.Format("2006-01"))
+}
+
+// timesheetErrors übersetzt Fehler der Stundenzettel in JSON-Antworten
+var timesheetErrors = errorResponse{
+	fallback: "Fehler beim Stundenzettel",
+	mappings: []errorMapping{
+		{errs: []error{model.ErrInvalidTimesheetMonth}, status: http.StatusBadRequest, message: "Der Monat muss im Format JJJJ-MM angegeben werden"},
+		{errs: []error{model.ErrTimesheetMonthOpen}, status: http.StatusConflict, message: "Der Stundenzettel kann erst nach Monatsende bestätigt werden"},
+		{errs: []error{model.ErrTimesheetAlreadyAcknowledged}, status: http.StatusConflict, message: "Der Stundenzettel wurde in diesem Stand bereits bestätigt"},
+		{errs: []error{model.ErrTimesheetSelfApproval}, status: http.StatusForbidden, message: "Der eigene Stundenzettel kann nicht als Vorgesetzter gegengezeichnet werden"},
+		{errs: []error{model.ErrTimesheetNotManager}, status: http.StatusForbidden, message: "Nur der eingetragene Vorgesetzte kann den Stundenzettel gegenzeichnen"},
+		{errs: []error{repository.ErrEmployeeNotFound}, status: http.StatusNotFound, message: "Mitarbeiter nicht gefunden"},
+		{errs: []error{service.ErrNoTimesheets}, status: http.StatusNotFound, message: "In dieser Abteilung gibt es keine Mitarbeiter mit Stundenzettel"},
+	},
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+	"PeopleFlow/backend/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestTimesheetErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		err         error
+		wantStatus  int
+		wantMessage string
+	}{
+		{model.ErrInvalidTimesheetMonth, http.StatusBadRequest, "Format JJJJ-MM"},
+		{model.ErrTimesheetMonthOpen, http.StatusConflict, "erst nach Monatsende"},
+		{model.ErrTimesheetAlreadyAcknowledged, http.StatusConflict, "bereits bestätigt"},
+		{model.ErrTimesheetSelfApproval, http.StatusForbidden, "eigene Stundenzettel"},
+		{repository.ErrEmployeeNotFound, http.StatusNotFound, "Mitarbeiter nicht gefunden"},
+		{service.ErrNoTimesheets, http.StatusNotFound, "keine Mitarbeiter"},
+		{assert.AnError, http.StatusInternalServerError, "Fehler beim Stundenzettel"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			timesheetErrors.respond(c, tt.err)
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantMessage)
+		})
+	}
+}
+
+func TestTimesheetHandler_RejectsInvalidRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := &TimesheetHandler{timesheetService: &service.TimesheetService{}}
+
+	ownID := primitive.NewObjectID()
+	employee := &model.User{ID: primitive.NewObjectID(), Role: model.RoleEmployee, EmployeeID: &ownID}
+	admin := &model.User{ID: primitive.NewObjectID(), Role: model.RoleAdmin}
+
+	tests := []struct {
+		name        string
+		user        *model.User
+		method      string
+		id          string
+		form        url.Values
+		handle      gin.HandlerFunc
+		wantStatus  int
+		wantMessage string
+	}{
+		{"ungültige ID", admin, http.MethodGet, "abc", nil, h.GetTimesheet, http.StatusBadRequest, "Ungültige Mitarbeiter-ID"},
+		{"fremder Stundenzettel", employee, http.MethodGet, primitive.NewObjectID().Hex(), nil, h.DownloadTimesheet, http.StatusForbidden, "Keine Berechtigung"},
+		{"fremde Bestätigung", employee, http.MethodPost, primitive.NewObjectID().Hex(), url.Values{"month": {"2024-04"}}, h.AcknowledgeTimesheet, http.StatusForbidden, "Keine Berechtigung"},
+		{"ungültiger Monat", employee, http.MethodPost, ownID.Hex(), url.Values{"month": {"04/2024"}}, h.AcknowledgeTimesheet, http.StatusBadRequest, "Format JJJJ-MM"},
+		{"Abteilung fehlt", admin, http.MethodGet, "", nil, h.DownloadDepartmentTimesheets, http.StatusBadRequest, "Abteilung"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(tt.method, "/api/timesheets", strings.NewReader(tt.form.Encode()))
+			c.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			c.Params = gin.Params{{Key: "id", Value: tt.id}}
+			c.Set("user", tt.user)
+
+			tt.handle(c)
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantMessage)
+		})
+	}
+}
+
+func TestTimesheetArchiveName(t *testing.T) {
+	month := time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, "Stundenzettel_IT_2024-04.zip", timesheetArchiveName("IT", month))
+	assert.Equal(t, "Stundenzettel_Human_Resources_2024-04.zip", timesheetArchiveName("Human Resources", month))
+}
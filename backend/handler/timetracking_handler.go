@@ -4,12 +4,15 @@ import (
 	"PeopleFlow/backend/model"
 	"PeopleFlow/backend/repository"
 	"PeopleFlow/backend/service"
+	"PeopleFlow/backend/utils"
 	"fmt"
 	"net/http"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // TimeTrackingHandler erweitern (bestehende Struktur ersetzen)
@@ -219,6 +222,19 @@ func (h *TimeTrackingHandler) GetTimeTrackingView(c *gin.Context) {
 		employeeSummariesWithOvertime = append(employeeSummariesWithOvertime, enhancedSummary)
 	}
 
+	// Abteilungen für den Download der Stundenzettel
+	departmentSet := make(map[string]bool)
+	for _, emp := range employees {
+		if emp.Department != "" {
+			departmentSet[string(emp.Department)] = true
+		}
+	}
+	departments := make([]string, 0, len(departmentSet))
+	for department := range departmentSet {
+		departments = append(departments, department)
+	}
+	sort.Strings(departments)
+
 	// Daten an das Template übergeben
 	c.HTML(http.StatusOK, "timetracking.html", gin.H{
 		"title":                       "Zeiterfassung",
@@ -234,6 +250,7 @@ func (h *TimeTrackingHandler) GetTimeTrackingView(c *gin.Context) {
 		"totalHours":                  totalHours,
 		"totalEmployees":              len(employeeSummaries),
 		"totalProjects":               len(projects),
+		"departments":                 departments,
 	})
 }
 
@@ -320,86 +337,164 @@ func (h *TimeTrackingHandler) GetEmployeeTimeEntries(c *gin.Context) {
 	})
 }
 
+// ExportTimeTracking exportiert Zeiteinträge als CSV, XLSX oder PDF.
+// Query: format, groupBy (day, week, project), startDate, endDate, employeeIds, department, projectId, source.
+// Mitarbeiter ohne Personalrolle exportieren nur ihre eigenen Einträge.
 func (h *TimeTrackingHandler) ExportTimeTracking(c *gin.Context) {
-	// Parameter für Filterung
-	startDateStr := c.Query("startDate")
-	endDateStr := c.Query("endDate")
-	projectID := c.Query("projectId")
-	employeeIDs := c.QueryArray("employeeIds")
-
-	// Datumswerte parsen
-	var startDate, endDate time.Time
-	var err error
+	format, err := model.ParseExportFormat(c.Query("format"), model.ExportFormatCSV, model.ExportFormatXLSX, model.ExportFormatPDF)
+	if err != nil {
+		timeExportErrors.respond(c, err)
+		return
+	}
+	grouping, err := model.ParseTimeExportGrouping(c.Query("groupBy"))
+	if err != nil {
+		timeExportErrors.respond(c, err)
+		return
+	}
+	location := timeExportLocation()
+	startDate, endDate, err := model.ParseTimeExportRange(c.Query("startDate"), c.Query("endDate"), location)
+	if err != nil {
+		timeExportErrors.respond(c, err)
+		return
+	}
 
-	if startDateStr != "" {
-		startDate, err = time.Parse("2006-01-02", startDateStr)
+	// Mitarbeiter-IDs als wiederholter Parameter oder kommagetrennt
+	query := repository.EmployeeQuery{}
+	if values := c.QueryArray("employeeIds"); len(values) > 0 {
+		ids, err := parseObjectIDs(strings.Join(values, ","))
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiges Startdatum"})
+			timeExportErrors.respond(c, fmt.Errorf("%w: %v", model.ErrInvalidTimeExportFilter, err))
 			return
 		}
+		query.IDs = ids
 	}
-
-	if endDateStr != "" {
-		endDate, err = time.Parse("2006-01-02", endDateStr)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Ungültiges Enddatum"})
-			return
+	user := apiCurrentUser(c)
+	if !apiIsStaff(user) {
+		var own []primitive.ObjectID
+		if user.EmployeeID != nil && (query.IDs == nil || containsObjectID(query.IDs, *user.EmployeeID)) {
+			own = append(own, *user.EmployeeID)
 		}
+		query.IDs = append([]primitive.ObjectID{}, own...)
 	}
 
-	// Alle Mitarbeiter abrufen, wenn keine spezifischen IDs angegeben wurden
-	var employees []*model.Employee
-	if len(employeeIDs) == 0 {
-		employees, _, err = h.employeeRepo.FindAll(0, 1000, "lastName", 1)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Fehler beim Abrufen der Mitarbeiter"})
-			return
-		}
-	} else {
-		// Nur die angegebenen Mitarbeiter abrufen
-		for _, id := range employeeIDs {
-			emp, err := h.employeeRepo.FindByID(id)
-			if err == nil {
-				employees = append(employees, emp)
-			}
-		}
+	employees, _, err := h.employeeRepo.Search(query)
+	if err != nil {
+		timeExportErrors.respond(c, err)
+		return
 	}
 
-	// CSV-Header
-	csvContent := "Mitarbeiter,Datum,Start,Ende,Dauer,Projekt,Tätigkeit\n"
+	entries := model.CollectTimeExportEntries(employees, model.TimeExportFilter{
+		From:        startDate,
+		To:          endDate,
+		Departments: splitExportList(c.Query("department")),
+		Projects:    splitExportList(c.Query("projectId")),
+		Sources:     splitExportList(c.Query("source")),
+		Location:    location,
+	})
+	table := model.BuildTimeExportTable(entries, grouping)
 
-	// Zeiteinträge filtern und sammeln
-	for _, employee := range employees {
-		for _, entry := range employee.TimeEntries {
-			// Datumsfilterung
-			if !startDate.IsZero() && entry.Date.Before(startDate) {
-				continue
-			}
-			if !endDate.IsZero() && entry.Date.After(endDate) {
-				continue
-			}
+	writeTimeExport(c, format, table, startDate, endDate, time.Now().In(location))
+}
 
-			// Projektfilterung
-			if projectID != "" && entry.ProjectID != projectID {
-				continue
-			}
+// timeExportLocation gibt die Zeitzone zurück, in der Datum und Uhrzeiten exportiert werden
+func timeExportLocation() *time.Location {
+	location, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		return time.Local
+	}
+	return location
+}
+
+// timeExportFileName gibt den Dateinamen eines Zeitexports zurück, z.B. Zeiterfassung_2024-04-01_2024-04-30.csv
+func timeExportFileName(format model.ExportFormat, startDate, endDate, now time.Time) string {
+	switch {
+	case !startDate.IsZero() && !endDate.IsZero():
+		return fmt.Sprintf("Zeiterfassung_%s_%s.%s", startDate.Format("2006-01-02"), endDate.Format("2006-01-02"), format)
+	case !startDate.IsZero():
+		return fmt.Sprintf("Zeiterfassung_ab_%s.%s", startDate.Format("2006-01-02"), format)
+	case !endDate.IsZero():
+		return fmt.Sprintf("Zeiterfassung_bis_%s.%s", endDate.Format("2006-01-02"), format)
+	default:
+		return fmt.Sprintf("Zeiterfassung_%s.%s", now.Format("2006-01-02"), format)
+	}
+}
 
-			// CSV-Zeile hinzufügen
-			csvContent += employee.FirstName + " " + employee.LastName + ","
-			csvContent += entry.Date.Format("02.01.2006") + ","
-			csvContent += entry.StartTime.Format("15:04") + ","
-			csvContent += entry.EndTime.Format("15:04") + ","
-			csvContent += fmt.Sprintf("%.2f", entry.Duration) + ","
-			csvContent += entry.ProjectName + ","
-			csvContent += entry.Activity + "\n"
+// timeExportSubtitle beschreibt Zeitraum und Erstellungszeitpunkt im PDF
+func timeExportSubtitle(startDate, endDate, now time.Time) string {
+	period := "gesamt"
+	switch {
+	case !startDate.IsZero() && !endDate.IsZero():
+		period = startDate.Format("02.01.2006") + " – " + endDate.Format("02.01.2006")
+	case !startDate.IsZero():
+		period = "ab " + startDate.Format("02.01.2006")
+	case !endDate.IsZero():
+		period = "bis " + endDate.Format("02.01.2006")
+	}
+	return "Zeitraum: " + period + " · erstellt am " + now.Format("02.01.2006 15:04")
+}
+
+// writeTimeExport schreibt die Tabelle eines Zeitexports im gewählten Format als Download
+func writeTimeExport(c *gin.Context, format model.ExportFormat, table *model.TimeExportTable, startDate, endDate, now time.Time) {
+	var content []byte
+	var contentType string
+	var err error
+
+	switch format {
+	case model.ExportFormatXLSX:
+		content, err = utils.WriteXLSX(utils.XLSXSheet{Name: table.Title, Header: table.Header(), Rows: table.Rows})
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case model.ExportFormatPDF:
+		pdfTable := utils.PDFTable{
+			Title:    table.Title,
+			Subtitle: timeExportSubtitle(startDate, endDate, now),
+			Header:   table.Header(),
+			Footer:   make([]string, len(table.Columns)),
+		}
+		for _, column := range table.Columns {
+			pdfTable.Widths = append(pdfTable.Widths, column.Width)
+			pdfTable.Right = append(pdfTable.Right, column.Numeric)
+		}
+		for _, row := range table.Rows {
+			pdfTable.Rows = append(pdfTable.Rows, formatExportRow(row))
+		}
+		pdfTable.Footer[0] = "Summe"
+		pdfTable.Footer[table.HoursIndex] = model.FormatExportValue(table.TotalHours)
+		content = utils.WritePDFTable(pdfTable)
+		contentType = "application/pdf"
+	default:
+		rows := make([][]string, len(table.Rows))
+		for i, row := range table.Rows {
+			rows[i] = formatExportRow(row)
 		}
+		content, err = utils.WriteCSV(table.Header(), rows)
+		contentType = "text/csv; charset=utf-8"
 	}
+	if err != nil {
+		timeExportErrors.respond(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", "attachment; filename="+timeExportFileName(format, startDate, endDate, now))
+	c.Data(http.StatusOK, contentType, content)
+}
+
+// formatExportRow formatiert die Zellen einer Exportzeile als Text
+func formatExportRow(row []interface{}) []string {
+	values := make([]string, len(row))
+	for i, value := range row {
+		values[i] = model.FormatExportValue(value)
+	}
+	return values
+}
 
-	// CSV-Datei senden
-	c.Header("Content-Description", "File Transfer")
-	c.Header("Content-Disposition", "attachment; filename=zeiterfassung.csv")
-	c.Header("Content-Type", "text/csv; charset=utf-8")
-	c.String(http.StatusOK, csvContent)
+// timeExportErrors übersetzt Fehler des Zeitexports in JSON-Antworten
+var timeExportErrors = errorResponse{
+	fallback: "Fehler beim Export der Zeiterfassung",
+	mappings: []errorMapping{
+		{errs: []error{model.ErrInvalidExportFormat}, status: http.StatusBadRequest, message: "Unbekanntes Exportformat (erlaubt: csv, xlsx, pdf)"},
+		{errs: []error{model.ErrInvalidTimeExportGrouping}, status: http.StatusBadRequest, message: "Unbekannte Gruppierung (erlaubt: day, week, project)"},
+		{errs: []error{model.ErrInvalidTimeExportFilter}, status: http.StatusBadRequest, message: "Ungültiger Filter: %s", detail: true},
+	},
 }
 
 func (h *TimeTrackingHandler) RecalculateOvertime(c *gin.Context) {
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeExportErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		err         error
+		wantStatus  int
+		wantMessage string
+	}{
+		{fmt.Errorf("%w: \"json\"", model.ErrInvalidExportFormat), http.StatusBadRequest, "erlaubt: csv, xlsx, pdf"},
+		{fmt.Errorf("%w: \"month\"", model.ErrInvalidTimeExportGrouping), http.StatusBadRequest, "Unbekannte Gruppierung"},
+		{fmt.Errorf("%w: ungültiges Startdatum", model.ErrInvalidTimeExportFilter), http.StatusBadRequest, "Ungültiger Filter: ungültiges Startdatum"},
+		{assert.AnError, http.StatusInternalServerError, "Fehler beim Export der Zeiterfassung"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			timeExportErrors.respond(c, tt.err)
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantMessage)
+		})
+	}
+}
+
+func TestTimeTrackingHandler_ExportRejectsInvalidFilters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := &TimeTrackingHandler{}
+
+	tests := []struct {
+		name        string
+		query       string
+		wantMessage string
+	}{
+		{"unbekanntes Format", "format=json", "Unbekanntes Exportformat"},
+		{"unbekannte Gruppierung", "groupBy=month", "Unbekannte Gruppierung"},
+		{"ungültiges Datum", "startDate=01.04.2024", "ungültiges Startdatum"},
+		{"Ende vor Beginn", "startDate=2024-04-30&endDate=2024-04-01", "Enddatum liegt vor dem Startdatum"},
+		{"ungültige Mitarbeiter-ID", "employeeIds=abc", "ungültige ID"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/timetracking/export?"+tt.query, nil)
+			c.Set("user", &model.User{Role: model.RoleAdmin})
+			h.ExportTimeTracking(c)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantMessage)
+		})
+	}
+}
+
+func TestTimeExportFileName(t *testing.T) {
+	start := time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, time.April, 30, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2024, time.May, 2, 9, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, "Zeiterfassung_2024-04-01_2024-04-30.csv", timeExportFileName(model.ExportFormatCSV, start, end, now))
+	assert.Equal(t, "Zeiterfassung_ab_2024-04-01.pdf", timeExportFileName(model.ExportFormatPDF, start, time.Time{}, now))
+	assert.Equal(t, "Zeiterfassung_bis_2024-04-30.xlsx", timeExportFileName(model.ExportFormatXLSX, time.Time{}, end, now))
+	assert.Equal(t, "Zeiterfassung_2024-05-02.csv", timeExportFileName(model.ExportFormatCSV, time.Time{}, time.Time{}, now))
+
+	assert.Equal(t, "Zeitraum: 01.04.2024 – 30.04.2024 · erstellt am 02.05.2024 09:30", timeExportSubtitle(start, end, now))
+	assert.Equal(t, "Zeitraum: gesamt · erstellt am 02.05.2024 09:30", timeExportSubtitle(time.Time{}, time.Time{}, now))
+}
+
+func TestWriteTimeExport(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	now := time.Date(2024, time.May, 2, 9, 30, 0, 0, time.UTC)
+	start := time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, time.April, 30, 0, 0, 0, 0, time.UTC)
+	table := model.BuildTimeExportTable([]model.TimeExportEntry{{
+		EmployeeName: "Anna Schmidt", EmployeeNumber: "1001", Department: "IT",
+		Date:      time.Date(2024, time.April, 2, 0, 0, 0, 0, time.UTC),
+		StartTime: time.Date(2024, time.April, 2, 8, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, time.April, 2, 12, 30, 0, 0, time.UTC),
+		Hours:     4.5, ProjectName: "Kunde, Nord", Description: `Abstimmung "Phase 2"`, WageType: "Normal", Source: "123erfasst",
+	}}, model.TimeExportGroupNone)
+
+	t.Run("CSV", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		writeTimeExport(c, model.ExportFormatCSV, table, start, end, now)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "attachment; filename=Zeiterfassung_2024-04-01_2024-04-30.csv", w.Header().Get("Content-Disposition"))
+		rows, err := utils.ReadCSVRows(w.Body.Bytes())
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{
+			{"Mitarbeiter", "Personalnummer", "Abteilung", "Datum", "Beginn", "Ende", "Stunden", "Projekt", "Tätigkeit", "Beschreibung", "Lohnart", "Quelle"},
+			{"Anna Schmidt", "1001", "IT", "02.04.2024", "08:00", "12:30", "4,5", "Kunde, Nord", "", `Abstimmung "Phase 2"`, "Normal", "123erfasst"},
+		}, rows)
+	})
+
+	t.Run("XLSX", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		writeTimeExport(c, model.ExportFormatXLSX, table, start, end, now)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		rows, err := utils.ReadXLSXRows(w.Body.Bytes())
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		assert.Equal(t, "Kunde, Nord", rows[1][7])
+		assert.Equal(t, "4.5", rows[1][6])
+	})
+
+	t.Run("PDF", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		writeTimeExport(c, model.ExportFormatPDF, table, start, end, now)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+		assert.True(t, bytes.HasPrefix(w.Body.Bytes(), []byte("%PDF-")))
+		assert.Contains(t, w.Body.String(), "(Summe) Tj")
+		assert.Contains(t, w.Body.String(), "(4,5) Tj")
+	})
+}
@@ -2,24 +2,31 @@
 package handler
 
 import (
+	"errors"
+	"log"
 	"net/http"
 	"time"
 
 	"PeopleFlow/backend/model"
 	"PeopleFlow/backend/repository"
+	"PeopleFlow/backend/service"
 
 	"github.com/gin-gonic/gin"
 )
 
 // UserHandler verwaltet alle Anfragen zu Benutzern
 type UserHandler struct {
-	userRepo *repository.UserRepository
+	userRepo          *repository.UserRepository
+	employeeRepo      *repository.EmployeeRepository
+	invitationService *service.InvitationService
 }
 
 // NewUserHandler erstellt einen neuen UserHandler
 func NewUserHandler() *UserHandler {
 	return &UserHandler{
-		userRepo: repository.NewUserRepository(),
+		userRepo:          repository.NewUserRepository(),
+		employeeRepo:      repository.NewEmployeeRepository(),
+		invitationService: service.NewInvitationService(),
 	}
 }
 
@@ -59,13 +66,20 @@ func (h *UserHandler) ShowAddUserForm(c *gin.Context) {
 	user, _ := c.Get("user")
 	userModel := user.(*model.User)
 
+	// Mitarbeiter für die optionale Übernahme der Stammdaten laden
+	employees, _, err := h.employeeRepo.FindAll(0, 1000, "lastName", 1)
+	if err != nil {
+		employees = []*model.Employee{}
+	}
+
 	c.HTML(http.StatusOK, "user_add.html", gin.H{
-		"title":    "Benutzer hinzufügen",
-		"active":   "users",
-		"user":     userModel.FirstName + " " + userModel.LastName,
-		"email":    userModel.Email,
-		"year":     time.Now().Year(),
-		"userRole": c.GetString("userRole"),
+		"title":     "Benutzer hinzufügen",
+		"active":    "users",
+		"user":      userModel.FirstName + " " + userModel.LastName,
+		"email":     userModel.Email,
+		"year":      time.Now().Year(),
+		"userRole":  c.GetString("userRole"),
+		"employees": employees,
 	})
 }
 
@@ -77,6 +91,50 @@ func (h *UserHandler) AddUser(c *gin.Context) {
 	email := c.PostForm("email")
 	password := c.PostForm("password")
 	role := c.PostForm("role")
+	employeeID := c.PostForm("employeeId")
+
+	currentUser, _ := c.Get("user")
+	currentUserModel := currentUser.(*model.User)
+
+	// Ohne Passwort (oder auf Wunsch) wird der Benutzer eingeladen und setzt sein Passwort selbst
+	if password == "" || c.PostForm("sendInvitation") == "on" {
+		invitedUser := &model.User{
+			FirstName: firstName,
+			LastName:  lastName,
+			Email:     email,
+			Role:      model.UserRole(role),
+		}
+
+		_, err := h.invitationService.InviteUser(invitedUser, employeeID, currentUserModel)
+		if err != nil && invitedUser.ID.IsZero() {
+			c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+				"title":   "Fehler",
+				"message": "Fehler beim Einladen des Benutzers: " + err.Error(),
+				"year":    time.Now().Year(),
+			})
+			return
+		}
+
+		activityRepo := repository.NewActivityRepository()
+		_, _ = activityRepo.LogActivity(
+			model.ActivityTypeUserAdded,
+			currentUserModel.ID,
+			currentUserModel.FirstName+" "+currentUserModel.LastName,
+			invitedUser.ID,
+			"user",
+			invitedUser.FirstName+" "+invitedUser.LastName,
+			"Neuer Benutzer eingeladen",
+		)
+
+		// Benutzer wurde angelegt, aber die E-Mail konnte nicht versendet werden
+		if err != nil {
+			c.Redirect(http.StatusFound, "/settings?success=invited&warning=invitation_email_failed")
+			return
+		}
+
+		c.Redirect(http.StatusFound, "/settings?success=invited")
+		return
+	}
 
 	// Neuen Benutzer erstellen
 	newUser := &model.User{
@@ -93,6 +151,14 @@ func (h *UserHandler) AddUser(c *gin.Context) {
 	// Benutzer in der Datenbank speichern
 	err := h.userRepo.Create(newUser)
 	if err != nil {
+		if errors.Is(err, repository.ErrInvalidPassword) {
+			c.HTML(http.StatusBadRequest, "error.html", gin.H{
+				"title":   "Fehler",
+				"message": passwordPolicyErrorMessage(err, h.userRepo.PasswordPolicy()),
+				"year":    time.Now().Year(),
+			})
+			return
+		}
 		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
 			"title":   "Fehler",
 			"message": "Fehler beim Erstellen des Benutzers: " + err.Error(),
@@ -101,10 +167,16 @@ func (h *UserHandler) AddUser(c *gin.Context) {
 		return
 	}
 
-	// Aktivität loggen
-	currentUser, _ := c.Get("user")
-	currentUserModel := currentUser.(*model.User)
+	// Mit Mitarbeiter verknüpfen (explizit ausgewählt oder über die E-Mail-Adresse)
+	if employeeID != "" {
+		if employee, err := h.employeeRepo.FindByID(employeeID); err == nil {
+			_ = h.userRepo.LinkEmployee(newUser.ID.Hex(), employee.ID)
+		}
+	} else if employee, err := h.employeeRepo.FindByEmail(newUser.Email); err == nil {
+		_ = h.userRepo.LinkEmployee(newUser.ID.Hex(), employee.ID)
+	}
 
+	// Aktivität loggen
 	activityRepo := repository.NewActivityRepository()
 	_, _ = activityRepo.LogActivity(
 		model.ActivityTypeUserAdded,
@@ -173,8 +245,18 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 	// Passwort nur aktualisieren, wenn ein neues angegeben wurde
 	newPassword := c.PostForm("password")
 	if newPassword != "" {
-		userToUpdate.Password = newPassword
-		userToUpdate.HashPassword()
+		if err := h.userRepo.UpdatePassword(userToUpdate.ID.Hex(), newPassword); err != nil {
+			message := "Fehler beim Aktualisieren des Passworts: " + err.Error()
+			if errors.Is(err, repository.ErrInvalidPassword) {
+				message = passwordPolicyErrorMessage(err, h.userRepo.PasswordPolicy())
+			}
+			c.HTML(http.StatusBadRequest, "error.html", gin.H{
+				"title":   "Fehler",
+				"message": message,
+				"year":    time.Now().Year(),
+			})
+			return
+		}
 	}
 
 	// Rolle nur aktualisieren, wenn der aktuelle Benutzer ein Admin ist
@@ -197,6 +279,22 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 		return
 	}
 
+	// Sprache für E-Mails; unbekannte Werte bedeuten Systemsprache
+	language := c.PostForm("language")
+	if !model.IsValidEmailLanguage(language) {
+		language = ""
+	}
+	if language != userToUpdate.Language {
+		if err := h.userRepo.UpdateLanguage(userToUpdate.ID, language); err != nil {
+			c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+				"title":   "Fehler",
+				"message": "Fehler beim Aktualisieren der Sprache: " + err.Error(),
+				"year":    time.Now().Year(),
+			})
+			return
+		}
+	}
+
 	// Aktivität loggen
 	currentUser, _ := c.Get("user")
 	currentUserModel := currentUser.(*model.User)
@@ -242,6 +340,11 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 	currentUser, _ := c.Get("user")
 	currentUserModel := currentUser.(*model.User)
 
+	// API-Tokens des gelöschten Benutzers widerrufen
+	if err := service.NewAPITokenService().RevokeAllForUser(userToDelete.ID, currentUserModel.FirstName+" "+currentUserModel.LastName); err != nil {
+		log.Printf("Fehler beim Widerrufen der API-Tokens von Benutzer %s: %v", userToDelete.Email, err)
+	}
+
 	activityRepo := repository.NewActivityRepository()
 	_, _ = activityRepo.LogActivity(
 		model.ActivityTypeUserDeleted,
@@ -263,14 +366,20 @@ func (h *UserHandler) ShowUserProfile(c *gin.Context) {
 	currentUser, _ := c.Get("user")
 	currentUserModel := currentUser.(*model.User)
 
+	policy := h.userRepo.PasswordPolicy()
+
 	c.HTML(http.StatusOK, "profile.html", gin.H{
-		"title":    "Mein Profil",
-		"active":   "profile",
-		"user":     currentUserModel.FirstName + " " + currentUserModel.LastName,
-		"email":    currentUserModel.Email,
-		"year":     time.Now().Year(),
-		"userRole": c.GetString("userRole"),
-		"profile":  currentUserModel,
+		"title":             "Mein Profil",
+		"active":            "profile",
+		"user":              currentUserModel.FirstName + " " + currentUserModel.LastName,
+		"email":             currentUserModel.Email,
+		"year":              time.Now().Year(),
+		"userRole":          c.GetString("userRole"),
+		"profile":           currentUserModel,
+		"passwordRules":     policy.Describe(),
+		"passwordMinLength": policy.MinLength,
+		"passwordExpired":   h.userRepo.IsPasswordExpired(currentUserModel),
+		"success":           c.Query("success"),
 	})
 }
 
@@ -315,35 +424,18 @@ func (h *UserHandler) ChangePassword(c *gin.Context) {
 		return
 	}
 
-	// Überprüfen, ob das neue Passwort eine Mindestlänge hat
-	if len(newPassword) < 6 {
-		c.HTML(http.StatusBadRequest, "error.html", gin.H{
-			"title":   "Fehler",
-			"message": "Das neue Passwort muss mindestens 6 Zeichen lang sein",
-			"year":    time.Now().Year(),
-		})
-		return
-	}
-
-	// Passwort aktualisieren
-	user.Password = newPassword
-	err = user.HashPassword()
-	if err != nil {
-		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
-			"title":   "Fehler",
-			"message": "Fehler beim Hashen des Passworts: " + err.Error(),
-			"year":    time.Now().Year(),
-		})
-		return
-	}
-
-	// Benutzer in der Datenbank aktualisieren
-	user.UpdatedAt = time.Now()
-	err = h.userRepo.Update(user)
+	// Passwort unter Anwendung der Passwortrichtlinie aktualisieren
+	err = h.userRepo.UpdatePassword(user.ID.Hex(), newPassword)
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+		status := http.StatusInternalServerError
+		message := "Fehler beim Aktualisieren des Passworts: " + err.Error()
+		if errors.Is(err, repository.ErrInvalidPassword) {
+			status = http.StatusBadRequest
+			message = passwordPolicyErrorMessage(err, h.userRepo.PasswordPolicy())
+		}
+		c.HTML(status, "error.html", gin.H{
 			"title":   "Fehler",
-			"message": "Fehler beim Aktualisieren des Benutzers: " + err.Error(),
+			"message": message,
 			"year":    time.Now().Year(),
 		})
 		return
@@ -397,6 +489,10 @@ func (h *UserHandler) ShowSettings(c *gin.Context) {
 		data["error"] = errorParam
 	}
 
+	if warning := c.Query("warning"); warning != "" {
+		data["warning"] = warning
+	}
+
 	// Wenn der Benutzer ein Administrator ist, fügen wir Benutzerdaten hinzu
 	if userRole == string(model.RoleAdmin) {
 		users, _, err := h.userRepo.FindAll(0, 1000)
@@ -405,6 +501,12 @@ func (h *UserHandler) ShowSettings(c *gin.Context) {
 		}
 		data["users"] = users
 		data["totalUsers"] = len(users)
+
+		// Mitarbeiter für die Verknüpfung beim Anlegen/Einladen neuer Benutzer
+		employees, _, err := h.employeeRepo.FindAll(0, 1000, "lastName", 1)
+		if err == nil {
+			data["employees"] = employees
+		}
 	}
 
 	c.HTML(http.StatusOK, "settings.html", data)
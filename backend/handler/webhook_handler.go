@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+	"PeopleFlow/backend/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// webhookDeliveryPageSize ist die Anzahl der Zustellungen pro Seite im Protokoll
+const webhookDeliveryPageSize = 50
+
+// WebhookHandler verwaltet ausgehende Webhooks (nur für Admins)
+type WebhookHandler struct {
+	webhookService *service.WebhookService
+}
+
+// NewWebhookHandler erstellt einen neuen WebhookHandler
+func NewWebhookHandler() *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: service.NewWebhookService(),
+	}
+}
+
+// ListEvents gibt alle abonnierbaren Ereignistypen zurück
+func (h *WebhookHandler) ListEvents(c *gin.Context) {
+	events := make([]gin.H, 0)
+	for _, event := range model.WebhookEvents() {
+		events = append(events, gin.H{
+			"type":  event,
+			"label": event.GetLabel(),
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    events,
+	})
+}
+
+// ListEndpoints gibt alle registrierten Endpunkte zurück
+func (h *WebhookHandler) ListEndpoints(c *gin.Context) {
+	endpoints, err := h.webhookService.ListEndpoints()
+	if err != nil {
+		webhookErrors.respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    endpoints,
+	})
+}
+
+// CreateEndpoint registriert einen neuen Endpunkt
+func (h *WebhookHandler) CreateEndpoint(c *gin.Context) {
+	user := currentWebhookUser(c)
+
+	secret, endpoint, err := h.webhookService.CreateEndpoint(user, c.PostForm("name"), c.PostForm("url"), c.PostFormArray("events"))
+	if err != nil {
+		webhookErrors.respond(c, err)
+		return
+	}
+
+	logWebhookActivity(user, endpoint, "Webhook \""+endpoint.Name+"\" registriert")
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Webhook registriert. Bitte kopieren Sie das Secret jetzt, es wird nicht erneut angezeigt.",
+		"data": gin.H{
+			"secret":   secret,
+			"endpoint": endpoint,
+		},
+	})
+}
+
+// UpdateEndpoint ändert einen Endpunkt
+func (h *WebhookHandler) UpdateEndpoint(c *gin.Context) {
+	user := currentWebhookUser(c)
+
+	active := c.PostForm("active") == "true" || c.PostForm("active") == "on"
+	endpoint, err := h.webhookService.UpdateEndpoint(c.Param("id"), c.PostForm("name"), c.PostForm("url"), c.PostFormArray("events"), active)
+	if err != nil {
+		webhookErrors.respond(c, err)
+		return
+	}
+
+	logWebhookActivity(user, endpoint, "Webhook \""+endpoint.Name+"\" aktualisiert")
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Webhook aktualisiert",
+		"data":    endpoint,
+	})
+}
+
+// RotateSecret erzeugt ein neues Signatur-Secret
+func (h *WebhookHandler) RotateSecret(c *gin.Context) {
+	user := currentWebhookUser(c)
+
+	secret, endpoint, err := h.webhookService.RotateSecret(c.Param("id"))
+	if err != nil {
+		webhookErrors.respond(c, err)
+		return
+	}
+
+	logWebhookActivity(user, endpoint, "Secret des Webhooks \""+endpoint.Name+"\" erneuert")
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Neues Secret erstellt. Bitte kopieren Sie es jetzt, es wird nicht erneut angezeigt.",
+		"data": gin.H{
+			"secret": secret,
+		},
+	})
+}
+
+// DeleteEndpoint löscht einen Endpunkt samt Zustellprotokoll
+func (h *WebhookHandler) DeleteEndpoint(c *gin.Context) {
+	user := currentWebhookUser(c)
+
+	endpoint, err := h.webhookService.DeleteEndpoint(c.Param("id"))
+	if err != nil {
+		webhookErrors.respond(c, err)
+		return
+	}
+
+	logWebhookActivity(user, endpoint, "Webhook \""+endpoint.Name+"\" gelöscht")
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Webhook gelöscht",
+	})
+}
+
+// ListDeliveries gibt das Zustellprotokoll eines Endpunkts zurück (?page=)
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	endpoint, err := h.webhookService.GetEndpoint(c.Param("id"))
+	if err != nil {
+		webhookErrors.respond(c, err)
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+
+	deliveries, total, err := h.webhookService.ListDeliveries(endpoint, int64((page-1)*webhookDeliveryPageSize), webhookDeliveryPageSize)
+	if err != nil {
+		webhookErrors.respond(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    deliveries,
+		"page":    page,
+		"total":   total,
+	})
+}
+
+// Redeliver stellt ein Ereignis erneut zu
+func (h *WebhookHandler) Redeliver(c *gin.Context) {
+	endpoint, err := h.webhookService.GetEndpoint(c.Param("id"))
+	if err != nil {
+		webhookErrors.respond(c, err)
+		return
+	}
+
+	delivery, err := h.webhookService.Redeliver(endpoint, c.Param("deliveryId"))
+	if err != nil {
+		webhookErrors.respond(c, err)
+		return
+	}
+
+	message := "Ereignis erneut zugestellt"
+	if delivery.Status != model.WebhookDeliverySuccess {
+		message = "Zustellung fehlgeschlagen, sie wird automatisch wiederholt"
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": message,
+		"data":    delivery,
+	})
+}
+
+// currentWebhookUser gibt den angemeldeten Benutzer zurück
+func currentWebhookUser(c *gin.Context) *model.User {
+	user, _ := c.Get("user")
+	return user.(*model.User)
+}
+
+// webhookErrors übersetzt Webhook-Fehler in JSON-Antworten
+var webhookErrors = errorResponse{
+	fallback: "Fehler bei der Webhook-Verwaltung",
+	mappings: []errorMapping{
+		{errs: []error{repository.ErrWebhookNotFound, repository.ErrInvalidID}, status: http.StatusNotFound, message: "Webhook nicht gefunden"},
+		{errs: []error{repository.ErrWebhookDeliveryNotFound}, status: http.StatusNotFound, message: "Zustellung nicht gefunden"},
+		{errs: []error{model.ErrWebhookNameRequired}, status: http.StatusBadRequest, message: "Bitte geben Sie einen Namen für den Webhook ein"},
+		{errs: []error{model.ErrInvalidWebhookURL}, status: http.StatusBadRequest, message: "Bitte geben Sie eine gültige http(s)-URL ein"},
+		{errs: []error{model.ErrInvalidWebhookEvent}, status: http.StatusBadRequest, message: "Unbekannter Ereignistyp"},
+	},
+}
+
+// logWebhookActivity protokolliert Änderungen an Webhooks
+func logWebhookActivity(user *model.User, endpoint *model.WebhookEndpoint, description string) {
+	activityRepo := repository.NewActivityRepository()
+	_, _ = activityRepo.LogActivity(
+		model.ActivityTypeSystemSettingChanged,
+		user.ID,
+		user.FirstName+" "+user.LastName,
+		endpoint.ID,
+		"webhook",
+		endpoint.Name,
+		description,
+	)
+}
@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// fakeAPITokenAuthenticator liefert ein festes Ergebnis für jedes Token
+type fakeAPITokenAuthenticator struct {
+	user *model.User
+	err  error
+}
+
+func (f *fakeAPITokenAuthenticator) Authenticate(string, string, string) (*model.APIToken, *model.User, error) {
+	if f.err != nil {
+		return nil, nil, f.err
+	}
+	return &model.APIToken{ID: primitive.NewObjectID()}, f.user, nil
+}
+
+// useAPITokenAuthenticator ersetzt den Prüfer für die Dauer des Tests
+func useAPITokenAuthenticator(t *testing.T, auth *fakeAPITokenAuthenticator) {
+	original := newAPITokenAuthenticator
+	newAPITokenAuthenticator = func() apiTokenAuthenticator { return auth }
+	t.Cleanup(func() { newAPITokenAuthenticator = original })
+}
+
+func newAPITokenTestRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.SetHTMLTemplate(template.Must(template.New("error.html").Parse("{{.message}}")))
+	router.Use(AuthMiddleware())
+	router.GET("/api/users/:id", SelfOrAdminMiddleware(), func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetString("userRole"))
+	})
+	router.POST("/api/users/:id", SelfOrAdminMiddleware(), func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetString("userRole"))
+	})
+	return router
+}
+
+func serveWithAPIToken(router *gin.Engine, method, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set("Authorization", "Bearer "+model.APITokenPrefix+"test")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAuthMiddleware_APITokenErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantStatus  int
+		wantMessage string
+	}{
+		{"fehlender Scope", service.ErrAPITokenScope, http.StatusForbidden, "erforderliche Berechtigung"},
+		{"Passwort abgelaufen", service.ErrAPITokenPasswordExpired, http.StatusForbidden, "Passwort ist abgelaufen"},
+		{"ungültiges Token", service.ErrAPITokenInvalid, http.StatusUnauthorized, "Ungültiges, abgelaufenes oder widerrufenes API-Token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useAPITokenAuthenticator(t, &fakeAPITokenAuthenticator{err: tt.err})
+			w := serveWithAPIToken(newAPITokenTestRouter(), http.MethodPost, "/api/users/"+primitive.NewObjectID().Hex())
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantMessage)
+		})
+	}
+}
+
+func TestAuthMiddleware_APITokenSetsRole(t *testing.T) {
+	user := &model.User{ID: primitive.NewObjectID(), Role: model.RoleHR}
+	useAPITokenAuthenticator(t, &fakeAPITokenAuthenticator{user: user})
+
+	w := serveWithAPIToken(newAPITokenTestRouter(), http.MethodGet, "/api/users/"+user.ID.Hex())
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, string(model.RoleHR), w.Body.String())
+}
+
+func TestSelfOrAdminMiddleware_APITokenWithoutAdminScope(t *testing.T) {
+	// Ohne admin-Scope liefert der Prüfer ein Admin-Konto mit der Rolle Manager
+	admin := &model.User{ID: primitive.NewObjectID(), Role: model.RoleManager}
+	useAPITokenAuthenticator(t, &fakeAPITokenAuthenticator{user: admin})
+	router := newAPITokenTestRouter()
+
+	w := serveWithAPIToken(router, http.MethodGet, "/api/users/"+primitive.NewObjectID().Hex())
+	assert.Equal(t, http.StatusForbidden, w.Code, "fremde Konten nur mit admin-Scope")
+
+	w = serveWithAPIToken(router, http.MethodGet, "/api/users/"+admin.ID.Hex())
+	assert.Equal(t, http.StatusOK, w.Code, "das eigene Konto bleibt erreichbar")
+
+	admin.Role = model.RoleAdmin
+	w = serveWithAPIToken(router, http.MethodGet, "/api/users/"+primitive.NewObjectID().Hex())
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -3,6 +3,7 @@ package middleware
 import (
 	"PeopleFlow/backend/model"
 	"PeopleFlow/backend/repository"
+	"PeopleFlow/backend/service"
 	"PeopleFlow/backend/utils"
 	"errors"
 	"net/http"
@@ -14,12 +15,17 @@ import (
 // AuthMiddleware ist eine Middleware für die Benutzerauthentifizierung
 func AuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		// API-Tokens (Authorization: Bearer pf_...) haben Vorrang vor dem Session-Cookie
+		if apiToken := extractAPIToken(c); apiToken != "" {
+			authenticateAPIToken(c, apiToken)
+			return
+		}
+
 		// Token aus dem Cookie oder Auth-Header extrahieren
 		tokenString, err := extractToken(c)
 		if err != nil {
 			// Kein Token gefunden, zum Login umleiten
-			c.Redirect(http.StatusFound, "/login")
-			c.Abort()
+			redirectToLogin(c)
 			return
 		}
 
@@ -27,8 +33,7 @@ func AuthMiddleware() gin.HandlerFunc {
 		claims, err := utils.ValidateJWT(tokenString)
 		if err != nil {
 			// Ungültiges Token, zum Login umleiten
-			c.Redirect(http.StatusFound, "/login")
-			c.Abort()
+			redirectToLogin(c)
 			return
 		}
 
@@ -37,15 +42,24 @@ func AuthMiddleware() gin.HandlerFunc {
 		user, err := userRepo.FindByID(claims.UserID)
 		if err != nil {
 			// Benutzer nicht gefunden, zum Login umleiten
-			c.Redirect(http.StatusFound, "/login")
-			c.Abort()
+			redirectToLogin(c)
 			return
 		}
 
 		// Überprüfen, ob der Benutzer aktiv ist
 		if user.Status != model.StatusActive {
 			// Benutzer inaktiv, zum Login umleiten
-			c.Redirect(http.StatusFound, "/login")
+			redirectToLogin(c)
+			return
+		}
+
+		// Abgelaufene Passwörter müssen zuerst im Profil geändert werden
+		if userRepo.IsPasswordExpired(user) && !isPasswordChangePath(c.Request.URL.Path) {
+			if strings.HasPrefix(c.Request.URL.Path, "/api/") {
+				abortJSON(c, http.StatusForbidden, "forbidden", "Ihr Passwort ist abgelaufen. Bitte ändern Sie es in Ihrem Profil.")
+				return
+			}
+			c.Redirect(http.StatusFound, "/profile?warning=password_expired")
 			c.Abort()
 			return
 		}
@@ -59,6 +73,74 @@ func AuthMiddleware() gin.HandlerFunc {
 	}
 }
 
+// apiTokenAuthenticator prüft API-Tokens (service.APITokenService)
+type apiTokenAuthenticator interface {
+	Authenticate(plainToken, method, clientIP string) (*model.APIToken, *model.User, error)
+}
+
+// newAPITokenAuthenticator erstellt den Prüfer für API-Tokens; Tests ersetzen ihn
+var newAPITokenAuthenticator = func() apiTokenAuthenticator {
+	return service.NewAPITokenService()
+}
+
+// authenticateAPIToken authentifiziert eine Anfrage mit einem persönlichen oder Service-Account-Token.
+// Fehler werden als JSON beantwortet, da API-Clients keiner Weiterleitung zum Login folgen.
+// Ohne admin-Scope liefert der Prüfer ein Admin-Konto als Manager, sodass auch Prüfungen
+// auf userRole in Handlern und SelfOrAdminMiddleware keine Admin-Rechte gewähren.
+func authenticateAPIToken(c *gin.Context, plainToken string) {
+	token, user, err := newAPITokenAuthenticator().Authenticate(plainToken, c.Request.Method, c.ClientIP())
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrAPITokenScope):
+			abortJSON(c, http.StatusForbidden, "forbidden", "Das API-Token besitzt nicht die erforderliche Berechtigung für diese Anfrage")
+		case errors.Is(err, service.ErrAPITokenPasswordExpired):
+			abortJSON(c, http.StatusForbidden, "forbidden", "Ihr Passwort ist abgelaufen. Bitte ändern Sie es in Ihrem Profil.")
+		default:
+			abortJSON(c, http.StatusUnauthorized, "unauthorized", "Ungültiges, abgelaufenes oder widerrufenes API-Token")
+		}
+		return
+	}
+
+	c.Set("user", user)
+	c.Set("userId", user.ID.Hex())
+	c.Set("userRole", string(user.Role))
+	c.Set("apiToken", token)
+
+	c.Next()
+}
+
+// redirectToLogin leitet nicht angemeldete Browser zum Login um.
+// Die REST-API v1 antwortet stattdessen mit 401, da Clients keiner Weiterleitung folgen.
+func redirectToLogin(c *gin.Context) {
+	if IsAPIv1Path(c.Request.URL.Path) {
+		abortJSON(c, http.StatusUnauthorized, "unauthorized", "Authentifizierung erforderlich")
+		return
+	}
+	c.Redirect(http.StatusFound, "/login")
+	c.Abort()
+}
+
+// abortJSON bricht eine API-Anfrage mit einem Fehler ab.
+// Unter /api/v1 wird das Fehlerformat der REST-API mit Fehlercode verwendet.
+func abortJSON(c *gin.Context, status int, code, message string) {
+	if IsAPIv1Path(c.Request.URL.Path) {
+		c.AbortWithStatusJSON(status, gin.H{
+			"success": false,
+			"error":   gin.H{"code": code, "message": message},
+		})
+		return
+	}
+	c.AbortWithStatusJSON(status, gin.H{
+		"success": false,
+		"error":   message,
+	})
+}
+
+// IsAPIv1Path prüft, ob eine Anfrage an die versionierte REST-API geht
+func IsAPIv1Path(path string) bool {
+	return path == "/api/v1" || strings.HasPrefix(path, "/api/v1/")
+}
+
 // AdminMiddleware ist eine Middleware für administrative Operationen
 func AdminMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -72,6 +154,15 @@ func AdminMiddleware() gin.HandlerFunc {
 	}
 }
 
+// isPasswordChangePath prüft, ob eine Route bei abgelaufenem Passwort erreichbar bleiben muss
+func isPasswordChangePath(path string) bool {
+	switch path {
+	case "/profile", "/users/change-password", "/logout":
+		return true
+	}
+	return false
+}
+
 // extractToken extrahiert das JWT-Token aus dem Cookie oder Header
 func extractToken(c *gin.Context) (string, error) {
 	// Zuerst nach Cookie suchen
@@ -89,6 +180,15 @@ func extractToken(c *gin.Context) (string, error) {
 	return "", errors.New("kein Token gefunden")
 }
 
+// extractAPIToken extrahiert ein API-Token aus dem Authorization-Header
+func extractAPIToken(c *gin.Context) string {
+	bearerToken := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if model.IsAPIToken(bearerToken) {
+		return bearerToken
+	}
+	return ""
+}
+
 // CORSMiddleware aktiviert CORS für Anfragen vom Frontend
 func CORSMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
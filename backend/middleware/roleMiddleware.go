@@ -31,7 +31,19 @@ func RoleMiddleware(allowedRoles ...model.UserRole) gin.HandlerFunc {
 			}
 		}
 
+		// API-Tokens ohne admin-Scope erhalten keine Admin-Rechte
+		if hasPermission && userRole == string(model.RoleAdmin) {
+			if token, ok := c.Get("apiToken"); ok && !token.(*model.APIToken).HasScope(model.APITokenScopeAdmin) {
+				hasPermission = false
+			}
+		}
+
 		if !hasPermission {
+			if _, ok := c.Get("apiToken"); ok {
+				c.JSON(http.StatusForbidden, gin.H{"success": false, "error": "Keine Berechtigung für diese Ressource"})
+				c.Abort()
+				return
+			}
 			c.HTML(http.StatusForbidden, "error.html", gin.H{
 				"title":   "Zugriff verweigert",
 				"message": "Sie haben keine Berechtigung, auf diese Ressource zuzugreifen.",
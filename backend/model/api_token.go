@@ -0,0 +1,195 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// APITokenType unterscheidet persönliche Tokens von Service-Account-Tokens
+type APITokenType string
+
+// APITokenScope beschreibt eine Berechtigung eines API-Tokens
+type APITokenScope string
+
+const (
+	APITokenTypePersonal APITokenType = "personal" // Gehört zu einem Benutzer und erbt dessen Rolle
+	APITokenTypeService  APITokenType = "service"  // Gehört zu keinem Benutzer, Rolle wird vom Admin festgelegt
+
+	APITokenScopeRead  APITokenScope = "read"  // Lesende Anfragen (GET, HEAD)
+	APITokenScopeWrite APITokenScope = "write" // Ändernde Anfragen (POST, PUT, PATCH, DELETE)
+	APITokenScopeAdmin APITokenScope = "admin" // Zugriff auf Admin-Routen (nur mit Admin-Rolle wirksam)
+
+	// APITokenPrefix kennzeichnet PeopleFlow-API-Tokens im Authorization-Header
+	APITokenPrefix = "pf_"
+
+	// MaxAPITokenValidityDays begrenzt die Gültigkeitsdauer eines Tokens
+	MaxAPITokenValidityDays = 365
+)
+
+// API-Token-Fehler
+var (
+	ErrInvalidTokenScope = errors.New("invalid token scope")
+	ErrInvalidTokenType  = errors.New("invalid token type")
+	ErrTokenNameRequired = errors.New("token name is required")
+)
+
+// APIToken repräsentiert ein Token für den skriptgesteuerten Zugriff
+type APIToken struct {
+	ID         primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	Name       string              `bson:"name" json:"name"`
+	Type       APITokenType        `bson:"type" json:"type"`
+	Prefix     string              `bson:"prefix" json:"prefix"` // Erkennbarer Anfang des Tokens für die Anzeige
+	TokenHash  string              `bson:"tokenHash" json:"-"`
+	UserID     *primitive.ObjectID `bson:"userId,omitempty" json:"userId,omitempty"` // Nur bei persönlichen Tokens
+	UserName   string              `bson:"userName,omitempty" json:"userName,omitempty"`
+	Role       UserRole            `bson:"role,omitempty" json:"role,omitempty"` // Nur bei Service-Account-Tokens
+	Scopes     []APITokenScope     `bson:"scopes" json:"scopes"`
+	ExpiresAt  *time.Time          `bson:"expiresAt,omitempty" json:"expiresAt,omitempty"`
+	LastUsedAt *time.Time          `bson:"lastUsedAt,omitempty" json:"lastUsedAt,omitempty"`
+	LastUsedIP string              `bson:"lastUsedIp,omitempty" json:"lastUsedIp,omitempty"`
+	RevokedAt  *time.Time          `bson:"revokedAt,omitempty" json:"revokedAt,omitempty"`
+	RevokedBy  string              `bson:"revokedBy,omitempty" json:"revokedBy,omitempty"`
+	CreatedBy  primitive.ObjectID  `bson:"createdBy" json:"createdBy"`
+	CreatedAt  time.Time           `bson:"createdAt" json:"createdAt"`
+}
+
+// HashAPIToken berechnet den SHA-256-Hash eines Klartext-Tokens
+func HashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsAPIToken prüft, ob ein Bearer-Wert ein API-Token (und kein JWT) ist
+func IsAPIToken(value string) bool {
+	return strings.HasPrefix(value, APITokenPrefix)
+}
+
+// ValidScopes gibt alle gültigen Scopes zurück
+func ValidScopes() []APITokenScope {
+	return []APITokenScope{APITokenScopeRead, APITokenScopeWrite, APITokenScopeAdmin}
+}
+
+// ParseScopes prüft und dedupliziert eine Liste von Scopes
+func ParseScopes(values []string) ([]APITokenScope, error) {
+	seen := make(map[APITokenScope]bool)
+	var scopes []APITokenScope
+	for _, value := range values {
+		scope := APITokenScope(strings.ToLower(strings.TrimSpace(value)))
+		if scope == "" || seen[scope] {
+			continue
+		}
+		switch scope {
+		case APITokenScopeRead, APITokenScopeWrite, APITokenScopeAdmin:
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrInvalidTokenScope, value)
+		}
+		seen[scope] = true
+		scopes = append(scopes, scope)
+	}
+	if len(scopes) == 0 {
+		return nil, fmt.Errorf("%w: at least one scope is required", ErrInvalidTokenScope)
+	}
+	return scopes, nil
+}
+
+// Validate prüft die Pflichtfelder eines Tokens
+func (t *APIToken) Validate() error {
+	if strings.TrimSpace(t.Name) == "" {
+		return ErrTokenNameRequired
+	}
+	switch t.Type {
+	case APITokenTypePersonal:
+		if t.UserID == nil || t.UserID.IsZero() {
+			return fmt.Errorf("%w: personal token requires a user", ErrInvalidTokenType)
+		}
+	case APITokenTypeService:
+		user := User{Role: t.Role}
+		if t.Role == "" {
+			return fmt.Errorf("%w: service token requires a role", ErrInvalidTokenType)
+		}
+		if err := user.ValidateRole(); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidTokenType, t.Type)
+	}
+	if _, err := ParseScopes(scopeStrings(t.Scopes)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// HasScope prüft, ob das Token einen Scope besitzt; write schließt read ein
+func (t *APIToken) HasScope(scope APITokenScope) bool {
+	for _, s := range t.Scopes {
+		if s == scope || (scope == APITokenScopeRead && s == APITokenScopeWrite) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsMethod prüft, ob das Token für die HTTP-Methode berechtigt ist
+func (t *APIToken) AllowsMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return t.HasScope(APITokenScopeRead)
+	default:
+		return t.HasScope(APITokenScopeWrite)
+	}
+}
+
+// IsExpired prüft, ob das Token abgelaufen ist
+func (t *APIToken) IsExpired() bool {
+	return t.ExpiresAt != nil && time.Now().After(*t.ExpiresAt)
+}
+
+// IsRevoked prüft, ob das Token widerrufen wurde
+func (t *APIToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+// IsUsable prüft, ob das Token zur Authentifizierung verwendet werden kann
+func (t *APIToken) IsUsable() bool {
+	return !t.IsExpired() && !t.IsRevoked()
+}
+
+// GetStatus gibt den Status des Tokens zurück
+func (t *APIToken) GetStatus() string {
+	switch {
+	case t.IsRevoked():
+		return "revoked"
+	case t.IsExpired():
+		return "expired"
+	default:
+		return "active"
+	}
+}
+
+// ServiceAccountUser erstellt den Benutzerkontext für ein Service-Account-Token
+func (t *APIToken) ServiceAccountUser() *User {
+	return &User{
+		ID:        t.ID,
+		FirstName: "Service-Account",
+		LastName:  t.Name,
+		Role:      t.Role,
+		Status:    StatusActive,
+		CreatedAt: t.CreatedAt,
+	}
+}
+
+// scopeStrings wandelt Scopes in Strings um
+func scopeStrings(scopes []APITokenScope) []string {
+	values := make([]string, len(scopes))
+	for i, scope := range scopes {
+		values[i] = string(scope)
+	}
+	return values
+}
@@ -0,0 +1,157 @@
+package model
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestHashAPIToken(t *testing.T) {
+	hash := HashAPIToken("pf_secret")
+
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, HashAPIToken("pf_secret"))
+	assert.NotEqual(t, hash, HashAPIToken("pf_other"))
+}
+
+func TestIsAPIToken(t *testing.T) {
+	assert.True(t, IsAPIToken("pf_abcdef"))
+	assert.False(t, IsAPIToken("eyJhbGciOiJIUzI1NiJ9.payload.signature"))
+	assert.False(t, IsAPIToken(""))
+}
+
+func TestParseScopes(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []string
+		expected []APITokenScope
+		wantErr  bool
+	}{
+		{"Single scope", []string{"read"}, []APITokenScope{APITokenScopeRead}, false},
+		{"Deduplicates and normalizes", []string{"READ", " write ", "read"}, []APITokenScope{APITokenScopeRead, APITokenScopeWrite}, false},
+		{"Unknown scope", []string{"read", "delete"}, nil, true},
+		{"Empty list", []string{}, nil, true},
+		{"Only blanks", []string{" ", ""}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scopes, err := ParseScopes(tt.values)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidTokenScope)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, scopes)
+			}
+		})
+	}
+}
+
+func TestAPIToken_Validate(t *testing.T) {
+	userID := primitive.NewObjectID()
+
+	tests := []struct {
+		name    string
+		token   *APIToken
+		wantErr error
+	}{
+		{
+			name:  "Valid personal token",
+			token: &APIToken{Name: "Script", Type: APITokenTypePersonal, UserID: &userID, Scopes: []APITokenScope{APITokenScopeRead}},
+		},
+		{
+			name:  "Valid service token",
+			token: &APIToken{Name: "Export", Type: APITokenTypeService, Role: RoleHR, Scopes: []APITokenScope{APITokenScopeRead}},
+		},
+		{
+			name:    "Missing name",
+			token:   &APIToken{Type: APITokenTypePersonal, UserID: &userID, Scopes: []APITokenScope{APITokenScopeRead}},
+			wantErr: ErrTokenNameRequired,
+		},
+		{
+			name:    "Personal token without user",
+			token:   &APIToken{Name: "Script", Type: APITokenTypePersonal, Scopes: []APITokenScope{APITokenScopeRead}},
+			wantErr: ErrInvalidTokenType,
+		},
+		{
+			name:    "Service token with invalid role",
+			token:   &APIToken{Name: "Export", Type: APITokenTypeService, Role: "root", Scopes: []APITokenScope{APITokenScopeRead}},
+			wantErr: ErrInvalidRole,
+		},
+		{
+			name:    "Unknown type",
+			token:   &APIToken{Name: "Export", Type: "robot", Scopes: []APITokenScope{APITokenScopeRead}},
+			wantErr: ErrInvalidTokenType,
+		},
+		{
+			name:    "No scopes",
+			token:   &APIToken{Name: "Script", Type: APITokenTypePersonal, UserID: &userID},
+			wantErr: ErrInvalidTokenScope,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.token.Validate()
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAPIToken_AllowsMethod(t *testing.T) {
+	readOnly := &APIToken{Scopes: []APITokenScope{APITokenScopeRead}}
+	writer := &APIToken{Scopes: []APITokenScope{APITokenScopeWrite}}
+	adminOnly := &APIToken{Scopes: []APITokenScope{APITokenScopeAdmin}}
+
+	assert.True(t, readOnly.AllowsMethod(http.MethodGet))
+	assert.False(t, readOnly.AllowsMethod(http.MethodPost))
+	assert.False(t, readOnly.AllowsMethod(http.MethodDelete))
+
+	assert.True(t, writer.AllowsMethod(http.MethodGet), "write implies read")
+	assert.True(t, writer.AllowsMethod(http.MethodPut))
+
+	assert.False(t, adminOnly.AllowsMethod(http.MethodGet))
+	assert.True(t, adminOnly.HasScope(APITokenScopeAdmin))
+	assert.False(t, writer.HasScope(APITokenScopeAdmin))
+}
+
+func TestAPIToken_Status(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name   string
+		token  *APIToken
+		usable bool
+		status string
+	}{
+		{"Active token", &APIToken{ExpiresAt: &future}, true, "active"},
+		{"Token without expiry", &APIToken{}, true, "active"},
+		{"Expired token", &APIToken{ExpiresAt: &past}, false, "expired"},
+		{"Revoked token", &APIToken{ExpiresAt: &future, RevokedAt: &past}, false, "revoked"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.usable, tt.token.IsUsable())
+			assert.Equal(t, tt.status, tt.token.GetStatus())
+		})
+	}
+}
+
+func TestAPIToken_ServiceAccountUser(t *testing.T) {
+	token := &APIToken{ID: primitive.NewObjectID(), Name: "Export", Type: APITokenTypeService, Role: RoleHR}
+	user := token.ServiceAccountUser()
+
+	assert.Equal(t, token.ID, user.ID)
+	assert.Equal(t, RoleHR, user.Role)
+	assert.Equal(t, StatusActive, user.Status)
+	assert.Equal(t, "Export", user.LastName)
+}
@@ -0,0 +1,234 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ChatProvider ist der Chat-Dienst eines Kanals
+type ChatProvider string
+
+// ChatEvent ist ein Ereignis, das an Chat-Kanäle gemeldet werden kann
+type ChatEvent string
+
+const (
+	ChatProviderSlack ChatProvider = "slack"
+	ChatProviderTeams ChatProvider = "teams"
+
+	ChatEventAbsencePending   ChatEvent = "absence_pending"   // Abwesenheitsantrag wartet auf Genehmigung
+	ChatEventAbsenceApproved  ChatEvent = "absence_approved"  // Abwesenheit genehmigt (nach Abteilung filterbar)
+	ChatEventAbsenceDigest    ChatEvent = "absence_digest"    // Morgendliche Übersicht "Wer ist heute abwesend"
+	ChatEventConversationsDue ChatEvent = "conversations_due" // Mitarbeitergespräche dieser Woche
+	ChatEventSyncFailed       ChatEvent = "sync_failed"       // Fehlgeschlagene Integrations-Synchronisation
+)
+
+// Chat-Kanal-Fehler
+var (
+	ErrChatChannelNameRequired = errors.New("chat channel name is required")
+	ErrInvalidChatProvider     = errors.New("invalid chat provider")
+	ErrInvalidChatWebhookURL   = errors.New("invalid chat webhook URL")
+	ErrInvalidChatEvent        = errors.New("invalid chat event")
+)
+
+// ChatChannel ist ein Slack- oder Teams-Kanal, der über einen Incoming Webhook benachrichtigt wird
+type ChatChannel struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Name        string             `bson:"name" json:"name"`
+	Provider    ChatProvider       `bson:"provider" json:"provider"`
+	WebhookURL  string             `bson:"webhookUrl" json:"-"` // Verschlüsselt gespeichert, die URL enthält das Zugangsgeheimnis
+	Events      []ChatEvent        `bson:"events" json:"events"`
+	Departments []Department       `bson:"departments" json:"departments"` // Leer = alle Abteilungen
+	Active      bool               `bson:"active" json:"active"`
+	LastError   string             `bson:"lastError,omitempty" json:"lastError,omitempty"`
+	LastSentAt  *time.Time         `bson:"lastSentAt,omitempty" json:"lastSentAt,omitempty"`
+	CreatedBy   primitive.ObjectID `bson:"createdBy" json:"createdBy"`
+	CreatedAt   time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt   time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
+
+// ChatMessage ist eine anbieterunabhängige Nachricht
+type ChatMessage struct {
+	Title string
+	Text  string
+	Lines []string // Aufzählungspunkte unter dem Text
+	Link  string   // Optionaler Link zur Detailansicht
+	Color string   // Hex-Farbe ohne #, z.B. "2563eb"
+}
+
+// ChatEvents gibt alle Ereignisse zurück, die ein Kanal abonnieren kann
+func ChatEvents() []ChatEvent {
+	return []ChatEvent{
+		ChatEventAbsencePending, ChatEventAbsenceApproved, ChatEventAbsenceDigest,
+		ChatEventConversationsDue, ChatEventSyncFailed,
+	}
+}
+
+// IsValid prüft, ob das Ereignis bekannt ist
+func (e ChatEvent) IsValid() bool {
+	for _, event := range ChatEvents() {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// GetLabel gibt eine deutsche Bezeichnung für das Ereignis zurück
+func (e ChatEvent) GetLabel() string {
+	switch e {
+	case ChatEventAbsencePending:
+		return "Offene Abwesenheitsanträge"
+	case ChatEventAbsenceApproved:
+		return "Genehmigte Abwesenheiten"
+	case ChatEventAbsenceDigest:
+		return "Wer ist heute abwesend (morgens)"
+	case ChatEventConversationsDue:
+		return "Mitarbeitergespräche dieser Woche"
+	case ChatEventSyncFailed:
+		return "Fehlgeschlagene Synchronisationen"
+	default:
+		return string(e)
+	}
+}
+
+// ParseChatEvents prüft und dedupliziert eine Liste von Ereignissen
+func ParseChatEvents(values []string) ([]ChatEvent, error) {
+	seen := make(map[ChatEvent]bool)
+	events := []ChatEvent{}
+	for _, value := range values {
+		event := ChatEvent(strings.TrimSpace(value))
+		if event == "" || seen[event] {
+			continue
+		}
+		if !event.IsValid() {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidChatEvent, value)
+		}
+		seen[event] = true
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// Validate prüft Name, Anbieter, Webhook-URL (Klartext) und Ereignisse
+func (c *ChatChannel) Validate(plainURL string) error {
+	if strings.TrimSpace(c.Name) == "" {
+		return ErrChatChannelNameRequired
+	}
+	if c.Provider != ChatProviderSlack && c.Provider != ChatProviderTeams {
+		return fmt.Errorf("%w: %s", ErrInvalidChatProvider, c.Provider)
+	}
+	parsed, err := url.Parse(plainURL)
+	if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+		return ErrInvalidChatWebhookURL
+	}
+	for _, event := range c.Events {
+		if !event.IsValid() {
+			return fmt.Errorf("%w: %s", ErrInvalidChatEvent, event)
+		}
+	}
+	return nil
+}
+
+// Wants prüft, ob der Kanal ein Ereignis für eine Abteilung erhalten soll.
+// Eine leere Abteilung (z.B. bei Synchronisationsfehlern) passt immer.
+func (c *ChatChannel) Wants(event ChatEvent, department Department) bool {
+	if !c.Active {
+		return false
+	}
+	subscribed := false
+	for _, e := range c.Events {
+		if e == event {
+			subscribed = true
+			break
+		}
+	}
+	if !subscribed {
+		return false
+	}
+	if department == "" || len(c.Departments) == 0 {
+		return true
+	}
+	for _, d := range c.Departments {
+		if d == department {
+			return true
+		}
+	}
+	return false
+}
+
+// Payload erzeugt den JSON-Body für den Incoming Webhook des Anbieters
+func (p ChatProvider) Payload(msg ChatMessage) ([]byte, error) {
+	switch p {
+	case ChatProviderSlack:
+		return json.Marshal(slackPayload(msg))
+	case ChatProviderTeams:
+		return json.Marshal(teamsPayload(msg))
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidChatProvider, p)
+	}
+}
+
+// slackPayload baut eine Slack-Nachricht mit Blocks und Text-Fallback
+func slackPayload(msg ChatMessage) map[string]interface{} {
+	body := msg.Text
+	if len(msg.Lines) > 0 {
+		if body != "" {
+			body += "\n"
+		}
+		body += "• " + strings.Join(msg.Lines, "\n• ")
+	}
+	if msg.Link != "" {
+		body += "\n<" + msg.Link + "|In PeopleFlow öffnen>"
+	}
+
+	blocks := []map[string]interface{}{
+		{"type": "header", "text": map[string]interface{}{"type": "plain_text", "text": msg.Title}},
+	}
+	if body != "" {
+		blocks = append(blocks, map[string]interface{}{
+			"type": "section",
+			"text": map[string]interface{}{"type": "mrkdwn", "text": body},
+		})
+	}
+
+	return map[string]interface{}{
+		"text":   msg.Title,
+		"blocks": blocks,
+	}
+}
+
+// teamsPayload baut eine Teams-MessageCard
+func teamsPayload(msg ChatMessage) map[string]interface{} {
+	text := msg.Text
+	if len(msg.Lines) > 0 {
+		if text != "" {
+			text += "\n\n"
+		}
+		text += "- " + strings.Join(msg.Lines, "\n- ")
+	}
+
+	card := map[string]interface{}{
+		"@type":    "MessageCard",
+		"@context": "https://schema.org/extensions",
+		"summary":  msg.Title,
+		"title":    msg.Title,
+		"text":     text,
+	}
+	if msg.Color != "" {
+		card["themeColor"] = msg.Color
+	}
+	if msg.Link != "" {
+		card["potentialAction"] = []map[string]interface{}{{
+			"@type":   "OpenUri",
+			"name":    "In PeopleFlow öffnen",
+			"targets": []map[string]string{{"os": "default", "uri": msg.Link}},
+		}}
+	}
+	return card
+}
@@ -0,0 +1,119 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChatChannel_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		channel ChatChannel
+		url     string
+		wantErr error
+	}{
+		{"Valid Slack channel", ChatChannel{Name: "#hr", Provider: ChatProviderSlack, Events: []ChatEvent{ChatEventAbsencePending}}, "https://hooks.slack.com/services/T/B/X", nil},
+		{"Valid Teams channel", ChatChannel{Name: "HR", Provider: ChatProviderTeams}, "https://example.webhook.office.com/webhookb2/abc", nil},
+		{"Missing name", ChatChannel{Provider: ChatProviderSlack}, "https://hooks.slack.com/x", ErrChatChannelNameRequired},
+		{"Unknown provider", ChatChannel{Name: "x", Provider: "discord"}, "https://discord.com/api/webhooks/x", ErrInvalidChatProvider},
+		{"Plain http is rejected", ChatChannel{Name: "x", Provider: ChatProviderSlack}, "http://hooks.slack.com/x", ErrInvalidChatWebhookURL},
+		{"Unknown event", ChatChannel{Name: "x", Provider: ChatProviderSlack, Events: []ChatEvent{"lunch"}}, "https://hooks.slack.com/x", ErrInvalidChatEvent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.channel.Validate(tt.url)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestChatChannel_Wants(t *testing.T) {
+	channel := ChatChannel{
+		Active:      true,
+		Events:      []ChatEvent{ChatEventAbsenceApproved, ChatEventSyncFailed},
+		Departments: []Department{DepartmentIT},
+	}
+
+	tests := []struct {
+		name       string
+		event      ChatEvent
+		department Department
+		expected   bool
+	}{
+		{"Subscribed event in department", ChatEventAbsenceApproved, DepartmentIT, true},
+		{"Subscribed event in other department", ChatEventAbsenceApproved, DepartmentSales, false},
+		{"Event without department", ChatEventSyncFailed, "", true},
+		{"Not subscribed", ChatEventAbsencePending, DepartmentIT, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, channel.Wants(tt.event, tt.department))
+		})
+	}
+
+	allDepartments := ChatChannel{Active: true, Events: []ChatEvent{ChatEventAbsenceApproved}}
+	assert.True(t, allDepartments.Wants(ChatEventAbsenceApproved, DepartmentSales))
+
+	channel.Active = false
+	assert.False(t, channel.Wants(ChatEventAbsenceApproved, DepartmentIT))
+}
+
+func TestParseChatEvents(t *testing.T) {
+	events, err := ParseChatEvents([]string{"absence_digest", "absence_digest", " sync_failed"})
+	assert.NoError(t, err)
+	assert.Equal(t, []ChatEvent{ChatEventAbsenceDigest, ChatEventSyncFailed}, events)
+
+	_, err = ParseChatEvents([]string{"employee_added"})
+	assert.ErrorIs(t, err, ErrInvalidChatEvent)
+}
+
+func TestChatProvider_Payload(t *testing.T) {
+	msg := ChatMessage{
+		Title: "Heute abwesend",
+		Text:  "2 Mitarbeiter",
+		Lines: []string{"Anna Schmidt (Urlaub)", "Max Müller (Krankheit)"},
+		Link:  "https://peopleflow.example.com/absence",
+		Color: "2563eb",
+	}
+
+	t.Run("Slack", func(t *testing.T) {
+		data, err := ChatProviderSlack.Payload(msg)
+		require.NoError(t, err)
+
+		var payload map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &payload))
+		assert.Equal(t, "Heute abwesend", payload["text"])
+		blocks := payload["blocks"].([]interface{})
+		require.Len(t, blocks, 2)
+		section := blocks[1].(map[string]interface{})["text"].(map[string]interface{})
+		assert.Contains(t, section["text"], "• Anna Schmidt (Urlaub)\n• Max Müller (Krankheit)")
+		assert.Contains(t, section["text"], "<https://peopleflow.example.com/absence|")
+	})
+
+	t.Run("Teams", func(t *testing.T) {
+		data, err := ChatProviderTeams.Payload(msg)
+		require.NoError(t, err)
+
+		var payload map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &payload))
+		assert.Equal(t, "MessageCard", payload["@type"])
+		assert.Equal(t, "Heute abwesend", payload["title"])
+		assert.Equal(t, "2563eb", payload["themeColor"])
+		assert.Contains(t, payload["text"], "- Anna Schmidt (Urlaub)")
+		assert.NotNil(t, payload["potentialAction"])
+	})
+
+	t.Run("Unknown provider", func(t *testing.T) {
+		_, err := ChatProvider("discord").Payload(msg)
+		assert.ErrorIs(t, err, ErrInvalidChatProvider)
+	})
+}
@@ -0,0 +1,166 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cron-Fehler
+var (
+	ErrInvalidCronExpression = errors.New("invalid cron expression")
+)
+
+// cronMaxLookahead begrenzt die Suche nach dem nächsten Termin (z.B. für "0 0 30 2 *")
+const cronMaxLookahead = 5 * 366 * 24 * time.Hour
+
+// CronSchedule ist ein Zeitplan im Cron-Format mit fünf Feldern:
+// Minute Stunde Tag Monat Wochentag, z.B. "*/5 * * * *" oder "0 7 * * 1-5".
+// Unterstützt werden *, Listen (1,15), Bereiche (1-5) und Schrittweiten (*/10, 8-18/2).
+// Der Wochentag 0 und 7 steht für Sonntag.
+type CronSchedule struct {
+	expression string
+	minutes    map[int]bool
+	hours      map[int]bool
+	days       map[int]bool
+	months     map[int]bool
+	weekdays   map[int]bool
+	anyDay     bool
+	anyWeekday bool
+}
+
+// ParseCron liest einen Cron-Ausdruck mit fünf Feldern
+func ParseCron(expression string) (*CronSchedule, error) {
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("%w: %q benötigt 5 Felder (Minute Stunde Tag Monat Wochentag)", ErrInvalidCronExpression, expression)
+	}
+
+	schedule := &CronSchedule{
+		expression: strings.Join(fields, " "),
+		anyDay:     fields[2] == "*",
+		anyWeekday: fields[4] == "*",
+	}
+
+	var err error
+	if schedule.minutes, err = parseCronField(fields[0], 0, 59); err != nil {
+		return nil, err
+	}
+	if schedule.hours, err = parseCronField(fields[1], 0, 23); err != nil {
+		return nil, err
+	}
+	if schedule.days, err = parseCronField(fields[2], 1, 31); err != nil {
+		return nil, err
+	}
+	if schedule.months, err = parseCronField(fields[3], 1, 12); err != nil {
+		return nil, err
+	}
+	if schedule.weekdays, err = parseCronField(fields[4], 0, 7); err != nil {
+		return nil, err
+	}
+	if schedule.weekdays[7] {
+		schedule.weekdays[0] = true
+	}
+	return schedule, nil
+}
+
+// String gibt den normalisierten Ausdruck zurück
+func (s *CronSchedule) String() string {
+	return s.expression
+}
+
+// Next gibt den ersten Termin nach after zurück (minutengenau).
+// Gibt es innerhalb von fünf Jahren keinen Termin, wird die Nullzeit zurückgegeben.
+func (s *CronSchedule) Next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := after.Add(cronMaxLookahead)
+
+	for t.Before(limit) {
+		if !s.months[int(t.Month())] {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.hours[t.Hour()] {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.minutes[t.Minute()] {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// matchesDay prüft Tag und Wochentag. Wie bei cron gilt: Sind beide eingeschränkt,
+// genügt es, wenn eines der beiden Felder passt.
+func (s *CronSchedule) matchesDay(t time.Time) bool {
+	dayMatches := s.days[t.Day()]
+	weekdayMatches := s.weekdays[int(t.Weekday())]
+
+	switch {
+	case s.anyDay && s.anyWeekday:
+		return true
+	case s.anyDay:
+		return weekdayMatches
+	case s.anyWeekday:
+		return dayMatches
+	default:
+		return dayMatches || weekdayMatches
+	}
+}
+
+// parseCronField liest ein Feld mit Listen, Bereichen und Schrittweiten
+func parseCronField(field string, min, max int) (map[int]bool, error) {
+	values := make(map[int]bool)
+
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			rangePart = part[:i]
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step < 1 {
+				return nil, fmt.Errorf("%w: ungültige Schrittweite in %q", ErrInvalidCronExpression, part)
+			}
+		}
+
+		start, end := min, max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err1, err2 error
+			start, err1 = strconv.Atoi(bounds[0])
+			end, err2 = strconv.Atoi(bounds[1])
+			if err1 != nil || err2 != nil {
+				return nil, fmt.Errorf("%w: ungültiger Bereich %q", ErrInvalidCronExpression, part)
+			}
+		default:
+			value, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return nil, fmt.Errorf("%w: ungültiger Wert %q", ErrInvalidCronExpression, part)
+			}
+			start = value
+			end = value
+			if strings.Contains(part, "/") {
+				end = max
+			}
+		}
+
+		if start < min || end > max || start > end {
+			return nil, fmt.Errorf("%w: %q liegt außerhalb von %d-%d", ErrInvalidCronExpression, part, min, max)
+		}
+		for v := start; v <= end; v += step {
+			values[v] = true
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,113 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCron_Invalid(t *testing.T) {
+	tests := []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+	}
+
+	for _, expression := range tests {
+		t.Run(expression, func(t *testing.T) {
+			_, err := ParseCron(expression)
+			assert.ErrorIs(t, err, ErrInvalidCronExpression)
+		})
+	}
+}
+
+func TestCronSchedule_Next(t *testing.T) {
+	// Mittwoch, 15.05.2024 10:17:30
+	now := time.Date(2024, 5, 15, 10, 17, 30, 0, time.UTC)
+	at := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2024, month, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		expression string
+		want       time.Time
+	}{
+		{"* * * * *", at(5, 15, 10, 18)},
+		{"*/5 * * * *", at(5, 15, 10, 20)},
+		{"0 * * * *", at(5, 15, 11, 0)},
+		{"0 7 * * *", at(5, 16, 7, 0)},
+		{"0 8 * * 1", at(5, 20, 8, 0)},
+		{"30 3 * * 0", at(5, 19, 3, 30)},
+		{"30 3 * * 7", at(5, 19, 3, 30)},
+		{"0 9-17/4 * * 1-5", at(5, 15, 13, 0)},
+		{"15,45 10 * * *", at(5, 15, 10, 45)},
+		{"0 6 1 * *", at(6, 1, 6, 0)},
+		{"0 0 1 1 *", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		// Tag und Wochentag eingeschränkt: einer von beiden genügt
+		{"0 12 20 * 5", at(5, 17, 12, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			schedule, err := ParseCron(tt.expression)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, schedule.Next(now))
+		})
+	}
+}
+
+func TestCronSchedule_NextNeverMatches(t *testing.T) {
+	schedule, err := ParseCron("0 0 30 2 *")
+	require.NoError(t, err)
+	assert.True(t, schedule.Next(time.Now()).IsZero())
+}
+
+func TestJob_IsDue(t *testing.T) {
+	now := time.Date(2024, 5, 15, 10, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Minute)
+
+	tests := []struct {
+		name string
+		job  Job
+		want bool
+	}{
+		{"Due", Job{NextRunAt: &past}, true},
+		{"Due exactly now", Job{NextRunAt: &now}, true},
+		{"Not yet due", Job{NextRunAt: &future}, false},
+		{"Paused", Job{NextRunAt: &past, Paused: true}, false},
+		{"Locked by another instance", Job{NextRunAt: &past, LockedUntil: &future}, false},
+		{"Expired lock", Job{NextRunAt: &past, LockedUntil: &past}, true},
+		{"No schedule", Job{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.job.IsDue(now))
+		})
+	}
+}
+
+func TestJobRun_Finish(t *testing.T) {
+	start := time.Date(2024, 5, 15, 10, 0, 0, 0, time.UTC)
+
+	run := &JobRun{Status: JobRunRunning, StartedAt: start}
+	run.Finish(start.Add(1500*time.Millisecond), "12 Mitarbeiter synchronisiert", nil)
+	assert.Equal(t, JobRunSuccess, run.Status)
+	assert.Equal(t, int64(1500), run.DurationMs)
+	assert.Equal(t, "12 Mitarbeiter synchronisiert", run.Result)
+
+	failed := &JobRun{Status: JobRunRunning, StartedAt: start}
+	failed.Finish(start.Add(time.Second), "", assert.AnError)
+	assert.Equal(t, JobRunFailed, failed.Status)
+	assert.Equal(t, assert.AnError.Error(), failed.Error)
+}
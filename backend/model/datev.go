@@ -0,0 +1,368 @@
+// backend/model/datev.go
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DATEV-Export-Fehler
+var (
+	ErrInvalidDatevSettings = errors.New("invalid DATEV settings")
+	ErrInvalidDatevMonth    = errors.New("invalid payroll month")
+)
+
+// DatevTarget ist das DATEV-Programm, in das die Bewegungsdaten importiert werden.
+// Unterstützt wird nur LODAS; Lohn und Gehalt erwartet einen anderen Satzaufbau.
+type DatevTarget string
+
+const (
+	DatevTargetLODAS DatevTarget = "LODAS"
+
+	// datevTargetLUG ist Lohn und Gehalt; früher gespeicherte Einstellungen können es noch enthalten
+	datevTargetLUG DatevTarget = "LUG"
+)
+
+// IsValid prüft, ob das Zielprogramm unterstützt wird
+func (t DatevTarget) IsValid() bool {
+	return t == DatevTargetLODAS
+}
+
+// DatevAbsenceTypes sind die Abwesenheitsarten, denen eine Lohnart zugeordnet werden kann
+var DatevAbsenceTypes = []string{"vacation", "sick", "special"}
+
+var (
+	datevConsultantPattern = regexp.MustCompile(`^\d{4,7}$`)
+	datevClientPattern     = regexp.MustCompile(`^\d{1,5}$`)
+	datevWageTypePattern   = regexp.MustCompile(`^\d{1,4}$`)
+)
+
+// DatevWageTypeMapping ordnet Abwesenheitsarten bzw. Lohnarten der Zeiterfassung
+// (TimeEntry.WageType) eine DATEV-Lohnart zu
+type DatevWageTypeMapping map[string]string
+
+// ParseDatevWageTypeMapping liest eine Zuordnung im Format "Schlüssel=Lohnart, ...",
+// z.B. "vacation=1000, sick=1010" oder "Nachtzuschlag=1200"
+func ParseDatevWageTypeMapping(value string) (DatevWageTypeMapping, error) {
+	mapping := DatevWageTypeMapping{}
+	entries := strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ';' || r == '\n' })
+	for _, entry := range entries {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		key, wageType, ok := strings.Cut(entry, "=")
+		key, wageType = strings.TrimSpace(key), strings.TrimSpace(wageType)
+		if !ok || key == "" || wageType == "" {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidDatevSettings, strings.TrimSpace(entry))
+		}
+		mapping[key] = wageType
+	}
+	return mapping, nil
+}
+
+// String gibt die Zuordnung im Format von ParseDatevWageTypeMapping zurück
+func (m DatevWageTypeMapping) String() string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	entries := make([]string, 0, len(keys))
+	for _, key := range keys {
+		entries = append(entries, key+"="+m[key])
+	}
+	return strings.Join(entries, ", ")
+}
+
+// DatevSettings enthält die Einstellungen des monatlichen DATEV-Lohnexports
+type DatevSettings struct {
+	ConsultantNumber       string               `bson:"consultantNumber" json:"consultantNumber"` // Beraternummer
+	ClientNumber           string               `bson:"clientNumber" json:"clientNumber"`         // Mandantennummer
+	Target                 DatevTarget          `bson:"target" json:"target"`
+	AbsenceWageTypes       DatevWageTypeMapping `bson:"absenceWageTypes,omitempty" json:"absenceWageTypes"`
+	WageTypes              DatevWageTypeMapping `bson:"wageTypes,omitempty" json:"wageTypes"`
+	OvertimePayoutWageType string               `bson:"overtimePayoutWageType,omitempty" json:"overtimePayoutWageType"`
+}
+
+// DefaultDatevSettings gibt die Standardeinstellungen zurück (LODAS, keine Lohnarten zugeordnet)
+func DefaultDatevSettings() *DatevSettings {
+	return &DatevSettings{
+		Target:           DatevTargetLODAS,
+		AbsenceWageTypes: DatevWageTypeMapping{},
+		WageTypes:        DatevWageTypeMapping{},
+	}
+}
+
+// Validate prüft Berater- und Mandantennummer sowie alle zugeordneten Lohnarten
+func (s *DatevSettings) Validate() error {
+	if s.ConsultantNumber != "" && !datevConsultantPattern.MatchString(s.ConsultantNumber) {
+		return fmt.Errorf("%w: Beraternummer muss 4 bis 7 Ziffern haben", ErrInvalidDatevSettings)
+	}
+	if s.ClientNumber != "" && !datevClientPattern.MatchString(s.ClientNumber) {
+		return fmt.Errorf("%w: Mandantennummer muss 1 bis 5 Ziffern haben", ErrInvalidDatevSettings)
+	}
+	if s.Target == datevTargetLUG {
+		return fmt.Errorf("%w: Lohn und Gehalt wird nicht unterstützt, bitte LODAS als Zielprogramm wählen", ErrInvalidDatevSettings)
+	}
+	if !s.Target.IsValid() {
+		return fmt.Errorf("%w: unbekanntes Zielprogramm %q", ErrInvalidDatevSettings, s.Target)
+	}
+
+	for absenceType, wageType := range s.AbsenceWageTypes {
+		if !isDatevAbsenceType(absenceType) {
+			return fmt.Errorf("%w: unbekannte Abwesenheitsart %q", ErrInvalidDatevSettings, absenceType)
+		}
+		if !datevWageTypePattern.MatchString(wageType) {
+			return fmt.Errorf("%w: ungültige Lohnart %q für %s", ErrInvalidDatevSettings, wageType, absenceType)
+		}
+	}
+	for name, wageType := range s.WageTypes {
+		if !datevWageTypePattern.MatchString(wageType) {
+			return fmt.Errorf("%w: ungültige Lohnart %q für %s", ErrInvalidDatevSettings, wageType, name)
+		}
+	}
+	if s.OvertimePayoutWageType != "" && !datevWageTypePattern.MatchString(s.OvertimePayoutWageType) {
+		return fmt.Errorf("%w: ungültige Lohnart %q für Überstunden-Auszahlungen", ErrInvalidDatevSettings, s.OvertimePayoutWageType)
+	}
+	return nil
+}
+
+// IsConfigured prüft, ob Berater- und Mandantennummer für den Export hinterlegt sind
+func (s *DatevSettings) IsConfigured() bool {
+	return s.ConsultantNumber != "" && s.ClientNumber != ""
+}
+
+func isDatevAbsenceType(absenceType string) bool {
+	for _, known := range DatevAbsenceTypes {
+		if known == absenceType {
+			return true
+		}
+	}
+	return false
+}
+
+// DatevUnit ist die Einheit eines Bewegungsdatensatzes
+type DatevUnit string
+
+const (
+	DatevUnitHours DatevUnit = "hours"
+	DatevUnitDays  DatevUnit = "days"
+)
+
+// ProcessingKey gibt den DATEV-Bearbeitungsschlüssel der Einheit zurück (1 = Stunden, 2 = Tage)
+func (u DatevUnit) ProcessingKey() int {
+	if u == DatevUnitDays {
+		return 2
+	}
+	return 1
+}
+
+// DatevMovement ist ein Bewegungsdatensatz: Wert einer Lohnart für einen Mitarbeiter im Abrechnungsmonat
+type DatevMovement struct {
+	EmployeeID      primitive.ObjectID `json:"employeeId"`
+	PersonnelNumber string             `json:"personnelNumber"`
+	EmployeeName    string             `json:"employeeName"`
+	WageType        string             `json:"wageType"` // DATEV-Lohnart
+	Unit            DatevUnit          `json:"unit"`
+	Value           float64            `json:"value"`
+	Source          string             `json:"source"` // z.B. "Urlaub" oder "Überstunden-Auszahlung"
+}
+
+// DatevExport enthält die Bewegungsdaten eines Abrechnungsmonats und die Hinweise zu
+// Daten, die nicht exportiert werden konnten
+type DatevExport struct {
+	Month     time.Time       `json:"month"`
+	Movements []DatevMovement `json:"movements"`
+	Warnings  []string        `json:"warnings"`
+}
+
+// BuildDatevExport erstellt die Bewegungsdaten des Monats month aus genehmigten Abwesenheiten,
+// Zeiteinträgen mit Lohnart und genehmigten Überstunden-Auszahlungen. Die Personalnummer ist
+// die EmployeeID des Mitarbeiters. Daten ohne zugeordnete Lohnart oder Personalnummer werden
+// nicht exportiert, sondern als Hinweis gemeldet.
+func BuildDatevExport(settings *DatevSettings, month time.Time, employees []*Employee, adjustments []*OvertimeAdjustment) *DatevExport {
+	start := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, month.Location())
+	end := start.AddDate(0, 1, 0)
+	export := &DatevExport{Month: start, Movements: []DatevMovement{}, Warnings: []string{}}
+	warned := make(map[string]bool)
+	warn := func(warning string) {
+		if !warned[warning] {
+			warned[warning] = true
+			export.Warnings = append(export.Warnings, warning)
+		}
+	}
+
+	payouts := make(map[primitive.ObjectID]float64)
+	for _, adjustment := range adjustments {
+		if adjustment.Type == OvertimeAdjustmentTypePayout && adjustment.IsApproved() {
+			payouts[adjustment.EmployeeID] += math.Abs(adjustment.Hours)
+		}
+	}
+
+	for _, employee := range employees {
+		name := strings.TrimSpace(employee.FirstName + " " + employee.LastName)
+		var movements []DatevMovement
+		add := func(wageType string, unit DatevUnit, value float64, source string) {
+			for i := range movements {
+				if movements[i].WageType == wageType && movements[i].Unit == unit {
+					movements[i].Value += value
+					if !strings.Contains(movements[i].Source, source) {
+						movements[i].Source += ", " + source
+					}
+					return
+				}
+			}
+			movements = append(movements, DatevMovement{
+				EmployeeID:      employee.ID,
+				PersonnelNumber: employee.EmployeeID,
+				EmployeeName:    name,
+				WageType:        wageType,
+				Unit:            unit,
+				Value:           value,
+				Source:          source,
+			})
+		}
+
+		absenceDays := make(map[string]float64)
+		for _, absence := range employee.Absences {
+			if absence.Status == "approved" {
+				absenceDays[absence.Type] += AbsenceDaysInPeriod(absence, start, end)
+			}
+		}
+		for _, absenceType := range DatevAbsenceTypes {
+			if absenceDays[absenceType] == 0 {
+				continue
+			}
+			wageType := settings.AbsenceWageTypes[absenceType]
+			if wageType == "" {
+				warn(fmt.Sprintf("Keine DATEV-Lohnart für Abwesenheitsart %q zugeordnet", absenceType))
+				continue
+			}
+			add(wageType, DatevUnitDays, absenceDays[absenceType], datevAbsenceLabel(absenceType))
+		}
+
+		wageTypeHours := make(map[string]float64)
+		for _, entry := range employee.TimeEntries {
+			if entry.WageType != "" && !entry.Date.Before(start) && entry.Date.Before(end) {
+				wageTypeHours[entry.WageType] += entry.Duration
+			}
+		}
+		for _, entryWageType := range sortedDatevKeys(wageTypeHours) {
+			wageType := settings.WageTypes[entryWageType]
+			if wageType == "" {
+				warn(fmt.Sprintf("Keine DATEV-Lohnart für Lohnart %q der Zeiterfassung zugeordnet", entryWageType))
+				continue
+			}
+			add(wageType, DatevUnitHours, wageTypeHours[entryWageType], entryWageType)
+		}
+
+		if hours := payouts[employee.ID]; hours > 0 {
+			if settings.OvertimePayoutWageType == "" {
+				warn("Keine DATEV-Lohnart für Überstunden-Auszahlungen zugeordnet")
+			} else {
+				add(settings.OvertimePayoutWageType, DatevUnitHours, hours, "Überstunden-Auszahlung")
+			}
+		}
+
+		if len(movements) == 0 {
+			continue
+		}
+		if employee.EmployeeID == "" {
+			warn(fmt.Sprintf("%s hat keine Personalnummer und wurde nicht exportiert", name))
+			continue
+		}
+		for i := range movements {
+			movements[i].Value = math.Round(movements[i].Value*100) / 100
+		}
+		export.Movements = append(export.Movements, movements...)
+	}
+
+	sort.SliceStable(export.Movements, func(i, j int) bool {
+		a, b := export.Movements[i], export.Movements[j]
+		if a.PersonnelNumber != b.PersonnelNumber {
+			return a.PersonnelNumber < b.PersonnelNumber
+		}
+		return a.WageType < b.WageType
+	})
+	return export
+}
+
+// ASCII erzeugt die Importdatei im DATEV-ASCII-Format (Bewegungsdaten, Satzart
+// u_lod_bwd_buchung_standard) mit Windows-Zeilenenden
+func (e *DatevExport) ASCII(settings *DatevSettings) []byte {
+	lines := []string{
+		"[Allgemein]",
+		"Ziel=" + string(settings.Target),
+		"Version_SST=1.0",
+		"BeraterNr=" + settings.ConsultantNumber,
+		"MandantenNr=" + settings.ClientNumber,
+		"Feldtrennzeichen=;",
+		"Zahlenkomma=,",
+		"Datumsformat=TT/MM/JJJJ",
+		"",
+		"[Satzbeschreibung]",
+		"10;u_lod_bwd_buchung_standard;abrechnung_zeitraum#bwd;pnr#bwd;la_eigene#bwd;bs_nr#bwd;bs_wert_butab#bwd;",
+		"",
+		"[Bewegungsdaten]",
+	}
+	period := e.Month.Format("02/01/2006")
+	for _, movement := range e.Movements {
+		lines = append(lines, fmt.Sprintf("10;%s;%s;%s;%d;%s;",
+			period,
+			movement.PersonnelNumber,
+			movement.WageType,
+			movement.Unit.ProcessingKey(),
+			strings.Replace(strconv.FormatFloat(movement.Value, 'f', 2, 64), ".", ",", 1),
+		))
+	}
+	return []byte(strings.Join(lines, "\r\n") + "\r\n")
+}
+
+// FileName gibt den Dateinamen der Importdatei zurück
+func (e *DatevExport) FileName(settings *DatevSettings) string {
+	return fmt.Sprintf("DATEV_%s_%s_%s_%s.txt", settings.Target, settings.ConsultantNumber, settings.ClientNumber, e.Month.Format("2006-01"))
+}
+
+// ParseDatevMonth liest einen Abrechnungsmonat im Format YYYY-MM. Ohne Angabe wird der
+// Vormonat von now verwendet.
+func ParseDatevMonth(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).AddDate(0, -1, 0), nil
+	}
+	month, err := time.ParseInLocation("2006-01", value, now.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidDatevMonth, value)
+	}
+	return month, nil
+}
+
+func datevAbsenceLabel(absenceType string) string {
+	switch absenceType {
+	case "vacation":
+		return "Urlaub"
+	case "sick":
+		return "Krankheit"
+	case "special":
+		return "Sonderurlaub"
+	default:
+		return absenceType
+	}
+}
+
+func sortedDatevKeys(values map[string]float64) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
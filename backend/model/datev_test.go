@@ -0,0 +1,227 @@
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func datevTestSettings() *DatevSettings {
+	return &DatevSettings{
+		ConsultantNumber:       "1234567",
+		ClientNumber:           "12345",
+		Target:                 DatevTargetLODAS,
+		AbsenceWageTypes:       DatevWageTypeMapping{"vacation": "1000", "sick": "1010"},
+		WageTypes:              DatevWageTypeMapping{"Nachtzuschlag": "1200", "Sonntagszuschlag": "1210"},
+		OvertimePayoutWageType: "1300",
+	}
+}
+
+func datevDate(day int, month time.Month) time.Time {
+	return time.Date(2025, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParseDatevWageTypeMapping(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    DatevWageTypeMapping
+		wantErr bool
+	}{
+		{"leer", "", DatevWageTypeMapping{}, false},
+		{"Komma und Zeilen", "vacation=1000, sick = 1010\nspecial=1020", DatevWageTypeMapping{"vacation": "1000", "sick": "1010", "special": "1020"}, false},
+		{"Lohnart mit Leerzeichen", "Nacht zuschlag=1200;", DatevWageTypeMapping{"Nacht zuschlag": "1200"}, false},
+		{"ohne Lohnart", "vacation=", nil, true},
+		{"ohne Gleichheitszeichen", "vacation", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDatevWageTypeMapping(tt.value)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidDatevSettings)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	mapping, err := ParseDatevWageTypeMapping(DatevWageTypeMapping{"sick": "1010", "vacation": "1000"}.String())
+	require.NoError(t, err)
+	assert.Equal(t, DatevWageTypeMapping{"sick": "1010", "vacation": "1000"}, mapping, "String ist wieder lesbar")
+}
+
+func TestDatevSettings_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  func(*DatevSettings)
+		wantErr bool
+	}{
+		{"gültig", func(*DatevSettings) {}, false},
+		{"Standard ohne Nummern", func(s *DatevSettings) { *s = *DefaultDatevSettings() }, false},
+		{"Lohn und Gehalt", func(s *DatevSettings) { s.Target = "LUG" }, true},
+		{"Beraternummer zu kurz", func(s *DatevSettings) { s.ConsultantNumber = "123" }, true},
+		{"Mandantennummer mit Buchstaben", func(s *DatevSettings) { s.ClientNumber = "12a" }, true},
+		{"unbekanntes Ziel", func(s *DatevSettings) { s.Target = "SAP" }, true},
+		{"unbekannte Abwesenheitsart", func(s *DatevSettings) { s.AbsenceWageTypes["homeoffice"] = "1000" }, true},
+		{"Lohnart zu lang", func(s *DatevSettings) { s.WageTypes["Nachtzuschlag"] = "12000" }, true},
+		{"Auszahlung ohne Ziffern", func(s *DatevSettings) { s.OvertimePayoutWageType = "LA1" }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := datevTestSettings()
+			tt.change(settings)
+			err := settings.Validate()
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidDatevSettings)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBuildDatevExport(t *testing.T) {
+	anna := &Employee{
+		ID:         primitive.NewObjectID(),
+		EmployeeID: "00002",
+		FirstName:  "Anna",
+		LastName:   "Schmidt",
+		Absences: []Absence{
+			// Mo 3.3. bis Fr 7.3.: 5 Tage
+			{Type: "vacation", Status: "approved", StartDate: datevDate(3, time.March), EndDate: datevDate(7, time.March), Days: 5},
+			// Fr 28.2. bis Di 4.3.: im März nur Mo und Di
+			{Type: "sick", Status: "approved", StartDate: datevDate(28, time.February), EndDate: datevDate(4, time.March), Days: 3},
+			{Type: "vacation", Status: "requested", StartDate: datevDate(17, time.March), EndDate: datevDate(17, time.March), Days: 1},
+			{Type: "special", Status: "approved", StartDate: datevDate(20, time.March), EndDate: datevDate(20, time.March), Days: 1},
+		},
+		TimeEntries: []TimeEntry{
+			{Date: datevDate(10, time.March), Duration: 2.5, WageType: "Nachtzuschlag"},
+			{Date: datevDate(11, time.March), Duration: 1.25, WageType: "Nachtzuschlag"},
+			{Date: datevDate(12, time.March), Duration: 8},
+			{Date: datevDate(1, time.April), Duration: 3, WageType: "Nachtzuschlag"},
+			{Date: datevDate(13, time.March), Duration: 4, WageType: "Feiertagszuschlag"},
+		},
+	}
+	ben := &Employee{
+		ID:         primitive.NewObjectID(),
+		EmployeeID: "00001",
+		FirstName:  "Ben",
+		LastName:   "Meyer",
+	}
+	noNumber := &Employee{
+		ID:        primitive.NewObjectID(),
+		FirstName: "Clara",
+		LastName:  "Ohne",
+		Absences:  []Absence{{Type: "vacation", Status: "approved", StartDate: datevDate(3, time.March), EndDate: datevDate(3, time.March), Days: 1}},
+	}
+	adjustments := []*OvertimeAdjustment{
+		{EmployeeID: ben.ID, Type: OvertimeAdjustmentTypePayout, Status: "approved", Hours: -10},
+		{EmployeeID: ben.ID, Type: OvertimeAdjustmentTypePayout, Status: "approved", Hours: -2.5},
+		{EmployeeID: ben.ID, Type: OvertimeAdjustmentTypeCorrection, Status: "approved", Hours: 4},
+		{EmployeeID: anna.ID, Type: OvertimeAdjustmentTypePayout, Status: "pending", Hours: -6},
+	}
+
+	export := BuildDatevExport(datevTestSettings(), datevDate(15, time.March), []*Employee{anna, ben, noNumber}, adjustments)
+
+	assert.Equal(t, datevDate(1, time.March), export.Month)
+	type row struct {
+		pnr, wageType string
+		unit          DatevUnit
+		value         float64
+	}
+	var rows []row
+	for _, movement := range export.Movements {
+		rows = append(rows, row{movement.PersonnelNumber, movement.WageType, movement.Unit, movement.Value})
+	}
+	assert.Equal(t, []row{
+		{"00001", "1300", DatevUnitHours, 12.5},
+		{"00002", "1000", DatevUnitDays, 5},
+		{"00002", "1010", DatevUnitDays, 2},
+		{"00002", "1200", DatevUnitHours, 3.75},
+	}, rows)
+
+	assert.ElementsMatch(t, []string{
+		`Keine DATEV-Lohnart für Abwesenheitsart "special" zugeordnet`,
+		`Keine DATEV-Lohnart für Lohnart "Feiertagszuschlag" der Zeiterfassung zugeordnet`,
+		"Clara Ohne hat keine Personalnummer und wurde nicht exportiert",
+	}, export.Warnings)
+}
+
+func TestBuildDatevExport_MergesSameWageType(t *testing.T) {
+	settings := datevTestSettings()
+	settings.AbsenceWageTypes["special"] = "1000"
+	employee := &Employee{
+		EmployeeID: "7",
+		Absences: []Absence{
+			{Type: "vacation", Status: "approved", StartDate: datevDate(3, time.March), EndDate: datevDate(3, time.March), Days: 1},
+			{Type: "special", Status: "approved", StartDate: datevDate(4, time.March), EndDate: datevDate(4, time.March), Days: 1},
+		},
+	}
+
+	export := BuildDatevExport(settings, datevDate(1, time.March), []*Employee{employee}, nil)
+
+	require.Len(t, export.Movements, 1)
+	assert.Equal(t, 2.0, export.Movements[0].Value)
+	assert.Equal(t, "Urlaub, Sonderurlaub", export.Movements[0].Source)
+	assert.Empty(t, export.Warnings)
+}
+
+func TestDatevExport_ASCII(t *testing.T) {
+	settings := datevTestSettings()
+	export := &DatevExport{
+		Month: datevDate(1, time.March),
+		Movements: []DatevMovement{
+			{PersonnelNumber: "00001", WageType: "1300", Unit: DatevUnitHours, Value: 12.5},
+			{PersonnelNumber: "00002", WageType: "1000", Unit: DatevUnitDays, Value: 5},
+		},
+	}
+
+	content := string(export.ASCII(settings))
+
+	assert.True(t, strings.HasPrefix(content, "[Allgemein]\r\nZiel=LODAS\r\n"))
+	assert.Contains(t, content, "BeraterNr=1234567\r\nMandantenNr=12345\r\n")
+	assert.Contains(t, content, "10;u_lod_bwd_buchung_standard;abrechnung_zeitraum#bwd;pnr#bwd;la_eigene#bwd;bs_nr#bwd;bs_wert_butab#bwd;\r\n")
+	assert.True(t, strings.HasSuffix(content, "[Bewegungsdaten]\r\n10;01/03/2025;00001;1300;1;12,50;\r\n10;01/03/2025;00002;1000;2;5,00;\r\n"))
+	assert.Equal(t, "DATEV_LODAS_1234567_12345_2025-03.txt", export.FileName(settings))
+}
+
+func TestParseDatevMonth(t *testing.T) {
+	now := time.Date(2025, time.January, 20, 10, 0, 0, 0, time.UTC)
+
+	month, err := ParseDatevMonth("", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, time.December, 1, 0, 0, 0, 0, time.UTC), month, "ohne Angabe der Vormonat")
+
+	month, err = ParseDatevMonth("2025-03", now)
+	require.NoError(t, err)
+	assert.Equal(t, datevDate(1, time.March), month)
+
+	_, err = ParseDatevMonth("03/2025", now)
+	assert.ErrorIs(t, err, ErrInvalidDatevMonth)
+}
+
+func TestSystemSettings_GetDatevSettings(t *testing.T) {
+	var settings *SystemSettings
+	assert.Equal(t, DefaultDatevSettings(), settings.GetDatevSettings())
+
+	settings = &SystemSettings{Datev: &DatevSettings{ConsultantNumber: "1234"}}
+	datev := settings.GetDatevSettings()
+	assert.Equal(t, DatevTargetLODAS, datev.Target)
+	assert.NotNil(t, datev.AbsenceWageTypes)
+	assert.NotNil(t, datev.WageTypes)
+
+	// Lohn und Gehalt wird nicht stillschweigend als LODAS exportiert
+	settings = &SystemSettings{Datev: &DatevSettings{ConsultantNumber: "1234", ClientNumber: "56", Target: "LUG"}}
+	datev = settings.GetDatevSettings()
+	assert.Equal(t, DatevTarget("LUG"), datev.Target)
+	err := datev.Validate()
+	assert.ErrorIs(t, err, ErrInvalidDatevSettings)
+	assert.Contains(t, err.Error(), "Lohn und Gehalt wird nicht unterstützt")
+}
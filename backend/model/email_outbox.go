@@ -0,0 +1,135 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// OutboxEmailStatus beschreibt den Zustand einer E-Mail im Postausgang
+type OutboxEmailStatus string
+
+const (
+	OutboxEmailPending OutboxEmailStatus = "pending" // Wartet auf (erneuten) Versand
+	OutboxEmailSent    OutboxEmailStatus = "sent"    // Vom SMTP-Server angenommen
+	OutboxEmailDead    OutboxEmailStatus = "dead"    // Alle Versuche fehlgeschlagen, nur manuell erneut sendbar
+
+	// EmailMaxAttempts begrenzt die Versandversuche pro E-Mail
+	EmailMaxAttempts = 6
+
+	// EmailRetryBaseDelay ist die Wartezeit nach dem ersten Fehlversuch; sie verdoppelt sich pro Versuch
+	EmailRetryBaseDelay = time.Minute
+
+	// OutboxRedactedBody ersetzt den Inhalt von E-Mails mit Einmal-Links nach dem letzten Versuch
+	OutboxRedactedBody = "[Inhalt entfernt: enthielt einen persönlichen Link]"
+)
+
+// OutboxEmail ist eine E-Mail im Postausgang. Jede ausgehende E-Mail wird zuerst hier
+// gespeichert und anschließend vom Versand bzw. Background-Worker zugestellt.
+type OutboxEmail struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	To            string             `bson:"to" json:"to"`
+	Subject       string             `bson:"subject" json:"subject"`
+	Body          string             `bson:"body" json:"body,omitempty"`
+	IsHTML        bool               `bson:"isHtml" json:"isHtml"`
+	TemplateKey   EmailTemplateKey   `bson:"templateKey,omitempty" json:"templateKey,omitempty"`
+	Status        OutboxEmailStatus  `bson:"status" json:"status"`
+	Attempts      int                `bson:"attempts" json:"attempts"`
+	Error         string             `bson:"error,omitempty" json:"error,omitempty"`
+	NextAttemptAt *time.Time         `bson:"nextAttemptAt,omitempty" json:"nextAttemptAt,omitempty"`
+	LastAttemptAt *time.Time         `bson:"lastAttemptAt,omitempty" json:"lastAttemptAt,omitempty"`
+	SentAt        *time.Time         `bson:"sentAt,omitempty" json:"sentAt,omitempty"`
+	Redacted      bool               `bson:"redacted,omitempty" json:"redacted,omitempty"`
+	CreatedAt     time.Time          `bson:"createdAt" json:"createdAt"`
+}
+
+// IsValid prüft, ob der Status bekannt ist
+func (s OutboxEmailStatus) IsValid() bool {
+	switch s {
+	case OutboxEmailPending, OutboxEmailSent, OutboxEmailDead:
+		return true
+	default:
+		return false
+	}
+}
+
+// GetLabel gibt eine deutsche Bezeichnung für den Status zurück
+func (s OutboxEmailStatus) GetLabel() string {
+	switch s {
+	case OutboxEmailPending:
+		return "Wartend"
+	case OutboxEmailSent:
+		return "Gesendet"
+	case OutboxEmailDead:
+		return "Fehlgeschlagen"
+	default:
+		return string(s)
+	}
+}
+
+// EmailRetryDelay gibt die Wartezeit nach dem n-ten Fehlversuch zurück (exponentiell)
+func EmailRetryDelay(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	return EmailRetryBaseDelay * time.Duration(1<<uint(attempt-1))
+}
+
+// RecordAttempt wertet einen Versandversuch aus und plant gegebenenfalls den nächsten
+func (e *OutboxEmail) RecordAttempt(at time.Time, errMessage string) {
+	e.Attempts++
+	e.LastAttemptAt = &at
+	e.Error = errMessage
+
+	if errMessage == "" {
+		e.Status = OutboxEmailSent
+		e.SentAt = &at
+		e.NextAttemptAt = nil
+		e.RedactSecrets()
+		return
+	}
+
+	if e.Attempts >= EmailMaxAttempts {
+		e.Status = OutboxEmailDead
+		e.NextAttemptAt = nil
+		e.RedactSecrets()
+		return
+	}
+
+	next := at.Add(EmailRetryDelay(e.Attempts))
+	e.Status = OutboxEmailPending
+	e.NextAttemptAt = &next
+}
+
+// Requeue stellt eine fehlgeschlagene E-Mail mit neuem Versuchszähler wieder in den Postausgang
+func (e *OutboxEmail) Requeue(at time.Time) {
+	e.Status = OutboxEmailPending
+	e.Attempts = 0
+	e.Error = ""
+	e.NextAttemptAt = &at
+}
+
+// ContainsSecret gibt an, ob der Inhalt der E-Mail einen Einmal-Link (Passwort-Reset, Einladung) enthält
+func (e *OutboxEmail) ContainsSecret() bool {
+	return e.TemplateKey.ContainsSecret()
+}
+
+// RedactSecrets entfernt den Inhalt von E-Mails mit Einmal-Links. Danach kann die E-Mail
+// nicht mehr erneut gesendet werden; der Link muss neu angefordert werden.
+func (e *OutboxEmail) RedactSecrets() {
+	if !e.ContainsSecret() || e.Redacted {
+		return
+	}
+	e.Body = OutboxRedactedBody
+	e.Redacted = true
+}
+
+// WithoutSecrets gibt eine Kopie zur Anzeige zurück, die keine Einmal-Links enthält
+func (e *OutboxEmail) WithoutSecrets() *OutboxEmail {
+	if !e.ContainsSecret() {
+		return e
+	}
+	copied := *e
+	copied.Body = OutboxRedactedBody
+	return &copied
+}
@@ -0,0 +1,113 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutboxEmail_RecordAttempt(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Success", func(t *testing.T) {
+		e := &OutboxEmail{Status: OutboxEmailPending}
+		e.RecordAttempt(now, "")
+
+		assert.Equal(t, OutboxEmailSent, e.Status)
+		assert.Equal(t, 1, e.Attempts)
+		assert.Equal(t, now, *e.SentAt)
+		assert.Nil(t, e.NextAttemptAt)
+	})
+
+	t.Run("Failure schedules retry with backoff", func(t *testing.T) {
+		e := &OutboxEmail{Status: OutboxEmailPending, Attempts: 2}
+		e.RecordAttempt(now, "dial tcp: connection refused")
+
+		assert.Equal(t, OutboxEmailPending, e.Status)
+		assert.Equal(t, 3, e.Attempts)
+		assert.Equal(t, "dial tcp: connection refused", e.Error)
+		assert.Equal(t, now.Add(4*time.Minute), *e.NextAttemptAt)
+	})
+
+	t.Run("Last attempt moves the mail to dead letters", func(t *testing.T) {
+		e := &OutboxEmail{Status: OutboxEmailPending, Attempts: EmailMaxAttempts - 1}
+		e.RecordAttempt(now, "550 mailbox unavailable")
+
+		assert.Equal(t, OutboxEmailDead, e.Status)
+		assert.Nil(t, e.NextAttemptAt)
+		assert.Nil(t, e.SentAt)
+	})
+}
+
+func TestOutboxEmail_Requeue(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	e := &OutboxEmail{Status: OutboxEmailDead, Attempts: EmailMaxAttempts, Error: "timeout"}
+
+	e.Requeue(now)
+
+	assert.Equal(t, OutboxEmailPending, e.Status)
+	assert.Equal(t, 0, e.Attempts)
+	assert.Empty(t, e.Error)
+	assert.Equal(t, now, *e.NextAttemptAt)
+}
+
+func TestOutboxEmailStatus_IsValid(t *testing.T) {
+	tests := []struct {
+		status   OutboxEmailStatus
+		expected bool
+	}{
+		{OutboxEmailPending, true},
+		{OutboxEmailSent, true},
+		{OutboxEmailDead, true},
+		{"bounced", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.status.IsValid())
+		})
+	}
+}
+
+func TestOutboxEmail_RedactSecrets(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	resetBody := "https://peopleflow.example/reset-password?token=geheim"
+
+	t.Run("Sent reset email is redacted", func(t *testing.T) {
+		e := &OutboxEmail{Status: OutboxEmailPending, TemplateKey: EmailTemplatePasswordReset, Body: resetBody}
+		e.RecordAttempt(now, "")
+
+		assert.True(t, e.Redacted)
+		assert.Equal(t, OutboxRedactedBody, e.Body)
+	})
+
+	t.Run("Dead invitation is redacted", func(t *testing.T) {
+		e := &OutboxEmail{Status: OutboxEmailPending, TemplateKey: EmailTemplateInvitation, Body: resetBody, Attempts: EmailMaxAttempts - 1}
+		e.RecordAttempt(now, "timeout")
+
+		assert.Equal(t, OutboxEmailDead, e.Status)
+		assert.True(t, e.Redacted)
+		assert.NotContains(t, e.Body, "token=")
+	})
+
+	t.Run("Pending reset email keeps its link for the next attempt", func(t *testing.T) {
+		e := &OutboxEmail{Status: OutboxEmailPending, TemplateKey: EmailTemplatePasswordReset, Body: resetBody}
+		e.RecordAttempt(now, "timeout")
+
+		assert.False(t, e.Redacted)
+		assert.Equal(t, resetBody, e.Body)
+		assert.Equal(t, OutboxRedactedBody, e.WithoutSecrets().Body)
+		assert.Equal(t, resetBody, e.Body, "WithoutSecrets darf das Original nicht verändern")
+	})
+
+	t.Run("Other templates are kept", func(t *testing.T) {
+		e := &OutboxEmail{Status: OutboxEmailPending, TemplateKey: EmailTemplateWeeklyReport, Body: "Bericht"}
+		e.RecordAttempt(now, "")
+
+		assert.False(t, e.Redacted)
+		assert.Equal(t, "Bericht", e.Body)
+		assert.Same(t, e, e.WithoutSecrets())
+	})
+}
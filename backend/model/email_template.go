@@ -0,0 +1,298 @@
+package model
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html"
+	htmltemplate "html/template"
+	"regexp"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// EmailTemplateKey bezeichnet eine Art ausgehender E-Mail
+type EmailTemplateKey string
+
+const (
+	EmailTemplatePasswordReset EmailTemplateKey = "password_reset"
+	EmailTemplateInvitation    EmailTemplateKey = "invitation"
+	EmailTemplateNotification  EmailTemplateKey = "notification"
+	EmailTemplateWeeklyReport  EmailTemplateKey = "weekly_report"
+	EmailTemplateTest          EmailTemplateKey = "test"
+
+	// Berichte aus Abonnements (die wöchentliche Arbeitszeit nutzt weekly_report)
+	EmailTemplateMonthlyOvertime EmailTemplateKey = "report_monthly_overtime"
+	EmailTemplateTeamDigest      EmailTemplateKey = "report_team_digest"
+	EmailTemplateMonthlyAbsences EmailTemplateKey = "report_monthly_absences"
+)
+
+// Sprachen für E-Mail-Vorlagen
+const (
+	EmailLanguageGerman  = "de"
+	EmailLanguageEnglish = "en"
+)
+
+// E-Mail-Vorlagen-Fehler
+var (
+	ErrInvalidEmailTemplateKey      = errors.New("invalid email template key")
+	ErrInvalidEmailLanguage         = errors.New("invalid email language")
+	ErrEmailTemplateSubjectRequired = errors.New("email template subject is required")
+	ErrEmailTemplateBodyRequired    = errors.New("email template body is required")
+	ErrInvalidEmailTemplateSyntax   = errors.New("invalid email template syntax")
+)
+
+// EmailTemplate ist eine von Admins angepasste Vorlage. Ohne gespeicherte Vorlage
+// wird die eingebaute Standardvorlage verwendet.
+type EmailTemplate struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Key       EmailTemplateKey   `bson:"key" json:"key"`
+	Language  string             `bson:"language" json:"language"`
+	Subject   string             `bson:"subject" json:"subject"` // text/template
+	Body      string             `bson:"body" json:"body"`       // html/template, Inhalt innerhalb des Standard-Layouts
+	UpdatedBy primitive.ObjectID `bson:"updatedBy,omitempty" json:"updatedBy,omitempty"`
+	UpdatedAt time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
+
+// EmailTemplateVariable beschreibt eine in einer Vorlage verfügbare Variable
+type EmailTemplateVariable struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// EmailTemplateKeys gibt alle Vorlagenarten zurück
+func EmailTemplateKeys() []EmailTemplateKey {
+	return []EmailTemplateKey{
+		EmailTemplatePasswordReset, EmailTemplateInvitation, EmailTemplateNotification,
+		EmailTemplateWeeklyReport, EmailTemplateMonthlyOvertime, EmailTemplateTeamDigest,
+		EmailTemplateMonthlyAbsences, EmailTemplateTest,
+	}
+}
+
+// EmailLanguages gibt alle Sprachen zurück, in denen Vorlagen gepflegt werden
+func EmailLanguages() []string {
+	return []string{EmailLanguageGerman, EmailLanguageEnglish}
+}
+
+// IsValidEmailLanguage prüft, ob für die Sprache Vorlagen existieren
+func IsValidEmailLanguage(language string) bool {
+	return language == EmailLanguageGerman || language == EmailLanguageEnglish
+}
+
+// IsValid prüft, ob die Vorlagenart bekannt ist
+func (k EmailTemplateKey) IsValid() bool {
+	for _, key := range EmailTemplateKeys() {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsSecret gibt an, ob E-Mails dieser Vorlage einen Einmal-Link mit Token enthalten
+func (k EmailTemplateKey) ContainsSecret() bool {
+	return k == EmailTemplatePasswordReset || k == EmailTemplateInvitation
+}
+
+// GetLabel gibt eine deutsche Bezeichnung für die Vorlagenart zurück
+func (k EmailTemplateKey) GetLabel() string {
+	switch k {
+	case EmailTemplatePasswordReset:
+		return "Passwort zurücksetzen"
+	case EmailTemplateInvitation:
+		return "Einladung"
+	case EmailTemplateNotification:
+		return "Benachrichtigung"
+	case EmailTemplateWeeklyReport:
+		return "Wochenbericht"
+	case EmailTemplateMonthlyOvertime:
+		return "Überstunden-Abrechnung"
+	case EmailTemplateTeamDigest:
+		return "Team-Übersicht"
+	case EmailTemplateMonthlyAbsences:
+		return "Abwesenheitsübersicht"
+	case EmailTemplateTest:
+		return "Test-E-Mail"
+	default:
+		return string(k)
+	}
+}
+
+// Variables gibt die in der Vorlage verfügbaren Variablen zurück.
+// CompanyName und BaseURL stehen in allen Vorlagen zur Verfügung.
+func (k EmailTemplateKey) Variables() []EmailTemplateVariable {
+	common := []EmailTemplateVariable{
+		{"CompanyName", "Firmenname aus den Einstellungen"},
+		{"BaseURL", "Adresse der PeopleFlow-Installation"},
+	}
+
+	var specific []EmailTemplateVariable
+	switch k {
+	case EmailTemplatePasswordReset:
+		specific = []EmailTemplateVariable{
+			{"ResetURL", "Link zum Zurücksetzen des Passworts (1 Stunde gültig)"},
+		}
+	case EmailTemplateInvitation:
+		specific = []EmailTemplateVariable{
+			{"Name", "Name des eingeladenen Benutzers"},
+			{"InvitedBy", "Name der einladenden Person (kann leer sein)"},
+			{"ActivationURL", "Aktivierungslink"},
+			{"ExpiresAt", "Ablaufzeitpunkt des Links"},
+		}
+	case EmailTemplateNotification:
+		specific = []EmailTemplateVariable{
+			{"Name", "Name des Empfängers"},
+			{"Title", "Titel der Benachrichtigung"},
+			{"Message", "Text der Benachrichtigung"},
+			{"Link", "Absoluter Link zum Ziel (kann leer sein)"},
+		}
+	case EmailTemplateWeeklyReport:
+		specific = []EmailTemplateVariable{
+			{"EmployeeName", "Name des Mitarbeiters"},
+			{"WeekStart", "Erster Tag der Woche"},
+			{"WeekEnd", "Letzter Tag der Woche"},
+			{"TotalHours", "Erfasste Arbeitszeit in Stunden"},
+			{"PlannedHours", "Soll-Arbeitszeit in Stunden"},
+			{"Difference", "Differenz Ist - Soll mit Vorzeichen"},
+			{"OvertimeBalance", "Aktuelles Überstundensaldo mit Vorzeichen"},
+			{"Days", "Liste mit .Date, .Hours"},
+			{"Projects", "Liste mit .Name, .Hours"},
+			{"ShowDays", "Abschnitt „Stunden je Tag“ gewählt"},
+			{"ShowProjects", "Abschnitt „Stunden je Projekt“ gewählt"},
+			{"ShowOvertime", "Abschnitt „Soll/Ist und Überstunden“ gewählt"},
+		}
+	case EmailTemplateMonthlyOvertime:
+		specific = []EmailTemplateVariable{
+			{"EmployeeName", "Name des Mitarbeiters"},
+			{"Month", "Abgerechneter Monat"},
+			{"PlannedHours", "Soll-Arbeitszeit in Stunden"},
+			{"ActualHours", "Erfasste Arbeitszeit in Stunden"},
+			{"Difference", "Differenz Ist - Soll mit Vorzeichen"},
+			{"OvertimeBalance", "Aktuelles Überstundensaldo mit Vorzeichen"},
+			{"Weeks", "Liste mit .Week, .Start, .End, .PlannedHours, .ActualHours, .Difference"},
+			{"Adjustments", "Liste mit .Date, .Type, .Hours, .Reason"},
+			{"ShowWeeks", "Abschnitt „Aufstellung je Woche“ gewählt"},
+			{"ShowAdjustments", "Abschnitt „Überstunden-Anpassungen“ gewählt"},
+		}
+	case EmailTemplateTeamDigest:
+		specific = []EmailTemplateVariable{
+			{"Name", "Name des Empfängers"},
+			{"Department", "Gewählte Abteilung (leer = eigenes Team bzw. alle)"},
+			{"PeriodStart", "Erster Tag des Zeitraums"},
+			{"PeriodEnd", "Letzter Tag des Zeitraums"},
+			{"MemberCount", "Anzahl der Mitarbeiter"},
+			{"TotalHours", "Erfasste Arbeitszeit aller Mitarbeiter"},
+			{"Members", "Liste mit .Name, .ActualHours, .PlannedHours, .Difference, .OvertimeBalance, .AbsenceDays"},
+			{"Absences", "Liste mit .EmployeeName, .Type, .StartDate, .EndDate, .Days"},
+			{"ShowHours", "Abschnitt „Stunden je Mitarbeiter“ gewählt"},
+			{"ShowOvertime", "Abschnitt „Soll/Ist und Überstunden“ gewählt"},
+			{"ShowAbsences", "Abschnitt „Einzelne Abwesenheiten“ gewählt"},
+		}
+	case EmailTemplateMonthlyAbsences:
+		specific = []EmailTemplateVariable{
+			{"Name", "Name des Empfängers"},
+			{"Month", "Ausgewerteter Monat"},
+			{"Department", "Gewählte Abteilung (leer = alle)"},
+			{"TotalDays", "Abwesenheitstage insgesamt"},
+			{"Totals", "Liste mit .Type, .Days, .Count"},
+			{"Absences", "Liste mit .EmployeeName, .Department, .Type, .StartDate, .EndDate, .Days"},
+			{"ShowTotals", "Abschnitt „Tage je Abwesenheitsart“ gewählt"},
+			{"ShowAbsences", "Abschnitt „Einzelne Abwesenheiten“ gewählt"},
+		}
+	case EmailTemplateTest:
+		specific = []EmailTemplateVariable{
+			{"SentAt", "Zeitpunkt des Versands"},
+		}
+	}
+	return append(specific, common...)
+}
+
+// Validate prüft Art, Sprache und die Syntax von Betreff und Inhalt
+func (t *EmailTemplate) Validate() error {
+	if !t.Key.IsValid() {
+		return fmt.Errorf("%w: %s", ErrInvalidEmailTemplateKey, t.Key)
+	}
+	if !IsValidEmailLanguage(t.Language) {
+		return fmt.Errorf("%w: %s", ErrInvalidEmailLanguage, t.Language)
+	}
+	if strings.TrimSpace(t.Subject) == "" {
+		return ErrEmailTemplateSubjectRequired
+	}
+	if strings.TrimSpace(t.Body) == "" {
+		return ErrEmailTemplateBodyRequired
+	}
+	if _, err := texttemplate.New("subject").Parse(t.Subject); err != nil {
+		return fmt.Errorf("%w: Betreff: %v", ErrInvalidEmailTemplateSyntax, err)
+	}
+	if _, err := htmltemplate.New("body").Parse(t.Body); err != nil {
+		return fmt.Errorf("%w: Inhalt: %v", ErrInvalidEmailTemplateSyntax, err)
+	}
+	return nil
+}
+
+// Render füllt Betreff und Inhalt mit den Daten. Der Inhalt wird HTML-maskiert,
+// der Betreff als Klartext erzeugt.
+func (t *EmailTemplate) Render(data map[string]interface{}) (subject string, body string, err error) {
+	subjectTmpl, err := texttemplate.New("subject").Option("missingkey=zero").Parse(t.Subject)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: Betreff: %v", ErrInvalidEmailTemplateSyntax, err)
+	}
+	var subjectBuf bytes.Buffer
+	if err := subjectTmpl.Execute(&subjectBuf, data); err != nil {
+		return "", "", fmt.Errorf("%w: Betreff: %v", ErrInvalidEmailTemplateSyntax, err)
+	}
+
+	bodyTmpl, err := htmltemplate.New("body").Option("missingkey=zero").Parse(t.Body)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: Inhalt: %v", ErrInvalidEmailTemplateSyntax, err)
+	}
+	var bodyBuf bytes.Buffer
+	if err := bodyTmpl.Execute(&bodyBuf, data); err != nil {
+		return "", "", fmt.Errorf("%w: Inhalt: %v", ErrInvalidEmailTemplateSyntax, err)
+	}
+
+	// Zeilenumbrüche im Betreff würden den Mail-Header zerstören
+	subject = strings.Join(strings.Fields(subjectBuf.String()), " ")
+	return subject, bodyBuf.String(), nil
+}
+
+var (
+	plainTextDropBlocks = regexp.MustCompile(`(?is)<(head|style|script)[^>]*>.*?</(head|style|script)>`)
+	plainTextLinks      = regexp.MustCompile(`(?is)<a\s[^>]*href="([^"]*)"[^>]*>(.*?)</a>`)
+	plainTextListItems  = regexp.MustCompile(`(?i)\s*<li[^>]*>`)
+	plainTextBreaks     = regexp.MustCompile(`(?i)<br\s*/?>`)
+	plainTextBlocks     = regexp.MustCompile(`(?i)</?(p|div|h[1-6]|ul|ol|tr|table)[^>]*>`)
+	plainTextTags       = regexp.MustCompile(`<[^>]+>`)
+	plainTextSpaces     = regexp.MustCompile(`[ \t]+`)
+	plainTextBlankLines = regexp.MustCompile(`\n{3,}`)
+)
+
+// EmailPlainText erzeugt die Klartext-Alternative zu einer HTML-E-Mail.
+// Links werden als "Text (URL)" ausgegeben, Listenpunkte mit "- ".
+func EmailPlainText(htmlBody string) string {
+	text := plainTextDropBlocks.ReplaceAllString(htmlBody, "")
+	text = plainTextLinks.ReplaceAllStringFunc(text, func(link string) string {
+		parts := plainTextLinks.FindStringSubmatch(link)
+		label := strings.TrimSpace(plainTextTags.ReplaceAllString(parts[2], ""))
+		if label == "" || label == parts[1] {
+			return parts[1]
+		}
+		return label + " (" + parts[1] + ")"
+	})
+	text = plainTextListItems.ReplaceAllString(text, "\n- ")
+	text = plainTextBreaks.ReplaceAllString(text, "\n")
+	text = plainTextBlocks.ReplaceAllString(text, "\n\n")
+	text = plainTextTags.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(plainTextSpaces.ReplaceAllString(line, " "))
+	}
+	text = strings.Join(lines, "\n")
+	text = plainTextBlankLines.ReplaceAllString(text, "\n\n")
+	return strings.TrimSpace(text) + "\n"
+}
@@ -0,0 +1,86 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailTemplate_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template EmailTemplate
+		wantErr  error
+	}{
+		{"Valid template", EmailTemplate{Key: EmailTemplateTest, Language: EmailLanguageGerman, Subject: "Test {{.CompanyName}}", Body: "<p>{{.SentAt}}</p>"}, nil},
+		{"Unknown key", EmailTemplate{Key: "newsletter", Language: EmailLanguageGerman, Subject: "x", Body: "x"}, ErrInvalidEmailTemplateKey},
+		{"Unknown language", EmailTemplate{Key: EmailTemplateTest, Language: "fr", Subject: "x", Body: "x"}, ErrInvalidEmailLanguage},
+		{"Missing subject", EmailTemplate{Key: EmailTemplateTest, Language: EmailLanguageEnglish, Subject: " ", Body: "x"}, ErrEmailTemplateSubjectRequired},
+		{"Missing body", EmailTemplate{Key: EmailTemplateTest, Language: EmailLanguageEnglish, Subject: "x"}, ErrEmailTemplateBodyRequired},
+		{"Broken subject", EmailTemplate{Key: EmailTemplateTest, Language: EmailLanguageEnglish, Subject: "{{.CompanyName", Body: "x"}, ErrInvalidEmailTemplateSyntax},
+		{"Broken body", EmailTemplate{Key: EmailTemplateTest, Language: EmailLanguageEnglish, Subject: "x", Body: "{{if .SentAt}}open"}, ErrInvalidEmailTemplateSyntax},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.template.Validate()
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEmailTemplate_Render(t *testing.T) {
+	template := EmailTemplate{
+		Key:      EmailTemplateNotification,
+		Language: EmailLanguageGerman,
+		Subject:  "{{.Title}}\n- {{.CompanyName}}",
+		Body:     `<p>Hallo {{.Name}},</p><p>{{.Message}}</p>{{if .Link}}<a href="{{.Link}}">Öffnen</a>{{end}}`,
+	}
+
+	subject, body, err := template.Render(map[string]interface{}{
+		"Title":       "Neuer Antrag",
+		"CompanyName": "ACME",
+		"Name":        "Anna",
+		"Message":     "<script>alert(1)</script>",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Neuer Antrag - ACME", subject, "line breaks in the subject must be collapsed")
+	assert.Contains(t, body, "Hallo Anna")
+	assert.Contains(t, body, "&lt;script&gt;", "data must be HTML-escaped in the body")
+	assert.NotContains(t, body, "<a href", "missing optional values render as empty")
+}
+
+func TestEmailPlainText(t *testing.T) {
+	html := `<!DOCTYPE html><html><head><style>p { color: red; }</style></head><body>
+<h1>Wochenbericht</h1>
+<p>Hallo Anna,<br>hier Ihre Woche &amp; mehr:</p>
+<ul>
+    <li>Montag</li>
+    <li>Dienstag</li>
+</ul>
+<p><a href="https://example.com/reset" class="button">Passwort zurücksetzen</a></p>
+<p><a href="https://example.com">https://example.com</a></p>
+</body></html>`
+
+	expected := "Wochenbericht\n\nHallo Anna,\nhier Ihre Woche & mehr:\n\n- Montag\n- Dienstag\n\n" +
+		"Passwort zurücksetzen (https://example.com/reset)\n\nhttps://example.com\n"
+	assert.Equal(t, expected, EmailPlainText(html))
+}
+
+func TestEmailTemplateKey_Variables(t *testing.T) {
+	for _, key := range EmailTemplateKeys() {
+		names := make(map[string]bool)
+		for _, variable := range key.Variables() {
+			names[variable.Name] = true
+		}
+		assert.True(t, names["CompanyName"], "%s should offer CompanyName", key)
+		assert.True(t, names["BaseURL"], "%s should offer BaseURL", key)
+	}
+	assert.False(t, EmailTemplateKey("newsletter").IsValid())
+}
@@ -56,6 +56,7 @@ type Employee struct {
 	HireDate   time.Time          `bson:"hireDate" json:"hireDate"`
 	Position   string             `bson:"position" json:"position"`
 	Department Department         `bson:"department" json:"department"`
+	Location   string             `bson:"location" json:"location"` // Standort
 	ManagerID  primitive.ObjectID `bson:"managerId,omitempty" json:"managerId"`
 	Status     EmployeeStatus     `bson:"status" json:"status"`
 
@@ -81,6 +82,11 @@ type Employee struct {
 	SocialSecID     string  `bson:"socialSecId" json:"socialSecId"`
 	HealthInsurance string  `bson:"healthInsurance" json:"healthInsurance"`
 
+	// Gehaltshistorie mit Stichtagen und Sonderzahlungen für die Personalkosten;
+	// nur über die Gehalts-API mit Gehaltseinsicht abrufbar
+	SalaryHistory []SalaryChange `bson:"salaryHistory,omitempty" json:"-"`
+	Bonuses       []SalaryBonus  `bson:"bonuses,omitempty" json:"-"`
+
 	// Notfallkontakt
 	EmergencyName  string `bson:"emergencyName" json:"emergencyName"`
 	EmergencyPhone string `bson:"emergencyPhone" json:"emergencyPhone"`
@@ -108,6 +114,12 @@ type Employee struct {
 	// Integration IDs
 	TimebutlerUserID string `bson:"timebutlerUserId" json:"timebutlerUserId"`
 	Erfasst123ID     string `bson:"erfasst123Id" json:"erfasst123Id"`
+	PersonioID       string `bson:"personioId,omitempty" json:"personioId,omitempty"`
+
+	// Herkunft synchronisierter Felder: letzte schreibende Quelle je Feld und der zuletzt
+	// von jeder Integration gelieferte Wert (Integration → Feld → Wert) zur Konflikterkennung
+	FieldSources map[string]FieldSource       `bson:"fieldSources,omitempty" json:"fieldSources,omitempty"`
+	SyncedValues map[string]map[string]string `bson:"syncedValues,omitempty" json:"-"`
 
 	// Timestamps
 	CreatedAt time.Time `bson:"createdAt" json:"createdAt"`
@@ -167,6 +179,7 @@ type Document struct {
 	FileSize    int64              `bson:"fileSize" json:"fileSize"`
 	UploadDate  time.Time          `bson:"uploadDate" json:"uploadDate"`
 	UploadedBy  primitive.ObjectID `bson:"uploadedBy,omitempty" json:"uploadedBy"`
+	ExpiresAt   *time.Time         `bson:"expiresAt,omitempty" json:"expiresAt,omitempty"` // Optionales Ablaufdatum (z.B. Arbeitserlaubnis, Zertifikat)
 }
 
 // Training repräsentiert eine Weiterbildung oder ein Training
@@ -234,6 +247,14 @@ type Absence struct {
 	Reason       string             `bson:"reason" json:"reason"`
 	Notes        string             `bson:"notes" json:"notes"`
 	Documents    []Document         `bson:"documents,omitempty" json:"documents,omitempty"`
+
+	// Timebutler-Abgleich: ID der Abwesenheit in Timebutler und Prüfsumme des zuletzt übernommenen Stands
+	TimebutlerID   string `bson:"timebutlerId,omitempty" json:"timebutlerId,omitempty"`
+	TimebutlerHash string `bson:"timebutlerHash,omitempty" json:"-"`
+
+	// Personio-Abgleich: ID der Abwesenheit in Personio und Prüfsumme des zuletzt übernommenen Stands
+	PersonioID   string `bson:"personioId,omitempty" json:"personioId,omitempty"`
+	PersonioHash string `bson:"personioHash,omitempty" json:"-"`
 }
 
 // ProjectAssignment represents an employee's assignment to a project
@@ -0,0 +1,273 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidExportFormat wird bei einem unbekannten Exportformat zurückgegeben
+	ErrInvalidExportFormat = errors.New("unsupported export format")
+	// ErrEmployeeExportColumns wird bei unbekannten oder nicht freigegebenen Spalten zurückgegeben
+	ErrEmployeeExportColumns = errors.New("invalid employee export columns")
+)
+
+// ExportFormat ist das Dateiformat eines Exports
+type ExportFormat string
+
+const (
+	ExportFormatCSV  ExportFormat = "csv"
+	ExportFormatXLSX ExportFormat = "xlsx"
+	ExportFormatJSON ExportFormat = "json"
+	ExportFormatPDF  ExportFormat = "pdf"
+)
+
+// ParseExportFormat prüft das Format eines Exports gegen die unterstützten Formate;
+// ohne Angabe wird das erste unterstützte Format verwendet
+func ParseExportFormat(value string, supported ...ExportFormat) (ExportFormat, error) {
+	format := ExportFormat(strings.ToLower(strings.TrimSpace(value)))
+	if format == "" && len(supported) > 0 {
+		return supported[0], nil
+	}
+	for _, candidate := range supported {
+		if candidate == format {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q", ErrInvalidExportFormat, value)
+}
+
+// EmployeeExportColumn ist eine exportierbare Spalte der Mitarbeiterliste. Die Bezeichnungen
+// entsprechen denen des Imports, sodass eine exportierte Datei wieder importiert werden kann.
+type EmployeeExportColumn struct {
+	Key       string `json:"key"`
+	Label     string `json:"label"`
+	Financial bool   `json:"financial"` // nur für Benutzer mit Gehaltseinsicht
+
+	value func(e *Employee) interface{} // string, int, float64 oder time.Time
+}
+
+// EmployeeExportColumns sind alle exportierbaren Spalten in der Standardreihenfolge
+var EmployeeExportColumns = []EmployeeExportColumn{
+	{Key: "employeeId", Label: "Personalnummer", value: func(e *Employee) interface{} { return e.EmployeeID }},
+	{Key: "firstName", Label: "Vorname", value: func(e *Employee) interface{} { return e.FirstName }},
+	{Key: "lastName", Label: "Nachname", value: func(e *Employee) interface{} { return e.LastName }},
+	{Key: "email", Label: "E-Mail", value: func(e *Employee) interface{} { return e.Email }},
+	{Key: "phone", Label: "Telefon", value: func(e *Employee) interface{} { return e.Phone }},
+	{Key: "internalPhone", Label: "Interne Telefonnummer", value: func(e *Employee) interface{} { return e.InternalPhone }},
+	{Key: "internalExtension", Label: "Durchwahl", value: func(e *Employee) interface{} { return e.InternalExtension }},
+	{Key: "address", Label: "Adresse", value: func(e *Employee) interface{} { return e.Address }},
+	{Key: "dateOfBirth", Label: "Geburtsdatum", value: func(e *Employee) interface{} { return e.DateOfBirth }},
+	{Key: "hireDate", Label: "Eintrittsdatum", value: func(e *Employee) interface{} { return e.HireDate }},
+	{Key: "position", Label: "Position", value: func(e *Employee) interface{} { return e.Position }},
+	{Key: "department", Label: "Abteilung", value: func(e *Employee) interface{} { return string(e.Department) }},
+	{Key: "location", Label: "Standort", value: func(e *Employee) interface{} { return e.Location }},
+	{Key: "status", Label: "Status", value: func(e *Employee) interface{} { return string(e.Status) }},
+	{Key: "workingHoursPerWeek", Label: "Wochenstunden", value: func(e *Employee) interface{} { return e.WorkingHoursPerWeek }},
+	{Key: "workingDaysPerWeek", Label: "Arbeitstage pro Woche", value: func(e *Employee) interface{} { return e.WorkingDaysPerWeek }},
+	{Key: "workTimeModel", Label: "Arbeitszeitmodell", value: func(e *Employee) interface{} { return string(e.WorkTimeModel) }},
+	{Key: "vacationDays", Label: "Urlaubstage", value: func(e *Employee) interface{} { return e.VacationDays }},
+	{Key: "remainingVacation", Label: "Resturlaub", value: func(e *Employee) interface{} { return e.RemainingVacation }},
+	{Key: "overtimeBalance", Label: "Überstundensaldo", value: func(e *Employee) interface{} { return e.OvertimeBalance }},
+	{Key: "emergencyName", Label: "Notfallkontakt", value: func(e *Employee) interface{} { return e.EmergencyName }},
+	{Key: "emergencyPhone", Label: "Notfalltelefon", value: func(e *Employee) interface{} { return e.EmergencyPhone }},
+	{Key: "notes", Label: "Notizen", value: func(e *Employee) interface{} { return e.Notes }},
+	{Key: "salary", Label: "Gehalt", Financial: true, value: func(e *Employee) interface{} { return e.Salary }},
+	{Key: "bankAccount", Label: "IBAN", Financial: true, value: func(e *Employee) interface{} { return e.BankAccount }},
+	{Key: "taxId", Label: "Steuer-ID", Financial: true, value: func(e *Employee) interface{} { return e.TaxID }},
+	{Key: "socialSecId", Label: "Sozialversicherungsnummer", Financial: true, value: func(e *Employee) interface{} { return e.SocialSecID }},
+	{Key: "healthInsurance", Label: "Krankenkasse", Financial: true, value: func(e *Employee) interface{} { return e.HealthInsurance }},
+}
+
+// AvailableEmployeeExportColumns gibt die Spalten zurück, die der Benutzer exportieren darf
+func AvailableEmployeeExportColumns(allowFinancial bool) []EmployeeExportColumn {
+	columns := make([]EmployeeExportColumn, 0, len(EmployeeExportColumns))
+	for _, column := range EmployeeExportColumns {
+		if column.Financial && !allowFinancial {
+			continue
+		}
+		columns = append(columns, column)
+	}
+	return columns
+}
+
+// ParseEmployeeExportColumns liest eine kommagetrennte Liste von Spaltenschlüsseln. Ohne Angabe
+// werden alle Spalten exportiert, die der Benutzer sehen darf.
+func ParseEmployeeExportColumns(value string, allowFinancial bool) ([]EmployeeExportColumn, error) {
+	available := AvailableEmployeeExportColumns(allowFinancial)
+	if strings.TrimSpace(value) == "" {
+		return available, nil
+	}
+
+	var columns []EmployeeExportColumn
+	seen := map[string]bool{}
+	for _, key := range strings.Split(value, ",") {
+		key = strings.TrimSpace(key)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		found := false
+		for _, column := range EmployeeExportColumns {
+			if column.Key != key {
+				continue
+			}
+			if column.Financial && !allowFinancial {
+				return nil, fmt.Errorf("%w: keine Berechtigung für die Spalte %q", ErrEmployeeExportColumns, key)
+			}
+			columns = append(columns, column)
+			found = true
+			break
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: unbekannte Spalte %q", ErrEmployeeExportColumns, key)
+		}
+	}
+	if len(columns) == 0 {
+		return available, nil
+	}
+	return columns, nil
+}
+
+// EmployeeExportFilter entspricht den Filtern der Mitarbeiterliste: Freitextsuche sowie
+// Status und Abteilungen (jeweils mehrere möglich, leer = alle)
+type EmployeeExportFilter struct {
+	Search      string
+	Statuses    []string
+	Departments []string
+	SortBy      string // name, position, department oder hireDate
+}
+
+// Matches prüft, ob ein Mitarbeiter den Filter erfüllt
+func (f EmployeeExportFilter) Matches(employee *Employee) bool {
+	// Ohne Status gilt ein Mitarbeiter wie in der Liste als aktiv
+	status := employee.Status
+	if status == "" {
+		status = EmployeeStatusActive
+	}
+	if len(f.Statuses) > 0 && !exportFilterContains(f.Statuses, string(status)) {
+		return false
+	}
+	if len(f.Departments) > 0 && !exportFilterContains(f.Departments, string(employee.Department)) {
+		return false
+	}
+	search := strings.ToLower(strings.TrimSpace(f.Search))
+	if search == "" {
+		return true
+	}
+	for _, value := range []string{
+		employee.FirstName + " " + employee.LastName, employee.Email, employee.EmployeeID,
+		employee.Position, string(employee.Department),
+	} {
+		if strings.Contains(strings.ToLower(value), search) {
+			return true
+		}
+	}
+	return false
+}
+
+func exportFilterContains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterEmployeesForExport wendet den Filter an und sortiert das Ergebnis
+func FilterEmployeesForExport(employees []*Employee, filter EmployeeExportFilter) []*Employee {
+	result := make([]*Employee, 0, len(employees))
+	for _, employee := range employees {
+		if filter.Matches(employee) {
+			result = append(result, employee)
+		}
+	}
+
+	name := func(e *Employee) string { return strings.ToLower(e.LastName + " " + e.FirstName) }
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		switch filter.SortBy {
+		case "position":
+			if a.Position != b.Position {
+				return a.Position < b.Position
+			}
+		case "department":
+			if a.Department != b.Department {
+				return a.Department < b.Department
+			}
+		case "hireDate":
+			if !a.HireDate.Equal(b.HireDate) {
+				return a.HireDate.Before(b.HireDate)
+			}
+		}
+		return name(a) < name(b)
+	})
+	return result
+}
+
+// EmployeeExportHeader gibt die Spaltenüberschriften zurück
+func EmployeeExportHeader(columns []EmployeeExportColumn) []string {
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.Label
+	}
+	return header
+}
+
+// EmployeeExportValues gibt die Werte eines Mitarbeiters in der Reihenfolge der Spalten zurück
+func EmployeeExportValues(employee *Employee, columns []EmployeeExportColumn) []interface{} {
+	values := make([]interface{}, len(columns))
+	for i, column := range columns {
+		values[i] = column.value(employee)
+	}
+	return values
+}
+
+// EmployeeExportRecord gibt die Werte eines Mitarbeiters für den JSON-Export zurück.
+// Datumswerte werden als YYYY-MM-DD ausgegeben, fehlende Daten als null.
+func EmployeeExportRecord(employee *Employee, columns []EmployeeExportColumn) map[string]interface{} {
+	record := make(map[string]interface{}, len(columns))
+	for _, column := range columns {
+		value := column.value(employee)
+		if date, ok := value.(time.Time); ok {
+			if date.IsZero() {
+				value = nil
+			} else {
+				value = date.Format("2006-01-02")
+			}
+		}
+		record[column.Key] = value
+	}
+	return record
+}
+
+// FormatExportValue gibt einen Exportwert als Text für CSV-Dateien zurück. Zahlen werden mit
+// Dezimalkomma, Datumswerte als TT.MM.JJJJ (mit Uhrzeit, falls vorhanden) geschrieben.
+func FormatExportValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strings.Replace(strconv.FormatFloat(v, 'f', -1, 64), ".", ",", 1)
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		if v.Hour() != 0 || v.Minute() != 0 {
+			return v.Format("02.01.2006 15:04")
+		}
+		return v.Format("02.01.2006")
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,113 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExportFormat(t *testing.T) {
+	supported := []ExportFormat{ExportFormatCSV, ExportFormatXLSX, ExportFormatJSON}
+	for value, want := range map[string]ExportFormat{"": ExportFormatCSV, "CSV": ExportFormatCSV, "xlsx": ExportFormatXLSX, " json ": ExportFormatJSON} {
+		got, err := ParseExportFormat(value, supported...)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+
+	_, err := ParseExportFormat("pdf", supported...)
+	assert.ErrorIs(t, err, ErrInvalidExportFormat)
+
+	got, err := ParseExportFormat("", ExportFormatPDF)
+	require.NoError(t, err)
+	assert.Equal(t, ExportFormatPDF, got)
+}
+
+func columnKeys(columns []EmployeeExportColumn) []string {
+	keys := make([]string, len(columns))
+	for i, column := range columns {
+		keys[i] = column.Key
+	}
+	return keys
+}
+
+func TestParseEmployeeExportColumns(t *testing.T) {
+	columns, err := ParseEmployeeExportColumns("", false)
+	require.NoError(t, err)
+	assert.NotContains(t, columnKeys(columns), "salary", "ohne Gehaltseinsicht keine Finanzdaten")
+	assert.Contains(t, columnKeys(columns), "employeeId")
+
+	columns, err = ParseEmployeeExportColumns("", true)
+	require.NoError(t, err)
+	assert.Len(t, columns, len(EmployeeExportColumns))
+
+	columns, err = ParseEmployeeExportColumns("lastName, firstName,lastName,salary", true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"lastName", "firstName", "salary"}, columnKeys(columns))
+
+	_, err = ParseEmployeeExportColumns("lastName,salary", false)
+	assert.ErrorIs(t, err, ErrEmployeeExportColumns)
+
+	_, err = ParseEmployeeExportColumns("lastName,password", true)
+	assert.ErrorIs(t, err, ErrEmployeeExportColumns)
+}
+
+func TestFilterEmployeesForExport(t *testing.T) {
+	anna := &Employee{FirstName: "Anna", LastName: "Schmidt", Department: "IT", Position: "Entwicklerin", HireDate: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)}
+	ben := &Employee{FirstName: "Ben", LastName: "Meyer", Department: "Sales", Status: EmployeeStatusRemote, Position: "Vertrieb", HireDate: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	clara := &Employee{FirstName: "Clara", LastName: "Adler", Department: "IT", Status: EmployeeStatusInactive, Email: "clara@example.com", HireDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)}
+	employees := []*Employee{anna, ben, clara}
+
+	tests := []struct {
+		name   string
+		filter EmployeeExportFilter
+		want   []*Employee
+	}{
+		{"alle nach Name", EmployeeExportFilter{}, []*Employee{clara, ben, anna}},
+		{"nach Eintritt", EmployeeExportFilter{SortBy: "hireDate"}, []*Employee{ben, clara, anna}},
+		{"Abteilung", EmployeeExportFilter{Departments: []string{"IT"}}, []*Employee{clara, anna}},
+		{"Status ohne Angabe gilt als aktiv", EmployeeExportFilter{Statuses: []string{"active", "remote"}}, []*Employee{ben, anna}},
+		{"Suche in E-Mail", EmployeeExportFilter{Search: "CLARA@"}, []*Employee{clara}},
+		{"Suche im vollen Namen", EmployeeExportFilter{Search: "anna sch"}, []*Employee{anna}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, FilterEmployeesForExport(employees, tt.filter))
+		})
+	}
+}
+
+func TestEmployeeExportValues(t *testing.T) {
+	employee := &Employee{EmployeeID: "1001", LastName: "Schmidt", WorkingHoursPerWeek: 38.5, VacationDays: 30, HireDate: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)}
+	columns, err := ParseEmployeeExportColumns("employeeId,lastName,workingHoursPerWeek,vacationDays,hireDate,dateOfBirth", false)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"Personalnummer", "Nachname", "Wochenstunden", "Urlaubstage", "Eintrittsdatum", "Geburtsdatum"}, EmployeeExportHeader(columns))
+
+	values := EmployeeExportValues(employee, columns)
+	assert.Equal(t, []interface{}{"1001", "Schmidt", 38.5, 30, employee.HireDate, time.Time{}}, values)
+
+	formatted := make([]string, len(values))
+	for i, value := range values {
+		formatted[i] = FormatExportValue(value)
+	}
+	assert.Equal(t, []string{"1001", "Schmidt", "38,5", "30", "01.04.2024", ""}, formatted)
+
+	assert.Equal(t, map[string]interface{}{
+		"employeeId": "1001", "lastName": "Schmidt", "workingHoursPerWeek": 38.5, "vacationDays": 30,
+		"hireDate": "2024-04-01", "dateOfBirth": nil,
+	}, EmployeeExportRecord(employee, columns))
+}
+
+func TestEmployeeExportHeader_ImportRoundTrip(t *testing.T) {
+	for _, column := range EmployeeExportColumns {
+		if _, importable := FindEmployeeImportField(column.Key); !importable {
+			continue
+		}
+		key, ok := DetectEmployeeImportField(column.Label)
+		assert.True(t, ok, column.Label)
+		assert.Equal(t, column.Key, key, "Exportüberschrift %q wird beim Import erkannt", column.Label)
+	}
+}
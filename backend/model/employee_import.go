@@ -0,0 +1,485 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrEmployeeImportFormat wird zurückgegeben, wenn die Importdatei weder CSV noch XLSX ist
+	ErrEmployeeImportFormat = errors.New("unsupported employee import format")
+	// ErrEmployeeImportMapping wird bei einer ungültigen Spaltenzuordnung zurückgegeben
+	ErrEmployeeImportMapping = errors.New("invalid employee import mapping")
+	// ErrEmployeeImportEmpty wird zurückgegeben, wenn die Datei keine Datenzeilen enthält
+	ErrEmployeeImportEmpty = errors.New("employee import contains no data rows")
+)
+
+// EmployeeImportAction beschreibt, was ein Import mit einer Zeile macht
+type EmployeeImportAction string
+
+const (
+	EmployeeImportActionCreate    EmployeeImportAction = "create"
+	EmployeeImportActionUpdate    EmployeeImportAction = "update"
+	EmployeeImportActionUnchanged EmployeeImportAction = "unchanged"
+	EmployeeImportActionError     EmployeeImportAction = "error"
+)
+
+// EmployeeImportField ist ein Mitarbeiterfeld, dem eine Spalte der Importdatei zugeordnet werden kann
+type EmployeeImportField struct {
+	Key       string `json:"key"`
+	Label     string `json:"label"`
+	Financial bool   `json:"financial"` // nur für Benutzer mit Gehaltseinsicht
+
+	aliases []string
+	get     func(e *Employee) string
+	set     func(e *Employee, value string) error
+}
+
+func importStringField(key, label string, target func(e *Employee) *string, aliases ...string) EmployeeImportField {
+	return EmployeeImportField{
+		Key:     key,
+		Label:   label,
+		aliases: aliases,
+		get:     func(e *Employee) string { return *target(e) },
+		set:     func(e *Employee, value string) error { *target(e) = value; return nil },
+	}
+}
+
+func importDateField(key, label string, target func(e *Employee) *time.Time, aliases ...string) EmployeeImportField {
+	return EmployeeImportField{
+		Key:     key,
+		Label:   label,
+		aliases: aliases,
+		get:     func(e *Employee) string { return formatSyncDiffDate(*target(e)) },
+		set: func(e *Employee, value string) error {
+			parsed, err := ParseImportDate(value)
+			if err != nil {
+				return err
+			}
+			*target(e) = parsed
+			return nil
+		},
+	}
+}
+
+func importIntField(key, label string, min, max int, target func(e *Employee) *int, aliases ...string) EmployeeImportField {
+	return EmployeeImportField{
+		Key:     key,
+		Label:   label,
+		aliases: aliases,
+		get:     func(e *Employee) string { return strconv.Itoa(*target(e)) },
+		set: func(e *Employee, value string) error {
+			number, err := ParseImportNumber(value)
+			if err != nil {
+				return err
+			}
+			if number != math.Trunc(number) || number < float64(min) || number > float64(max) {
+				return fmt.Errorf("muss eine ganze Zahl zwischen %d und %d sein", min, max)
+			}
+			*target(e) = int(number)
+			return nil
+		},
+	}
+}
+
+// EmployeeImportFields sind die importierbaren Felder in der Reihenfolge der Vorlage
+var EmployeeImportFields = []EmployeeImportField{
+	importStringField("employeeId", "Personalnummer", func(e *Employee) *string { return &e.EmployeeID },
+		"personalnummer", "personalnr", "pnr", "mitarbeiternummer", "mitarbeiterid", "employeeid", "employeenumber"),
+	importStringField("firstName", "Vorname", func(e *Employee) *string { return &e.FirstName },
+		"vorname", "firstname", "givenname"),
+	importStringField("lastName", "Nachname", func(e *Employee) *string { return &e.LastName },
+		"nachname", "familienname", "lastname", "surname"),
+	{
+		Key:     "email",
+		Label:   "E-Mail",
+		aliases: []string{"email", "emailadresse", "mail", "emailaddress"},
+		get:     func(e *Employee) string { return e.Email },
+		set: func(e *Employee, value string) error {
+			if !strings.Contains(value, "@") {
+				return errors.New("ist keine gültige E-Mail-Adresse")
+			}
+			// Groß- und Kleinschreibung gilt nicht als Änderung
+			if !strings.EqualFold(e.Email, value) {
+				e.Email = value
+			}
+			return nil
+		},
+	},
+	importStringField("phone", "Telefon", func(e *Employee) *string { return &e.Phone },
+		"telefon", "telefonnummer", "mobil", "phone", "phonenumber"),
+	importStringField("internalPhone", "Interne Telefonnummer", func(e *Employee) *string { return &e.InternalPhone },
+		"internetelefonnummer", "internestelefon", "internalphone"),
+	importStringField("internalExtension", "Durchwahl", func(e *Employee) *string { return &e.InternalExtension },
+		"durchwahl", "extension", "internalextension"),
+	importStringField("address", "Adresse", func(e *Employee) *string { return &e.Address },
+		"adresse", "anschrift", "address"),
+	importDateField("dateOfBirth", "Geburtsdatum", func(e *Employee) *time.Time { return &e.DateOfBirth },
+		"geburtsdatum", "geburtstag", "dateofbirth", "birthdate", "birthday"),
+	importDateField("hireDate", "Eintrittsdatum", func(e *Employee) *time.Time { return &e.HireDate },
+		"eintrittsdatum", "eintritt", "einstellungsdatum", "hiredate", "startdate"),
+	importStringField("position", "Position", func(e *Employee) *string { return &e.Position },
+		"position", "stelle", "jobtitel", "jobtitle", "title"),
+	{
+		Key:     "department",
+		Label:   "Abteilung",
+		aliases: []string{"abteilung", "department", "team"},
+		get:     func(e *Employee) string { return string(e.Department) },
+		set:     func(e *Employee, value string) error { e.Department = Department(value); return nil },
+	},
+	importStringField("location", "Standort", func(e *Employee) *string { return &e.Location },
+		"standort", "arbeitsort", "niederlassung", "location", "office"),
+	{
+		Key:     "status",
+		Label:   "Status",
+		aliases: []string{"status"},
+		get:     func(e *Employee) string { return string(e.Status) },
+		set: func(e *Employee, value string) error {
+			status, err := ParseImportEmployeeStatus(value)
+			if err != nil {
+				return err
+			}
+			e.Status = status
+			return nil
+		},
+	},
+	{
+		Key:     "workingHoursPerWeek",
+		Label:   "Wochenstunden",
+		aliases: []string{"wochenstunden", "stundenprowoche", "wochenarbeitszeit", "workinghoursperweek", "hoursperweek"},
+		get:     func(e *Employee) string { return strconv.FormatFloat(e.WorkingHoursPerWeek, 'f', -1, 64) },
+		set: func(e *Employee, value string) error {
+			hours, err := ParseImportNumber(value)
+			if err != nil {
+				return err
+			}
+			e.WorkingHoursPerWeek = hours
+			return nil
+		},
+	},
+	importIntField("workingDaysPerWeek", "Arbeitstage pro Woche", 0, 7, func(e *Employee) *int { return &e.WorkingDaysPerWeek },
+		"arbeitstage", "arbeitstageprowoche", "workingdaysperweek", "daysperweek"),
+	{
+		Key:     "workTimeModel",
+		Label:   "Arbeitszeitmodell",
+		aliases: []string{"arbeitszeitmodell", "worktimemodel"},
+		get:     func(e *Employee) string { return string(e.WorkTimeModel) },
+		set: func(e *Employee, value string) error {
+			workTimeModel, err := ParseImportWorkTimeModel(value)
+			if err != nil {
+				return err
+			}
+			e.WorkTimeModel = workTimeModel
+			return nil
+		},
+	},
+	importIntField("vacationDays", "Urlaubstage", 0, 365, func(e *Employee) *int { return &e.VacationDays },
+		"urlaubstage", "urlaubsanspruch", "vacationdays"),
+	importIntField("remainingVacation", "Resturlaub", 0, 365, func(e *Employee) *int { return &e.RemainingVacation },
+		"resturlaub", "remainingvacation"),
+	importStringField("emergencyName", "Notfallkontakt", func(e *Employee) *string { return &e.EmergencyName },
+		"notfallkontakt", "emergencyname", "emergencycontact"),
+	importStringField("emergencyPhone", "Notfalltelefon", func(e *Employee) *string { return &e.EmergencyPhone },
+		"notfalltelefon", "notfallnummer", "emergencyphone"),
+	importStringField("notes", "Notizen", func(e *Employee) *string { return &e.Notes },
+		"notizen", "bemerkungen", "notes"),
+	{
+		Key:       "salary",
+		Label:     "Gehalt",
+		Financial: true,
+		aliases:   []string{"gehalt", "bruttogehalt", "salary"},
+		get:       func(e *Employee) string { return strconv.FormatFloat(e.Salary, 'f', -1, 64) },
+		set: func(e *Employee, value string) error {
+			salary, err := ParseImportNumber(value)
+			if err != nil {
+				return err
+			}
+			if salary < 0 {
+				return errors.New("darf nicht negativ sein")
+			}
+			e.Salary = salary
+			return nil
+		},
+	},
+	financialImportField(importStringField("bankAccount", "IBAN", func(e *Employee) *string { return &e.BankAccount },
+		"iban", "bankverbindung", "bankaccount")),
+	financialImportField(importStringField("taxId", "Steuer-ID", func(e *Employee) *string { return &e.TaxID },
+		"steuerid", "steueridentifikationsnummer", "taxid")),
+	financialImportField(importStringField("socialSecId", "Sozialversicherungsnummer", func(e *Employee) *string { return &e.SocialSecID },
+		"sozialversicherungsnummer", "svnummer", "socialsecid", "socialsecuritynumber")),
+	financialImportField(importStringField("healthInsurance", "Krankenkasse", func(e *Employee) *string { return &e.HealthInsurance },
+		"krankenkasse", "krankenversicherung", "healthinsurance")),
+}
+
+func financialImportField(field EmployeeImportField) EmployeeImportField {
+	field.Financial = true
+	return field
+}
+
+// FindEmployeeImportField sucht ein importierbares Feld anhand seines Schlüssels
+func FindEmployeeImportField(key string) (EmployeeImportField, bool) {
+	for _, field := range EmployeeImportFields {
+		if field.Key == key {
+			return field, true
+		}
+	}
+	return EmployeeImportField{}, false
+}
+
+// normalizeImportHeader vereinheitlicht Spaltenüberschriften für die automatische Zuordnung
+func normalizeImportHeader(header string) string {
+	replacer := strings.NewReplacer(" ", "", "-", "", "_", "", ".", "", "/", "", "ä", "ae", "ö", "oe", "ü", "ue", "ß", "ss")
+	return replacer.Replace(strings.ToLower(strings.TrimSpace(header)))
+}
+
+// DetectEmployeeImportField ordnet eine Spaltenüberschrift anhand bekannter Bezeichnungen einem Feld zu
+func DetectEmployeeImportField(header string) (string, bool) {
+	normalized := normalizeImportHeader(header)
+	if normalized == "" {
+		return "", false
+	}
+	for _, field := range EmployeeImportFields {
+		if normalized == normalizeImportHeader(field.Key) || normalized == normalizeImportHeader(field.Label) {
+			return field.Key, true
+		}
+		for _, alias := range field.aliases {
+			if normalized == alias {
+				return field.Key, true
+			}
+		}
+	}
+	return "", false
+}
+
+// ParseEmployeeImportMapping liest eine Spaltenzuordnung im Format "Spalte=feld", getrennt durch
+// Zeilenumbrüche oder Semikolons. Ein leeres Feld oder "-" ignoriert die Spalte.
+func ParseEmployeeImportMapping(value string) (map[string]string, error) {
+	mapping := map[string]string{}
+	entries := strings.FieldsFunc(value, func(r rune) bool { return r == '\n' || r == ';' })
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		index := strings.LastIndex(entry, "=")
+		if index <= 0 {
+			return nil, fmt.Errorf("%w: %q muss das Format Spalte=feld haben", ErrEmployeeImportMapping, entry)
+		}
+		header := strings.TrimSpace(entry[:index])
+		key := strings.TrimSpace(entry[index+1:])
+		if key == "-" {
+			key = ""
+		}
+		if key != "" {
+			if _, ok := FindEmployeeImportField(key); !ok {
+				return nil, fmt.Errorf("%w: unbekanntes Feld %q", ErrEmployeeImportMapping, key)
+			}
+		}
+		mapping[header] = key
+	}
+	return mapping, nil
+}
+
+// EmployeeImportRow ist eine Datenzeile mit den nicht leeren Werten je Feld
+type EmployeeImportRow struct {
+	Line   int               // Zeilennummer in der Datei (Kopfzeile = 1)
+	Values map[string]string // Feldschlüssel → Wert
+}
+
+// EmployeeImportTable ist der Inhalt einer Importdatei nach Anwendung der Spaltenzuordnung
+type EmployeeImportTable struct {
+	Headers []string
+	Columns map[string]string // Spaltenüberschrift → Feldschlüssel (nur zugeordnete Spalten)
+	Rows    []EmployeeImportRow
+}
+
+// NewEmployeeImportTable ordnet die Spalten der Importdatei den Feldern zu. Die erste Zeile enthält
+// die Überschriften; Spalten ohne Eintrag in mapping werden automatisch erkannt. Mindestens
+// Personalnummer oder E-Mail muss zugeordnet sein, damit bestehende Mitarbeiter gefunden werden.
+func NewEmployeeImportTable(records [][]string, mapping map[string]string) (*EmployeeImportTable, error) {
+	if len(records) == 0 {
+		return nil, ErrEmployeeImportEmpty
+	}
+
+	table := &EmployeeImportTable{Columns: map[string]string{}}
+	columnFields := make([]string, len(records[0]))
+	mappedBy := map[string]string{}
+	used := map[string]bool{}
+
+	for i, header := range records[0] {
+		header = strings.TrimSpace(header)
+		table.Headers = append(table.Headers, header)
+
+		key, explicit := mapping[header]
+		if explicit {
+			used[header] = true
+		} else {
+			key, _ = DetectEmployeeImportField(header)
+		}
+		if key == "" {
+			continue
+		}
+		if previous, ok := mappedBy[key]; ok {
+			return nil, fmt.Errorf("%w: die Spalten %q und %q sind beide dem Feld %q zugeordnet", ErrEmployeeImportMapping, previous, header, key)
+		}
+		mappedBy[key] = header
+		columnFields[i] = key
+		table.Columns[header] = key
+	}
+
+	for header := range mapping {
+		if !used[header] {
+			return nil, fmt.Errorf("%w: die Spalte %q ist nicht in der Datei enthalten", ErrEmployeeImportMapping, header)
+		}
+	}
+	if mappedBy["employeeId"] == "" && mappedBy["email"] == "" {
+		return nil, fmt.Errorf("%w: Personalnummer oder E-Mail muss zugeordnet sein", ErrEmployeeImportMapping)
+	}
+
+	for i, record := range records[1:] {
+		row := EmployeeImportRow{Line: i + 2, Values: map[string]string{}}
+		for column, value := range record {
+			value = strings.TrimSpace(value)
+			if column < len(columnFields) && columnFields[column] != "" && value != "" {
+				row.Values[columnFields[column]] = value
+			}
+		}
+		if len(row.Values) > 0 {
+			table.Rows = append(table.Rows, row)
+		}
+	}
+	if len(table.Rows) == 0 {
+		return nil, ErrEmployeeImportEmpty
+	}
+	return table, nil
+}
+
+// ApplyEmployeeImportRow überträgt die Werte einer Zeile auf den Mitarbeiter. Leere Zellen lassen
+// bestehende Werte unverändert. Zurückgegeben werden die Schlüssel der geänderten Felder und
+// die Fehler einzelner Felder; Finanzdaten werden ohne allowFinancial abgelehnt.
+func ApplyEmployeeImportRow(employee *Employee, row EmployeeImportRow, allowFinancial bool) (changes []string, errs []string) {
+	for _, field := range EmployeeImportFields {
+		value, ok := row.Values[field.Key]
+		if !ok {
+			continue
+		}
+		if field.Financial && !allowFinancial {
+			errs = append(errs, field.Label+": Finanzdaten dürfen nur von Admins und Managern importiert werden")
+			continue
+		}
+		before := field.get(employee)
+		if err := field.set(employee, value); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", field.Label, err))
+			continue
+		}
+		if field.get(employee) != before {
+			changes = append(changes, field.Key)
+		}
+	}
+	return changes, errs
+}
+
+// excelEpoch ist der Bezugstag der Excel-Seriennummern (unter Berücksichtigung des 29.02.1900)
+var excelEpoch = time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)
+
+// ParseImportDate liest ein Datum im Format YYYY-MM-DD, TT.MM.JJJJ oder als Excel-Seriennummer
+func ParseImportDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range []string{"2006-01-02", "02.01.2006", "2.1.2006", "2006-01-02T15:04:05Z07:00"} {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return time.Date(parsed.Year(), parsed.Month(), parsed.Day(), 0, 0, 0, 0, time.UTC), nil
+		}
+	}
+	if serial, err := strconv.ParseFloat(value, 64); err == nil && serial >= 1 && serial < 2958466 {
+		return excelEpoch.AddDate(0, 0, int(serial)), nil
+	}
+	return time.Time{}, fmt.Errorf("%q ist kein Datum (JJJJ-MM-TT oder TT.MM.JJJJ)", value)
+}
+
+// ParseImportNumber liest eine Zahl mit Dezimalpunkt oder deutschem Dezimalkomma ("1.234,50")
+func ParseImportNumber(value string) (float64, error) {
+	normalized := strings.ReplaceAll(strings.TrimSpace(value), " ", "")
+	if strings.Contains(normalized, ",") {
+		normalized = strings.ReplaceAll(normalized, ".", "")
+		normalized = strings.ReplaceAll(normalized, ",", ".")
+	}
+	number, err := strconv.ParseFloat(normalized, 64)
+	if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+		return 0, fmt.Errorf("%q ist keine Zahl", value)
+	}
+	return number, nil
+}
+
+// ParseImportEmployeeStatus akzeptiert die Statuswerte und ihre deutschen Bezeichnungen
+func ParseImportEmployeeStatus(value string) (EmployeeStatus, error) {
+	switch normalizeImportHeader(value) {
+	case "active", "aktiv":
+		return EmployeeStatusActive, nil
+	case "inactive", "inaktiv", "ausgeschieden":
+		return EmployeeStatusInactive, nil
+	case "onleave", "beurlaubt", "imurlaub", "abwesend":
+		return EmployeeStatusOnLeave, nil
+	case "remote", "homeoffice":
+		return EmployeeStatusRemote, nil
+	}
+	return "", fmt.Errorf("%q ist kein gültiger Status (active, inactive, onleave, remote)", value)
+}
+
+// ParseImportWorkTimeModel akzeptiert die Arbeitszeitmodelle und ihre deutschen Bezeichnungen
+func ParseImportWorkTimeModel(value string) (WorkTimeModel, error) {
+	normalized := normalizeImportHeader(value)
+	for _, workTimeModel := range []WorkTimeModel{
+		WorkTimeModelFullTime, WorkTimeModelPartTime, WorkTimeModelFlexTime, WorkTimeModelRemote,
+		WorkTimeModelShift, WorkTimeModelContract, WorkTimeModelInternship,
+	} {
+		if normalized == string(workTimeModel) || normalized == normalizeImportHeader(workTimeModel.GetDisplayName()) {
+			return workTimeModel, nil
+		}
+	}
+	if normalized == "homeoffice" {
+		return WorkTimeModelRemote, nil
+	}
+	return "", fmt.Errorf("%q ist kein gültiges Arbeitszeitmodell", value)
+}
+
+// EmployeeImportRowResult ist das Ergebnis einer Zeile des Imports
+type EmployeeImportRowResult struct {
+	Row        int                  `json:"row"`
+	Action     EmployeeImportAction `json:"action"`
+	ID         string               `json:"id,omitempty"` // Datenbank-ID des Mitarbeiters, falls vorhanden
+	EmployeeID string               `json:"employeeId"`
+	Name       string               `json:"name"`
+	Changes    []string             `json:"changes,omitempty"`
+	Errors     []string             `json:"errors,omitempty"`
+}
+
+// EmployeeImportReport fasst einen Import oder Probelauf zusammen
+type EmployeeImportReport struct {
+	DryRun    bool                      `json:"dryRun"`
+	Headers   []string                  `json:"headers"`
+	Columns   map[string]string         `json:"columns"`
+	Rows      []EmployeeImportRowResult `json:"rows"`
+	Created   int                       `json:"created"`
+	Updated   int                       `json:"updated"`
+	Unchanged int                       `json:"unchanged"`
+	Failed    int                       `json:"failed"`
+}
+
+// Add übernimmt ein Zeilenergebnis und zählt es in der Zusammenfassung
+func (r *EmployeeImportReport) Add(result EmployeeImportRowResult) {
+	r.Rows = append(r.Rows, result)
+	switch result.Action {
+	case EmployeeImportActionCreate:
+		r.Created++
+	case EmployeeImportActionUpdate:
+		r.Updated++
+	case EmployeeImportActionUnchanged:
+		r.Unchanged++
+	case EmployeeImportActionError:
+		r.Failed++
+	}
+}
@@ -0,0 +1,173 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectEmployeeImportField(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"Personalnummer", "employeeId"},
+		{"employee_id", "employeeId"},
+		{"Vorname", "firstName"},
+		{"E-Mail-Adresse", "email"},
+		{" Eintrittsdatum ", "hireDate"},
+		{"Arbeitszeitmodell", "workTimeModel"},
+		{"Gehalt", "salary"},
+		{"Lieblingsfarbe", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			got, ok := DetectEmployeeImportField(tt.header)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.want != "", ok)
+		})
+	}
+}
+
+func TestParseEmployeeImportMapping(t *testing.T) {
+	mapping, err := ParseEmployeeImportMapping("PNR=employeeId\nRufname = firstName; Kommentar=-")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"PNR": "employeeId", "Rufname": "firstName", "Kommentar": ""}, mapping)
+
+	_, err = ParseEmployeeImportMapping("PNR=personalnummer")
+	assert.ErrorIs(t, err, ErrEmployeeImportMapping)
+
+	_, err = ParseEmployeeImportMapping("PNR")
+	assert.ErrorIs(t, err, ErrEmployeeImportMapping)
+}
+
+func TestNewEmployeeImportTable(t *testing.T) {
+	records := [][]string{
+		{"PNR", "Vorname", "Nachname", "E-Mail", "Kommentar"},
+		{"1001", " Anna ", "Schmidt", "anna@example.com", "egal"},
+		{"", "", "", "", ""},
+		{"1002", "Ben", "", "", ""},
+	}
+
+	table, err := NewEmployeeImportTable(records, map[string]string{"PNR": "employeeId"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"PNR", "Vorname", "Nachname", "E-Mail", "Kommentar"}, table.Headers)
+	assert.Equal(t, map[string]string{"PNR": "employeeId", "Vorname": "firstName", "Nachname": "lastName", "E-Mail": "email"}, table.Columns)
+	require.Len(t, table.Rows, 2, "leere Zeilen werden übersprungen")
+	assert.Equal(t, EmployeeImportRow{Line: 2, Values: map[string]string{
+		"employeeId": "1001", "firstName": "Anna", "lastName": "Schmidt", "email": "anna@example.com",
+	}}, table.Rows[0])
+	assert.Equal(t, EmployeeImportRow{Line: 4, Values: map[string]string{"employeeId": "1002", "firstName": "Ben"}}, table.Rows[1])
+}
+
+func TestNewEmployeeImportTable_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		records [][]string
+		mapping map[string]string
+		wantErr error
+	}{
+		{"leere Datei", nil, nil, ErrEmployeeImportEmpty},
+		{"nur Kopfzeile", [][]string{{"Personalnummer"}}, nil, ErrEmployeeImportEmpty},
+		{"ohne Schlüsselspalte", [][]string{{"Vorname"}, {"Anna"}}, nil, ErrEmployeeImportMapping},
+		{"Feld doppelt", [][]string{{"Personalnummer", "PNR"}, {"1", "2"}}, map[string]string{"PNR": "employeeId"}, ErrEmployeeImportMapping},
+		{"unbekannte Spalte", [][]string{{"Personalnummer"}, {"1"}}, map[string]string{"Nummer": "employeeId"}, ErrEmployeeImportMapping},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewEmployeeImportTable(tt.records, tt.mapping)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestApplyEmployeeImportRow(t *testing.T) {
+	employee := &Employee{EmployeeID: "1001", FirstName: "Anna", LastName: "Schmidt", Salary: 4000}
+	row := EmployeeImportRow{Line: 2, Values: map[string]string{
+		"employeeId":          "1001",
+		"firstName":           "Anna",
+		"hireDate":            "01.04.2024",
+		"workingHoursPerWeek": "38,5",
+		"workTimeModel":       "Teilzeit",
+		"status":              "aktiv",
+		"vacationDays":        "30",
+	}}
+
+	changes, errs := ApplyEmployeeImportRow(employee, row, false)
+
+	assert.Empty(t, errs)
+	assert.Equal(t, []string{"hireDate", "status", "workingHoursPerWeek", "workTimeModel", "vacationDays"}, changes)
+	assert.Equal(t, time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC), employee.HireDate)
+	assert.Equal(t, 38.5, employee.WorkingHoursPerWeek)
+	assert.Equal(t, WorkTimeModelPartTime, employee.WorkTimeModel)
+	assert.Equal(t, EmployeeStatusActive, employee.Status)
+	assert.Equal(t, 30, employee.VacationDays)
+}
+
+func TestApplyEmployeeImportRow_Errors(t *testing.T) {
+	employee := &Employee{Salary: 4000}
+	row := EmployeeImportRow{Line: 3, Values: map[string]string{
+		"email":              "keine-adresse",
+		"workingDaysPerWeek": "8",
+		"status":             "verschollen",
+		"salary":             "5000",
+	}}
+
+	changes, errs := ApplyEmployeeImportRow(employee, row, false)
+
+	assert.Empty(t, changes)
+	assert.Len(t, errs, 4)
+	assert.Contains(t, errs, "Gehalt: Finanzdaten dürfen nur von Admins und Managern importiert werden")
+	assert.Equal(t, 4000.0, employee.Salary)
+
+	changes, errs = ApplyEmployeeImportRow(employee, EmployeeImportRow{Values: map[string]string{"salary": "5.250,50"}}, true)
+	assert.Empty(t, errs)
+	assert.Equal(t, []string{"salary"}, changes)
+	assert.Equal(t, 5250.5, employee.Salary)
+}
+
+func TestParseImportDate(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"2024-04-01", time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC), false},
+		{"1.4.2024", time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC), false},
+		{"45383", time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC), false},
+		{"April 2024", time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseImportDate(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEmployeeImportReport_Add(t *testing.T) {
+	report := &EmployeeImportReport{}
+	for _, action := range []EmployeeImportAction{
+		EmployeeImportActionCreate, EmployeeImportActionCreate, EmployeeImportActionUpdate,
+		EmployeeImportActionUnchanged, EmployeeImportActionError,
+	} {
+		report.Add(EmployeeImportRowResult{Action: action})
+	}
+
+	assert.Len(t, report.Rows, 5)
+	assert.Equal(t, 2, report.Created)
+	assert.Equal(t, 1, report.Updated)
+	assert.Equal(t, 1, report.Unchanged)
+	assert.Equal(t, 1, report.Failed)
+}
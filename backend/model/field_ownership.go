@@ -0,0 +1,274 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrInvalidFieldOwnership wird bei einer unbekannten Zuständigkeit zurückgegeben
+var ErrInvalidFieldOwnership = errors.New("invalid field ownership")
+
+// FieldOwnership legt fest, welches System bei einem synchronisierten Feld Vorrang hat
+type FieldOwnership string
+
+const (
+	FieldOwnershipPeopleFlow  FieldOwnership = "peopleflow"    // PeopleFlow hat Vorrang, die Synchronisierung schreibt nie
+	FieldOwnershipExternal    FieldOwnership = "external"      // das angebundene System hat Vorrang
+	FieldOwnershipFillIfEmpty FieldOwnership = "fill_if_empty" // nur leere Felder werden gefüllt
+)
+
+// FieldSourcePeopleFlow ist die Quelle von Änderungen in der Weboberfläche oder über die API
+const FieldSourcePeopleFlow = "peopleflow"
+
+// ParseFieldOwnership prüft eine Zuständigkeit aus einer Anfrage
+func ParseFieldOwnership(value string) (FieldOwnership, error) {
+	ownership := FieldOwnership(strings.ToLower(strings.TrimSpace(value)))
+	switch ownership {
+	case FieldOwnershipPeopleFlow, FieldOwnershipExternal, FieldOwnershipFillIfEmpty:
+		return ownership, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrInvalidFieldOwnership, value)
+}
+
+// Label gibt die deutsche Bezeichnung der Zuständigkeit zurück
+func (o FieldOwnership) Label() string {
+	switch o {
+	case FieldOwnershipPeopleFlow:
+		return "PeopleFlow hat Vorrang"
+	case FieldOwnershipExternal:
+		return "Externes System hat Vorrang"
+	case FieldOwnershipFillIfEmpty:
+		return "Nur leere Felder füllen"
+	default:
+		return string(o)
+	}
+}
+
+// SyncedField ist ein Mitarbeiterfeld, das eine Integration schreibt
+type SyncedField struct {
+	Name    string         `json:"name"` // JSON-Name des Feldes, z.B. "phone"
+	Label   string         `json:"label"`
+	Default FieldOwnership `json:"default"`
+}
+
+// FieldSource hält fest, welche Quelle ein Feld zuletzt geschrieben hat
+type FieldSource struct {
+	Source    string    `bson:"source" json:"source"` // FieldSourcePeopleFlow oder Typ der Integration
+	UpdatedAt time.Time `bson:"updatedAt" json:"updatedAt"`
+}
+
+// FieldConflict ist ein Feld, das seit der letzten Synchronisierung sowohl in PeopleFlow als
+// auch im angebundenen System geändert wurde
+type FieldConflict struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Integration   string             `bson:"integration" json:"integration"`
+	EmployeeID    primitive.ObjectID `bson:"employeeId" json:"employeeId"`
+	EmployeeName  string             `bson:"employeeName" json:"employeeName"`
+	Field         string             `bson:"field" json:"field"`
+	Label         string             `bson:"label" json:"label"`
+	BaseValue     string             `bson:"baseValue" json:"baseValue"`         // Wert der letzten Synchronisierung
+	LocalValue    string             `bson:"localValue" json:"localValue"`       // Wert in PeopleFlow
+	ExternalValue string             `bson:"externalValue" json:"externalValue"` // neuer Wert im angebundenen System
+	Ownership     FieldOwnership     `bson:"ownership" json:"ownership"`         // angewandte Zuständigkeit
+	Applied       bool               `bson:"applied" json:"applied"`             // externer Wert wurde übernommen
+	DetectedAt    time.Time          `bson:"detectedAt" json:"detectedAt"`
+
+	ResolvedAt     *time.Time         `bson:"resolvedAt,omitempty" json:"resolvedAt,omitempty"`
+	Resolution     FieldOwnership     `bson:"resolution,omitempty" json:"resolution,omitempty"`
+	ResolvedBy     primitive.ObjectID `bson:"resolvedBy,omitempty" json:"resolvedBy,omitempty"`
+	ResolvedByName string             `bson:"resolvedByName,omitempty" json:"resolvedByName,omitempty"`
+}
+
+// FieldSyncResult beschreibt, was eine Synchronisierung mit einem Feld gemacht hat
+type FieldSyncResult struct {
+	Updated         bool           // der Feldwert wurde geändert
+	BaselineChanged bool           // der zuletzt synchronisierte Wert wurde aktualisiert
+	Conflict        *FieldConflict // beide Seiten haben das Feld geändert
+}
+
+// Changed prüft, ob der Mitarbeiter gespeichert werden muss
+func (r FieldSyncResult) Changed() bool {
+	return r.Updated || r.BaselineChanged
+}
+
+// syncedFieldAccessor liest und schreibt ein synchronisiertes Feld als Text
+type syncedFieldAccessor struct {
+	get   func(e *Employee) string
+	set   func(e *Employee, value string) error
+	empty string // Wert eines leeren Feldes
+}
+
+// syncedFieldAccessors sind die Felder, deren Zuständigkeit konfiguriert werden kann
+var syncedFieldAccessors = map[string]syncedFieldAccessor{
+	"phone": {
+		get: func(e *Employee) string { return e.Phone },
+		set: func(e *Employee, value string) error { e.Phone = value; return nil },
+	},
+	"position": {
+		get: func(e *Employee) string { return e.Position },
+		set: func(e *Employee, value string) error { e.Position = value; return nil },
+	},
+	"department": {
+		get: func(e *Employee) string { return string(e.Department) },
+		set: func(e *Employee, value string) error { e.Department = Department(value); return nil },
+	},
+	"hireDate": {
+		get: func(e *Employee) string { return formatSyncDiffDate(e.HireDate) },
+		set: func(e *Employee, value string) error { return setSyncedDate(&e.HireDate, value) },
+	},
+	"dateOfBirth": {
+		get: func(e *Employee) string { return formatSyncDiffDate(e.DateOfBirth) },
+		set: func(e *Employee, value string) error { return setSyncedDate(&e.DateOfBirth, value) },
+	},
+	"vacationDays": {
+		get:   func(e *Employee) string { return strconv.Itoa(e.VacationDays) },
+		set:   func(e *Employee, value string) error { return setSyncedInt(&e.VacationDays, value) },
+		empty: "0",
+	},
+	"remainingVacation": {
+		get:   func(e *Employee) string { return strconv.Itoa(e.RemainingVacation) },
+		set:   func(e *Employee, value string) error { return setSyncedInt(&e.RemainingVacation, value) },
+		empty: "0",
+	},
+}
+
+// IsTrackedField prüft, ob für ein Feld Zuständigkeiten und Herkunft geführt werden
+func IsTrackedField(name string) bool {
+	_, ok := syncedFieldAccessors[name]
+	return ok
+}
+
+// SyncedFieldValue gibt den Wert eines synchronisierten Feldes als Text zurück
+func (e *Employee) SyncedFieldValue(name string) (string, error) {
+	accessor, ok := syncedFieldAccessors[name]
+	if !ok {
+		return "", fmt.Errorf("unknown synced field %q", name)
+	}
+	return accessor.get(e), nil
+}
+
+// SetSyncedFieldValue setzt ein synchronisiertes Feld und vermerkt die Quelle
+func (e *Employee) SetSyncedFieldValue(name, value, source string, now time.Time) error {
+	accessor, ok := syncedFieldAccessors[name]
+	if !ok {
+		return fmt.Errorf("unknown synced field %q", name)
+	}
+	if err := accessor.set(e, value); err != nil {
+		return err
+	}
+	e.SetFieldSource(name, source, now)
+	return nil
+}
+
+// SetFieldSource vermerkt, welche Quelle ein Feld zuletzt geschrieben hat
+func (e *Employee) SetFieldSource(name, source string, now time.Time) {
+	if e.FieldSources == nil {
+		e.FieldSources = make(map[string]FieldSource)
+	}
+	e.FieldSources[name] = FieldSource{Source: source, UpdatedAt: now}
+}
+
+// TrackFieldChanges vermerkt source als Quelle aller synchronisierten Felder, die sich
+// gegenüber before geändert haben
+func (e *Employee) TrackFieldChanges(before *Employee, source string, now time.Time) {
+	for name, accessor := range syncedFieldAccessors {
+		if accessor.get(before) != accessor.get(e) {
+			e.SetFieldSource(name, source, now)
+		}
+	}
+}
+
+// ApplySyncedValue übernimmt den Wert eines Feldes aus einer Integration gemäß der Zuständigkeit.
+// Ein Konflikt liegt vor, wenn sich seit der letzten Synchronisierung sowohl der Wert in PeopleFlow
+// als auch der im angebundenen System geändert hat; er wird unabhängig von der Zuständigkeit
+// gemeldet. Leere externe Werte werden ignoriert.
+func (e *Employee) ApplySyncedValue(integration string, field SyncedField, ownership FieldOwnership, external string, now time.Time) (FieldSyncResult, error) {
+	var result FieldSyncResult
+	accessor, ok := syncedFieldAccessors[field.Name]
+	if !ok {
+		return result, fmt.Errorf("unknown synced field %q", field.Name)
+	}
+	if external == "" {
+		return result, nil
+	}
+
+	local := accessor.get(e)
+	base, hasBase := e.SyncedValues[integration][field.Name]
+
+	if hasBase && local != base && external != base && local != external {
+		result.Conflict = &FieldConflict{
+			Integration:   integration,
+			EmployeeID:    e.ID,
+			EmployeeName:  e.FirstName + " " + e.LastName,
+			Field:         field.Name,
+			Label:         field.Label,
+			BaseValue:     base,
+			LocalValue:    local,
+			ExternalValue: external,
+			Ownership:     ownership,
+			DetectedAt:    now,
+		}
+	}
+
+	apply := false
+	switch ownership {
+	case FieldOwnershipExternal:
+		apply = local != external
+	case FieldOwnershipFillIfEmpty:
+		apply = local == accessor.empty
+	}
+	if apply {
+		if err := accessor.set(e, external); err != nil {
+			return result, err
+		}
+		e.SetFieldSource(field.Name, integration, now)
+		result.Updated = true
+		if result.Conflict != nil {
+			result.Conflict.Applied = true
+		}
+	}
+
+	if !hasBase || base != external {
+		if e.SyncedValues == nil {
+			e.SyncedValues = make(map[string]map[string]string)
+		}
+		if e.SyncedValues[integration] == nil {
+			e.SyncedValues[integration] = make(map[string]string)
+		}
+		e.SyncedValues[integration][field.Name] = external
+		result.BaselineChanged = true
+	}
+	return result, nil
+}
+
+// FormatSyncedDate gibt ein Datum im Format der synchronisierten Werte zurück (leer bei Nullwert)
+func FormatSyncedDate(t time.Time) string {
+	return formatSyncDiffDate(t)
+}
+
+func setSyncedDate(target *time.Time, value string) error {
+	if value == "" {
+		*target = time.Time{}
+		return nil
+	}
+	parsed, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return fmt.Errorf("invalid date %q: %w", value, err)
+	}
+	*target = parsed
+	return nil
+}
+
+func setSyncedInt(target *int, value string) error {
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("invalid number %q: %w", value, err)
+	}
+	*target = parsed
+	return nil
+}
@@ -0,0 +1,122 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestParseFieldOwnership(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    FieldOwnership
+		wantErr bool
+	}{
+		{"peopleflow", FieldOwnershipPeopleFlow, false},
+		{"External", FieldOwnershipExternal, false},
+		{" fill_if_empty ", FieldOwnershipFillIfEmpty, false},
+		{"newest", "", true},
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseFieldOwnership(tt.value)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidFieldOwnership)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEmployee_ApplySyncedValue(t *testing.T) {
+	now := time.Date(2025, 5, 1, 8, 0, 0, 0, time.UTC)
+	phone := SyncedField{Name: "phone", Label: "Telefon"}
+
+	tests := []struct {
+		name         string
+		local        string
+		base         string // leer: noch nie synchronisiert
+		external     string
+		ownership    FieldOwnership
+		wantPhone    string
+		wantUpdated  bool
+		wantConflict bool
+	}{
+		{"externes System überschreibt", "030-1", "", "030-2", FieldOwnershipExternal, "030-2", true, false},
+		{"PeopleFlow behält seinen Wert", "030-1", "", "030-2", FieldOwnershipPeopleFlow, "030-1", false, false},
+		{"leeres Feld wird gefüllt", "", "", "030-2", FieldOwnershipFillIfEmpty, "030-2", true, false},
+		{"gefülltes Feld bleibt", "030-1", "", "030-2", FieldOwnershipFillIfEmpty, "030-1", false, false},
+		{"leerer externer Wert wird ignoriert", "030-1", "", "", FieldOwnershipExternal, "030-1", false, false},
+		{"nur extern geändert", "030-1", "030-1", "030-2", FieldOwnershipPeopleFlow, "030-1", false, false},
+		{"nur in PeopleFlow geändert", "030-9", "030-1", "030-1", FieldOwnershipExternal, "030-1", true, false},
+		{"beide geändert, extern hat Vorrang", "030-9", "030-1", "030-2", FieldOwnershipExternal, "030-2", true, true},
+		{"beide geändert, PeopleFlow hat Vorrang", "030-9", "030-1", "030-2", FieldOwnershipPeopleFlow, "030-9", false, true},
+		{"beide gleich geändert", "030-2", "030-1", "030-2", FieldOwnershipExternal, "030-2", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			employee := &Employee{ID: primitive.NewObjectID(), FirstName: "Anna", LastName: "Schmidt", Phone: tt.local}
+			if tt.base != "" {
+				employee.SyncedValues = map[string]map[string]string{"timebutler": {"phone": tt.base}}
+			}
+
+			result, err := employee.ApplySyncedValue("timebutler", phone, tt.ownership, tt.external, now)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantPhone, employee.Phone)
+			assert.Equal(t, tt.wantUpdated, result.Updated)
+			assert.Equal(t, tt.wantConflict, result.Conflict != nil)
+			if tt.external != "" {
+				assert.Equal(t, tt.external, employee.SyncedValues["timebutler"]["phone"])
+			}
+			if tt.wantUpdated {
+				assert.Equal(t, FieldSource{Source: "timebutler", UpdatedAt: now}, employee.FieldSources["phone"])
+			}
+			if result.Conflict != nil {
+				assert.Equal(t, tt.base, result.Conflict.BaseValue)
+				assert.Equal(t, tt.local, result.Conflict.LocalValue)
+				assert.Equal(t, tt.external, result.Conflict.ExternalValue)
+				assert.Equal(t, tt.wantUpdated, result.Conflict.Applied)
+				assert.Equal(t, "Anna Schmidt", result.Conflict.EmployeeName)
+			}
+		})
+	}
+}
+
+func TestEmployee_ApplySyncedValue_TypedFields(t *testing.T) {
+	now := time.Now()
+	employee := &Employee{VacationDays: 0, HireDate: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	result, err := employee.ApplySyncedValue("timebutler", SyncedField{Name: "vacationDays"}, FieldOwnershipFillIfEmpty, "28", now)
+	require.NoError(t, err)
+	assert.True(t, result.Updated)
+	assert.Equal(t, 28, employee.VacationDays)
+
+	result, err = employee.ApplySyncedValue("123erfasst", SyncedField{Name: "hireDate"}, FieldOwnershipExternal, "2021-03-15", now)
+	require.NoError(t, err)
+	assert.True(t, result.Updated)
+	assert.Equal(t, "2021-03-15", FormatSyncedDate(employee.HireDate))
+
+	_, err = employee.ApplySyncedValue("timebutler", SyncedField{Name: "salary"}, FieldOwnershipExternal, "1", now)
+	assert.Error(t, err)
+}
+
+func TestEmployee_TrackFieldChanges(t *testing.T) {
+	now := time.Now()
+	before := &Employee{Phone: "030-1", Department: DepartmentIT}
+	after := *before
+	after.Phone = "030-2"
+	after.Notes = "nicht verfolgt"
+
+	after.TrackFieldChanges(before, FieldSourcePeopleFlow, now)
+
+	assert.Equal(t, map[string]FieldSource{"phone": {Source: FieldSourcePeopleFlow, UpdatedAt: now}}, after.FieldSources)
+}
@@ -0,0 +1,330 @@
+package model
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Schwellenwerte für Zuordnungsvorschläge
+const (
+	IdentityMatchMinScore     = 50  // Mindestpunktzahl für einen Vorschlag
+	identityMatchMaxScore     = 100 // Höchstpunktzahl
+	identityMatchMaxCandidate = 3   // Vorschläge pro externem Benutzer
+	identityNameMinSimilarity = 0.8 // Mindestähnlichkeit der Namen
+)
+
+// IdentityMatchSource beschreibt, wie ein externer Benutzer zugeordnet wurde
+type IdentityMatchSource string
+
+const (
+	IdentityMatchManual IdentityMatchSource = "manual" // manuell bestätigte Zuordnung
+	IdentityMatchID     IdentityMatchSource = "id"     // gespeicherte externe ID am Mitarbeiter
+	IdentityMatchEmail  IdentityMatchSource = "email"  // gleiche E-Mail-Adresse
+)
+
+// ExternalIdentity ist ein Benutzer aus einem angebundenen System
+type ExternalIdentity struct {
+	ExternalID     string    `json:"externalId"`
+	FirstName      string    `json:"firstName"`
+	LastName       string    `json:"lastName"`
+	Email          string    `json:"email"`
+	EmployeeNumber string    `json:"employeeNumber,omitempty"`
+	DateOfBirth    time.Time `json:"dateOfBirth,omitempty"`
+	HireDate       time.Time `json:"hireDate,omitempty"`
+	Department     string    `json:"department,omitempty"`
+	Phone          string    `json:"phone,omitempty"`
+}
+
+// FullName gibt Vor- und Nachname zurück, ersatzweise die E-Mail oder die externe ID
+func (i ExternalIdentity) FullName() string {
+	name := strings.TrimSpace(i.FirstName + " " + i.LastName)
+	if name == "" {
+		name = i.Email
+	}
+	if name == "" {
+		name = i.ExternalID
+	}
+	return name
+}
+
+// IdentityMatchCandidate ist ein vorgeschlagener Mitarbeiter für einen externen Benutzer
+type IdentityMatchCandidate struct {
+	EmployeeID   primitive.ObjectID `json:"employeeId"`
+	EmployeeName string             `json:"employeeName"`
+	Email        string             `json:"email"`
+	Score        int                `json:"score"`
+	Reasons      []string           `json:"reasons"`
+}
+
+// IdentityMatch ist eine bestehende Zuordnung
+type IdentityMatch struct {
+	External     ExternalIdentity    `json:"external"`
+	EmployeeID   primitive.ObjectID  `json:"employeeId"`
+	EmployeeName string              `json:"employeeName"`
+	Source       IdentityMatchSource `json:"source"`
+}
+
+// UnmatchedIdentity ist ein externer Benutzer ohne Mitarbeiter, mit Vorschlägen
+type UnmatchedIdentity struct {
+	External    ExternalIdentity         `json:"external"`
+	Suggestions []IdentityMatchCandidate `json:"suggestions"`
+}
+
+// UnmatchedEmployee ist ein Mitarbeiter ohne externen Benutzer
+type UnmatchedEmployee struct {
+	EmployeeID     primitive.ObjectID `json:"employeeId"`
+	EmployeeName   string             `json:"employeeName"`
+	Email          string             `json:"email"`
+	EmployeeNumber string             `json:"employeeNumber"`
+}
+
+// IdentityMatchReport ist das Ergebnis des Abgleichs zwischen externen Benutzern und Mitarbeitern
+type IdentityMatchReport struct {
+	Integration        string              `json:"integration"`
+	Matched            []IdentityMatch     `json:"matched"`
+	UnmatchedExternal  []UnmatchedIdentity `json:"unmatchedExternal"`
+	UnmatchedEmployees []UnmatchedEmployee `json:"unmatchedEmployees"`
+}
+
+// EmployeeExternalIDs gibt die zugeordnete externe ID je Mitarbeiter zurück
+func (r *IdentityMatchReport) EmployeeExternalIDs() map[primitive.ObjectID]string {
+	ids := make(map[primitive.ObjectID]string, len(r.Matched))
+	for _, match := range r.Matched {
+		ids[match.EmployeeID] = match.External.ExternalID
+	}
+	return ids
+}
+
+// FindMatch gibt die Zuordnung eines externen Benutzers zurück
+func (r *IdentityMatchReport) FindMatch(externalID string) (IdentityMatch, bool) {
+	for _, match := range r.Matched {
+		if match.External.ExternalID == externalID {
+			return match, true
+		}
+	}
+	return IdentityMatch{}, false
+}
+
+// MatchIdentities ordnet externe Benutzer Mitarbeitern zu. Manuelle Zuordnungen (externe ID →
+// Mitarbeiter) haben Vorrang, danach die am Mitarbeiter gespeicherte externe ID und zuletzt die
+// E-Mail-Adresse. Für die übrigen externen Benutzer werden Vorschläge berechnet.
+func MatchIdentities(identities []ExternalIdentity, employees []*Employee, mappings map[string]primitive.ObjectID, storedID func(*Employee) string) IdentityMatchReport {
+	report := IdentityMatchReport{
+		Matched:            []IdentityMatch{},
+		UnmatchedExternal:  []UnmatchedIdentity{},
+		UnmatchedEmployees: []UnmatchedEmployee{},
+	}
+
+	sorted := append([]ExternalIdentity(nil), identities...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return strings.ToLower(sorted[i].LastName+" "+sorted[i].FirstName) < strings.ToLower(sorted[j].LastName+" "+sorted[j].FirstName)
+	})
+
+	byID := make(map[primitive.ObjectID]*Employee, len(employees))
+	for _, employee := range employees {
+		byID[employee.ID] = employee
+	}
+
+	matchedExternal := make(map[string]bool)
+	matchedEmployee := make(map[primitive.ObjectID]bool)
+	match := func(identity ExternalIdentity, employee *Employee, source IdentityMatchSource) {
+		matchedExternal[identity.ExternalID] = true
+		matchedEmployee[employee.ID] = true
+		report.Matched = append(report.Matched, IdentityMatch{
+			External:     identity,
+			EmployeeID:   employee.ID,
+			EmployeeName: employee.FirstName + " " + employee.LastName,
+			Source:       source,
+		})
+	}
+
+	for _, identity := range sorted {
+		if employeeID, ok := mappings[identity.ExternalID]; ok {
+			if employee := byID[employeeID]; employee != nil && !matchedEmployee[employee.ID] {
+				match(identity, employee, IdentityMatchManual)
+			}
+		}
+	}
+
+	// Manuell zugeordnete externe Benutzer werden nicht automatisch anderen Mitarbeitern zugeordnet
+	isFree := func(identity ExternalIdentity) bool {
+		_, mapped := mappings[identity.ExternalID]
+		return !matchedExternal[identity.ExternalID] && !mapped
+	}
+
+	for _, identity := range sorted {
+		if !isFree(identity) || identity.ExternalID == "" {
+			continue
+		}
+		for _, employee := range employees {
+			if !matchedEmployee[employee.ID] && storedID(employee) == identity.ExternalID {
+				match(identity, employee, IdentityMatchID)
+				break
+			}
+		}
+	}
+
+	for _, identity := range sorted {
+		email := strings.ToLower(strings.TrimSpace(identity.Email))
+		if !isFree(identity) || email == "" {
+			continue
+		}
+		for _, employee := range employees {
+			if !matchedEmployee[employee.ID] && strings.ToLower(strings.TrimSpace(employee.Email)) == email {
+				match(identity, employee, IdentityMatchEmail)
+				break
+			}
+		}
+	}
+
+	var remaining []*Employee
+	for _, employee := range employees {
+		if matchedEmployee[employee.ID] {
+			continue
+		}
+		remaining = append(remaining, employee)
+		report.UnmatchedEmployees = append(report.UnmatchedEmployees, UnmatchedEmployee{
+			EmployeeID:     employee.ID,
+			EmployeeName:   employee.FirstName + " " + employee.LastName,
+			Email:          employee.Email,
+			EmployeeNumber: employee.EmployeeID,
+		})
+	}
+
+	for _, identity := range sorted {
+		if matchedExternal[identity.ExternalID] {
+			continue
+		}
+		report.UnmatchedExternal = append(report.UnmatchedExternal, UnmatchedIdentity{
+			External:    identity,
+			Suggestions: SuggestIdentityMatches(identity, remaining),
+		})
+	}
+
+	return report
+}
+
+// SuggestIdentityMatches gibt die besten Vorschläge für einen externen Benutzer zurück
+func SuggestIdentityMatches(identity ExternalIdentity, employees []*Employee) []IdentityMatchCandidate {
+	candidates := []IdentityMatchCandidate{}
+	for _, employee := range employees {
+		candidate := ScoreIdentityMatch(identity, employee)
+		if candidate.Score >= IdentityMatchMinScore {
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+	if len(candidates) > identityMatchMaxCandidate {
+		candidates = candidates[:identityMatchMaxCandidate]
+	}
+	return candidates
+}
+
+// ScoreIdentityMatch bewertet, wie gut ein Mitarbeiter zu einem externen Benutzer passt.
+// E-Mail und Personalnummer gelten als eindeutig, ein ähnlicher Name und das Geburtsdatum
+// ergeben zusammen einen sicheren Vorschlag.
+func ScoreIdentityMatch(identity ExternalIdentity, employee *Employee) IdentityMatchCandidate {
+	candidate := IdentityMatchCandidate{
+		EmployeeID:   employee.ID,
+		EmployeeName: employee.FirstName + " " + employee.LastName,
+		Email:        employee.Email,
+		Reasons:      []string{},
+	}
+
+	email := strings.ToLower(strings.TrimSpace(identity.Email))
+	if email != "" && email == strings.ToLower(strings.TrimSpace(employee.Email)) {
+		candidate.Score = identityMatchMaxScore
+		candidate.Reasons = append(candidate.Reasons, "E-Mail")
+	}
+
+	number := strings.TrimSpace(identity.EmployeeNumber)
+	if number != "" && strings.EqualFold(number, strings.TrimSpace(employee.EmployeeID)) {
+		candidate.Score = identityMatchMaxScore
+		candidate.Reasons = append(candidate.Reasons, "Personalnummer")
+	}
+
+	similarity := nameSimilarity(identity.FirstName, identity.LastName, employee.FirstName, employee.LastName)
+	if similarity >= identityNameMinSimilarity {
+		candidate.Score += int(60*similarity + 0.5)
+		if similarity == 1 {
+			candidate.Reasons = append(candidate.Reasons, "Name")
+		} else {
+			candidate.Reasons = append(candidate.Reasons, "ähnlicher Name")
+		}
+	}
+
+	if !identity.DateOfBirth.IsZero() && !employee.DateOfBirth.IsZero() &&
+		identity.DateOfBirth.Format("2006-01-02") == employee.DateOfBirth.Format("2006-01-02") {
+		candidate.Score += 30
+		candidate.Reasons = append(candidate.Reasons, "Geburtsdatum")
+	}
+
+	if candidate.Score > identityMatchMaxScore {
+		candidate.Score = identityMatchMaxScore
+	}
+	return candidate
+}
+
+// nameSimilarity vergleicht zwei Namen unabhängig von Groß-/Kleinschreibung, Umlauten und
+// vertauschter Reihenfolge von Vor- und Nachname (1 = gleich)
+func nameSimilarity(firstA, lastA, firstB, lastB string) float64 {
+	a := normalizeName(firstA + " " + lastA)
+	if a == "" {
+		return 0
+	}
+	similarity := stringSimilarity(a, normalizeName(firstB+" "+lastB))
+	if swapped := stringSimilarity(a, normalizeName(lastB+" "+firstB)); swapped > similarity {
+		similarity = swapped
+	}
+	return similarity
+}
+
+var nameReplacer = strings.NewReplacer("ä", "ae", "ö", "oe", "ü", "ue", "ß", "ss", "é", "e", "è", "e", "á", "a", "à", "a")
+
+// normalizeName vereinheitlicht Schreibweisen: Kleinbuchstaben, Umlaute ausgeschrieben,
+// Bindestriche und mehrfache Leerzeichen als ein Leerzeichen
+func normalizeName(name string) string {
+	name = nameReplacer.Replace(strings.ToLower(name))
+	fields := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	return strings.Join(fields, " ")
+}
+
+// stringSimilarity gibt 1 minus die relative Levenshtein-Distanz zurück
+func stringSimilarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	if longest == 0 {
+		return 0
+	}
+
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return 1 - float64(previous[len(rb)])/float64(longest)
+}
@@ -0,0 +1,142 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestScoreIdentityMatch(t *testing.T) {
+	birthday := time.Date(1985, 6, 12, 0, 0, 0, 0, time.UTC)
+	employee := &Employee{
+		ID:          primitive.NewObjectID(),
+		FirstName:   "Jürgen",
+		LastName:    "Müller",
+		Email:       "j.mueller@example.com",
+		EmployeeID:  "P-1001",
+		DateOfBirth: birthday,
+	}
+
+	tests := []struct {
+		name        string
+		identity    ExternalIdentity
+		wantScore   int
+		wantReasons []string
+	}{
+		{
+			name:        "E-Mail unabhängig von Groß-/Kleinschreibung",
+			identity:    ExternalIdentity{Email: "J.Mueller@Example.com"},
+			wantScore:   100,
+			wantReasons: []string{"E-Mail"},
+		},
+		{
+			name:        "Personalnummer",
+			identity:    ExternalIdentity{EmployeeNumber: "p-1001", FirstName: "Max", LastName: "Mustermann"},
+			wantScore:   100,
+			wantReasons: []string{"Personalnummer"},
+		},
+		{
+			name:        "Name mit ausgeschriebenen Umlauten",
+			identity:    ExternalIdentity{FirstName: "Juergen", LastName: "Mueller"},
+			wantScore:   60,
+			wantReasons: []string{"Name"},
+		},
+		{
+			name:        "vertauschter Name und Geburtsdatum",
+			identity:    ExternalIdentity{FirstName: "Müller", LastName: "Jürgen", DateOfBirth: birthday},
+			wantScore:   90,
+			wantReasons: []string{"Name", "Geburtsdatum"},
+		},
+		{
+			name:        "Tippfehler im Namen",
+			identity:    ExternalIdentity{FirstName: "Jürgen", LastName: "Müler"},
+			wantScore:   56,
+			wantReasons: []string{"ähnlicher Name"},
+		},
+		{
+			name:        "nur Geburtsdatum reicht nicht",
+			identity:    ExternalIdentity{FirstName: "Anna", LastName: "Schmidt", DateOfBirth: birthday},
+			wantScore:   30,
+			wantReasons: []string{"Geburtsdatum"},
+		},
+		{
+			name:        "keine Übereinstimmung",
+			identity:    ExternalIdentity{FirstName: "Anna", LastName: "Schmidt", Email: "anna@example.com"},
+			wantScore:   0,
+			wantReasons: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidate := ScoreIdentityMatch(tt.identity, employee)
+			assert.Equal(t, tt.wantScore, candidate.Score)
+			assert.Equal(t, tt.wantReasons, candidate.Reasons)
+			assert.Equal(t, "Jürgen Müller", candidate.EmployeeName)
+		})
+	}
+}
+
+func TestMatchIdentities(t *testing.T) {
+	anna := &Employee{ID: primitive.NewObjectID(), FirstName: "Anna", LastName: "Schmidt", Email: "anna.neu@example.com"}
+	ben := &Employee{ID: primitive.NewObjectID(), FirstName: "Ben", LastName: "Braun", Email: "ben@example.com", TimebutlerUserID: "tb-2"}
+	carla := &Employee{ID: primitive.NewObjectID(), FirstName: "Carla", LastName: "Weber", Email: "carla@example.com"}
+	dora := &Employee{ID: primitive.NewObjectID(), FirstName: "Dora", LastName: "Klein", Email: "dora@example.com"}
+	employees := []*Employee{anna, ben, carla, dora}
+
+	identities := []ExternalIdentity{
+		{ExternalID: "tb-1", FirstName: "Anna", LastName: "Schmidt", Email: "anna.alt@example.com"},
+		{ExternalID: "tb-2", FirstName: "Benjamin", LastName: "Braun", Email: "b.braun@example.com"},
+		{ExternalID: "tb-3", FirstName: "Carla", LastName: "Weber", Email: "carla@example.com"},
+		{ExternalID: "tb-4", FirstName: "Dora", LastName: "Klein", Email: "dora.k@example.com"},
+		{ExternalID: "tb-5", FirstName: "Erik", LastName: "Lang", Email: "dora@example.com"},
+	}
+	// tb-1 wurde manuell Anna zugeordnet, tb-5 manuell einem gelöschten Mitarbeiter
+	mappings := map[string]primitive.ObjectID{
+		"tb-1": anna.ID,
+		"tb-5": primitive.NewObjectID(),
+	}
+
+	report := MatchIdentities(identities, employees, mappings, func(e *Employee) string { return e.TimebutlerUserID })
+
+	sources := make(map[string]IdentityMatchSource)
+	for _, match := range report.Matched {
+		sources[match.External.ExternalID] = match.Source
+	}
+	assert.Equal(t, map[string]IdentityMatchSource{
+		"tb-1": IdentityMatchManual,
+		"tb-2": IdentityMatchID,
+		"tb-3": IdentityMatchEmail,
+	}, sources)
+
+	ids := report.EmployeeExternalIDs()
+	assert.Equal(t, "tb-1", ids[anna.ID])
+	assert.Equal(t, "tb-3", ids[carla.ID])
+
+	// tb-5 hat Doras E-Mail, ist aber manuell zugeordnet und wird daher nicht automatisch verknüpft
+	require.Len(t, report.UnmatchedExternal, 2)
+	assert.Equal(t, "tb-4", report.UnmatchedExternal[0].External.ExternalID)
+	require.Len(t, report.UnmatchedExternal[0].Suggestions, 1)
+	assert.Equal(t, dora.ID, report.UnmatchedExternal[0].Suggestions[0].EmployeeID)
+	assert.Equal(t, "tb-5", report.UnmatchedExternal[1].External.ExternalID)
+
+	require.Len(t, report.UnmatchedEmployees, 1)
+	assert.Equal(t, dora.ID, report.UnmatchedEmployees[0].EmployeeID)
+}
+
+func TestSuggestIdentityMatches_OrdersAndLimits(t *testing.T) {
+	var employees []*Employee
+	for _, last := range []string{"Maier", "Meier", "Meyer", "Mayer", "Schulz"} {
+		employees = append(employees, &Employee{ID: primitive.NewObjectID(), FirstName: "Hans", LastName: last})
+	}
+
+	suggestions := SuggestIdentityMatches(ExternalIdentity{FirstName: "Hans", LastName: "Meier"}, employees)
+
+	require.Len(t, suggestions, 3)
+	assert.Equal(t, "Hans Meier", suggestions[0].EmployeeName)
+	assert.Equal(t, 60, suggestions[0].Score)
+	assert.GreaterOrEqual(t, suggestions[1].Score, suggestions[2].Score)
+}
@@ -0,0 +1,22 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// IntegrationMapping ist eine manuell bestätigte Zuordnung eines externen Benutzers zu einem
+// Mitarbeiter. Sie hat beim Abgleich Vorrang vor E-Mail-Adresse und gespeicherter externer ID.
+type IntegrationMapping struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Integration   string             `bson:"integration" json:"integration"`
+	ExternalID    string             `bson:"externalId" json:"externalId"`
+	ExternalName  string             `bson:"externalName" json:"externalName"`
+	EmployeeID    primitive.ObjectID `bson:"employeeId" json:"employeeId"`
+	EmployeeName  string             `bson:"employeeName" json:"employeeName"`
+	CreatedBy     primitive.ObjectID `bson:"createdBy" json:"createdBy"`
+	CreatedByName string             `bson:"createdByName" json:"createdByName"`
+	CreatedAt     time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt     time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
@@ -0,0 +1,109 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// JobRunStatus ist das Ergebnis eines Joblaufs
+type JobRunStatus string
+
+const (
+	JobRunRunning JobRunStatus = "running"
+	JobRunSuccess JobRunStatus = "success"
+	JobRunFailed  JobRunStatus = "failed"
+	JobRunSkipped JobRunStatus = "skipped" // Termin verpasst und nicht nachgeholt
+)
+
+// JobTrigger gibt an, wodurch ein Joblauf ausgelöst wurde
+type JobTrigger string
+
+const (
+	JobTriggerSchedule JobTrigger = "schedule"
+	JobTriggerManual   JobTrigger = "manual"
+)
+
+// Job ist der gespeicherte Zustand eines Hintergrundjobs. Die Jobs selbst werden
+// im Code registriert; in der Datenbank liegen Zeitplan, Pause, Sperre und letztes Ergebnis.
+type Job struct {
+	Name      string     `bson:"_id" json:"name"`
+	Schedule  string     `bson:"schedule" json:"schedule"` // Cron-Ausdruck
+	Paused    bool       `bson:"paused" json:"paused"`
+	NextRunAt *time.Time `bson:"nextRunAt,omitempty" json:"nextRunAt,omitempty"`
+
+	// Verteilte Sperre: nur die Instanz mit gültiger Sperre führt den Job aus
+	LockedBy    string     `bson:"lockedBy,omitempty" json:"lockedBy,omitempty"`
+	LockedUntil *time.Time `bson:"lockedUntil,omitempty" json:"lockedUntil,omitempty"`
+
+	LastRunAt      *time.Time   `bson:"lastRunAt,omitempty" json:"lastRunAt,omitempty"`
+	LastStatus     JobRunStatus `bson:"lastStatus,omitempty" json:"lastStatus,omitempty"`
+	LastError      string       `bson:"lastError,omitempty" json:"lastError,omitempty"`
+	LastDurationMs int64        `bson:"lastDurationMs,omitempty" json:"lastDurationMs,omitempty"`
+
+	UpdatedAt time.Time `bson:"updatedAt" json:"updatedAt"`
+}
+
+// JobRun ist ein Eintrag der Laufhistorie eines Jobs
+type JobRun struct {
+	ID          primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	Job         string              `bson:"job" json:"job"`
+	Trigger     JobTrigger          `bson:"trigger" json:"trigger"`
+	TriggeredBy *primitive.ObjectID `bson:"triggeredBy,omitempty" json:"triggeredBy,omitempty"`
+	Instance    string              `bson:"instance" json:"instance"`
+	Status      JobRunStatus        `bson:"status" json:"status"`
+	Result      string              `bson:"result,omitempty" json:"result,omitempty"` // Zusammenfassung, z.B. "12 Mitarbeiter synchronisiert"
+	Error       string              `bson:"error,omitempty" json:"error,omitempty"`
+	StartedAt   time.Time           `bson:"startedAt" json:"startedAt"`
+	FinishedAt  *time.Time          `bson:"finishedAt,omitempty" json:"finishedAt,omitempty"`
+	DurationMs  int64               `bson:"durationMs" json:"durationMs"`
+}
+
+// IsValid prüft, ob der Status bekannt ist
+func (s JobRunStatus) IsValid() bool {
+	switch s {
+	case JobRunRunning, JobRunSuccess, JobRunFailed, JobRunSkipped:
+		return true
+	default:
+		return false
+	}
+}
+
+// GetLabel gibt eine deutsche Bezeichnung für den Status zurück
+func (s JobRunStatus) GetLabel() string {
+	switch s {
+	case JobRunRunning:
+		return "Läuft"
+	case JobRunSuccess:
+		return "Erfolgreich"
+	case JobRunFailed:
+		return "Fehlgeschlagen"
+	case JobRunSkipped:
+		return "Übersprungen"
+	default:
+		return string(s)
+	}
+}
+
+// IsLocked prüft, ob der Job zum Zeitpunkt now von einer Instanz gesperrt ist
+func (j *Job) IsLocked(now time.Time) bool {
+	return j.LockedUntil != nil && j.LockedUntil.After(now)
+}
+
+// IsDue prüft, ob der Job zum Zeitpunkt now planmäßig ausgeführt werden soll
+func (j *Job) IsDue(now time.Time) bool {
+	return !j.Paused && j.NextRunAt != nil && !j.NextRunAt.After(now) && !j.IsLocked(now)
+}
+
+// Finish schließt einen Lauf mit Ergebnis oder Fehler ab
+func (r *JobRun) Finish(now time.Time, result string, err error) {
+	r.FinishedAt = &now
+	r.DurationMs = now.Sub(r.StartedAt).Milliseconds()
+	r.Result = result
+	if err != nil {
+		r.Status = JobRunFailed
+		r.Error = err.Error()
+	} else {
+		r.Status = JobRunSuccess
+	}
+}
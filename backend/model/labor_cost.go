@@ -0,0 +1,455 @@
+// backend/model/labor_cost.go
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Fehler der Gehaltshistorie
+var (
+	ErrInvalidSalaryChange = errors.New("invalid salary change")
+	ErrInvalidSalaryBonus  = errors.New("invalid salary bonus")
+	ErrSalaryEntryNotFound = errors.New("salary entry not found")
+)
+
+// DefaultEmployerContributionPercent ist der Arbeitgeberanteil zur Sozialversicherung in Prozent
+// des Bruttogehalts, wenn für einen Mitarbeiter kein eigener Satz hinterlegt ist
+const DefaultEmployerContributionPercent = 21.5
+
+// Gruppennamen für Mitarbeiter ohne Abteilung oder Standort
+const (
+	LaborCostUnknownDepartment = "Unbekannt"
+	LaborCostUnknownLocation   = "Ohne Standort"
+)
+
+// SalaryChange ist ein Eintrag der Gehaltshistorie, der ab EffectiveDate gilt
+type SalaryChange struct {
+	ID                          primitive.ObjectID `bson:"_id" json:"id"`
+	EffectiveDate               time.Time          `bson:"effectiveDate" json:"effectiveDate"` // leer = seit Beginn der Beschäftigung
+	MonthlySalary               float64            `bson:"monthlySalary" json:"monthlySalary"`
+	EmployerContributionPercent float64            `bson:"employerContributionPercent" json:"employerContributionPercent"`
+	Reason                      string             `bson:"reason,omitempty" json:"reason,omitempty"`
+	CreatedBy                   string             `bson:"createdBy,omitempty" json:"createdBy,omitempty"`
+	CreatedAt                   time.Time          `bson:"createdAt" json:"createdAt"`
+}
+
+// Validate prüft Gehalt, Stichtag und Arbeitgeberanteil
+func (c SalaryChange) Validate() error {
+	switch {
+	case c.EffectiveDate.IsZero():
+		return fmt.Errorf("%w: Gültig ab fehlt", ErrInvalidSalaryChange)
+	case c.MonthlySalary < 0:
+		return fmt.Errorf("%w: das Gehalt darf nicht negativ sein", ErrInvalidSalaryChange)
+	case c.EmployerContributionPercent < 0 || c.EmployerContributionPercent > 100:
+		return fmt.Errorf("%w: der Arbeitgeberanteil muss zwischen 0 und 100 Prozent liegen", ErrInvalidSalaryChange)
+	}
+	return nil
+}
+
+// SalaryBonus ist eine Sonderzahlung (Bonus, Prämie, Weihnachtsgeld), die im Monat der Auszahlung anfällt
+type SalaryBonus struct {
+	ID          primitive.ObjectID `bson:"_id" json:"id"`
+	PaymentDate time.Time          `bson:"paymentDate" json:"paymentDate"`
+	Amount      float64            `bson:"amount" json:"amount"`
+	Description string             `bson:"description" json:"description"`
+	CreatedBy   string             `bson:"createdBy,omitempty" json:"createdBy,omitempty"`
+	CreatedAt   time.Time          `bson:"createdAt" json:"createdAt"`
+}
+
+// Validate prüft Datum und Betrag einer Sonderzahlung
+func (b SalaryBonus) Validate() error {
+	switch {
+	case b.PaymentDate.IsZero():
+		return fmt.Errorf("%w: Auszahlungsdatum fehlt", ErrInvalidSalaryBonus)
+	case b.Amount == 0:
+		return fmt.Errorf("%w: der Betrag darf nicht 0 sein", ErrInvalidSalaryBonus)
+	case b.Description == "":
+		return fmt.Errorf("%w: Bezeichnung fehlt", ErrInvalidSalaryBonus)
+	}
+	return nil
+}
+
+// SalaryAt gibt den am Tag date gültigen Eintrag der Gehaltshistorie zurück. Ohne Historie gilt
+// das aktuelle Gehalt mit dem Standard-Arbeitgeberanteil.
+func (e *Employee) SalaryAt(date time.Time) (SalaryChange, bool) {
+	if len(e.SalaryHistory) == 0 {
+		return SalaryChange{MonthlySalary: e.Salary, EmployerContributionPercent: DefaultEmployerContributionPercent}, true
+	}
+
+	var current SalaryChange
+	found := false
+	for _, change := range e.SalaryHistory {
+		if change.EffectiveDate.After(date) {
+			continue
+		}
+		if !found || !change.EffectiveDate.Before(current.EffectiveDate) {
+			current = change
+			found = true
+		}
+	}
+	return current, found
+}
+
+// AddSalaryChange trägt eine Gehaltsänderung ein. Beim ersten Eintrag wird das bisherige Gehalt
+// als Ausgangsgehalt ab Eintrittsdatum übernommen, damit frühere Monate ihre Kosten behalten.
+// Eine Änderung mit demselben Stichtag ersetzt die vorhandene. Salary wird auf das zum
+// Zeitpunkt now gültige Gehalt gesetzt.
+func (e *Employee) AddSalaryChange(change SalaryChange, now time.Time) error {
+	if err := change.Validate(); err != nil {
+		return err
+	}
+	if change.ID.IsZero() {
+		change.ID = primitive.NewObjectID()
+	}
+	if change.CreatedAt.IsZero() {
+		change.CreatedAt = now
+	}
+
+	if len(e.SalaryHistory) == 0 && e.Salary > 0 {
+		e.SalaryHistory = append(e.SalaryHistory, SalaryChange{
+			ID:                          primitive.NewObjectID(),
+			EffectiveDate:               e.HireDate,
+			MonthlySalary:               e.Salary,
+			EmployerContributionPercent: DefaultEmployerContributionPercent,
+			Reason:                      "Ausgangsgehalt",
+			CreatedAt:                   now,
+		})
+	}
+
+	replaced := false
+	for i, existing := range e.SalaryHistory {
+		if salaryDay(existing.EffectiveDate).Equal(salaryDay(change.EffectiveDate)) {
+			e.SalaryHistory[i] = change
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		e.SalaryHistory = append(e.SalaryHistory, change)
+	}
+	sort.SliceStable(e.SalaryHistory, func(i, j int) bool {
+		return e.SalaryHistory[i].EffectiveDate.Before(e.SalaryHistory[j].EffectiveDate)
+	})
+
+	e.SyncCurrentSalary(now)
+	return nil
+}
+
+// RemoveSalaryChange löscht einen Eintrag der Gehaltshistorie
+func (e *Employee) RemoveSalaryChange(id primitive.ObjectID, now time.Time) error {
+	for i, change := range e.SalaryHistory {
+		if change.ID == id {
+			e.SalaryHistory = append(e.SalaryHistory[:i], e.SalaryHistory[i+1:]...)
+			e.SyncCurrentSalary(now)
+			return nil
+		}
+	}
+	return ErrSalaryEntryNotFound
+}
+
+// TrackSalaryChange trägt eine Änderung von Salary gegenüber before (z.B. aus dem
+// Mitarbeiterformular, der API oder einem Import) mit Stichtag now in die Historie ein
+func (e *Employee) TrackSalaryChange(before *Employee, createdBy string, now time.Time) {
+	if before.Salary == e.Salary {
+		return
+	}
+
+	salary := e.Salary
+	e.Salary = before.Salary
+
+	percent := DefaultEmployerContributionPercent
+	if current, ok := e.SalaryAt(now); ok {
+		percent = current.EmployerContributionPercent
+	}
+	_ = e.AddSalaryChange(SalaryChange{
+		EffectiveDate:               salaryDay(now),
+		MonthlySalary:               salary,
+		EmployerContributionPercent: percent,
+		Reason:                      "Gehaltsänderung",
+		CreatedBy:                   createdBy,
+	}, now)
+	e.Salary = salary
+}
+
+// SyncCurrentSalary setzt Salary auf das zum Zeitpunkt now gültige Gehalt der Historie.
+// Gibt true zurück, wenn sich Salary geändert hat, z.B. weil eine Erhöhung wirksam wurde.
+func (e *Employee) SyncCurrentSalary(now time.Time) bool {
+	if len(e.SalaryHistory) == 0 {
+		return false
+	}
+	current, ok := e.SalaryAt(now)
+	if !ok || current.MonthlySalary == e.Salary {
+		return false
+	}
+	e.Salary = current.MonthlySalary
+	return true
+}
+
+// AddBonus trägt eine Sonderzahlung ein
+func (e *Employee) AddBonus(bonus SalaryBonus, now time.Time) error {
+	if err := bonus.Validate(); err != nil {
+		return err
+	}
+	if bonus.ID.IsZero() {
+		bonus.ID = primitive.NewObjectID()
+	}
+	if bonus.CreatedAt.IsZero() {
+		bonus.CreatedAt = now
+	}
+	e.Bonuses = append(e.Bonuses, bonus)
+	sort.SliceStable(e.Bonuses, func(i, j int) bool {
+		return e.Bonuses[i].PaymentDate.Before(e.Bonuses[j].PaymentDate)
+	})
+	return nil
+}
+
+// RemoveBonus löscht eine Sonderzahlung
+func (e *Employee) RemoveBonus(id primitive.ObjectID) error {
+	for i, bonus := range e.Bonuses {
+		if bonus.ID == id {
+			e.Bonuses = append(e.Bonuses[:i], e.Bonuses[i+1:]...)
+			return nil
+		}
+	}
+	return ErrSalaryEntryNotFound
+}
+
+func salaryDay(value time.Time) time.Time {
+	return time.Date(value.Year(), value.Month(), value.Day(), 0, 0, 0, 0, value.Location())
+}
+
+// LaborCost sind die Personalkosten eines Mitarbeiters oder einer Gruppe in einem Monat
+type LaborCost struct {
+	EmployeeCount         int     `bson:"employeeCount" json:"employeeCount"`
+	Salaries              float64 `bson:"salaries" json:"salaries"`
+	Bonuses               float64 `bson:"bonuses" json:"bonuses"`
+	EmployerContributions float64 `bson:"employerContributions" json:"employerContributions"`
+	Total                 float64 `bson:"total" json:"total"`
+}
+
+func (c *LaborCost) add(other LaborCost) {
+	c.EmployeeCount += other.EmployeeCount
+	c.Salaries += other.Salaries
+	c.Bonuses += other.Bonuses
+	c.EmployerContributions += other.EmployerContributions
+	c.Total += other.Total
+}
+
+func (c *LaborCost) round() {
+	c.Salaries = roundCurrency(c.Salaries)
+	c.Bonuses = roundCurrency(c.Bonuses)
+	c.EmployerContributions = roundCurrency(c.EmployerContributions)
+	c.Total = roundCurrency(c.Total)
+}
+
+func roundCurrency(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+// LaborCostForMonth berechnet die Personalkosten des Mitarbeiters im Monat month: das am
+// Monatsende gültige Gehalt (ohne anteilige Berechnung bei Ein- oder Austritt), die im Monat
+// ausgezahlten Sonderzahlungen und den Arbeitgeberanteil auf beides. Mitarbeiter zählen ab dem
+// Eintrittsmonat. Für inaktive Mitarbeiter fällt kein Gehalt mehr an; ihre Kosten in
+// abgeschlossenen Monaten bleiben über die gespeicherten Momentaufnahmen erhalten.
+func (e *Employee) LaborCostForMonth(month time.Time) (LaborCost, bool) {
+	start := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, month.Location())
+	end := start.AddDate(0, 1, 0)
+
+	var cost LaborCost
+	for _, bonus := range e.Bonuses {
+		if !bonus.PaymentDate.Before(start) && bonus.PaymentDate.Before(end) {
+			cost.Bonuses += bonus.Amount
+		}
+	}
+
+	percent := DefaultEmployerContributionPercent
+	employed := (e.HireDate.IsZero() || e.HireDate.Before(end)) && e.Status != EmployeeStatusInactive
+	if change, ok := e.SalaryAt(end.Add(-time.Nanosecond)); ok {
+		percent = change.EmployerContributionPercent
+		if employed {
+			cost.Salaries = change.MonthlySalary
+		}
+	}
+
+	if cost.Salaries == 0 && cost.Bonuses == 0 {
+		return LaborCost{}, false
+	}
+	cost.EmployeeCount = 1
+	cost.EmployerContributions = (cost.Salaries + cost.Bonuses) * percent / 100
+	cost.Total = cost.Salaries + cost.Bonuses + cost.EmployerContributions
+	return cost, true
+}
+
+// LaborCostGroup sind die Personalkosten einer Kombination aus Abteilung und Standort
+type LaborCostGroup struct {
+	Department string `bson:"department" json:"department"`
+	Location   string `bson:"location" json:"location"`
+	LaborCost  `bson:",inline"`
+}
+
+// LaborCostSnapshot hält die Personalkosten eines Monats fest. Abgeschlossene Monate werden
+// gespeichert, damit spätere Austritte oder Korrekturen den Verlauf nicht verändern.
+type LaborCostSnapshot struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"-"`
+	Month       string             `bson:"month" json:"month"` // YYYY-MM
+	LaborCost   `bson:",inline"`
+	Groups      []LaborCostGroup `bson:"groups" json:"groups"`
+	Stored      bool             `bson:"-" json:"stored"`      // aus der Datenbank statt live berechnet
+	Unavailable bool             `bson:"-" json:"unavailable"` // abgeschlossener Monat ohne Momentaufnahme
+	CreatedAt   time.Time        `bson:"createdAt" json:"createdAt"`
+}
+
+// UnavailableLaborCostSnapshot kennzeichnet einen abgeschlossenen Monat ohne Momentaufnahme.
+// Solche Monate werden nicht nachträglich berechnet: der heutige Status würde ausgeschiedene
+// Mitarbeiter aus dem Monat entfernen und die Kosten zu niedrig ausweisen.
+func UnavailableLaborCostSnapshot(month time.Time) *LaborCostSnapshot {
+	return &LaborCostSnapshot{Month: month.Format("2006-01"), Unavailable: true}
+}
+
+// BuildLaborCostSnapshot berechnet die Personalkosten aller Mitarbeiter im Monat month,
+// aufgeteilt nach Abteilung und Standort
+func BuildLaborCostSnapshot(employees []*Employee, month time.Time, now time.Time) *LaborCostSnapshot {
+	snapshot := &LaborCostSnapshot{Month: month.Format("2006-01"), CreatedAt: now}
+
+	groups := make(map[[2]string]*LaborCostGroup)
+	for _, employee := range employees {
+		cost, ok := employee.LaborCostForMonth(month)
+		if !ok {
+			continue
+		}
+
+		key := [2]string{string(employee.Department), employee.Location}
+		if key[0] == "" {
+			key[0] = LaborCostUnknownDepartment
+		}
+		if key[1] == "" {
+			key[1] = LaborCostUnknownLocation
+		}
+		group, exists := groups[key]
+		if !exists {
+			group = &LaborCostGroup{Department: key[0], Location: key[1]}
+			groups[key] = group
+		}
+		group.add(cost)
+		snapshot.add(cost)
+	}
+
+	for _, group := range groups {
+		group.round()
+		snapshot.Groups = append(snapshot.Groups, *group)
+	}
+	sort.Slice(snapshot.Groups, func(i, j int) bool {
+		if snapshot.Groups[i].Department != snapshot.Groups[j].Department {
+			return snapshot.Groups[i].Department < snapshot.Groups[j].Department
+		}
+		return snapshot.Groups[i].Location < snapshot.Groups[j].Location
+	})
+	snapshot.round()
+	return snapshot
+}
+
+// Filter gibt die Kosten der Gruppen zurück, die zu Abteilung und Standort passen (leer = alle)
+func (s *LaborCostSnapshot) Filter(department, location string) LaborCost {
+	if department == "" && location == "" {
+		return s.LaborCost
+	}
+	var cost LaborCost
+	for _, group := range s.Groups {
+		if (department == "" || group.Department == department) && (location == "" || group.Location == location) {
+			cost.add(group.LaborCost)
+		}
+	}
+	cost.round()
+	return cost
+}
+
+// LaborCostSeries ist der Kostenverlauf einer Abteilung oder eines Standorts
+type LaborCostSeries struct {
+	Name   string     `json:"name"`
+	Totals []*float64 `json:"totals"` // nil für Monate ohne Daten
+}
+
+// LaborCostTrend ist der monatliche Verlauf der Personalkosten für Diagramme
+type LaborCostTrend struct {
+	Months      []string          `json:"months"` // YYYY-MM, älteste zuerst
+	Costs       []LaborCost       `json:"costs"`  // Summen je Monat nach Filter
+	Stored      []bool            `json:"stored"` // Monat aus gespeicherter Momentaufnahme
+	Available   []bool            `json:"available"`
+	Departments []LaborCostSeries `json:"departments"`
+	Locations   []LaborCostSeries `json:"locations"`
+}
+
+// BuildLaborCostTrend fasst Momentaufnahmen (älteste zuerst) zu einem Verlauf zusammen.
+// Abteilungs- und Standortreihen berücksichtigen jeweils den anderen Filter.
+func BuildLaborCostTrend(snapshots []*LaborCostSnapshot, department, location string) LaborCostTrend {
+	trend := LaborCostTrend{
+		Months:    make([]string, len(snapshots)),
+		Costs:     make([]LaborCost, len(snapshots)),
+		Stored:    make([]bool, len(snapshots)),
+		Available: make([]bool, len(snapshots)),
+	}
+
+	departments := make(map[string][]float64)
+	locations := make(map[string][]float64)
+	for i, snapshot := range snapshots {
+		trend.Months[i] = snapshot.Month
+		trend.Costs[i] = snapshot.Filter(department, location)
+		trend.Stored[i] = snapshot.Stored
+		trend.Available[i] = !snapshot.Unavailable
+
+		for _, group := range snapshot.Groups {
+			if location == "" || group.Location == location {
+				if departments[group.Department] == nil {
+					departments[group.Department] = make([]float64, len(snapshots))
+				}
+				departments[group.Department][i] = roundCurrency(departments[group.Department][i] + group.Total)
+			}
+			if department == "" || group.Department == department {
+				if locations[group.Location] == nil {
+					locations[group.Location] = make([]float64, len(snapshots))
+				}
+				locations[group.Location][i] = roundCurrency(locations[group.Location][i] + group.Total)
+			}
+		}
+	}
+
+	trend.Departments = laborCostSeries(departments, trend.Available)
+	trend.Locations = laborCostSeries(locations, trend.Available)
+	return trend
+}
+
+// Totals gibt die Gesamtkosten je Monat zurück (nil für Monate ohne Daten)
+func (t LaborCostTrend) Totals() []*float64 {
+	totals := make([]float64, len(t.Costs))
+	for i, cost := range t.Costs {
+		totals[i] = cost.Total
+	}
+	return availableTotals(totals, t.Available)
+}
+
+func laborCostSeries(values map[string][]float64, available []bool) []LaborCostSeries {
+	series := make([]LaborCostSeries, 0, len(values))
+	for name, totals := range values {
+		series = append(series, LaborCostSeries{Name: name, Totals: availableTotals(totals, available)})
+	}
+	sort.Slice(series, func(i, j int) bool { return series[i].Name < series[j].Name })
+	return series
+}
+
+// availableTotals ersetzt die Werte von Monaten ohne Daten durch nil, damit Diagramme dort
+// eine Lücke statt Kosten von 0 € zeigen
+func availableTotals(totals []float64, available []bool) []*float64 {
+	values := make([]*float64, len(totals))
+	for i := range totals {
+		if i >= len(available) || available[i] {
+			values[i] = &totals[i]
+		}
+	}
+	return values
+}
@@ -0,0 +1,174 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func laborCostDate(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestSalaryChangeValidate(t *testing.T) {
+	valid := SalaryChange{EffectiveDate: laborCostDate(2024, time.March, 1), MonthlySalary: 4000, EmployerContributionPercent: 21.5}
+
+	tests := []struct {
+		name    string
+		modify  func(c *SalaryChange)
+		wantErr bool
+	}{
+		{"gültig", func(c *SalaryChange) {}, false},
+		{"Stichtag fehlt", func(c *SalaryChange) { c.EffectiveDate = time.Time{} }, true},
+		{"negatives Gehalt", func(c *SalaryChange) { c.MonthlySalary = -1 }, true},
+		{"Anteil über 100", func(c *SalaryChange) { c.EmployerContributionPercent = 101 }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			change := valid
+			tt.modify(&change)
+			err := change.Validate()
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, ErrInvalidSalaryChange))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSalaryBonusValidate(t *testing.T) {
+	assert.NoError(t, SalaryBonus{PaymentDate: laborCostDate(2024, time.December, 1), Amount: 500, Description: "Weihnachtsgeld"}.Validate())
+	assert.ErrorIs(t, SalaryBonus{PaymentDate: laborCostDate(2024, time.December, 1), Description: "Weihnachtsgeld"}.Validate(), ErrInvalidSalaryBonus)
+	assert.ErrorIs(t, SalaryBonus{Amount: 500, Description: "Weihnachtsgeld"}.Validate(), ErrInvalidSalaryBonus)
+}
+
+func TestEmployee_AddSalaryChangeKeepsStartingSalary(t *testing.T) {
+	now := laborCostDate(2024, time.June, 15)
+	e := &Employee{Salary: 4000, HireDate: laborCostDate(2023, time.January, 1)}
+
+	require.NoError(t, e.AddSalaryChange(SalaryChange{EffectiveDate: laborCostDate(2024, time.July, 1), MonthlySalary: 4500, EmployerContributionPercent: 20}, now))
+
+	require.Len(t, e.SalaryHistory, 2)
+	assert.Equal(t, "Ausgangsgehalt", e.SalaryHistory[0].Reason)
+	assert.Equal(t, 4000.0, e.Salary, "die Erhöhung ist noch nicht wirksam")
+
+	before, _ := e.SalaryAt(laborCostDate(2024, time.June, 30))
+	after, _ := e.SalaryAt(laborCostDate(2024, time.July, 1))
+	assert.Equal(t, 4000.0, before.MonthlySalary)
+	assert.Equal(t, 4500.0, after.MonthlySalary)
+	assert.Equal(t, 20.0, after.EmployerContributionPercent)
+
+	assert.True(t, e.SyncCurrentSalary(laborCostDate(2024, time.July, 2)))
+	assert.Equal(t, 4500.0, e.Salary)
+
+	// Gleicher Stichtag ersetzt den Eintrag
+	require.NoError(t, e.AddSalaryChange(SalaryChange{EffectiveDate: laborCostDate(2024, time.July, 1), MonthlySalary: 4600, EmployerContributionPercent: 20}, now))
+	assert.Len(t, e.SalaryHistory, 2)
+
+	require.NoError(t, e.RemoveSalaryChange(e.SalaryHistory[1].ID, laborCostDate(2024, time.July, 2)))
+	assert.Equal(t, 4000.0, e.Salary)
+	assert.ErrorIs(t, e.RemoveSalaryChange(e.ID, now), ErrSalaryEntryNotFound)
+}
+
+func TestEmployee_TrackSalaryChange(t *testing.T) {
+	now := laborCostDate(2024, time.May, 10)
+	before := Employee{Salary: 3000, HireDate: laborCostDate(2022, time.April, 1)}
+
+	unchanged := before
+	unchanged.TrackSalaryChange(&before, "admin", now)
+	assert.Empty(t, unchanged.SalaryHistory)
+
+	changed := before
+	changed.Salary = 3300
+	changed.TrackSalaryChange(&before, "admin", now)
+
+	require.Len(t, changed.SalaryHistory, 2)
+	assert.Equal(t, 3300.0, changed.Salary)
+	assert.Equal(t, now, changed.SalaryHistory[1].EffectiveDate)
+	assert.Equal(t, "admin", changed.SalaryHistory[1].CreatedBy)
+
+	april, _ := changed.LaborCostForMonth(laborCostDate(2024, time.April, 1))
+	assert.Equal(t, 3000.0, april.Salaries, "frühere Monate behalten das alte Gehalt")
+}
+
+func TestEmployee_LaborCostForMonth(t *testing.T) {
+	e := &Employee{Salary: 4000, HireDate: laborCostDate(2024, time.March, 15), Status: EmployeeStatusActive}
+	require.NoError(t, e.AddBonus(SalaryBonus{PaymentDate: laborCostDate(2024, time.November, 20), Amount: 1000, Description: "Weihnachtsgeld"}, time.Now()))
+
+	tests := []struct {
+		name        string
+		employee    *Employee
+		month       time.Time
+		wantCounted bool
+		wantSalary  float64
+		wantBonus   float64
+		wantTotal   float64
+	}{
+		{"vor Eintritt", e, laborCostDate(2024, time.February, 1), false, 0, 0, 0},
+		{"Eintrittsmonat", e, laborCostDate(2024, time.March, 1), true, 4000, 0, 4860},
+		{"Monat mit Sonderzahlung", e, laborCostDate(2024, time.November, 1), true, 4000, 1000, 6075},
+		{"inaktiv", &Employee{Salary: 4000, Status: EmployeeStatusInactive}, laborCostDate(2024, time.March, 1), false, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cost, counted := tt.employee.LaborCostForMonth(tt.month)
+			assert.Equal(t, tt.wantCounted, counted)
+			assert.Equal(t, tt.wantSalary, cost.Salaries)
+			assert.Equal(t, tt.wantBonus, cost.Bonuses)
+			assert.InDelta(t, tt.wantTotal, cost.Total, 0.001)
+		})
+	}
+}
+
+func TestBuildLaborCostSnapshotAndTrend(t *testing.T) {
+	employees := []*Employee{
+		{Salary: 4000, Department: DepartmentIT, Location: "Berlin"},
+		{Salary: 3000, Department: DepartmentIT, Location: "München"},
+		{Salary: 2000, Department: DepartmentSales},
+	}
+	march := BuildLaborCostSnapshot(employees, laborCostDate(2024, time.March, 1), time.Now())
+
+	assert.Equal(t, "2024-03", march.Month)
+	assert.Equal(t, 3, march.EmployeeCount)
+	assert.Equal(t, 9000.0, march.Salaries)
+	assert.Equal(t, 10935.0, march.Total)
+	require.Len(t, march.Groups, 3)
+	assert.Equal(t, LaborCostUnknownLocation, march.Groups[2].Location)
+
+	assert.Equal(t, 8505.0, march.Filter(string(DepartmentIT), "").Total)
+	assert.Equal(t, 4860.0, march.Filter("", "Berlin").Total)
+	assert.Equal(t, 0.0, march.Filter(string(DepartmentSales), "Berlin").Total)
+
+	employees[0].Salary = 5000
+	april := BuildLaborCostSnapshot(employees, laborCostDate(2024, time.April, 1), time.Now())
+	march.Stored = true
+
+	february := UnavailableLaborCostSnapshot(laborCostDate(2024, time.February, 1))
+
+	trend := BuildLaborCostTrend([]*LaborCostSnapshot{february, march, april}, string(DepartmentIT), "")
+	assert.Equal(t, []string{"2024-02", "2024-03", "2024-04"}, trend.Months)
+	assert.Equal(t, []bool{false, true, false}, trend.Stored)
+	assert.Equal(t, []bool{false, true, true}, trend.Available)
+	assert.Equal(t, laborCostTotals(nil, 8505.0, 9720.0), trend.Totals(), "Monate ohne Daten bleiben leer statt 0 €")
+	require.Len(t, trend.Departments, 2, "Abteilungsreihen ignorieren den Abteilungsfilter")
+	require.Len(t, trend.Locations, 2)
+	assert.Equal(t, "Berlin", trend.Locations[0].Name)
+	assert.Equal(t, laborCostTotals(nil, 4860.0, 6075.0), trend.Locations[0].Totals)
+}
+
+// laborCostTotals baut erwartete Monatswerte; nil steht für einen Monat ohne Daten
+func laborCostTotals(values ...interface{}) []*float64 {
+	totals := make([]*float64, len(values))
+	for i, value := range values {
+		if value, ok := value.(float64); ok {
+			totals[i] = &value
+		}
+	}
+	return totals
+}
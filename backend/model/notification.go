@@ -0,0 +1,139 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// NotificationType ist die Art einer Benachrichtigung
+type NotificationType string
+
+const (
+	NotificationApprovalRequested    NotificationType = "approval_requested"    // Antrag wartet auf Genehmigung
+	NotificationRequestDecided       NotificationType = "request_decided"       // Eigener Antrag genehmigt oder abgelehnt
+	NotificationConversationUpcoming NotificationType = "conversation_upcoming" // Mitarbeitergespräch in den nächsten Tagen
+	NotificationDocumentExpiring     NotificationType = "document_expiring"     // Dokument läuft bald ab
+	NotificationSyncFailed           NotificationType = "sync_failed"           // Integrations-Synchronisation fehlgeschlagen
+)
+
+// Benachrichtigungsfehler
+var (
+	ErrInvalidNotificationType   = errors.New("invalid notification type")
+	ErrNotificationUserRequired  = errors.New("notification recipient is required")
+	ErrNotificationTitleRequired = errors.New("notification title is required")
+)
+
+// Notification ist eine In-App-Benachrichtigung für einen Benutzer
+type Notification struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID     primitive.ObjectID `bson:"userId" json:"userId"`
+	Type       NotificationType   `bson:"type" json:"type"`
+	Title      string             `bson:"title" json:"title"`
+	Message    string             `bson:"message" json:"message"`
+	Link       string             `bson:"link,omitempty" json:"link,omitempty"` // Relativer Link zum Ziel, z.B. /employees/view/<id>
+	TargetID   primitive.ObjectID `bson:"targetId,omitempty" json:"targetId,omitempty"`
+	TargetType string             `bson:"targetType,omitempty" json:"targetType,omitempty"`
+	Read       bool               `bson:"read" json:"read"`
+	ReadAt     *time.Time         `bson:"readAt,omitempty" json:"readAt,omitempty"`
+	CreatedAt  time.Time          `bson:"createdAt" json:"createdAt"`
+}
+
+// NotificationPreference legt fest, über welche Kanäle ein Benutzer eine Benachrichtigungsart erhält
+type NotificationPreference struct {
+	InApp bool `bson:"inApp" json:"inApp"`
+	Email bool `bson:"email" json:"email"`
+}
+
+// NotificationPreferences sind die Einstellungen eines Benutzers je Benachrichtigungsart
+type NotificationPreferences map[NotificationType]NotificationPreference
+
+// NotificationTypes gibt alle Benachrichtigungsarten zurück
+func NotificationTypes() []NotificationType {
+	return []NotificationType{
+		NotificationApprovalRequested, NotificationRequestDecided, NotificationConversationUpcoming,
+		NotificationDocumentExpiring, NotificationSyncFailed,
+	}
+}
+
+// IsValid prüft, ob die Benachrichtigungsart bekannt ist
+func (t NotificationType) IsValid() bool {
+	for _, notificationType := range NotificationTypes() {
+		if t == notificationType {
+			return true
+		}
+	}
+	return false
+}
+
+// GetLabel gibt eine deutsche Bezeichnung für die Benachrichtigungsart zurück
+func (t NotificationType) GetLabel() string {
+	switch t {
+	case NotificationApprovalRequested:
+		return "Anträge zur Genehmigung"
+	case NotificationRequestDecided:
+		return "Entscheidungen über eigene Anträge"
+	case NotificationConversationUpcoming:
+		return "Anstehende Mitarbeitergespräche"
+	case NotificationDocumentExpiring:
+		return "Ablaufende Dokumente"
+	case NotificationSyncFailed:
+		return "Fehlgeschlagene Synchronisationen"
+	default:
+		return string(t)
+	}
+}
+
+// DefaultNotificationPreference gibt die Voreinstellung einer Benachrichtigungsart zurück.
+// In-App ist immer aktiv, E-Mails nur für Genehmigungen und Entscheidungen.
+func DefaultNotificationPreference(t NotificationType) NotificationPreference {
+	switch t {
+	case NotificationApprovalRequested, NotificationRequestDecided:
+		return NotificationPreference{InApp: true, Email: true}
+	default:
+		return NotificationPreference{InApp: true}
+	}
+}
+
+// For gibt die Einstellung einer Benachrichtigungsart zurück, ohne Eintrag die Voreinstellung
+func (p NotificationPreferences) For(t NotificationType) NotificationPreference {
+	if preference, ok := p[t]; ok {
+		return preference
+	}
+	return DefaultNotificationPreference(t)
+}
+
+// Resolved gibt die Einstellungen für alle Benachrichtigungsarten inklusive Voreinstellungen zurück
+func (p NotificationPreferences) Resolved() NotificationPreferences {
+	resolved := make(NotificationPreferences, len(NotificationTypes()))
+	for _, t := range NotificationTypes() {
+		resolved[t] = p.For(t)
+	}
+	return resolved
+}
+
+// Validate prüft, ob alle Einträge bekannte Benachrichtigungsarten betreffen
+func (p NotificationPreferences) Validate() error {
+	for t := range p {
+		if !t.IsValid() {
+			return fmt.Errorf("%w: %s", ErrInvalidNotificationType, t)
+		}
+	}
+	return nil
+}
+
+// Validate prüft Empfänger, Art und Titel einer Benachrichtigung
+func (n *Notification) Validate() error {
+	if n.UserID.IsZero() {
+		return ErrNotificationUserRequired
+	}
+	if !n.Type.IsValid() {
+		return fmt.Errorf("%w: %s", ErrInvalidNotificationType, n.Type)
+	}
+	if n.Title == "" {
+		return ErrNotificationTitleRequired
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestNotification_Validate(t *testing.T) {
+	userID := primitive.NewObjectID()
+
+	tests := []struct {
+		name         string
+		notification Notification
+		wantErr      error
+	}{
+		{"Valid notification", Notification{UserID: userID, Type: NotificationApprovalRequested, Title: "Neuer Urlaubsantrag"}, nil},
+		{"Missing recipient", Notification{Type: NotificationSyncFailed, Title: "Sync"}, ErrNotificationUserRequired},
+		{"Unknown type", Notification{UserID: userID, Type: "birthday", Title: "x"}, ErrInvalidNotificationType},
+		{"Missing title", Notification{UserID: userID, Type: NotificationDocumentExpiring}, ErrNotificationTitleRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.notification.Validate()
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNotificationPreferences_For(t *testing.T) {
+	preferences := NotificationPreferences{
+		NotificationApprovalRequested: {InApp: true, Email: false},
+		NotificationSyncFailed:        {InApp: false, Email: true},
+	}
+
+	tests := []struct {
+		name     string
+		t        NotificationType
+		expected NotificationPreference
+	}{
+		{"Stored preference", NotificationApprovalRequested, NotificationPreference{InApp: true}},
+		{"Email only", NotificationSyncFailed, NotificationPreference{Email: true}},
+		{"Default with email", NotificationRequestDecided, NotificationPreference{InApp: true, Email: true}},
+		{"Default in-app only", NotificationDocumentExpiring, NotificationPreference{InApp: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, preferences.For(tt.t))
+		})
+	}
+
+	var empty NotificationPreferences
+	assert.Len(t, empty.Resolved(), len(NotificationTypes()))
+	assert.Equal(t, DefaultNotificationPreference(NotificationConversationUpcoming), empty.For(NotificationConversationUpcoming))
+}
+
+func TestNotificationPreferences_Validate(t *testing.T) {
+	assert.NoError(t, NotificationPreferences{NotificationSyncFailed: {InApp: true}}.Validate())
+	assert.ErrorIs(t, NotificationPreferences{"newsletter": {Email: true}}.Validate(), ErrInvalidNotificationType)
+}
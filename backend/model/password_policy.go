@@ -0,0 +1,196 @@
+package model
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Passwortrichtlinien-Fehler
+var (
+	ErrPasswordReused   = errors.New("password was used recently")
+	ErrPasswordBreached = errors.New("password appears in a list of breached passwords")
+	ErrPasswordExpired  = errors.New("password has expired")
+)
+
+// Grenzen für die konfigurierbaren Werte
+const (
+	MinPasswordPolicyLength = 8
+	MaxPasswordPolicyLength = 128
+	MaxPasswordHistorySize  = 24
+)
+
+// PasswordPolicy beschreibt die systemweite Passwortrichtlinie
+type PasswordPolicy struct {
+	MinLength        int  `bson:"minLength" json:"minLength"`
+	RequireUppercase bool `bson:"requireUppercase" json:"requireUppercase"`
+	RequireLowercase bool `bson:"requireLowercase" json:"requireLowercase"`
+	RequireDigit     bool `bson:"requireDigit" json:"requireDigit"`
+	RequireSpecial   bool `bson:"requireSpecial" json:"requireSpecial"`
+	HistorySize      int  `bson:"historySize" json:"historySize"`     // Anzahl der letzten Passwörter, die nicht wiederverwendet werden dürfen (0 = aus)
+	MaxAgeDays       int  `bson:"maxAgeDays" json:"maxAgeDays"`       // Maximales Passwortalter in Tagen (0 = unbegrenzt)
+	CheckBreached    bool `bson:"checkBreached" json:"checkBreached"` // Abgleich mit der Liste kompromittierter Passwörter
+}
+
+// DefaultPasswordPolicy erstellt die Standard-Passwortrichtlinie
+func DefaultPasswordPolicy() *PasswordPolicy {
+	return &PasswordPolicy{
+		MinLength:     MinPasswordPolicyLength,
+		HistorySize:   0,
+		MaxAgeDays:    0,
+		CheckBreached: true,
+	}
+}
+
+// Normalize bringt die Werte der Richtlinie in gültige Bereiche
+func (p *PasswordPolicy) Normalize() {
+	if p.MinLength < MinPasswordPolicyLength {
+		p.MinLength = MinPasswordPolicyLength
+	}
+	if p.MinLength > MaxPasswordPolicyLength {
+		p.MinLength = MaxPasswordPolicyLength
+	}
+	if p.HistorySize < 0 {
+		p.HistorySize = 0
+	}
+	if p.HistorySize > MaxPasswordHistorySize {
+		p.HistorySize = MaxPasswordHistorySize
+	}
+	if p.MaxAgeDays < 0 {
+		p.MaxAgeDays = 0
+	}
+}
+
+// Validate prüft Länge und Zeichenklassen eines Passworts.
+// Passwort-Historie und kompromittierte Passwörter werden separat geprüft.
+func (p *PasswordPolicy) Validate(password string) error {
+	var violations []string
+
+	if len([]rune(password)) < p.MinLength {
+		violations = append(violations, fmt.Sprintf("at least %d characters", p.MinLength))
+	}
+	if len(password) > 72 {
+		// bcrypt verarbeitet nur die ersten 72 Bytes
+		violations = append(violations, "at most 72 bytes")
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSpecial bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSpecial = true
+		}
+	}
+
+	if p.RequireUppercase && !hasUpper {
+		violations = append(violations, "an uppercase letter")
+	}
+	if p.RequireLowercase && !hasLower {
+		violations = append(violations, "a lowercase letter")
+	}
+	if p.RequireDigit && !hasDigit {
+		violations = append(violations, "a digit")
+	}
+	if p.RequireSpecial && !hasSpecial {
+		violations = append(violations, "a special character")
+	}
+
+	if len(violations) > 0 {
+		return fmt.Errorf("%w: password must contain %s", ErrWeakPassword, strings.Join(violations, ", "))
+	}
+	return nil
+}
+
+// Describe gibt die Anforderungen der Richtlinie als deutsche Hinweise zurück
+func (p *PasswordPolicy) Describe() []string {
+	rules := []string{fmt.Sprintf("Mindestens %d Zeichen", p.MinLength)}
+	if p.RequireUppercase {
+		rules = append(rules, "Mindestens ein Großbuchstabe")
+	}
+	if p.RequireLowercase {
+		rules = append(rules, "Mindestens ein Kleinbuchstabe")
+	}
+	if p.RequireDigit {
+		rules = append(rules, "Mindestens eine Ziffer")
+	}
+	if p.RequireSpecial {
+		rules = append(rules, "Mindestens ein Sonderzeichen")
+	}
+	if p.HistorySize > 0 {
+		rules = append(rules, fmt.Sprintf("Keines der letzten %d Passwörter", p.HistorySize))
+	}
+	if p.CheckBreached {
+		rules = append(rules, "Kein bekanntermaßen kompromittiertes Passwort")
+	}
+	return rules
+}
+
+// IsPasswordExpired prüft, ob ein am angegebenen Zeitpunkt gesetztes Passwort abgelaufen ist
+func (p *PasswordPolicy) IsPasswordExpired(changedAt time.Time) bool {
+	if p.MaxAgeDays <= 0 || changedAt.IsZero() {
+		return false
+	}
+	return time.Now().After(changedAt.AddDate(0, 0, p.MaxAgeDays))
+}
+
+// MatchesHistory prüft, ob das Passwort einem der angegebenen bcrypt-Hashes entspricht
+func MatchesHistory(password string, hashes []string) bool {
+	for _, hash := range hashes {
+		if hash == "" {
+			continue
+		}
+		if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// AppendPasswordHistory fügt einen Hash vorne an die Historie an und kürzt sie auf die gewünschte Größe
+func AppendPasswordHistory(history []string, hash string, size int) []string {
+	if size <= 0 || hash == "" {
+		return []string{}
+	}
+	result := append([]string{hash}, history...)
+	if len(result) > size {
+		result = result[:size]
+	}
+	return result
+}
+
+// BreachedPasswordHash berechnet den SHA-1-Hash (Großbuchstaben, hex) im Format gängiger Breach-Listen
+func BreachedPasswordHash(password string) string {
+	sum := sha1.Sum([]byte(password))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+// NormalizeBreachedHashLine parst eine Zeile einer Breach-Liste ("HASH" oder "HASH:COUNT")
+func NormalizeBreachedHashLine(line string) (string, bool) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", false
+	}
+	if idx := strings.IndexByte(line, ':'); idx >= 0 {
+		line = line[:idx]
+	}
+	line = strings.ToUpper(strings.TrimSpace(line))
+	if len(line) != 40 {
+		return "", false
+	}
+	if _, err := hex.DecodeString(line); err != nil {
+		return "", false
+	}
+	return line, true
+}
@@ -0,0 +1,137 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	strict := &PasswordPolicy{
+		MinLength:        10,
+		RequireUppercase: true,
+		RequireLowercase: true,
+		RequireDigit:     true,
+		RequireSpecial:   true,
+	}
+
+	tests := []struct {
+		name     string
+		policy   *PasswordPolicy
+		password string
+		wantErr  bool
+	}{
+		{"Default policy accepts 8 characters", DefaultPasswordPolicy(), "abcdefgh", false},
+		{"Default policy rejects 7 characters", DefaultPasswordPolicy(), "abcdefg", true},
+		{"Strict policy accepts complex password", strict, "Abcdef12!x", false},
+		{"Strict policy rejects missing uppercase", strict, "abcdef12!x", true},
+		{"Strict policy rejects missing lowercase", strict, "ABCDEF12!X", true},
+		{"Strict policy rejects missing digit", strict, "Abcdefgh!x", true},
+		{"Strict policy rejects missing special", strict, "Abcdef12xy", true},
+		{"Strict policy rejects too short", strict, "Ab1!x", true},
+		{"Rejects more than 72 bytes", DefaultPasswordPolicy(), string(make([]byte, 73)), true},
+		{"Counts runes not bytes", DefaultPasswordPolicy(), "äöüäöüäö", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate(tt.password)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrWeakPassword)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPasswordPolicy_Normalize(t *testing.T) {
+	policy := &PasswordPolicy{MinLength: 4, HistorySize: 100, MaxAgeDays: -5}
+	policy.Normalize()
+
+	assert.Equal(t, MinPasswordPolicyLength, policy.MinLength)
+	assert.Equal(t, MaxPasswordHistorySize, policy.HistorySize)
+	assert.Equal(t, 0, policy.MaxAgeDays)
+
+	policy = &PasswordPolicy{MinLength: 500, HistorySize: -1}
+	policy.Normalize()
+
+	assert.Equal(t, MaxPasswordPolicyLength, policy.MinLength)
+	assert.Equal(t, 0, policy.HistorySize)
+}
+
+func TestPasswordPolicy_Describe(t *testing.T) {
+	rules := DefaultPasswordPolicy().Describe()
+	assert.Equal(t, []string{"Mindestens 8 Zeichen", "Kein bekanntermaßen kompromittiertes Passwort"}, rules)
+
+	policy := &PasswordPolicy{MinLength: 12, RequireDigit: true, HistorySize: 5}
+	assert.Equal(t, []string{"Mindestens 12 Zeichen", "Mindestens eine Ziffer", "Keines der letzten 5 Passwörter"}, policy.Describe())
+}
+
+func TestPasswordPolicy_IsPasswordExpired(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxAgeDays int
+		changedAt  time.Time
+		expected   bool
+	}{
+		{"No max age", 0, time.Now().AddDate(-5, 0, 0), false},
+		{"Within max age", 90, time.Now().AddDate(0, 0, -30), false},
+		{"Exceeded max age", 90, time.Now().AddDate(0, 0, -91), true},
+		{"Unknown change date", 90, time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &PasswordPolicy{MinLength: 8, MaxAgeDays: tt.maxAgeDays}
+			assert.Equal(t, tt.expected, policy.IsPasswordExpired(tt.changedAt))
+		})
+	}
+}
+
+func TestAppendPasswordHistory(t *testing.T) {
+	assert.Equal(t, []string{}, AppendPasswordHistory([]string{"a"}, "b", 0))
+	assert.Equal(t, []string{"b", "a"}, AppendPasswordHistory([]string{"a"}, "b", 3))
+	assert.Equal(t, []string{"c", "b"}, AppendPasswordHistory([]string{"b", "a"}, "c", 2))
+}
+
+func TestMatchesHistory(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("OldPassword1"), bcrypt.MinCost)
+	assert.NoError(t, err)
+
+	history := []string{"", string(hash)}
+	assert.True(t, MatchesHistory("OldPassword1", history))
+	assert.False(t, MatchesHistory("NewPassword1", history))
+	assert.False(t, MatchesHistory("OldPassword1", nil))
+}
+
+func TestBreachedPasswordHash(t *testing.T) {
+	// SHA-1 von "password"
+	assert.Equal(t, "5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8", BreachedPasswordHash("password"))
+}
+
+func TestNormalizeBreachedHashLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		expected string
+		ok       bool
+	}{
+		{"Plain hash", "5baa61e4c9b93f3f0682250b6cf8331b7ee68fd8", "5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8", true},
+		{"Hash with count", " 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8:3861493 ", "5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8", true},
+		{"Comment", "# header", "", false},
+		{"Empty line", "   ", "", false},
+		{"Wrong length", "5BAA61E4", "", false},
+		{"Not hex", "ZZAA61E4C9B93F3F0682250B6CF8331B7EE68FD8", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hash, ok := NormalizeBreachedHashLine(tt.line)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, hash)
+		})
+	}
+}
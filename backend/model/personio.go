@@ -0,0 +1,381 @@
+// backend/model/personio.go
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrInvalidPersonioAttributeMapping wird bei einer fehlerhaften Attributzuordnung zurückgegeben
+var ErrInvalidPersonioAttributeMapping = errors.New("invalid Personio attribute mapping")
+
+// PersonioSource ist die Quelle der aus Personio übernommenen Zeiteinträge
+const PersonioSource = "personio"
+
+// PersonioAttribute ist ein Attribut eines Personio-Mitarbeiters. Personio liefert Standardattribute
+// (z.B. first_name) und eigene Attribute (dynamic_<ID>) jeweils mit Bezeichnung, Typ und Wert.
+type PersonioAttribute struct {
+	Label       string `json:"label"`
+	Value       any    `json:"value"`
+	Type        string `json:"type"`
+	UniversalID string `json:"universal_id"`
+}
+
+// Text gibt den Wert des Attributs als Text zurück. Datumswerte werden auf YYYY-MM-DD gekürzt,
+// bei verknüpften Objekten (Abteilung, Standort) wird deren Name verwendet.
+func (a PersonioAttribute) Text() string {
+	switch value := a.Value.(type) {
+	case nil:
+		return ""
+	case string:
+		value = strings.TrimSpace(value)
+		if a.Type == "date" && len(value) > 10 {
+			return value[:10]
+		}
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(value)
+	case map[string]any:
+		attributes, _ := value["attributes"].(map[string]any)
+		for _, key := range []string{"name", "value"} {
+			if name, ok := attributes[key]; ok && name != nil {
+				return strings.TrimSpace(fmt.Sprint(name))
+			}
+		}
+		return ""
+	default:
+		return strings.TrimSpace(fmt.Sprint(value))
+	}
+}
+
+// PersonioEmployee ist ein Mitarbeiter aus der Personio-API
+type PersonioEmployee struct {
+	Attributes map[string]PersonioAttribute `json:"attributes"`
+}
+
+// Attribute gibt den Wert eines Attributs zurück. name ist der Schlüssel (z.B. "hire_date" oder
+// "dynamic_123456") oder die in Personio angezeigte Bezeichnung; Groß- und Kleinschreibung
+// wird nicht beachtet.
+func (p PersonioEmployee) Attribute(name string) string {
+	if attribute, ok := p.Attributes[name]; ok {
+		return attribute.Text()
+	}
+	name = strings.TrimSpace(name)
+	for key, attribute := range p.Attributes {
+		if strings.EqualFold(key, name) || strings.EqualFold(attribute.Label, name) {
+			return attribute.Text()
+		}
+	}
+	return ""
+}
+
+// ID gibt die Personio-ID des Mitarbeiters zurück
+func (p PersonioEmployee) ID() string {
+	return p.Attribute("id")
+}
+
+// IsActive prüft, ob der Mitarbeiter in Personio nicht ausgeschieden ist
+// (aktiv, im Onboarding oder in Abwesenheit wie Elternzeit)
+func (p PersonioEmployee) IsActive() bool {
+	return !strings.EqualFold(p.Attribute("status"), "inactive")
+}
+
+// MappedValues gibt die Werte der zugeordneten Mitarbeiterfelder zurück (Feld → Wert)
+func (p PersonioEmployee) MappedValues(mapping PersonioAttributeMapping) map[string]string {
+	values := make(map[string]string, len(mapping))
+	for field, attribute := range mapping {
+		values[field] = p.Attribute(attribute)
+	}
+	return values
+}
+
+// Identity wandelt den Mitarbeiter in einen externen Benutzer für den Abgleich um
+func (p PersonioEmployee) Identity(mapping PersonioAttributeMapping) ExternalIdentity {
+	values := p.MappedValues(mapping)
+	identity := ExternalIdentity{
+		ExternalID: p.ID(),
+		FirstName:  p.Attribute("first_name"),
+		LastName:   p.Attribute("last_name"),
+		Email:      p.Attribute("email"),
+		Department: values["department"],
+		Phone:      values["phone"],
+	}
+	identity.HireDate, _ = time.Parse("2006-01-02", values["hireDate"])
+	identity.DateOfBirth, _ = time.Parse("2006-01-02", values["dateOfBirth"])
+	return identity
+}
+
+// PersonioMappableFields sind die Mitarbeiterfelder, denen Personio-Attribute zugeordnet werden können
+var PersonioMappableFields = []string{"phone", "position", "department", "hireDate", "dateOfBirth"}
+
+// PersonioAttributeMapping ordnet Mitarbeiterfeldern Personio-Attribute zu (Feld → Attribut)
+type PersonioAttributeMapping map[string]string
+
+// DefaultPersonioAttributeMapping gibt die Zuordnung der Personio-Standardattribute zurück.
+// Telefon und Geburtsdatum sind in Personio eigene Attribute und müssen zugeordnet werden.
+func DefaultPersonioAttributeMapping() PersonioAttributeMapping {
+	return PersonioAttributeMapping{
+		"position":   "position",
+		"department": "department",
+		"hireDate":   "hire_date",
+	}
+}
+
+// ParsePersonioAttributeMapping liest eine Zuordnung im Format "Attribut=Feld, ..." und ergänzt
+// sie um die Standardzuordnung, z.B. "dynamic_123456=phone, Geburtstag=dateOfBirth"
+func ParsePersonioAttributeMapping(value string) (PersonioAttributeMapping, error) {
+	mapping := DefaultPersonioAttributeMapping()
+	entries := strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ';' || r == '\n' })
+	for _, entry := range entries {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		attribute, field, ok := strings.Cut(entry, "=")
+		attribute, field = strings.TrimSpace(attribute), strings.TrimSpace(field)
+		if !ok || attribute == "" || field == "" {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidPersonioAttributeMapping, strings.TrimSpace(entry))
+		}
+		if !isPersonioMappableField(field) {
+			return nil, fmt.Errorf("%w: unbekanntes Feld %q", ErrInvalidPersonioAttributeMapping, field)
+		}
+		mapping[field] = attribute
+	}
+	return mapping, nil
+}
+
+// String gibt die Zuordnung im Format von ParsePersonioAttributeMapping zurück
+func (m PersonioAttributeMapping) String() string {
+	fields := make([]string, 0, len(m))
+	for field := range m {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	entries := make([]string, 0, len(fields))
+	for _, field := range fields {
+		entries = append(entries, m[field]+"="+field)
+	}
+	return strings.Join(entries, ", ")
+}
+
+func isPersonioMappableField(field string) bool {
+	for _, mappable := range PersonioMappableFields {
+		if mappable == field {
+			return true
+		}
+	}
+	return false
+}
+
+// PersonioTimeOff ist eine Abwesenheit (Time-off) aus der Personio-API
+type PersonioTimeOff struct {
+	ID           string    `json:"id"`
+	EmployeeID   string    `json:"employeeId"`
+	TypeName     string    `json:"typeName"` // Bezeichnung der Abwesenheitsart, z.B. "Urlaub"
+	Status       string    `json:"status"`   // approved, pending, rejected, cancelled
+	StartDate    time.Time `json:"startDate"`
+	EndDate      time.Time `json:"endDate"`
+	DaysCount    float64   `json:"daysCount"`
+	HalfDayStart bool      `json:"halfDayStart"`
+	HalfDayEnd   bool      `json:"halfDayEnd"`
+	Comment      string    `json:"comment"`
+}
+
+// Hash gibt eine Prüfsumme der Felder zurück, die in eine Abwesenheit übernommen werden.
+// Ändert sich die Prüfsumme, wurde die Abwesenheit in Personio bearbeitet.
+func (t PersonioTimeOff) Hash() string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		t.ID,
+		t.EmployeeID,
+		t.TypeName,
+		t.Status,
+		t.StartDate.Format("2006-01-02"),
+		t.EndDate.Format("2006-01-02"),
+		fmt.Sprint(t.DaysCount, t.HalfDayStart, t.HalfDayEnd),
+		t.Comment,
+	}, ";")))
+	return hex.EncodeToString(sum[:])
+}
+
+// PeopleFlowType gibt den PeopleFlow-Abwesenheitstyp zurück (vacation, sick oder special)
+func (t PersonioTimeOff) PeopleFlowType() string {
+	typeName := strings.ToLower(t.TypeName)
+	switch {
+	case strings.Contains(typeName, "krank"), strings.Contains(typeName, "sick"):
+		return "sick"
+	case strings.Contains(typeName, "sonder"), strings.Contains(typeName, "special"):
+		return "special"
+	case strings.Contains(typeName, "urlaub"), strings.Contains(typeName, "vacation"),
+		strings.Contains(typeName, "holiday"), strings.Contains(typeName, "paid time off"):
+		return "vacation"
+	default:
+		return "special"
+	}
+}
+
+// PeopleFlowStatus gibt den PeopleFlow-Status zurück (requested, approved, rejected oder cancelled)
+func (t PersonioTimeOff) PeopleFlowStatus() string {
+	switch strings.ToLower(t.Status) {
+	case "pending", "requested":
+		return "requested"
+	case "rejected":
+		return "rejected"
+	case "cancelled", "canceled":
+		return "cancelled"
+	default:
+		return "approved"
+	}
+}
+
+// Days gibt die Abwesenheitstage zurück; fehlen sie in Personio, werden sie aus dem
+// Zeitraum berechnet (mindestens ein halber Tag)
+func (t PersonioTimeOff) Days() float64 {
+	if t.DaysCount > 0 {
+		return t.DaysCount
+	}
+	days := t.EndDate.Sub(t.StartDate).Hours()/24 + 1
+	if t.HalfDayStart {
+		days -= 0.5
+	}
+	if t.HalfDayEnd && !t.EndDate.Equal(t.StartDate) {
+		days -= 0.5
+	}
+	return max(days, 0.5)
+}
+
+// applyTo überträgt die Personio-Daten auf eine Abwesenheit
+func (t PersonioTimeOff) applyTo(absence *Absence) {
+	absence.Type = t.PeopleFlowType()
+	absence.StartDate = t.StartDate
+	absence.EndDate = t.EndDate
+	absence.Days = t.Days()
+	absence.Status = t.PeopleFlowStatus()
+	absence.Reason = t.TypeName
+	absence.Notes = t.Comment
+	absence.PersonioID = t.ID
+	absence.PersonioHash = t.Hash()
+}
+
+// ApplyPersonioTimeOffs gleicht die Abwesenheiten des Mitarbeiters mit den Personio-Abwesenheiten
+// im Zeitraum start bis end ab. Abwesenheiten werden über die Personio-ID zugeordnet und nur
+// übernommen, wenn sich ihre Prüfsumme geändert hat; noch keinem System zugeordnete Abwesenheiten
+// mit gleichem Zeitraum erhalten die ID. Zugeordnete Abwesenheiten, die im Zeitraum beginnen und
+// in Personio fehlen, wurden dort gelöscht und werden entfernt.
+func (e *Employee) ApplyPersonioTimeOffs(start, end time.Time, timeOffs []PersonioTimeOff) AbsenceSyncResult {
+	var result AbsenceSyncResult
+	seen := make(map[string]bool, len(timeOffs))
+
+	for _, timeOff := range timeOffs {
+		seen[timeOff.ID] = true
+
+		index := e.personioAbsenceIndex(timeOff)
+		if index < 0 {
+			absence := Absence{ID: primitive.NewObjectID()}
+			timeOff.applyTo(&absence)
+			e.Absences = append(e.Absences, absence)
+			result.Created++
+			continue
+		}
+
+		absence := &e.Absences[index]
+		if absence.PersonioID == timeOff.ID && absence.PersonioHash == timeOff.Hash() {
+			result.Unchanged++
+			continue
+		}
+		wasCancelled := absence.Status == "cancelled"
+		timeOff.applyTo(absence)
+		if absence.Status == "cancelled" && !wasCancelled {
+			result.Cancelled++
+		} else {
+			result.Updated++
+		}
+	}
+
+	kept := e.Absences[:0]
+	for _, absence := range e.Absences {
+		if absence.PersonioID != "" && !seen[absence.PersonioID] &&
+			!absence.StartDate.Before(start) && !absence.StartDate.After(end) {
+			result.Removed++
+			continue
+		}
+		kept = append(kept, absence)
+	}
+	e.Absences = kept
+
+	return result
+}
+
+// personioAbsenceIndex sucht die Abwesenheit zu einer Personio-Abwesenheit: zuerst über die ID,
+// dann über den Zeitraum einer Abwesenheit, die noch keinem System zugeordnet ist
+func (e *Employee) personioAbsenceIndex(timeOff PersonioTimeOff) int {
+	for i, absence := range e.Absences {
+		if absence.PersonioID == timeOff.ID {
+			return i
+		}
+	}
+	for i, absence := range e.Absences {
+		if absence.PersonioID == "" && absence.TimebutlerID == "" &&
+			absence.StartDate.Equal(timeOff.StartDate) && absence.EndDate.Equal(timeOff.EndDate) {
+			return i
+		}
+	}
+	return -1
+}
+
+// PersonioAttendance ist eine Anwesenheit (Arbeitszeit) aus der Personio-API
+type PersonioAttendance struct {
+	ID           string `json:"id"`
+	EmployeeID   string `json:"employeeId"`
+	Date         string `json:"date"`      // YYYY-MM-DD
+	StartTime    string `json:"startTime"` // HH:MM
+	EndTime      string `json:"endTime"`   // HH:MM, leer bei noch laufender Erfassung
+	BreakMinutes int    `json:"breakMinutes"`
+	ProjectName  string `json:"projectName"`
+	Comment      string `json:"comment"`
+}
+
+// TimeEntry wandelt die Anwesenheit in einen Zeiteintrag um. Endet sie vor ihrem Beginn,
+// reicht sie über Mitternacht. Noch laufende Anwesenheiten ohne Ende werden abgelehnt.
+func (a PersonioAttendance) TimeEntry(location *time.Location) (TimeEntry, error) {
+	date, err := time.ParseInLocation("2006-01-02", a.Date, location)
+	if err != nil {
+		return TimeEntry{}, fmt.Errorf("invalid attendance date %q: %w", a.Date, err)
+	}
+	if a.EndTime == "" {
+		return TimeEntry{}, fmt.Errorf("attendance %s on %s has no end time", a.ID, a.Date)
+	}
+	start, err := time.ParseInLocation("2006-01-02 15:04", a.Date+" "+a.StartTime, location)
+	if err != nil {
+		return TimeEntry{}, fmt.Errorf("invalid attendance start %q: %w", a.StartTime, err)
+	}
+	end, err := time.ParseInLocation("2006-01-02 15:04", a.Date+" "+a.EndTime, location)
+	if err != nil {
+		return TimeEntry{}, fmt.Errorf("invalid attendance end %q: %w", a.EndTime, err)
+	}
+	if end.Before(start) {
+		end = end.AddDate(0, 0, 1)
+	}
+
+	duration := end.Sub(start).Hours() - float64(a.BreakMinutes)/60
+	return TimeEntry{
+		ID:          primitive.NewObjectID(),
+		Date:        date,
+		StartTime:   start,
+		EndTime:     end,
+		Duration:    max(duration, 0),
+		ProjectName: a.ProjectName,
+		Activity:    "Anwesenheit",
+		Description: a.Comment,
+		Source:      PersonioSource,
+	}, nil
+}
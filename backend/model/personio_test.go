@@ -0,0 +1,218 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func personioTestEmployee() PersonioEmployee {
+	return PersonioEmployee{Attributes: map[string]PersonioAttribute{
+		"id":         {Label: "ID", Value: float64(4711), Type: "integer"},
+		"first_name": {Label: "Vorname", Value: "Anna", Type: "standard"},
+		"last_name":  {Label: "Nachname", Value: "Schmidt", Type: "standard"},
+		"email":      {Label: "E-Mail", Value: "anna@example.com", Type: "standard"},
+		"status":     {Label: "Status", Value: "active", Type: "standard"},
+		"position":   {Label: "Position", Value: "Entwicklerin", Type: "standard"},
+		"hire_date":  {Label: "Eintrittsdatum", Value: "2021-03-15T00:00:00+01:00", Type: "date"},
+		"department": {Label: "Abteilung", Type: "standard", Value: map[string]any{
+			"type":       "Department",
+			"attributes": map[string]any{"id": float64(3), "name": "IT"},
+		}},
+		"dynamic_99": {Label: "Telefon geschäftlich", Value: "030-123", Type: "standard"},
+		"dynamic_98": {Label: "Geburtstag", Value: "1990-07-01T00:00:00+02:00", Type: "date"},
+	}}
+}
+
+func TestPersonioEmployee_Attribute(t *testing.T) {
+	employee := personioTestEmployee()
+
+	assert.Equal(t, "4711", employee.ID())
+	assert.Equal(t, "2021-03-15", employee.Attribute("hire_date"))
+	assert.Equal(t, "IT", employee.Attribute("department"))
+	assert.Equal(t, "030-123", employee.Attribute("telefon GESCHÄFTLICH"), "Bezeichnung ohne Groß-/Kleinschreibung")
+	assert.Equal(t, "", employee.Attribute("unbekannt"))
+	assert.True(t, employee.IsActive())
+
+	employee.Attributes["status"] = PersonioAttribute{Value: "inactive"}
+	assert.False(t, employee.IsActive())
+}
+
+func TestParsePersonioAttributeMapping(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    PersonioAttributeMapping
+		wantErr bool
+	}{
+		{"leer ergibt Standard", "", DefaultPersonioAttributeMapping(), false},
+		{"eigene Attribute", "dynamic_99=phone; Geburtstag = dateOfBirth", PersonioAttributeMapping{
+			"position": "position", "department": "department", "hireDate": "hire_date",
+			"phone": "dynamic_99", "dateOfBirth": "Geburtstag",
+		}, false},
+		{"Standard überschreiben", "Funktion=position", PersonioAttributeMapping{
+			"position": "Funktion", "department": "department", "hireDate": "hire_date",
+		}, false},
+		{"unbekanntes Feld", "dynamic_1=salary", nil, true},
+		{"ohne Gleichheitszeichen", "dynamic_1", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePersonioAttributeMapping(tt.value)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidPersonioAttributeMapping)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPersonioAttributeMapping_StringRoundTrip(t *testing.T) {
+	mapping, err := ParsePersonioAttributeMapping("dynamic_99=phone")
+	require.NoError(t, err)
+
+	assert.Equal(t, "department=department, hire_date=hireDate, dynamic_99=phone, position=position", mapping.String())
+	parsed, err := ParsePersonioAttributeMapping(mapping.String())
+	require.NoError(t, err)
+	assert.Equal(t, mapping, parsed)
+}
+
+func TestPersonioEmployee_Identity(t *testing.T) {
+	mapping, err := ParsePersonioAttributeMapping("dynamic_99=phone, Geburtstag=dateOfBirth")
+	require.NoError(t, err)
+
+	identity := personioTestEmployee().Identity(mapping)
+
+	assert.Equal(t, "4711", identity.ExternalID)
+	assert.Equal(t, "Anna", identity.FirstName)
+	assert.Equal(t, "anna@example.com", identity.Email)
+	assert.Equal(t, "IT", identity.Department)
+	assert.Equal(t, "030-123", identity.Phone)
+	assert.Equal(t, time.Date(2021, 3, 15, 0, 0, 0, 0, time.UTC), identity.HireDate)
+	assert.Equal(t, time.Date(1990, 7, 1, 0, 0, 0, 0, time.UTC), identity.DateOfBirth)
+}
+
+func personioTestTimeOff(id string, day int, status string) PersonioTimeOff {
+	return PersonioTimeOff{
+		ID:         id,
+		EmployeeID: "4711",
+		TypeName:   "Urlaub",
+		Status:     status,
+		StartDate:  time.Date(2025, 6, day, 0, 0, 0, 0, time.UTC),
+		EndDate:    time.Date(2025, 6, day+1, 0, 0, 0, 0, time.UTC),
+		DaysCount:  2,
+	}
+}
+
+func TestPersonioTimeOff_Mapping(t *testing.T) {
+	tests := []struct {
+		typeName, status    string
+		wantType, wantState string
+	}{
+		{"Urlaub", "approved", "vacation", "approved"},
+		{"Krankheit", "pending", "sick", "requested"},
+		{"Sonderurlaub", "rejected", "special", "rejected"},
+		{"Elternzeit", "cancelled", "special", "cancelled"},
+		{"Paid time off", "approved", "vacation", "approved"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typeName+"/"+tt.status, func(t *testing.T) {
+			timeOff := PersonioTimeOff{TypeName: tt.typeName, Status: tt.status}
+			assert.Equal(t, tt.wantType, timeOff.PeopleFlowType())
+			assert.Equal(t, tt.wantState, timeOff.PeopleFlowStatus())
+		})
+	}
+}
+
+func TestPersonioTimeOff_Days(t *testing.T) {
+	day := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 1.5, PersonioTimeOff{DaysCount: 1.5}.Days())
+	assert.Equal(t, 3.0, PersonioTimeOff{StartDate: day, EndDate: day.AddDate(0, 0, 2)}.Days())
+	assert.Equal(t, 0.5, PersonioTimeOff{StartDate: day, EndDate: day, HalfDayStart: true}.Days())
+	assert.Equal(t, 2.0, PersonioTimeOff{StartDate: day, EndDate: day.AddDate(0, 0, 2), HalfDayStart: true, HalfDayEnd: true}.Days())
+}
+
+func TestEmployee_ApplyPersonioTimeOffs(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+	employee := &Employee{}
+
+	result := employee.ApplyPersonioTimeOffs(start, end, []PersonioTimeOff{
+		personioTestTimeOff("1", 2, "approved"),
+		personioTestTimeOff("2", 10, "pending"),
+	})
+	assert.Equal(t, AbsenceSyncResult{Created: 2}, result)
+	require.Len(t, employee.Absences, 2)
+	assert.Equal(t, "1", employee.Absences[0].PersonioID)
+	assert.Equal(t, "requested", employee.Absences[1].Status)
+
+	// Unveränderte Abwesenheiten werden übersprungen
+	result = employee.ApplyPersonioTimeOffs(start, end, []PersonioTimeOff{
+		personioTestTimeOff("1", 2, "approved"),
+		personioTestTimeOff("2", 10, "pending"),
+	})
+	assert.Equal(t, AbsenceSyncResult{Unchanged: 2}, result)
+
+	// Geändert, storniert und gelöscht
+	changed := personioTestTimeOff("2", 10, "approved")
+	result = employee.ApplyPersonioTimeOffs(start, end, []PersonioTimeOff{changed})
+	assert.Equal(t, AbsenceSyncResult{Updated: 1, Removed: 1}, result)
+	require.Len(t, employee.Absences, 1)
+	assert.Equal(t, "approved", employee.Absences[0].Status)
+
+	result = employee.ApplyPersonioTimeOffs(start, end, []PersonioTimeOff{personioTestTimeOff("2", 10, "cancelled")})
+	assert.Equal(t, AbsenceSyncResult{Cancelled: 1}, result)
+}
+
+func TestEmployee_ApplyPersonioTimeOffs_KeepsOtherAbsences(t *testing.T) {
+	start := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+	manual := personioTestTimeOff("", 2, "approved")
+	employee := &Employee{Absences: []Absence{
+		// In PeopleFlow erfasst, gleicher Zeitraum: erhält die Personio-ID
+		{ID: primitive.NewObjectID(), StartDate: manual.StartDate, EndDate: manual.EndDate, Status: "requested"},
+		// Aus Timebutler: bleibt unverändert
+		{ID: primitive.NewObjectID(), StartDate: manual.StartDate, EndDate: manual.EndDate, TimebutlerID: "tb-1"},
+		// Personio-Abwesenheit außerhalb des Zeitraums: wird nicht entfernt
+		{ID: primitive.NewObjectID(), StartDate: start.AddDate(0, 2, 0), EndDate: start.AddDate(0, 2, 1), PersonioID: "9"},
+	}}
+
+	result := employee.ApplyPersonioTimeOffs(start, end, []PersonioTimeOff{personioTestTimeOff("1", 2, "approved")})
+
+	assert.Equal(t, AbsenceSyncResult{Updated: 1}, result)
+	require.Len(t, employee.Absences, 3)
+	assert.Equal(t, "1", employee.Absences[0].PersonioID)
+	assert.Equal(t, "approved", employee.Absences[0].Status)
+	assert.Empty(t, employee.Absences[1].PersonioID)
+}
+
+func TestPersonioAttendance_TimeEntry(t *testing.T) {
+	location := time.FixedZone("CET", 3600)
+
+	entry, err := PersonioAttendance{
+		ID: "7", Date: "2025-06-02", StartTime: "08:00", EndTime: "16:30", BreakMinutes: 30,
+		ProjectName: "Website", Comment: "Relaunch",
+	}.TimeEntry(location)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 6, 2, 0, 0, 0, 0, location), entry.Date)
+	assert.Equal(t, time.Date(2025, 6, 2, 8, 0, 0, 0, location), entry.StartTime)
+	assert.Equal(t, 8.0, entry.Duration)
+	assert.Equal(t, "Website", entry.ProjectName)
+	assert.Equal(t, PersonioSource, entry.Source)
+
+	// Nachtschicht über Mitternacht
+	entry, err = PersonioAttendance{Date: "2025-06-02", StartTime: "22:00", EndTime: "06:00"}.TimeEntry(location)
+	require.NoError(t, err)
+	assert.Equal(t, 8.0, entry.Duration)
+
+	_, err = PersonioAttendance{Date: "2025-06-02", StartTime: "08:00"}.TimeEntry(location)
+	assert.Error(t, err, "laufende Anwesenheit ohne Ende")
+}
@@ -0,0 +1,289 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ReportType bezeichnet einen per E-Mail abonnierbaren Bericht
+type ReportType string
+
+const (
+	ReportWeeklyTimeSummary ReportType = "weekly_time_summary" // Persönliche Arbeitszeit der Woche
+	ReportMonthlyOvertime   ReportType = "monthly_overtime"    // Persönliche Überstunden-Abrechnung des Vormonats
+	ReportTeamDigest        ReportType = "team_digest"         // Arbeitszeiten und Abwesenheiten des Teams
+	ReportMonthlyAbsences   ReportType = "monthly_absences"    // Abwesenheiten aller Mitarbeiter im Vormonat
+)
+
+// ReportFrequency bestimmt, wie oft ein Bericht versendet wird
+type ReportFrequency string
+
+const (
+	ReportWeekly  ReportFrequency = "weekly"
+	ReportMonthly ReportFrequency = "monthly"
+)
+
+// ReportSection ist ein optionaler Abschnitt eines Berichts
+type ReportSection string
+
+const (
+	ReportSectionDays        ReportSection = "days"        // Stunden je Tag
+	ReportSectionProjects    ReportSection = "projects"    // Stunden je Projekt
+	ReportSectionOvertime    ReportSection = "overtime"    // Soll/Ist und Überstundensaldo
+	ReportSectionWeeks       ReportSection = "weeks"       // Soll/Ist je Woche
+	ReportSectionAdjustments ReportSection = "adjustments" // Überstunden-Anpassungen
+	ReportSectionHours       ReportSection = "hours"       // Stunden je Teammitglied
+	ReportSectionAbsences    ReportSection = "absences"    // Einzelne Abwesenheiten
+	ReportSectionTotals      ReportSection = "totals"      // Tage je Abwesenheitsart
+)
+
+// Berichts-Abonnement-Fehler
+var (
+	ErrInvalidReportType      = errors.New("invalid report type")
+	ErrInvalidReportFrequency = errors.New("invalid report frequency")
+	ErrInvalidReportSchedule  = errors.New("invalid report schedule")
+	ErrInvalidReportSection   = errors.New("invalid report section")
+)
+
+// ReportSubscription ist das Abonnement eines Benutzers für einen Bericht per E-Mail
+type ReportSubscription struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID     primitive.ObjectID `bson:"userId" json:"userId"`
+	Type       ReportType         `bson:"type" json:"type"`
+	Frequency  ReportFrequency    `bson:"frequency" json:"frequency"`
+	Weekday    time.Weekday       `bson:"weekday" json:"weekday"`       // Bei wöchentlichem Versand (0 = Sonntag)
+	DayOfMonth int                `bson:"dayOfMonth" json:"dayOfMonth"` // Bei monatlichem Versand (1-28)
+	Hour       int                `bson:"hour" json:"hour"`             // Uhrzeit des Versands (0-23)
+	Sections   []ReportSection    `bson:"sections" json:"sections"`     // Leer = alle Abschnitte
+	Department Department         `bson:"department,omitempty" json:"department,omitempty"`
+	Active     bool               `bson:"active" json:"active"`
+	LastSentAt *time.Time         `bson:"lastSentAt,omitempty" json:"lastSentAt,omitempty"`
+	CreatedAt  time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt  time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
+
+// ReportTypes gibt alle Berichtsarten zurück
+func ReportTypes() []ReportType {
+	return []ReportType{ReportWeeklyTimeSummary, ReportMonthlyOvertime, ReportTeamDigest, ReportMonthlyAbsences}
+}
+
+// IsValid prüft, ob die Berichtsart bekannt ist
+func (t ReportType) IsValid() bool {
+	for _, reportType := range ReportTypes() {
+		if t == reportType {
+			return true
+		}
+	}
+	return false
+}
+
+// GetLabel gibt eine deutsche Bezeichnung für die Berichtsart zurück
+func (t ReportType) GetLabel() string {
+	switch t {
+	case ReportWeeklyTimeSummary:
+		return "Wöchentliche Arbeitszeit"
+	case ReportMonthlyOvertime:
+		return "Monatliche Überstunden-Abrechnung"
+	case ReportTeamDigest:
+		return "Team-Übersicht"
+	case ReportMonthlyAbsences:
+		return "Monatliche Abwesenheitsübersicht"
+	default:
+		return string(t)
+	}
+}
+
+// IsPersonal gibt an, ob der Bericht die eigenen Daten des Empfängers enthält
+// (dafür muss der Benutzer mit einem Mitarbeiter verknüpft sein)
+func (t ReportType) IsPersonal() bool {
+	return t == ReportWeeklyTimeSummary || t == ReportMonthlyOvertime
+}
+
+// AllowedRoles gibt die Rollen zurück, die den Bericht abonnieren dürfen (nil = alle)
+func (t ReportType) AllowedRoles() []UserRole {
+	switch t {
+	case ReportTeamDigest:
+		return []UserRole{RoleAdmin, RoleManager, RoleHR}
+	case ReportMonthlyAbsences:
+		return []UserRole{RoleAdmin, RoleHR}
+	default:
+		return nil
+	}
+}
+
+// AllowsRole prüft, ob ein Benutzer mit dieser Rolle den Bericht abonnieren darf
+func (t ReportType) AllowsRole(role UserRole) bool {
+	roles := t.AllowedRoles()
+	if roles == nil {
+		return true
+	}
+	for _, allowed := range roles {
+		if role == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultFrequency gibt den üblichen Versandrhythmus der Berichtsart zurück
+func (t ReportType) DefaultFrequency() ReportFrequency {
+	if t == ReportWeeklyTimeSummary || t == ReportTeamDigest {
+		return ReportWeekly
+	}
+	return ReportMonthly
+}
+
+// Frequencies gibt die für die Berichtsart möglichen Versandrhythmen zurück
+func (t ReportType) Frequencies() []ReportFrequency {
+	switch t {
+	case ReportWeeklyTimeSummary:
+		return []ReportFrequency{ReportWeekly}
+	case ReportTeamDigest:
+		return []ReportFrequency{ReportWeekly, ReportMonthly}
+	default:
+		return []ReportFrequency{ReportMonthly}
+	}
+}
+
+// Sections gibt die wählbaren Abschnitte der Berichtsart zurück
+func (t ReportType) Sections() []ReportSection {
+	switch t {
+	case ReportWeeklyTimeSummary:
+		return []ReportSection{ReportSectionDays, ReportSectionProjects, ReportSectionOvertime}
+	case ReportMonthlyOvertime:
+		return []ReportSection{ReportSectionWeeks, ReportSectionAdjustments}
+	case ReportTeamDigest:
+		return []ReportSection{ReportSectionHours, ReportSectionOvertime, ReportSectionAbsences}
+	case ReportMonthlyAbsences:
+		return []ReportSection{ReportSectionTotals, ReportSectionAbsences}
+	default:
+		return nil
+	}
+}
+
+// GetLabel gibt eine deutsche Bezeichnung für den Abschnitt zurück
+func (s ReportSection) GetLabel() string {
+	switch s {
+	case ReportSectionDays:
+		return "Stunden je Tag"
+	case ReportSectionProjects:
+		return "Stunden je Projekt"
+	case ReportSectionOvertime:
+		return "Soll/Ist und Überstunden"
+	case ReportSectionWeeks:
+		return "Aufstellung je Woche"
+	case ReportSectionAdjustments:
+		return "Überstunden-Anpassungen"
+	case ReportSectionHours:
+		return "Stunden je Mitarbeiter"
+	case ReportSectionAbsences:
+		return "Einzelne Abwesenheiten"
+	case ReportSectionTotals:
+		return "Tage je Abwesenheitsart"
+	default:
+		return string(s)
+	}
+}
+
+// Validate prüft Berichtsart, Rhythmus, Zeitplan und Abschnitte
+func (s *ReportSubscription) Validate() error {
+	if !s.Type.IsValid() {
+		return fmt.Errorf("%w: %s", ErrInvalidReportType, s.Type)
+	}
+
+	validFrequency := false
+	for _, frequency := range s.Type.Frequencies() {
+		if s.Frequency == frequency {
+			validFrequency = true
+		}
+	}
+	if !validFrequency {
+		return fmt.Errorf("%w: %s", ErrInvalidReportFrequency, s.Frequency)
+	}
+
+	if s.Hour < 0 || s.Hour > 23 {
+		return fmt.Errorf("%w: Stunde %d", ErrInvalidReportSchedule, s.Hour)
+	}
+	if s.Frequency == ReportWeekly && (s.Weekday < time.Sunday || s.Weekday > time.Saturday) {
+		return fmt.Errorf("%w: Wochentag %d", ErrInvalidReportSchedule, s.Weekday)
+	}
+	// Höchstens der 28., damit der Bericht in jedem Monat versendet wird
+	if s.Frequency == ReportMonthly && (s.DayOfMonth < 1 || s.DayOfMonth > 28) {
+		return fmt.Errorf("%w: Tag %d", ErrInvalidReportSchedule, s.DayOfMonth)
+	}
+
+	allowed := make(map[ReportSection]bool)
+	for _, section := range s.Type.Sections() {
+		allowed[section] = true
+	}
+	for _, section := range s.Sections {
+		if !allowed[section] {
+			return fmt.Errorf("%w: %s", ErrInvalidReportSection, section)
+		}
+	}
+	return nil
+}
+
+// Includes prüft, ob ein Abschnitt im Bericht enthalten sein soll
+func (s *ReportSubscription) Includes(section ReportSection) bool {
+	if len(s.Sections) == 0 {
+		return true
+	}
+	for _, included := range s.Sections {
+		if included == section {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDue prüft, ob der Bericht zum Zeitpunkt now versendet werden soll. Ist die Stunde
+// verpasst worden (z.B. Neustart), wird der Versand am selben Tag nachgeholt.
+func (s *ReportSubscription) IsDue(now time.Time) bool {
+	if !s.Active || now.Hour() < s.Hour {
+		return false
+	}
+
+	switch s.Frequency {
+	case ReportWeekly:
+		if now.Weekday() != s.Weekday {
+			return false
+		}
+	case ReportMonthly:
+		if now.Day() != s.DayOfMonth {
+			return false
+		}
+	default:
+		return false
+	}
+
+	if s.LastSentAt != nil {
+		last := s.LastSentAt.In(now.Location())
+		if last.Year() == now.Year() && last.YearDay() == now.YearDay() {
+			return false
+		}
+	}
+	return true
+}
+
+// ReportPeriod gibt den Berichtszeitraum [start, end) für einen Versand zum Zeitpunkt now zurück.
+// Wöchentlich: die laufende Kalenderwoche ab Freitag, sonst die Vorwoche.
+// Monatlich: der Vormonat.
+func ReportPeriod(frequency ReportFrequency, now time.Time) (time.Time, time.Time) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	if frequency == ReportMonthly {
+		end := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+		return end.AddDate(0, -1, 0), end
+	}
+
+	daysSinceMonday := (int(today.Weekday()) + 6) % 7
+	start := today.AddDate(0, 0, -daysSinceMonday)
+	if today.Weekday() >= time.Monday && today.Weekday() <= time.Thursday {
+		start = start.AddDate(0, 0, -7)
+	}
+	return start, start.AddDate(0, 0, 7)
+}
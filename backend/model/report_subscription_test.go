@@ -0,0 +1,109 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportSubscription_Validate(t *testing.T) {
+	tests := []struct {
+		name         string
+		subscription ReportSubscription
+		wantErr      error
+	}{
+		{"Valid weekly summary", ReportSubscription{Type: ReportWeeklyTimeSummary, Frequency: ReportWeekly, Weekday: time.Friday, Hour: 17}, nil},
+		{"Valid monthly team digest", ReportSubscription{Type: ReportTeamDigest, Frequency: ReportMonthly, DayOfMonth: 1, Hour: 8, Sections: []ReportSection{ReportSectionHours}}, nil},
+		{"Unknown type", ReportSubscription{Type: "payroll", Frequency: ReportMonthly, DayOfMonth: 1}, ErrInvalidReportType},
+		{"Frequency not offered for type", ReportSubscription{Type: ReportMonthlyOvertime, Frequency: ReportWeekly, Weekday: time.Monday}, ErrInvalidReportFrequency},
+		{"Hour out of range", ReportSubscription{Type: ReportWeeklyTimeSummary, Frequency: ReportWeekly, Weekday: time.Friday, Hour: 24}, ErrInvalidReportSchedule},
+		{"Weekday out of range", ReportSubscription{Type: ReportWeeklyTimeSummary, Frequency: ReportWeekly, Weekday: 7}, ErrInvalidReportSchedule},
+		{"Day 29 is not sent every month", ReportSubscription{Type: ReportMonthlyAbsences, Frequency: ReportMonthly, DayOfMonth: 29}, ErrInvalidReportSchedule},
+		{"Section of another report", ReportSubscription{Type: ReportMonthlyAbsences, Frequency: ReportMonthly, DayOfMonth: 1, Sections: []ReportSection{ReportSectionProjects}}, ErrInvalidReportSection},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.subscription.Validate()
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReportSubscription_IsDue(t *testing.T) {
+	friday := time.Date(2024, 5, 10, 17, 30, 0, 0, time.UTC)
+	sentEarlier := friday.Add(-10 * time.Minute)
+	sentLastWeek := friday.AddDate(0, 0, -7)
+
+	weekly := ReportSubscription{Type: ReportWeeklyTimeSummary, Frequency: ReportWeekly, Weekday: time.Friday, Hour: 17, Active: true}
+	monthly := ReportSubscription{Type: ReportMonthlyOvertime, Frequency: ReportMonthly, DayOfMonth: 10, Hour: 6, Active: true}
+
+	inactive := weekly
+	inactive.Active = false
+	alreadySent := weekly
+	alreadySent.LastSentAt = &sentEarlier
+	sentBefore := weekly
+	sentBefore.LastSentAt = &sentLastWeek
+
+	tests := []struct {
+		name         string
+		subscription ReportSubscription
+		now          time.Time
+		want         bool
+	}{
+		{"Weekly at the configured hour", weekly, friday, true},
+		{"Weekly catches up later that day", weekly, friday.Add(4 * time.Hour), true},
+		{"Weekly before the hour", weekly, friday.Add(-2 * time.Hour), false},
+		{"Weekly on another day", weekly, friday.AddDate(0, 0, 1), false},
+		{"Inactive", inactive, friday, false},
+		{"Already sent today", alreadySent, friday, false},
+		{"Sent last week", sentBefore, friday, true},
+		{"Monthly on the configured day", monthly, friday, true},
+		{"Monthly on another day", monthly, friday.AddDate(0, 0, 1), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.subscription.IsDue(tt.now))
+		})
+	}
+}
+
+func TestReportPeriod(t *testing.T) {
+	date := func(day int) time.Time { return time.Date(2024, 5, day, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name      string
+		frequency ReportFrequency
+		now       time.Time
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{"Friday reports the current week", ReportWeekly, time.Date(2024, 5, 10, 17, 0, 0, 0, time.UTC), date(6), date(13)},
+		{"Sunday reports the current week", ReportWeekly, time.Date(2024, 5, 12, 9, 0, 0, 0, time.UTC), date(6), date(13)},
+		{"Monday reports the previous week", ReportWeekly, time.Date(2024, 5, 13, 8, 0, 0, 0, time.UTC), date(6), date(13)},
+		{"Monthly reports the previous month", ReportMonthly, time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC), time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), date(1)},
+		{"Monthly in January reports December", ReportMonthly, time.Date(2024, 1, 15, 8, 0, 0, 0, time.UTC), time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := ReportPeriod(tt.frequency, tt.now)
+			assert.Equal(t, tt.wantStart, start)
+			assert.Equal(t, tt.wantEnd, end)
+		})
+	}
+}
+
+func TestReportType_AllowsRole(t *testing.T) {
+	assert.True(t, ReportWeeklyTimeSummary.AllowsRole(RoleEmployee))
+	assert.True(t, ReportTeamDigest.AllowsRole(RoleManager))
+	assert.False(t, ReportTeamDigest.AllowsRole(RoleEmployee))
+	assert.True(t, ReportMonthlyAbsences.AllowsRole(RoleHR))
+	assert.False(t, ReportMonthlyAbsences.AllowsRole(RoleManager))
+}
@@ -0,0 +1,371 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// syncDiffMaxEmployees begrenzt die im Detail gespeicherten Mitarbeiter einer Änderungsübersicht
+	syncDiffMaxEmployees = 500
+
+	// syncDiffMaxItems begrenzt die gespeicherten Einträge je Liste und Mitarbeiter
+	syncDiffMaxItems = 50
+)
+
+// SyncFieldChange ist ein Feld, das eine Synchronisierung überschreibt
+type SyncFieldChange struct {
+	Field string `bson:"field" json:"field"` // JSON-Name des Feldes, z.B. "vacationDays"
+	Label string `bson:"label" json:"label"`
+	Old   string `bson:"old" json:"old"`
+	New   string `bson:"new" json:"new"`
+}
+
+// SyncItemChange ist eine hinzugefügte oder entfernte Abwesenheit, Zeitbuchung oder Projektzuordnung
+type SyncItemChange struct {
+	StartDate   time.Time `bson:"startDate" json:"startDate"`
+	EndDate     time.Time `bson:"endDate,omitempty" json:"endDate,omitempty"`
+	Description string    `bson:"description" json:"description"`
+}
+
+// EmployeeSyncChange beschreibt die Änderungen einer Synchronisierung an einem Mitarbeiter
+type EmployeeSyncChange struct {
+	EmployeeID   primitive.ObjectID `bson:"employeeId" json:"employeeId"`
+	EmployeeName string             `bson:"employeeName" json:"employeeName"`
+	Created      bool               `bson:"created,omitempty" json:"created,omitempty"`
+
+	Fields             []SyncFieldChange `bson:"fields,omitempty" json:"fields,omitempty"`
+	AbsencesAdded      []SyncItemChange  `bson:"absencesAdded,omitempty" json:"absencesAdded,omitempty"`
+	AbsencesRemoved    []SyncItemChange  `bson:"absencesRemoved,omitempty" json:"absencesRemoved,omitempty"`
+	TimeEntriesAdded   []SyncItemChange  `bson:"timeEntriesAdded,omitempty" json:"timeEntriesAdded,omitempty"`
+	TimeEntriesRemoved []SyncItemChange  `bson:"timeEntriesRemoved,omitempty" json:"timeEntriesRemoved,omitempty"`
+	ProjectsAdded      []SyncItemChange  `bson:"projectsAdded,omitempty" json:"projectsAdded,omitempty"`
+	ProjectsRemoved    []SyncItemChange  `bson:"projectsRemoved,omitempty" json:"projectsRemoved,omitempty"`
+
+	// Omitted zählt Einträge, die über syncDiffMaxItems hinausgehen und nur in den Summen enthalten sind
+	Omitted int `bson:"omitted,omitempty" json:"omitted,omitempty"`
+}
+
+// SyncDiff ist die Änderungsübersicht einer Synchronisierung. Bei einem Probelauf beschreibt
+// sie, was die Synchronisierung ändern würde, sonst, was sie geändert hat.
+type SyncDiff struct {
+	EmployeesCreated   int `bson:"employeesCreated" json:"employeesCreated"`
+	EmployeesUpdated   int `bson:"employeesUpdated" json:"employeesUpdated"`
+	FieldsOverwritten  int `bson:"fieldsOverwritten" json:"fieldsOverwritten"`
+	AbsencesAdded      int `bson:"absencesAdded" json:"absencesAdded"`
+	AbsencesRemoved    int `bson:"absencesRemoved" json:"absencesRemoved"`
+	TimeEntriesAdded   int `bson:"timeEntriesAdded" json:"timeEntriesAdded"`
+	TimeEntriesRemoved int `bson:"timeEntriesRemoved" json:"timeEntriesRemoved"`
+	ProjectsAdded      int `bson:"projectsAdded" json:"projectsAdded"`
+	ProjectsRemoved    int `bson:"projectsRemoved" json:"projectsRemoved"`
+
+	Employees []EmployeeSyncChange `bson:"employees,omitempty" json:"employees,omitempty"`
+	// OmittedEmployees zählt geänderte Mitarbeiter über syncDiffMaxEmployees hinaus
+	OmittedEmployees int `bson:"omittedEmployees,omitempty" json:"omittedEmployees,omitempty"`
+}
+
+// syncDiffField ist ein Mitarbeiterfeld, das Synchronisierungen überschreiben können
+type syncDiffField struct {
+	name  string
+	label string
+	value func(e *Employee) string
+}
+
+// syncDiffFields sind die verglichenen Mitarbeiterfelder in Anzeigereihenfolge
+var syncDiffFields = []syncDiffField{
+	{"firstName", "Vorname", func(e *Employee) string { return e.FirstName }},
+	{"lastName", "Nachname", func(e *Employee) string { return e.LastName }},
+	{"email", "E-Mail", func(e *Employee) string { return e.Email }},
+	{"phone", "Telefon", func(e *Employee) string { return e.Phone }},
+	{"position", "Position", func(e *Employee) string { return e.Position }},
+	{"department", "Abteilung", func(e *Employee) string { return string(e.Department) }},
+	{"status", "Status", func(e *Employee) string { return string(e.Status) }},
+	{"hireDate", "Eintrittsdatum", func(e *Employee) string { return formatSyncDiffDate(e.HireDate) }},
+	{"dateOfBirth", "Geburtsdatum", func(e *Employee) string { return formatSyncDiffDate(e.DateOfBirth) }},
+	{"workingHoursPerWeek", "Wochenarbeitszeit", func(e *Employee) string {
+		return strconv.FormatFloat(e.WorkingHoursPerWeek, 'f', -1, 64)
+	}},
+	{"vacationDays", "Urlaubsanspruch", func(e *Employee) string { return strconv.Itoa(e.VacationDays) }},
+	{"remainingVacation", "Resturlaub", func(e *Employee) string { return strconv.Itoa(e.RemainingVacation) }},
+	{"timebutlerUserId", "Timebutler-ID", func(e *Employee) string { return e.TimebutlerUserID }},
+	{"erfasst123Id", "123erfasst-ID", func(e *Employee) string { return e.Erfasst123ID }},
+	{"personioId", "Personio-ID", func(e *Employee) string { return e.PersonioID }},
+}
+
+// DiffEmployee vergleicht den gespeicherten Stand eines Mitarbeiters mit dem synchronisierten.
+// Ist before nil, wird der Mitarbeiter neu angelegt.
+func DiffEmployee(before, after *Employee) EmployeeSyncChange {
+	change := EmployeeSyncChange{
+		EmployeeID:   after.ID,
+		EmployeeName: after.FirstName + " " + after.LastName,
+	}
+	if before == nil {
+		change.Created = true
+		before = &Employee{}
+	}
+
+	for _, field := range syncDiffFields {
+		oldValue, newValue := field.value(before), field.value(after)
+		if oldValue != newValue {
+			change.Fields = append(change.Fields, SyncFieldChange{Field: field.name, Label: field.label, Old: oldValue, New: newValue})
+		}
+	}
+
+	change.AbsencesAdded, change.AbsencesRemoved = diffSyncItems(absenceSyncItems(before.Absences), absenceSyncItems(after.Absences))
+	change.TimeEntriesAdded, change.TimeEntriesRemoved = diffSyncItems(timeEntrySyncItems(before.TimeEntries), timeEntrySyncItems(after.TimeEntries))
+	change.ProjectsAdded, change.ProjectsRemoved = diffSyncItems(projectSyncItems(before.ProjectAssignments), projectSyncItems(after.ProjectAssignments))
+	return change
+}
+
+// IsEmpty prüft, ob die Synchronisierung den Mitarbeiter unverändert lässt
+func (c *EmployeeSyncChange) IsEmpty() bool {
+	return !c.Created && len(c.Fields) == 0 &&
+		len(c.AbsencesAdded) == 0 && len(c.AbsencesRemoved) == 0 &&
+		len(c.TimeEntriesAdded) == 0 && len(c.TimeEntriesRemoved) == 0 &&
+		len(c.ProjectsAdded) == 0 && len(c.ProjectsRemoved) == 0
+}
+
+// Add übernimmt die Änderungen an einem Mitarbeiter in die Übersicht. Meldet eine vollständige
+// Synchronisierung denselben Mitarbeiter in mehreren Schritten, werden die Änderungen zusammengeführt.
+func (d *SyncDiff) Add(change EmployeeSyncChange) {
+	d.FieldsOverwritten += len(change.Fields)
+	d.AbsencesAdded += len(change.AbsencesAdded)
+	d.AbsencesRemoved += len(change.AbsencesRemoved)
+	d.TimeEntriesAdded += len(change.TimeEntriesAdded)
+	d.TimeEntriesRemoved += len(change.TimeEntriesRemoved)
+	d.ProjectsAdded += len(change.ProjectsAdded)
+	d.ProjectsRemoved += len(change.ProjectsRemoved)
+
+	for i := range d.Employees {
+		if d.Employees[i].EmployeeID == change.EmployeeID {
+			d.Employees[i].merge(change)
+			return
+		}
+	}
+
+	if change.Created {
+		d.EmployeesCreated++
+	} else {
+		d.EmployeesUpdated++
+	}
+	if len(d.Employees) >= syncDiffMaxEmployees {
+		d.OmittedEmployees++
+		return
+	}
+	existing := EmployeeSyncChange{EmployeeID: change.EmployeeID, EmployeeName: change.EmployeeName, Created: change.Created}
+	existing.merge(change)
+	d.Employees = append(d.Employees, existing)
+}
+
+// IsEmpty prüft, ob die Synchronisierung nichts ändert
+func (d *SyncDiff) IsEmpty() bool {
+	return d.EmployeesCreated == 0 && d.EmployeesUpdated == 0
+}
+
+// DeviationsFrom vergleicht die Änderungen einer übernommenen Synchronisierung mit dem Probelauf
+// und beschreibt jede Abweichung. Die Mitarbeiter werden nur verglichen, wenn beide Übersichten
+// vollständig gespeichert sind; sonst werden nur die Summen verglichen.
+func (d *SyncDiff) DeviationsFrom(preview *SyncDiff) []string {
+	var deviations []string
+	totals := []struct {
+		label            string
+		applied, preview int
+	}{
+		{"Neue Mitarbeiter", d.EmployeesCreated, preview.EmployeesCreated},
+		{"Geänderte Mitarbeiter", d.EmployeesUpdated, preview.EmployeesUpdated},
+		{"Überschriebene Felder", d.FieldsOverwritten, preview.FieldsOverwritten},
+		{"Hinzugefügte Abwesenheiten", d.AbsencesAdded, preview.AbsencesAdded},
+		{"Entfernte Abwesenheiten", d.AbsencesRemoved, preview.AbsencesRemoved},
+		{"Hinzugefügte Zeitbuchungen", d.TimeEntriesAdded, preview.TimeEntriesAdded},
+		{"Entfernte Zeitbuchungen", d.TimeEntriesRemoved, preview.TimeEntriesRemoved},
+		{"Hinzugefügte Projektzuordnungen", d.ProjectsAdded, preview.ProjectsAdded},
+		{"Entfernte Projektzuordnungen", d.ProjectsRemoved, preview.ProjectsRemoved},
+	}
+	for _, total := range totals {
+		if total.applied != total.preview {
+			deviations = append(deviations, fmt.Sprintf("%s: %d statt %d im Probelauf", total.label, total.applied, total.preview))
+		}
+	}
+	if d.OmittedEmployees > 0 || preview.OmittedEmployees > 0 {
+		return deviations
+	}
+
+	previewed := make(map[string]*EmployeeSyncChange, len(preview.Employees))
+	for i := range preview.Employees {
+		previewed[preview.Employees[i].diffKey()] = &preview.Employees[i]
+	}
+	for i := range d.Employees {
+		change := &d.Employees[i]
+		expected, ok := previewed[change.diffKey()]
+		delete(previewed, change.diffKey())
+		switch {
+		case !ok:
+			deviations = append(deviations, change.EmployeeName+": nicht im Probelauf enthalten")
+		case change.fingerprint() != expected.fingerprint():
+			deviations = append(deviations, change.EmployeeName+": Änderungen weichen vom Probelauf ab")
+		}
+	}
+	for _, change := range preview.Employees {
+		if _, ok := previewed[change.diffKey()]; ok {
+			deviations = append(deviations, change.EmployeeName+": im Probelauf geändert, aber nicht übernommen")
+		}
+	}
+	return deviations
+}
+
+// diffKey identifiziert einen Mitarbeiter beim Vergleich zweier Übersichten. Neu angelegte
+// Mitarbeiter haben im Probelauf noch keine gespeicherte ID und werden über den Namen verglichen.
+func (c *EmployeeSyncChange) diffKey() string {
+	if c.Created {
+		return "new|" + c.EmployeeName
+	}
+	return c.EmployeeID.Hex()
+}
+
+// fingerprint fasst die Änderungen an einem Mitarbeiter für den Vergleich zusammen. Zeitpunkte
+// werden sekundengenau verglichen, da gespeicherte Übersichten keine Nanosekunden enthalten.
+func (c *EmployeeSyncChange) fingerprint() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%t|%d", c.Created, c.Omitted)
+	for _, field := range c.Fields {
+		fmt.Fprintf(&b, "|f:%s=%s>%s", field.Field, field.Old, field.New)
+	}
+	lists := [][]SyncItemChange{c.AbsencesAdded, c.AbsencesRemoved, c.TimeEntriesAdded, c.TimeEntriesRemoved, c.ProjectsAdded, c.ProjectsRemoved}
+	for i, items := range lists {
+		for _, item := range items {
+			fmt.Fprintf(&b, "|%d:%d-%d:%s", i, item.StartDate.Unix(), item.EndDate.Unix(), item.Description)
+		}
+	}
+	return b.String()
+}
+
+// merge hängt die Änderungen eines weiteren Schritts an; Einträge über dem Limit werden nur gezählt
+func (c *EmployeeSyncChange) merge(other EmployeeSyncChange) {
+	c.Fields = mergeSyncFieldChanges(c.Fields, other.Fields)
+	c.AbsencesAdded = c.appendLimited(c.AbsencesAdded, other.AbsencesAdded)
+	c.AbsencesRemoved = c.appendLimited(c.AbsencesRemoved, other.AbsencesRemoved)
+	c.TimeEntriesAdded = c.appendLimited(c.TimeEntriesAdded, other.TimeEntriesAdded)
+	c.TimeEntriesRemoved = c.appendLimited(c.TimeEntriesRemoved, other.TimeEntriesRemoved)
+	c.ProjectsAdded = c.appendLimited(c.ProjectsAdded, other.ProjectsAdded)
+	c.ProjectsRemoved = c.appendLimited(c.ProjectsRemoved, other.ProjectsRemoved)
+	c.Omitted += other.Omitted
+}
+
+// appendLimited hängt Einträge bis syncDiffMaxItems an und zählt den Rest in Omitted
+func (c *EmployeeSyncChange) appendLimited(items, more []SyncItemChange) []SyncItemChange {
+	for _, item := range more {
+		if len(items) >= syncDiffMaxItems {
+			c.Omitted++
+			continue
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// mergeSyncFieldChanges führt Feldänderungen zusammen; ändert ein späterer Schritt dasselbe
+// Feld erneut, bleibt der ursprüngliche alte Wert erhalten
+func mergeSyncFieldChanges(fields, more []SyncFieldChange) []SyncFieldChange {
+	for _, change := range more {
+		merged := false
+		for i := range fields {
+			if fields[i].Field == change.Field {
+				fields[i].New = change.New
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			fields = append(fields, change)
+		}
+	}
+	return fields
+}
+
+// syncItem ist ein Listeneintrag mit einem Vergleichsschlüssel
+type syncItem struct {
+	key    string
+	change SyncItemChange
+}
+
+// diffSyncItems vergleicht zwei Listen anhand der Schlüssel; doppelte Einträge werden einzeln gezählt
+func diffSyncItems(before, after []syncItem) (added, removed []SyncItemChange) {
+	remaining := make(map[string]int, len(before))
+	for _, item := range before {
+		remaining[item.key]++
+	}
+	for _, item := range after {
+		if remaining[item.key] > 0 {
+			remaining[item.key]--
+			continue
+		}
+		added = append(added, item.change)
+	}
+	for _, item := range before {
+		if remaining[item.key] > 0 {
+			remaining[item.key]--
+			removed = append(removed, item.change)
+		}
+	}
+	return added, removed
+}
+
+// absenceSyncItems vergleicht Abwesenheiten nach Art, Zeitraum, Tagen und Status
+func absenceSyncItems(absences []Absence) []syncItem {
+	items := make([]syncItem, 0, len(absences))
+	for _, absence := range absences {
+		items = append(items, syncItem{
+			key: fmt.Sprintf("%s|%d|%d|%g|%s", absence.Type, absence.StartDate.Unix(), absence.EndDate.Unix(), absence.Days, absence.Status),
+			change: SyncItemChange{
+				StartDate:   absence.StartDate,
+				EndDate:     absence.EndDate,
+				Description: fmt.Sprintf("%s (%s, %g Tage)", absence.Type, absence.Status, absence.Days),
+			},
+		})
+	}
+	return items
+}
+
+// timeEntrySyncItems vergleicht Zeitbuchungen nach Zeitraum, Projekt, Tätigkeit und Dauer
+func timeEntrySyncItems(entries []TimeEntry) []syncItem {
+	items := make([]syncItem, 0, len(entries))
+	for _, entry := range entries {
+		items = append(items, syncItem{
+			key: fmt.Sprintf("%d|%d|%d|%s|%s|%g", entry.Date.Unix(), entry.StartTime.Unix(), entry.EndTime.Unix(), entry.ProjectID, entry.Activity, entry.Duration),
+			change: SyncItemChange{
+				StartDate:   entry.StartTime,
+				EndDate:     entry.EndTime,
+				Description: fmt.Sprintf("%s – %s (%.2f Std.)", entry.ProjectName, entry.Activity, entry.Duration),
+			},
+		})
+	}
+	return items
+}
+
+// projectSyncItems vergleicht Projektzuordnungen nach Projekt und Zeitraum
+func projectSyncItems(assignments []ProjectAssignment) []syncItem {
+	items := make([]syncItem, 0, len(assignments))
+	for _, assignment := range assignments {
+		items = append(items, syncItem{
+			key: fmt.Sprintf("%s|%d|%d", assignment.ProjectID, assignment.StartDate.Unix(), assignment.EndDate.Unix()),
+			change: SyncItemChange{
+				StartDate:   assignment.StartDate,
+				EndDate:     assignment.EndDate,
+				Description: assignment.ProjectName,
+			},
+		})
+	}
+	return items
+}
+
+// formatSyncDiffDate formatiert ein Datum für die Änderungsübersicht (leer, wenn nicht gesetzt)
+func formatSyncDiffDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
@@ -0,0 +1,246 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func syncDiffEmployee() *Employee {
+	return &Employee{
+		ID:                primitive.NewObjectID(),
+		FirstName:         "Anna",
+		LastName:          "Schmidt",
+		Phone:             "0301234",
+		VacationDays:      28,
+		RemainingVacation: 10,
+		Absences: []Absence{{
+			ID:        primitive.NewObjectID(),
+			Type:      "vacation",
+			StartDate: time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC),
+			EndDate:   time.Date(2025, 3, 7, 0, 0, 0, 0, time.UTC),
+			Days:      5,
+			Status:    "approved",
+		}},
+		TimeEntries: []TimeEntry{{
+			ID:        primitive.NewObjectID(),
+			Date:      time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC),
+			StartTime: time.Date(2025, 3, 10, 8, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2025, 3, 10, 16, 0, 0, 0, time.UTC),
+			Duration:  8,
+			ProjectID: "p1",
+			Activity:  "Montage",
+			Source:    "123erfasst",
+		}},
+		ProjectAssignments: []ProjectAssignment{{
+			ProjectID:   "p1",
+			ProjectName: "Neubau Nord",
+			StartDate:   time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+			EndDate:     time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC),
+			Source:      "123erfasst",
+		}},
+	}
+}
+
+// cloneSyncDiffEmployee kopiert einen Mitarbeiter inklusive der Listen
+func cloneSyncDiffEmployee(e *Employee) *Employee {
+	clone := *e
+	clone.Absences = append([]Absence(nil), e.Absences...)
+	clone.TimeEntries = append([]TimeEntry(nil), e.TimeEntries...)
+	clone.ProjectAssignments = append([]ProjectAssignment(nil), e.ProjectAssignments...)
+	return &clone
+}
+
+func TestDiffEmployee(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(e *Employee)
+		check  func(t *testing.T, change EmployeeSyncChange)
+	}{
+		{
+			name:   "unverändert",
+			modify: func(e *Employee) {},
+			check: func(t *testing.T, change EmployeeSyncChange) {
+				assert.True(t, change.IsEmpty())
+			},
+		},
+		{
+			name: "überschriebene Felder",
+			modify: func(e *Employee) {
+				e.VacationDays = 30
+				e.TimebutlerUserID = "tb-7"
+				e.UpdatedAt = time.Now()
+			},
+			check: func(t *testing.T, change EmployeeSyncChange) {
+				assert.Equal(t, []SyncFieldChange{
+					{Field: "vacationDays", Label: "Urlaubsanspruch", Old: "28", New: "30"},
+					{Field: "timebutlerUserId", Label: "Timebutler-ID", Old: "", New: "tb-7"},
+				}, change.Fields)
+			},
+		},
+		{
+			name: "Abwesenheit hinzugefügt",
+			modify: func(e *Employee) {
+				e.Absences = append(e.Absences, Absence{
+					ID:        primitive.NewObjectID(),
+					Type:      "sick",
+					StartDate: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+					EndDate:   time.Date(2025, 4, 2, 0, 0, 0, 0, time.UTC),
+					Days:      2,
+					Status:    "approved",
+				})
+			},
+			check: func(t *testing.T, change EmployeeSyncChange) {
+				require.Len(t, change.AbsencesAdded, 1)
+				assert.Equal(t, "sick (approved, 2 Tage)", change.AbsencesAdded[0].Description)
+				assert.Empty(t, change.AbsencesRemoved)
+			},
+		},
+		{
+			name: "neu geladene Zeiteinträge mit neuen IDs gelten nicht als Änderung",
+			modify: func(e *Employee) {
+				e.TimeEntries[0].ID = primitive.NewObjectID()
+			},
+			check: func(t *testing.T, change EmployeeSyncChange) {
+				assert.True(t, change.IsEmpty())
+			},
+		},
+		{
+			name: "geänderter Zeiteintrag wird entfernt und hinzugefügt",
+			modify: func(e *Employee) {
+				e.TimeEntries[0].EndTime = e.TimeEntries[0].EndTime.Add(-time.Hour)
+				e.TimeEntries[0].Duration = 7
+			},
+			check: func(t *testing.T, change EmployeeSyncChange) {
+				assert.Len(t, change.TimeEntriesAdded, 1)
+				assert.Len(t, change.TimeEntriesRemoved, 1)
+			},
+		},
+		{
+			name: "Projektzuordnung entfernt",
+			modify: func(e *Employee) {
+				e.ProjectAssignments = nil
+			},
+			check: func(t *testing.T, change EmployeeSyncChange) {
+				require.Len(t, change.ProjectsRemoved, 1)
+				assert.Equal(t, "Neubau Nord", change.ProjectsRemoved[0].Description)
+				assert.Empty(t, change.ProjectsAdded)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := syncDiffEmployee()
+			after := cloneSyncDiffEmployee(before)
+			tt.modify(after)
+
+			change := DiffEmployee(before, after)
+			assert.Equal(t, before.ID, change.EmployeeID)
+			assert.Equal(t, "Anna Schmidt", change.EmployeeName)
+			tt.check(t, change)
+		})
+	}
+}
+
+func TestDiffEmployee_Created(t *testing.T) {
+	employee := syncDiffEmployee()
+
+	change := DiffEmployee(nil, employee)
+
+	assert.True(t, change.Created)
+	assert.False(t, change.IsEmpty())
+	assert.Len(t, change.AbsencesAdded, 1)
+	assert.Len(t, change.TimeEntriesAdded, 1)
+}
+
+func TestSyncDiff_AddMergesStepsOfSameEmployee(t *testing.T) {
+	id := primitive.NewObjectID()
+	var diff SyncDiff
+
+	diff.Add(EmployeeSyncChange{
+		EmployeeID:   id,
+		EmployeeName: "Anna Schmidt",
+		Fields:       []SyncFieldChange{{Field: "vacationDays", Old: "28", New: "29"}},
+	})
+	diff.Add(EmployeeSyncChange{
+		EmployeeID:    id,
+		EmployeeName:  "Anna Schmidt",
+		Fields:        []SyncFieldChange{{Field: "vacationDays", Old: "29", New: "30"}},
+		AbsencesAdded: []SyncItemChange{{Description: "vacation"}},
+	})
+	diff.Add(EmployeeSyncChange{EmployeeID: primitive.NewObjectID(), Created: true})
+
+	assert.Equal(t, 1, diff.EmployeesUpdated)
+	assert.Equal(t, 1, diff.EmployeesCreated)
+	assert.Equal(t, 2, diff.FieldsOverwritten)
+	assert.Equal(t, 1, diff.AbsencesAdded)
+	require.Len(t, diff.Employees, 2)
+	assert.Equal(t, []SyncFieldChange{{Field: "vacationDays", Old: "28", New: "30"}}, diff.Employees[0].Fields)
+	assert.Len(t, diff.Employees[0].AbsencesAdded, 1)
+}
+
+func TestSyncDiff_AddLimitsStoredDetails(t *testing.T) {
+	var diff SyncDiff
+	items := make([]SyncItemChange, syncDiffMaxItems+5)
+
+	diff.Add(EmployeeSyncChange{EmployeeID: primitive.NewObjectID(), TimeEntriesAdded: items})
+	for i := 1; i < syncDiffMaxEmployees+3; i++ {
+		diff.Add(EmployeeSyncChange{EmployeeID: primitive.NewObjectID(), AbsencesAdded: items[:1]})
+	}
+
+	assert.Equal(t, syncDiffMaxItems+5, diff.TimeEntriesAdded)
+	assert.Len(t, diff.Employees[0].TimeEntriesAdded, syncDiffMaxItems)
+	assert.Equal(t, 5, diff.Employees[0].Omitted)
+	assert.Len(t, diff.Employees, syncDiffMaxEmployees)
+	assert.Equal(t, 3, diff.OmittedEmployees)
+	assert.Equal(t, syncDiffMaxEmployees+3, diff.EmployeesUpdated)
+}
+
+func TestSyncDiff_DeviationsFrom(t *testing.T) {
+	id := primitive.NewObjectID()
+	start := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	changed := EmployeeSyncChange{
+		EmployeeID:    id,
+		EmployeeName:  "Anna Schmidt",
+		Fields:        []SyncFieldChange{{Field: "vacationDays", Old: "28", New: "30"}},
+		AbsencesAdded: []SyncItemChange{{StartDate: start, EndDate: start, Description: "vacation"}},
+	}
+
+	var preview, applied SyncDiff
+	preview.Add(changed)
+	preview.Add(EmployeeSyncChange{EmployeeID: primitive.NewObjectID(), EmployeeName: "Neu Angelegt", Created: true})
+	// Aus der Datenbank gelesene Zeitpunkte haben eine andere Zeitzone
+	roundTripped := changed
+	roundTripped.AbsencesAdded = []SyncItemChange{{StartDate: start.Local(), EndDate: start.Local(), Description: "vacation"}}
+	applied.Add(roundTripped)
+	applied.Add(EmployeeSyncChange{EmployeeID: primitive.NewObjectID(), EmployeeName: "Neu Angelegt", Created: true})
+
+	assert.Empty(t, applied.DeviationsFrom(&preview))
+
+	var deviating SyncDiff
+	moved := changed
+	moved.Fields = []SyncFieldChange{{Field: "vacationDays", Old: "28", New: "31"}}
+	deviating.Add(moved)
+	deviating.Add(EmployeeSyncChange{EmployeeID: primitive.NewObjectID(), EmployeeName: "Max Müller", Fields: moved.Fields})
+
+	assert.Equal(t, []string{
+		"Neue Mitarbeiter: 0 statt 1 im Probelauf",
+		"Geänderte Mitarbeiter: 2 statt 1 im Probelauf",
+		"Überschriebene Felder: 2 statt 1 im Probelauf",
+		"Anna Schmidt: Änderungen weichen vom Probelauf ab",
+		"Max Müller: nicht im Probelauf enthalten",
+		"Neu Angelegt: im Probelauf geändert, aber nicht übernommen",
+	}, deviating.DeviationsFrom(&preview))
+}
+
+func TestSyncDiff_DeviationsFromComparesOnlyTotalsWhenCapped(t *testing.T) {
+	preview := SyncDiff{EmployeesUpdated: syncDiffMaxEmployees + 1, OmittedEmployees: 1}
+	applied := SyncDiff{EmployeesUpdated: syncDiffMaxEmployees + 1, OmittedEmployees: 1,
+		Employees: []EmployeeSyncChange{{EmployeeID: primitive.NewObjectID(), EmployeeName: "Anna Schmidt"}}}
+
+	assert.Empty(t, applied.DeviationsFrom(&preview))
+}
@@ -0,0 +1,181 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SyncJob-Fehler
+var (
+	ErrInvalidSyncCapability = errors.New("invalid sync capability")
+)
+
+// SyncCapability ist ein Datenbereich, den ein Integrationsanbieter synchronisieren kann
+type SyncCapability string
+
+const (
+	SyncUsers               SyncCapability = "users"
+	SyncAbsences            SyncCapability = "absences"
+	SyncHolidayEntitlements SyncCapability = "holiday_entitlements"
+	SyncProjects            SyncCapability = "projects"
+	SyncTimeEntries         SyncCapability = "time_entries"
+	SyncAll                 SyncCapability = "all" // alle Bereiche des Anbieters nacheinander
+)
+
+// syncCapabilityAliases enthält die Bezeichnungen der früheren, anbieterspezifischen Sync-Routen
+var syncCapabilityAliases = map[string]SyncCapability{
+	"employees":           SyncUsers,
+	"times":               SyncTimeEntries,
+	"holidayentitlements": SyncHolidayEntitlements,
+}
+
+// SyncJobStatus ist der Zustand einer Synchronisierung
+type SyncJobStatus string
+
+const (
+	SyncJobQueued    SyncJobStatus = "queued"
+	SyncJobRunning   SyncJobStatus = "running"
+	SyncJobCompleted SyncJobStatus = "completed"
+	SyncJobFailed    SyncJobStatus = "failed"
+)
+
+const (
+	// SyncJobStaleAfter ist die Zeit ohne Lebenszeichen, nach der eine laufende
+	// Synchronisierung als abgebrochen gilt (z.B. nach einem Neustart des Servers)
+	SyncJobStaleAfter = 10 * time.Minute
+
+	// SyncPreviewMaxAge ist die Zeit, nach der ein Probelauf nicht mehr übernommen werden kann,
+	// weil sich Quelle oder Mitarbeiterdaten inzwischen geändert haben können
+	SyncPreviewMaxAge = time.Hour
+
+	// syncJobMaxWarnings begrenzt die gespeicherten Warnungen pro Synchronisierung
+	syncJobMaxWarnings = 100
+)
+
+// SyncJobParams enthält die Parameter einer Synchronisierung
+type SyncJobParams struct {
+	Year      string `bson:"year,omitempty" json:"year,omitempty"`
+	StartDate string `bson:"startDate,omitempty" json:"startDate,omitempty"`
+	EndDate   string `bson:"endDate,omitempty" json:"endDate,omitempty"`
+	// DryRun berechnet nur die Änderungsübersicht, ohne Mitarbeiter zu speichern
+	DryRun bool `bson:"dryRun,omitempty" json:"dryRun,omitempty"`
+	// Scheduled kennzeichnet den geplanten Lauf des Hintergrundjobs; ein fehlgeschlagener
+	// Bereich hält die übrigen Bereiche dann nicht auf
+	Scheduled bool `bson:"scheduled,omitempty" json:"scheduled,omitempty"`
+}
+
+// SyncJob ist eine im Hintergrund laufende Synchronisierung mit einer Integration.
+// Phase und Zähler werden während des Laufs fortgeschrieben, damit der Fortschritt
+// abgefragt werden kann.
+type SyncJob struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Integration string             `bson:"integration" json:"integration"`
+	Capability  SyncCapability     `bson:"capability" json:"capability"`
+	Params      SyncJobParams      `bson:"params" json:"params"`
+	Status      SyncJobStatus      `bson:"status" json:"status"`
+	// Active ist gesetzt, solange die Synchronisierung nicht abgeschlossen ist; ein eindeutiger
+	// Index darauf lässt je Integration nur eine aktive Synchronisierung zu
+	Active bool `bson:"active,omitempty" json:"-"`
+
+	Phase     string   `bson:"phase,omitempty" json:"phase,omitempty"`
+	Processed int      `bson:"processed" json:"processed"`
+	Total     int      `bson:"total" json:"total"` // 0 = unbekannt (z.B. während des Abrufs)
+	Warnings  []string `bson:"warnings,omitempty" json:"warnings,omitempty"`
+	Dropped   int      `bson:"droppedWarnings,omitempty" json:"droppedWarnings,omitempty"` // über das Limit hinausgehende Warnungen
+
+	Counts  map[string]int `bson:"counts,omitempty" json:"counts,omitempty"` // Ergebnis je Schritt, z.B. "employees": 12
+	Summary string         `bson:"summary,omitempty" json:"summary,omitempty"`
+	Error   string         `bson:"error,omitempty" json:"error,omitempty"`
+	Diff    *SyncDiff      `bson:"diff,omitempty" json:"diff,omitempty"`
+
+	// PreviewID verweist auf den Probelauf, aus dem diese Synchronisierung übernommen wurde;
+	// AppliedJobID beim Probelauf auf die Synchronisierung, die ihn übernommen hat
+	PreviewID    *primitive.ObjectID `bson:"previewId,omitempty" json:"previewId,omitempty"`
+	AppliedJobID *primitive.ObjectID `bson:"appliedJobId,omitempty" json:"appliedJobId,omitempty"`
+	// PreviewMismatch ist gesetzt, wenn die übernommenen Änderungen vom Probelauf abweichen;
+	// die Abweichungen stehen in den Warnungen
+	PreviewMismatch bool `bson:"previewMismatch,omitempty" json:"previewMismatch,omitempty"`
+
+	RequestedBy     primitive.ObjectID `bson:"requestedBy" json:"requestedBy"`
+	RequestedByName string             `bson:"requestedByName" json:"requestedByName"`
+	Instance        string             `bson:"instance,omitempty" json:"instance,omitempty"`
+
+	CreatedAt  time.Time  `bson:"createdAt" json:"createdAt"`
+	StartedAt  *time.Time `bson:"startedAt,omitempty" json:"startedAt,omitempty"`
+	FinishedAt *time.Time `bson:"finishedAt,omitempty" json:"finishedAt,omitempty"`
+	UpdatedAt  time.Time  `bson:"updatedAt" json:"updatedAt"`
+}
+
+// ParseSyncCapability wandelt den Bereich aus einer Sync-Route um; die Bezeichnungen
+// der früheren Routen (z.B. "times") werden weiterhin akzeptiert
+func ParseSyncCapability(value string) (SyncCapability, error) {
+	if alias, ok := syncCapabilityAliases[value]; ok {
+		return alias, nil
+	}
+	capability := SyncCapability(value)
+	if !capability.IsValid() {
+		return "", fmt.Errorf("%w: %s", ErrInvalidSyncCapability, value)
+	}
+	return capability, nil
+}
+
+// IsValid prüft, ob der Bereich bekannt ist
+func (c SyncCapability) IsValid() bool {
+	switch c {
+	case SyncUsers, SyncAbsences, SyncHolidayEntitlements, SyncProjects, SyncTimeEntries, SyncAll:
+		return true
+	default:
+		return false
+	}
+}
+
+// GetLabel gibt eine deutsche Bezeichnung zurück
+func (c SyncCapability) GetLabel() string {
+	switch c {
+	case SyncUsers:
+		return "Mitarbeiter"
+	case SyncAbsences:
+		return "Abwesenheiten"
+	case SyncHolidayEntitlements:
+		return "Urlaubsansprüche"
+	case SyncProjects:
+		return "Projekte"
+	case SyncTimeEntries:
+		return "Zeiteinträge"
+	case SyncAll:
+		return "Vollständige Synchronisierung"
+	default:
+		return string(c)
+	}
+}
+
+// IsFinished prüft, ob die Synchronisierung abgeschlossen ist (erfolgreich oder nicht)
+func (j *SyncJob) IsFinished() bool {
+	return j.Status == SyncJobCompleted || j.Status == SyncJobFailed
+}
+
+// IsStale prüft, ob eine nicht abgeschlossene Synchronisierung seit SyncJobStaleAfter
+// kein Lebenszeichen mehr gegeben hat
+func (j *SyncJob) IsStale(now time.Time) bool {
+	return !j.IsFinished() && now.Sub(j.UpdatedAt) > SyncJobStaleAfter
+}
+
+// RecordChange übernimmt die Änderungen an einem Mitarbeiter in die Änderungsübersicht
+func (j *SyncJob) RecordChange(change EmployeeSyncChange) {
+	if j.Diff == nil {
+		j.Diff = &SyncDiff{}
+	}
+	j.Diff.Add(change)
+}
+
+// AddWarning speichert eine Warnung; über dem Limit wird nur noch gezählt
+func (j *SyncJob) AddWarning(message string) {
+	if len(j.Warnings) >= syncJobMaxWarnings {
+		j.Dropped++
+		return
+	}
+	j.Warnings = append(j.Warnings, message)
+}
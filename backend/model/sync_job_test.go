@@ -0,0 +1,74 @@
+package model
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSyncCapability(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    SyncCapability
+		wantErr bool
+	}{
+		{"users", SyncUsers, false},
+		{"absences", SyncAbsences, false},
+		{"holiday_entitlements", SyncHolidayEntitlements, false},
+		{"projects", SyncProjects, false},
+		{"time_entries", SyncTimeEntries, false},
+		{"all", SyncAll, false},
+		{"employees", SyncUsers, false},
+		{"times", SyncTimeEntries, false},
+		{"holidayentitlements", SyncHolidayEntitlements, false},
+		{"salaries", "", true},
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseSyncCapability(tt.value)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidSyncCapability)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSyncJob_IsStale(t *testing.T) {
+	now := time.Date(2024, 5, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		job  SyncJob
+		want bool
+	}{
+		{"Running with recent heartbeat", SyncJob{Status: SyncJobRunning, UpdatedAt: now.Add(-time.Minute)}, false},
+		{"Running without heartbeat", SyncJob{Status: SyncJobRunning, UpdatedAt: now.Add(-SyncJobStaleAfter - time.Second)}, true},
+		{"Queued without heartbeat", SyncJob{Status: SyncJobQueued, UpdatedAt: now.Add(-time.Hour)}, true},
+		{"Completed long ago", SyncJob{Status: SyncJobCompleted, UpdatedAt: now.Add(-time.Hour)}, false},
+		{"Failed long ago", SyncJob{Status: SyncJobFailed, UpdatedAt: now.Add(-time.Hour)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.job.IsStale(now))
+		})
+	}
+}
+
+func TestSyncJob_AddWarning(t *testing.T) {
+	job := &SyncJob{}
+	for i := 0; i < syncJobMaxWarnings+5; i++ {
+		job.AddWarning(fmt.Sprintf("Warnung %d", i))
+	}
+
+	assert.Len(t, job.Warnings, syncJobMaxWarnings)
+	assert.Equal(t, 5, job.Dropped)
+	assert.Equal(t, "Warnung 0", job.Warnings[0])
+}
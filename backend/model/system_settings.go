@@ -38,6 +38,9 @@ type SystemSettings struct {
 	DefaultWorkingHours float64                    `bson:"defaultWorkingHours" json:"defaultWorkingHours"`
 	DefaultVacationDays int                        `bson:"defaultVacationDays" json:"defaultVacationDays"`
 	EmailNotifications  *EmailNotificationSettings `bson:"emailNotifications,omitempty" json:"emailNotifications,omitempty"`
+	RequireTwoFactor    bool                       `bson:"requireTwoFactor" json:"requireTwoFactor"` // 2FA bei Kontoaktivierung verpflichtend
+	PasswordPolicy      *PasswordPolicy            `bson:"passwordPolicy,omitempty" json:"passwordPolicy,omitempty"`
+	Datev               *DatevSettings             `bson:"datev,omitempty" json:"datev,omitempty"`
 	CreatedAt           time.Time                  `bson:"createdAt" json:"createdAt"`
 	UpdatedAt           time.Time                  `bson:"updatedAt" json:"updatedAt"`
 }
@@ -54,6 +57,35 @@ func DefaultSystemSettings() *SystemSettings {
 	}
 }
 
+// GetPasswordPolicy gibt die konfigurierte oder die Standard-Passwortrichtlinie zurück
+func (s *SystemSettings) GetPasswordPolicy() *PasswordPolicy {
+	if s == nil || s.PasswordPolicy == nil {
+		return DefaultPasswordPolicy()
+	}
+	policy := *s.PasswordPolicy
+	policy.Normalize()
+	return &policy
+}
+
+// GetDatevSettings gibt die Einstellungen des DATEV-Lohnexports oder die Standardeinstellungen zurück
+func (s *SystemSettings) GetDatevSettings() *DatevSettings {
+	if s == nil || s.Datev == nil {
+		return DefaultDatevSettings()
+	}
+	settings := *s.Datev
+	// Ein früher gespeichertes Ziel "LUG" bleibt erhalten; Validate weist es beim Export ab
+	if settings.Target == "" {
+		settings.Target = DatevTargetLODAS
+	}
+	if settings.AbsenceWageTypes == nil {
+		settings.AbsenceWageTypes = DatevWageTypeMapping{}
+	}
+	if settings.WageTypes == nil {
+		settings.WageTypes = DatevWageTypeMapping{}
+	}
+	return &settings
+}
+
 // IsValid prüft, ob die GermanState gültig ist
 func (gs GermanState) IsValid() bool {
 	switch gs {
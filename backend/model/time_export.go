@@ -0,0 +1,334 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidTimeExportGrouping wird bei einer unbekannten Gruppierung zurückgegeben
+	ErrInvalidTimeExportGrouping = errors.New("invalid time export grouping")
+	// ErrInvalidTimeExportFilter wird bei einem ungültigen Zeitraum oder Mitarbeiterfilter zurückgegeben
+	ErrInvalidTimeExportFilter = errors.New("invalid time export filter")
+)
+
+// TimeExportGrouping legt fest, ob Einzeleinträge oder Summen exportiert werden
+type TimeExportGrouping string
+
+const (
+	TimeExportGroupNone    TimeExportGrouping = ""        // Einzeleinträge
+	TimeExportGroupDay     TimeExportGrouping = "day"     // Summe je Mitarbeiter und Tag
+	TimeExportGroupWeek    TimeExportGrouping = "week"    // Summe je Mitarbeiter und Kalenderwoche
+	TimeExportGroupProject TimeExportGrouping = "project" // Summe je Projekt und Mitarbeiter
+)
+
+// ParseTimeExportGrouping prüft die Gruppierung eines Zeitexports; leer oder "none" = Einzeleinträge
+func ParseTimeExportGrouping(value string) (TimeExportGrouping, error) {
+	switch grouping := TimeExportGrouping(strings.ToLower(strings.TrimSpace(value))); grouping {
+	case TimeExportGroupNone, "none":
+		return TimeExportGroupNone, nil
+	case TimeExportGroupDay, TimeExportGroupWeek, TimeExportGroupProject:
+		return grouping, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidTimeExportGrouping, value)
+	}
+}
+
+// ParseTimeExportRange liest Start- und Enddatum (YYYY-MM-DD, jeweils optional) in der angegebenen Zeitzone
+func ParseTimeExportRange(from, to string, location *time.Location) (time.Time, time.Time, error) {
+	var start, end time.Time
+	var err error
+	if from != "" {
+		if start, err = time.ParseInLocation("2006-01-02", from, location); err != nil {
+			return start, end, fmt.Errorf("%w: ungültiges Startdatum %q", ErrInvalidTimeExportFilter, from)
+		}
+	}
+	if to != "" {
+		if end, err = time.ParseInLocation("2006-01-02", to, location); err != nil {
+			return start, end, fmt.Errorf("%w: ungültiges Enddatum %q", ErrInvalidTimeExportFilter, to)
+		}
+	}
+	if !start.IsZero() && !end.IsZero() && end.Before(start) {
+		return start, end, fmt.Errorf("%w: das Enddatum liegt vor dem Startdatum", ErrInvalidTimeExportFilter)
+	}
+	return start, end, nil
+}
+
+// TimeExportFilter schränkt die exportierten Zeiteinträge ein; leere Listen bedeuten keine Einschränkung
+type TimeExportFilter struct {
+	From        time.Time // erster Tag (einschließlich)
+	To          time.Time // letzter Tag (einschließlich)
+	Departments []string
+	Projects    []string // Projekt-ID oder Projektname
+	Sources     []string
+	Location    *time.Location // Zeitzone für Datum und Uhrzeiten, Standard UTC
+}
+
+// TimeExportEntry ist ein Zeiteintrag mit den Angaben des Mitarbeiters
+type TimeExportEntry struct {
+	EmployeeID     string
+	EmployeeNumber string // Personalnummer
+	EmployeeName   string
+	Department     string
+	Date           time.Time // Tag in der Zeitzone des Filters
+	StartTime      time.Time
+	EndTime        time.Time
+	Hours          float64
+	ProjectID      string
+	ProjectName    string
+	Activity       string
+	Description    string
+	WageType       string
+	Source         string
+}
+
+// timeExportDay gibt den Tag eines Zeitpunkts in der Zeitzone zurück
+func timeExportDay(value time.Time, location *time.Location) time.Time {
+	local := value.In(location)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, location)
+}
+
+// CollectTimeExportEntries sammelt die Zeiteinträge der Mitarbeiter, die dem Filter entsprechen,
+// sortiert nach Mitarbeiter, Datum und Beginn
+func CollectTimeExportEntries(employees []*Employee, filter TimeExportFilter) []TimeExportEntry {
+	location := filter.Location
+	if location == nil {
+		location = time.UTC
+	}
+
+	var entries []TimeExportEntry
+	for _, employee := range employees {
+		if len(filter.Departments) > 0 && !exportFilterContains(filter.Departments, string(employee.Department)) {
+			continue
+		}
+		for _, entry := range employee.TimeEntries {
+			day := timeExportDay(entry.Date, location)
+			if !filter.From.IsZero() && day.Before(timeExportDay(filter.From, location)) {
+				continue
+			}
+			if !filter.To.IsZero() && day.After(timeExportDay(filter.To, location)) {
+				continue
+			}
+			if len(filter.Projects) > 0 && !exportFilterContains(filter.Projects, entry.ProjectID) && !exportFilterContains(filter.Projects, entry.ProjectName) {
+				continue
+			}
+			if len(filter.Sources) > 0 && !exportFilterContains(filter.Sources, entry.Source) {
+				continue
+			}
+
+			exportEntry := TimeExportEntry{
+				EmployeeID:     employee.ID.Hex(),
+				EmployeeNumber: employee.EmployeeID,
+				EmployeeName:   employee.FirstName + " " + employee.LastName,
+				Department:     string(employee.Department),
+				Date:           day,
+				Hours:          entry.Duration,
+				ProjectID:      entry.ProjectID,
+				ProjectName:    entry.ProjectName,
+				Activity:       entry.Activity,
+				Description:    entry.Description,
+				WageType:       entry.WageType,
+				Source:         entry.Source,
+			}
+			if !entry.StartTime.IsZero() {
+				exportEntry.StartTime = entry.StartTime.In(location)
+			}
+			if !entry.EndTime.IsZero() {
+				exportEntry.EndTime = entry.EndTime.In(location)
+			}
+			entries = append(entries, exportEntry)
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.EmployeeName != b.EmployeeName {
+			return a.EmployeeName < b.EmployeeName
+		}
+		if !a.Date.Equal(b.Date) {
+			return a.Date.Before(b.Date)
+		}
+		return a.StartTime.Before(b.StartTime)
+	})
+	return entries
+}
+
+// TimeExportColumn ist eine Spalte eines Zeitexports
+type TimeExportColumn struct {
+	Label   string
+	Width   float64 // relative Breite im PDF
+	Numeric bool    // rechtsbündig im PDF
+}
+
+// TimeExportTable ist ein Zeitexport als Tabelle, aus der CSV, XLSX und PDF erzeugt werden.
+// Zellen enthalten string, int, float64 oder time.Time (Datum).
+type TimeExportTable struct {
+	Title      string
+	Columns    []TimeExportColumn
+	Rows       [][]interface{}
+	HoursIndex int // Spalte mit den Stunden, für die Summenzeile
+	TotalHours float64
+}
+
+// Header gibt die Spaltenüberschriften zurück
+func (t *TimeExportTable) Header() []string {
+	header := make([]string, len(t.Columns))
+	for i, column := range t.Columns {
+		header[i] = column.Label
+	}
+	return header
+}
+
+// roundHours rundet Stunden auf zwei Nachkommastellen
+func roundHours(hours float64) float64 {
+	return math.Round(hours*100) / 100
+}
+
+// timeExportClock gibt die Uhrzeit als HH:MM zurück, leer für fehlende Zeiten
+func timeExportClock(value time.Time) string {
+	if value.IsZero() {
+		return ""
+	}
+	return value.Format("15:04")
+}
+
+// timeExportGroup sammelt die Summen einer Gruppe
+type timeExportGroup struct {
+	first    TimeExportEntry
+	hours    float64
+	count    int
+	projects []string
+}
+
+// BuildTimeExportTable erzeugt die Tabelle eines Zeitexports für die gewählte Gruppierung
+func BuildTimeExportTable(entries []TimeExportEntry, grouping TimeExportGrouping) *TimeExportTable {
+	table := &TimeExportTable{}
+	for _, entry := range entries {
+		table.TotalHours += entry.Hours
+	}
+	table.TotalHours = roundHours(table.TotalHours)
+
+	employeeColumns := []TimeExportColumn{{Label: "Mitarbeiter", Width: 3}, {Label: "Personalnummer", Width: 1.5}, {Label: "Abteilung", Width: 2}}
+
+	switch grouping {
+	case TimeExportGroupDay, TimeExportGroupWeek:
+		var keys []string
+		groups := map[string]*timeExportGroup{}
+		for _, entry := range entries {
+			period := entry.Date.Format("2006-01-02")
+			if grouping == TimeExportGroupWeek {
+				year, week := entry.Date.ISOWeek()
+				period = fmt.Sprintf("%d-W%02d", year, week)
+			}
+			key := entry.EmployeeID + "|" + period
+			group, ok := groups[key]
+			if !ok {
+				group = &timeExportGroup{first: entry}
+				groups[key] = group
+				keys = append(keys, key)
+			}
+			group.hours += entry.Hours
+			group.count++
+			if entry.ProjectName != "" && !exportFilterContains(group.projects, entry.ProjectName) {
+				group.projects = append(group.projects, entry.ProjectName)
+			}
+		}
+
+		if grouping == TimeExportGroupDay {
+			table.Title = "Zeiterfassung pro Tag"
+			table.Columns = append(employeeColumns,
+				TimeExportColumn{Label: "Datum", Width: 1.5},
+				TimeExportColumn{Label: "Stunden", Width: 1, Numeric: true},
+				TimeExportColumn{Label: "Einträge", Width: 1, Numeric: true},
+				TimeExportColumn{Label: "Projekte", Width: 4})
+			table.HoursIndex = 4
+		} else {
+			table.Title = "Zeiterfassung pro Woche"
+			table.Columns = append(employeeColumns,
+				TimeExportColumn{Label: "Kalenderwoche", Width: 1.5},
+				TimeExportColumn{Label: "Von", Width: 1.5},
+				TimeExportColumn{Label: "Bis", Width: 1.5},
+				TimeExportColumn{Label: "Stunden", Width: 1, Numeric: true},
+				TimeExportColumn{Label: "Einträge", Width: 1, Numeric: true})
+			table.HoursIndex = 6
+		}
+
+		// Die Einträge sind nach Mitarbeiter und Datum sortiert, die Gruppen damit auch
+		for _, key := range keys {
+			group := groups[key]
+			row := []interface{}{group.first.EmployeeName, group.first.EmployeeNumber, group.first.Department}
+			if grouping == TimeExportGroupDay {
+				row = append(row, group.first.Date, roundHours(group.hours), group.count, strings.Join(group.projects, ", "))
+			} else {
+				year, week := group.first.Date.ISOWeek()
+				monday := group.first.Date.AddDate(0, 0, -((int(group.first.Date.Weekday()) + 6) % 7))
+				row = append(row, fmt.Sprintf("KW %02d/%d", week, year), monday, monday.AddDate(0, 0, 6), roundHours(group.hours), group.count)
+			}
+			table.Rows = append(table.Rows, row)
+		}
+
+	case TimeExportGroupProject:
+		var keys []string
+		groups := map[string]*timeExportGroup{}
+		for _, entry := range entries {
+			key := entry.ProjectName + "|" + entry.ProjectID + "|" + entry.EmployeeID
+			group, ok := groups[key]
+			if !ok {
+				group = &timeExportGroup{first: entry}
+				groups[key] = group
+				keys = append(keys, key)
+			}
+			group.hours += entry.Hours
+			group.count++
+		}
+		sort.SliceStable(keys, func(i, j int) bool {
+			a, b := groups[keys[i]].first, groups[keys[j]].first
+			if a.ProjectName != b.ProjectName {
+				return a.ProjectName < b.ProjectName
+			}
+			return a.EmployeeName < b.EmployeeName
+		})
+
+		table.Title = "Zeiterfassung pro Projekt"
+		table.Columns = append([]TimeExportColumn{{Label: "Projekt", Width: 4}}, employeeColumns...)
+		table.Columns = append(table.Columns,
+			TimeExportColumn{Label: "Stunden", Width: 1, Numeric: true},
+			TimeExportColumn{Label: "Einträge", Width: 1, Numeric: true})
+		table.HoursIndex = 4
+		for _, key := range keys {
+			group := groups[key]
+			project := group.first.ProjectName
+			if project == "" {
+				project = "Ohne Projekt"
+			}
+			table.Rows = append(table.Rows, []interface{}{project, group.first.EmployeeName, group.first.EmployeeNumber, group.first.Department, roundHours(group.hours), group.count})
+		}
+
+	default:
+		table.Title = "Zeiterfassung"
+		table.Columns = append(employeeColumns,
+			TimeExportColumn{Label: "Datum", Width: 1.5},
+			TimeExportColumn{Label: "Beginn", Width: 1},
+			TimeExportColumn{Label: "Ende", Width: 1},
+			TimeExportColumn{Label: "Stunden", Width: 1, Numeric: true},
+			TimeExportColumn{Label: "Projekt", Width: 3},
+			TimeExportColumn{Label: "Tätigkeit", Width: 2},
+			TimeExportColumn{Label: "Beschreibung", Width: 4},
+			TimeExportColumn{Label: "Lohnart", Width: 1.5},
+			TimeExportColumn{Label: "Quelle", Width: 1.5})
+		table.HoursIndex = 6
+		for _, entry := range entries {
+			table.Rows = append(table.Rows, []interface{}{
+				entry.EmployeeName, entry.EmployeeNumber, entry.Department, entry.Date,
+				timeExportClock(entry.StartTime), timeExportClock(entry.EndTime), roundHours(entry.Hours),
+				entry.ProjectName, entry.Activity, entry.Description, entry.WageType, entry.Source,
+			})
+		}
+	}
+
+	return table
+}
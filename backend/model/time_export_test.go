@@ -0,0 +1,145 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestParseTimeExportGrouping(t *testing.T) {
+	for value, want := range map[string]TimeExportGrouping{"": TimeExportGroupNone, "none": TimeExportGroupNone, "Day": TimeExportGroupDay, "week": TimeExportGroupWeek, "project": TimeExportGroupProject} {
+		got, err := ParseTimeExportGrouping(value)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+
+	_, err := ParseTimeExportGrouping("month")
+	assert.ErrorIs(t, err, ErrInvalidTimeExportGrouping)
+}
+
+func TestParseTimeExportRange(t *testing.T) {
+	from, to, err := ParseTimeExportRange("2024-04-01", "2024-04-30", time.UTC)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC), from)
+	assert.Equal(t, time.Date(2024, time.April, 30, 0, 0, 0, 0, time.UTC), to)
+
+	from, to, err = ParseTimeExportRange("", "", time.UTC)
+	require.NoError(t, err)
+	assert.True(t, from.IsZero() && to.IsZero())
+
+	for _, tt := range [][2]string{{"01.04.2024", ""}, {"", "2024-13-01"}, {"2024-04-30", "2024-04-01"}} {
+		_, _, err := ParseTimeExportRange(tt[0], tt[1], time.UTC)
+		assert.ErrorIs(t, err, ErrInvalidTimeExportFilter, tt)
+	}
+}
+
+// timeExportTestEmployees liefert zwei Mitarbeiter mit Einträgen in zwei Wochen
+func timeExportTestEmployees() []*Employee {
+	day := func(d, hour int) time.Time { return time.Date(2024, time.April, d, hour, 0, 0, 0, time.UTC) }
+	return []*Employee{
+		{
+			ID: primitive.NewObjectID(), EmployeeID: "1002", FirstName: "Ben", LastName: "Meyer", Department: "Sales",
+			TimeEntries: []TimeEntry{
+				{Date: day(2, 0), StartTime: day(2, 8), EndTime: day(2, 12), Duration: 4, ProjectID: "p1", ProjectName: "Kunde, Nord", Source: "manual"},
+			},
+		},
+		{
+			ID: primitive.NewObjectID(), EmployeeID: "1001", FirstName: "Anna", LastName: "Schmidt", Department: "IT",
+			TimeEntries: []TimeEntry{
+				{Date: day(8, 0), StartTime: day(8, 7), EndTime: day(8, 15), Duration: 8, ProjectID: "p2", ProjectName: "Intern", Source: "123erfasst"},
+				{Date: day(2, 0), StartTime: day(2, 13), EndTime: day(2, 15), Duration: 2, ProjectID: "p1", ProjectName: "Kunde, Nord", WageType: "Normal", Description: "Abstimmung", Source: "123erfasst"},
+				{Date: day(2, 0), StartTime: day(2, 8), EndTime: day(2, 12), Duration: 4, ProjectID: "p2", ProjectName: "Intern", Source: "123erfasst"},
+			},
+		},
+	}
+}
+
+func TestCollectTimeExportEntries(t *testing.T) {
+	employees := timeExportTestEmployees()
+
+	tests := []struct {
+		name   string
+		filter TimeExportFilter
+		want   []float64 // Stunden der Einträge in Ausgabereihenfolge
+	}{
+		{"alle sortiert", TimeExportFilter{}, []float64{4, 2, 8, 4}},
+		{"Zeitraum", TimeExportFilter{From: time.Date(2024, time.April, 3, 0, 0, 0, 0, time.UTC)}, []float64{8}},
+		{"Abteilung", TimeExportFilter{Departments: []string{"Sales"}}, []float64{4}},
+		{"Projekt nach Name", TimeExportFilter{Projects: []string{"Kunde, Nord"}}, []float64{2, 4}},
+		{"Projekt nach ID und Quelle", TimeExportFilter{Projects: []string{"p1"}, Sources: []string{"123erfasst"}}, []float64{2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries := CollectTimeExportEntries(employees, tt.filter)
+			hours := make([]float64, len(entries))
+			for i, entry := range entries {
+				hours[i] = entry.Hours
+			}
+			assert.Equal(t, tt.want, hours)
+		})
+	}
+}
+
+func TestCollectTimeExportEntries_Location(t *testing.T) {
+	berlin := time.FixedZone("CEST", 2*60*60)
+	employee := &Employee{ID: primitive.NewObjectID(), TimeEntries: []TimeEntry{{
+		Date:      time.Date(2024, time.April, 1, 22, 0, 0, 0, time.UTC), // 2. April 0 Uhr in Berlin
+		StartTime: time.Date(2024, time.April, 2, 6, 0, 0, 0, time.UTC),
+		Duration:  1,
+	}}}
+
+	entries := CollectTimeExportEntries([]*Employee{employee}, TimeExportFilter{
+		From: time.Date(2024, time.April, 2, 0, 0, 0, 0, berlin), Location: berlin,
+	})
+	require.Len(t, entries, 1)
+	assert.Equal(t, time.Date(2024, time.April, 2, 0, 0, 0, 0, berlin), entries[0].Date)
+	assert.Equal(t, "08:00", timeExportClock(entries[0].StartTime))
+}
+
+func TestBuildTimeExportTable(t *testing.T) {
+	entries := CollectTimeExportEntries(timeExportTestEmployees(), TimeExportFilter{})
+	april := func(d int) time.Time { return time.Date(2024, time.April, d, 0, 0, 0, 0, time.UTC) }
+
+	t.Run("Einzeleinträge", func(t *testing.T) {
+		table := BuildTimeExportTable(entries, TimeExportGroupNone)
+		assert.Equal(t, []string{"Mitarbeiter", "Personalnummer", "Abteilung", "Datum", "Beginn", "Ende", "Stunden", "Projekt", "Tätigkeit", "Beschreibung", "Lohnart", "Quelle"}, table.Header())
+		assert.Equal(t, []interface{}{"Anna Schmidt", "1001", "IT", april(2), "13:00", "15:00", 2.0, "Kunde, Nord", "", "Abstimmung", "Normal", "123erfasst"}, table.Rows[1])
+		assert.Equal(t, 18.0, table.TotalHours)
+		assert.Equal(t, "Stunden", table.Columns[table.HoursIndex].Label)
+	})
+
+	t.Run("pro Tag", func(t *testing.T) {
+		table := BuildTimeExportTable(entries, TimeExportGroupDay)
+		assert.Equal(t, [][]interface{}{
+			{"Anna Schmidt", "1001", "IT", april(2), 6.0, 2, "Intern, Kunde, Nord"},
+			{"Anna Schmidt", "1001", "IT", april(8), 8.0, 1, "Intern"},
+			{"Ben Meyer", "1002", "Sales", april(2), 4.0, 1, "Kunde, Nord"},
+		}, table.Rows)
+		assert.Equal(t, "Stunden", table.Columns[table.HoursIndex].Label)
+	})
+
+	t.Run("pro Woche", func(t *testing.T) {
+		table := BuildTimeExportTable(entries, TimeExportGroupWeek)
+		assert.Equal(t, [][]interface{}{
+			{"Anna Schmidt", "1001", "IT", "KW 14/2024", april(1), april(7), 6.0, 2},
+			{"Anna Schmidt", "1001", "IT", "KW 15/2024", april(8), april(14), 8.0, 1},
+			{"Ben Meyer", "1002", "Sales", "KW 14/2024", april(1), april(7), 4.0, 1},
+		}, table.Rows)
+		assert.Equal(t, "Stunden", table.Columns[table.HoursIndex].Label)
+	})
+
+	t.Run("pro Projekt", func(t *testing.T) {
+		table := BuildTimeExportTable(append(entries, TimeExportEntry{EmployeeID: "x", EmployeeName: "Clara Adler", Hours: 1.256}), TimeExportGroupProject)
+		assert.Equal(t, [][]interface{}{
+			{"Ohne Projekt", "Clara Adler", "", "", 1.26, 1},
+			{"Intern", "Anna Schmidt", "1001", "IT", 12.0, 2},
+			{"Kunde, Nord", "Anna Schmidt", "1001", "IT", 2.0, 1},
+			{"Kunde, Nord", "Ben Meyer", "1002", "Sales", 4.0, 1},
+		}, table.Rows)
+		assert.Equal(t, "Stunden", table.Columns[table.HoursIndex].Label)
+	})
+}
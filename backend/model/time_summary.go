@@ -0,0 +1,87 @@
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+// DayHours sind die erfassten Stunden eines Tages
+type DayHours struct {
+	Date  time.Time `json:"date"`
+	Hours float64   `json:"hours"`
+}
+
+// ProjectHours sind die erfassten Stunden eines Projekts
+type ProjectHours struct {
+	Name  string  `json:"name"`
+	Hours float64 `json:"hours"`
+}
+
+// TimeSummary fasst Zeiteinträge eines Zeitraums zusammen
+type TimeSummary struct {
+	TotalHours float64        `json:"totalHours"`
+	Days       []DayHours     `json:"days"`     // Jeder Tag des Zeitraums, auch ohne Einträge
+	Projects   []ProjectHours `json:"projects"` // Absteigend nach Stunden
+}
+
+// SummarizeTimeEntries summiert die Zeiteinträge im Zeitraum [start, end) je Tag und Projekt
+func SummarizeTimeEntries(entries []TimeEntry, start, end time.Time) TimeSummary {
+	summary := TimeSummary{}
+	dayIndex := make(map[string]int)
+	for d := start; d.Before(end); d = d.AddDate(0, 0, 1) {
+		dayIndex[d.Format("2006-01-02")] = len(summary.Days)
+		summary.Days = append(summary.Days, DayHours{Date: d})
+	}
+
+	projectHours := make(map[string]float64)
+	for _, entry := range entries {
+		if entry.Date.Before(start) || !entry.Date.Before(end) {
+			continue
+		}
+		summary.TotalHours += entry.Duration
+		if i, ok := dayIndex[entry.Date.In(start.Location()).Format("2006-01-02")]; ok {
+			summary.Days[i].Hours += entry.Duration
+		}
+
+		name := entry.ProjectName
+		if name == "" {
+			name = "Ohne Projekt"
+		}
+		projectHours[name] += entry.Duration
+	}
+
+	for name, hours := range projectHours {
+		summary.Projects = append(summary.Projects, ProjectHours{Name: name, Hours: hours})
+	}
+	sort.Slice(summary.Projects, func(i, j int) bool {
+		if summary.Projects[i].Hours != summary.Projects[j].Hours {
+			return summary.Projects[i].Hours > summary.Projects[j].Hours
+		}
+		return summary.Projects[i].Name < summary.Projects[j].Name
+	})
+	return summary
+}
+
+// AbsenceDaysInPeriod gibt die Abwesenheitstage im Zeitraum [start, end) zurück.
+// Liegt die Abwesenheit vollständig im Zeitraum, gilt die erfasste Anzahl Tage
+// (inklusive halber Tage), sonst werden die Werktage (Mo-Fr) der Überschneidung gezählt.
+func AbsenceDaysInPeriod(absence Absence, start, end time.Time) float64 {
+	if absence.EndDate.Before(start) || !absence.StartDate.Before(end) {
+		return 0
+	}
+	if !absence.StartDate.Before(start) && absence.EndDate.Before(end) && absence.Days > 0 {
+		return absence.Days
+	}
+
+	from := absence.StartDate
+	if from.Before(start) {
+		from = start
+	}
+	days := 0.0
+	for d := from; !d.After(absence.EndDate) && d.Before(end); d = d.AddDate(0, 0, 1) {
+		if d.Weekday() != time.Saturday && d.Weekday() != time.Sunday {
+			days++
+		}
+	}
+	return days
+}
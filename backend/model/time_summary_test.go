@@ -0,0 +1,63 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarizeTimeEntries(t *testing.T) {
+	monday := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+	at := func(day, hour int) time.Time { return monday.AddDate(0, 0, day).Add(time.Duration(hour) * time.Hour) }
+
+	entries := []TimeEntry{
+		{Date: at(0, 8), Duration: 4, ProjectName: "Website"},
+		{Date: at(0, 13), Duration: 3.5, ProjectName: "Intern"},
+		{Date: at(2, 9), Duration: 8, ProjectName: "Website"},
+		{Date: at(4, 9), Duration: 2},
+		{Date: at(-1, 9), Duration: 5, ProjectName: "Website"}, // Vorwoche
+		{Date: at(7, 9), Duration: 6, ProjectName: "Website"},  // Folgewoche
+	}
+
+	summary := SummarizeTimeEntries(entries, monday, monday.AddDate(0, 0, 7))
+
+	assert.Equal(t, 17.5, summary.TotalHours)
+	require.Len(t, summary.Days, 7)
+	assert.Equal(t, monday, summary.Days[0].Date)
+	assert.Equal(t, 7.5, summary.Days[0].Hours)
+	assert.Equal(t, 0.0, summary.Days[1].Hours)
+	assert.Equal(t, 8.0, summary.Days[2].Hours)
+	assert.Equal(t, 2.0, summary.Days[4].Hours)
+
+	assert.Equal(t, []ProjectHours{
+		{Name: "Website", Hours: 12},
+		{Name: "Intern", Hours: 3.5},
+		{Name: "Ohne Projekt", Hours: 2},
+	}, summary.Projects)
+}
+
+func TestAbsenceDaysInPeriod(t *testing.T) {
+	date := func(month time.Month, day int) time.Time { return time.Date(2024, month, day, 0, 0, 0, 0, time.UTC) }
+	mayStart, juneStart := date(5, 1), date(6, 1)
+
+	tests := []struct {
+		name    string
+		absence Absence
+		want    float64
+	}{
+		{"Inside the period uses recorded days", Absence{StartDate: date(5, 13), EndDate: date(5, 17), Days: 4}, 4},
+		{"Half day", Absence{StartDate: date(5, 14), EndDate: date(5, 14), Days: 0.5}, 0.5},
+		{"Starts in the previous month", Absence{StartDate: date(4, 29), EndDate: date(5, 3), Days: 5}, 3},
+		{"Ends in the next month", Absence{StartDate: date(5, 30), EndDate: date(6, 5), Days: 5}, 2},
+		{"Outside the period", Absence{StartDate: date(4, 1), EndDate: date(4, 5), Days: 5}, 0},
+		{"Starts at the end of the period", Absence{StartDate: juneStart, EndDate: date(6, 3), Days: 1}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, AbsenceDaysInPeriod(tt.absence, mayStart, juneStart))
+		})
+	}
+}
@@ -2,7 +2,13 @@
 package model
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
 	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // TimebutlerUser repräsentiert einen Benutzer aus der Timebutler-API
@@ -47,3 +53,239 @@ type TimebutlerAbsence struct {
 	SubstituteUserID   string    `json:"substituteUserId"`
 	EmailAddress       string    `json:"emailAddress"`
 }
+
+// Hash gibt eine Prüfsumme der Felder zurück, die in eine Abwesenheit übernommen werden.
+// Ändert sich die Prüfsumme, wurde die Abwesenheit in Timebutler bearbeitet.
+func (a TimebutlerAbsence) Hash() string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		a.ID,
+		a.UserID,
+		a.StartDate.Format("2006-01-02"),
+		a.EndDate.Format("2006-01-02"),
+		fmt.Sprint(a.IsHalfDay, a.IsMorning, a.IsExtraVacationDay),
+		a.AbsenceType,
+		a.Status,
+		fmt.Sprint(a.Workdays),
+		a.Comment,
+	}, ";")))
+	return hex.EncodeToString(sum[:])
+}
+
+// PeopleFlowType gibt den PeopleFlow-Abwesenheitstyp zurück (vacation, sick oder special)
+func (a TimebutlerAbsence) PeopleFlowType() string {
+	absenceType := strings.ToLower(a.AbsenceType)
+	switch {
+	case strings.Contains(absenceType, "sick"), strings.Contains(absenceType, "krank"):
+		return "sick"
+	case strings.Contains(absenceType, "special"), strings.Contains(absenceType, "sonder"):
+		return "special"
+	default:
+		return "vacation"
+	}
+}
+
+// PeopleFlowStatus gibt den PeopleFlow-Status zurück (requested, approved, rejected oder cancelled)
+func (a TimebutlerAbsence) PeopleFlowStatus() string {
+	switch strings.ToLower(a.Status) {
+	case "requested":
+		return "requested"
+	case "rejected", "declined":
+		return "rejected"
+	case "cancelled", "canceled", "storniert":
+		return "cancelled"
+	default:
+		return "approved"
+	}
+}
+
+// Days gibt die Arbeitstage der Abwesenheit zurück; fehlen sie in Timebutler, werden
+// sie aus dem Zeitraum berechnet (mindestens ein halber Tag)
+func (a TimebutlerAbsence) Days() float64 {
+	if a.Workdays >= 0.1 {
+		return a.Workdays
+	}
+	days := a.EndDate.Sub(a.StartDate).Hours() / 24
+	if a.IsHalfDay {
+		days = 0.5
+	}
+	if days < 0.5 {
+		days = 0.5
+	}
+	return days
+}
+
+// applyTo überträgt die Timebutler-Daten auf eine Abwesenheit
+func (a TimebutlerAbsence) applyTo(absence *Absence) {
+	absence.Type = a.PeopleFlowType()
+	absence.StartDate = a.StartDate
+	absence.EndDate = a.EndDate
+	absence.Days = a.Days()
+	absence.Status = a.PeopleFlowStatus()
+	absence.Reason = a.AbsenceType
+	absence.Notes = a.Comment
+	absence.TimebutlerID = a.ID
+	absence.TimebutlerHash = a.Hash()
+}
+
+// differsFrom prüft, ob die Abwesenheit seit der letzten Übernahme in PeopleFlow geändert wurde
+func (a TimebutlerAbsence) differsFrom(absence Absence) bool {
+	return absence.Status != a.PeopleFlowStatus() ||
+		absence.Type != a.PeopleFlowType() ||
+		!absence.StartDate.Equal(a.StartDate) ||
+		!absence.EndDate.Equal(a.EndDate)
+}
+
+// AbsenceSyncResult zählt die Änderungen eines Abgleichs der Abwesenheiten
+type AbsenceSyncResult struct {
+	Created   int // neu übernommen
+	Updated   int // im angebundenen System geändert
+	Cancelled int // im angebundenen System storniert
+	Removed   int // im angebundenen System gelöscht
+	Unchanged int // Prüfsumme unverändert
+
+	// Diverged enthält zugeordnete Abwesenheiten, die nur in PeopleFlow geändert wurden
+	// (z.B. storniert oder verschoben). Solche Änderungen werden nicht zurückübertragen.
+	Diverged []Absence
+}
+
+// TimebutlerSkippedRows beschreibt Zeilen des Abwesenheitsexports, die nicht gelesen werden konnten.
+// Für sie darf der Abgleich keine Abwesenheiten als gelöscht behandeln.
+type TimebutlerSkippedRows struct {
+	IDs       map[string]bool // Timebutler-IDs der übersprungenen Zeilen
+	WithoutID int             // übersprungene Zeilen ohne erkennbare ID
+}
+
+// Add vermerkt eine übersprungene Zeile mit der Timebutler-ID id (leer, wenn unbekannt)
+func (s *TimebutlerSkippedRows) Add(id string) {
+	if id == "" {
+		s.WithoutID++
+		return
+	}
+	if s.IDs == nil {
+		s.IDs = make(map[string]bool)
+	}
+	s.IDs[id] = true
+}
+
+// Count gibt die Anzahl übersprungener Zeilen zurück
+func (s TimebutlerSkippedRows) Count() int {
+	return len(s.IDs) + s.WithoutID
+}
+
+// protects prüft, ob eine fehlende Abwesenheit nur übersprungen und nicht gelöscht wurde.
+// Ist eine Zeile ohne ID übersprungen worden, gilt das für alle Abwesenheiten.
+func (s TimebutlerSkippedRows) protects(id string) bool {
+	return s.WithoutID > 0 || s.IDs[id]
+}
+
+// Changed prüft, ob sich Abwesenheiten des Mitarbeiters geändert haben
+func (r AbsenceSyncResult) Changed() bool {
+	return r.Created+r.Updated+r.Cancelled+r.Removed > 0
+}
+
+// Add zählt das Ergebnis eines weiteren Mitarbeiters hinzu
+func (r *AbsenceSyncResult) Add(other AbsenceSyncResult) {
+	r.Created += other.Created
+	r.Updated += other.Updated
+	r.Cancelled += other.Cancelled
+	r.Removed += other.Removed
+	r.Unchanged += other.Unchanged
+	r.Diverged = append(r.Diverged, other.Diverged...)
+}
+
+// ApplyTimebutlerAbsences gleicht die Abwesenheiten des Mitarbeiters mit den Timebutler-Abwesenheiten
+// eines Jahres ab. Abwesenheiten werden über die Timebutler-ID zugeordnet und nur übernommen, wenn
+// sich ihre Prüfsumme geändert hat. Noch nicht zugeordnete Abwesenheiten mit gleichem Zeitraum
+// (z.B. aus früheren Synchronisierungen oder an Timebutler übertragene) erhalten die ID.
+// Zugeordnete Abwesenheiten des Jahres, die in Timebutler fehlen, wurden dort gelöscht und
+// werden entfernt – außer sie gehören zu einer übersprungenen Zeile des Exports (skipped).
+func (e *Employee) ApplyTimebutlerAbsences(year int, absences []TimebutlerAbsence, skipped TimebutlerSkippedRows) AbsenceSyncResult {
+	var result AbsenceSyncResult
+	seen := make(map[string]bool, len(absences))
+
+	for _, tbAbsence := range absences {
+		seen[tbAbsence.ID] = true
+
+		index := e.timebutlerAbsenceIndex(tbAbsence)
+		if index < 0 {
+			absence := Absence{ID: primitive.NewObjectID()}
+			tbAbsence.applyTo(&absence)
+			e.Absences = append(e.Absences, absence)
+			result.Created++
+			continue
+		}
+
+		absence := &e.Absences[index]
+		if absence.TimebutlerID == tbAbsence.ID && absence.TimebutlerHash == tbAbsence.Hash() {
+			result.Unchanged++
+			if tbAbsence.differsFrom(*absence) {
+				result.Diverged = append(result.Diverged, *absence)
+			}
+			continue
+		}
+		wasCancelled := absence.Status == "cancelled"
+		tbAbsence.applyTo(absence)
+		if absence.Status == "cancelled" && !wasCancelled {
+			result.Cancelled++
+		} else {
+			result.Updated++
+		}
+	}
+
+	kept := e.Absences[:0]
+	for _, absence := range e.Absences {
+		if absence.TimebutlerID != "" && absence.StartDate.Year() == year && !seen[absence.TimebutlerID] && !skipped.protects(absence.TimebutlerID) {
+			result.Removed++
+			continue
+		}
+		kept = append(kept, absence)
+	}
+	e.Absences = kept
+
+	return result
+}
+
+// timebutlerAbsenceIndex sucht die Abwesenheit zu einer Timebutler-Abwesenheit: zuerst über
+// die ID, dann über den Zeitraum einer noch nicht zugeordneten Abwesenheit
+func (e *Employee) timebutlerAbsenceIndex(tbAbsence TimebutlerAbsence) int {
+	for i, absence := range e.Absences {
+		if absence.TimebutlerID == tbAbsence.ID {
+			return i
+		}
+	}
+	for i, absence := range e.Absences {
+		if absence.TimebutlerID == "" &&
+			absence.StartDate.Equal(tbAbsence.StartDate) && absence.EndDate.Equal(tbAbsence.EndDate) {
+			return i
+		}
+	}
+	return -1
+}
+
+// PendingTimebutlerAbsences gibt die Indizes der beantragten und genehmigten Abwesenheiten eines
+// Jahres zurück, die in PeopleFlow erfasst und noch nicht an Timebutler übertragen wurden.
+// Übertragen werden nur neue Abwesenheiten; spätere Änderungen meldet ApplyTimebutlerAbsences als Diverged.
+func (e *Employee) PendingTimebutlerAbsences(year int) []int {
+	var pending []int
+	for i, absence := range e.Absences {
+		if absence.TimebutlerID != "" || absence.StartDate.Year() != year {
+			continue
+		}
+		if absence.Status == "requested" || absence.Status == "approved" {
+			pending = append(pending, i)
+		}
+	}
+	return pending
+}
+
+// TimebutlerAbsenceType gibt den Timebutler-Abwesenheitstyp für eine PeopleFlow-Abwesenheit zurück
+func TimebutlerAbsenceType(absenceType string) string {
+	switch absenceType {
+	case "sick":
+		return "Krankheit"
+	case "special":
+		return "Sonderurlaub"
+	default:
+		return "Urlaub"
+	}
+}
@@ -0,0 +1,144 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func timebutlerTestAbsence(id string, day int, status string) TimebutlerAbsence {
+	return TimebutlerAbsence{
+		ID:          id,
+		UserID:      "tb-1",
+		StartDate:   time.Date(2025, 6, day, 0, 0, 0, 0, time.UTC),
+		EndDate:     time.Date(2025, 6, day+1, 0, 0, 0, 0, time.UTC),
+		AbsenceType: "Urlaub",
+		Status:      status,
+		Workdays:    2,
+	}
+}
+
+func TestTimebutlerAbsence_Mapping(t *testing.T) {
+	tests := []struct {
+		absenceType, status string
+		wantType, wantState string
+	}{
+		{"Urlaub", "Approved", "vacation", "approved"},
+		{"Krankheit", "requested", "sick", "requested"},
+		{"Sonderurlaub", "declined", "special", "rejected"},
+		{"Special leave", "Cancelled", "special", "cancelled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.absenceType+"/"+tt.status, func(t *testing.T) {
+			absence := TimebutlerAbsence{AbsenceType: tt.absenceType, Status: tt.status}
+			assert.Equal(t, tt.wantType, absence.PeopleFlowType())
+			assert.Equal(t, tt.wantState, absence.PeopleFlowStatus())
+		})
+	}
+}
+
+func TestTimebutlerAbsence_HashChangesWithContent(t *testing.T) {
+	absence := timebutlerTestAbsence("1", 2, "approved")
+	same := absence
+	same.SubstituteState = "egal"
+	changed := absence
+	changed.Comment = "verschoben"
+
+	assert.Equal(t, absence.Hash(), same.Hash())
+	assert.NotEqual(t, absence.Hash(), changed.Hash())
+}
+
+func TestEmployee_ApplyTimebutlerAbsences(t *testing.T) {
+	employee := &Employee{}
+
+	result := employee.ApplyTimebutlerAbsences(2025, []TimebutlerAbsence{
+		timebutlerTestAbsence("1", 2, "approved"),
+		timebutlerTestAbsence("2", 10, "requested"),
+	}, TimebutlerSkippedRows{})
+	assert.Equal(t, AbsenceSyncResult{Created: 2}, result)
+	require.Len(t, employee.Absences, 2)
+	firstID := employee.Absences[0].ID
+
+	// Unveränderte Abwesenheiten werden übersprungen
+	result = employee.ApplyTimebutlerAbsences(2025, []TimebutlerAbsence{
+		timebutlerTestAbsence("1", 2, "approved"),
+		timebutlerTestAbsence("2", 10, "requested"),
+	}, TimebutlerSkippedRows{})
+	assert.Equal(t, AbsenceSyncResult{Unchanged: 2}, result)
+	assert.False(t, result.Changed())
+
+	// Geändert, storniert, gelöscht und neu
+	moved := timebutlerTestAbsence("1", 3, "approved")
+	result = employee.ApplyTimebutlerAbsences(2025, []TimebutlerAbsence{
+		moved,
+		timebutlerTestAbsence("3", 20, "approved"),
+	}, TimebutlerSkippedRows{})
+	assert.Equal(t, AbsenceSyncResult{Created: 1, Updated: 1, Removed: 1}, result)
+	require.Len(t, employee.Absences, 2)
+	assert.Equal(t, firstID, employee.Absences[0].ID, "die Abwesenheit behält ihre PeopleFlow-ID")
+	assert.Equal(t, moved.StartDate, employee.Absences[0].StartDate)
+
+	result = employee.ApplyTimebutlerAbsences(2025, []TimebutlerAbsence{
+		timebutlerTestAbsence("1", 3, "cancelled"),
+		timebutlerTestAbsence("3", 20, "approved"),
+	}, TimebutlerSkippedRows{})
+	assert.Equal(t, AbsenceSyncResult{Cancelled: 1, Unchanged: 1}, result)
+	assert.Equal(t, "cancelled", employee.Absences[0].Status)
+}
+
+func TestEmployee_ApplyTimebutlerAbsences_AdoptsAndKeepsLocalAbsences(t *testing.T) {
+	tbAbsence := timebutlerTestAbsence("7", 2, "approved")
+	local := Absence{ID: primitive.NewObjectID(), Type: "vacation", StartDate: tbAbsence.StartDate, EndDate: tbAbsence.EndDate, Status: "approved"}
+	otherYear := Absence{ID: primitive.NewObjectID(), StartDate: time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC), TimebutlerID: "old"}
+	unsynced := Absence{ID: primitive.NewObjectID(), StartDate: time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC), Status: "requested"}
+	employee := &Employee{Absences: []Absence{local, otherYear, unsynced}}
+
+	result := employee.ApplyTimebutlerAbsences(2025, []TimebutlerAbsence{tbAbsence}, TimebutlerSkippedRows{})
+
+	assert.Equal(t, AbsenceSyncResult{Updated: 1}, result)
+	require.Len(t, employee.Absences, 3, "Abwesenheiten anderer Jahre und ohne Timebutler-ID bleiben erhalten")
+	assert.Equal(t, local.ID, employee.Absences[0].ID)
+	assert.Equal(t, "7", employee.Absences[0].TimebutlerID)
+	assert.Equal(t, []int{2}, employee.PendingTimebutlerAbsences(2025))
+}
+
+func TestEmployee_ApplyTimebutlerAbsences_KeepsSkippedRows(t *testing.T) {
+	employee := &Employee{}
+	employee.ApplyTimebutlerAbsences(2025, []TimebutlerAbsence{
+		timebutlerTestAbsence("1", 2, "approved"),
+		timebutlerTestAbsence("2", 10, "approved"),
+	}, TimebutlerSkippedRows{})
+
+	// Zeile 2 konnte nicht gelesen werden: nicht als gelöscht behandeln
+	var skipped TimebutlerSkippedRows
+	skipped.Add("2")
+	result := employee.ApplyTimebutlerAbsences(2025, []TimebutlerAbsence{timebutlerTestAbsence("1", 2, "approved")}, skipped)
+	assert.Equal(t, AbsenceSyncResult{Unchanged: 1}, result)
+	require.Len(t, employee.Absences, 2)
+
+	// Eine Zeile ohne ID schützt alle Abwesenheiten
+	skipped = TimebutlerSkippedRows{}
+	skipped.Add("")
+	result = employee.ApplyTimebutlerAbsences(2025, nil, skipped)
+	assert.Equal(t, 0, result.Removed)
+	assert.Len(t, employee.Absences, 2)
+	assert.Equal(t, 1, skipped.Count())
+}
+
+func TestEmployee_ApplyTimebutlerAbsences_ReportsLocalChanges(t *testing.T) {
+	employee := &Employee{}
+	tbAbsence := timebutlerTestAbsence("1", 2, "approved")
+	employee.ApplyTimebutlerAbsences(2025, []TimebutlerAbsence{tbAbsence}, TimebutlerSkippedRows{})
+
+	employee.Absences[0].Status = "cancelled"
+	result := employee.ApplyTimebutlerAbsences(2025, []TimebutlerAbsence{tbAbsence}, TimebutlerSkippedRows{})
+
+	assert.Equal(t, 1, result.Unchanged)
+	require.Len(t, result.Diverged, 1)
+	assert.Equal(t, "cancelled", employee.Absences[0].Status, "die Änderung in PeopleFlow bleibt erhalten")
+	assert.False(t, result.Changed())
+}
@@ -0,0 +1,376 @@
+// backend/model/timesheet.go
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Fehler rund um den monatlichen Stundenzettel
+var (
+	ErrInvalidTimesheetMonth        = errors.New("invalid timesheet month")
+	ErrTimesheetMonthOpen           = errors.New("timesheet month is not finished yet")
+	ErrTimesheetAlreadyAcknowledged = errors.New("timesheet already acknowledged")
+	ErrTimesheetSelfApproval        = errors.New("own timesheet cannot be approved as manager")
+	ErrTimesheetNotManager          = errors.New("only the employee's manager can approve the timesheet")
+)
+
+// TimesheetDay enthält eine Zeile des Stundenzettels
+type TimesheetDay struct {
+	Date        time.Time `json:"date"`
+	Start       string    `json:"start,omitempty"` // erster Beginn des Tages (HH:MM)
+	End         string    `json:"end,omitempty"`   // letztes Ende des Tages (HH:MM)
+	BreakHours  float64   `json:"breakHours"`      // Zeit zwischen Beginn und Ende, die nicht erfasst wurde
+	WorkedHours float64   `json:"workedHours"`
+	TargetHours float64   `json:"targetHours"`
+	Holiday     string    `json:"holiday,omitempty"`
+	Absence     string    `json:"absence,omitempty"`
+	Weekend     bool      `json:"weekend"`
+}
+
+// Note gibt den Vermerk des Tages zurück (Feiertag, Abwesenheit oder Wochenende)
+func (d TimesheetDay) Note() string {
+	var notes []string
+	if d.Holiday != "" {
+		notes = append(notes, d.Holiday)
+	}
+	if d.Absence != "" {
+		notes = append(notes, d.Absence)
+	}
+	if len(notes) == 0 && d.Weekend {
+		notes = append(notes, "Wochenende")
+	}
+	return strings.Join(notes, ", ")
+}
+
+// Timesheet ist der monatliche Stundenzettel eines Mitarbeiters
+type Timesheet struct {
+	EmployeeID     primitive.ObjectID `json:"employeeId"`
+	EmployeeNumber string             `json:"employeeNumber"`
+	EmployeeName   string             `json:"employeeName"`
+	Department     string             `json:"department"`
+	Position       string             `json:"position"`
+	Month          time.Time          `json:"month"`
+	Days           []TimesheetDay     `json:"days"`
+	TargetHours    float64            `json:"targetHours"`
+	ActualHours    float64            `json:"actualHours"`
+	Difference     float64            `json:"difference"`
+	CarriedForward float64            `json:"carriedForward"` // Überstunden-Saldo zum Monatsbeginn
+	Adjustments    float64            `json:"adjustments"`    // im Monat genehmigte Anpassungen
+	ClosingBalance float64            `json:"closingBalance"` // Überstunden-Saldo zum Monatsende
+	VacationDays   int                `json:"vacationDays"`
+	SickDays       int                `json:"sickDays"`
+}
+
+// MonthKey gibt den Monat des Stundenzettels im Format YYYY-MM zurück
+func (t *Timesheet) MonthKey() string {
+	return t.Month.Format("2006-01")
+}
+
+// Checksum gibt eine Prüfsumme über alle Zeiten und Salden zurück. Weicht sie von der
+// Prüfsumme einer Bestätigung ab, wurde der Stundenzettel danach noch geändert.
+func (t *Timesheet) Checksum() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s|%s\n", t.EmployeeID.Hex(), t.MonthKey())
+	for _, day := range t.Days {
+		fmt.Fprintf(&b, "%s|%s|%s|%.2f|%.2f|%.2f|%s\n",
+			day.Date.Format("2006-01-02"), day.Start, day.End, day.BreakHours, day.WorkedHours, day.TargetHours, day.Note())
+	}
+	fmt.Fprintf(&b, "%.2f|%.2f|%.2f|%.2f", t.CarriedForward, t.Adjustments, t.ClosingBalance, t.Difference)
+
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+// FileName gibt den Dateinamen des Stundenzettels zurück, z.B. Stundenzettel_2024-04_1001_Max_Mustermann.pdf
+func (t *Timesheet) FileName() string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r == ' ':
+			return '_'
+		case r == '/' || r == '\\' || r == ':' || r == '"':
+			return -1
+		default:
+			return r
+		}
+	}, t.EmployeeName)
+
+	parts := []string{"Stundenzettel", t.MonthKey()}
+	if t.EmployeeNumber != "" {
+		parts = append(parts, t.EmployeeNumber)
+	}
+	if name != "" {
+		parts = append(parts, name)
+	}
+	return strings.Join(parts, "_") + ".pdf"
+}
+
+// ParseTimesheetMonth liest einen Monat im Format YYYY-MM. Ohne Angabe wird der Vormonat
+// von now verwendet, da der laufende Monat noch nicht abgeschlossen ist.
+func ParseTimesheetMonth(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).AddDate(0, -1, 0), nil
+	}
+	month, err := time.ParseInLocation("2006-01", value, now.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidTimesheetMonth, value)
+	}
+	return month, nil
+}
+
+// IsTimesheetMonthClosed prüft, ob der Monat zum Zeitpunkt now vollständig vergangen ist
+func IsTimesheetMonthClosed(month, now time.Time) bool {
+	start := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, month.Location())
+	return !now.Before(start.AddDate(0, 1, 0))
+}
+
+// BuildTimesheet erstellt den Stundenzettel des Monats month. holidays enthält die Feiertage
+// (YYYY-MM-DD → Name) aller Jahre, in denen Zeiteinträge vorliegen. Das Soll wird wie im
+// Zeitkonto berechnet: Montag bis Freitag ohne Feiertage und genehmigte Abwesenheiten, und nur
+// in Wochen mit Zeiteinträgen. Der Übertrag ergibt sich aus allen Tagen vor dem Monat und den
+// bis dahin genehmigten Anpassungen.
+func BuildTimesheet(employee *Employee, month time.Time, holidays map[string]string) *Timesheet {
+	location := month.Location()
+	start := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, location)
+	end := start.AddDate(0, 1, 0)
+
+	sheet := &Timesheet{
+		EmployeeID:     employee.ID,
+		EmployeeNumber: employee.EmployeeID,
+		EmployeeName:   employee.FirstName + " " + employee.LastName,
+		Department:     string(employee.Department),
+		Position:       employee.Position,
+		Month:          start,
+	}
+
+	entriesByDay := make(map[string][]TimeEntry)
+	weeks := make(map[string]time.Time)
+	for _, entry := range employee.TimeEntries {
+		day := timeExportDay(entry.Date, location)
+		entriesByDay[day.Format("2006-01-02")] = append(entriesByDay[day.Format("2006-01-02")], entry)
+		monday := timesheetMonday(day)
+		weeks[monday.Format("2006-01-02")] = monday
+	}
+
+	dailyTarget := timesheetDailyTarget(employee)
+	target := func(day time.Time) float64 {
+		if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+			return 0
+		}
+		if holidays[day.Format("2006-01-02")] != "" || timesheetAbsence(employee, day) != nil {
+			return 0
+		}
+		if _, ok := weeks[timesheetMonday(day).Format("2006-01-02")]; !ok {
+			return 0
+		}
+		return dailyTarget
+	}
+
+	// Übertrag aus allen Wochen mit Zeiteinträgen vor dem Monat
+	for _, monday := range weeks {
+		for day := monday; day.Before(monday.AddDate(0, 0, 7)) && day.Before(start); day = day.AddDate(0, 0, 1) {
+			sheet.CarriedForward += timesheetWorkedHours(entriesByDay[day.Format("2006-01-02")]) - target(day)
+		}
+	}
+	for _, adjustment := range employee.GetApprovedAdjustments() {
+		date := adjustment.ApprovedAt
+		if date.IsZero() {
+			date = adjustment.CreatedAt
+		}
+		if date.Before(start) {
+			sheet.CarriedForward += adjustment.Hours
+		} else if date.Before(end) {
+			sheet.Adjustments += adjustment.Hours
+		}
+	}
+
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		entries := entriesByDay[day.Format("2006-01-02")]
+		row := TimesheetDay{
+			Date:        day,
+			WorkedHours: roundHours(timesheetWorkedHours(entries)),
+			TargetHours: roundHours(target(day)),
+			Holiday:     holidays[day.Format("2006-01-02")],
+			Weekend:     day.Weekday() == time.Saturday || day.Weekday() == time.Sunday,
+		}
+		if absence := timesheetAbsence(employee, day); absence != nil {
+			row.Absence = datevAbsenceLabel(absence.Type)
+			if !row.Weekend && row.Holiday == "" {
+				switch absence.Type {
+				case "vacation":
+					sheet.VacationDays++
+				case "sick":
+					sheet.SickDays++
+				}
+			}
+		}
+
+		var first, last time.Time
+		for _, entry := range entries {
+			if !entry.StartTime.IsZero() && (first.IsZero() || entry.StartTime.Before(first)) {
+				first = entry.StartTime
+			}
+			if !entry.EndTime.IsZero() && entry.EndTime.After(last) {
+				last = entry.EndTime
+			}
+		}
+		if !first.IsZero() && !last.IsZero() && last.After(first) {
+			row.Start = timeExportClock(first.In(location))
+			row.End = timeExportClock(last.In(location))
+			if pause := last.Sub(first).Hours() - row.WorkedHours; pause > 0 {
+				row.BreakHours = roundHours(pause)
+			}
+		}
+
+		sheet.TargetHours += row.TargetHours
+		sheet.ActualHours += row.WorkedHours
+		sheet.Days = append(sheet.Days, row)
+	}
+
+	sheet.TargetHours = roundHours(sheet.TargetHours)
+	sheet.ActualHours = roundHours(sheet.ActualHours)
+	sheet.Difference = roundHours(sheet.ActualHours - sheet.TargetHours)
+	sheet.CarriedForward = roundHours(sheet.CarriedForward)
+	sheet.Adjustments = roundHours(sheet.Adjustments)
+	sheet.ClosingBalance = roundHours(sheet.CarriedForward + sheet.Difference + sheet.Adjustments)
+	return sheet
+}
+
+// timesheetDailyTarget gibt das Soll eines Arbeitstages zurück; bei Teilzeit mit weniger als
+// fünf Tagen wird es wie im Zeitkonto anteilig auf Montag bis Freitag verteilt
+func timesheetDailyTarget(employee *Employee) float64 {
+	daily := employee.GetWorkingHoursPerDay()
+	if daily == 0 {
+		daily = 8.0
+	}
+	if employee.WorkingDaysPerWeek > 0 && employee.WorkingDaysPerWeek < 5 {
+		daily *= float64(employee.WorkingDaysPerWeek) / 5.0
+	}
+	return daily
+}
+
+// timesheetAbsence gibt die genehmigte Abwesenheit am Tag day zurück
+func timesheetAbsence(employee *Employee, day time.Time) *Absence {
+	for i := range employee.Absences {
+		absence := &employee.Absences[i]
+		if absence.Status != "approved" {
+			continue
+		}
+		if !day.Before(timeExportDay(absence.StartDate, day.Location())) && !day.After(timeExportDay(absence.EndDate, day.Location())) {
+			return absence
+		}
+	}
+	return nil
+}
+
+func timesheetWorkedHours(entries []TimeEntry) float64 {
+	var hours float64
+	for _, entry := range entries {
+		hours += entry.Duration
+	}
+	return hours
+}
+
+func timesheetMonday(day time.Time) time.Time {
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+// TimesheetSignatureRole unterscheidet die Bestätigung durch den Mitarbeiter und den Vorgesetzten
+type TimesheetSignatureRole string
+
+const (
+	TimesheetSignatureEmployee TimesheetSignatureRole = "employee"
+	TimesheetSignatureManager  TimesheetSignatureRole = "manager"
+)
+
+// TimesheetSignature hält fest, wer den Stundenzettel wann in welchem Stand bestätigt hat
+type TimesheetSignature struct {
+	UserID   primitive.ObjectID `bson:"userId" json:"userId"`
+	Name     string             `bson:"name" json:"name"`
+	At       time.Time          `bson:"at" json:"at"`
+	Checksum string             `bson:"checksum" json:"-"`
+}
+
+// TimesheetAcknowledgement speichert die Bestätigungen eines Stundenzettels
+type TimesheetAcknowledgement struct {
+	ID         primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	EmployeeID primitive.ObjectID  `bson:"employeeId" json:"employeeId"`
+	Month      string              `bson:"month" json:"month"` // YYYY-MM
+	Employee   *TimesheetSignature `bson:"employee,omitempty" json:"employee,omitempty"`
+	Manager    *TimesheetSignature `bson:"manager,omitempty" json:"manager,omitempty"`
+	CreatedAt  time.Time           `bson:"createdAt" json:"createdAt"`
+	UpdatedAt  time.Time           `bson:"updatedAt" json:"updatedAt"`
+}
+
+// Signature gibt die Bestätigung der Rolle role zurück
+func (a *TimesheetAcknowledgement) Signature(role TimesheetSignatureRole) *TimesheetSignature {
+	if a == nil {
+		return nil
+	}
+	if role == TimesheetSignatureManager {
+		return a.Manager
+	}
+	return a.Employee
+}
+
+// Sign trägt eine Bestätigung ein. Eine erneute Bestätigung ist nur möglich, wenn sich der
+// Stundenzettel seit der letzten Bestätigung dieser Rolle geändert hat.
+func (a *TimesheetAcknowledgement) Sign(role TimesheetSignatureRole, signature TimesheetSignature) error {
+	if existing := a.Signature(role); existing != nil && existing.Checksum == signature.Checksum {
+		return ErrTimesheetAlreadyAcknowledged
+	}
+	if role == TimesheetSignatureManager {
+		a.Manager = &signature
+	} else {
+		a.Employee = &signature
+	}
+	return nil
+}
+
+// CheckTimesheetApprover prüft, ob user den Stundenzettel von employee gegenzeichnen darf:
+// Admins immer, Manager nur als eingetragener Vorgesetzter. Den eigenen Stundenzettel darf
+// niemand gegenzeichnen.
+func CheckTimesheetApprover(user *User, employee *Employee) error {
+	if user.EmployeeID != nil && *user.EmployeeID == employee.ID {
+		return ErrTimesheetSelfApproval
+	}
+	if user.Role == RoleAdmin {
+		return nil
+	}
+	if user.EmployeeID == nil || employee.ManagerID.IsZero() || *user.EmployeeID != employee.ManagerID {
+		return ErrTimesheetNotManager
+	}
+	return nil
+}
+
+// TimesheetStatus fasst den Stand der Bestätigungen für den aktuellen Stundenzettel zusammen
+type TimesheetStatus struct {
+	EmployeeAcknowledged bool                `json:"employeeAcknowledged"`
+	ManagerAcknowledged  bool                `json:"managerAcknowledged"`
+	ChangedSinceSigning  bool                `json:"changedSinceSigning"` // mindestens eine Bestätigung bezieht sich auf einen älteren Stand
+	Employee             *TimesheetSignature `json:"employee,omitempty"`
+	Manager              *TimesheetSignature `json:"manager,omitempty"`
+}
+
+// Status vergleicht die Bestätigungen mit der aktuellen Prüfsumme des Stundenzettels
+func (a *TimesheetAcknowledgement) Status(checksum string) TimesheetStatus {
+	status := TimesheetStatus{
+		Employee: a.Signature(TimesheetSignatureEmployee),
+		Manager:  a.Signature(TimesheetSignatureManager),
+	}
+	if status.Employee != nil {
+		status.EmployeeAcknowledged = status.Employee.Checksum == checksum
+		status.ChangedSinceSigning = !status.EmployeeAcknowledged
+	}
+	if status.Manager != nil {
+		status.ManagerAcknowledged = status.Manager.Checksum == checksum
+		status.ChangedSinceSigning = status.ChangedSinceSigning || !status.ManagerAcknowledged
+	}
+	return status
+}
@@ -0,0 +1,160 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestParseTimesheetMonth(t *testing.T) {
+	now := time.Date(2024, time.May, 15, 10, 0, 0, 0, time.UTC)
+
+	month, err := ParseTimesheetMonth("", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC), month)
+
+	month, err = ParseTimesheetMonth("2024-02", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC), month)
+
+	_, err = ParseTimesheetMonth("02/2024", now)
+	assert.ErrorIs(t, err, ErrInvalidTimesheetMonth)
+}
+
+func TestIsTimesheetMonthClosed(t *testing.T) {
+	april := time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)
+	assert.False(t, IsTimesheetMonthClosed(april, time.Date(2024, time.April, 30, 23, 59, 0, 0, time.UTC)))
+	assert.True(t, IsTimesheetMonthClosed(april, time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+// timesheetTestEmployee liefert eine Teilzeitkraft (20 Stunden an 4 Tagen) mit Einträgen im März und April 2024
+func timesheetTestEmployee() *Employee {
+	at := func(month time.Month, d, hour, minute int) time.Time {
+		return time.Date(2024, month, d, hour, minute, 0, 0, time.UTC)
+	}
+
+	employee := &Employee{
+		ID: primitive.NewObjectID(), EmployeeID: "1001", FirstName: "Anna", LastName: "Schmidt", Department: "IT", Position: "Entwicklerin",
+		WorkingHoursPerWeek: 20, WorkingDaysPerWeek: 4,
+		Absences: []Absence{
+			{Type: "vacation", StartDate: at(time.April, 3, 0, 0), EndDate: at(time.April, 3, 0, 0), Status: "approved"},
+			{Type: "sick", StartDate: at(time.April, 4, 0, 0), EndDate: at(time.April, 4, 0, 0), Status: "requested"},
+		},
+		OvertimeAdjustments: []OvertimeAdjustment{
+			{Hours: 1.5, Status: "approved", ApprovedAt: at(time.March, 30, 9, 0)},
+			{Hours: -2, Status: "approved", ApprovedAt: at(time.April, 10, 9, 0)},
+			{Hours: 10, Status: "pending", CreatedAt: at(time.April, 10, 9, 0)},
+		},
+	}
+	// Woche vom 25.03.: 22,5 Stunden bei 20 Stunden Soll
+	for d := 25; d <= 29; d++ {
+		employee.TimeEntries = append(employee.TimeEntries, TimeEntry{Date: at(time.March, d, 0, 0), Duration: 4.5})
+	}
+	employee.TimeEntries = append(employee.TimeEntries,
+		TimeEntry{Date: at(time.April, 2, 0, 0), StartTime: at(time.April, 2, 12, 30), EndTime: at(time.April, 2, 14, 0), Duration: 1.5},
+		TimeEntry{Date: at(time.April, 2, 0, 0), StartTime: at(time.April, 2, 8, 0), EndTime: at(time.April, 2, 12, 0), Duration: 4},
+	)
+	return employee
+}
+
+func TestBuildTimesheet(t *testing.T) {
+	april := time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)
+	sheet := BuildTimesheet(timesheetTestEmployee(), april, map[string]string{"2024-04-01": "Ostermontag"})
+
+	require.Len(t, sheet.Days, 30)
+	assert.Equal(t, "Anna Schmidt", sheet.EmployeeName)
+	assert.Equal(t, "2024-04", sheet.MonthKey())
+
+	easterMonday := sheet.Days[0]
+	assert.Equal(t, "Ostermontag", easterMonday.Note())
+	assert.Equal(t, 0.0, easterMonday.TargetHours)
+
+	tuesday := sheet.Days[1]
+	assert.Equal(t, "08:00", tuesday.Start)
+	assert.Equal(t, "14:00", tuesday.End)
+	assert.Equal(t, 0.5, tuesday.BreakHours)
+	assert.Equal(t, 5.5, tuesday.WorkedHours)
+	assert.Equal(t, 4.0, tuesday.TargetHours)
+
+	assert.Equal(t, "Urlaub", sheet.Days[2].Note())
+	assert.Equal(t, 0.0, sheet.Days[2].TargetHours)
+	assert.Equal(t, "", sheet.Days[3].Absence, "nicht genehmigte Abwesenheiten werden nicht vermerkt")
+	assert.Equal(t, "Wochenende", sheet.Days[5].Note())
+	assert.Equal(t, 0.0, sheet.Days[8].TargetHours, "Wochen ohne Zeiteinträge zählen wie im Zeitkonto nicht")
+
+	assert.Equal(t, 12.0, sheet.TargetHours)
+	assert.Equal(t, 5.5, sheet.ActualHours)
+	assert.Equal(t, -6.5, sheet.Difference)
+	assert.Equal(t, 4.0, sheet.CarriedForward)
+	assert.Equal(t, -2.0, sheet.Adjustments)
+	assert.Equal(t, -4.5, sheet.ClosingBalance)
+	assert.Equal(t, 1, sheet.VacationDays)
+	assert.Equal(t, 0, sheet.SickDays)
+	assert.Equal(t, "Stundenzettel_2024-04_1001_Anna_Schmidt.pdf", sheet.FileName())
+}
+
+func TestTimesheetChecksum(t *testing.T) {
+	april := time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)
+	employee := timesheetTestEmployee()
+
+	checksum := BuildTimesheet(employee, april, nil).Checksum()
+	assert.Equal(t, checksum, BuildTimesheet(employee, april, nil).Checksum())
+
+	employee.TimeEntries[len(employee.TimeEntries)-1].Duration = 3.5
+	assert.NotEqual(t, checksum, BuildTimesheet(employee, april, nil).Checksum())
+}
+
+func TestTimesheetAcknowledgement(t *testing.T) {
+	ack := &TimesheetAcknowledgement{Month: "2024-04"}
+	status := ack.Status("v1")
+	assert.False(t, status.EmployeeAcknowledged || status.ManagerAcknowledged || status.ChangedSinceSigning)
+
+	require.NoError(t, ack.Sign(TimesheetSignatureEmployee, TimesheetSignature{Name: "Anna Schmidt", Checksum: "v1"}))
+	assert.ErrorIs(t, ack.Sign(TimesheetSignatureEmployee, TimesheetSignature{Name: "Anna Schmidt", Checksum: "v1"}), ErrTimesheetAlreadyAcknowledged)
+	require.NoError(t, ack.Sign(TimesheetSignatureManager, TimesheetSignature{Name: "Max Chef", Checksum: "v1"}))
+
+	status = ack.Status("v1")
+	assert.True(t, status.EmployeeAcknowledged)
+	assert.True(t, status.ManagerAcknowledged)
+	assert.False(t, status.ChangedSinceSigning)
+
+	// Nach einer Änderung gelten die Bestätigungen nicht mehr, der Mitarbeiter kann erneut bestätigen
+	status = ack.Status("v2")
+	assert.False(t, status.EmployeeAcknowledged)
+	assert.True(t, status.ChangedSinceSigning)
+	require.NoError(t, ack.Sign(TimesheetSignatureEmployee, TimesheetSignature{Name: "Anna Schmidt", Checksum: "v2"}))
+	assert.Equal(t, "v2", ack.Employee.Checksum)
+
+	var missing *TimesheetAcknowledgement
+	assert.Nil(t, missing.Signature(TimesheetSignatureManager))
+	assert.False(t, missing.Status("v1").EmployeeAcknowledged)
+}
+
+func TestCheckTimesheetApprover(t *testing.T) {
+	managerEmployeeID := primitive.NewObjectID()
+	otherEmployeeID := primitive.NewObjectID()
+	employee := &Employee{ID: primitive.NewObjectID(), ManagerID: managerEmployeeID}
+
+	tests := []struct {
+		name string
+		user *User
+		want error
+	}{
+		{"eingetragener Vorgesetzter", &User{Role: RoleManager, EmployeeID: &managerEmployeeID}, nil},
+		{"anderer Manager", &User{Role: RoleManager, EmployeeID: &otherEmployeeID}, ErrTimesheetNotManager},
+		{"Manager ohne Mitarbeiter", &User{Role: RoleManager}, ErrTimesheetNotManager},
+		{"Admin", &User{Role: RoleAdmin}, nil},
+		{"eigener Stundenzettel", &User{Role: RoleAdmin, EmployeeID: &employee.ID}, ErrTimesheetSelfApproval},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, CheckTimesheetApprover(tt.user, employee), tt.want)
+		})
+	}
+
+	withoutManager := &Employee{ID: primitive.NewObjectID()}
+	assert.ErrorIs(t, CheckTimesheetApprover(&User{Role: RoleManager, EmployeeID: &otherEmployeeID}, withoutManager), ErrTimesheetNotManager)
+}
@@ -28,6 +28,7 @@ const (
 	// Benutzerstatus
 	StatusActive   UserStatus = "active"
 	StatusInactive UserStatus = "inactive"
+	StatusInvited  UserStatus = "invited" // Eingeladen, Konto noch nicht aktiviert
 )
 
 // User validation errors
@@ -55,6 +56,20 @@ type User struct {
 	DeletedAt    *time.Time          `bson:"deletedAt,omitempty" json:"deletedAt,omitempty"`
 	CreatedAt    time.Time           `bson:"createdAt" json:"createdAt"`
 	UpdatedAt    time.Time           `bson:"updatedAt" json:"updatedAt"`
+
+	// Passwortrichtlinie
+	PasswordHistory   []string   `bson:"passwordHistory,omitempty" json:"-"` // Letzte Passwort-Hashes (neueste zuerst)
+	PasswordChangedAt *time.Time `bson:"passwordChangedAt,omitempty" json:"passwordChangedAt,omitempty"`
+
+	// Zwei-Faktor-Authentifizierung (TOTP)
+	TwoFactorEnabled bool   `bson:"twoFactorEnabled" json:"twoFactorEnabled"`
+	TwoFactorSecret  string `bson:"twoFactorSecret,omitempty" json:"-"` // Encrypted TOTP secret (never exposed)
+
+	// Sprache für E-Mails ("de", "en"; leer = Systemsprache)
+	Language string `bson:"language,omitempty" json:"language,omitempty"`
+
+	// Benachrichtigungen (In-App / E-Mail je Art, fehlende Einträge = Voreinstellung)
+	NotificationPreferences NotificationPreferences `bson:"notificationPreferences,omitempty" json:"notificationPreferences,omitempty"`
 }
 
 // Validate validates all user fields
@@ -112,16 +127,13 @@ func (u *User) ValidateEmail() error {
 	return nil
 }
 
-// ValidatePassword validates password strength
+// ValidatePassword prüft das Passwort gegen die Mindestanforderungen, die jede Passwortrichtlinie
+// einhält. Die konfigurierte Richtlinie samt Historie und Breach-Liste prüft UserRepository.CheckPasswordPolicy.
 func (u *User) ValidatePassword() error {
 	if u.Password == "" {
 		return ErrPasswordTooShort
 	}
-	if len(u.Password) < 6 {
-		return ErrPasswordTooShort
-	}
-	// Add more password strength requirements as needed
-	return nil
+	return DefaultPasswordPolicy().Validate(u.Password)
 }
 
 // ValidateRole validates user role
@@ -137,7 +149,7 @@ func (u *User) ValidateRole() error {
 // ValidateStatus validates user status
 func (u *User) ValidateStatus() error {
 	switch u.Status {
-	case StatusActive, StatusInactive:
+	case StatusActive, StatusInactive, StatusInvited:
 		return nil
 	default:
 		return fmt.Errorf("%w: %s", ErrInvalidStatus, u.Status)
@@ -181,6 +193,14 @@ func (u *User) CheckPassword(password string) bool {
 	return false
 }
 
+// GetPasswordChangedAt returns when the password was last set (creation time for legacy users)
+func (u *User) GetPasswordChangedAt() time.Time {
+	if u.PasswordChangedAt != nil {
+		return *u.PasswordChangedAt
+	}
+	return u.CreatedAt
+}
+
 // GetFullName returns the user's full name
 func (u *User) GetFullName() string {
 	return strings.TrimSpace(u.FirstName + " " + u.LastName)
@@ -200,6 +220,11 @@ func (u *User) IsActive() bool {
 	return u.Status == StatusActive && u.DeletedAt == nil
 }
 
+// IsInvited returns true if the user has been invited but not yet activated
+func (u *User) IsInvited() bool {
+	return u.Status == StatusInvited && u.DeletedAt == nil
+}
+
 // IsAdmin returns true if the user has admin role
 func (u *User) IsAdmin() bool {
 	return u.Role == RoleAdmin
@@ -334,6 +359,8 @@ func (u *User) GetStatusDisplayName() string {
 		return "Aktiv"
 	case StatusInactive:
 		return "Inaktiv"
+	case StatusInvited:
+		return "Eingeladen"
 	default:
 		return string(u.Status)
 	}
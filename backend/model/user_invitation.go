@@ -0,0 +1,67 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DefaultInvitationValidity ist die Standard-Gültigkeitsdauer eines Aktivierungslinks
+const DefaultInvitationValidity = 72 * time.Hour
+
+// UserInvitation repräsentiert eine Einladung zur Kontoaktivierung
+type UserInvitation struct {
+	ID            primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	UserID        primitive.ObjectID  `bson:"userId" json:"userId"`
+	Email         string              `bson:"email" json:"email"`
+	TokenHash     string              `bson:"tokenHash" json:"-"` // SHA-256 des Tokens, das Token selbst wird nie gespeichert
+	EmployeeID    *primitive.ObjectID `bson:"employeeId,omitempty" json:"employeeId,omitempty"`
+	InvitedBy     primitive.ObjectID  `bson:"invitedBy" json:"invitedBy"`
+	InvitedByName string              `bson:"invitedByName" json:"invitedByName"`
+	ExpiresAt     time.Time           `bson:"expiresAt" json:"expiresAt"`
+	AcceptedAt    *time.Time          `bson:"acceptedAt,omitempty" json:"acceptedAt,omitempty"`
+	RevokedAt     *time.Time          `bson:"revokedAt,omitempty" json:"revokedAt,omitempty"`
+	CreatedAt     time.Time           `bson:"createdAt" json:"createdAt"`
+}
+
+// HashInvitationToken berechnet den Hash, unter dem ein Einladungstoken gespeichert wird
+func HashInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsExpired prüft, ob die Einladung abgelaufen ist
+func (i *UserInvitation) IsExpired() bool {
+	return time.Now().After(i.ExpiresAt)
+}
+
+// IsAccepted prüft, ob die Einladung bereits angenommen wurde
+func (i *UserInvitation) IsAccepted() bool {
+	return i.AcceptedAt != nil
+}
+
+// IsRevoked prüft, ob die Einladung zurückgezogen wurde
+func (i *UserInvitation) IsRevoked() bool {
+	return i.RevokedAt != nil
+}
+
+// IsUsable prüft, ob die Einladung noch zur Aktivierung verwendet werden kann
+func (i *UserInvitation) IsUsable() bool {
+	return !i.IsExpired() && !i.IsAccepted() && !i.IsRevoked()
+}
+
+// GetStatus gibt den Status der Einladung als Schlüssel zurück
+func (i *UserInvitation) GetStatus() string {
+	switch {
+	case i.IsAccepted():
+		return "accepted"
+	case i.IsRevoked():
+		return "revoked"
+	case i.IsExpired():
+		return "expired"
+	default:
+		return "pending"
+	}
+}
@@ -0,0 +1,67 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashInvitationToken(t *testing.T) {
+	hash := HashInvitationToken("secret-token")
+
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, HashInvitationToken("secret-token"))
+	assert.NotEqual(t, hash, HashInvitationToken("other-token"))
+	assert.NotContains(t, hash, "secret-token")
+}
+
+func TestUserInvitation_Status(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+
+	tests := []struct {
+		name       string
+		invitation *UserInvitation
+		usable     bool
+		status     string
+	}{
+		{
+			name:       "Pending invitation",
+			invitation: &UserInvitation{ExpiresAt: now.Add(DefaultInvitationValidity)},
+			usable:     true,
+			status:     "pending",
+		},
+		{
+			name:       "Expired invitation",
+			invitation: &UserInvitation{ExpiresAt: past},
+			usable:     false,
+			status:     "expired",
+		},
+		{
+			name:       "Accepted invitation",
+			invitation: &UserInvitation{ExpiresAt: now.Add(time.Hour), AcceptedAt: &past},
+			usable:     false,
+			status:     "accepted",
+		},
+		{
+			name:       "Revoked invitation",
+			invitation: &UserInvitation{ExpiresAt: now.Add(time.Hour), RevokedAt: &past},
+			usable:     false,
+			status:     "revoked",
+		},
+		{
+			name:       "Accepted wins over expired",
+			invitation: &UserInvitation{ExpiresAt: past, AcceptedAt: &past},
+			usable:     false,
+			status:     "accepted",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.usable, tt.invitation.IsUsable())
+			assert.Equal(t, tt.status, tt.invitation.GetStatus())
+		})
+	}
+}
@@ -129,9 +129,14 @@ func TestUserValidation(t *testing.T) {
 			},
 			{
 				name: "minimum length password",
-				password: "123456",
+				password: "12345678",
 				expectErr: false,
 			},
+			{
+				name: "below password policy minimum",
+				password: "123456",
+				expectErr: true,
+			},
 			{
 				name: "empty password",
 				password: "",
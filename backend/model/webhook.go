@@ -0,0 +1,230 @@
+package model
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// WebhookDeliveryStatus beschreibt den Zustand einer Webhook-Zustellung
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending WebhookDeliveryStatus = "pending" // Wartet auf (erneuten) Versand
+	WebhookDeliverySuccess WebhookDeliveryStatus = "success" // Empfänger hat mit 2xx geantwortet
+	WebhookDeliveryFailed  WebhookDeliveryStatus = "failed"  // Alle Versuche fehlgeschlagen
+
+	// WebhookMaxAttempts begrenzt die Zustellversuche pro Zustellung
+	WebhookMaxAttempts = 8
+
+	// WebhookRetryBaseDelay ist die Wartezeit nach dem ersten Fehlversuch; sie verdoppelt sich pro Versuch
+	WebhookRetryBaseDelay = time.Minute
+
+	// Header der ausgehenden Webhook-Anfragen
+	WebhookHeaderEvent     = "X-PeopleFlow-Event"
+	WebhookHeaderDelivery  = "X-PeopleFlow-Delivery"
+	WebhookHeaderTimestamp = "X-PeopleFlow-Timestamp"
+	WebhookHeaderSignature = "X-PeopleFlow-Signature"
+)
+
+// Webhook-Fehler
+var (
+	ErrWebhookNameRequired = errors.New("webhook name is required")
+	ErrInvalidWebhookURL   = errors.New("invalid webhook URL")
+	ErrInvalidWebhookEvent = errors.New("invalid webhook event")
+)
+
+// WebhookEndpoint ist ein vom Admin registrierter Empfänger für HR-Ereignisse
+type WebhookEndpoint struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Name      string             `bson:"name" json:"name"`
+	URL       string             `bson:"url" json:"url"`
+	Secret    string             `bson:"secret" json:"-"`      // Verschlüsselt gespeichert, wird für die HMAC-Signatur benötigt
+	Events    []ActivityType     `bson:"events" json:"events"` // Leer = alle Ereignisse
+	Active    bool               `bson:"active" json:"active"`
+	CreatedBy primitive.ObjectID `bson:"createdBy" json:"createdBy"`
+	CreatedAt time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
+
+// WebhookDelivery protokolliert die Zustellung eines Ereignisses an einen Endpunkt
+type WebhookDelivery struct {
+	ID             primitive.ObjectID    `bson:"_id,omitempty" json:"id"`
+	EndpointID     primitive.ObjectID    `bson:"endpointId" json:"endpointId"`
+	EventID        string                `bson:"eventId" json:"eventId"`
+	EventType      ActivityType          `bson:"eventType" json:"eventType"`
+	Payload        string                `bson:"payload" json:"payload"`
+	Status         WebhookDeliveryStatus `bson:"status" json:"status"`
+	Attempts       int                   `bson:"attempts" json:"attempts"`
+	ResponseStatus int                   `bson:"responseStatus,omitempty" json:"responseStatus,omitempty"`
+	ResponseBody   string                `bson:"responseBody,omitempty" json:"responseBody,omitempty"`
+	Error          string                `bson:"error,omitempty" json:"error,omitempty"`
+	DurationMs     int64                 `bson:"durationMs,omitempty" json:"durationMs,omitempty"`
+	NextAttemptAt  *time.Time            `bson:"nextAttemptAt,omitempty" json:"nextAttemptAt,omitempty"`
+	LastAttemptAt  *time.Time            `bson:"lastAttemptAt,omitempty" json:"lastAttemptAt,omitempty"`
+	DeliveredAt    *time.Time            `bson:"deliveredAt,omitempty" json:"deliveredAt,omitempty"`
+	RedeliveryOf   *primitive.ObjectID   `bson:"redeliveryOf,omitempty" json:"redeliveryOf,omitempty"`
+	CreatedAt      time.Time             `bson:"createdAt" json:"createdAt"`
+}
+
+// WebhookEvent ist der JSON-Body, der an die Endpunkte gesendet wird
+type WebhookEvent struct {
+	ID          string              `json:"id"`
+	Type        ActivityType        `json:"type"`
+	OccurredAt  time.Time           `json:"occurredAt"`
+	Actor       WebhookEventRef     `json:"actor"`
+	Target      *WebhookEventTarget `json:"target,omitempty"`
+	Description string              `json:"description"`
+	Metadata    map[string]string   `json:"metadata,omitempty"`
+}
+
+// WebhookEventRef verweist auf den auslösenden Benutzer
+type WebhookEventRef struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// WebhookEventTarget verweist auf das betroffene Objekt
+type WebhookEventTarget struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+// WebhookEvents gibt alle Ereignistypen zurück, die Endpunkte abonnieren können
+func WebhookEvents() []ActivityType {
+	return []ActivityType{
+		ActivityTypeEmployeeAdded, ActivityTypeEmployeeUpdated, ActivityTypeEmployeeDeleted,
+		ActivityTypeVacationRequested, ActivityTypeVacationApproved, ActivityTypeVacationRejected,
+		ActivityTypeOvertimeAdjusted, ActivityTypeDocumentUploaded, ActivityTypeSystemSettingChanged,
+		ActivityTypeConversationAdded, ActivityTypeConversationCompleted, ActivityTypeConversationUpdated,
+		ActivityTypeUserAdded, ActivityTypeUserUpdated, ActivityTypeUserDeleted,
+	}
+}
+
+// NewWebhookEvent erstellt den Ereignis-Body aus einer protokollierten Aktivität
+func NewWebhookEvent(activity *Activity) WebhookEvent {
+	event := WebhookEvent{
+		ID:          activity.ID.Hex(),
+		Type:        activity.Type,
+		OccurredAt:  activity.Timestamp,
+		Actor:       WebhookEventRef{ID: activity.UserID.Hex(), Name: activity.UserName},
+		Description: activity.Description,
+		Metadata:    activity.Metadata,
+	}
+	if !activity.TargetID.IsZero() {
+		event.Target = &WebhookEventTarget{
+			ID:   activity.TargetID.Hex(),
+			Type: activity.TargetType,
+			Name: activity.TargetName,
+		}
+	}
+	return event
+}
+
+// ParseWebhookEvents prüft und dedupliziert eine Liste von Ereignistypen
+func ParseWebhookEvents(values []string) ([]ActivityType, error) {
+	seen := make(map[ActivityType]bool)
+	events := []ActivityType{}
+	for _, value := range values {
+		event := ActivityType(strings.TrimSpace(value))
+		if event == "" || seen[event] {
+			continue
+		}
+		if !event.IsValid() {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidWebhookEvent, value)
+		}
+		seen[event] = true
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// Validate prüft Name, URL und Ereignisse des Endpunkts
+func (e *WebhookEndpoint) Validate() error {
+	if strings.TrimSpace(e.Name) == "" {
+		return ErrWebhookNameRequired
+	}
+	parsed, err := url.Parse(e.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("%w: %s", ErrInvalidWebhookURL, e.URL)
+	}
+	for _, event := range e.Events {
+		if !event.IsValid() {
+			return fmt.Errorf("%w: %s", ErrInvalidWebhookEvent, event)
+		}
+	}
+	return nil
+}
+
+// Subscribes prüft, ob der Endpunkt ein Ereignis empfangen soll
+func (e *WebhookEndpoint) Subscribes(event ActivityType) bool {
+	if !e.Active {
+		return false
+	}
+	if len(e.Events) == 0 {
+		return true
+	}
+	for _, subscribed := range e.Events {
+		if subscribed == event {
+			return true
+		}
+	}
+	return false
+}
+
+// SignWebhookPayload berechnet die Signatur "sha256=<hex>" über "<timestamp>.<body>"
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhookSignature prüft eine Signatur in konstanter Zeit (für Empfänger und Tests)
+func VerifyWebhookSignature(secret string, timestamp int64, body []byte, signature string) bool {
+	expected := SignWebhookPayload(secret, timestamp, body)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+// WebhookRetryDelay gibt die Wartezeit nach dem n-ten Fehlversuch zurück (exponentiell)
+func WebhookRetryDelay(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	return WebhookRetryBaseDelay * time.Duration(1<<uint(attempt-1))
+}
+
+// RecordAttempt wertet einen Zustellversuch aus und plant gegebenenfalls den nächsten
+func (d *WebhookDelivery) RecordAttempt(at time.Time, responseStatus int, errMessage string) {
+	d.Attempts++
+	d.LastAttemptAt = &at
+	d.ResponseStatus = responseStatus
+	d.Error = errMessage
+
+	if errMessage == "" && responseStatus >= 200 && responseStatus < 300 {
+		d.Status = WebhookDeliverySuccess
+		d.DeliveredAt = &at
+		d.NextAttemptAt = nil
+		return
+	}
+
+	if d.Attempts >= WebhookMaxAttempts {
+		d.Status = WebhookDeliveryFailed
+		d.NextAttemptAt = nil
+		return
+	}
+
+	next := at.Add(WebhookRetryDelay(d.Attempts))
+	d.Status = WebhookDeliveryPending
+	d.NextAttemptAt = &next
+}
@@ -0,0 +1,162 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestWebhookEndpoint_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint WebhookEndpoint
+		wantErr  error
+	}{
+		{"Valid https endpoint", WebhookEndpoint{Name: "Payroll", URL: "https://payroll.example.com/hook"}, nil},
+		{"Valid with events", WebhookEndpoint{Name: "IT", URL: "http://it.local:8080/in", Events: []ActivityType{ActivityTypeEmployeeAdded}}, nil},
+		{"Missing name", WebhookEndpoint{Name: " ", URL: "https://example.com"}, ErrWebhookNameRequired},
+		{"Unsupported scheme", WebhookEndpoint{Name: "FTP", URL: "ftp://example.com"}, ErrInvalidWebhookURL},
+		{"Missing host", WebhookEndpoint{Name: "Relative", URL: "/hook"}, ErrInvalidWebhookURL},
+		{"Unknown event", WebhookEndpoint{Name: "IT", URL: "https://example.com", Events: []ActivityType{"coffee_brewed"}}, ErrInvalidWebhookEvent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.endpoint.Validate()
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWebhookEndpoint_Subscribes(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint WebhookEndpoint
+		event    ActivityType
+		expected bool
+	}{
+		{"All events when list is empty", WebhookEndpoint{Active: true}, ActivityTypeUserDeleted, true},
+		{"Subscribed event", WebhookEndpoint{Active: true, Events: []ActivityType{ActivityTypeVacationApproved}}, ActivityTypeVacationApproved, true},
+		{"Other event", WebhookEndpoint{Active: true, Events: []ActivityType{ActivityTypeVacationApproved}}, ActivityTypeEmployeeAdded, false},
+		{"Inactive endpoint", WebhookEndpoint{Active: false}, ActivityTypeEmployeeAdded, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.endpoint.Subscribes(tt.event))
+		})
+	}
+}
+
+func TestParseWebhookEvents(t *testing.T) {
+	events, err := ParseWebhookEvents([]string{"employee_added", " employee_added ", "", "user_deleted"})
+	assert.NoError(t, err)
+	assert.Equal(t, []ActivityType{ActivityTypeEmployeeAdded, ActivityTypeUserDeleted}, events)
+
+	events, err = ParseWebhookEvents(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+
+	_, err = ParseWebhookEvents([]string{"employee_added", "unknown"})
+	assert.ErrorIs(t, err, ErrInvalidWebhookEvent)
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	body := []byte(`{"id":"1","type":"employee_added"}`)
+	signature := SignWebhookPayload("secret", 1700000000, body)
+
+	assert.Regexp(t, `^sha256=[0-9a-f]{64}$`, signature)
+	assert.True(t, VerifyWebhookSignature("secret", 1700000000, body, signature))
+	assert.False(t, VerifyWebhookSignature("other", 1700000000, body, signature), "different secret")
+	assert.False(t, VerifyWebhookSignature("secret", 1700000001, body, signature), "different timestamp")
+	assert.False(t, VerifyWebhookSignature("secret", 1700000000, []byte(`{}`), signature), "different body")
+}
+
+func TestWebhookRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempt  int
+		expected time.Duration
+	}{
+		{0, time.Minute},
+		{1, time.Minute},
+		{2, 2 * time.Minute},
+		{3, 4 * time.Minute},
+		{7, 64 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, WebhookRetryDelay(tt.attempt), "attempt %d", tt.attempt)
+	}
+}
+
+func TestWebhookDelivery_RecordAttempt(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Success", func(t *testing.T) {
+		d := &WebhookDelivery{Status: WebhookDeliveryPending}
+		d.RecordAttempt(now, 204, "")
+
+		assert.Equal(t, WebhookDeliverySuccess, d.Status)
+		assert.Equal(t, 1, d.Attempts)
+		assert.Equal(t, now, *d.DeliveredAt)
+		assert.Nil(t, d.NextAttemptAt)
+	})
+
+	t.Run("Failure schedules retry with backoff", func(t *testing.T) {
+		d := &WebhookDelivery{Status: WebhookDeliveryPending, Attempts: 2}
+		d.RecordAttempt(now, 500, "")
+
+		assert.Equal(t, WebhookDeliveryPending, d.Status)
+		assert.Equal(t, 3, d.Attempts)
+		assert.Equal(t, now.Add(4*time.Minute), *d.NextAttemptAt)
+	})
+
+	t.Run("Network error schedules retry", func(t *testing.T) {
+		d := &WebhookDelivery{Status: WebhookDeliveryPending}
+		d.RecordAttempt(now, 0, "connection refused")
+
+		assert.Equal(t, WebhookDeliveryPending, d.Status)
+		assert.Equal(t, "connection refused", d.Error)
+		assert.Equal(t, now.Add(time.Minute), *d.NextAttemptAt)
+	})
+
+	t.Run("Last attempt fails permanently", func(t *testing.T) {
+		d := &WebhookDelivery{Status: WebhookDeliveryPending, Attempts: WebhookMaxAttempts - 1}
+		d.RecordAttempt(now, 503, "")
+
+		assert.Equal(t, WebhookDeliveryFailed, d.Status)
+		assert.Nil(t, d.NextAttemptAt)
+	})
+}
+
+func TestNewWebhookEvent(t *testing.T) {
+	activity := &Activity{
+		ID:          primitive.NewObjectID(),
+		Type:        ActivityTypeEmployeeAdded,
+		UserID:      primitive.NewObjectID(),
+		UserName:    "Admin User",
+		TargetID:    primitive.NewObjectID(),
+		TargetType:  "employee",
+		TargetName:  "Max Mustermann",
+		Description: "Mitarbeiter hinzugefügt",
+		Timestamp:   time.Now(),
+	}
+
+	event := NewWebhookEvent(activity)
+	assert.Equal(t, activity.ID.Hex(), event.ID)
+	assert.Equal(t, "Admin User", event.Actor.Name)
+	if assert.NotNil(t, event.Target) {
+		assert.Equal(t, "employee", event.Target.Type)
+		assert.Equal(t, "Max Mustermann", event.Target.Name)
+	}
+
+	activity.Type = ActivityTypeSystemSettingChanged
+	activity.TargetID = primitive.NilObjectID
+	assert.Nil(t, NewWebhookEvent(activity).Target)
+}
@@ -0,0 +1,222 @@
+package backend
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"PeopleFlow/backend/handler"
+	"PeopleFlow/backend/model"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// registeredRoute ist eine im Router registrierte Route mit ihren Rollen
+type registeredRoute struct {
+	Method string
+	Path   string
+	Roles  []model.UserRole
+	Public bool
+}
+
+// collectRouterRoutes liest die Routen aus router.go, ohne InitializeRoutes
+// aufzurufen (das würde eine Datenbankverbindung benötigen).
+func collectRouterRoutes(t *testing.T) []registeredRoute {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "router.go", nil, 0)
+	require.NoError(t, err)
+
+	prefixes := map[string]string{"router": ""}
+	public := map[string]bool{"router": true}
+	guards := map[string][]model.UserRole{}
+	var routes []registeredRoute
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			if len(node.Lhs) != 1 || len(node.Rhs) != 1 {
+				return true
+			}
+			name, ok := node.Lhs[0].(*ast.Ident)
+			call, isCall := node.Rhs[0].(*ast.CallExpr)
+			if !ok || !isCall {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			switch sel.Sel.Name {
+			case "Group":
+				parent := selectorReceiver(sel)
+				prefix, known := prefixes[parent]
+				if !known || len(call.Args) == 0 {
+					return true
+				}
+				prefixes[name.Name] = joinRoutePath(prefix, stringLiteral(t, call.Args[0]))
+				// Gruppen direkt am Router werden erst durch Use(AuthMiddleware) geschützt
+				public[name.Name] = false
+			case "RequireRoles":
+				guards[name.Name] = roleArgs(call.Args)
+			}
+
+		case *ast.CallExpr:
+			sel, ok := node.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			method := sel.Sel.Name
+			switch method {
+			case "GET", "POST", "PUT", "PATCH", "DELETE":
+			default:
+				return true
+			}
+			receiver := selectorReceiver(sel)
+			prefix, known := prefixes[receiver]
+			if !known || len(node.Args) == 0 {
+				return true
+			}
+
+			route := registeredRoute{
+				Method: method,
+				Path:   joinRoutePath(prefix, stringLiteral(t, node.Args[0])),
+				Public: public[receiver],
+			}
+			for _, arg := range node.Args[1:] {
+				switch a := arg.(type) {
+				case *ast.Ident:
+					if roles, ok := guards[a.Name]; ok {
+						route.Roles = roles
+					}
+				case *ast.CallExpr:
+					if s, ok := a.Fun.(*ast.SelectorExpr); ok && (s.Sel.Name == "RoleMiddleware" || s.Sel.Name == "RequireRoles") {
+						route.Roles = roleArgs(a.Args)
+					}
+				}
+			}
+			routes = append(routes, route)
+		}
+		return true
+	})
+
+	return routes
+}
+
+func selectorReceiver(sel *ast.SelectorExpr) string {
+	if ident, ok := sel.X.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+func stringLiteral(t *testing.T, expr ast.Expr) string {
+	lit, ok := expr.(*ast.BasicLit)
+	require.True(t, ok, "Routenpfad muss ein String-Literal sein")
+	value, err := strconv.Unquote(lit.Value)
+	require.NoError(t, err)
+	return value
+}
+
+func joinRoutePath(prefix, path string) string {
+	joined := strings.TrimSuffix(prefix, "/") + "/" + strings.TrimPrefix(path, "/")
+	if joined != "/" {
+		joined = strings.TrimSuffix(joined, "/")
+	}
+	return joined
+}
+
+// roleArgs übersetzt Argumente wie model.RoleAdmin in Rollen
+func roleArgs(args []ast.Expr) []model.UserRole {
+	names := map[string]model.UserRole{
+		"RoleAdmin":    model.RoleAdmin,
+		"RoleManager":  model.RoleManager,
+		"RoleHR":       model.RoleHR,
+		"RoleEmployee": model.RoleEmployee,
+		"RoleUser":     model.RoleUser,
+	}
+	var roles []model.UserRole
+	for _, arg := range args {
+		if sel, ok := arg.(*ast.SelectorExpr); ok {
+			if role, ok := names[sel.Sel.Name]; ok {
+				roles = append(roles, role)
+			}
+		}
+	}
+	return roles
+}
+
+func sortedRoles(roles []model.UserRole) []string {
+	result := make([]string, 0, len(roles))
+	for _, role := range roles {
+		result = append(result, string(role))
+	}
+	sort.Strings(result)
+	return result
+}
+
+func apiRoutes(t *testing.T) []registeredRoute {
+	var result []registeredRoute
+	for _, route := range collectRouterRoutes(t) {
+		if handler.IsDocumentedAPIPath(route.Path) {
+			result = append(result, route)
+		}
+	}
+	require.NotEmpty(t, result, "keine /api-Routen in router.go gefunden")
+	return result
+}
+
+func TestOpenAPIContract_EveryRouteDocumented(t *testing.T) {
+	for _, route := range apiRoutes(t) {
+		key := route.Method + " " + route.Path
+		_, ok := handler.APIRouteDocs[key]
+		assert.True(t, ok, "Route %s ist nicht in handler.APIRouteDocs dokumentiert", key)
+	}
+}
+
+func TestOpenAPIContract_NoStaleDocs(t *testing.T) {
+	registered := map[string]bool{}
+	for _, route := range apiRoutes(t) {
+		registered[route.Method+" "+route.Path] = true
+	}
+	for key := range handler.APIRouteDocs {
+		assert.True(t, registered[key], "Dokumentierte Route %s ist im Router nicht registriert", key)
+	}
+}
+
+func TestOpenAPIContract_RolesMatchRouter(t *testing.T) {
+	for _, route := range apiRoutes(t) {
+		key := route.Method + " " + route.Path
+		doc, ok := handler.APIRouteDocs[key]
+		if !ok {
+			continue
+		}
+		assert.Equal(t, sortedRoles(route.Roles), sortedRoles(doc.Roles), "Rollen von %s", key)
+		assert.Equal(t, route.Public, doc.Public, "Öffentlicher Zugriff von %s", key)
+	}
+}
+
+func TestOpenAPIContract_SpecHasNoUndocumentedOperations(t *testing.T) {
+	var infos gin.RoutesInfo
+	for _, route := range apiRoutes(t) {
+		infos = append(infos, gin.RouteInfo{Method: route.Method, Path: route.Path})
+	}
+
+	spec := handler.BuildOpenAPISpec(infos)
+	paths, ok := spec["paths"].(map[string]interface{})
+	require.True(t, ok)
+
+	for path, item := range paths {
+		for method, operation := range item.(map[string]interface{}) {
+			op := operation.(map[string]interface{})
+			assert.Nil(t, op["x-undocumented"], "%s %s ist undokumentiert", strings.ToUpper(method), path)
+		}
+	}
+}
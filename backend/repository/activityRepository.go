@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"go.mongodb.org/mongo-driver/mongo"
+	"sync"
 	"time"
 
 	"PeopleFlow/backend/db"
@@ -22,6 +23,30 @@ var (
 	ErrInvalidActivityData = errors.New("invalid activity data")
 )
 
+// ActivityListener wird nach dem Speichern einer Aktivität aufgerufen und darf nicht blockieren
+type ActivityListener func(activity *model.Activity)
+
+var (
+	activityListenersMu sync.RWMutex
+	activityListeners   []ActivityListener
+)
+
+// AddActivityListener registriert einen Listener für neu gespeicherte Aktivitäten (z.B. Webhooks)
+func AddActivityListener(listener ActivityListener) {
+	activityListenersMu.Lock()
+	defer activityListenersMu.Unlock()
+	activityListeners = append(activityListeners, listener)
+}
+
+// notifyActivityListeners informiert alle registrierten Listener über eine neue Aktivität
+func notifyActivityListeners(activity *model.Activity) {
+	activityListenersMu.RLock()
+	defer activityListenersMu.RUnlock()
+	for _, listener := range activityListeners {
+		listener(activity)
+	}
+}
+
 // ActivityRepository enthält alle Datenbankoperationen für das Activity-Modell
 type ActivityRepository struct {
 	*BaseRepository
@@ -40,19 +65,7 @@ func NewActivityRepository() *ActivityRepository {
 // ValidateActivity validates activity data
 func (r *ActivityRepository) ValidateActivity(activity *model.Activity) error {
 	// Validate activity type
-	validTypes := map[model.ActivityType]bool{
-		model.ActivityTypeEmployeeAdded:        true,
-		model.ActivityTypeEmployeeUpdated:      true,
-		model.ActivityTypeEmployeeDeleted:      true,
-		model.ActivityTypeVacationRequested:    true,
-		model.ActivityTypeVacationApproved:     true,
-		model.ActivityTypeVacationRejected:     true,
-		model.ActivityTypeOvertimeAdjusted:     true,
-		model.ActivityTypeDocumentUploaded:     true,
-		model.ActivityTypeSystemSettingChanged: true,
-	}
-
-	if !validTypes[activity.Type] {
+	if !activity.Type.IsValid() {
 		return fmt.Errorf("%w: %s", ErrInvalidActivityType, activity.Type)
 	}
 
@@ -110,6 +123,7 @@ func (r *ActivityRepository) Create(activity *model.Activity) error {
 	}
 
 	activity.ID = *id
+	notifyActivityListeners(activity)
 	return nil
 }
 
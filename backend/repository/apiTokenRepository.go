@@ -0,0 +1,167 @@
+// backend/repository/apiTokenRepository.go
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"PeopleFlow/backend/db"
+	"PeopleFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// APITokenRepository errors
+var (
+	ErrAPITokenNotFound = errors.New("API token not found")
+)
+
+// lastUsedUpdateInterval begrenzt Schreibzugriffe bei häufig genutzten Tokens
+const lastUsedUpdateInterval = time.Minute
+
+// APITokenRepository enthält alle Datenbankoperationen für API-Tokens
+type APITokenRepository struct {
+	*BaseRepository
+	collection *mongo.Collection
+}
+
+// NewAPITokenRepository erstellt ein neues APITokenRepository
+func NewAPITokenRepository() *APITokenRepository {
+	collection := db.GetCollection("api_tokens")
+	return &APITokenRepository{
+		BaseRepository: NewBaseRepository(collection),
+		collection:     collection,
+	}
+}
+
+// Create speichert ein neues Token; das Klartext-Token wird nur als Hash abgelegt
+func (r *APITokenRepository) Create(token *model.APIToken, plainToken string) error {
+	if plainToken == "" {
+		return fmt.Errorf("%w: token is required", ErrValidation)
+	}
+	if err := token.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrValidation, err)
+	}
+
+	token.TokenHash = model.HashAPIToken(plainToken)
+	token.CreatedAt = time.Now()
+
+	id, err := r.InsertOne(token)
+	if err != nil {
+		return err
+	}
+
+	token.ID = *id
+	return nil
+}
+
+// FindByID findet ein Token anhand seiner ID
+func (r *APITokenRepository) FindByID(id string) (*model.APIToken, error) {
+	var token model.APIToken
+	if err := r.BaseRepository.FindByID(id, &token); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, ErrAPITokenNotFound
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// FindByToken findet ein Token anhand des Klartext-Tokens
+func (r *APITokenRepository) FindByToken(plainToken string) (*model.APIToken, error) {
+	var token model.APIToken
+	if err := r.FindOne(bson.M{"tokenHash": model.HashAPIToken(plainToken)}, &token); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, ErrAPITokenNotFound
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// FindByUserID findet alle persönlichen Tokens eines Benutzers
+func (r *APITokenRepository) FindByUserID(userID primitive.ObjectID) ([]*model.APIToken, error) {
+	var tokens []*model.APIToken
+	opts := options.Find().SetSort(bson.M{"createdAt": -1})
+	if err := r.BaseRepository.FindAll(bson.M{"userId": userID}, &tokens, opts); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// FindAll findet alle Tokens (persönliche und Service-Account-Tokens)
+func (r *APITokenRepository) FindAll() ([]*model.APIToken, error) {
+	var tokens []*model.APIToken
+	opts := options.Find().SetSort(bson.M{"createdAt": -1})
+	if err := r.BaseRepository.FindAll(bson.M{}, &tokens, opts); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// Revoke widerruft ein Token
+func (r *APITokenRepository) Revoke(id primitive.ObjectID, revokedBy string) error {
+	result, err := r.UpdateOne(bson.M{
+		"_id":       id,
+		"revokedAt": bson.M{"$exists": false},
+	}, bson.M{"$set": bson.M{"revokedAt": time.Now(), "revokedBy": revokedBy}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrAPITokenNotFound
+	}
+	return nil
+}
+
+// RevokeAllByUserID widerruft alle Tokens eines Benutzers
+func (r *APITokenRepository) RevokeAllByUserID(userID primitive.ObjectID, revokedBy string) error {
+	_, err := r.UpdateMany(bson.M{
+		"userId":    userID,
+		"revokedAt": bson.M{"$exists": false},
+	}, bson.M{"$set": bson.M{"revokedAt": time.Now(), "revokedBy": revokedBy}})
+	return err
+}
+
+// TouchLastUsed aktualisiert Zeitpunkt und IP der letzten Nutzung (höchstens einmal pro Minute)
+func (r *APITokenRepository) TouchLastUsed(token *model.APIToken, ip string) error {
+	now := time.Now()
+	if token.LastUsedAt != nil && now.Sub(*token.LastUsedAt) < lastUsedUpdateInterval && token.LastUsedIP == ip {
+		return nil
+	}
+
+	_, err := r.UpdateOne(bson.M{"_id": token.ID}, bson.M{"$set": bson.M{
+		"lastUsedAt": now,
+		"lastUsedIp": ip,
+	}})
+	if err != nil {
+		return err
+	}
+
+	token.LastUsedAt = &now
+	token.LastUsedIP = ip
+	return nil
+}
+
+// CreateIndexes erstellt erforderliche Indizes
+func (r *APITokenRepository) CreateIndexes() error {
+	if err := r.CreateIndex(bson.M{"tokenHash": 1}, true); err != nil {
+		return fmt.Errorf("failed to create token index: %w", err)
+	}
+
+	// bson.D, damit die Reihenfolge der Felder bei jedem Start gleich ist
+	ctx, cancel := r.GetContext()
+	defer cancel()
+
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "userId", Value: 1}, {Key: "createdAt", Value: -1}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create user index: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+// backend/repository/breachedPasswordRepository.go
+package repository
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"PeopleFlow/backend/db"
+	"PeopleFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Mitgelieferte Liste kompromittierter Passwörter (SHA-1)
+//
+//go:embed data/breached_password_hashes.txt
+var bundledBreachedHashes string
+
+var (
+	bundledBreachedOnce sync.Once
+	bundledBreachedSet  map[string]struct{}
+)
+
+// breachedImportBatchSize begrenzt die Größe eines BulkWrite beim Import
+const breachedImportBatchSize = 1000
+
+// BreachedPasswordRepository prüft Passwörter offline gegen Listen kompromittierter Passwort-Hashes.
+// Neben der mitgelieferten Liste können Administratoren weitere Hashes hochladen.
+type BreachedPasswordRepository struct {
+	*BaseRepository
+	collection *mongo.Collection
+}
+
+// NewBreachedPasswordRepository erstellt ein neues BreachedPasswordRepository
+func NewBreachedPasswordRepository() *BreachedPasswordRepository {
+	collection := db.GetCollection("breached_password_hashes")
+	return &BreachedPasswordRepository{
+		BaseRepository: NewBaseRepository(collection),
+		collection:     collection,
+	}
+}
+
+// loadBundledBreachedHashes lädt die mitgelieferte Liste einmalig in den Speicher
+func loadBundledBreachedHashes() map[string]struct{} {
+	bundledBreachedOnce.Do(func() {
+		bundledBreachedSet = make(map[string]struct{})
+		for _, line := range strings.Split(bundledBreachedHashes, "\n") {
+			if hash, ok := model.NormalizeBreachedHashLine(line); ok {
+				bundledBreachedSet[hash] = struct{}{}
+			}
+		}
+	})
+	return bundledBreachedSet
+}
+
+// IsBreached prüft, ob ein Passwort in der mitgelieferten oder hochgeladenen Liste enthalten ist
+func (r *BreachedPasswordRepository) IsBreached(password string) (bool, error) {
+	hash := model.BreachedPasswordHash(password)
+
+	if _, ok := loadBundledBreachedHashes()[hash]; ok {
+		return true, nil
+	}
+
+	return r.Exists(bson.M{"_id": hash})
+}
+
+// Import liest eine Liste im Format "HASH" bzw. "HASH:COUNT" und speichert neue Hashes.
+// Gibt die Anzahl der verarbeiteten gültigen Zeilen zurück.
+func (r *BreachedPasswordRepository) Import(reader io.Reader) (int, error) {
+	scanner := bufio.NewScanner(reader)
+	now := time.Now()
+
+	var operations []mongo.WriteModel
+	imported := 0
+
+	flush := func() error {
+		if len(operations) == 0 {
+			return nil
+		}
+		_, err := r.BulkWrite(operations)
+		operations = operations[:0]
+		return err
+	}
+
+	for scanner.Scan() {
+		hash, ok := model.NormalizeBreachedHashLine(scanner.Text())
+		if !ok {
+			continue
+		}
+
+		operations = append(operations, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": hash}).
+			SetUpdate(bson.M{"$setOnInsert": bson.M{"importedAt": now}}).
+			SetUpsert(true))
+		imported++
+
+		if len(operations) >= breachedImportBatchSize {
+			if err := flush(); err != nil {
+				return imported, fmt.Errorf("failed to import breached hashes: %w", err)
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return imported, fmt.Errorf("failed to read breached hash list: %w", err)
+	}
+
+	if err := flush(); err != nil {
+		return imported, fmt.Errorf("failed to import breached hashes: %w", err)
+	}
+
+	return imported, nil
+}
+
+// CountUploaded zählt die hochgeladenen Hashes
+func (r *BreachedPasswordRepository) CountUploaded() (int64, error) {
+	return r.Count(bson.M{})
+}
+
+// CountBundled gibt die Anzahl der mitgelieferten Hashes zurück
+func (r *BreachedPasswordRepository) CountBundled() int {
+	return len(loadBundledBreachedHashes())
+}
+
+// ClearUploaded entfernt alle hochgeladenen Hashes
+func (r *BreachedPasswordRepository) ClearUploaded() error {
+	_, err := r.DeleteMany(bson.M{})
+	return err
+}
@@ -0,0 +1,118 @@
+// backend/repository/chatChannelRepository.go
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"PeopleFlow/backend/db"
+	"PeopleFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ChatChannelRepository errors
+var (
+	ErrChatChannelNotFound = errors.New("chat channel not found")
+)
+
+// ChatChannelRepository enthält alle Datenbankoperationen für Slack- und Teams-Kanäle
+type ChatChannelRepository struct {
+	*BaseRepository
+	collection *mongo.Collection
+}
+
+// NewChatChannelRepository erstellt ein neues ChatChannelRepository
+func NewChatChannelRepository() *ChatChannelRepository {
+	collection := db.GetCollection("chat_channels")
+	return &ChatChannelRepository{
+		BaseRepository: NewBaseRepository(collection),
+		collection:     collection,
+	}
+}
+
+// Create speichert einen neuen Kanal; die Webhook-URL muss bereits verschlüsselt sein
+func (r *ChatChannelRepository) Create(channel *model.ChatChannel) error {
+	now := time.Now()
+	channel.CreatedAt = now
+	channel.UpdatedAt = now
+
+	id, err := r.InsertOne(channel)
+	if err != nil {
+		return err
+	}
+
+	channel.ID = *id
+	return nil
+}
+
+// FindByID findet einen Kanal anhand seiner ID
+func (r *ChatChannelRepository) FindByID(id string) (*model.ChatChannel, error) {
+	var channel model.ChatChannel
+	if err := r.BaseRepository.FindByID(id, &channel); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, ErrChatChannelNotFound
+		}
+		return nil, err
+	}
+	return &channel, nil
+}
+
+// FindAll findet alle Kanäle
+func (r *ChatChannelRepository) FindAll() ([]*model.ChatChannel, error) {
+	var channels []*model.ChatChannel
+	opts := options.Find().SetSort(bson.M{"name": 1})
+	if err := r.BaseRepository.FindAll(bson.M{}, &channels, opts); err != nil {
+		return nil, err
+	}
+	return channels, nil
+}
+
+// FindSubscribers findet alle aktiven Kanäle, die ein Ereignis abonniert haben
+func (r *ChatChannelRepository) FindSubscribers(event model.ChatEvent) ([]*model.ChatChannel, error) {
+	var channels []*model.ChatChannel
+	if err := r.BaseRepository.FindAll(bson.M{"active": true, "events": event}, &channels); err != nil {
+		return nil, err
+	}
+	return channels, nil
+}
+
+// Update aktualisiert die Konfiguration eines Kanals
+func (r *ChatChannelRepository) Update(channel *model.ChatChannel) error {
+	channel.UpdatedAt = time.Now()
+	err := r.UpdateByID(channel.ID.Hex(), bson.M{"$set": bson.M{
+		"name":        channel.Name,
+		"provider":    channel.Provider,
+		"webhookUrl":  channel.WebhookURL,
+		"events":      channel.Events,
+		"departments": channel.Departments,
+		"active":      channel.Active,
+		"updatedAt":   channel.UpdatedAt,
+	}})
+	if errors.Is(err, ErrNotFound) {
+		return ErrChatChannelNotFound
+	}
+	return err
+}
+
+// RecordResult speichert das Ergebnis der letzten Zustellung
+func (r *ChatChannelRepository) RecordResult(id primitive.ObjectID, sentAt time.Time, errMessage string) error {
+	set := bson.M{"lastError": errMessage}
+	if errMessage == "" {
+		set["lastSentAt"] = sentAt
+	}
+	_, err := r.UpdateOne(bson.M{"_id": id}, bson.M{"$set": set})
+	return err
+}
+
+// Delete löscht einen Kanal
+func (r *ChatChannelRepository) Delete(id string) error {
+	err := r.DeleteByID(id)
+	if errors.Is(err, ErrNotFound) {
+		return ErrChatChannelNotFound
+	}
+	return err
+}
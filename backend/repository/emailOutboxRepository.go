@@ -0,0 +1,203 @@
+// backend/repository/emailOutboxRepository.go
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"PeopleFlow/backend/db"
+	"PeopleFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// EmailOutboxRepository errors
+var (
+	ErrOutboxEmailNotFound = errors.New("outbox email not found")
+)
+
+// EmailOutboxRepository enthält alle Datenbankoperationen für den E-Mail-Postausgang
+type EmailOutboxRepository struct {
+	*BaseRepository
+	collection *mongo.Collection
+}
+
+// NewEmailOutboxRepository erstellt ein neues EmailOutboxRepository
+func NewEmailOutboxRepository() *EmailOutboxRepository {
+	collection := db.GetCollection("email_outbox")
+	return &EmailOutboxRepository{
+		BaseRepository: NewBaseRepository(collection),
+		collection:     collection,
+	}
+}
+
+// Create legt eine E-Mail im Postausgang an
+func (r *EmailOutboxRepository) Create(email *model.OutboxEmail) error {
+	email.CreatedAt = time.Now()
+	if email.Status == "" {
+		email.Status = model.OutboxEmailPending
+	}
+
+	id, err := r.InsertOne(email)
+	if err != nil {
+		return err
+	}
+
+	email.ID = *id
+	return nil
+}
+
+// FindByID findet eine E-Mail anhand ihrer ID
+func (r *EmailOutboxRepository) FindByID(id string) (*model.OutboxEmail, error) {
+	var email model.OutboxEmail
+	if err := r.BaseRepository.FindByID(id, &email); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, ErrOutboxEmailNotFound
+		}
+		return nil, err
+	}
+	return &email, nil
+}
+
+// FindByStatus findet E-Mails (optional gefiltert nach Status), neueste zuerst.
+// Der Inhalt wird für die Liste nicht geladen.
+func (r *EmailOutboxRepository) FindByStatus(status model.OutboxEmailStatus, skip, limit int64) ([]*model.OutboxEmail, int64, error) {
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = status
+	}
+
+	total, err := r.Count(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var emails []*model.OutboxEmail
+	opts := options.Find().
+		SetSort(bson.M{"createdAt": -1}).
+		SetSkip(skip).
+		SetLimit(limit).
+		SetProjection(bson.M{"body": 0})
+	if err := r.BaseRepository.FindAll(filter, &emails, opts); err != nil {
+		return nil, 0, err
+	}
+	return emails, total, nil
+}
+
+// CountByStatus zählt die E-Mails je Status
+func (r *EmailOutboxRepository) CountByStatus() (map[model.OutboxEmailStatus]int64, error) {
+	var results []struct {
+		Status model.OutboxEmailStatus `bson:"_id"`
+		Count  int64                   `bson:"count"`
+	}
+	pipeline := []bson.M{
+		{"$group": bson.M{"_id": "$status", "count": bson.M{"$sum": 1}}},
+	}
+	if err := r.Aggregate(pipeline, &results); err != nil {
+		return nil, err
+	}
+
+	counts := map[model.OutboxEmailStatus]int64{
+		model.OutboxEmailPending: 0,
+		model.OutboxEmailSent:    0,
+		model.OutboxEmailDead:    0,
+	}
+	for _, result := range results {
+		counts[result.Status] = result.Count
+	}
+	return counts, nil
+}
+
+// ClaimDue reserviert eine fällige E-Mail für den Versand.
+// Die Reservierung verschiebt nextAttemptAt, damit parallele Worker sie nicht doppelt senden.
+func (r *EmailOutboxRepository) ClaimDue(now time.Time, lease time.Duration) (*model.OutboxEmail, error) {
+	ctx, cancel := r.GetContext()
+	defer cancel()
+
+	filter := bson.M{
+		"status":        model.OutboxEmailPending,
+		"nextAttemptAt": bson.M{"$lte": now},
+	}
+	update := bson.M{"$set": bson.M{"nextAttemptAt": now.Add(lease)}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.M{"nextAttemptAt": 1}).
+		SetReturnDocument(options.After)
+
+	var email model.OutboxEmail
+	if err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&email); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, r.HandleError(ctx, err, "ClaimDue")
+	}
+	return &email, nil
+}
+
+// SaveAttempt speichert das Ergebnis eines Versandversuchs
+func (r *EmailOutboxRepository) SaveAttempt(email *model.OutboxEmail) error {
+	set := bson.M{
+		"status":        email.Status,
+		"attempts":      email.Attempts,
+		"error":         email.Error,
+		"lastAttemptAt": email.LastAttemptAt,
+	}
+	update := bson.M{"$set": set}
+	if email.NextAttemptAt != nil {
+		set["nextAttemptAt"] = email.NextAttemptAt
+	} else {
+		update["$unset"] = bson.M{"nextAttemptAt": ""}
+	}
+	if email.SentAt != nil {
+		set["sentAt"] = email.SentAt
+	}
+	if email.Redacted {
+		set["body"] = email.Body
+		set["redacted"] = true
+	}
+
+	err := r.UpdateByID(email.ID.Hex(), update)
+	if errors.Is(err, ErrNotFound) {
+		return ErrOutboxEmailNotFound
+	}
+	return err
+}
+
+// Requeue stellt eine fehlgeschlagene E-Mail wieder in den Postausgang
+func (r *EmailOutboxRepository) Requeue(email *model.OutboxEmail) error {
+	err := r.UpdateByID(email.ID.Hex(), bson.M{"$set": bson.M{
+		"status":        email.Status,
+		"attempts":      email.Attempts,
+		"error":         email.Error,
+		"nextAttemptAt": email.NextAttemptAt,
+	}})
+	if errors.Is(err, ErrNotFound) {
+		return ErrOutboxEmailNotFound
+	}
+	return err
+}
+
+// DeleteSentBefore löscht gesendete E-Mails, die vor dem Stichtag zugestellt wurden
+func (r *EmailOutboxRepository) DeleteSentBefore(before time.Time) (int64, error) {
+	result, err := r.DeleteMany(bson.M{
+		"status": model.OutboxEmailSent,
+		"sentAt": bson.M{"$lt": before},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
+// CreateIndexes erstellt erforderliche Indizes
+func (r *EmailOutboxRepository) CreateIndexes() error {
+	if err := r.CreateIndex(bson.M{"status": 1, "nextAttemptAt": 1}, false); err != nil {
+		return fmt.Errorf("failed to create due index: %w", err)
+	}
+	if err := r.CreateIndex(bson.M{"createdAt": -1}, false); err != nil {
+		return fmt.Errorf("failed to create createdAt index: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+// backend/repository/emailTemplateRepository.go
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"PeopleFlow/backend/db"
+	"PeopleFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// EmailTemplateRepository errors
+var (
+	ErrEmailTemplateNotFound = errors.New("email template not found")
+)
+
+// EmailTemplateRepository enthält die von Admins angepassten E-Mail-Vorlagen
+type EmailTemplateRepository struct {
+	*BaseRepository
+	collection *mongo.Collection
+}
+
+// NewEmailTemplateRepository erstellt ein neues EmailTemplateRepository
+func NewEmailTemplateRepository() *EmailTemplateRepository {
+	collection := db.GetCollection("email_templates")
+	return &EmailTemplateRepository{
+		BaseRepository: NewBaseRepository(collection),
+		collection:     collection,
+	}
+}
+
+// Find findet die angepasste Vorlage einer Art in einer Sprache
+func (r *EmailTemplateRepository) Find(key model.EmailTemplateKey, language string) (*model.EmailTemplate, error) {
+	var template model.EmailTemplate
+	if err := r.FindOne(bson.M{"key": key, "language": language}, &template); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, ErrEmailTemplateNotFound
+		}
+		return nil, err
+	}
+	return &template, nil
+}
+
+// FindAll gibt alle angepassten Vorlagen zurück
+func (r *EmailTemplateRepository) FindAll() ([]*model.EmailTemplate, error) {
+	var templates []*model.EmailTemplate
+	if err := r.BaseRepository.FindAll(bson.M{}, &templates); err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+// Save legt eine angepasste Vorlage an oder überschreibt sie
+func (r *EmailTemplateRepository) Save(template *model.EmailTemplate) error {
+	if err := template.Validate(); err != nil {
+		return err
+	}
+
+	ctx, cancel := r.GetContext()
+	defer cancel()
+
+	template.UpdatedAt = time.Now()
+	filter := bson.M{"key": template.Key, "language": template.Language}
+	update := bson.M{"$set": bson.M{
+		"subject":   template.Subject,
+		"body":      template.Body,
+		"updatedBy": template.UpdatedBy,
+		"updatedAt": template.UpdatedAt,
+	}}
+
+	_, err := r.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return r.HandleError(ctx, err, "Save")
+	}
+	return nil
+}
+
+// Delete entfernt eine angepasste Vorlage, danach gilt wieder die Standardvorlage
+func (r *EmailTemplateRepository) Delete(key model.EmailTemplateKey, language string) error {
+	result, err := r.DeleteOne(bson.M{"key": key, "language": language})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrEmailTemplateNotFound
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 	"time"
 
@@ -87,6 +88,17 @@ func (r *EmployeeRepository) ValidateEmployee(employee *model.Employee, isUpdate
 
 // Create erstellt einen neuen Mitarbeiter mit Validierung und Transaktion
 func (r *EmployeeRepository) Create(employee *model.Employee) error {
+	return r.create(employee, true)
+}
+
+// CreateWithoutAccount erstellt einen neuen Mitarbeiter ohne zugehöriges Benutzerkonto.
+// Für Massenanlagen (Import, Synchronisation), bei denen niemand ein Startpasswort übergibt;
+// Zugänge werden anschließend über Einladungen vergeben.
+func (r *EmployeeRepository) CreateWithoutAccount(employee *model.Employee) error {
+	return r.create(employee, false)
+}
+
+func (r *EmployeeRepository) create(employee *model.Employee, withAccount bool) error {
 	// Validate employee data
 	if err := r.ValidateEmployee(employee, false); err != nil {
 		return err
@@ -165,7 +177,7 @@ func (r *EmployeeRepository) Create(employee *model.Employee) error {
 		employee.ID = result.InsertedID.(primitive.ObjectID)
 
 		// Create corresponding user if email is provided
-		if employee.Email != "" {
+		if withAccount && employee.Email != "" {
 			user := &model.User{
 				Email:      employee.Email,
 				FirstName:  employee.FirstName,
@@ -283,6 +295,75 @@ func (r *EmployeeRepository) FindAll(skip, limit int64, sortBy string, sortOrder
 	return employees, total, nil
 }
 
+// EmployeeQuery beschreibt Filter, Sortierung und Pagination für Mitarbeiterabfragen
+type EmployeeQuery struct {
+	Search     string              // Volltextsuche über Name, E-Mail, Personalnummer und Position
+	Department string              // Abteilung (leer = alle)
+	Status     string              // Mitarbeiterstatus (leer = alle)
+	ManagerID  *primitive.ObjectID // Nur direkte Mitarbeiter dieses Vorgesetzten
+	IDs        []primitive.ObjectID
+	Skip       int64
+	Limit      int64 // 0 = unbegrenzt
+	SortBy     string
+	SortOrder  int
+}
+
+// Search findet Mitarbeiter anhand einer EmployeeQuery; Profilbilder werden nicht geladen
+func (r *EmployeeRepository) Search(query EmployeeQuery) ([]*model.Employee, int64, error) {
+	filter := bson.M{}
+	if query.Search != "" {
+		pattern := primitive.Regex{Pattern: regexp.QuoteMeta(strings.TrimSpace(query.Search)), Options: "i"}
+		filter["$or"] = []bson.M{
+			{"firstName": pattern},
+			{"lastName": pattern},
+			{"email": pattern},
+			{"employeeId": pattern},
+			{"position": pattern},
+		}
+	}
+	if query.Department != "" {
+		filter["department"] = query.Department
+	}
+	if query.Status != "" {
+		filter["status"] = query.Status
+	}
+	if query.ManagerID != nil {
+		filter["managerId"] = *query.ManagerID
+	}
+	if query.IDs != nil {
+		filter["_id"] = bson.M{"$in": query.IDs}
+	}
+
+	sortBy := query.SortBy
+	if sortBy == "" {
+		sortBy = "lastName"
+	}
+	sortOrder := query.SortOrder
+	if sortOrder == 0 {
+		sortOrder = 1
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: sortBy, Value: sortOrder}, {Key: "_id", Value: 1}}).
+		SetProjection(bson.M{"profileImageData": 0}).
+		SetSkip(query.Skip)
+	if query.Limit > 0 {
+		findOptions.SetLimit(query.Limit)
+	}
+
+	var employees []*model.Employee
+	if err := r.BaseRepository.FindAll(filter, &employees, findOptions); err != nil {
+		return nil, 0, err
+	}
+
+	total, err := r.Count(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return employees, total, nil
+}
+
 // Update aktualisiert einen Mitarbeiter mit Validierung
 func (r *EmployeeRepository) Update(employee *model.Employee) error {
 	// Validate employee data
@@ -360,6 +441,65 @@ func (r *EmployeeRepository) Update(employee *model.Employee) error {
 		setFields["projectAssignments"] = employee.ProjectAssignments
 	}
 
+	// Eingebettete Personalakten-Daten
+	if employee.Documents != nil {
+		setFields["documents"] = employee.Documents
+	}
+	if employee.ApplicationDocuments != nil {
+		setFields["applicationDocuments"] = employee.ApplicationDocuments
+	}
+	if employee.Trainings != nil {
+		setFields["trainings"] = employee.Trainings
+	}
+	if employee.Evaluations != nil {
+		setFields["evaluations"] = employee.Evaluations
+	}
+	if employee.DevelopmentPlan != nil {
+		setFields["developmentPlan"] = employee.DevelopmentPlan
+	}
+	if employee.Conversations != nil {
+		setFields["conversations"] = employee.Conversations
+	}
+	if employee.SalaryHistory != nil {
+		setFields["salaryHistory"] = employee.SalaryHistory
+	}
+	if employee.Bonuses != nil {
+		setFields["bonuses"] = employee.Bonuses
+	}
+
+	// Stammdaten, die auch geleert werden dürfen
+	setFields["phone"] = employee.Phone
+	setFields["internalPhone"] = employee.InternalPhone
+	setFields["location"] = employee.Location
+	setFields["internalExtension"] = employee.InternalExtension
+	setFields["address"] = employee.Address
+	setFields["dateOfBirth"] = employee.DateOfBirth
+	setFields["hireDate"] = employee.HireDate
+	setFields["managerId"] = employee.ManagerID
+	setFields["workingDaysPerWeek"] = employee.WorkingDaysPerWeek
+	setFields["workTimeModel"] = employee.WorkTimeModel
+	setFields["flexibleWorkingHours"] = employee.FlexibleWorkingHours
+	setFields["coreWorkingTimeStart"] = employee.CoreWorkingTimeStart
+	setFields["coreWorkingTimeEnd"] = employee.CoreWorkingTimeEnd
+	setFields["salary"] = employee.Salary
+	setFields["bankAccount"] = employee.BankAccount
+	setFields["taxId"] = employee.TaxID
+	setFields["socialSecId"] = employee.SocialSecID
+	setFields["healthInsurance"] = employee.HealthInsurance
+	setFields["emergencyName"] = employee.EmergencyName
+	setFields["emergencyPhone"] = employee.EmergencyPhone
+	setFields["notes"] = employee.Notes
+	if employee.Status != "" {
+		setFields["status"] = employee.Status
+	}
+	if employee.EmployeeID != "" {
+		setFields["employeeId"] = employee.EmployeeID
+	}
+	if employee.ProfileImage != "" {
+		setFields["profileImage"] = employee.ProfileImage
+		setFields["profileImageData"] = employee.ProfileImageData
+	}
+
 	// Update integration IDs
 	if employee.TimebutlerUserID != "" {
 		setFields["timebutlerUserId"] = employee.TimebutlerUserID
@@ -367,6 +507,17 @@ func (r *EmployeeRepository) Update(employee *model.Employee) error {
 	if employee.Erfasst123ID != "" {
 		setFields["erfasst123Id"] = employee.Erfasst123ID
 	}
+	if employee.PersonioID != "" {
+		setFields["personioId"] = employee.PersonioID
+	}
+
+	// Herkunft synchronisierter Felder
+	if employee.FieldSources != nil {
+		setFields["fieldSources"] = employee.FieldSources
+	}
+	if employee.SyncedValues != nil {
+		setFields["syncedValues"] = employee.SyncedValues
+	}
 
 	// Update overtime balance
 	setFields["overtimeBalance"] = employee.OvertimeBalance
@@ -730,3 +881,56 @@ func (r *EmployeeRepository) UpdateErfasst123ID(employeeID string, erfasst123ID
 
 	return nil
 }
+
+// UpdatePersonioID aktualisiert die Personio ID eines Mitarbeiters
+func (r *EmployeeRepository) UpdatePersonioID(employeeID string, personioID string) error {
+	objID, err := r.ValidateObjectID(employeeID)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"personioId": personioID,
+			"updatedAt":  time.Now(),
+		},
+	}
+
+	result, err := r.UpdateOne(bson.M{"_id": objID}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return ErrEmployeeNotFound
+	}
+
+	return nil
+}
+
+// UpdateSalary setzt nur das aktuelle Gehalt eines Mitarbeiters, z.B. wenn eine Gehaltsänderung
+// aus der Gehaltshistorie wirksam wird. Andere Felder bleiben unverändert.
+func (r *EmployeeRepository) UpdateSalary(employeeID string, salary float64) error {
+	objID, err := r.ValidateObjectID(employeeID)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"salary":    salary,
+			"updatedAt": time.Now(),
+		},
+	}
+
+	result, err := r.UpdateOne(bson.M{"_id": objID}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return ErrEmployeeNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+// backend/repository/fieldConflictRepository.go
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"PeopleFlow/backend/db"
+	"PeopleFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FieldConflictRepository errors
+var (
+	ErrFieldConflictNotFound = errors.New("field conflict not found")
+	ErrFieldConflictResolved = errors.New("field conflict has already been resolved")
+)
+
+// FieldConflictRepository enthält alle Datenbankoperationen für Konflikte synchronisierter Felder
+type FieldConflictRepository struct {
+	*BaseRepository
+	collection *mongo.Collection
+}
+
+// NewFieldConflictRepository erstellt ein neues FieldConflictRepository
+func NewFieldConflictRepository() *FieldConflictRepository {
+	collection := db.GetCollection("field_conflicts")
+	return &FieldConflictRepository{
+		BaseRepository: NewBaseRepository(collection),
+		collection:     collection,
+	}
+}
+
+// SaveOpen speichert einen Konflikt. Ein noch offener Konflikt desselben Feldes wird durch
+// den neuen ersetzt, damit je Mitarbeiter und Feld höchstens ein Konflikt offen ist.
+func (r *FieldConflictRepository) SaveOpen(conflict *model.FieldConflict) error {
+	ctx, cancel := r.GetContext()
+	defer cancel()
+
+	filter := bson.M{
+		"integration": conflict.Integration,
+		"employeeId":  conflict.EmployeeID,
+		"field":       conflict.Field,
+		"resolvedAt":  bson.M{"$exists": false},
+	}
+	update := bson.M{"$set": bson.M{
+		"employeeName":  conflict.EmployeeName,
+		"label":         conflict.Label,
+		"baseValue":     conflict.BaseValue,
+		"localValue":    conflict.LocalValue,
+		"externalValue": conflict.ExternalValue,
+		"ownership":     conflict.Ownership,
+		"applied":       conflict.Applied,
+		"detectedAt":    conflict.DetectedAt,
+	}}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	if err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(conflict); err != nil {
+		return r.HandleError(ctx, err, "SaveFieldConflict")
+	}
+	return nil
+}
+
+// FindByID findet einen Konflikt anhand seiner ID
+func (r *FieldConflictRepository) FindByID(id string) (*model.FieldConflict, error) {
+	var conflict model.FieldConflict
+	if err := r.BaseRepository.FindByID(id, &conflict); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, ErrFieldConflictNotFound
+		}
+		return nil, err
+	}
+	return &conflict, nil
+}
+
+// FindByIntegration gibt die Konflikte einer Integration zurück, neueste zuerst;
+// ohne includeResolved nur die offenen
+func (r *FieldConflictRepository) FindByIntegration(integration string, includeResolved bool) ([]*model.FieldConflict, error) {
+	filter := bson.M{"integration": integration}
+	if !includeResolved {
+		filter["resolvedAt"] = bson.M{"$exists": false}
+	}
+
+	var conflicts []*model.FieldConflict
+	opts := options.Find().SetSort(bson.M{"detectedAt": -1}).SetLimit(500)
+	if err := r.FindAll(filter, &conflicts, opts); err != nil {
+		return nil, err
+	}
+	return conflicts, nil
+}
+
+// MarkResolved schließt einen offenen Konflikt ab
+func (r *FieldConflictRepository) MarkResolved(id primitive.ObjectID, resolution model.FieldOwnership, user *model.User) error {
+	result, err := r.UpdateOne(
+		bson.M{"_id": id, "resolvedAt": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{
+			"resolvedAt":     time.Now(),
+			"resolution":     resolution,
+			"resolvedBy":     user.ID,
+			"resolvedByName": user.FirstName + " " + user.LastName,
+		}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrFieldConflictResolved
+	}
+	return nil
+}
+
+// CreateIndexes erstellt erforderliche Indizes
+func (r *FieldConflictRepository) CreateIndexes() error {
+	if err := r.CreateIndex(bson.M{"integration": 1, "employeeId": 1, "field": 1}, false); err != nil {
+		return fmt.Errorf("failed to create field index: %w", err)
+	}
+	if err := r.CreateIndex(bson.M{"detectedAt": -1}, false); err != nil {
+		return fmt.Errorf("failed to create detectedAt index: %w", err)
+	}
+	return nil
+}
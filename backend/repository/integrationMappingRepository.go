@@ -0,0 +1,115 @@
+// backend/repository/integrationMappingRepository.go
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"PeopleFlow/backend/db"
+	"PeopleFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// IntegrationMappingRepository errors
+var (
+	ErrIntegrationMappingNotFound = errors.New("integration mapping not found")
+)
+
+// IntegrationMappingRepository enthält alle Datenbankoperationen für manuelle Zuordnungen
+// externer Benutzer zu Mitarbeitern
+type IntegrationMappingRepository struct {
+	*BaseRepository
+	collection *mongo.Collection
+}
+
+// NewIntegrationMappingRepository erstellt ein neues IntegrationMappingRepository
+func NewIntegrationMappingRepository() *IntegrationMappingRepository {
+	collection := db.GetCollection("integration_mappings")
+	return &IntegrationMappingRepository{
+		BaseRepository: NewBaseRepository(collection),
+		collection:     collection,
+	}
+}
+
+// Upsert speichert die Zuordnung eines externen Benutzers. Bestehende Zuordnungen desselben
+// Mitarbeiters zu anderen externen Benutzern dieser Integration werden entfernt.
+func (r *IntegrationMappingRepository) Upsert(mapping *model.IntegrationMapping) error {
+	ctx, cancel := r.GetContext()
+	defer cancel()
+
+	now := time.Now()
+	mapping.UpdatedAt = now
+
+	filter := bson.M{"integration": mapping.Integration, "externalId": mapping.ExternalID}
+	update := bson.M{
+		"$set": bson.M{
+			"externalName":  mapping.ExternalName,
+			"employeeId":    mapping.EmployeeID,
+			"employeeName":  mapping.EmployeeName,
+			"createdBy":     mapping.CreatedBy,
+			"createdByName": mapping.CreatedByName,
+			"updatedAt":     now,
+		},
+		"$setOnInsert": bson.M{"createdAt": now},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	if err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(mapping); err != nil {
+		return r.HandleError(ctx, err, "UpsertIntegrationMapping")
+	}
+
+	_, err := r.DeleteMany(bson.M{
+		"integration": mapping.Integration,
+		"employeeId":  mapping.EmployeeID,
+		"externalId":  bson.M{"$ne": mapping.ExternalID},
+	})
+	return err
+}
+
+// FindByIntegration gibt alle Zuordnungen einer Integration zurück
+func (r *IntegrationMappingRepository) FindByIntegration(integration string) ([]*model.IntegrationMapping, error) {
+	var mappings []*model.IntegrationMapping
+	opts := options.Find().SetSort(bson.M{"externalName": 1})
+	if err := r.FindAll(bson.M{"integration": integration}, &mappings, opts); err != nil {
+		return nil, err
+	}
+	return mappings, nil
+}
+
+// Delete entfernt die Zuordnung eines externen Benutzers
+func (r *IntegrationMappingRepository) Delete(integration, externalID string) error {
+	result, err := r.DeleteOne(bson.M{"integration": integration, "externalId": externalID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrIntegrationMappingNotFound
+	}
+	return nil
+}
+
+// CreateIndexes erstellt erforderliche Indizes. Der eindeutige Index auf (integration, externalId)
+// verhindert doppelte Zuordnungen, wenn zwei Bestätigungen gleichzeitig Upsert aufrufen.
+func (r *IntegrationMappingRepository) CreateIndexes() error {
+	ctx, cancel := r.GetContext()
+	defer cancel()
+
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "integration", Value: 1}, {Key: "externalId", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create externalId index: %w", err)
+	}
+
+	_, err = r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "integration", Value: 1}, {Key: "employeeId", Value: 1}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create employeeId index: %w", err)
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"PeopleFlow/backend/db"
@@ -27,11 +28,11 @@ var (
 	ErrInvalidMetadata        = errors.New("invalid metadata")
 )
 
-// Supported integration types
+// Integration types of the built-in providers
 const (
 	IntegrationTypeTimebutler = "timebutler"
 	IntegrationType123Erfasst = "123erfasst"
-	IntegrationTypeAwork      = "awork"
+	IntegrationTypePersonio   = "personio"
 )
 
 // IntegrationRepository enthält Datenbankoperationen für Integrationen
@@ -49,17 +50,29 @@ func NewIntegrationRepository() *IntegrationRepository {
 	}
 }
 
+// integrationTypes enthält die bekannten Integrationstypen mit ihrem Anzeigenamen.
+// Die Typen werden von der Anbieter-Registry im Service registriert.
+var integrationTypes = struct {
+	sync.RWMutex
+	names map[string]string
+}{names: make(map[string]string)}
+
+// RegisterIntegrationType macht einen Integrationstyp bekannt
+func RegisterIntegrationType(integrationType, name string) {
+	integrationTypes.Lock()
+	defer integrationTypes.Unlock()
+	integrationTypes.names[strings.ToLower(strings.TrimSpace(integrationType))] = name
+}
+
 // ValidateIntegrationType validates the integration type
 func (r *IntegrationRepository) ValidateIntegrationType(integrationType string) error {
 	integrationType = strings.ToLower(strings.TrimSpace(integrationType))
 
-	validTypes := map[string]bool{
-		IntegrationTypeTimebutler: true,
-		IntegrationType123Erfasst: true,
-		IntegrationTypeAwork:      true,
-	}
+	integrationTypes.RLock()
+	_, known := integrationTypes.names[integrationType]
+	integrationTypes.RUnlock()
 
-	if !validTypes[integrationType] {
+	if !known {
 		return fmt.Errorf("%w: %s", ErrInvalidIntegrationType, integrationType)
 	}
 
@@ -491,18 +504,16 @@ func (r *IntegrationRepository) CreateIndexes() error {
 
 // Helper function to get the display name for an integration type
 func getIntegrationName(integrationType string) string {
-	switch integrationType {
-	case IntegrationTypeTimebutler:
-		return "Timebutler"
-	case IntegrationType123Erfasst:
-		return "123erfasst"
-	case IntegrationTypeAwork:
-		return "AWork"
-	default:
-		// Capitalize first letter
-		if len(integrationType) > 0 {
-			return strings.ToUpper(string(integrationType[0])) + integrationType[1:]
-		}
-		return integrationType
+	integrationTypes.RLock()
+	name, ok := integrationTypes.names[integrationType]
+	integrationTypes.RUnlock()
+	if ok && name != "" {
+		return name
+	}
+
+	// Capitalize first letter
+	if len(integrationType) > 0 {
+		return strings.ToUpper(string(integrationType[0])) + integrationType[1:]
 	}
+	return integrationType
 }
@@ -0,0 +1,265 @@
+// backend/repository/jobRepository.go
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"PeopleFlow/backend/db"
+	"PeopleFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// JobRepository errors
+var (
+	ErrJobNotFound = errors.New("job not found")
+)
+
+// JobRepository enthält alle Datenbankoperationen für Hintergrundjobs und deren Laufhistorie
+type JobRepository struct {
+	*BaseRepository
+	collection *mongo.Collection
+	runs       *BaseRepository
+}
+
+// NewJobRepository erstellt ein neues JobRepository
+func NewJobRepository() *JobRepository {
+	collection := db.GetCollection("jobs")
+	return &JobRepository{
+		BaseRepository: NewBaseRepository(collection),
+		collection:     collection,
+		runs:           NewBaseRepository(db.GetCollection("job_runs")),
+	}
+}
+
+// EnsureJob legt einen registrierten Job an, falls er noch nicht existiert.
+// Ein bereits gespeicherter Zeitplan (z.B. vom Admin geändert) bleibt erhalten.
+func (r *JobRepository) EnsureJob(name, schedule string, nextRunAt time.Time) error {
+	ctx, cancel := r.GetContext()
+	defer cancel()
+
+	update := bson.M{"$setOnInsert": bson.M{
+		"schedule":  schedule,
+		"paused":    false,
+		"nextRunAt": nextRunAt,
+		"updatedAt": time.Now(),
+	}}
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": name}, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return r.HandleError(ctx, err, "EnsureJob")
+	}
+	return nil
+}
+
+// FindAll gibt alle gespeicherten Jobs zurück
+func (r *JobRepository) FindAll() ([]*model.Job, error) {
+	var jobs []*model.Job
+	if err := r.BaseRepository.FindAll(bson.M{}, &jobs, options.Find().SetSort(bson.M{"_id": 1})); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// FindByName findet einen Job anhand seines Namens
+func (r *JobRepository) FindByName(name string) (*model.Job, error) {
+	var job model.Job
+	if err := r.FindOne(bson.M{"_id": name}, &job); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, ErrJobNotFound
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+// UpdateSchedule speichert einen neuen Zeitplan und den daraus berechneten nächsten Termin
+func (r *JobRepository) UpdateSchedule(name, schedule string, nextRunAt time.Time) error {
+	return r.updateJob(name, bson.M{"$set": bson.M{
+		"schedule":  schedule,
+		"nextRunAt": nextRunAt,
+		"updatedAt": time.Now(),
+	}})
+}
+
+// SetPaused pausiert einen Job oder setzt ihn mit dem nächsten Termin fort
+func (r *JobRepository) SetPaused(name string, paused bool, nextRunAt time.Time) error {
+	return r.updateJob(name, bson.M{"$set": bson.M{
+		"paused":    paused,
+		"nextRunAt": nextRunAt,
+		"updatedAt": time.Now(),
+	}})
+}
+
+// AcquireDue sperrt einen fälligen, nicht pausierten Job für diese Instanz und setzt
+// gleichzeitig den nächsten Termin. Ist der Job nicht fällig oder bereits von einer
+// anderen Instanz gesperrt, wird nil zurückgegeben. Der zurückgegebene Job enthält
+// den Zustand vor der Sperre (insbesondere den fälligen Termin).
+func (r *JobRepository) AcquireDue(name, instance string, now, nextRunAt time.Time, lease time.Duration) (*model.Job, error) {
+	filter := bson.M{
+		"_id":       name,
+		"paused":    false,
+		"nextRunAt": bson.M{"$lte": now},
+	}
+	update := bson.M{"$set": bson.M{"nextRunAt": nextRunAt}}
+	return r.acquire(filter, update, instance, now, lease, options.Before)
+}
+
+// AcquireLock sperrt einen Job für eine manuelle Ausführung unabhängig von Zeitplan und Pause.
+// Ist der Job bereits gesperrt, wird nil zurückgegeben.
+func (r *JobRepository) AcquireLock(name, instance string, now time.Time, lease time.Duration) (*model.Job, error) {
+	return r.acquire(bson.M{"_id": name}, bson.M{"$set": bson.M{}}, instance, now, lease, options.After)
+}
+
+// acquire setzt die Sperre atomar, sofern keine gültige Sperre einer anderen Ausführung besteht
+func (r *JobRepository) acquire(filter, update bson.M, instance string, now time.Time, lease time.Duration, returnDocument options.ReturnDocument) (*model.Job, error) {
+	ctx, cancel := r.GetContext()
+	defer cancel()
+
+	filter["$or"] = bson.A{
+		bson.M{"lockedUntil": bson.M{"$exists": false}},
+		bson.M{"lockedUntil": nil},
+		bson.M{"lockedUntil": bson.M{"$lte": now}},
+	}
+	set := update["$set"].(bson.M)
+	set["lockedBy"] = instance
+	set["lockedUntil"] = now.Add(lease)
+
+	var job model.Job
+	opts := options.FindOneAndUpdate().SetReturnDocument(returnDocument)
+	if err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&job); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, r.HandleError(ctx, err, "AcquireJob")
+	}
+	return &job, nil
+}
+
+// ExtendLock verlängert die Sperre dieser Instanz bis until. Gibt false zurück, wenn die
+// Instanz die Sperre nicht mehr hält (z.B. weil sie abgelaufen und neu vergeben ist).
+func (r *JobRepository) ExtendLock(name, instance string, until time.Time) (bool, error) {
+	ctx, cancel := r.GetContext()
+	defer cancel()
+
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": name, "lockedBy": instance},
+		bson.M{"$set": bson.M{"lockedUntil": until}},
+	)
+	if err != nil {
+		return false, r.HandleError(ctx, err, "ExtendJobLock")
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// Release hebt die Sperre dieser Instanz auf und speichert das Ergebnis des Laufs
+func (r *JobRepository) Release(name, instance string, run *model.JobRun) error {
+	ctx, cancel := r.GetContext()
+	defer cancel()
+
+	update := bson.M{
+		"$set": bson.M{
+			"lastRunAt":      run.StartedAt,
+			"lastStatus":     run.Status,
+			"lastError":      run.Error,
+			"lastDurationMs": run.DurationMs,
+			"updatedAt":      time.Now(),
+		},
+		"$unset": bson.M{"lockedBy": "", "lockedUntil": ""},
+	}
+	if _, err := r.collection.UpdateOne(ctx, bson.M{"_id": name, "lockedBy": instance}, update); err != nil {
+		return r.HandleError(ctx, err, "ReleaseJob")
+	}
+	return nil
+}
+
+// CreateRun legt einen Eintrag in der Laufhistorie an
+func (r *JobRepository) CreateRun(run *model.JobRun) error {
+	id, err := r.runs.InsertOne(run)
+	if err != nil {
+		return err
+	}
+	run.ID = *id
+	return nil
+}
+
+// FinishRun speichert Status, Ergebnis und Dauer eines Laufs
+func (r *JobRepository) FinishRun(run *model.JobRun) error {
+	return r.runs.UpdateByID(run.ID.Hex(), bson.M{"$set": bson.M{
+		"status":     run.Status,
+		"result":     run.Result,
+		"error":      run.Error,
+		"finishedAt": run.FinishedAt,
+		"durationMs": run.DurationMs,
+	}})
+}
+
+// AbortRunningRuns markiert Läufe eines Jobs als fehlgeschlagen, die noch als laufend
+// gespeichert sind. Wird aufgerufen, nachdem eine abgelaufene Sperre übernommen wurde –
+// die Instanz des alten Laufs wurde dann beendet, bevor sie ihn abschließen konnte.
+func (r *JobRepository) AbortRunningRuns(name string, now time.Time) error {
+	_, err := r.runs.UpdateMany(
+		bson.M{"job": name, "status": model.JobRunRunning},
+		bson.M{"$set": bson.M{
+			"status":     model.JobRunFailed,
+			"error":      "Lauf wurde abgebrochen (Instanz beendet oder Zeitlimit überschritten)",
+			"finishedAt": now,
+		}},
+	)
+	return err
+}
+
+// FindRuns gibt die Laufhistorie eines Jobs zurück, neueste zuerst
+func (r *JobRepository) FindRuns(name string, skip, limit int64) ([]*model.JobRun, int64, error) {
+	filter := bson.M{"job": name}
+
+	total, err := r.runs.Count(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var runs []*model.JobRun
+	opts := options.Find().
+		SetSort(bson.M{"startedAt": -1}).
+		SetSkip(skip).
+		SetLimit(limit)
+	if err := r.runs.FindAll(filter, &runs, opts); err != nil {
+		return nil, 0, err
+	}
+	return runs, total, nil
+}
+
+// DeleteRunsBefore löscht abgeschlossene Läufe, die vor dem Stichtag gestartet wurden
+func (r *JobRepository) DeleteRunsBefore(before time.Time) (int64, error) {
+	result, err := r.runs.DeleteMany(bson.M{
+		"status":    bson.M{"$ne": model.JobRunRunning},
+		"startedAt": bson.M{"$lt": before},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
+// updateJob ändert einen Job und übersetzt fehlende Jobs in ErrJobNotFound
+func (r *JobRepository) updateJob(name string, update bson.M) error {
+	result, err := r.UpdateOne(bson.M{"_id": name}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrJobNotFound
+	}
+	return nil
+}
+
+// CreateIndexes erstellt erforderliche Indizes
+func (r *JobRepository) CreateIndexes() error {
+	if err := r.runs.CreateIndex(bson.M{"job": 1, "startedAt": -1}, false); err != nil {
+		return fmt.Errorf("failed to create job run index: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+// backend/repository/laborCostSnapshotRepository.go
+package repository
+
+import (
+	"PeopleFlow/backend/db"
+	"PeopleFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// LaborCostSnapshotRepository enthält alle Datenbankoperationen für monatliche Personalkosten-Momentaufnahmen
+type LaborCostSnapshotRepository struct {
+	*BaseRepository
+	collection *mongo.Collection
+}
+
+// NewLaborCostSnapshotRepository erstellt ein neues LaborCostSnapshotRepository
+func NewLaborCostSnapshotRepository() *LaborCostSnapshotRepository {
+	collection := db.GetCollection("labor_cost_snapshots")
+	return &LaborCostSnapshotRepository{
+		BaseRepository: NewBaseRepository(collection),
+		collection:     collection,
+	}
+}
+
+// FindByMonths findet die gespeicherten Momentaufnahmen der angegebenen Monate (YYYY-MM), nach Monat
+func (r *LaborCostSnapshotRepository) FindByMonths(months []string) (map[string]*model.LaborCostSnapshot, error) {
+	var snapshots []*model.LaborCostSnapshot
+	if err := r.FindAll(bson.M{"month": bson.M{"$in": months}}, &snapshots); err != nil {
+		return nil, err
+	}
+
+	byMonth := make(map[string]*model.LaborCostSnapshot, len(snapshots))
+	for _, snapshot := range snapshots {
+		snapshot.Stored = true
+		byMonth[snapshot.Month] = snapshot
+	}
+	return byMonth, nil
+}
+
+// Upsert speichert die Momentaufnahme eines Monats und ersetzt eine vorhandene
+func (r *LaborCostSnapshotRepository) Upsert(snapshot *model.LaborCostSnapshot) error {
+	ctx, cancel := r.GetContext()
+	defer cancel()
+
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"month": snapshot.Month},
+		bson.M{"$set": bson.M{
+			"employeeCount":         snapshot.EmployeeCount,
+			"salaries":              snapshot.Salaries,
+			"bonuses":               snapshot.Bonuses,
+			"employerContributions": snapshot.EmployerContributions,
+			"total":                 snapshot.Total,
+			"groups":                snapshot.Groups,
+			"createdAt":             snapshot.CreatedAt,
+		}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		return r.HandleError(ctx, err, "Upsert")
+	}
+	snapshot.Stored = true
+	return nil
+}
@@ -0,0 +1,137 @@
+// backend/repository/notificationRepository.go
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"PeopleFlow/backend/db"
+	"PeopleFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// NotificationRepository errors
+var (
+	ErrNotificationNotFound = errors.New("notification not found")
+)
+
+// NotificationRepository enthält alle Datenbankoperationen für In-App-Benachrichtigungen
+type NotificationRepository struct {
+	*BaseRepository
+	collection *mongo.Collection
+}
+
+// NewNotificationRepository erstellt ein neues NotificationRepository
+func NewNotificationRepository() *NotificationRepository {
+	collection := db.GetCollection("notifications")
+	return &NotificationRepository{
+		BaseRepository: NewBaseRepository(collection),
+		collection:     collection,
+	}
+}
+
+// Create speichert eine neue Benachrichtigung
+func (r *NotificationRepository) Create(notification *model.Notification) error {
+	if err := notification.Validate(); err != nil {
+		return err
+	}
+
+	notification.CreatedAt = time.Now()
+	notification.Read = false
+	notification.ReadAt = nil
+
+	id, err := r.InsertOne(notification)
+	if err != nil {
+		return err
+	}
+
+	notification.ID = *id
+	return nil
+}
+
+// FindByUser gibt die Benachrichtigungen eines Benutzers zurück (neueste zuerst)
+func (r *NotificationRepository) FindByUser(userID primitive.ObjectID, unreadOnly bool, skip, limit int64) ([]*model.Notification, int64, error) {
+	filter := bson.M{"userId": userID}
+	if unreadOnly {
+		filter["read"] = false
+	}
+
+	total, err := r.Count(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var notifications []*model.Notification
+	opts := options.Find().SetSort(bson.M{"createdAt": -1}).SetSkip(skip).SetLimit(limit)
+	if err := r.BaseRepository.FindAll(filter, &notifications, opts); err != nil {
+		return nil, 0, err
+	}
+	return notifications, total, nil
+}
+
+// CountUnread zählt die ungelesenen Benachrichtigungen eines Benutzers
+func (r *NotificationRepository) CountUnread(userID primitive.ObjectID) (int64, error) {
+	return r.Count(bson.M{"userId": userID, "read": false})
+}
+
+// ExistsForTarget prüft, ob ein Benutzer zu einem Ziel bereits eine Benachrichtigung dieser Art erhalten hat.
+// Verhindert doppelte Erinnerungen bei täglich wiederholten Prüfungen.
+func (r *NotificationRepository) ExistsForTarget(userID primitive.ObjectID, notificationType model.NotificationType, targetID primitive.ObjectID) (bool, error) {
+	return r.Exists(bson.M{"userId": userID, "type": notificationType, "targetId": targetID})
+}
+
+// ExistsUnread prüft, ob ein Benutzer eine gleichlautende ungelesene Benachrichtigung hat
+func (r *NotificationRepository) ExistsUnread(userID primitive.ObjectID, notificationType model.NotificationType, title string) (bool, error) {
+	return r.Exists(bson.M{"userId": userID, "type": notificationType, "title": title, "read": false})
+}
+
+// MarkRead markiert eine Benachrichtigung des Benutzers als gelesen
+func (r *NotificationRepository) MarkRead(userID primitive.ObjectID, id string) error {
+	objID, err := r.ValidateObjectID(id)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	result, err := r.UpdateOne(
+		bson.M{"_id": *objID, "userId": userID},
+		bson.M{"$set": bson.M{"read": true, "readAt": now}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrNotificationNotFound
+	}
+	return nil
+}
+
+// MarkAllRead markiert alle ungelesenen Benachrichtigungen des Benutzers als gelesen
+func (r *NotificationRepository) MarkAllRead(userID primitive.ObjectID) (int64, error) {
+	result, err := r.UpdateMany(
+		bson.M{"userId": userID, "read": false},
+		bson.M{"$set": bson.M{"read": true, "readAt": time.Now()}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+// DeleteReadBefore löscht gelesene Benachrichtigungen, die älter als der Stichtag sind
+func (r *NotificationRepository) DeleteReadBefore(before time.Time) (int64, error) {
+	result, err := r.DeleteMany(bson.M{"read": true, "createdAt": bson.M{"$lt": before}})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
+// CreateIndexes erstellt die Indizes für die Abfrage nach Benutzer
+func (r *NotificationRepository) CreateIndexes() error {
+	return r.CreateIndex(bson.M{"userId": 1, "createdAt": -1}, false)
+}
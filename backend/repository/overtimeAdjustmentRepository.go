@@ -248,6 +248,28 @@ func (r *OvertimeAdjustmentRepository) FindByDateRange(start, end time.Time, sta
 	return adjustments, nil
 }
 
+// FindApprovedPayouts findet die genehmigten Überstunden-Auszahlungen, die im Zeitraum
+// [start, end) genehmigt wurden
+func (r *OvertimeAdjustmentRepository) FindApprovedPayouts(start, end time.Time) ([]*model.OvertimeAdjustment, error) {
+	var adjustments []*model.OvertimeAdjustment
+
+	filter := bson.M{
+		"type":   model.OvertimeAdjustmentTypePayout,
+		"status": StatusApproved,
+		"approvedAt": bson.M{
+			"$gte": start,
+			"$lt":  end,
+		},
+	}
+
+	err := r.FindAll(filter, &adjustments, options.Find().SetSort(bson.M{"approvedAt": 1}))
+	if err != nil {
+		return nil, err
+	}
+
+	return adjustments, nil
+}
+
 // UpdateStatus aktualisiert den Status einer Anpassung mit Validierung
 func (r *OvertimeAdjustmentRepository) UpdateStatus(adjustmentID string, status string, approverID primitive.ObjectID, approverName string) error {
 	// Validate status
@@ -0,0 +1,123 @@
+// backend/repository/reportSubscriptionRepository.go
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"PeopleFlow/backend/db"
+	"PeopleFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ReportSubscriptionRepository errors
+var (
+	ErrReportSubscriptionNotFound = errors.New("report subscription not found")
+)
+
+// ReportSubscriptionRepository enthält alle Datenbankoperationen für Berichts-Abonnements
+type ReportSubscriptionRepository struct {
+	*BaseRepository
+	collection *mongo.Collection
+}
+
+// NewReportSubscriptionRepository erstellt ein neues ReportSubscriptionRepository
+func NewReportSubscriptionRepository() *ReportSubscriptionRepository {
+	collection := db.GetCollection("report_subscriptions")
+	return &ReportSubscriptionRepository{
+		BaseRepository: NewBaseRepository(collection),
+		collection:     collection,
+	}
+}
+
+// Create speichert ein neues Abonnement
+func (r *ReportSubscriptionRepository) Create(subscription *model.ReportSubscription) error {
+	if err := subscription.Validate(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	subscription.CreatedAt = now
+	subscription.UpdatedAt = now
+
+	id, err := r.InsertOne(subscription)
+	if err != nil {
+		return err
+	}
+
+	subscription.ID = *id
+	return nil
+}
+
+// FindByID findet ein Abonnement anhand seiner ID
+func (r *ReportSubscriptionRepository) FindByID(id string) (*model.ReportSubscription, error) {
+	var subscription model.ReportSubscription
+	if err := r.BaseRepository.FindByID(id, &subscription); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, ErrReportSubscriptionNotFound
+		}
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+// FindByUser findet alle Abonnements eines Benutzers
+func (r *ReportSubscriptionRepository) FindByUser(userID primitive.ObjectID) ([]*model.ReportSubscription, error) {
+	var subscriptions []*model.ReportSubscription
+	opts := options.Find().SetSort(bson.M{"createdAt": 1})
+	if err := r.BaseRepository.FindAll(bson.M{"userId": userID}, &subscriptions, opts); err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+// FindActive findet alle aktiven Abonnements
+func (r *ReportSubscriptionRepository) FindActive() ([]*model.ReportSubscription, error) {
+	var subscriptions []*model.ReportSubscription
+	if err := r.BaseRepository.FindAll(bson.M{"active": true}, &subscriptions); err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+// Update aktualisiert Zeitplan und Inhalt eines Abonnements
+func (r *ReportSubscriptionRepository) Update(subscription *model.ReportSubscription) error {
+	if err := subscription.Validate(); err != nil {
+		return err
+	}
+
+	subscription.UpdatedAt = time.Now()
+	err := r.UpdateByID(subscription.ID.Hex(), bson.M{"$set": bson.M{
+		"frequency":  subscription.Frequency,
+		"weekday":    subscription.Weekday,
+		"dayOfMonth": subscription.DayOfMonth,
+		"hour":       subscription.Hour,
+		"sections":   subscription.Sections,
+		"department": subscription.Department,
+		"active":     subscription.Active,
+		"updatedAt":  subscription.UpdatedAt,
+	}})
+	if errors.Is(err, ErrNotFound) {
+		return ErrReportSubscriptionNotFound
+	}
+	return err
+}
+
+// MarkSent speichert den Zeitpunkt des letzten Versands
+func (r *ReportSubscriptionRepository) MarkSent(id primitive.ObjectID, sentAt time.Time) error {
+	_, err := r.UpdateOne(bson.M{"_id": id}, bson.M{"$set": bson.M{"lastSentAt": sentAt}})
+	return err
+}
+
+// Delete löscht ein Abonnement
+func (r *ReportSubscriptionRepository) Delete(id string) error {
+	err := r.DeleteByID(id)
+	if errors.Is(err, ErrNotFound) {
+		return ErrReportSubscriptionNotFound
+	}
+	return err
+}
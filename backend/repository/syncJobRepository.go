@@ -0,0 +1,221 @@
+// backend/repository/syncJobRepository.go
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"PeopleFlow/backend/db"
+	"PeopleFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SyncJobRepository errors
+var (
+	ErrSyncJobNotFound    = errors.New("sync job not found")
+	ErrSyncPreviewApplied = errors.New("sync preview has already been applied")
+	ErrSyncJobActive      = errors.New("another sync job of this integration is active")
+)
+
+// SyncJobRepository enthält alle Datenbankoperationen für Synchronisierungen im Hintergrund
+type SyncJobRepository struct {
+	*BaseRepository
+	collection *mongo.Collection
+}
+
+// NewSyncJobRepository erstellt ein neues SyncJobRepository
+func NewSyncJobRepository() *SyncJobRepository {
+	collection := db.GetCollection("sync_jobs")
+	return &SyncJobRepository{
+		BaseRepository: NewBaseRepository(collection),
+		collection:     collection,
+	}
+}
+
+// Create legt eine Synchronisierung an. Ist für die Integration bereits eine andere
+// Synchronisierung aktiv, verhindert der eindeutige Index das Anlegen (ErrSyncJobActive).
+func (r *SyncJobRepository) Create(job *model.SyncJob) error {
+	now := time.Now()
+	job.CreatedAt = now
+	job.UpdatedAt = now
+	if job.Status == "" {
+		job.Status = model.SyncJobQueued
+	}
+	job.Active = !job.IsFinished()
+
+	id, err := r.InsertOne(job)
+	if err != nil {
+		if errors.Is(err, ErrDuplicateEntry) {
+			return ErrSyncJobActive
+		}
+		return err
+	}
+
+	job.ID = *id
+	return nil
+}
+
+// FindByID findet eine Synchronisierung anhand ihrer ID
+func (r *SyncJobRepository) FindByID(id string) (*model.SyncJob, error) {
+	var job model.SyncJob
+	if err := r.BaseRepository.FindByID(id, &job); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, ErrSyncJobNotFound
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+// FindActive findet eine noch nicht abgeschlossene Synchronisierung einer Integration,
+// die seit since ein Lebenszeichen gegeben hat
+func (r *SyncJobRepository) FindActive(integration string, since time.Time) (*model.SyncJob, error) {
+	var job model.SyncJob
+	err := r.FindOne(bson.M{
+		"integration": integration,
+		"status":      bson.M{"$in": []model.SyncJobStatus{model.SyncJobQueued, model.SyncJobRunning}},
+		"updatedAt":   bson.M{"$gte": since},
+	}, &job)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+// FindRecent gibt die letzten Synchronisierungen zurück, neueste zuerst
+func (r *SyncJobRepository) FindRecent(limit int64) ([]*model.SyncJob, error) {
+	var jobs []*model.SyncJob
+	opts := options.Find().
+		SetSort(bson.M{"createdAt": -1}).
+		SetLimit(limit).
+		SetProjection(bson.M{"warnings": 0, "diff.employees": 0})
+	if err := r.FindAll(bson.M{}, &jobs, opts); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// ExpireStale schließt die nicht abgeschlossenen Synchronisierungen einer Integration als
+// fehlgeschlagen ab, die seit before kein Lebenszeichen mehr gegeben haben
+func (r *SyncJobRepository) ExpireStale(integration string, before time.Time) (int64, error) {
+	now := time.Now()
+	result, err := r.UpdateMany(bson.M{
+		"integration": integration,
+		"status":      bson.M{"$in": []model.SyncJobStatus{model.SyncJobQueued, model.SyncJobRunning}},
+		"updatedAt":   bson.M{"$lt": before},
+	}, bson.M{
+		"$set": bson.M{
+			"status":     model.SyncJobFailed,
+			"error":      "Die Synchronisierung wurde abgebrochen (keine Rückmeldung vom Server)",
+			"finishedAt": now,
+			"updatedAt":  now,
+		},
+		"$unset": bson.M{"active": ""},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+// Save speichert Zustand, Fortschritt und Ergebnis einer Synchronisierung
+func (r *SyncJobRepository) Save(job *model.SyncJob) error {
+	job.UpdatedAt = time.Now()
+	job.Active = !job.IsFinished()
+
+	set := bson.M{
+		"status":          job.Status,
+		"phase":           job.Phase,
+		"processed":       job.Processed,
+		"total":           job.Total,
+		"warnings":        job.Warnings,
+		"droppedWarnings": job.Dropped,
+		"counts":          job.Counts,
+		"summary":         job.Summary,
+		"error":           job.Error,
+		"diff":            job.Diff,
+		"previewMismatch": job.PreviewMismatch,
+		"instance":        job.Instance,
+		"startedAt":       job.StartedAt,
+		"finishedAt":      job.FinishedAt,
+		"updatedAt":       job.UpdatedAt,
+	}
+	update := bson.M{"$set": set}
+	if job.Active {
+		set["active"] = true
+	} else {
+		// Eine abgeschlossene Synchronisierung gibt den eindeutigen Index für die nächste frei
+		update["$unset"] = bson.M{"active": ""}
+	}
+
+	err := r.UpdateByID(job.ID.Hex(), update)
+	if errors.Is(err, ErrNotFound) {
+		return ErrSyncJobNotFound
+	}
+	return err
+}
+
+// MarkApplied verknüpft einen Probelauf mit der Synchronisierung, die ihn übernimmt.
+// Wurde der Probelauf bereits übernommen, wird ErrSyncPreviewApplied zurückgegeben.
+func (r *SyncJobRepository) MarkApplied(previewID, appliedID primitive.ObjectID) error {
+	ctx, cancel := r.GetContext()
+	defer cancel()
+
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": previewID, "appliedJobId": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"appliedJobId": appliedID, "updatedAt": time.Now()}},
+	)
+	if err != nil {
+		return r.HandleError(ctx, err, "MarkSyncPreviewApplied")
+	}
+	if result.MatchedCount == 0 {
+		return ErrSyncPreviewApplied
+	}
+	return nil
+}
+
+// DeleteFinishedBefore löscht abgeschlossene Synchronisierungen, die vor dem Stichtag endeten
+func (r *SyncJobRepository) DeleteFinishedBefore(before time.Time) (int64, error) {
+	result, err := r.DeleteMany(bson.M{
+		"status":     bson.M{"$in": []model.SyncJobStatus{model.SyncJobCompleted, model.SyncJobFailed}},
+		"finishedAt": bson.M{"$lt": before},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
+// CreateIndexes erstellt erforderliche Indizes
+func (r *SyncJobRepository) CreateIndexes() error {
+	if err := r.CreateIndex(bson.M{"integration": 1, "status": 1}, false); err != nil {
+		return fmt.Errorf("failed to create integration index: %w", err)
+	}
+	if err := r.CreateIndex(bson.M{"createdAt": -1}, false); err != nil {
+		return fmt.Errorf("failed to create createdAt index: %w", err)
+	}
+
+	// Je Integration höchstens eine aktive Synchronisierung
+	ctx, cancel := r.GetContext()
+	defer cancel()
+
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "integration", Value: 1}},
+		Options: options.Index().
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"active": true}).
+			SetName("integration_active"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create active sync index: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// backend/repository/timesheetAcknowledgementRepository.go
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"PeopleFlow/backend/db"
+	"PeopleFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TimesheetAcknowledgementRepository enthält alle Datenbankoperationen für Bestätigungen von Stundenzetteln
+type TimesheetAcknowledgementRepository struct {
+	*BaseRepository
+	collection *mongo.Collection
+}
+
+// NewTimesheetAcknowledgementRepository erstellt ein neues TimesheetAcknowledgementRepository
+func NewTimesheetAcknowledgementRepository() *TimesheetAcknowledgementRepository {
+	collection := db.GetCollection("timesheet_acknowledgements")
+	return &TimesheetAcknowledgementRepository{
+		BaseRepository: NewBaseRepository(collection),
+		collection:     collection,
+	}
+}
+
+// FindByEmployeeAndMonth findet die Bestätigungen eines Stundenzettels. Gibt es noch keine,
+// wird ein leerer Datensatz ohne ID zurückgegeben.
+func (r *TimesheetAcknowledgementRepository) FindByEmployeeAndMonth(employeeID primitive.ObjectID, month string) (*model.TimesheetAcknowledgement, error) {
+	var ack model.TimesheetAcknowledgement
+	err := r.FindOne(bson.M{"employeeId": employeeID, "month": month}, &ack)
+	if errors.Is(err, ErrNotFound) {
+		return &model.TimesheetAcknowledgement{EmployeeID: employeeID, Month: month}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &ack, nil
+}
+
+// FindByMonth findet alle Bestätigungen eines Monats, nach Mitarbeiter-ID
+func (r *TimesheetAcknowledgementRepository) FindByMonth(month string) (map[primitive.ObjectID]*model.TimesheetAcknowledgement, error) {
+	var acks []*model.TimesheetAcknowledgement
+	if err := r.FindAll(bson.M{"month": month}, &acks); err != nil {
+		return nil, err
+	}
+
+	byEmployee := make(map[primitive.ObjectID]*model.TimesheetAcknowledgement, len(acks))
+	for _, ack := range acks {
+		byEmployee[ack.EmployeeID] = ack
+	}
+	return byEmployee, nil
+}
+
+// SaveSignature speichert die Bestätigung einer Rolle. Der Datensatz je Mitarbeiter und Monat wird
+// bei Bedarf angelegt; die Bestätigung der anderen Rolle bleibt unberührt, auch wenn beide
+// gleichzeitig bestätigen. Gibt den gespeicherten Stand mit beiden Bestätigungen zurück.
+func (r *TimesheetAcknowledgementRepository) SaveSignature(employeeID primitive.ObjectID, month string, role model.TimesheetSignatureRole, signature model.TimesheetSignature) (*model.TimesheetAcknowledgement, error) {
+	ctx, cancel := r.GetContext()
+	defer cancel()
+
+	now := time.Now()
+	update := bson.M{
+		"$set":         bson.M{string(role): signature, "updatedAt": now},
+		"$setOnInsert": bson.M{"createdAt": now},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var ack model.TimesheetAcknowledgement
+	err := r.collection.FindOneAndUpdate(ctx, bson.M{"employeeId": employeeID, "month": month}, update, opts).Decode(&ack)
+	if err != nil {
+		return nil, r.HandleError(ctx, err, "SaveTimesheetSignature")
+	}
+	return &ack, nil
+}
+
+// CreateIndexes erstellt den eindeutigen Index je Mitarbeiter und Monat
+func (r *TimesheetAcknowledgementRepository) CreateIndexes() error {
+	ctx, cancel := r.GetContext()
+	defer cancel()
+
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "employeeId", Value: 1}, {Key: "month", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create employee month index: %w", err)
+	}
+	return nil
+}
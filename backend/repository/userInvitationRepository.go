@@ -0,0 +1,134 @@
+// backend/repository/userInvitationRepository.go
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"PeopleFlow/backend/db"
+	"PeopleFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// UserInvitationRepository errors
+var (
+	ErrInvitationNotFound = errors.New("invitation not found")
+	ErrInvitationInvalid  = errors.New("invitation expired or already used")
+)
+
+// UserInvitationRepository enthält alle Datenbankoperationen für Einladungen
+type UserInvitationRepository struct {
+	*BaseRepository
+	collection *mongo.Collection
+}
+
+// NewUserInvitationRepository erstellt ein neues UserInvitationRepository
+func NewUserInvitationRepository() *UserInvitationRepository {
+	collection := db.GetCollection("user_invitations")
+	return &UserInvitationRepository{
+		BaseRepository: NewBaseRepository(collection),
+		collection:     collection,
+	}
+}
+
+// Create speichert eine neue Einladung; das Klartext-Token wird nur als Hash abgelegt
+func (r *UserInvitationRepository) Create(invitation *model.UserInvitation, token string) error {
+	if invitation.UserID.IsZero() {
+		return fmt.Errorf("%w: user ID is required", ErrValidation)
+	}
+	if token == "" {
+		return fmt.Errorf("%w: token is required", ErrValidation)
+	}
+
+	invitation.TokenHash = model.HashInvitationToken(token)
+	invitation.CreatedAt = time.Now()
+	if invitation.ExpiresAt.IsZero() {
+		invitation.ExpiresAt = invitation.CreatedAt.Add(model.DefaultInvitationValidity)
+	}
+
+	id, err := r.InsertOne(invitation)
+	if err != nil {
+		return err
+	}
+
+	invitation.ID = *id
+	return nil
+}
+
+// FindByToken findet eine Einladung anhand des Klartext-Tokens
+func (r *UserInvitationRepository) FindByToken(token string) (*model.UserInvitation, error) {
+	var invitation model.UserInvitation
+	err := r.FindOne(bson.M{"tokenHash": model.HashInvitationToken(token)}, &invitation)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, ErrInvitationNotFound
+		}
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// FindLatestByUserID findet die neueste Einladung eines Benutzers
+func (r *UserInvitationRepository) FindLatestByUserID(userID primitive.ObjectID) (*model.UserInvitation, error) {
+	var invitations []*model.UserInvitation
+	opts := options.Find().SetSort(bson.M{"createdAt": -1}).SetLimit(1)
+	if err := r.BaseRepository.FindAll(bson.M{"userId": userID}, &invitations, opts); err != nil {
+		return nil, err
+	}
+	if len(invitations) == 0 {
+		return nil, ErrInvitationNotFound
+	}
+	return invitations[0], nil
+}
+
+// MarkAccepted markiert eine Einladung als angenommen, sofern sie noch verwendbar ist
+func (r *UserInvitationRepository) MarkAccepted(id primitive.ObjectID) error {
+	now := time.Now()
+	result, err := r.UpdateOne(bson.M{
+		"_id":        id,
+		"acceptedAt": bson.M{"$exists": false},
+		"revokedAt":  bson.M{"$exists": false},
+		"expiresAt":  bson.M{"$gt": now},
+	}, bson.M{"$set": bson.M{"acceptedAt": now}})
+	if err != nil {
+		return err
+	}
+	if result.ModifiedCount == 0 {
+		return ErrInvitationInvalid
+	}
+	return nil
+}
+
+// RevokeOpenByUserID zieht alle offenen Einladungen eines Benutzers zurück
+func (r *UserInvitationRepository) RevokeOpenByUserID(userID primitive.ObjectID) error {
+	_, err := r.UpdateMany(bson.M{
+		"userId":     userID,
+		"acceptedAt": bson.M{"$exists": false},
+		"revokedAt":  bson.M{"$exists": false},
+	}, bson.M{"$set": bson.M{"revokedAt": time.Now()}})
+	return err
+}
+
+// CreateIndexes erstellt erforderliche Indizes
+func (r *UserInvitationRepository) CreateIndexes() error {
+	if err := r.CreateIndex(bson.M{"tokenHash": 1}, true); err != nil {
+		return fmt.Errorf("failed to create token index: %w", err)
+	}
+
+	// bson.D, damit die Reihenfolge der Felder bei jedem Start gleich ist
+	ctx, cancel := r.GetContext()
+	defer cancel()
+
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "userId", Value: 1}, {Key: "createdAt", Value: -1}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create user index: %w", err)
+	}
+	return nil
+}
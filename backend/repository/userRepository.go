@@ -22,7 +22,7 @@ var (
 	ErrUserNotFound    = errors.New("user not found")
 	ErrEmailTaken      = errors.New("email already taken")
 	ErrInvalidEmail    = errors.New("invalid email format")
-	ErrInvalidPassword = errors.New("password must be at least 8 characters")
+	ErrInvalidPassword = errors.New("password does not meet the password policy")
 )
 
 // Email validation regex
@@ -59,8 +59,8 @@ func (r *UserRepository) ValidateUser(user *model.User, isUpdate bool) error {
 
 	// Password validation (only for create or if password is being updated)
 	if !isUpdate && user.Password != "" {
-		if len(user.Password) < 8 {
-			return ErrInvalidPassword
+		if err := r.CheckPasswordPolicy(nil, user.Password); err != nil {
+			return err
 		}
 	}
 
@@ -73,7 +73,7 @@ func (r *UserRepository) ValidateUser(user *model.User, isUpdate bool) error {
 
 	// Status validation
 	if !isUpdate || user.Status != "" {
-		if user.Status != model.StatusActive && user.Status != model.StatusInactive {
+		if user.Status != model.StatusActive && user.Status != model.StatusInactive && user.Status != model.StatusInvited {
 			return fmt.Errorf("%w: invalid status %s", ErrValidation, user.Status)
 		}
 	}
@@ -105,6 +105,9 @@ func (r *UserRepository) Create(user *model.User) error {
 	if err := user.HashPassword(); err != nil {
 		return fmt.Errorf("failed to hash password: %w", err)
 	}
+	changedAt := time.Now()
+	user.PasswordChangedAt = &changedAt
+	user.PasswordHistory = model.AppendPasswordHistory(nil, user.PasswordHash, r.PasswordPolicy().HistorySize)
 
 	// Insert user
 	id, err := r.InsertOne(user)
@@ -119,6 +122,80 @@ func (r *UserRepository) Create(user *model.User) error {
 	return nil
 }
 
+// CreateInvited legt einen eingeladenen Benutzer ohne Passwort an.
+// Das Passwort setzt der Benutzer selbst bei der Aktivierung über den Einladungslink.
+func (r *UserRepository) CreateInvited(user *model.User) error {
+	user.Password = ""
+	user.PasswordHash = ""
+	user.Status = model.StatusInvited
+
+	if err := r.ValidateUser(user, false); err != nil {
+		return err
+	}
+
+	exists, err := r.EmailExists(user.Email)
+	if err != nil {
+		return fmt.Errorf("failed to check email existence: %w", err)
+	}
+	if exists {
+		return ErrEmailTaken
+	}
+
+	user.CreatedAt = time.Now()
+	user.UpdatedAt = time.Now()
+
+	id, err := r.InsertOne(user)
+	if err != nil {
+		if errors.Is(err, ErrDuplicateEntry) {
+			return ErrEmailTaken
+		}
+		return err
+	}
+
+	user.ID = *id
+	return nil
+}
+
+// ActivateInvited setzt Passwort und optional 2FA-Secret und aktiviert den Benutzer. Die
+// Aktivierung gelingt nur, solange der Benutzer noch eingeladen ist; parallele Aktivierungen
+// mit demselben Link schlagen mit ErrInvitationInvalid fehl.
+func (r *UserRepository) ActivateInvited(userID string, password, encryptedTOTPSecret string) error {
+	user, err := r.FindByID(userID)
+	if err != nil {
+		return err
+	}
+
+	set, err := r.passwordUpdateFields(user, password)
+	if err != nil {
+		return err
+	}
+	set["status"] = model.StatusActive
+	if encryptedTOTPSecret != "" {
+		set["twoFactorEnabled"] = true
+		set["twoFactorSecret"] = encryptedTOTPSecret
+	}
+
+	result, err := r.UpdateOne(bson.M{"_id": user.ID, "status": model.StatusInvited}, bson.M{"$set": set})
+	if err != nil {
+		return err
+	}
+	if result.ModifiedCount == 0 {
+		return ErrInvitationInvalid
+	}
+	return nil
+}
+
+// LinkEmployee verknüpft einen Benutzer mit einem Mitarbeiterdatensatz
+func (r *UserRepository) LinkEmployee(userID string, employeeID primitive.ObjectID) error {
+	update := bson.M{
+		"$set": bson.M{
+			"employeeId": employeeID,
+			"updatedAt":  time.Now(),
+		},
+	}
+	return r.UpdateByID(userID, update)
+}
+
 // FindByID findet einen Benutzer anhand seiner ID
 func (r *UserRepository) FindByID(id string) (*model.User, error) {
 	var user model.User
@@ -177,6 +254,64 @@ func (r *UserRepository) FindAll(skip, limit int64) ([]*model.User, int64, error
 	return users, total, nil
 }
 
+// UserQuery beschreibt Filter, Sortierung und Pagination für Benutzerabfragen
+type UserQuery struct {
+	Search    string // Suche über Name und E-Mail
+	Role      string
+	Status    string
+	Skip      int64
+	Limit     int64 // 0 = unbegrenzt
+	SortBy    string
+	SortOrder int
+}
+
+// Search findet Benutzer anhand einer UserQuery
+func (r *UserRepository) Search(query UserQuery) ([]*model.User, int64, error) {
+	filter := bson.M{}
+	if query.Search != "" {
+		pattern := primitive.Regex{Pattern: regexp.QuoteMeta(strings.TrimSpace(query.Search)), Options: "i"}
+		filter["$or"] = []bson.M{
+			{"firstName": pattern},
+			{"lastName": pattern},
+			{"email": pattern},
+		}
+	}
+	if query.Role != "" {
+		filter["role"] = query.Role
+	}
+	if query.Status != "" {
+		filter["status"] = query.Status
+	}
+
+	sortBy := query.SortBy
+	if sortBy == "" {
+		sortBy = "createdAt"
+	}
+	sortOrder := query.SortOrder
+	if sortOrder == 0 {
+		sortOrder = -1
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: sortBy, Value: sortOrder}, {Key: "_id", Value: 1}}).
+		SetSkip(query.Skip)
+	if query.Limit > 0 {
+		findOptions.SetLimit(query.Limit)
+	}
+
+	var users []*model.User
+	if err := r.BaseRepository.FindAll(filter, &users, findOptions); err != nil {
+		return nil, 0, err
+	}
+
+	total, err := r.Count(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
 // Update aktualisiert einen Benutzer
 func (r *UserRepository) Update(user *model.User) error {
 	// Validate user data
@@ -228,26 +363,115 @@ func (r *UserRepository) Update(user *model.User) error {
 	return r.UpdateByID(user.ID.Hex(), update)
 }
 
-// UpdatePassword aktualisiert das Passwort eines Benutzers
+// UpdateLanguage setzt die Sprache für E-Mails; leer bedeutet Systemsprache
+func (r *UserRepository) UpdateLanguage(userID primitive.ObjectID, language string) error {
+	if language != "" && !model.IsValidEmailLanguage(language) {
+		return fmt.Errorf("%w: %s", model.ErrInvalidEmailLanguage, language)
+	}
+	return r.UpdateByID(userID.Hex(), bson.M{"$set": bson.M{
+		"language":  language,
+		"updatedAt": time.Now(),
+	}})
+}
+
+// UpdateNotificationPreferences speichert die Benachrichtigungseinstellungen eines Benutzers
+func (r *UserRepository) UpdateNotificationPreferences(userID primitive.ObjectID, preferences model.NotificationPreferences) error {
+	if err := preferences.Validate(); err != nil {
+		return err
+	}
+	return r.UpdateByID(userID.Hex(), bson.M{"$set": bson.M{
+		"notificationPreferences": preferences,
+		"updatedAt":               time.Now(),
+	}})
+}
+
+// UpdatePassword aktualisiert das Passwort eines Benutzers unter Anwendung der Passwortrichtlinie
 func (r *UserRepository) UpdatePassword(userID string, newPassword string) error {
-	if len(newPassword) < 8 {
-		return ErrInvalidPassword
+	user, err := r.FindByID(userID)
+	if err != nil {
+		return err
 	}
 
-	// Create temporary user to hash password
-	tempUser := &model.User{Password: newPassword}
-	if err := tempUser.HashPassword(); err != nil {
-		return fmt.Errorf("failed to hash password: %w", err)
+	set, err := r.passwordUpdateFields(user, newPassword)
+	if err != nil {
+		return err
 	}
 
-	update := bson.M{
-		"$set": bson.M{
-			"passwordHash": tempUser.PasswordHash,
-			"updatedAt":    time.Now(),
-		},
+	return r.UpdateByID(userID, bson.M{"$set": set})
+}
+
+// PasswordPolicy gibt die aktuell konfigurierte Passwortrichtlinie zurück
+func (r *UserRepository) PasswordPolicy() *model.PasswordPolicy {
+	settings, err := NewSystemSettingsRepository().GetSettings()
+	if err != nil {
+		return model.DefaultPasswordPolicy()
+	}
+	return settings.GetPasswordPolicy()
+}
+
+// CheckPasswordPolicy prüft ein neues Passwort gegen Richtlinie, Passwort-Historie und Breach-Liste.
+// user ist nil, wenn ein neuer Benutzer angelegt wird.
+func (r *UserRepository) CheckPasswordPolicy(user *model.User, password string) error {
+	policy := r.PasswordPolicy()
+
+	if err := policy.Validate(password); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidPassword, err)
 	}
 
-	return r.UpdateByID(userID, update)
+	if user != nil && policy.HistorySize > 0 {
+		history := currentPasswordHistory(user)
+		if len(history) > policy.HistorySize {
+			history = history[:policy.HistorySize]
+		}
+		if model.MatchesHistory(password, history) {
+			return fmt.Errorf("%w: %w", ErrInvalidPassword, model.ErrPasswordReused)
+		}
+	}
+
+	if policy.CheckBreached {
+		breached, err := NewBreachedPasswordRepository().IsBreached(password)
+		if err != nil {
+			return fmt.Errorf("failed to check breached passwords: %w", err)
+		}
+		if breached {
+			return fmt.Errorf("%w: %w", ErrInvalidPassword, model.ErrPasswordBreached)
+		}
+	}
+
+	return nil
+}
+
+// IsPasswordExpired prüft, ob das Passwort eines Benutzers laut Richtlinie abgelaufen ist
+func (r *UserRepository) IsPasswordExpired(user *model.User) bool {
+	return r.PasswordPolicy().IsPasswordExpired(user.GetPasswordChangedAt())
+}
+
+// passwordUpdateFields prüft das neue Passwort und erstellt die zu setzenden Felder inkl. Historie
+func (r *UserRepository) passwordUpdateFields(user *model.User, password string) (bson.M, error) {
+	if err := r.CheckPasswordPolicy(user, password); err != nil {
+		return nil, err
+	}
+
+	tempUser := &model.User{Password: password}
+	if err := tempUser.HashPassword(); err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	now := time.Now()
+	return bson.M{
+		"passwordHash":      tempUser.PasswordHash,
+		"passwordHistory":   model.AppendPasswordHistory(currentPasswordHistory(user), tempUser.PasswordHash, r.PasswordPolicy().HistorySize),
+		"passwordChangedAt": now,
+		"updatedAt":         now,
+	}, nil
+}
+
+// currentPasswordHistory gibt die Passwort-Historie inkl. des aktuellen Hashes zurück (neueste zuerst)
+func currentPasswordHistory(user *model.User) []string {
+	if user.PasswordHash == "" || (len(user.PasswordHistory) > 0 && user.PasswordHistory[0] == user.PasswordHash) {
+		return user.PasswordHistory
+	}
+	return append([]string{user.PasswordHash}, user.PasswordHistory...)
 }
 
 // Delete löscht einen Benutzer (soft delete)
@@ -279,6 +503,19 @@ func (r *UserRepository) FindByRole(role model.UserRole) ([]*model.User, error)
 	return users, nil
 }
 
+// FindByEmployeeID findet den aktiven Benutzer, der mit einem Mitarbeiter verknüpft ist
+func (r *UserRepository) FindByEmployeeID(employeeID primitive.ObjectID) (*model.User, error) {
+	var user model.User
+	err := r.FindOne(bson.M{"employeeId": employeeID, "status": model.StatusActive}, &user)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
 // EmailExists prüft, ob eine E-Mail bereits existiert
 func (r *UserRepository) EmailExists(email string) (bool, error) {
 	email = strings.ToLower(strings.TrimSpace(email))
@@ -0,0 +1,254 @@
+// backend/repository/webhookRepository.go
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"PeopleFlow/backend/db"
+	"PeopleFlow/backend/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Webhook-Repository-Fehler
+var (
+	ErrWebhookNotFound         = errors.New("webhook endpoint not found")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+)
+
+// WebhookEndpointRepository enthält alle Datenbankoperationen für Webhook-Endpunkte
+type WebhookEndpointRepository struct {
+	*BaseRepository
+	collection *mongo.Collection
+}
+
+// NewWebhookEndpointRepository erstellt ein neues WebhookEndpointRepository
+func NewWebhookEndpointRepository() *WebhookEndpointRepository {
+	collection := db.GetCollection("webhook_endpoints")
+	return &WebhookEndpointRepository{
+		BaseRepository: NewBaseRepository(collection),
+		collection:     collection,
+	}
+}
+
+// Create speichert einen neuen Endpunkt; das Secret muss bereits verschlüsselt sein
+func (r *WebhookEndpointRepository) Create(endpoint *model.WebhookEndpoint) error {
+	if err := endpoint.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrValidation, err)
+	}
+
+	now := time.Now()
+	endpoint.CreatedAt = now
+	endpoint.UpdatedAt = now
+
+	id, err := r.InsertOne(endpoint)
+	if err != nil {
+		return err
+	}
+
+	endpoint.ID = *id
+	return nil
+}
+
+// FindByID findet einen Endpunkt anhand seiner ID
+func (r *WebhookEndpointRepository) FindByID(id string) (*model.WebhookEndpoint, error) {
+	var endpoint model.WebhookEndpoint
+	if err := r.BaseRepository.FindByID(id, &endpoint); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	return &endpoint, nil
+}
+
+// FindAll findet alle Endpunkte
+func (r *WebhookEndpointRepository) FindAll() ([]*model.WebhookEndpoint, error) {
+	var endpoints []*model.WebhookEndpoint
+	opts := options.Find().SetSort(bson.M{"createdAt": -1})
+	if err := r.BaseRepository.FindAll(bson.M{}, &endpoints, opts); err != nil {
+		return nil, err
+	}
+	return endpoints, nil
+}
+
+// FindSubscribers findet alle aktiven Endpunkte, die ein Ereignis abonniert haben
+func (r *WebhookEndpointRepository) FindSubscribers(event model.ActivityType) ([]*model.WebhookEndpoint, error) {
+	var endpoints []*model.WebhookEndpoint
+	filter := bson.M{
+		"active": true,
+		"$or": []bson.M{
+			{"events": bson.M{"$size": 0}},
+			{"events": event},
+		},
+	}
+	if err := r.BaseRepository.FindAll(filter, &endpoints); err != nil {
+		return nil, err
+	}
+	return endpoints, nil
+}
+
+// Update aktualisiert einen Endpunkt
+func (r *WebhookEndpointRepository) Update(endpoint *model.WebhookEndpoint) error {
+	if err := endpoint.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrValidation, err)
+	}
+
+	endpoint.UpdatedAt = time.Now()
+	err := r.UpdateByID(endpoint.ID.Hex(), bson.M{"$set": bson.M{
+		"name":      endpoint.Name,
+		"url":       endpoint.URL,
+		"secret":    endpoint.Secret,
+		"events":    endpoint.Events,
+		"active":    endpoint.Active,
+		"updatedAt": endpoint.UpdatedAt,
+	}})
+	if errors.Is(err, ErrNotFound) {
+		return ErrWebhookNotFound
+	}
+	return err
+}
+
+// Delete löscht einen Endpunkt
+func (r *WebhookEndpointRepository) Delete(id string) error {
+	err := r.DeleteByID(id)
+	if errors.Is(err, ErrNotFound) {
+		return ErrWebhookNotFound
+	}
+	return err
+}
+
+// WebhookDeliveryRepository enthält alle Datenbankoperationen für das Zustellprotokoll
+type WebhookDeliveryRepository struct {
+	*BaseRepository
+	collection *mongo.Collection
+}
+
+// NewWebhookDeliveryRepository erstellt ein neues WebhookDeliveryRepository
+func NewWebhookDeliveryRepository() *WebhookDeliveryRepository {
+	collection := db.GetCollection("webhook_deliveries")
+	return &WebhookDeliveryRepository{
+		BaseRepository: NewBaseRepository(collection),
+		collection:     collection,
+	}
+}
+
+// Create speichert eine neue Zustellung
+func (r *WebhookDeliveryRepository) Create(delivery *model.WebhookDelivery) error {
+	delivery.CreatedAt = time.Now()
+	if delivery.Status == "" {
+		delivery.Status = model.WebhookDeliveryPending
+	}
+
+	id, err := r.InsertOne(delivery)
+	if err != nil {
+		return err
+	}
+
+	delivery.ID = *id
+	return nil
+}
+
+// FindByID findet eine Zustellung anhand ihrer ID
+func (r *WebhookDeliveryRepository) FindByID(id string) (*model.WebhookDelivery, error) {
+	var delivery model.WebhookDelivery
+	if err := r.BaseRepository.FindByID(id, &delivery); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, ErrWebhookDeliveryNotFound
+		}
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// FindByEndpoint findet die Zustellungen eines Endpunkts, neueste zuerst
+func (r *WebhookDeliveryRepository) FindByEndpoint(endpointID primitive.ObjectID, skip, limit int64) ([]*model.WebhookDelivery, int64, error) {
+	filter := bson.M{"endpointId": endpointID}
+
+	total, err := r.Count(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var deliveries []*model.WebhookDelivery
+	opts := options.Find().SetSort(bson.M{"createdAt": -1}).SetSkip(skip).SetLimit(limit)
+	if err := r.BaseRepository.FindAll(filter, &deliveries, opts); err != nil {
+		return nil, 0, err
+	}
+	return deliveries, total, nil
+}
+
+// ClaimDue reserviert eine fällige Zustellung für einen Versand.
+// Die Reservierung verschiebt nextAttemptAt, damit parallele Worker sie nicht doppelt senden.
+func (r *WebhookDeliveryRepository) ClaimDue(now time.Time, lease time.Duration) (*model.WebhookDelivery, error) {
+	ctx, cancel := r.GetContext()
+	defer cancel()
+
+	filter := bson.M{
+		"status":        model.WebhookDeliveryPending,
+		"nextAttemptAt": bson.M{"$lte": now},
+	}
+	update := bson.M{"$set": bson.M{"nextAttemptAt": now.Add(lease)}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.M{"nextAttemptAt": 1}).
+		SetReturnDocument(options.After)
+
+	var delivery model.WebhookDelivery
+	if err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&delivery); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, r.HandleError(ctx, err, "ClaimDue")
+	}
+	return &delivery, nil
+}
+
+// SaveAttempt speichert das Ergebnis eines Zustellversuchs
+func (r *WebhookDeliveryRepository) SaveAttempt(delivery *model.WebhookDelivery) error {
+	set := bson.M{
+		"status":         delivery.Status,
+		"attempts":       delivery.Attempts,
+		"responseStatus": delivery.ResponseStatus,
+		"responseBody":   delivery.ResponseBody,
+		"error":          delivery.Error,
+		"durationMs":     delivery.DurationMs,
+		"lastAttemptAt":  delivery.LastAttemptAt,
+	}
+	update := bson.M{"$set": set}
+	if delivery.NextAttemptAt != nil {
+		set["nextAttemptAt"] = delivery.NextAttemptAt
+	} else {
+		update["$unset"] = bson.M{"nextAttemptAt": ""}
+	}
+	if delivery.DeliveredAt != nil {
+		set["deliveredAt"] = delivery.DeliveredAt
+	}
+
+	err := r.UpdateByID(delivery.ID.Hex(), update)
+	if errors.Is(err, ErrNotFound) {
+		return ErrWebhookDeliveryNotFound
+	}
+	return err
+}
+
+// DeleteByEndpoint löscht das Zustellprotokoll eines Endpunkts
+func (r *WebhookDeliveryRepository) DeleteByEndpoint(endpointID primitive.ObjectID) error {
+	_, err := r.DeleteMany(bson.M{"endpointId": endpointID})
+	return err
+}
+
+// CreateIndexes erstellt erforderliche Indizes
+func (r *WebhookDeliveryRepository) CreateIndexes() error {
+	if err := r.CreateIndex(bson.M{"endpointId": 1, "createdAt": -1}, false); err != nil {
+		return fmt.Errorf("failed to create endpoint index: %w", err)
+	}
+	if err := r.CreateIndex(bson.M{"status": 1, "nextAttemptAt": 1}, false); err != nil {
+		return fmt.Errorf("failed to create due index: %w", err)
+	}
+	return nil
+}
@@ -106,6 +106,15 @@ func InitializeRoutes(router *gin.Engine) {
 	router.GET("/reset-password", passwordResetHandler.ShowPasswordResetForm)
 	router.POST("/api/auth/reset-password", passwordResetHandler.ResetPassword)
 
+	// Kontoaktivierung über Einladungslink
+	invitationHandler := handler.NewInvitationHandler()
+	router.GET("/activate", invitationHandler.ShowActivationForm)
+	router.POST("/api/auth/activate", invitationHandler.ActivateAccount)
+
+	// OpenAPI-Spezifikation aller /api-Routen (öffentlich zugänglich)
+	openAPIHandler := handler.NewOpenAPIHandler(router)
+	router.GET("/api/openapi.json", openAPIHandler.GetSpec)
+
 	// Auth middleware für geschützte Routen
 	authorized := router.Group("/")
 	authorized.Use(middleware.AuthMiddleware())
@@ -373,9 +382,16 @@ func InitializeRoutes(router *gin.Engine) {
 			// Services für Berechnungen
 			costService := service.NewCostService()
 
-			// Personalkosten
+			// Personalkosten aus der Gehaltshistorie (abgeschlossene Monate aus den Momentaufnahmen)
 			monthlyLaborCosts := costService.CalculateMonthlyLaborCosts(allEmployees)
-			monthlyCostsData := costService.GenerateMonthlyLaborCostsTrend(monthlyLaborCosts)
+			laborCostTrend, err := costService.LaborCostTrend(12, "", "", time.Now())
+			if err != nil {
+				laborCostTrend = model.LaborCostTrend{Costs: make([]model.LaborCost, 12)}
+			}
+			monthlyCostsData := laborCostTrend.Totals()
+			if len(laborCostTrend.Months) > 0 {
+				monthlyLaborCosts = laborCostTrend.Costs[len(laborCostTrend.Costs)-1].Total
+			}
 
 			// Überstunden-Daten sammeln
 			var totalOvertime float64 = 0
@@ -594,6 +610,8 @@ func InitializeRoutes(router *gin.Engine) {
 			adminData := gin.H{
 				"monthlyLaborCosts":          fmt.Sprintf("%.2f", monthlyLaborCosts),
 				"monthlyCostsData":           monthlyCostsData,
+				"laborCostDepartments":       laborCostTrend.Departments,
+				"laborCostLocations":         laborCostTrend.Locations,
 				"totalOvertime":              totalOvertime,
 				"overtimeEmployees":          overtimeEmployees,
 				"pendingAbsences":            pendingAbsences,
@@ -643,6 +661,148 @@ func InitializeRoutes(router *gin.Engine) {
 		// Benutzerprofilrouten
 		authorized.GET("/profile", userHandler.ShowUserProfile)
 
+		// In-App-Benachrichtigungen des angemeldeten Benutzers
+		notificationHandler := handler.NewNotificationHandler()
+		authorized.GET("/api/notifications", notificationHandler.ListNotifications)
+		authorized.GET("/api/notifications/unread-count", notificationHandler.UnreadCount)
+		authorized.POST("/api/notifications/read-all", notificationHandler.MarkAllRead)
+		authorized.POST("/api/notifications/:id/read", notificationHandler.MarkRead)
+		authorized.GET("/api/notifications/preferences", notificationHandler.GetPreferences)
+		authorized.PUT("/api/notifications/preferences", notificationHandler.UpdatePreferences)
+
+		// Berichte per E-Mail (Abonnements des angemeldeten Benutzers)
+		reportSubscriptionHandler := handler.NewReportSubscriptionHandler()
+		authorized.GET("/api/report-subscriptions", reportSubscriptionHandler.ListSubscriptions)
+		authorized.GET("/api/report-subscriptions/types", reportSubscriptionHandler.ListTypes)
+		authorized.POST("/api/report-subscriptions", reportSubscriptionHandler.CreateSubscription)
+		authorized.PUT("/api/report-subscriptions/:id", reportSubscriptionHandler.UpdateSubscription)
+		authorized.DELETE("/api/report-subscriptions/:id", reportSubscriptionHandler.DeleteSubscription)
+		authorized.POST("/api/report-subscriptions/:id/send", reportSubscriptionHandler.SendNow)
+
+		// API-Tokens für den skriptgesteuerten Zugriff
+		apiTokenHandler := handler.NewAPITokenHandler()
+		authorized.GET("/api/tokens", apiTokenHandler.ListMyTokens)
+		authorized.POST("/api/tokens", apiTokenHandler.CreateMyToken)
+		authorized.DELETE("/api/tokens/:id", apiTokenHandler.RevokeMyToken)
+		authorized.GET("/api/admin/tokens", middleware.RoleMiddleware(model.RoleAdmin), apiTokenHandler.ListAllTokens)
+		authorized.POST("/api/admin/tokens", middleware.RoleMiddleware(model.RoleAdmin), apiTokenHandler.CreateServiceToken)
+		authorized.DELETE("/api/admin/tokens/:id", middleware.RoleMiddleware(model.RoleAdmin), apiTokenHandler.RevokeToken)
+
+		// Ausgehende Webhooks (nur für Admins)
+		webhookHandler := handler.NewWebhookHandler()
+		authorized.GET("/api/webhooks", middleware.RoleMiddleware(model.RoleAdmin), webhookHandler.ListEndpoints)
+		authorized.POST("/api/webhooks", middleware.RoleMiddleware(model.RoleAdmin), webhookHandler.CreateEndpoint)
+		authorized.GET("/api/webhooks/events", middleware.RoleMiddleware(model.RoleAdmin), webhookHandler.ListEvents)
+		authorized.PUT("/api/webhooks/:id", middleware.RoleMiddleware(model.RoleAdmin), webhookHandler.UpdateEndpoint)
+		authorized.DELETE("/api/webhooks/:id", middleware.RoleMiddleware(model.RoleAdmin), webhookHandler.DeleteEndpoint)
+		authorized.POST("/api/webhooks/:id/rotate-secret", middleware.RoleMiddleware(model.RoleAdmin), webhookHandler.RotateSecret)
+		authorized.GET("/api/webhooks/:id/deliveries", middleware.RoleMiddleware(model.RoleAdmin), webhookHandler.ListDeliveries)
+		authorized.POST("/api/webhooks/:id/deliveries/:deliveryId/redeliver", middleware.RoleMiddleware(model.RoleAdmin), webhookHandler.Redeliver)
+
+		// Slack- und Teams-Benachrichtigungen (nur für Admins)
+		chatChannelHandler := handler.NewChatChannelHandler()
+		authorized.GET("/api/chat-channels", middleware.RoleMiddleware(model.RoleAdmin), chatChannelHandler.ListChannels)
+		authorized.POST("/api/chat-channels", middleware.RoleMiddleware(model.RoleAdmin), chatChannelHandler.CreateChannel)
+		authorized.GET("/api/chat-channels/events", middleware.RoleMiddleware(model.RoleAdmin), chatChannelHandler.ListEvents)
+		authorized.PUT("/api/chat-channels/:id", middleware.RoleMiddleware(model.RoleAdmin), chatChannelHandler.UpdateChannel)
+		authorized.DELETE("/api/chat-channels/:id", middleware.RoleMiddleware(model.RoleAdmin), chatChannelHandler.DeleteChannel)
+		authorized.POST("/api/chat-channels/:id/test", middleware.RoleMiddleware(model.RoleAdmin), chatChannelHandler.SendTest)
+
+		// E-Mail-Vorlagen (nur für Admins)
+		emailTemplateHandler := handler.NewEmailTemplateHandler()
+		authorized.GET("/api/email-templates", middleware.RoleMiddleware(model.RoleAdmin), emailTemplateHandler.ListTemplates)
+		authorized.GET("/api/email-templates/:key/:lang", middleware.RoleMiddleware(model.RoleAdmin), emailTemplateHandler.GetTemplate)
+		authorized.PUT("/api/email-templates/:key/:lang", middleware.RoleMiddleware(model.RoleAdmin), emailTemplateHandler.SaveTemplate)
+		authorized.DELETE("/api/email-templates/:key/:lang", middleware.RoleMiddleware(model.RoleAdmin), emailTemplateHandler.ResetTemplate)
+		authorized.POST("/api/email-templates/:key/:lang/preview", middleware.RoleMiddleware(model.RoleAdmin), emailTemplateHandler.PreviewTemplate)
+
+		// E-Mail-Postausgang (nur für Admins)
+		emailOutboxHandler := handler.NewEmailOutboxHandler()
+		authorized.GET("/api/email-outbox", middleware.RoleMiddleware(model.RoleAdmin), emailOutboxHandler.ListEmails)
+		authorized.GET("/api/email-outbox/:id", middleware.RoleMiddleware(model.RoleAdmin), emailOutboxHandler.GetEmail)
+		authorized.POST("/api/email-outbox/:id/retry", middleware.RoleMiddleware(model.RoleAdmin), emailOutboxHandler.RetryEmail)
+
+		// Hintergrundjobs (nur für Admins)
+		jobHandler := handler.NewJobHandler()
+		authorized.GET("/api/jobs", middleware.RoleMiddleware(model.RoleAdmin), jobHandler.ListJobs)
+		authorized.PUT("/api/jobs/:name", middleware.RoleMiddleware(model.RoleAdmin), jobHandler.UpdateJob)
+		authorized.GET("/api/jobs/:name/runs", middleware.RoleMiddleware(model.RoleAdmin), jobHandler.ListRuns)
+		authorized.POST("/api/jobs/:name/run", middleware.RoleMiddleware(model.RoleAdmin), jobHandler.RunJob)
+		authorized.POST("/api/jobs/:name/pause", middleware.RoleMiddleware(model.RoleAdmin), jobHandler.PauseJob)
+		authorized.POST("/api/jobs/:name/resume", middleware.RoleMiddleware(model.RoleAdmin), jobHandler.ResumeJob)
+
+		// Versionierte REST-API (JSON) für das Frontend und Skripte
+		apiV1Handler := handler.NewAPIV1Handler()
+		staff := apiV1Handler.RequireRoles(model.RoleAdmin, model.RoleManager, model.RoleHR)
+		approvers := apiV1Handler.RequireRoles(model.RoleAdmin, model.RoleManager)
+		admins := apiV1Handler.RequireRoles(model.RoleAdmin)
+
+		v1 := authorized.Group("/api/v1")
+		{
+			// Mitarbeiter
+			v1.GET("/employees", apiV1Handler.ListEmployees)
+			v1.POST("/employees", staff, apiV1Handler.CreateEmployee)
+			v1.GET("/employees/:id", apiV1Handler.GetEmployee)
+			v1.PATCH("/employees/:id", staff, apiV1Handler.UpdateEmployee)
+			v1.DELETE("/employees/:id", staff, apiV1Handler.DeleteEmployee)
+
+			// Abwesenheiten
+			v1.GET("/absences", apiV1Handler.ListAbsences)
+			v1.GET("/employees/:id/absences", apiV1Handler.ListEmployeeAbsences)
+			v1.POST("/employees/:id/absences", apiV1Handler.CreateAbsence)
+			v1.PATCH("/employees/:id/absences/:absenceId/status", apiV1Handler.UpdateAbsenceStatus)
+			v1.DELETE("/employees/:id/absences/:absenceId", staff, apiV1Handler.DeleteAbsence)
+
+			// Zeiteinträge und Überstunden
+			v1.GET("/time-entries", apiV1Handler.ListTimeEntries)
+			v1.GET("/employees/:id/time-entries", apiV1Handler.ListEmployeeTimeEntries)
+			v1.GET("/employees/:id/overtime", apiV1Handler.GetEmployeeOvertime)
+			v1.GET("/employees/:id/overtime/adjustments", apiV1Handler.ListEmployeeOvertimeAdjustments)
+			v1.POST("/employees/:id/overtime/adjustments", staff, apiV1Handler.CreateOvertimeAdjustment)
+			v1.GET("/overtime/adjustments/pending", approvers, apiV1Handler.ListPendingOvertimeAdjustments)
+			v1.PATCH("/overtime/adjustments/:adjustmentId/status", approvers, apiV1Handler.UpdateOvertimeAdjustmentStatus)
+
+			// Dokumente
+			v1.GET("/employees/:id/documents", apiV1Handler.ListEmployeeDocuments)
+			v1.POST("/employees/:id/documents", staff, apiV1Handler.UploadEmployeeDocument)
+			v1.GET("/employees/:id/documents/:documentId/download", apiV1Handler.DownloadEmployeeDocument)
+			v1.DELETE("/employees/:id/documents/:documentId", staff, apiV1Handler.DeleteEmployeeDocument)
+
+			// Weiterbildungen, Beurteilungen und Gespräche
+			v1.GET("/employees/:id/trainings", apiV1Handler.ListEmployeeTrainings)
+			v1.POST("/employees/:id/trainings", staff, apiV1Handler.CreateEmployeeTraining)
+			v1.PATCH("/employees/:id/trainings/:trainingId", staff, apiV1Handler.UpdateEmployeeTraining)
+			v1.DELETE("/employees/:id/trainings/:trainingId", staff, apiV1Handler.DeleteEmployeeTraining)
+			v1.GET("/employees/:id/evaluations", apiV1Handler.ListEmployeeEvaluations)
+			v1.POST("/employees/:id/evaluations", staff, apiV1Handler.CreateEmployeeEvaluation)
+			v1.PATCH("/employees/:id/evaluations/:evaluationId", staff, apiV1Handler.UpdateEmployeeEvaluation)
+			v1.DELETE("/employees/:id/evaluations/:evaluationId", staff, apiV1Handler.DeleteEmployeeEvaluation)
+			v1.GET("/conversations", apiV1Handler.ListConversations)
+			v1.GET("/employees/:id/conversations", apiV1Handler.ListEmployeeConversations)
+			v1.POST("/employees/:id/conversations", staff, apiV1Handler.CreateEmployeeConversation)
+			v1.PATCH("/employees/:id/conversations/:conversationId", staff, apiV1Handler.UpdateEmployeeConversation)
+			v1.DELETE("/employees/:id/conversations/:conversationId", staff, apiV1Handler.DeleteEmployeeConversation)
+
+			// Benutzer
+			v1.GET("/users/me", apiV1Handler.GetCurrentUser)
+			v1.GET("/users", approvers, apiV1Handler.ListUsers)
+			v1.POST("/users", admins, apiV1Handler.CreateUser)
+			v1.GET("/users/:id", approvers, apiV1Handler.GetUser)
+			v1.PATCH("/users/:id", admins, apiV1Handler.UpdateUser)
+			v1.DELETE("/users/:id", admins, apiV1Handler.DeleteUser)
+
+			// System-Einstellungen
+			v1.GET("/settings", apiV1Handler.GetSettings)
+			v1.PATCH("/settings", admins, apiV1Handler.UpdateSettings)
+		}
+
+		// Unbekannte Pfade der REST-API erhalten das einheitliche Fehlerformat
+		router.NoRoute(func(c *gin.Context) {
+			if middleware.IsAPIv1Path(c.Request.URL.Path) {
+				apiV1Handler.NotFound(c)
+			}
+		})
+
 		// Einstellungsrouten (für alle Benutzer)
 		authorized.GET("/settings", userHandler.ShowSettings)
 
@@ -657,6 +817,13 @@ func InitializeRoutes(router *gin.Engine) {
 		authorized.POST("/api/settings/email", middleware.RoleMiddleware(model.RoleAdmin), systemSettingsHandler.UpdateEmailSettings)
 		authorized.GET("/api/settings/email/test", middleware.RoleMiddleware(model.RoleAdmin), systemSettingsHandler.TestEmailConfiguration)
 
+		// Passwortrichtlinie (nur für Admins)
+		passwordPolicyHandler := handler.NewPasswordPolicyHandler()
+		authorized.GET("/api/settings/password-policy", middleware.RoleMiddleware(model.RoleAdmin), passwordPolicyHandler.GetPasswordPolicy)
+		authorized.POST("/api/settings/password-policy", middleware.RoleMiddleware(model.RoleAdmin), passwordPolicyHandler.UpdatePasswordPolicy)
+		authorized.POST("/api/settings/password-policy/breached", middleware.RoleMiddleware(model.RoleAdmin), passwordPolicyHandler.UploadBreachedPasswords)
+		authorized.DELETE("/api/settings/password-policy/breached", middleware.RoleMiddleware(model.RoleAdmin), passwordPolicyHandler.ClearBreachedPasswords)
+
 		// Feiertags-API Routen
 		authorized.GET("/api/holidays", holidayHandler.GetHolidays)
 		authorized.GET("/api/holidays/check", holidayHandler.CheckHoliday)
@@ -670,6 +837,7 @@ func InitializeRoutes(router *gin.Engine) {
 		authorized.GET("/users/edit/:id", middleware.RoleMiddleware(model.RoleAdmin, model.RoleManager), middleware.HRMiddleware(), userHandler.ShowEditUserForm)
 		authorized.POST("/users/edit/:id", middleware.RoleMiddleware(model.RoleAdmin, model.RoleManager), middleware.HRMiddleware(), userHandler.UpdateUser)
 		authorized.DELETE("/users/delete/:id", middleware.RoleMiddleware(model.RoleAdmin), middleware.HRMiddleware(), userHandler.DeleteUser)
+		authorized.POST("/users/:id/invite/resend", middleware.RoleMiddleware(model.RoleAdmin), invitationHandler.ResendInvitation)
 
 		// Passwortänderungsroute
 		authorized.POST("/users/change-password", middleware.SelfOrAdminMiddleware(), userHandler.ChangePassword)
@@ -745,27 +913,70 @@ func InitializeRoutes(router *gin.Engine) {
 		// Integrations-Handler
 		integrationHandler := handler.NewIntegrationHandler()
 
-		// API-Endpunkte für Integrationen
-		authorized.POST("/api/integrations/timebutler/save", middleware.RoleMiddleware(model.RoleAdmin), integrationHandler.SaveTimebutlerApiKey)
+		// API-Endpunkte für Integrationen (:type ist ein registrierter Anbieter, z.B. timebutler oder 123erfasst)
 		authorized.GET("/api/integrations/status", integrationHandler.GetIntegrationStatus)
-		authorized.GET("/api/integrations/timebutler/test", integrationHandler.TestTimebutlerConnection)
-		authorized.POST("/api/integrations/timebutler/sync/users", middleware.RoleMiddleware(model.RoleAdmin, model.RoleHR), integrationHandler.SyncTimebutlerUsers)
-		authorized.POST("/api/integrations/timebutler/sync/absences", middleware.RoleMiddleware(model.RoleAdmin, model.RoleHR), integrationHandler.SyncTimebutlerAbsences)
-		authorized.POST("/api/integrations/timebutler/sync/holidayentitlements", middleware.RoleMiddleware(model.RoleAdmin, model.RoleHR), integrationHandler.SyncTimebutlerHolidayEntitlements)
+		authorized.POST("/api/integrations/:type/save", middleware.RoleMiddleware(model.RoleAdmin), integrationHandler.SaveIntegration)
+		authorized.GET("/api/integrations/:type/test", integrationHandler.TestIntegrationConnection)
+		authorized.POST("/api/integrations/:type/remove", middleware.RoleMiddleware(model.RoleAdmin), integrationHandler.RemoveIntegration)
+		authorized.POST("/api/integrations/:type/sync/:capability", middleware.RoleMiddleware(model.RoleAdmin, model.RoleHR), integrationHandler.SyncIntegration)
+		authorized.POST("/api/integrations/:type/full-sync", middleware.RoleMiddleware(model.RoleAdmin, model.RoleHR), integrationHandler.FullSyncIntegration)
+		authorized.GET("/api/integrations/:type/matching", middleware.RoleMiddleware(model.RoleAdmin, model.RoleHR), integrationHandler.GetIdentityMatches)
+		authorized.POST("/api/integrations/:type/matching", middleware.RoleMiddleware(model.RoleAdmin, model.RoleHR), integrationHandler.ConfirmIdentityMatch)
+		authorized.POST("/api/integrations/:type/matching/create-employee", middleware.RoleMiddleware(model.RoleAdmin, model.RoleHR), integrationHandler.CreateEmployeeFromIdentity)
+		authorized.DELETE("/api/integrations/:type/matching/:externalId", middleware.RoleMiddleware(model.RoleAdmin, model.RoleHR), integrationHandler.RemoveIdentityMatch)
+		authorized.GET("/api/integrations/:type/field-ownership", middleware.RoleMiddleware(model.RoleAdmin, model.RoleHR), integrationHandler.GetFieldOwnership)
+		authorized.POST("/api/integrations/:type/field-ownership", middleware.RoleMiddleware(model.RoleAdmin), integrationHandler.SetFieldOwnership)
+		authorized.GET("/api/integrations/:type/conflicts", middleware.RoleMiddleware(model.RoleAdmin, model.RoleHR), integrationHandler.GetFieldConflicts)
+		authorized.POST("/api/integrations/conflicts/:id/resolve", middleware.RoleMiddleware(model.RoleAdmin, model.RoleHR), integrationHandler.ResolveFieldConflict)
+
+		// API-Endpunkte für Timebutler
+		authorized.POST("/api/integrations/timebutler/set-absence-push", middleware.RoleMiddleware(model.RoleAdmin), integrationHandler.SetTimebutlerAbsencePush)
 
 		// API-Endpunkte für 123Erfasst
-		authorized.POST("/api/integrations/123erfasst/save", middleware.RoleMiddleware(model.RoleAdmin), integrationHandler.SaveErfasst123Credentials)
-		authorized.GET("/api/integrations/123erfasst/test", integrationHandler.TestErfasst123Connection)
-		authorized.POST("/api/integrations/123erfasst/sync/projects", middleware.RoleMiddleware(model.RoleAdmin, model.RoleHR), integrationHandler.SyncErfasst123Projects)
-		authorized.POST("/api/integrations/123erfasst/remove", middleware.RoleMiddleware(model.RoleAdmin), integrationHandler.RemoveErfasst123Integration)
-		authorized.POST("/api/integrations/123erfasst/sync/times", middleware.RoleMiddleware(model.RoleAdmin, model.RoleHR), integrationHandler.SyncErfasst123TimeEntries)
 		authorized.GET("/api/integrations/123erfasst/sync-status", integrationHandler.GetErfasst123SyncStatus)
 		authorized.POST("/api/integrations/123erfasst/set-auto-sync", middleware.RoleMiddleware(model.RoleAdmin), integrationHandler.SetErfasst123AutoSync)
 		authorized.POST("/api/integrations/123erfasst/set-sync-start-date", middleware.RoleMiddleware(model.RoleAdmin), integrationHandler.SetErfasst123SyncStartDate)
-		authorized.POST("/api/integrations/123erfasst/full-sync", middleware.RoleMiddleware(model.RoleAdmin, model.RoleHR), integrationHandler.TriggerErfasst123FullSync)
-		authorized.POST("/api/integrations/123erfasst/sync/employees", middleware.RoleMiddleware(model.RoleAdmin, model.RoleHR), integrationHandler.SyncErfasst123Employees)
 		authorized.POST("/api/integrations/123erfasst/cleanup-duplicates", middleware.RoleMiddleware(model.RoleAdmin), integrationHandler.CleanupDuplicates)
 		authorized.POST("/api/integrations/123erfasst/test-projects", middleware.RoleMiddleware(model.RoleAdmin), integrationHandler.TestErfasst123ProjectAPI)
+		authorized.GET("/api/integrations/sync-jobs", middleware.RoleMiddleware(model.RoleAdmin, model.RoleHR), integrationHandler.ListSyncJobs)
+		authorized.GET("/api/integrations/sync-jobs/:id", middleware.RoleMiddleware(model.RoleAdmin, model.RoleHR), integrationHandler.GetSyncJob)
+		authorized.GET("/api/integrations/sync-jobs/:id/events", middleware.RoleMiddleware(model.RoleAdmin, model.RoleHR), integrationHandler.StreamSyncJob)
+		authorized.POST("/api/integrations/sync-jobs/:id/apply", middleware.RoleMiddleware(model.RoleAdmin, model.RoleHR), integrationHandler.ApplySyncPreview)
+
+		// DATEV-Lohnexport (Admins und HR; Einstellungen speichern nur Admins)
+		datevHandler := handler.NewDatevHandler()
+		authorized.GET("/api/payroll/datev/settings", middleware.RoleMiddleware(model.RoleAdmin, model.RoleHR), datevHandler.GetSettings)
+		authorized.POST("/api/payroll/datev/settings", middleware.RoleMiddleware(model.RoleAdmin), datevHandler.UpdateSettings)
+		authorized.GET("/api/payroll/datev/preview", middleware.RoleMiddleware(model.RoleAdmin, model.RoleHR), datevHandler.PreviewExport)
+		authorized.GET("/api/payroll/datev/export", middleware.RoleMiddleware(model.RoleAdmin, model.RoleHR), datevHandler.DownloadExport)
+
+		// Mitarbeiterimport aus CSV/XLSX (Finanzdaten nur mit Gehaltseinsicht)
+		employeeImportHandler := handler.NewEmployeeImportHandler()
+		authorized.GET("/api/employees/import/fields", middleware.SalaryViewMiddleware(), middleware.RoleMiddleware(model.RoleAdmin, model.RoleManager, model.RoleHR), employeeImportHandler.GetFields)
+		authorized.POST("/api/employees/import", middleware.SalaryViewMiddleware(), middleware.RoleMiddleware(model.RoleAdmin, model.RoleManager, model.RoleHR), employeeImportHandler.ImportEmployees)
+
+		// Mitarbeiterexport als CSV/XLSX/JSON (Finanzdaten nur mit Gehaltseinsicht)
+		employeeExportHandler := handler.NewEmployeeExportHandler()
+		authorized.GET("/api/employees/export/columns", middleware.SalaryViewMiddleware(), middleware.RoleMiddleware(model.RoleAdmin, model.RoleManager, model.RoleHR), employeeExportHandler.GetColumns)
+		authorized.GET("/api/employees/export", middleware.SalaryViewMiddleware(), middleware.RoleMiddleware(model.RoleAdmin, model.RoleManager, model.RoleHR), employeeExportHandler.ExportEmployees)
+
+		// Monatliche Stundenzettel als PDF mit Bestätigung durch Mitarbeiter und Vorgesetzte
+		timesheetHandler := handler.NewTimesheetHandler()
+		authorized.GET("/api/timesheets/employees/:id", timesheetHandler.GetTimesheet)
+		authorized.GET("/api/timesheets/employees/:id/pdf", timesheetHandler.DownloadTimesheet)
+		authorized.POST("/api/timesheets/employees/:id/acknowledge", timesheetHandler.AcknowledgeTimesheet)
+		authorized.POST("/api/timesheets/employees/:id/approve", middleware.RoleMiddleware(model.RoleAdmin, model.RoleManager), timesheetHandler.ApproveTimesheet)
+		authorized.GET("/api/timesheets/department", middleware.RoleMiddleware(model.RoleAdmin, model.RoleManager, model.RoleHR), timesheetHandler.DownloadDepartmentTimesheets)
+
+		// Gehaltshistorie, Sonderzahlungen und Personalkosten-Verlauf (nur mit Gehaltseinsicht)
+		laborCostHandler := handler.NewLaborCostHandler()
+		authorized.GET("/api/employees/:id/salary-history", middleware.RoleMiddleware(model.RoleAdmin, model.RoleManager), laborCostHandler.GetSalaryHistory)
+		authorized.POST("/api/employees/:id/salary-history", middleware.RoleMiddleware(model.RoleAdmin, model.RoleManager), laborCostHandler.AddSalaryChange)
+		authorized.DELETE("/api/employees/:id/salary-history/:entryId", middleware.RoleMiddleware(model.RoleAdmin, model.RoleManager), laborCostHandler.DeleteSalaryChange)
+		authorized.POST("/api/employees/:id/bonuses", middleware.RoleMiddleware(model.RoleAdmin, model.RoleManager), laborCostHandler.AddBonus)
+		authorized.DELETE("/api/employees/:id/bonuses/:bonusId", middleware.RoleMiddleware(model.RoleAdmin, model.RoleManager), laborCostHandler.DeleteBonus)
+		authorized.GET("/api/labor-costs", middleware.RoleMiddleware(model.RoleAdmin, model.RoleManager), laborCostHandler.GetLaborCosts)
+		authorized.POST("/api/labor-costs/snapshots", middleware.RoleMiddleware(model.RoleAdmin), laborCostHandler.CreateLaborCostSnapshot)
 
 		// Optionale API-Endpoints für AJAX-Anfragen
 		api := router.Group("/api")
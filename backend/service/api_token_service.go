@@ -0,0 +1,219 @@
+// backend/service/api_token_service.go
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// API-Token-Fehler
+var (
+	ErrAPITokenInvalid   = errors.New("API token is invalid, expired or revoked")
+	ErrAPITokenScope     = errors.New("API token lacks the required scope")
+	ErrAPITokenForbidden = errors.New("not allowed to manage this API token")
+	ErrAPITokenValidity  = errors.New("invalid token validity")
+
+	ErrAPITokenPasswordExpired = errors.New("password of the token owner has expired")
+)
+
+// DefaultAPITokenValidityDays ist die Standard-Gültigkeit neuer Tokens
+const DefaultAPITokenValidityDays = 90
+
+// APITokenService verwaltet persönliche und Service-Account-Tokens
+type APITokenService struct {
+	tokenRepo *repository.APITokenRepository
+	userRepo  *repository.UserRepository
+}
+
+// NewAPITokenService erstellt einen neuen APITokenService
+func NewAPITokenService() *APITokenService {
+	return &APITokenService{
+		tokenRepo: repository.NewAPITokenRepository(),
+		userRepo:  repository.NewUserRepository(),
+	}
+}
+
+// CreatePersonalToken erstellt ein Token für den angegebenen Benutzer.
+// Das Klartext-Token wird nur einmal zurückgegeben.
+func (s *APITokenService) CreatePersonalToken(user *model.User, name string, scopes []string, validityDays int) (string, *model.APIToken, error) {
+	parsed, err := model.ParseScopes(scopes)
+	if err != nil {
+		return "", nil, err
+	}
+	if containsScope(parsed, model.APITokenScopeAdmin) && !user.IsAdmin() {
+		return "", nil, fmt.Errorf("%w: admin scope requires the admin role", model.ErrInvalidTokenScope)
+	}
+
+	userID := user.ID
+	token := &model.APIToken{
+		Name:      strings.TrimSpace(name),
+		Type:      model.APITokenTypePersonal,
+		UserID:    &userID,
+		UserName:  user.FirstName + " " + user.LastName,
+		Scopes:    parsed,
+		CreatedBy: user.ID,
+	}
+	return s.create(token, validityDays)
+}
+
+// CreateServiceToken erstellt ein Service-Account-Token mit fester Rolle
+func (s *APITokenService) CreateServiceToken(createdBy *model.User, name string, role model.UserRole, scopes []string, validityDays int) (string, *model.APIToken, error) {
+	parsed, err := model.ParseScopes(scopes)
+	if err != nil {
+		return "", nil, err
+	}
+
+	token := &model.APIToken{
+		Name:      strings.TrimSpace(name),
+		Type:      model.APITokenTypeService,
+		Role:      role,
+		Scopes:    parsed,
+		CreatedBy: createdBy.ID,
+	}
+	return s.create(token, validityDays)
+}
+
+// apiTokenStore ist der Teil des APITokenRepository, den die Authentifizierung benötigt
+type apiTokenStore interface {
+	FindByToken(plainToken string) (*model.APIToken, error)
+	TouchLastUsed(token *model.APIToken, ip string) error
+}
+
+// apiTokenOwners ist der Teil des UserRepository, den die Authentifizierung benötigt
+type apiTokenOwners interface {
+	FindByID(id string) (*model.User, error)
+	IsPasswordExpired(user *model.User) bool
+}
+
+// Authenticate prüft ein Klartext-Token für eine Anfrage und liefert Token und Benutzerkontext.
+// Hat das Token keinen admin-Scope, wird ein Admin-Konto wie ein Manager behandelt, damit auch
+// Rollenprüfungen außerhalb von RoleMiddleware keine Admin-Rechte gewähren.
+func (s *APITokenService) Authenticate(plainToken, method, clientIP string) (*model.APIToken, *model.User, error) {
+	return authenticateAPIToken(s.tokenRepo, s.userRepo, plainToken, method, clientIP)
+}
+
+// authenticateAPIToken enthält die Prüfungen von Authenticate
+func authenticateAPIToken(tokens apiTokenStore, owners apiTokenOwners, plainToken, method, clientIP string) (*model.APIToken, *model.User, error) {
+	token, err := tokens.FindByToken(plainToken)
+	if err != nil {
+		if errors.Is(err, repository.ErrAPITokenNotFound) {
+			return nil, nil, ErrAPITokenInvalid
+		}
+		return nil, nil, err
+	}
+	if !token.IsUsable() {
+		return nil, nil, ErrAPITokenInvalid
+	}
+
+	var user *model.User
+	if token.Type == model.APITokenTypeService {
+		user = token.ServiceAccountUser()
+	} else {
+		if token.UserID == nil {
+			return nil, nil, ErrAPITokenInvalid
+		}
+		user, err = owners.FindByID(token.UserID.Hex())
+		if err != nil || user.Status != model.StatusActive {
+			return nil, nil, ErrAPITokenInvalid
+		}
+	}
+
+	if !token.AllowsMethod(method) {
+		return token, user, ErrAPITokenScope
+	}
+
+	// Persönliche Tokens unterliegen wie der Benutzer selbst dem Passwortablauf
+	if token.Type == model.APITokenTypePersonal && owners.IsPasswordExpired(user) {
+		return token, user, ErrAPITokenPasswordExpired
+	}
+
+	if err := tokens.TouchLastUsed(token, clientIP); err != nil {
+		log.Printf("Fehler beim Aktualisieren der letzten Token-Nutzung: %v", err)
+	}
+
+	if user.IsAdmin() && !token.HasScope(model.APITokenScopeAdmin) {
+		restricted := *user
+		restricted.Role = model.RoleManager
+		user = &restricted
+	}
+	return token, user, nil
+}
+
+// ListForUser gibt die persönlichen Tokens eines Benutzers zurück
+func (s *APITokenService) ListForUser(user *model.User) ([]*model.APIToken, error) {
+	return s.tokenRepo.FindByUserID(user.ID)
+}
+
+// ListAll gibt alle Tokens zurück (nur für Admins)
+func (s *APITokenService) ListAll() ([]*model.APIToken, error) {
+	return s.tokenRepo.FindAll()
+}
+
+// RevokeOwn widerruft ein persönliches Token des Benutzers
+func (s *APITokenService) RevokeOwn(user *model.User, tokenID string) (*model.APIToken, error) {
+	token, err := s.tokenRepo.FindByID(tokenID)
+	if err != nil {
+		return nil, err
+	}
+	if token.UserID == nil || *token.UserID != user.ID {
+		return nil, ErrAPITokenForbidden
+	}
+	return token, s.tokenRepo.Revoke(token.ID, user.FirstName+" "+user.LastName)
+}
+
+// Revoke widerruft ein beliebiges Token (nur für Admins)
+func (s *APITokenService) Revoke(admin *model.User, tokenID string) (*model.APIToken, error) {
+	token, err := s.tokenRepo.FindByID(tokenID)
+	if err != nil {
+		return nil, err
+	}
+	return token, s.tokenRepo.Revoke(token.ID, admin.FirstName+" "+admin.LastName)
+}
+
+// RevokeAllForUser widerruft alle Tokens eines Benutzers, z.B. beim Löschen des Kontos
+func (s *APITokenService) RevokeAllForUser(userID primitive.ObjectID, revokedBy string) error {
+	return s.tokenRepo.RevokeAllByUserID(userID, revokedBy)
+}
+
+// create setzt Ablaufdatum und Klartext-Token und speichert das Token
+func (s *APITokenService) create(token *model.APIToken, validityDays int) (string, *model.APIToken, error) {
+	if validityDays == 0 {
+		validityDays = DefaultAPITokenValidityDays
+	}
+	if validityDays < 1 || validityDays > model.MaxAPITokenValidityDays {
+		return "", nil, fmt.Errorf("%w: must be between 1 and %d days", ErrAPITokenValidity, model.MaxAPITokenValidityDays)
+	}
+
+	secret, err := generateSecureToken()
+	if err != nil {
+		return "", nil, fmt.Errorf("fehler beim Generieren des Tokens: %w", err)
+	}
+	plainToken := model.APITokenPrefix + secret
+
+	expiresAt := time.Now().AddDate(0, 0, validityDays)
+	token.ExpiresAt = &expiresAt
+	token.Prefix = plainToken[:len(model.APITokenPrefix)+8]
+
+	if err := s.tokenRepo.Create(token, plainToken); err != nil {
+		return "", nil, err
+	}
+	return plainToken, token, nil
+}
+
+// containsScope prüft, ob ein Scope in der Liste enthalten ist
+func containsScope(scopes []model.APITokenScope, scope model.APITokenScope) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
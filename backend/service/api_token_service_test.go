@@ -0,0 +1,133 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// fakeAPITokenStore kennt genau ein Token und zählt die Nutzungen
+type fakeAPITokenStore struct {
+	token   *model.APIToken
+	touched int
+}
+
+func (f *fakeAPITokenStore) FindByToken(plainToken string) (*model.APIToken, error) {
+	if f.token == nil || plainToken != "pf_test" {
+		return nil, repository.ErrAPITokenNotFound
+	}
+	return f.token, nil
+}
+
+func (f *fakeAPITokenStore) TouchLastUsed(*model.APIToken, string) error {
+	f.touched++
+	return nil
+}
+
+// fakeAPITokenOwners liefert den Besitzer persönlicher Tokens
+type fakeAPITokenOwners struct {
+	user            *model.User
+	passwordExpired bool
+}
+
+func (f *fakeAPITokenOwners) FindByID(id string) (*model.User, error) {
+	if f.user == nil || f.user.ID.Hex() != id {
+		return nil, repository.ErrUserNotFound
+	}
+	return f.user, nil
+}
+
+func (f *fakeAPITokenOwners) IsPasswordExpired(*model.User) bool { return f.passwordExpired }
+
+func personalAPIToken(owner *model.User, scopes ...model.APITokenScope) *model.APIToken {
+	return &model.APIToken{ID: primitive.NewObjectID(), Type: model.APITokenTypePersonal, UserID: &owner.ID, Scopes: scopes}
+}
+
+func TestAuthenticateAPIToken(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	owner := &model.User{ID: primitive.NewObjectID(), Role: model.RoleHR, Status: model.StatusActive}
+
+	tests := []struct {
+		name    string
+		token   *model.APIToken
+		method  string
+		wantErr error
+	}{
+		{"lesendes Token liest", personalAPIToken(owner, model.APITokenScopeRead), http.MethodGet, nil},
+		{"lesendes Token ändert nicht", personalAPIToken(owner, model.APITokenScopeRead), http.MethodPost, ErrAPITokenScope},
+		{"schreibendes Token liest auch", personalAPIToken(owner, model.APITokenScopeWrite), http.MethodGet, nil},
+		{"schreibendes Token ändert", personalAPIToken(owner, model.APITokenScopeWrite), http.MethodDelete, nil},
+		{"widerrufenes Token", func() *model.APIToken {
+			token := personalAPIToken(owner, model.APITokenScopeRead)
+			token.RevokedAt = &past
+			return token
+		}(), http.MethodGet, ErrAPITokenInvalid},
+		{"abgelaufenes Token", func() *model.APIToken {
+			token := personalAPIToken(owner, model.APITokenScopeRead)
+			token.ExpiresAt = &past
+			return token
+		}(), http.MethodGet, ErrAPITokenInvalid},
+		{"unbekanntes Token", nil, http.MethodGet, ErrAPITokenInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens := &fakeAPITokenStore{token: tt.token}
+			_, user, err := authenticateAPIToken(tokens, &fakeAPITokenOwners{user: owner}, "pf_test", tt.method, "10.0.0.1")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Zero(t, tokens.touched, "fehlgeschlagene Anfragen zählen nicht als Nutzung")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, owner, user)
+			assert.Equal(t, 1, tokens.touched)
+		})
+	}
+}
+
+func TestAuthenticateAPIToken_InactiveOrExpiredOwner(t *testing.T) {
+	owner := &model.User{ID: primitive.NewObjectID(), Role: model.RoleEmployee, Status: model.StatusInactive}
+	token := personalAPIToken(owner, model.APITokenScopeRead)
+
+	_, _, err := authenticateAPIToken(&fakeAPITokenStore{token: token}, &fakeAPITokenOwners{user: owner}, "pf_test", http.MethodGet, "")
+	assert.ErrorIs(t, err, ErrAPITokenInvalid)
+
+	owner.Status = model.StatusActive
+	_, _, err = authenticateAPIToken(&fakeAPITokenStore{token: token}, &fakeAPITokenOwners{user: owner, passwordExpired: true}, "pf_test", http.MethodGet, "")
+	assert.ErrorIs(t, err, ErrAPITokenPasswordExpired)
+}
+
+func TestAuthenticateAPIToken_ServiceTokenUsesTokenRole(t *testing.T) {
+	token := &model.APIToken{ID: primitive.NewObjectID(), Name: "Lohnbuchhaltung", Type: model.APITokenTypeService, Role: model.RoleHR, Scopes: []model.APITokenScope{model.APITokenScopeRead}}
+
+	// Service-Tokens haben keinen Besitzer; abgelaufene Passwörter spielen keine Rolle
+	_, user, err := authenticateAPIToken(&fakeAPITokenStore{token: token}, &fakeAPITokenOwners{passwordExpired: true}, "pf_test", http.MethodGet, "")
+
+	require.NoError(t, err)
+	assert.Equal(t, model.RoleHR, user.Role)
+	assert.Equal(t, token.ID, user.ID)
+	assert.Equal(t, "Service-Account Lohnbuchhaltung", user.FirstName+" "+user.LastName)
+}
+
+func TestAuthenticateAPIToken_AdminNeedsAdminScope(t *testing.T) {
+	admin := &model.User{ID: primitive.NewObjectID(), Role: model.RoleAdmin, Status: model.StatusActive}
+
+	_, user, err := authenticateAPIToken(&fakeAPITokenStore{token: personalAPIToken(admin, model.APITokenScopeRead, model.APITokenScopeWrite)},
+		&fakeAPITokenOwners{user: admin}, "pf_test", http.MethodPost, "")
+	require.NoError(t, err)
+	assert.Equal(t, model.RoleManager, user.Role, "ohne admin-Scope wie ein Manager")
+	assert.Equal(t, model.RoleAdmin, admin.Role, "der gespeicherte Benutzer bleibt unverändert")
+
+	_, user, err = authenticateAPIToken(&fakeAPITokenStore{token: personalAPIToken(admin, model.APITokenScopeRead, model.APITokenScopeAdmin)},
+		&fakeAPITokenOwners{user: admin}, "pf_test", http.MethodGet, "")
+	require.NoError(t, err)
+	assert.Equal(t, model.RoleAdmin, user.Role)
+}
@@ -0,0 +1,423 @@
+// backend/service/chat_notification_service.go
+package service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+	"PeopleFlow/backend/utils"
+)
+
+// chatTimeout begrenzt die Wartezeit auf Slack bzw. Teams
+const chatTimeout = 10 * time.Second
+
+// Chat-Benachrichtigungsfehler
+var (
+	ErrChatDeliveryFailed = errors.New("chat notification could not be delivered")
+)
+
+// ChatNotificationService verschickt Benachrichtigungen an Slack- und Teams-Kanäle
+type ChatNotificationService struct {
+	channelRepo  *repository.ChatChannelRepository
+	employeeRepo *repository.EmployeeRepository
+	client       *http.Client
+}
+
+// NewChatNotificationService erstellt einen neuen ChatNotificationService
+func NewChatNotificationService() *ChatNotificationService {
+	return &ChatNotificationService{
+		channelRepo:  repository.NewChatChannelRepository(),
+		employeeRepo: repository.NewEmployeeRepository(),
+		client:       &http.Client{Timeout: chatTimeout},
+	}
+}
+
+// ChatChannelInput enthält die vom Admin bearbeitbaren Felder eines Kanals
+type ChatChannelInput struct {
+	Name        string
+	Provider    string
+	WebhookURL  string // Leer beim Bearbeiten = bisherige URL behalten
+	Events      []string
+	Departments []string
+	Active      bool
+}
+
+// CreateChannel legt einen neuen Kanal an
+func (s *ChatNotificationService) CreateChannel(createdBy *model.User, input ChatChannelInput) (*model.ChatChannel, error) {
+	channel := &model.ChatChannel{CreatedBy: createdBy.ID}
+	if err := s.apply(channel, input, true); err != nil {
+		return nil, err
+	}
+	if err := s.channelRepo.Create(channel); err != nil {
+		return nil, err
+	}
+	return channel, nil
+}
+
+// UpdateChannel ändert einen bestehenden Kanal
+func (s *ChatNotificationService) UpdateChannel(id string, input ChatChannelInput) (*model.ChatChannel, error) {
+	channel, err := s.channelRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.apply(channel, input, false); err != nil {
+		return nil, err
+	}
+	if err := s.channelRepo.Update(channel); err != nil {
+		return nil, err
+	}
+	return channel, nil
+}
+
+// DeleteChannel löscht einen Kanal
+func (s *ChatNotificationService) DeleteChannel(id string) (*model.ChatChannel, error) {
+	channel, err := s.channelRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return channel, s.channelRepo.Delete(id)
+}
+
+// ListChannels gibt alle Kanäle zurück
+func (s *ChatNotificationService) ListChannels() ([]*model.ChatChannel, error) {
+	return s.channelRepo.FindAll()
+}
+
+// SendTestMessage schickt eine Testnachricht an einen Kanal
+func (s *ChatNotificationService) SendTestMessage(id string) (*model.ChatChannel, error) {
+	channel, err := s.channelRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return channel, s.send(channel, model.ChatMessage{
+		Title: "PeopleFlow-Testnachricht",
+		Text:  fmt.Sprintf("Der Kanal \"%s\" ist korrekt verbunden.", channel.Name),
+		Link:  GetBaseURL() + "/settings",
+		Color: "16a34a",
+	})
+}
+
+// Publish ist der ActivityListener für Abwesenheitsanträge und Genehmigungen
+func (s *ChatNotificationService) Publish(activity *model.Activity) {
+	var event model.ChatEvent
+	switch activity.Type {
+	case model.ActivityTypeVacationRequested:
+		event = model.ChatEventAbsencePending
+	case model.ActivityTypeVacationApproved:
+		event = model.ChatEventAbsenceApproved
+	default:
+		return
+	}
+
+	copied := *activity
+	go s.notifyAbsence(event, &copied)
+}
+
+// notifyAbsence meldet einen neuen Antrag bzw. eine Genehmigung an die abonnierenden Kanäle
+func (s *ChatNotificationService) notifyAbsence(event model.ChatEvent, activity *model.Activity) {
+	var department model.Department
+	employeeName := activity.TargetName
+	if activity.TargetType == "employee" && !activity.TargetID.IsZero() {
+		if employee, err := s.employeeRepo.FindByID(activity.TargetID.Hex()); err == nil {
+			department = employee.Department
+			employeeName = employee.FirstName + " " + employee.LastName
+		}
+	}
+
+	msg := model.ChatMessage{
+		Text:  activity.Description,
+		Lines: []string{"Mitarbeiter: " + employeeName, "Durch: " + activity.UserName},
+		Link:  GetBaseURL() + "/absence-overview",
+	}
+	if department != "" {
+		msg.Lines = append(msg.Lines, "Abteilung: "+string(department))
+	}
+	if event == model.ChatEventAbsencePending {
+		msg.Title = "Abwesenheitsantrag wartet auf Genehmigung"
+		msg.Color = "f59e0b"
+	} else {
+		msg.Title = "Abwesenheit genehmigt: " + employeeName
+		msg.Color = "16a34a"
+	}
+
+	s.broadcast(event, department, msg)
+}
+
+// SendAbsenceDigest meldet morgens, wer am angegebenen Tag abwesend ist (gefiltert nach Abteilungen des Kanals)
+func (s *ChatNotificationService) SendAbsenceDigest(day time.Time) error {
+	channels, err := s.channelRepo.FindSubscribers(model.ChatEventAbsenceDigest)
+	if err != nil || len(channels) == 0 {
+		return err
+	}
+
+	employees, _, err := s.employeeRepo.FindAll(0, 10000, "lastName", 1)
+	if err != nil {
+		return err
+	}
+
+	entries := AbsentOn(employees, day)
+	for _, channel := range channels {
+		var lines []string
+		for _, entry := range entries {
+			if channel.Wants(model.ChatEventAbsenceDigest, entry.Department) {
+				lines = append(lines, entry.Line)
+			}
+		}
+
+		msg := model.ChatMessage{
+			Title: "Heute abwesend – " + day.Format("02.01.2006"),
+			Lines: lines,
+			Link:  GetBaseURL() + "/absence",
+			Color: "2563eb",
+		}
+		if len(lines) == 0 {
+			msg.Text = "Heute sind keine Abwesenheiten eingetragen."
+		} else {
+			msg.Text = fmt.Sprintf("%d Mitarbeiter sind heute abwesend:", len(lines))
+		}
+		s.deliver(channel, msg)
+	}
+	return nil
+}
+
+// SendConversationsDue meldet die geplanten Mitarbeitergespräche der Woche ab weekStart
+func (s *ChatNotificationService) SendConversationsDue(weekStart time.Time) error {
+	channels, err := s.channelRepo.FindSubscribers(model.ChatEventConversationsDue)
+	if err != nil || len(channels) == 0 {
+		return err
+	}
+
+	employees, _, err := s.employeeRepo.FindAll(0, 10000, "lastName", 1)
+	if err != nil {
+		return err
+	}
+
+	entries := ConversationsDue(employees, weekStart, weekStart.AddDate(0, 0, 7))
+	for _, channel := range channels {
+		var lines []string
+		for _, entry := range entries {
+			if channel.Wants(model.ChatEventConversationsDue, entry.Department) {
+				lines = append(lines, entry.Line)
+			}
+		}
+		if len(lines) == 0 {
+			continue
+		}
+
+		s.deliver(channel, model.ChatMessage{
+			Title: fmt.Sprintf("Mitarbeitergespräche KW %d", isoWeek(weekStart)),
+			Text:  fmt.Sprintf("%d Gespräche sind diese Woche geplant:", len(lines)),
+			Lines: lines,
+			Link:  GetBaseURL() + "/upcoming-conversations",
+			Color: "6366f1",
+		})
+	}
+	return nil
+}
+
+// NotifySyncFailure meldet eine fehlgeschlagene Integrations-Synchronisation
+func (s *ChatNotificationService) NotifySyncFailure(integration, step string, syncErr error) {
+	s.broadcast(model.ChatEventSyncFailed, "", model.ChatMessage{
+		Title: "Synchronisation fehlgeschlagen: " + integration,
+		Text:  fmt.Sprintf("%s konnte nicht synchronisiert werden.", step),
+		Lines: []string{"Fehler: " + syncErr.Error(), "Zeitpunkt: " + time.Now().Format("02.01.2006 15:04")},
+		Link:  GetBaseURL() + "/settings",
+		Color: "dc2626",
+	})
+}
+
+// ChatDigestEntry ist eine Zeile einer Übersicht mit der Abteilung für die Kanalfilterung
+type ChatDigestEntry struct {
+	Department model.Department
+	Line       string
+}
+
+// AbsentOn listet genehmigte Abwesenheiten am angegebenen Tag auf
+func AbsentOn(employees []*model.Employee, day time.Time) []ChatDigestEntry {
+	date := truncateDay(day)
+	var entries []ChatDigestEntry
+	for _, employee := range employees {
+		for _, absence := range employee.Absences {
+			if absence.Status != "approved" {
+				continue
+			}
+			if date.Before(truncateDay(absence.StartDate)) || date.After(truncateDay(absence.EndDate)) {
+				continue
+			}
+			line := fmt.Sprintf("%s %s (%s, bis %s)", employee.FirstName, employee.LastName,
+				absenceTypeLabel(absence.Type), absence.EndDate.Format("02.01."))
+			if employee.Department != "" {
+				line += " – " + string(employee.Department)
+			}
+			entries = append(entries, ChatDigestEntry{Department: employee.Department, Line: line})
+			break
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Line < entries[j].Line })
+	return entries
+}
+
+// ConversationsDue listet geplante Gespräche im Zeitraum [from, to) auf, sortiert nach Datum
+func ConversationsDue(employees []*model.Employee, from, to time.Time) []ChatDigestEntry {
+	type dated struct {
+		date  time.Time
+		entry ChatDigestEntry
+	}
+	var items []dated
+	for _, employee := range employees {
+		for _, conversation := range employee.Conversations {
+			if conversation.Status == "completed" {
+				continue
+			}
+			if conversation.Date.Before(from) || !conversation.Date.Before(to) {
+				continue
+			}
+			items = append(items, dated{
+				date: conversation.Date,
+				entry: ChatDigestEntry{
+					Department: employee.Department,
+					Line: fmt.Sprintf("%s: %s mit %s %s", conversation.Date.Format("Mon 02.01."),
+						conversation.Title, employee.FirstName, employee.LastName),
+				},
+			})
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].date.Before(items[j].date) })
+
+	entries := make([]ChatDigestEntry, len(items))
+	for i, item := range items {
+		entries[i] = item.entry
+	}
+	return entries
+}
+
+// broadcast sendet eine Nachricht an alle Kanäle, die Ereignis und Abteilung abonniert haben
+func (s *ChatNotificationService) broadcast(event model.ChatEvent, department model.Department, msg model.ChatMessage) {
+	channels, err := s.channelRepo.FindSubscribers(event)
+	if err != nil {
+		log.Printf("Fehler beim Laden der Chat-Kanäle für %s: %v", event, err)
+		return
+	}
+	for _, channel := range channels {
+		if channel.Wants(event, department) {
+			s.deliver(channel, msg)
+		}
+	}
+}
+
+// deliver sendet eine Nachricht und protokolliert Fehler, ohne sie weiterzugeben
+func (s *ChatNotificationService) deliver(channel *model.ChatChannel, msg model.ChatMessage) {
+	if err := s.send(channel, msg); err != nil {
+		log.Printf("Fehler beim Senden an Chat-Kanal %s: %v", channel.Name, err)
+	}
+}
+
+// send schickt eine Nachricht an den Incoming Webhook eines Kanals und speichert das Ergebnis
+func (s *ChatNotificationService) send(channel *model.ChatChannel, msg model.ChatMessage) error {
+	err := s.post(channel, msg)
+
+	errMessage := ""
+	if err != nil {
+		errMessage = err.Error()
+	}
+	if recordErr := s.channelRepo.RecordResult(channel.ID, time.Now(), errMessage); recordErr != nil {
+		log.Printf("Fehler beim Speichern des Zustellstatus für Chat-Kanal %s: %v", channel.Name, recordErr)
+	}
+	return err
+}
+
+// post führt die HTTP-Anfrage an Slack bzw. Teams aus
+func (s *ChatNotificationService) post(channel *model.ChatChannel, msg model.ChatMessage) error {
+	webhookURL, err := utils.DecryptString(channel.WebhookURL)
+	if err != nil {
+		return fmt.Errorf("webhook-URL konnte nicht entschlüsselt werden: %w", err)
+	}
+	payload, err := channel.Provider.Payload(msg)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.client.Post(webhookURL, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrChatDeliveryFailed, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%w: HTTP %d %s", ErrChatDeliveryFailed, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// apply übernimmt und prüft die Eingaben; die Webhook-URL wird verschlüsselt gespeichert
+func (s *ChatNotificationService) apply(channel *model.ChatChannel, input ChatChannelInput, requireURL bool) error {
+	events, err := model.ParseChatEvents(input.Events)
+	if err != nil {
+		return err
+	}
+
+	departments := []model.Department{}
+	for _, department := range input.Departments {
+		if department = strings.TrimSpace(department); department != "" {
+			departments = append(departments, model.Department(department))
+		}
+	}
+
+	channel.Name = strings.TrimSpace(input.Name)
+	channel.Provider = model.ChatProvider(strings.ToLower(strings.TrimSpace(input.Provider)))
+	channel.Events = events
+	channel.Departments = departments
+	channel.Active = input.Active
+
+	plainURL := strings.TrimSpace(input.WebhookURL)
+	if plainURL == "" && !requireURL {
+		// Bisherige URL behalten, aber trotzdem die übrigen Felder prüfen
+		if plainURL, err = utils.DecryptString(channel.WebhookURL); err != nil {
+			return fmt.Errorf("webhook-URL konnte nicht entschlüsselt werden: %w", err)
+		}
+		return channel.Validate(plainURL)
+	}
+
+	if err := channel.Validate(plainURL); err != nil {
+		return err
+	}
+	if channel.WebhookURL, err = utils.EncryptString(plainURL); err != nil {
+		return fmt.Errorf("webhook-URL konnte nicht verschlüsselt werden: %w", err)
+	}
+	return nil
+}
+
+// truncateDay setzt die Uhrzeit auf Mitternacht in der lokalen Zeitzone des Datums
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// isoWeek gibt die ISO-Kalenderwoche zurück
+func isoWeek(t time.Time) int {
+	_, week := t.ISOWeek()
+	return week
+}
+
+// absenceTypeLabel gibt die deutsche Bezeichnung einer Abwesenheitsart zurück
+func absenceTypeLabel(absenceType string) string {
+	switch absenceType {
+	case "vacation":
+		return "Urlaub"
+	case "sick":
+		return "Krankheit"
+	case "special":
+		return "Sonderurlaub"
+	default:
+		return absenceType
+	}
+}
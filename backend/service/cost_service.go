@@ -2,33 +2,42 @@ package service
 
 import (
 	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
 	"sort"
 	"time"
 )
 
+// laborCostEmployeeStore ist der Teil des EmployeeRepository, den die Personalkosten benötigen.
+// Die Suche lädt keine Profilbilder; gespeichert wird deshalb nur das Gehalt.
+type laborCostEmployeeStore interface {
+	Search(query repository.EmployeeQuery) ([]*model.Employee, int64, error)
+	UpdateSalary(employeeID string, salary float64) error
+}
+
+// laborCostSnapshotStore speichert die monatlichen Momentaufnahmen (LaborCostSnapshotRepository)
+type laborCostSnapshotStore interface {
+	FindByMonths(months []string) (map[string]*model.LaborCostSnapshot, error)
+	Upsert(snapshot *model.LaborCostSnapshot) error
+}
+
 // CostService verwaltet Berechnungen und Abfragen zu Personalkosten
-type CostService struct{}
+type CostService struct {
+	employeeRepo laborCostEmployeeStore
+	snapshotRepo laborCostSnapshotStore
+}
 
 // NewCostService erstellt einen neuen CostService
 func NewCostService() *CostService {
-	return &CostService{}
+	return &CostService{
+		employeeRepo: repository.NewEmployeeRepository(),
+		snapshotRepo: repository.NewLaborCostSnapshotRepository(),
+	}
 }
 
-// CalculateMonthlyLaborCosts berechnet die monatlichen Personalkosten basierend auf aktiven Mitarbeitern
-// inklusive der Arbeitgeberkosten (ca. 21,5% des Bruttogehalts)
+// CalculateMonthlyLaborCosts berechnet die Personalkosten des laufenden Monats aus der
+// Gehaltshistorie inklusive Sonderzahlungen und Arbeitgeberanteil
 func (s *CostService) CalculateMonthlyLaborCosts(employees []*model.Employee) float64 {
-	var totalBrutto float64
-	for _, emp := range employees {
-		if emp.Status == model.EmployeeStatusActive || emp.Status == model.EmployeeStatusRemote || emp.Status == model.EmployeeStatusOnLeave {
-			totalBrutto += emp.Salary
-		}
-	}
-
-	// Arbeitgeberkosten hinzufügen (21,5% des Bruttogehalts)
-	employerContribution := totalBrutto * 0.215
-	totalCost := totalBrutto + employerContribution
-
-	return totalCost
+	return model.BuildLaborCostSnapshot(employees, time.Now().In(getGermanLocation()), time.Now()).Total
 }
 
 // CalculateAvgCostPerEmployee berechnet die durchschnittlichen Kosten pro Mitarbeiter
@@ -39,26 +48,105 @@ func (s *CostService) CalculateAvgCostPerEmployee(totalCost float64, employeeCou
 	return totalCost / float64(employeeCount)
 }
 
-// GenerateMonthlyLaborCostsTrend erzeugt Trenddaten für monatliche Personalkosten
-func (s *CostService) GenerateMonthlyLaborCostsTrend(currentCosts float64) []float64 {
-	// Generiert historische Monatsdaten basierend auf dem aktuellen Wert
-	// mit leichter Variation, um einen realistischen Trend zu simulieren
-	monthlyTrend := []float64{
-		currentCosts * 0.95, // Jan
-		currentCosts * 0.96, // Feb
-		currentCosts * 0.98, // März
-		currentCosts * 0.99, // April
-		currentCosts * 0.99, // Mai
-		currentCosts * 1.00, // Juni
-		currentCosts * 1.01, // Juli
-		currentCosts * 1.02, // August
-		currentCosts * 1.02, // Sept
-		currentCosts * 1.03, // Okt
-		currentCosts * 1.04, // Nov
-		currentCosts * 1.05, // Dez
+// LaborCostHistory gibt die Personalkosten der letzten months Monate zurück, älteste zuerst.
+// Abgeschlossene Monate kommen aus den gespeicherten Momentaufnahmen; fehlt eine, ist der Monat
+// nicht verfügbar. Nur der laufende Monat wird aus der Gehaltshistorie berechnet, da der
+// Mitarbeiterstatus nur für heute bekannt ist.
+func (s *CostService) LaborCostHistory(months int, now time.Time) ([]*model.LaborCostSnapshot, error) {
+	now = now.In(getGermanLocation())
+	current := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+
+	keys := make([]string, months)
+	for i := range keys {
+		keys[i] = current.AddDate(0, i-months+1, 0).Format("2006-01")
+	}
+	stored, err := s.snapshotRepo.FindByMonths(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]*model.LaborCostSnapshot, months)
+	for i := range snapshots[:months-1] {
+		if snapshot, ok := stored[keys[i]]; ok {
+			snapshots[i] = snapshot
+		} else {
+			snapshots[i] = model.UnavailableLaborCostSnapshot(current.AddDate(0, i-months+1, 0))
+		}
+	}
+
+	employees, err := s.allEmployees()
+	if err != nil {
+		return nil, err
+	}
+	snapshots[months-1] = model.BuildLaborCostSnapshot(employees, current, now)
+	return snapshots, nil
+}
+
+// LaborCostTrend gibt den Verlauf der Personalkosten der letzten months Monate zurück,
+// gefiltert nach Abteilung und Standort (leer = alle)
+func (s *CostService) LaborCostTrend(months int, department, location string, now time.Time) (model.LaborCostTrend, error) {
+	snapshots, err := s.LaborCostHistory(months, now)
+	if err != nil {
+		return model.LaborCostTrend{}, err
+	}
+	return model.BuildLaborCostTrend(snapshots, department, location), nil
+}
+
+// SnapshotMonth berechnet die Personalkosten eines Monats neu und speichert sie als Momentaufnahme
+func (s *CostService) SnapshotMonth(month time.Time, now time.Time) (*model.LaborCostSnapshot, error) {
+	employees, err := s.allEmployees()
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := model.BuildLaborCostSnapshot(employees, month, now)
+	if err := s.snapshotRepo.Upsert(snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// SnapshotPreviousMonth speichert die Momentaufnahme des Vormonats, falls sie noch fehlt.
+// Gibt nil zurück, wenn bereits eine Momentaufnahme vorhanden war.
+func (s *CostService) SnapshotPreviousMonth(now time.Time) (*model.LaborCostSnapshot, error) {
+	now = now.In(getGermanLocation())
+	previous := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).AddDate(0, -1, 0)
+
+	stored, err := s.snapshotRepo.FindByMonths([]string{previous.Format("2006-01")})
+	if err != nil {
+		return nil, err
+	}
+	if len(stored) > 0 {
+		return nil, nil
+	}
+	return s.SnapshotMonth(previous, now)
+}
+
+// SyncCurrentSalaries übernimmt wirksam gewordene Gehaltsänderungen aus der Gehaltshistorie
+// in das aktuelle Gehalt der Mitarbeiter und gibt die Anzahl der Änderungen zurück
+func (s *CostService) SyncCurrentSalaries(now time.Time) (int, error) {
+	employees, err := s.allEmployees()
+	if err != nil {
+		return 0, err
+	}
+
+	updated := 0
+	for _, employee := range employees {
+		if !employee.SyncCurrentSalary(now) {
+			continue
+		}
+		if err := s.employeeRepo.UpdateSalary(employee.ID.Hex(), employee.Salary); err != nil {
+			return updated, err
+		}
+		updated++
 	}
+	return updated, nil
+}
 
-	return monthlyTrend
+// allEmployees lädt alle Mitarbeiter einschließlich inaktiver
+func (s *CostService) allEmployees() ([]*model.Employee, error) {
+	employees, _, err := s.employeeRepo.Search(repository.EmployeeQuery{})
+	return employees, err
 }
 
 // CountEmployeesByDepartment zählt Mitarbeiter pro Abteilung
@@ -96,6 +184,7 @@ func (s *CostService) CountEmployeesByDepartment(employees []*model.Employee) ([
 func (s *CostService) CalculateCostsByDepartment(employees []*model.Employee) ([]string, []float64) {
 	// Kosten pro Abteilung sammeln
 	departmentCosts := make(map[string]float64)
+	now := time.Now().In(getGermanLocation())
 
 	for _, emp := range employees {
 		if emp.Status == model.EmployeeStatusActive || emp.Status == model.EmployeeStatusRemote || emp.Status == model.EmployeeStatusOnLeave {
@@ -106,9 +195,9 @@ func (s *CostService) CalculateCostsByDepartment(employees []*model.Employee) ([
 				deptName = "Unbekannt"
 			}
 
-			// Bruttolohn + AG-Anteil (21.5%)
-			totalCost := emp.Salary * 1.215
-			departmentCosts[deptName] += totalCost
+			// Gehalt, Sonderzahlungen und AG-Anteil laut Gehaltshistorie
+			cost, _ := emp.LaborCostForMonth(now)
+			departmentCosts[deptName] += cost.Total
 		}
 	}
 
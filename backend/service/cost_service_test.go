@@ -0,0 +1,99 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// fakeLaborCostEmployees liefert Mitarbeiter wie die Suche ohne Profilbilddaten und merkt sich
+// die gespeicherten Gehälter
+type fakeLaborCostEmployees struct {
+	employees []*model.Employee
+	salaries  map[string]float64
+}
+
+func (f *fakeLaborCostEmployees) Search(repository.EmployeeQuery) ([]*model.Employee, int64, error) {
+	return f.employees, int64(len(f.employees)), nil
+}
+
+func (f *fakeLaborCostEmployees) UpdateSalary(employeeID string, salary float64) error {
+	f.salaries[employeeID] = salary
+	return nil
+}
+
+func TestCostService_SyncCurrentSalariesOnlyStoresSalary(t *testing.T) {
+	now := time.Date(2024, time.July, 2, 4, 15, 0, 0, time.UTC)
+
+	raised := &model.Employee{ID: primitive.NewObjectID(), Salary: 4000, ProfileImage: "/uploads/raised.png"}
+	require.NoError(t, raised.AddSalaryChange(model.SalaryChange{
+		EffectiveDate:               time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC),
+		MonthlySalary:               4500,
+		EmployerContributionPercent: model.DefaultEmployerContributionPercent,
+	}, now.AddDate(0, -1, 0)))
+	unchanged := &model.Employee{ID: primitive.NewObjectID(), Salary: 3000, ProfileImage: "/uploads/unchanged.png"}
+
+	store := &fakeLaborCostEmployees{employees: []*model.Employee{raised, unchanged}, salaries: map[string]float64{}}
+	s := &CostService{employeeRepo: store}
+
+	updated, err := s.SyncCurrentSalaries(now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, updated)
+	assert.Equal(t, map[string]float64{raised.ID.Hex(): 4500}, store.salaries)
+}
+
+// fakeLaborCostSnapshots hält gespeicherte Momentaufnahmen nach Monat
+type fakeLaborCostSnapshots map[string]*model.LaborCostSnapshot
+
+func (f fakeLaborCostSnapshots) FindByMonths(months []string) (map[string]*model.LaborCostSnapshot, error) {
+	found := make(map[string]*model.LaborCostSnapshot)
+	for _, month := range months {
+		if snapshot, ok := f[month]; ok {
+			snapshot.Stored = true
+			found[month] = snapshot
+		}
+	}
+	return found, nil
+}
+
+func (f fakeLaborCostSnapshots) Upsert(snapshot *model.LaborCostSnapshot) error {
+	f[snapshot.Month] = snapshot
+	return nil
+}
+
+func TestCostService_LaborCostHistoryDoesNotRecomputePastMonths(t *testing.T) {
+	now := time.Date(2024, time.July, 10, 12, 0, 0, 0, time.UTC)
+	hired := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	// Die Mitarbeiterin ist im Juni ausgeschieden; heute zählt sie nicht mehr
+	left := &model.Employee{ID: primitive.NewObjectID(), Salary: 5000, HireDate: hired, Status: model.EmployeeStatusInactive}
+	active := &model.Employee{ID: primitive.NewObjectID(), Salary: 3000, HireDate: hired, Status: model.EmployeeStatusActive}
+	may := &model.LaborCostSnapshot{Month: "2024-05", LaborCost: model.LaborCost{EmployeeCount: 2, Salaries: 8000, Total: 9720}}
+
+	s := &CostService{
+		employeeRepo: &fakeLaborCostEmployees{employees: []*model.Employee{left, active}},
+		snapshotRepo: fakeLaborCostSnapshots{"2024-05": may},
+	}
+
+	history, err := s.LaborCostHistory(3, now)
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+
+	assert.Equal(t, "2024-05", history[0].Month)
+	assert.True(t, history[0].Stored)
+	assert.Equal(t, 9720.0, history[0].Total, "gespeicherte Monate enthalten ausgeschiedene Mitarbeiter")
+
+	assert.Equal(t, "2024-06", history[1].Month)
+	assert.True(t, history[1].Unavailable, "ohne Momentaufnahme wird der Monat nicht aus dem heutigen Status berechnet")
+	assert.Zero(t, history[1].Total)
+
+	assert.Equal(t, "2024-07", history[2].Month)
+	assert.False(t, history[2].Unavailable)
+	assert.Equal(t, 3000.0, history[2].Salaries, "der laufende Monat wird live berechnet")
+}
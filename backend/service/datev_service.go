@@ -0,0 +1,86 @@
+// backend/service/datev_service.go
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+)
+
+// ErrDatevNotConfigured wird zurückgegeben, wenn Berater- oder Mandantennummer fehlen
+var ErrDatevNotConfigured = errors.New("DATEV export is not configured")
+
+// DatevService erstellt den monatlichen Lohnexport im DATEV-ASCII-Format
+type DatevService struct {
+	settingsRepo   *repository.SystemSettingsRepository
+	employeeRepo   *repository.EmployeeRepository
+	adjustmentRepo *repository.OvertimeAdjustmentRepository
+}
+
+// NewDatevService erstellt einen neuen DatevService
+func NewDatevService() *DatevService {
+	return &DatevService{
+		settingsRepo:   repository.NewSystemSettingsRepository(),
+		employeeRepo:   repository.NewEmployeeRepository(),
+		adjustmentRepo: repository.NewOvertimeAdjustmentRepository(),
+	}
+}
+
+// Settings gibt die Einstellungen des DATEV-Exports zurück
+func (s *DatevService) Settings() (*model.DatevSettings, error) {
+	settings, err := s.settingsRepo.GetSettings()
+	if err != nil {
+		return nil, err
+	}
+	return settings.GetDatevSettings(), nil
+}
+
+// SaveSettings prüft und speichert die Einstellungen des DATEV-Exports
+func (s *DatevService) SaveSettings(datev *model.DatevSettings) error {
+	if err := datev.Validate(); err != nil {
+		return err
+	}
+
+	settings, err := s.settingsRepo.GetSettings()
+	if err != nil {
+		return err
+	}
+	settings.Datev = datev
+	return s.settingsRepo.Update(settings)
+}
+
+// Export erstellt die Bewegungsdaten des Abrechnungsmonats month für alle Mitarbeiter
+func (s *DatevService) Export(month time.Time) (*model.DatevExport, *model.DatevSettings, error) {
+	settings, err := s.Settings()
+	if err != nil {
+		return nil, nil, err
+	}
+	if !settings.IsConfigured() {
+		return nil, nil, ErrDatevNotConfigured
+	}
+	if err := settings.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	// Auch inaktive Mitarbeiter werden berücksichtigt, damit Austritte im Monat abgerechnet werden
+	employees, _, err := s.employeeRepo.Search(repository.EmployeeQuery{SortBy: "employeeId"})
+	if err != nil {
+		return nil, nil, fmt.Errorf("Mitarbeiter konnten nicht geladen werden: %w", err)
+	}
+
+	start := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, month.Location())
+	payouts, err := s.adjustmentRepo.FindApprovedPayouts(start, start.AddDate(0, 1, 0))
+	if err != nil {
+		return nil, nil, fmt.Errorf("Überstunden-Auszahlungen konnten nicht geladen werden: %w", err)
+	}
+
+	return model.BuildDatevExport(settings, start, employees, payouts), settings, nil
+}
+
+// ParseMonth liest den Abrechnungsmonat (YYYY-MM) in deutscher Zeit; ohne Angabe gilt der Vormonat
+func (s *DatevService) ParseMonth(value string) (time.Time, error) {
+	return model.ParseDatevMonth(value, time.Now().In(getGermanLocation()))
+}
@@ -0,0 +1,224 @@
+// backend/service/email_outbox_service.go
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"PeopleFlow/backend/model"
+)
+
+// Postausgang-Fehler
+var (
+	ErrOutboxEmailAlreadySent = errors.New("email has already been sent")
+	ErrOutboxEmailRedacted    = errors.New("email content has been removed")
+)
+
+const (
+	// emailOutboxBatchSize begrenzt die Anzahl der E-Mails pro Worker-Durchlauf
+	emailOutboxBatchSize = 100
+
+	// emailClaimLease ist die Zeit, für die eine E-Mail während des Versands reserviert bleibt
+	emailClaimLease = 2 * time.Minute
+
+	// emailOutboxRetention bestimmt, wie lange gesendete E-Mails im Postausgang sichtbar bleiben
+	emailOutboxRetention = 30 * 24 * time.Hour
+
+	// defaultEmailRateLimit ist die Standardanzahl an E-Mails pro Minute
+	defaultEmailRateLimit = 30
+)
+
+// emailLimiter begrenzt den Versand prozessweit, damit Massenversand (z.B. Wochenberichte)
+// nicht vom SMTP-Anbieter abgewiesen wird. Überzählige E-Mails bleiben im Postausgang
+// und werden vom Background-Worker in den folgenden Minuten gesendet.
+var emailLimiter = newEmailRateLimiter(emailRateLimitFromEnv(), time.Minute)
+
+// emailRateLimiter lässt höchstens limit Versandversuche pro Zeitfenster zu
+type emailRateLimiter struct {
+	mu     sync.Mutex
+	limit  int
+	window time.Duration
+	sent   []time.Time
+}
+
+// newEmailRateLimiter erstellt einen neuen emailRateLimiter
+func newEmailRateLimiter(limit int, window time.Duration) *emailRateLimiter {
+	return &emailRateLimiter{limit: limit, window: window}
+}
+
+// Allow prüft, ob im aktuellen Zeitfenster noch ein Versand erlaubt ist, und zählt ihn gegebenenfalls
+func (l *emailRateLimiter) Allow(now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	cutoff := now.Add(-l.window)
+	recent := l.sent[:0]
+	for _, at := range l.sent {
+		if at.After(cutoff) {
+			recent = append(recent, at)
+		}
+	}
+	l.sent = recent
+
+	if len(l.sent) >= l.limit {
+		return false
+	}
+	l.sent = append(l.sent, now)
+	return true
+}
+
+// emailRateLimitFromEnv liest PEOPLEFLOW_EMAIL_RATE_LIMIT (E-Mails pro Minute)
+func emailRateLimitFromEnv() int {
+	if value, err := strconv.Atoi(os.Getenv("PEOPLEFLOW_EMAIL_RATE_LIMIT")); err == nil && value > 0 {
+		return value
+	}
+	return defaultEmailRateLimit
+}
+
+// OutboxPage ist eine Seite des Postausgangs samt Anzahl je Status
+type OutboxPage struct {
+	Emails []*model.OutboxEmail              `json:"emails"`
+	Total  int64                             `json:"total"`
+	Counts map[model.OutboxEmailStatus]int64 `json:"counts"`
+}
+
+// enqueue speichert eine E-Mail im Postausgang und versendet sie im Hintergrund,
+// sofern das Versandlimit es zulässt
+func (es *EmailService) enqueue(email *model.OutboxEmail) error {
+	if !es.IsEmailConfigured() {
+		return fmt.Errorf("E-Mail-Konfiguration ist nicht vollständig")
+	}
+
+	now := time.Now()
+	immediate := emailLimiter.Allow(now)
+	next := now
+	if immediate {
+		next = now.Add(emailClaimLease)
+	}
+	email.NextAttemptAt = &next
+
+	if err := es.outboxRepo.Create(email); err != nil {
+		return fmt.Errorf("fehler beim Speichern im Postausgang: %v", err)
+	}
+
+	if immediate {
+		go es.attempt(email)
+	}
+	return nil
+}
+
+// sendImmediately speichert eine E-Mail im Postausgang und versendet sie sofort.
+// Der Fehler des ersten Versuchs wird zurückgegeben; weitere Versuche übernimmt der Worker.
+func (es *EmailService) sendImmediately(email *model.OutboxEmail) error {
+	if !es.IsEmailConfigured() {
+		return fmt.Errorf("E-Mail-Konfiguration ist nicht vollständig")
+	}
+
+	lease := time.Now().Add(emailClaimLease)
+	email.NextAttemptAt = &lease
+	if err := es.outboxRepo.Create(email); err != nil {
+		return fmt.Errorf("fehler beim Speichern im Postausgang: %v", err)
+	}
+
+	emailLimiter.Allow(time.Now())
+	return es.attempt(email)
+}
+
+// ProcessOutbox sendet fällige E-Mails im Rahmen des Versandlimits; wird vom Background-Worker aufgerufen
+func (es *EmailService) ProcessOutbox() (int, error) {
+	processed := 0
+	for processed < emailOutboxBatchSize {
+		if !emailLimiter.Allow(time.Now()) {
+			break
+		}
+
+		email, err := es.outboxRepo.ClaimDue(time.Now(), emailClaimLease)
+		if err != nil {
+			return processed, err
+		}
+		if email == nil {
+			break
+		}
+		processed++
+		_ = es.attempt(email)
+	}
+	return processed, nil
+}
+
+// attempt führt einen Versandversuch aus und speichert das Ergebnis
+func (es *EmailService) attempt(email *model.OutboxEmail) error {
+	err := es.deliver(email)
+
+	errMessage := ""
+	if err != nil {
+		errMessage = err.Error()
+	}
+	email.RecordAttempt(time.Now(), errMessage)
+
+	if saveErr := es.outboxRepo.SaveAttempt(email); saveErr != nil {
+		log.Printf("Fehler beim Speichern des Versandversuchs für E-Mail %s: %v", email.ID.Hex(), saveErr)
+	}
+	if email.Status == model.OutboxEmailDead {
+		log.Printf("E-Mail \"%s\" an %s nach %d Versuchen endgültig fehlgeschlagen: %s", email.Subject, email.To, email.Attempts, errMessage)
+	}
+	return err
+}
+
+// ListOutbox gibt eine Seite des Postausgangs zurück (optional gefiltert nach Status)
+func (es *EmailService) ListOutbox(status model.OutboxEmailStatus, skip, limit int64) (*OutboxPage, error) {
+	emails, total, err := es.outboxRepo.FindByStatus(status, skip, limit)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := es.outboxRepo.CountByStatus()
+	if err != nil {
+		return nil, err
+	}
+	if emails == nil {
+		emails = []*model.OutboxEmail{}
+	}
+	return &OutboxPage{Emails: emails, Total: total, Counts: counts}, nil
+}
+
+// GetOutboxEmail gibt eine E-Mail aus dem Postausgang samt Inhalt zurück.
+// Einmal-Links (Passwort-Reset, Einladung) werden nie ausgegeben.
+func (es *EmailService) GetOutboxEmail(id string) (*model.OutboxEmail, error) {
+	email, err := es.outboxRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return email.WithoutSecrets(), nil
+}
+
+// RetryOutboxEmail stellt eine fehlgeschlagene oder wartende E-Mail sofort erneut zu
+func (es *EmailService) RetryOutboxEmail(id string) (*model.OutboxEmail, error) {
+	email, err := es.outboxRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if email.Status == model.OutboxEmailSent {
+		return nil, ErrOutboxEmailAlreadySent
+	}
+	if email.Redacted {
+		return nil, ErrOutboxEmailRedacted
+	}
+
+	email.Requeue(time.Now().Add(emailClaimLease))
+	if err := es.outboxRepo.Requeue(email); err != nil {
+		return nil, err
+	}
+
+	emailLimiter.Allow(time.Now())
+	_ = es.attempt(email)
+	return email, nil
+}
+
+// CleanupOutbox löscht gesendete E-Mails nach Ablauf der Aufbewahrungsfrist
+func (es *EmailService) CleanupOutbox() (int64, error) {
+	return es.outboxRepo.DeleteSentBefore(time.Now().Add(-emailOutboxRetention))
+}
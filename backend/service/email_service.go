@@ -6,34 +6,54 @@ import (
 	"bytes"
 	"crypto/tls"
 	"fmt"
-	"html/template"
 	"log"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
 	"net/smtp"
+	"net/textproto"
+	"os"
+	"strconv"
+	"strings"
 	"time"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// smtpTimeout begrenzt Verbindungsaufbau und Dialog mit dem SMTP-Server
+const smtpTimeout = 30 * time.Second
+
 // EmailService verwaltet das Senden von E-Mails
 type EmailService struct {
 	settingsRepo *repository.SystemSettingsRepository
+	templateRepo *repository.EmailTemplateRepository
+	userRepo     *repository.UserRepository
+	outboxRepo   *repository.EmailOutboxRepository
 }
 
 // NewEmailService erstellt einen neuen EmailService
 func NewEmailService() *EmailService {
 	return &EmailService{
 		settingsRepo: repository.NewSystemSettingsRepository(),
+		templateRepo: repository.NewEmailTemplateRepository(),
+		userRepo:     repository.NewUserRepository(),
+		outboxRepo:   repository.NewEmailOutboxRepository(),
 	}
 }
 
-// EmailTemplate definiert die Struktur für E-Mail-Templates
-type EmailTemplate struct {
-	Subject string
-	Body    string
-	IsHTML  bool
+// SendEmail legt eine E-Mail im Postausgang ab. Der Versand erfolgt im Hintergrund,
+// bei Fehlern wiederholt der Background-Worker ihn mit wachsendem Abstand.
+func (es *EmailService) SendEmail(to, subject, body string, isHTML bool) error {
+	return es.enqueue(&model.OutboxEmail{
+		To:      to,
+		Subject: subject,
+		Body:    body,
+		IsHTML:  isHTML,
+	})
 }
 
-// SendEmail sendet eine E-Mail mit den angegebenen Parametern
-func (es *EmailService) SendEmail(to, subject, body string, isHTML bool) error {
+// deliver sendet eine E-Mail aus dem Postausgang über den konfigurierten SMTP-Server
+func (es *EmailService) deliver(email *model.OutboxEmail) error {
 	settings, err := es.settingsRepo.GetSettings()
 	if err != nil {
 		return fmt.Errorf("fehler beim Abrufen der System-Einstellungen: %v", err)
@@ -45,74 +65,126 @@ func (es *EmailService) SendEmail(to, subject, body string, isHTML bool) error {
 
 	emailSettings := settings.EmailNotifications
 
-	// SMTP-Authentifizierung
-	auth := smtp.PlainAuth("", emailSettings.SMTPUser, emailSettings.SMTPPass, emailSettings.SMTPHost)
+	// E-Mail mit Headern erstellen (HTML-Mails erhalten eine Klartext-Alternative)
+	msg, err := buildEmailMessage(emailSettings.FromName, emailSettings.FromEmail, email.To, email.Subject, email.Body, email.IsHTML)
+	if err != nil {
+		return fmt.Errorf("fehler beim Erstellen der E-Mail: %v", err)
+	}
+
+	return sendSMTP(emailSettings, email.To, msg)
+}
 
-	// E-Mail-Header erstellen
+// buildEmailMessage erstellt die MIME-Nachricht. HTML-Inhalte werden als multipart/alternative
+// mit einem aus dem HTML erzeugten Klartext-Teil verschickt.
+func buildEmailMessage(fromName, fromEmail, to, subject, body string, isHTML bool) ([]byte, error) {
 	var msg bytes.Buffer
-	msg.WriteString(fmt.Sprintf("From: %s <%s>\r\n", emailSettings.FromName, emailSettings.FromEmail))
+	msg.WriteString(fmt.Sprintf("From: %s <%s>\r\n", mime.QEncoding.Encode("UTF-8", fromName), fromEmail))
 	msg.WriteString(fmt.Sprintf("To: %s\r\n", to))
-	msg.WriteString(fmt.Sprintf("Subject: %s\r\n", subject))
-	
-	if isHTML {
-		msg.WriteString("MIME-Version: 1.0\r\n")
-		msg.WriteString("Content-Type: text/html; charset=UTF-8\r\n")
-	} else {
+	msg.WriteString(fmt.Sprintf("Subject: %s\r\n", mime.QEncoding.Encode("UTF-8", subject)))
+	msg.WriteString(fmt.Sprintf("Date: %s\r\n", time.Now().Format(time.RFC1123Z)))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+
+	if !isHTML {
 		msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+		msg.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+		if err := writeQuotedPrintable(&msg, body); err != nil {
+			return nil, err
+		}
+		return msg.Bytes(), nil
 	}
-	
-	msg.WriteString("\r\n")
-	msg.WriteString(body)
-
-	// SMTP-Server-Adresse
-	addr := fmt.Sprintf("%s:%d", emailSettings.SMTPHost, emailSettings.SMTPPort)
 
-	if emailSettings.UseTLS {
-		return es.sendEmailWithTLS(addr, auth, emailSettings.FromEmail, []string{to}, msg.Bytes())
+	var parts bytes.Buffer
+	writer := multipart.NewWriter(&parts)
+	alternatives := []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=UTF-8", model.EmailPlainText(body)},
+		{"text/html; charset=UTF-8", body},
+	}
+	for _, alternative := range alternatives {
+		part, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {alternative.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := writeQuotedPrintable(part, alternative.content); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
 	}
 
-	return smtp.SendMail(addr, auth, emailSettings.FromEmail, []string{to}, msg.Bytes())
+	msg.WriteString(fmt.Sprintf("Content-Type: multipart/alternative; boundary=\"%s\"\r\n\r\n", writer.Boundary()))
+	msg.Write(parts.Bytes())
+	return msg.Bytes(), nil
 }
 
-// sendEmailWithTLS sendet E-Mail über TLS-Verbindung
-func (es *EmailService) sendEmailWithTLS(addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
-	// TLS-Konfiguration
-	tlsConfig := &tls.Config{
-		InsecureSkipVerify: false,
-		ServerName:         addr[:len(addr)-4], // Remove port from address
+// writeQuotedPrintable kodiert einen Text als quoted-printable (verhindert zu lange Zeilen)
+func writeQuotedPrintable(w interface{ Write([]byte) (int, error) }, content string) error {
+	encoder := quotedprintable.NewWriter(w)
+	if _, err := encoder.Write([]byte(content)); err != nil {
+		return err
 	}
+	return encoder.Close()
+}
+
+// sendSMTP übermittelt eine fertige Nachricht an den SMTP-Server. Bei UseTLS wird direkt
+// per TLS verbunden (Port 465), sonst wird STARTTLS genutzt, sofern der Server es anbietet.
+func sendSMTP(settings *model.EmailNotificationSettings, to string, msg []byte) error {
+	addr := net.JoinHostPort(settings.SMTPHost, strconv.Itoa(settings.SMTPPort))
+	tlsConfig := &tls.Config{ServerName: settings.SMTPHost}
+	dialer := &net.Dialer{Timeout: smtpTimeout}
 
 	// Verbindung zum SMTP-Server aufbauen
-	conn, err := tls.Dial("tcp", addr, tlsConfig)
+	var conn net.Conn
+	var err error
+	if settings.UseTLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
 	if err != nil {
-		return fmt.Errorf("fehler beim Aufbau der TLS-Verbindung: %v", err)
+		return fmt.Errorf("fehler beim Verbindungsaufbau zu %s: %v", addr, err)
 	}
-	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(smtpTimeout))
 
 	// SMTP-Client erstellen
-	client, err := smtp.NewClient(conn, tlsConfig.ServerName)
+	client, err := smtp.NewClient(conn, settings.SMTPHost)
 	if err != nil {
+		conn.Close()
 		return fmt.Errorf("fehler beim Erstellen des SMTP-Clients: %v", err)
 	}
-	defer client.Quit()
+	defer client.Close()
+
+	if !settings.UseTLS {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(tlsConfig); err != nil {
+				return fmt.Errorf("STARTTLS fehlgeschlagen: %v", err)
+			}
+		}
+	}
 
 	// Authentifizierung
-	if auth != nil {
-		if err = client.Auth(auth); err != nil {
+	if settings.SMTPUser != "" {
+		if ok, _ := client.Extension("AUTH"); !ok {
+			return fmt.Errorf("SMTP-Server unterstützt keine Authentifizierung")
+		}
+		auth := smtp.PlainAuth("", settings.SMTPUser, settings.SMTPPass, settings.SMTPHost)
+		if err := client.Auth(auth); err != nil {
 			return fmt.Errorf("SMTP-Authentifizierung fehlgeschlagen: %v", err)
 		}
 	}
 
-	// Absender setzen
-	if err = client.Mail(from); err != nil {
+	// Absender und Empfänger setzen
+	if err := client.Mail(settings.FromEmail); err != nil {
 		return fmt.Errorf("fehler beim Setzen des Absenders: %v", err)
 	}
-
-	// Empfänger hinzufügen
-	for _, recipient := range to {
-		if err = client.Rcpt(recipient); err != nil {
-			return fmt.Errorf("fehler beim Hinzufügen des Empfängers %s: %v", recipient, err)
-		}
+	if err := client.Rcpt(to); err != nil {
+		return fmt.Errorf("fehler beim Hinzufügen des Empfängers %s: %v", to, err)
 	}
 
 	// E-Mail-Inhalt senden
@@ -120,177 +192,74 @@ func (es *EmailService) sendEmailWithTLS(addr string, auth smtp.Auth, from strin
 	if err != nil {
 		return fmt.Errorf("fehler beim Öffnen des Daten-Streams: %v", err)
 	}
-
-	_, err = writer.Write(msg)
-	if err != nil {
+	if _, err := writer.Write(msg); err != nil {
 		return fmt.Errorf("fehler beim Schreiben der E-Mail-Daten: %v", err)
 	}
-
-	err = writer.Close()
-	if err != nil {
+	if err := writer.Close(); err != nil {
 		return fmt.Errorf("fehler beim Schließen des Daten-Streams: %v", err)
 	}
 
-	return nil
+	return client.Quit()
+}
+
+// GetBaseURL gibt die öffentliche Basis-URL der Anwendung für Links in E-Mails zurück
+func GetBaseURL() string {
+	baseURL := os.Getenv("PEOPLEFLOW_BASE_URL")
+	if baseURL == "" {
+		return "http://localhost:8080"
+	}
+	return strings.TrimRight(baseURL, "/")
 }
 
 // SendPasswordResetEmail sendet eine Passwort-Reset-E-Mail
 func (es *EmailService) SendPasswordResetEmail(email, token string) error {
-	resetURL := fmt.Sprintf("http://localhost:8080/reset-password?token=%s", token)
-	
-	subject := "Passwort zurücksetzen - PeopleFlow"
-	
-	// HTML-Template für Passwort-Reset
-	htmlTemplate := `
-<!DOCTYPE html>
-<html>
-<head>
-    <meta charset="UTF-8">
-    <style>
-        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
-        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
-        .header { background-color: #10b981; color: white; padding: 20px; text-align: center; }
-        .content { padding: 20px; background-color: #f9f9f9; }
-        .button { display: inline-block; padding: 12px 24px; background-color: #10b981; color: white; text-decoration: none; border-radius: 5px; margin: 20px 0; }
-        .footer { text-align: center; padding: 20px; font-size: 12px; color: #666; }
-    </style>
-</head>
-<body>
-    <div class="container">
-        <div class="header">
-            <h1>Passwort zurücksetzen</h1>
-        </div>
-        <div class="content">
-            <p>Hallo,</p>
-            <p>Sie haben eine Anfrage zum Zurücksetzen Ihres Passworts gestellt. Klicken Sie auf den folgenden Link, um ein neues Passwort zu erstellen:</p>
-            <p style="text-align: center;">
-                <a href="{{.ResetURL}}" class="button">Passwort zurücksetzen</a>
-            </p>
-            <p>Dieser Link ist 1 Stunde gültig.</p>
-            <p>Falls Sie diese Anfrage nicht gestellt haben, können Sie diese E-Mail ignorieren.</p>
-            <p>Mit freundlichen Grüßen,<br>Ihr PeopleFlow Team</p>
-        </div>
-        <div class="footer">
-            <p>Diese E-Mail wurde automatisch generiert. Bitte antworten Sie nicht auf diese E-Mail.</p>
-        </div>
-    </div>
-</body>
-</html>`
-
-	tmpl, err := template.New("passwordReset").Parse(htmlTemplate)
-	if err != nil {
-		return fmt.Errorf("fehler beim Parsen des E-Mail-Templates: %v", err)
+	resetURL := fmt.Sprintf("%s/reset-password?token=%s", GetBaseURL(), token)
+
+	language := es.SystemLanguage()
+	if user, err := es.userRepo.FindByEmail(email); err == nil {
+		language = es.LanguageFor(user)
 	}
 
-	var body bytes.Buffer
-	err = tmpl.Execute(&body, map[string]string{
+	return es.SendTemplate(email, model.EmailTemplatePasswordReset, language, map[string]interface{}{
 		"ResetURL": resetURL,
 	})
-	if err != nil {
-		return fmt.Errorf("fehler beim Ausführen des E-Mail-Templates: %v", err)
-	}
+}
 
-	return es.SendEmail(email, subject, body.String(), true)
+// SendInvitationEmail sendet einen zeitlich begrenzten Aktivierungslink an einen eingeladenen Benutzer
+func (es *EmailService) SendInvitationEmail(user *model.User, invitedByName, token string, expiresAt time.Time) error {
+	return es.SendTemplate(user.Email, model.EmailTemplateInvitation, es.LanguageFor(user), map[string]interface{}{
+		"Name":          user.GetDisplayName(),
+		"InvitedBy":     invitedByName,
+		"ActivationURL": fmt.Sprintf("%s/activate?token=%s", GetBaseURL(), token),
+		"ExpiresAt":     invitationExpiryText(expiresAt),
+	})
 }
 
-// SendWeeklyReport sendet einen wöchentlichen Bericht an einen Mitarbeiter
-func (es *EmailService) SendWeeklyReport(employee *model.Employee, weekStart, weekEnd time.Time, totalHours float64, activities []model.Activity) error {
-	subject := fmt.Sprintf("Wochenbericht %s - %s", weekStart.Format("02.01.2006"), weekEnd.Format("02.01.2006"))
-	
-	// HTML-Template für Wochenbericht
-	htmlTemplate := `
-<!DOCTYPE html>
-<html>
-<head>
-    <meta charset="UTF-8">
-    <style>
-        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
-        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
-        .header { background-color: #10b981; color: white; padding: 20px; text-align: center; }
-        .content { padding: 20px; background-color: #f9f9f9; }
-        .summary { background-color: white; padding: 15px; margin: 20px 0; border-radius: 5px; border-left: 4px solid #10b981; }
-        .activity-list { margin: 20px 0; }
-        .activity-item { background-color: white; padding: 10px; margin: 5px 0; border-radius: 3px; border-left: 2px solid #10b981; }
-        .footer { text-align: center; padding: 20px; font-size: 12px; color: #666; }
-        .hours { font-size: 24px; font-weight: bold; color: #10b981; }
-    </style>
-</head>
-<body>
-    <div class="container">
-        <div class="header">
-            <h1>Wochenbericht</h1>
-            <p>{{.WeekStart}} - {{.WeekEnd}}</p>
-        </div>
-        <div class="content">
-            <p>Hallo {{.EmployeeName}},</p>
-            <p>hier ist Ihr Wochenbericht für die vergangene Woche:</p>
-            
-            <div class="summary">
-                <h3>Zusammenfassung</h3>
-                <p>Gesamtarbeitszeit: <span class="hours">{{.TotalHours}} Stunden</span></p>
-            </div>
-            
-            {{if .Activities}}
-            <div class="activity-list">
-                <h3>Aktivitäten dieser Woche</h3>
-                {{range .Activities}}
-                <div class="activity-item">
-                    <strong>{{.Date.Format "02.01.2006"}}</strong> - {{.Type}}<br>
-                    {{if .Description}}{{.Description}}{{end}}
-                </div>
-                {{end}}
-            </div>
-            {{end}}
-            
-            <p>Haben Sie eine schöne Woche!</p>
-            <p>Mit freundlichen Grüßen,<br>Ihr PeopleFlow Team</p>
-        </div>
-        <div class="footer">
-            <p>Diese E-Mail wurde automatisch generiert. Bei Fragen wenden Sie sich an Ihre Personalabteilung.</p>
-        </div>
-    </div>
-</body>
-</html>`
-
-	tmpl, err := template.New("weeklyReport").Parse(htmlTemplate)
-	if err != nil {
-		return fmt.Errorf("fehler beim Parsen des E-Mail-Templates: %v", err)
+// SendNotificationEmail sendet eine Benachrichtigung per E-Mail
+func (es *EmailService) SendNotificationEmail(user *model.User, notification *model.Notification) error {
+	link := ""
+	if notification.Link != "" {
+		link = GetBaseURL() + notification.Link
 	}
 
-	var body bytes.Buffer
-	err = tmpl.Execute(&body, map[string]interface{}{
-		"EmployeeName": employee.FirstName + " " + employee.LastName,
-		"WeekStart":    weekStart.Format("02.01.2006"),
-		"WeekEnd":      weekEnd.Format("02.01.2006"),
-		"TotalHours":   fmt.Sprintf("%.1f", totalHours),
-		"Activities":   activities,
+	return es.SendTemplate(user.Email, model.EmailTemplateNotification, es.LanguageFor(user), map[string]interface{}{
+		"Name":    user.GetDisplayName(),
+		"Title":   notification.Title,
+		"Message": notification.Message,
+		"Link":    link,
 	})
-	if err != nil {
-		return fmt.Errorf("fehler beim Ausführen des E-Mail-Templates: %v", err)
-	}
-
-	return es.SendEmail(employee.Email, subject, body.String(), true)
 }
 
-// SendTestEmail sendet eine Test-E-Mail zur Überprüfung der Konfiguration
+// SendTestEmail sendet eine Test-E-Mail zur Überprüfung der Konfiguration.
+// Sie wird sofort zugestellt, damit ein Fehler der SMTP-Einstellungen direkt angezeigt wird.
 func (es *EmailService) SendTestEmail(to string) error {
-	subject := "Test-E-Mail - PeopleFlow SMTP-Konfiguration"
-	
-	body := `
-Hallo,
-
-dies ist eine Test-E-Mail zur Überprüfung Ihrer SMTP-Konfiguration in PeopleFlow.
-
-Wenn Sie diese E-Mail erhalten, ist Ihre E-Mail-Konfiguration korrekt eingerichtet.
-
-Mit freundlichen Grüßen,
-Ihr PeopleFlow System
-
----
-Diese E-Mail wurde automatisch generiert am ` + time.Now().Format("02.01.2006 15:04:05") + `
-`
-
-	return es.SendEmail(to, subject, body, false)
+	email, err := es.renderOutboxEmail(to, model.EmailTemplateTest, es.SystemLanguage(), map[string]interface{}{
+		"SentAt": time.Now().Format("02.01.2006 15:04:05"),
+	})
+	if err != nil {
+		return err
+	}
+	return es.sendImmediately(email)
 }
 
 // IsEmailConfigured prüft, ob E-Mail-Funktionen verfügbar sind
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"bufio"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"PeopleFlow/backend/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSMTPServer ist ein minimaler SMTP-Server für Tests. Er nimmt Nachrichten an
+// und kann Empfänger mit einem Fehlercode abweisen.
+type fakeSMTPServer struct {
+	listener   net.Listener
+	rejectRcpt string
+
+	mu       sync.Mutex
+	messages []fakeSMTPMessage
+}
+
+type fakeSMTPMessage struct {
+	From string
+	To   []string
+	Auth string
+	Data string
+}
+
+func startFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := &fakeSMTPServer{listener: listener}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.handle(conn)
+		}
+	}()
+	return server
+}
+
+func (s *fakeSMTPServer) settings() *model.EmailNotificationSettings {
+	addr := s.listener.Addr().(*net.TCPAddr)
+	return &model.EmailNotificationSettings{
+		Enabled:   true,
+		SMTPHost:  "127.0.0.1",
+		SMTPPort:  addr.Port,
+		SMTPUser:  "mailer",
+		SMTPPass:  "secret",
+		FromEmail: "noreply@example.com",
+		FromName:  "PeopleFlow",
+	}
+}
+
+func (s *fakeSMTPServer) received() []fakeSMTPMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]fakeSMTPMessage(nil), s.messages...)
+}
+
+func (s *fakeSMTPServer) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { _, _ = io.WriteString(conn, line+"\r\n") }
+
+	var current fakeSMTPMessage
+	reply("220 fake.smtp ESMTP")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		command := strings.ToUpper(line)
+
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			reply("250-fake.smtp")
+			reply("250 AUTH PLAIN")
+		case strings.HasPrefix(command, "AUTH PLAIN"):
+			current.Auth = strings.TrimSpace(line[len("AUTH PLAIN"):])
+			reply("235 Authentication successful")
+		case strings.HasPrefix(command, "MAIL FROM:"):
+			current.From = strings.Trim(line[len("MAIL FROM:"):], "<> ")
+			reply("250 OK")
+		case strings.HasPrefix(command, "RCPT TO:"):
+			recipient := strings.Trim(line[len("RCPT TO:"):], "<> ")
+			if recipient == s.rejectRcpt {
+				reply("550 Mailbox unavailable")
+				continue
+			}
+			current.To = append(current.To, recipient)
+			reply("250 OK")
+		case command == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				dataLine, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				data.WriteString(strings.TrimPrefix(dataLine, "."))
+			}
+			current.Data = data.String()
+			s.mu.Lock()
+			s.messages = append(s.messages, current)
+			s.mu.Unlock()
+			current = fakeSMTPMessage{}
+			reply("250 OK: queued")
+		case command == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+func TestSendSMTP_DeliversMultipartMessage(t *testing.T) {
+	server := startFakeSMTPServer(t)
+	settings := server.settings()
+
+	html := "<p>Hallo Anna,</p><p><a href=\"https://example.com/reset\">Passwort zurücksetzen</a></p>"
+	msg, err := buildEmailMessage(settings.FromName, settings.FromEmail, "anna@example.com", "Passwort zurücksetzen", html, true)
+	require.NoError(t, err)
+
+	require.NoError(t, sendSMTP(settings, "anna@example.com", msg))
+
+	messages := server.received()
+	require.Len(t, messages, 1)
+	assert.Equal(t, "noreply@example.com", messages[0].From)
+	assert.Equal(t, []string{"anna@example.com"}, messages[0].To)
+	assert.NotEmpty(t, messages[0].Auth, "credentials should be sent via AUTH PLAIN")
+
+	parsed, err := mail.ReadMessage(strings.NewReader(messages[0].Data))
+	require.NoError(t, err)
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, "Passwort zurücksetzen", subject)
+
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	var contentTypes []string
+	parts := multipart.NewReader(parsed.Body, params["boundary"])
+	for {
+		part, err := parts.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		body, err := io.ReadAll(part)
+		require.NoError(t, err)
+		contentTypes = append(contentTypes, part.Header.Get("Content-Type"))
+		if strings.HasPrefix(part.Header.Get("Content-Type"), "text/plain") {
+			assert.Contains(t, string(body), "Passwort zurücksetzen (https://example.com/reset)")
+		}
+	}
+	assert.Equal(t, []string{"text/plain; charset=UTF-8", "text/html; charset=UTF-8"}, contentTypes)
+}
+
+func TestSendSMTP_RejectedRecipient(t *testing.T) {
+	server := startFakeSMTPServer(t)
+	server.rejectRcpt = "gone@example.com"
+
+	msg, err := buildEmailMessage("PeopleFlow", "noreply@example.com", "gone@example.com", "Test", "Hallo", false)
+	require.NoError(t, err)
+
+	err = sendSMTP(server.settings(), "gone@example.com", msg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "550")
+	assert.Empty(t, server.received())
+}
+
+func TestSendSMTP_ServerUnavailable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	settings := &model.EmailNotificationSettings{SMTPHost: "127.0.0.1", SMTPPort: port, FromEmail: "noreply@example.com"}
+	err = sendSMTP(settings, "anna@example.com", []byte("Subject: Test\r\n\r\nHallo"))
+	assert.Error(t, err)
+}
+
+func TestEmailRateLimiter(t *testing.T) {
+	limiter := newEmailRateLimiter(2, time.Minute)
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.True(t, limiter.Allow(now))
+	assert.True(t, limiter.Allow(now.Add(10*time.Second)))
+	assert.False(t, limiter.Allow(now.Add(20*time.Second)), "third mail within a minute must wait")
+	assert.True(t, limiter.Allow(now.Add(61*time.Second)), "the window slides after a minute")
+}
@@ -0,0 +1,366 @@
+// backend/service/email_template_defaults.go
+package service
+
+import "PeopleFlow/backend/model"
+
+// emailLayout umschließt den Inhalt jeder Vorlage mit Kopf- und Fußzeile.
+// Der Inhalt ist bereits gerendertes HTML der Vorlage.
+const emailLayout = `<!DOCTYPE html>
+<html lang="{{.Language}}">
+<head>
+    <meta charset="UTF-8">
+    <style>
+        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
+        .header { background-color: #10b981; color: white; padding: 20px; text-align: center; }
+        .content { padding: 20px; background-color: #f9f9f9; }
+        .button { display: inline-block; padding: 12px 24px; background-color: #10b981; color: white; text-decoration: none; border-radius: 5px; margin: 20px 0; }
+        .summary { background-color: white; padding: 15px; margin: 20px 0; border-radius: 5px; border-left: 4px solid #10b981; }
+        .footer { text-align: center; padding: 20px; font-size: 12px; color: #666; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>{{.Heading}}</h1>
+        </div>
+        <div class="content">
+{{.Content}}
+        </div>
+        <div class="footer">
+            <p>{{.Footer}}</p>
+        </div>
+    </div>
+</body>
+</html>`
+
+// emailFooters ist der Hinweis in der Fußzeile je Sprache
+var emailFooters = map[string]string{
+	model.EmailLanguageGerman:  "Diese E-Mail wurde automatisch generiert. Bitte antworten Sie nicht auf diese E-Mail.",
+	model.EmailLanguageEnglish: "This email was generated automatically. Please do not reply to this email.",
+}
+
+// defaultEmailTemplates sind die eingebauten Vorlagen je Art und Sprache
+var defaultEmailTemplates = map[model.EmailTemplateKey]map[string]model.EmailTemplate{
+	model.EmailTemplatePasswordReset: {
+		model.EmailLanguageGerman: {
+			Subject: "Passwort zurücksetzen - {{.CompanyName}}",
+			Body: `<p>Hallo,</p>
+<p>Sie haben eine Anfrage zum Zurücksetzen Ihres Passworts gestellt. Klicken Sie auf den folgenden Link, um ein neues Passwort zu erstellen:</p>
+<p style="text-align: center;"><a href="{{.ResetURL}}" class="button">Passwort zurücksetzen</a></p>
+<p>Dieser Link ist 1 Stunde gültig.</p>
+<p>Falls Sie diese Anfrage nicht gestellt haben, können Sie diese E-Mail ignorieren.</p>
+<p>Mit freundlichen Grüßen,<br>Ihr {{.CompanyName}} Team</p>`,
+		},
+		model.EmailLanguageEnglish: {
+			Subject: "Reset your password - {{.CompanyName}}",
+			Body: `<p>Hello,</p>
+<p>We received a request to reset your password. Click the link below to choose a new password:</p>
+<p style="text-align: center;"><a href="{{.ResetURL}}" class="button">Reset password</a></p>
+<p>This link is valid for 1 hour.</p>
+<p>If you did not request this, you can ignore this email.</p>
+<p>Kind regards,<br>Your {{.CompanyName}} team</p>`,
+		},
+	},
+	model.EmailTemplateInvitation: {
+		model.EmailLanguageGerman: {
+			Subject: "Einladung zu {{.CompanyName}}",
+			Body: `<p>Hallo {{.Name}},</p>
+<p>{{if .InvitedBy}}{{.InvitedBy}} hat Sie{{else}}Sie wurden{{end}} zu PeopleFlow eingeladen. Klicken Sie auf den folgenden Link, um Ihr Konto zu aktivieren und ein eigenes Passwort festzulegen:</p>
+<p style="text-align: center;"><a href="{{.ActivationURL}}" class="button">Konto aktivieren</a></p>
+<p>Dieser Link ist gültig bis {{.ExpiresAt}}.</p>
+<p>Mit freundlichen Grüßen,<br>Ihr {{.CompanyName}} Team</p>`,
+		},
+		model.EmailLanguageEnglish: {
+			Subject: "Invitation to {{.CompanyName}}",
+			Body: `<p>Hello {{.Name}},</p>
+<p>{{if .InvitedBy}}{{.InvitedBy}} has invited you{{else}}You have been invited{{end}} to PeopleFlow. Click the link below to activate your account and choose a password:</p>
+<p style="text-align: center;"><a href="{{.ActivationURL}}" class="button">Activate account</a></p>
+<p>This link is valid until {{.ExpiresAt}}.</p>
+<p>Kind regards,<br>Your {{.CompanyName}} team</p>`,
+		},
+	},
+	model.EmailTemplateNotification: {
+		model.EmailLanguageGerman: {
+			Subject: "{{.Title}}",
+			Body: `<p>Hallo {{.Name}},</p>
+<p>{{.Message}}</p>
+{{if .Link}}<p style="text-align: center;"><a href="{{.Link}}" class="button">In PeopleFlow öffnen</a></p>{{end}}
+<p>Sie können in Ihrem Profil unter „Benachrichtigungen“ festlegen, welche E-Mails Sie erhalten.</p>`,
+		},
+		model.EmailLanguageEnglish: {
+			Subject: "{{.Title}}",
+			Body: `<p>Hello {{.Name}},</p>
+<p>{{.Message}}</p>
+{{if .Link}}<p style="text-align: center;"><a href="{{.Link}}" class="button">Open in PeopleFlow</a></p>{{end}}
+<p>You can choose which emails you receive under "Notifications" in your profile.</p>`,
+		},
+	},
+	model.EmailTemplateWeeklyReport: {
+		model.EmailLanguageGerman: {
+			Subject: "Wochenbericht {{.WeekStart}} - {{.WeekEnd}}",
+			Body: `<p>Hallo {{.EmployeeName}},</p>
+<p>hier ist Ihr Wochenbericht für die Woche vom {{.WeekStart}} bis {{.WeekEnd}}:</p>
+<div class="summary">
+    <h3>Zusammenfassung</h3>
+    <p>Erfasste Arbeitszeit: <strong>{{.TotalHours}} Stunden</strong></p>
+{{if .ShowOvertime}}    <p>Soll-Arbeitszeit: {{.PlannedHours}} Stunden (Differenz {{.Difference}})</p>
+    <p>Aktuelles Überstundensaldo: <strong>{{.OvertimeBalance}} Stunden</strong></p>
+{{end}}</div>
+{{if and .ShowDays .Days}}<h3>Stunden je Tag</h3>
+<ul>
+{{range .Days}}    <li><strong>{{.Date}}</strong> – {{.Hours}} Std.</li>
+{{end}}</ul>{{end}}
+{{if and .ShowProjects .Projects}}<h3>Stunden je Projekt</h3>
+<ul>
+{{range .Projects}}    <li>{{.Name}} – {{.Hours}} Std.</li>
+{{end}}</ul>{{end}}
+<p>Haben Sie eine schöne Woche!</p>
+<p>Mit freundlichen Grüßen,<br>Ihr {{.CompanyName}} Team</p>`,
+		},
+		model.EmailLanguageEnglish: {
+			Subject: "Weekly report {{.WeekStart}} - {{.WeekEnd}}",
+			Body: `<p>Hello {{.EmployeeName}},</p>
+<p>here is your weekly report for {{.WeekStart}} to {{.WeekEnd}}:</p>
+<div class="summary">
+    <h3>Summary</h3>
+    <p>Recorded working time: <strong>{{.TotalHours}} hours</strong></p>
+{{if .ShowOvertime}}    <p>Target working time: {{.PlannedHours}} hours (difference {{.Difference}})</p>
+    <p>Current overtime balance: <strong>{{.OvertimeBalance}} hours</strong></p>
+{{end}}</div>
+{{if and .ShowDays .Days}}<h3>Hours per day</h3>
+<ul>
+{{range .Days}}    <li><strong>{{.Date}}</strong> – {{.Hours}} h</li>
+{{end}}</ul>{{end}}
+{{if and .ShowProjects .Projects}}<h3>Hours per project</h3>
+<ul>
+{{range .Projects}}    <li>{{.Name}} – {{.Hours}} h</li>
+{{end}}</ul>{{end}}
+<p>Have a great week!</p>
+<p>Kind regards,<br>Your {{.CompanyName}} team</p>`,
+		},
+	},
+	model.EmailTemplateMonthlyOvertime: {
+		model.EmailLanguageGerman: {
+			Subject: "Überstunden-Abrechnung {{.Month}}",
+			Body: `<p>Hallo {{.EmployeeName}},</p>
+<p>hier ist Ihre Überstunden-Abrechnung für {{.Month}}:</p>
+<div class="summary">
+    <p>Soll-Arbeitszeit: {{.PlannedHours}} Stunden</p>
+    <p>Erfasste Arbeitszeit: {{.ActualHours}} Stunden</p>
+    <p>Differenz im Monat: <strong>{{.Difference}} Stunden</strong></p>
+    <p>Aktuelles Überstundensaldo: <strong>{{.OvertimeBalance}} Stunden</strong></p>
+</div>
+{{if and .ShowWeeks .Weeks}}<h3>Aufstellung je Woche</h3>
+<ul>
+{{range .Weeks}}    <li><strong>KW {{.Week}}</strong> ({{.Start}}–{{.End}}): {{.ActualHours}} von {{.PlannedHours}} Std. ({{.Difference}})</li>
+{{end}}</ul>{{end}}
+{{if and .ShowAdjustments .Adjustments}}<h3>Überstunden-Anpassungen</h3>
+<ul>
+{{range .Adjustments}}    <li>{{.Date}} – {{.Type}}: {{.Hours}} Std.{{if .Reason}} ({{.Reason}}){{end}}</li>
+{{end}}</ul>{{end}}
+<p>Mit freundlichen Grüßen,<br>Ihr {{.CompanyName}} Team</p>`,
+		},
+		model.EmailLanguageEnglish: {
+			Subject: "Overtime statement {{.Month}}",
+			Body: `<p>Hello {{.EmployeeName}},</p>
+<p>here is your overtime statement for {{.Month}}:</p>
+<div class="summary">
+    <p>Target working time: {{.PlannedHours}} hours</p>
+    <p>Recorded working time: {{.ActualHours}} hours</p>
+    <p>Difference this month: <strong>{{.Difference}} hours</strong></p>
+    <p>Current overtime balance: <strong>{{.OvertimeBalance}} hours</strong></p>
+</div>
+{{if and .ShowWeeks .Weeks}}<h3>Weekly breakdown</h3>
+<ul>
+{{range .Weeks}}    <li><strong>CW {{.Week}}</strong> ({{.Start}}–{{.End}}): {{.ActualHours}} of {{.PlannedHours}} h ({{.Difference}})</li>
+{{end}}</ul>{{end}}
+{{if and .ShowAdjustments .Adjustments}}<h3>Overtime adjustments</h3>
+<ul>
+{{range .Adjustments}}    <li>{{.Date}} – {{.Type}}: {{.Hours}} h{{if .Reason}} ({{.Reason}}){{end}}</li>
+{{end}}</ul>{{end}}
+<p>Kind regards,<br>Your {{.CompanyName}} team</p>`,
+		},
+	},
+	model.EmailTemplateTeamDigest: {
+		model.EmailLanguageGerman: {
+			Subject: "Team-Übersicht {{.PeriodStart}} - {{.PeriodEnd}}",
+			Body: `<p>Hallo {{.Name}},</p>
+<p>hier ist die Übersicht {{if .Department}}der Abteilung {{.Department}}{{else}}Ihres Teams{{end}} für den Zeitraum vom {{.PeriodStart}} bis {{.PeriodEnd}}:</p>
+<div class="summary">
+    <p>Mitarbeiter: {{.MemberCount}}</p>
+    <p>Erfasste Arbeitszeit insgesamt: <strong>{{.TotalHours}} Stunden</strong></p>
+</div>
+{{if and .ShowHours .Members}}<h3>Stunden je Mitarbeiter</h3>
+<ul>
+{{range .Members}}    <li><strong>{{.Name}}</strong>: {{.ActualHours}} von {{.PlannedHours}} Std.{{if $.ShowOvertime}} ({{.Difference}}, Saldo {{.OvertimeBalance}}){{end}}{{if ne .AbsenceDays "0"}}, {{.AbsenceDays}} Tage abwesend{{end}}</li>
+{{end}}</ul>{{end}}
+{{if and .ShowAbsences .Absences}}<h3>Abwesenheiten</h3>
+<ul>
+{{range .Absences}}    <li>{{.EmployeeName}} – {{.Type}} vom {{.StartDate}} bis {{.EndDate}} ({{.Days}} Tage im Zeitraum)</li>
+{{end}}</ul>{{end}}
+<p>Mit freundlichen Grüßen,<br>Ihr {{.CompanyName}} Team</p>`,
+		},
+		model.EmailLanguageEnglish: {
+			Subject: "Team digest {{.PeriodStart}} - {{.PeriodEnd}}",
+			Body: `<p>Hello {{.Name}},</p>
+<p>here is the overview {{if .Department}}of the {{.Department}} department{{else}}of your team{{end}} for {{.PeriodStart}} to {{.PeriodEnd}}:</p>
+<div class="summary">
+    <p>Employees: {{.MemberCount}}</p>
+    <p>Total recorded working time: <strong>{{.TotalHours}} hours</strong></p>
+</div>
+{{if and .ShowHours .Members}}<h3>Hours per employee</h3>
+<ul>
+{{range .Members}}    <li><strong>{{.Name}}</strong>: {{.ActualHours}} of {{.PlannedHours}} h{{if $.ShowOvertime}} ({{.Difference}}, balance {{.OvertimeBalance}}){{end}}{{if ne .AbsenceDays "0"}}, absent {{.AbsenceDays}} days{{end}}</li>
+{{end}}</ul>{{end}}
+{{if and .ShowAbsences .Absences}}<h3>Absences</h3>
+<ul>
+{{range .Absences}}    <li>{{.EmployeeName}} – {{.Type}} from {{.StartDate}} to {{.EndDate}} ({{.Days}} days in this period)</li>
+{{end}}</ul>{{end}}
+<p>Kind regards,<br>Your {{.CompanyName}} team</p>`,
+		},
+	},
+	model.EmailTemplateMonthlyAbsences: {
+		model.EmailLanguageGerman: {
+			Subject: "Abwesenheitsübersicht {{.Month}}",
+			Body: `<p>Hallo {{.Name}},</p>
+<p>hier ist die Übersicht der genehmigten Abwesenheiten {{if .Department}}der Abteilung {{.Department}} {{end}}im {{.Month}}:</p>
+<div class="summary">
+    <p>Abwesenheitstage insgesamt: <strong>{{.TotalDays}}</strong></p>
+{{if .ShowTotals}}{{range .Totals}}    <p>{{.Type}}: {{.Days}} Tage ({{.Count}} Abwesenheiten)</p>
+{{end}}{{end}}</div>
+{{if and .ShowAbsences .Absences}}<h3>Abwesenheiten</h3>
+<ul>
+{{range .Absences}}    <li>{{.EmployeeName}}{{if .Department}} ({{.Department}}){{end}} – {{.Type}} vom {{.StartDate}} bis {{.EndDate}}: {{.Days}} Tage</li>
+{{end}}</ul>{{end}}
+<p>Mit freundlichen Grüßen,<br>Ihr {{.CompanyName}} Team</p>`,
+		},
+		model.EmailLanguageEnglish: {
+			Subject: "Absence summary {{.Month}}",
+			Body: `<p>Hello {{.Name}},</p>
+<p>here is the summary of approved absences {{if .Department}}in the {{.Department}} department {{end}}for {{.Month}}:</p>
+<div class="summary">
+    <p>Total days of absence: <strong>{{.TotalDays}}</strong></p>
+{{if .ShowTotals}}{{range .Totals}}    <p>{{.Type}}: {{.Days}} days ({{.Count}} absences)</p>
+{{end}}{{end}}</div>
+{{if and .ShowAbsences .Absences}}<h3>Absences</h3>
+<ul>
+{{range .Absences}}    <li>{{.EmployeeName}}{{if .Department}} ({{.Department}}){{end}} – {{.Type}} from {{.StartDate}} to {{.EndDate}}: {{.Days}} days</li>
+{{end}}</ul>{{end}}
+<p>Kind regards,<br>Your {{.CompanyName}} team</p>`,
+		},
+	},
+	model.EmailTemplateTest: {
+		model.EmailLanguageGerman: {
+			Subject: "Test-E-Mail - {{.CompanyName}} SMTP-Konfiguration",
+			Body: `<p>Hallo,</p>
+<p>dies ist eine Test-E-Mail zur Überprüfung Ihrer SMTP-Konfiguration in PeopleFlow.</p>
+<p>Wenn Sie diese E-Mail erhalten, ist Ihre E-Mail-Konfiguration korrekt eingerichtet.</p>
+<p>Gesendet am {{.SentAt}}</p>`,
+		},
+		model.EmailLanguageEnglish: {
+			Subject: "Test email - {{.CompanyName}} SMTP configuration",
+			Body: `<p>Hello,</p>
+<p>this is a test email to verify your SMTP configuration in PeopleFlow.</p>
+<p>If you received this email, your email settings are working.</p>
+<p>Sent at {{.SentAt}}</p>`,
+		},
+	},
+}
+
+// emailTemplateSamples sind Beispieldaten für die Vorschau im Editor
+var emailTemplateSamples = map[model.EmailTemplateKey]map[string]interface{}{
+	model.EmailTemplatePasswordReset: {
+		"ResetURL": "https://peopleflow.example.com/reset-password?token=beispiel",
+	},
+	model.EmailTemplateInvitation: {
+		"Name":          "Anna Schmidt",
+		"InvitedBy":     "Max Müller",
+		"ActivationURL": "https://peopleflow.example.com/activate?token=beispiel",
+		"ExpiresAt":     "24.12.2026 18:00",
+	},
+	model.EmailTemplateNotification: {
+		"Name":    "Anna Schmidt",
+		"Title":   "Neuer Abwesenheitsantrag von Max Müller",
+		"Message": "Abwesenheitsantrag gestellt: Urlaub vom 02.01.2027 bis 09.01.2027",
+		"Link":    "https://peopleflow.example.com/absence-overview",
+	},
+	model.EmailTemplateWeeklyReport: {
+		"EmployeeName":    "Anna Schmidt",
+		"WeekStart":       "14.12.2026",
+		"WeekEnd":         "20.12.2026",
+		"TotalHours":      "38.5",
+		"PlannedHours":    "40.0",
+		"Difference":      "-1.5",
+		"OvertimeBalance": "+12.5",
+		"Days": []map[string]string{
+			{"Date": "Mo 14.12.2026", "Hours": "8.0"},
+			{"Date": "Di 15.12.2026", "Hours": "7.5"},
+			{"Date": "Mi 16.12.2026", "Hours": "8.5"},
+			{"Date": "Do 17.12.2026", "Hours": "8.0"},
+			{"Date": "Fr 18.12.2026", "Hours": "6.5"},
+		},
+		"Projects": []map[string]string{
+			{"Name": "Website-Relaunch", "Hours": "30.0"},
+			{"Name": "Intern", "Hours": "8.5"},
+		},
+		"ShowDays":     true,
+		"ShowProjects": true,
+		"ShowOvertime": true,
+	},
+	model.EmailTemplateMonthlyOvertime: {
+		"EmployeeName":    "Anna Schmidt",
+		"Month":           "November 2026",
+		"PlannedHours":    "168.0",
+		"ActualHours":     "174.5",
+		"Difference":      "+6.5",
+		"OvertimeBalance": "+12.5",
+		"Weeks": []map[string]string{
+			{"Week": "45", "Start": "02.11.", "End": "08.11.", "PlannedHours": "40.0", "ActualHours": "42.5", "Difference": "+2.5"},
+			{"Week": "46", "Start": "09.11.", "End": "15.11.", "PlannedHours": "40.0", "ActualHours": "44.0", "Difference": "+4.0"},
+		},
+		"Adjustments": []map[string]string{
+			{"Date": "20.11.2026", "Type": "Auszahlung", "Hours": "-10.0", "Reason": "Auszahlung mit Novembergehalt"},
+		},
+		"ShowWeeks":       true,
+		"ShowAdjustments": true,
+	},
+	model.EmailTemplateTeamDigest: {
+		"Name":        "Max Müller",
+		"Department":  "",
+		"PeriodStart": "14.12.2026",
+		"PeriodEnd":   "20.12.2026",
+		"MemberCount": 2,
+		"TotalHours":  "70.5",
+		"Members": []map[string]string{
+			{"Name": "Anna Schmidt", "ActualHours": "38.5", "PlannedHours": "40.0", "Difference": "-1.5", "OvertimeBalance": "+12.5", "AbsenceDays": "0"},
+			{"Name": "Tom Weber", "ActualHours": "32.0", "PlannedHours": "32.0", "Difference": "+0.0", "OvertimeBalance": "-2.0", "AbsenceDays": "1"},
+		},
+		"Absences": []map[string]string{
+			{"EmployeeName": "Tom Weber", "Type": "Urlaub", "StartDate": "18.12.2026", "EndDate": "18.12.2026", "Days": "1"},
+		},
+		"ShowHours":    true,
+		"ShowOvertime": true,
+		"ShowAbsences": true,
+	},
+	model.EmailTemplateMonthlyAbsences: {
+		"Name":       "Eva Hofmann",
+		"Month":      "November 2026",
+		"Department": "",
+		"TotalDays":  "7.5",
+		"Totals": []map[string]string{
+			{"Type": "Urlaub", "Days": "5", "Count": "1"},
+			{"Type": "Krankheit", "Days": "2.5", "Count": "2"},
+		},
+		"Absences": []map[string]string{
+			{"EmployeeName": "Anna Schmidt", "Department": "IT", "Type": "Urlaub", "StartDate": "02.11.2026", "EndDate": "06.11.2026", "Days": "5"},
+			{"EmployeeName": "Tom Weber", "Department": "Sales", "Type": "Krankheit", "StartDate": "12.11.2026", "EndDate": "13.11.2026", "Days": "2"},
+			{"EmployeeName": "Tom Weber", "Department": "Sales", "Type": "Krankheit", "StartDate": "24.11.2026", "EndDate": "24.11.2026", "Days": "0.5"},
+		},
+		"ShowTotals":   true,
+		"ShowAbsences": true,
+	},
+	model.EmailTemplateTest: {
+		"SentAt": "18.10.2026 09:30:00",
+	},
+}
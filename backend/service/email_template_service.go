@@ -0,0 +1,219 @@
+// backend/service/email_template_service.go
+package service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"time"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+)
+
+// EmailTemplateInfo ist ein Eintrag der Vorlagenübersicht
+type EmailTemplateInfo struct {
+	Key        model.EmailTemplateKey `json:"key"`
+	Label      string                 `json:"label"`
+	Language   string                 `json:"language"`
+	Customized bool                   `json:"customized"`
+	UpdatedAt  *time.Time             `json:"updatedAt,omitempty"`
+}
+
+// EmailPreview ist eine mit Beispieldaten gerenderte Vorlage
+type EmailPreview struct {
+	Subject string `json:"subject"`
+	HTML    string `json:"html"`
+	Text    string `json:"text"`
+}
+
+var emailLayoutTemplate = template.Must(template.New("layout").Parse(emailLayout))
+
+// SystemLanguage gibt die Systemsprache zurück, sofern es dafür Vorlagen gibt, sonst Deutsch
+func (es *EmailService) SystemLanguage() string {
+	settings, err := es.settingsRepo.GetSettings()
+	if err == nil && model.IsValidEmailLanguage(settings.Language) {
+		return settings.Language
+	}
+	return model.EmailLanguageGerman
+}
+
+// LanguageFor bestimmt die Sprache für einen Empfänger: eigene Einstellung, sonst Systemsprache
+func (es *EmailService) LanguageFor(user *model.User) string {
+	if user != nil && model.IsValidEmailLanguage(user.Language) {
+		return user.Language
+	}
+	return es.SystemLanguage()
+}
+
+// SendTemplate rendert eine Vorlage in der angegebenen Sprache und legt die E-Mail im Postausgang ab
+func (es *EmailService) SendTemplate(to string, key model.EmailTemplateKey, language string, data map[string]interface{}) error {
+	email, err := es.renderOutboxEmail(to, key, language, data)
+	if err != nil {
+		return err
+	}
+	return es.enqueue(email)
+}
+
+// renderOutboxEmail erstellt aus einer Vorlage eine E-Mail für den Postausgang
+func (es *EmailService) renderOutboxEmail(to string, key model.EmailTemplateKey, language string, data map[string]interface{}) (*model.OutboxEmail, error) {
+	emailTemplate, _, err := es.GetTemplate(key, language)
+	if err != nil {
+		return nil, err
+	}
+
+	subject, html, err := es.render(emailTemplate, data)
+	if err != nil {
+		return nil, err
+	}
+	return &model.OutboxEmail{
+		To:          to,
+		Subject:     subject,
+		Body:        html,
+		IsHTML:      true,
+		TemplateKey: key,
+	}, nil
+}
+
+// GetTemplate gibt die angepasste Vorlage zurück, sonst die Standardvorlage (customized = false)
+func (es *EmailService) GetTemplate(key model.EmailTemplateKey, language string) (*model.EmailTemplate, bool, error) {
+	if !key.IsValid() {
+		return nil, false, fmt.Errorf("%w: %s", model.ErrInvalidEmailTemplateKey, key)
+	}
+	if !model.IsValidEmailLanguage(language) {
+		return nil, false, fmt.Errorf("%w: %s", model.ErrInvalidEmailLanguage, language)
+	}
+
+	stored, err := es.templateRepo.Find(key, language)
+	if err == nil {
+		return stored, true, nil
+	}
+	if !errors.Is(err, repository.ErrEmailTemplateNotFound) {
+		return nil, false, err
+	}
+
+	fallback := defaultEmailTemplates[key][language]
+	fallback.Key = key
+	fallback.Language = language
+	return &fallback, false, nil
+}
+
+// DefaultTemplate gibt die eingebaute Vorlage zurück
+func (es *EmailService) DefaultTemplate(key model.EmailTemplateKey, language string) model.EmailTemplate {
+	fallback := defaultEmailTemplates[key][language]
+	fallback.Key = key
+	fallback.Language = language
+	return fallback
+}
+
+// ListTemplates gibt alle Vorlagenarten je Sprache mit ihrem Anpassungsstatus zurück
+func (es *EmailService) ListTemplates() ([]EmailTemplateInfo, error) {
+	stored, err := es.templateRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	customized := make(map[string]*model.EmailTemplate)
+	for _, t := range stored {
+		customized[string(t.Key)+"/"+t.Language] = t
+	}
+
+	var infos []EmailTemplateInfo
+	for _, key := range model.EmailTemplateKeys() {
+		for _, language := range model.EmailLanguages() {
+			info := EmailTemplateInfo{Key: key, Label: key.GetLabel(), Language: language}
+			if t, ok := customized[string(key)+"/"+language]; ok {
+				info.Customized = true
+				updatedAt := t.UpdatedAt
+				info.UpdatedAt = &updatedAt
+			}
+			infos = append(infos, info)
+		}
+	}
+	return infos, nil
+}
+
+// SaveTemplate speichert eine angepasste Vorlage
+func (es *EmailService) SaveTemplate(user *model.User, key model.EmailTemplateKey, language, subject, body string) (*model.EmailTemplate, error) {
+	emailTemplate := &model.EmailTemplate{
+		Key:       key,
+		Language:  language,
+		Subject:   subject,
+		Body:      body,
+		UpdatedBy: user.ID,
+	}
+
+	// Mit Beispieldaten rendern, damit Laufzeitfehler (z.B. unbekannte Felder in range) auffallen
+	if err := emailTemplate.Validate(); err != nil {
+		return nil, err
+	}
+	if _, _, err := es.render(emailTemplate, emailTemplateSamples[key]); err != nil {
+		return nil, err
+	}
+
+	if err := es.templateRepo.Save(emailTemplate); err != nil {
+		return nil, err
+	}
+	return emailTemplate, nil
+}
+
+// ResetTemplate löscht die Anpassung, danach gilt wieder die Standardvorlage
+func (es *EmailService) ResetTemplate(key model.EmailTemplateKey, language string) error {
+	return es.templateRepo.Delete(key, language)
+}
+
+// PreviewTemplate rendert einen (noch nicht gespeicherten) Entwurf mit Beispieldaten
+func (es *EmailService) PreviewTemplate(key model.EmailTemplateKey, language, subject, body string) (*EmailPreview, error) {
+	draft := &model.EmailTemplate{Key: key, Language: language, Subject: subject, Body: body}
+	if err := draft.Validate(); err != nil {
+		return nil, err
+	}
+
+	renderedSubject, html, err := es.render(draft, emailTemplateSamples[key])
+	if err != nil {
+		return nil, err
+	}
+	return &EmailPreview{
+		Subject: renderedSubject,
+		HTML:    html,
+		Text:    model.EmailPlainText(html),
+	}, nil
+}
+
+// render füllt die Vorlage inklusive der allgemeinen Variablen und bettet sie in das Layout ein
+func (es *EmailService) render(emailTemplate *model.EmailTemplate, data map[string]interface{}) (string, string, error) {
+	values := map[string]interface{}{
+		"CompanyName": es.companyName(),
+		"BaseURL":     GetBaseURL(),
+	}
+	for key, value := range data {
+		values[key] = value
+	}
+
+	subject, body, err := emailTemplate.Render(values)
+	if err != nil {
+		return "", "", err
+	}
+
+	var html bytes.Buffer
+	err = emailLayoutTemplate.Execute(&html, map[string]interface{}{
+		"Language": emailTemplate.Language,
+		"Heading":  subject,
+		"Content":  template.HTML(body),
+		"Footer":   emailFooters[emailTemplate.Language],
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("fehler beim Ausführen des E-Mail-Layouts: %v", err)
+	}
+	return subject, html.String(), nil
+}
+
+// companyName gibt den Firmennamen aus den Einstellungen zurück, sonst "PeopleFlow"
+func (es *EmailService) companyName() string {
+	settings, err := es.settingsRepo.GetSettings()
+	if err == nil && settings.CompanyName != "" {
+		return settings.CompanyName
+	}
+	return "PeopleFlow"
+}
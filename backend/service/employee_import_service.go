@@ -0,0 +1,202 @@
+// backend/service/employee_import_service.go
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+	"PeopleFlow/backend/utils"
+)
+
+// EmployeeImportOptions steuert einen Mitarbeiterimport
+type EmployeeImportOptions struct {
+	DryRun         bool // nur prüfen, nichts speichern
+	AllowFinancial bool // Gehalt und Bankdaten dürfen importiert werden
+}
+
+// EmployeeImportService legt Mitarbeiter aus CSV- und XLSX-Dateien an oder aktualisiert sie
+type EmployeeImportService struct {
+	employeeRepo *repository.EmployeeRepository
+}
+
+// NewEmployeeImportService erstellt einen neuen EmployeeImportService
+func NewEmployeeImportService() *EmployeeImportService {
+	return &EmployeeImportService{
+		employeeRepo: repository.NewEmployeeRepository(),
+	}
+}
+
+// ReadEmployeeImportFile liest die Zeilen einer Importdatei; das Format wird an der Dateiendung erkannt
+func ReadEmployeeImportFile(fileName string, content []byte) ([][]string, error) {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv", ".txt":
+		return utils.ReadCSVRows(content)
+	case ".xlsx":
+		return utils.ReadXLSXRows(content)
+	default:
+		return nil, fmt.Errorf("%w: %s", model.ErrEmployeeImportFormat, fileName)
+	}
+}
+
+// Import prüft alle Zeilen der Tabelle und speichert sie, sofern kein Probelauf angefordert ist.
+// Zeilen mit Fehlern werden übersprungen und im Bericht aufgeführt.
+func (s *EmployeeImportService) Import(table *model.EmployeeImportTable, options EmployeeImportOptions) (*model.EmployeeImportReport, error) {
+	existing, _, err := s.employeeRepo.Search(repository.EmployeeQuery{})
+	if err != nil {
+		return nil, err
+	}
+
+	plans := planEmployeeImport(table, existing, options.AllowFinancial, s.employeeRepo.ValidateEmployee)
+
+	report := &model.EmployeeImportReport{
+		DryRun:  options.DryRun,
+		Headers: table.Headers,
+		Columns: table.Columns,
+	}
+	for _, plan := range plans {
+		if !options.DryRun {
+			s.apply(&plan, options.AllowFinancial)
+		}
+		report.Add(plan.result)
+	}
+	return report, nil
+}
+
+// apply speichert eine geplante Zeile. Bei Aktualisierungen wird der Mitarbeiter vollständig neu
+// geladen, da die Suche ohne Profilbilder arbeitet.
+func (s *EmployeeImportService) apply(plan *employeeImportPlan, allowFinancial bool) {
+	fail := func(err error) {
+		plan.result.Action = model.EmployeeImportActionError
+		plan.result.Errors = append(plan.result.Errors, err.Error())
+	}
+
+	switch plan.result.Action {
+	case model.EmployeeImportActionCreate:
+		if err := s.employeeRepo.CreateWithoutAccount(plan.employee); err != nil {
+			fail(err)
+			return
+		}
+		plan.result.ID = plan.employee.ID.Hex()
+
+	case model.EmployeeImportActionUpdate:
+		employee, err := s.employeeRepo.FindByID(plan.result.ID)
+		if err != nil {
+			fail(err)
+			return
+		}
+		before := *employee
+		if _, errs := model.ApplyEmployeeImportRow(employee, plan.row, allowFinancial); len(errs) > 0 {
+			plan.result.Action = model.EmployeeImportActionError
+			plan.result.Errors = errs
+			return
+		}
+		employee.TrackFieldChanges(&before, model.FieldSourcePeopleFlow, time.Now())
+		employee.TrackSalaryChange(&before, "Import", time.Now())
+		if err := s.employeeRepo.Update(employee); err != nil {
+			fail(err)
+		}
+	}
+}
+
+// employeeImportPlan ist das geprüfte Ergebnis einer Zeile vor dem Speichern
+type employeeImportPlan struct {
+	row      model.EmployeeImportRow
+	employee *model.Employee
+	result   model.EmployeeImportRowResult
+}
+
+// planEmployeeImport ordnet jede Zeile einem bestehenden Mitarbeiter zu (zuerst über die
+// Personalnummer, dann über die E-Mail-Adresse) oder plant eine Neuanlage und prüft die Daten.
+func planEmployeeImport(table *model.EmployeeImportTable, existing []*model.Employee, allowFinancial bool, validate func(*model.Employee, bool) error) []employeeImportPlan {
+	byEmployeeID := make(map[string]*model.Employee, len(existing))
+	byEmail := make(map[string]*model.Employee, len(existing))
+	for _, employee := range existing {
+		if employee.EmployeeID != "" {
+			byEmployeeID[employee.EmployeeID] = employee
+		}
+		if employee.Email != "" {
+			byEmail[strings.ToLower(employee.Email)] = employee
+		}
+	}
+
+	seenEmployeeIDs := map[string]int{}
+	seenEmails := map[string]int{}
+	plans := make([]employeeImportPlan, 0, len(table.Rows))
+
+	for _, row := range table.Rows {
+		employeeID := row.Values["employeeId"]
+		email := strings.ToLower(row.Values["email"])
+		plan := employeeImportPlan{row: row, result: model.EmployeeImportRowResult{Row: row.Line, EmployeeID: employeeID}}
+		var errs []string
+
+		if line, ok := seenEmployeeIDs[employeeID]; ok && employeeID != "" {
+			errs = append(errs, fmt.Sprintf("Personalnummer %s kommt bereits in Zeile %d vor", employeeID, line))
+		}
+		if line, ok := seenEmails[email]; ok && email != "" {
+			errs = append(errs, fmt.Sprintf("E-Mail %s kommt bereits in Zeile %d vor", email, line))
+		}
+		if employeeID != "" {
+			seenEmployeeIDs[employeeID] = row.Line
+		}
+		if email != "" {
+			seenEmails[email] = row.Line
+		}
+
+		matchByID := byEmployeeID[employeeID]
+		matchByEmail := byEmail[email]
+		target := matchByID
+		if target == nil {
+			target = matchByEmail
+		}
+		if matchByID != nil && matchByEmail != nil && matchByID != matchByEmail {
+			errs = append(errs, fmt.Sprintf("E-Mail %s gehört bereits zu %s %s (Personalnummer %s)",
+				email, matchByEmail.FirstName, matchByEmail.LastName, matchByEmail.EmployeeID))
+		}
+
+		var changes []string
+		var fieldErrs []string
+		if target != nil {
+			updated := *target
+			plan.employee = &updated
+			plan.result.ID = target.ID.Hex()
+			changes, fieldErrs = model.ApplyEmployeeImportRow(plan.employee, row, allowFinancial)
+			errs = append(errs, fieldErrs...)
+			if len(fieldErrs) == 0 {
+				if err := validate(plan.employee, true); err != nil {
+					errs = append(errs, err.Error())
+				}
+			}
+		} else {
+			plan.employee = &model.Employee{Status: model.EmployeeStatusActive}
+			changes, fieldErrs = model.ApplyEmployeeImportRow(plan.employee, row, allowFinancial)
+			errs = append(errs, fieldErrs...)
+			if len(fieldErrs) == 0 {
+				if err := validate(plan.employee, false); err != nil {
+					errs = append(errs, err.Error())
+				}
+			}
+		}
+
+		plan.result.EmployeeID = plan.employee.EmployeeID
+		plan.result.Name = strings.TrimSpace(plan.employee.FirstName + " " + plan.employee.LastName)
+		plan.result.Changes = changes
+
+		switch {
+		case len(errs) > 0:
+			plan.result.Action = model.EmployeeImportActionError
+			plan.result.Errors = errs
+		case target == nil:
+			plan.result.Action = model.EmployeeImportActionCreate
+		case len(changes) == 0:
+			plan.result.Action = model.EmployeeImportActionUnchanged
+		default:
+			plan.result.Action = model.EmployeeImportActionUpdate
+		}
+		plans = append(plans, plan)
+	}
+	return plans
+}
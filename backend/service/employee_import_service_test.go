@@ -0,0 +1,77 @@
+package service
+
+import (
+	"testing"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestReadEmployeeImportFile(t *testing.T) {
+	rows, err := ReadEmployeeImportFile("Mitarbeiter.CSV", []byte("PNR;Name\n1;Anna\n"))
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"PNR", "Name"}, {"1", "Anna"}}, rows)
+
+	_, err = ReadEmployeeImportFile("Mitarbeiter.xls", nil)
+	assert.ErrorIs(t, err, model.ErrEmployeeImportFormat)
+}
+
+func TestPlanEmployeeImport(t *testing.T) {
+	anna := &model.Employee{ID: primitive.NewObjectID(), EmployeeID: "1001", FirstName: "Anna", LastName: "Schmidt", Email: "anna@example.com", Salary: 4000}
+	ben := &model.Employee{ID: primitive.NewObjectID(), EmployeeID: "1002", FirstName: "Ben", LastName: "Meyer", Email: "ben@example.com", Position: "Vertrieb"}
+	carl := &model.Employee{ID: primitive.NewObjectID(), EmployeeID: "1005", FirstName: "Carl", LastName: "Wagner"}
+
+	table, err := model.NewEmployeeImportTable([][]string{
+		{"Personalnummer", "Vorname", "Nachname", "E-Mail", "Position", "Gehalt"},
+		{"1001", "Anna", "Schmidt", "", "Teamleitung", ""},    // 2: Änderung über Personalnummer
+		{"", "", "", "BEN@example.com", "Vertrieb", ""},       // 3: unverändert, gefunden über E-Mail
+		{"1003", "Clara", "Neu", "clara@example.com", "", ""}, // 4: Neuanlage
+		{"1004", "", "Ohne", "", "", ""},                      // 5: Vorname fehlt
+		{"1003", "Clara", "Doppelt", "", "", ""},              // 6: Personalnummer doppelt
+		{"1005", "", "", "anna@example.com", "", ""},          // 7: Personalnummer und E-Mail passen nicht zusammen
+		{"1002", "", "", "", "", "5000"},                      // 8: Gehalt ohne Berechtigung
+	}, nil)
+	require.NoError(t, err)
+
+	validate := (&repository.EmployeeRepository{}).ValidateEmployee
+	plans := planEmployeeImport(table, []*model.Employee{anna, ben, carl}, false, validate)
+	require.Len(t, plans, 7)
+
+	type result struct {
+		row     int
+		action  model.EmployeeImportAction
+		changes []string
+		errors  int
+	}
+	var got []result
+	for _, plan := range plans {
+		got = append(got, result{plan.result.Row, plan.result.Action, plan.result.Changes, len(plan.result.Errors)})
+	}
+	assert.Equal(t, []result{
+		{2, model.EmployeeImportActionUpdate, []string{"position"}, 0},
+		{3, model.EmployeeImportActionUnchanged, nil, 0},
+		{4, model.EmployeeImportActionCreate, []string{"employeeId", "firstName", "lastName", "email"}, 0},
+		{5, model.EmployeeImportActionError, []string{"employeeId", "lastName"}, 1},
+		{6, model.EmployeeImportActionError, []string{"employeeId", "firstName", "lastName"}, 1},
+		{7, model.EmployeeImportActionError, []string{"email"}, 1},
+		{8, model.EmployeeImportActionError, nil, 1},
+	}, got)
+
+	assert.Equal(t, anna.ID.Hex(), plans[0].result.ID)
+	assert.Equal(t, "Teamleitung", plans[0].employee.Position)
+	assert.Empty(t, anna.Position, "bestehende Datensätze bleiben unverändert")
+	assert.Equal(t, ben.ID.Hex(), plans[1].result.ID)
+	assert.Equal(t, "1002", plans[1].result.EmployeeID)
+	assert.Equal(t, model.EmployeeStatusActive, plans[2].employee.Status)
+	assert.Equal(t, "Clara Neu", plans[2].result.Name)
+	assert.Contains(t, plans[4].result.Errors[0], "bereits in Zeile 4")
+	assert.Contains(t, plans[5].result.Errors[0], "gehört bereits zu Anna Schmidt")
+
+	plans = planEmployeeImport(table, []*model.Employee{anna, ben, carl}, true, validate)
+	assert.Equal(t, model.EmployeeImportActionUpdate, plans[6].result.Action)
+	assert.Equal(t, 5000.0, plans[6].employee.Salary)
+}
@@ -0,0 +1,152 @@
+// backend/service/erfasst123_integration.go
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+)
+
+// erfasst123TimeEntriesField ist das synchronisierte Feld der Zeiteinträge
+const erfasst123TimeEntriesField = "timeEntries"
+
+// erfasst123Integration bindet den Erfasst123Service als Integrationsanbieter an
+type erfasst123Integration struct{}
+
+// Info beschreibt den 123erfasst-Anbieter
+func (erfasst123Integration) Info() IntegrationInfo {
+	return IntegrationInfo{
+		Type:        repository.IntegrationType123Erfasst,
+		Name:        "123erfasst",
+		Description: "Mitarbeiter, Projekte und Zeiteinträge",
+		Fields: []IntegrationField{
+			{Name: "erfasst123-email", Label: "E-Mail", Type: "email", Required: true},
+			{Name: "erfasst123-password", Label: "Passwort", Type: "password", Required: true},
+			{Name: "erfasst123-sync-start-date", Label: "Synchronisieren ab", Type: "date"},
+		},
+		Capabilities: []model.SyncCapability{model.SyncUsers, model.SyncProjects, model.SyncTimeEntries},
+		SyncedFields: []model.SyncedField{
+			{Name: "hireDate", Label: "Eintrittsdatum", Default: model.FieldOwnershipFillIfEmpty},
+			{Name: erfasst123TimeEntriesField, Label: "Zeiteinträge", Default: model.FieldOwnershipExternal},
+		},
+		JobName:         "erfasst123_sync",
+		DefaultSchedule: "*/5 * * * *",
+	}
+}
+
+// Configure testet die Anmeldedaten und speichert sie mit dem Startdatum der Synchronisierung
+func (erfasst123Integration) Configure(values map[string]string) error {
+	return NewErfasst123Service().SaveCredentials(values["erfasst123-email"], values["erfasst123-password"], values["erfasst123-sync-start-date"])
+}
+
+// TestConnection prüft die gespeicherten Anmeldedaten
+func (erfasst123Integration) TestConnection() error {
+	erfasst123Service := NewErfasst123Service()
+	email, password, err := erfasst123Service.GetCredentials()
+	if err != nil {
+		return err
+	}
+	return erfasst123Service.testConnection(email, password)
+}
+
+// IsConnected prüft, ob 123erfasst verbunden ist
+func (erfasst123Integration) IsConnected() bool {
+	return NewErfasst123Service().IsConnected()
+}
+
+// AutoSyncEnabled gibt die Einstellung "Automatische Synchronisierung" zurück
+func (erfasst123Integration) AutoSyncEnabled() bool {
+	enabled, err := NewErfasst123Service().IsAutoSyncEnabled()
+	return err == nil && enabled
+}
+
+// Status gibt den Zustand der 123erfasst-Integration mit dem Startdatum der Synchronisierung zurück
+func (p erfasst123Integration) Status() (*IntegrationStatus, error) {
+	status := newIntegrationStatus(p.Info(), p.IsConnected())
+	status.AutoSync = p.AutoSyncEnabled()
+	if startDate, err := NewErfasst123Service().GetSyncStartDate(); err == nil {
+		status.Details = map[string]string{"syncStartDate": startDate}
+	}
+	return status, nil
+}
+
+// PrepareSync ergänzt den Zeitraum: ab dem gespeicherten Startdatum (bei Projekten sonst
+// ab Monatsanfang, bei Zeiteinträgen ab dem 1. Januar) bis heute
+func (erfasst123Integration) PrepareSync(capability model.SyncCapability, params model.SyncJobParams, now time.Time) (model.SyncJobParams, error) {
+	if capability == model.SyncUsers {
+		return model.SyncJobParams{}, nil
+	}
+
+	prepared := model.SyncJobParams{StartDate: params.StartDate, EndDate: params.EndDate}
+	if prepared.StartDate == "" {
+		if saved, err := NewErfasst123Service().GetSyncStartDate(); err == nil && saved != "" {
+			prepared.StartDate = saved
+		} else if capability == model.SyncProjects {
+			prepared.StartDate = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).Format("2006-01-02")
+		} else {
+			prepared.StartDate = time.Date(now.Year(), 1, 1, 0, 0, 0, 0, time.UTC).Format("2006-01-02")
+		}
+	}
+	if prepared.EndDate == "" {
+		prepared.EndDate = now.Format("2006-01-02")
+	}
+
+	if err := validateSyncDateRange(prepared.StartDate, prepared.EndDate); err != nil {
+		return params, err
+	}
+	return prepared, nil
+}
+
+// Sync synchronisiert einen Bereich aus 123erfasst
+func (erfasst123Integration) Sync(capability model.SyncCapability, params model.SyncJobParams, progress SyncProgress) (int, error) {
+	erfasst123Service := NewErfasst123Service().WithProgress(progress).WithDryRun(params.DryRun)
+	switch capability {
+	case model.SyncUsers:
+		return erfasst123Service.SyncErfasst123Employees()
+	case model.SyncProjects:
+		return erfasst123Service.SyncErfasst123Projects(params.StartDate, params.EndDate)
+	case model.SyncTimeEntries:
+		return erfasst123Service.SyncErfasst123TimeEntries(params.StartDate, params.EndDate)
+	default:
+		return 0, fmt.Errorf("%w: %s", ErrSyncCapabilityUnsupported, capability)
+	}
+}
+
+// ExternalIdentities gibt die aktiven 123erfasst-Mitarbeiter für den Abgleich zurück
+func (erfasst123Integration) ExternalIdentities() ([]model.ExternalIdentity, error) {
+	persons, err := NewErfasst123Service().GetEmployees()
+	if err != nil {
+		return nil, err
+	}
+	return erfasst123Identities(persons), nil
+}
+
+// EmployeeExternalID gibt die 123erfasst-ID eines Mitarbeiters zurück
+func (erfasst123Integration) EmployeeExternalID(employee *model.Employee) string {
+	return employee.Erfasst123ID
+}
+
+// SetEmployeeExternalID speichert die 123erfasst-ID eines Mitarbeiters
+func (erfasst123Integration) SetEmployeeExternalID(employeeID, externalID string) error {
+	return repository.NewEmployeeRepository().UpdateErfasst123ID(employeeID, externalID)
+}
+
+// erfasst123Identities wandelt die aktiven 123erfasst-Mitarbeiter in externe Benutzer für den Abgleich um
+func erfasst123Identities(persons []model.Erfasst123Person) []model.ExternalIdentity {
+	identities := make([]model.ExternalIdentity, 0, len(persons))
+	for _, person := range persons {
+		if !person.Employee.IsActive {
+			continue
+		}
+		identities = append(identities, model.ExternalIdentity{
+			ExternalID: person.Ident,
+			FirstName:  person.Firstname,
+			LastName:   person.Lastname,
+			Email:      person.Mail,
+			HireDate:   person.Employee.HireDateParsed,
+		})
+	}
+	return identities
+}
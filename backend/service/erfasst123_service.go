@@ -22,6 +22,8 @@ import (
 // Erfasst123Service verwaltet die Integration mit 123erfasst
 type Erfasst123Service struct {
 	integrationRepo *repository.IntegrationRepository
+	progress        SyncProgress
+	dryRun          bool
 }
 
 // NewErfasst123Service erstellt einen neuen Erfasst123Service
@@ -31,6 +33,42 @@ func NewErfasst123Service() *Erfasst123Service {
 	}
 }
 
+// WithProgress gibt eine Kopie des Services zurück, die den Fortschritt der
+// Synchronisierung an progress meldet
+func (s *Erfasst123Service) WithProgress(progress SyncProgress) *Erfasst123Service {
+	clone := *s
+	clone.progress = progress
+	return &clone
+}
+
+// WithDryRun gibt eine Kopie des Services zurück, die bei dryRun keine Mitarbeiter speichert,
+// sondern nur die Änderungen meldet (Probelauf)
+func (s *Erfasst123Service) WithDryRun(dryRun bool) *Erfasst123Service {
+	clone := *s
+	clone.dryRun = dryRun
+	return &clone
+}
+
+// saveEmployee speichert einen synchronisierten Mitarbeiter und meldet die Änderungen
+func (s *Erfasst123Service) saveEmployee(employeeRepo *repository.EmployeeRepository, employee *model.Employee) error {
+	return saveSyncedEmployee(employeeRepo, employee, s.dryRun, s.reporter())
+}
+
+// setLastSync speichert den Zeitpunkt der letzten Synchronisierung (nicht bei einem Probelauf)
+func (s *Erfasst123Service) setLastSync() {
+	if !s.dryRun {
+		s.integrationRepo.SetLastSync("123erfasst", time.Now())
+	}
+}
+
+// reporter gibt den Fortschrittsempfänger zurück (ohne gesetzten Empfänger wird nichts gemeldet)
+func (s *Erfasst123Service) reporter() SyncProgress {
+	if s.progress == nil {
+		return noSyncProgress{}
+	}
+	return s.progress
+}
+
 // getGermanLocation gibt die deutsche Zeitzone zurück
 func getGermanLocation() *time.Location {
 	location, err := time.LoadLocation("Europe/Berlin")
@@ -328,13 +366,16 @@ func (s *Erfasst123Service) GetEmployees() ([]model.Erfasst123Person, error) {
 	s.integrationRepo.SetIntegrationStatus("123erfasst", true)
 
 	// Letzte Synchronisierung aktualisieren
-	s.integrationRepo.SetLastSync("123erfasst", time.Now())
+	s.setLastSync()
 
 	return response.Data.Persons.Nodes, nil
 }
 
 // SyncErfasst123Employees synchronisiert 123erfasst-Mitarbeiter mit PeopleFlow-Mitarbeitern
 func (s *Erfasst123Service) SyncErfasst123Employees() (int, error) {
+	progress := s.reporter()
+	progress.StartPhase("Mitarbeiter von 123erfasst abrufen", 0)
+
 	// 123erfasst-Mitarbeiter abrufen
 	employees, err := s.GetEmployees()
 	if err != nil {
@@ -364,30 +405,35 @@ func (s *Erfasst123Service) SyncErfasst123Employees() (int, error) {
 	// Logging für Debugging
 	fmt.Printf("Gefunden: %d aktive Mitarbeiter in 123erfasst\n", len(activeEmployees))
 
-	// Mitarbeiter durchgehen und mit 123erfasst-Daten abgleichen
-	for _, employee := range peopleFlowEmployees {
-		// E-Mail in Kleinbuchstaben umwandeln
-		employeeEmail := strings.ToLower(employee.Email)
+	// 123erfasst-Mitarbeiter zuordnen: manuelle Zuordnungen, gespeicherte ID, dann E-Mail
+	matchedIDs, err := matchSyncedIdentities(repository.IntegrationType123Erfasst, "123erfasst",
+		erfasst123Identities(activeEmployees), peopleFlowEmployees,
+		func(e *model.Employee) string { return e.Erfasst123ID }, progress)
+	if err != nil {
+		return 0, err
+	}
 
-		// Prüfen, ob ein 123erfasst-Mitarbeiter mit dieser E-Mail existiert
-		var matchedEmployee model.Erfasst123Person
-		found := false
+	policy, err := newFieldPolicy(erfasst123Integration{}.Info())
+	if err != nil {
+		return 0, err
+	}
 
-		for _, erfasst123Emp := range activeEmployees {
-			if strings.ToLower(erfasst123Emp.Mail) == employeeEmail {
-				matchedEmployee = erfasst123Emp
-				found = true
-				break
-			}
-		}
+	personsByID := make(map[string]model.Erfasst123Person, len(activeEmployees))
+	for _, erfasst123Emp := range activeEmployees {
+		personsByID[erfasst123Emp.Ident] = erfasst123Emp
+	}
+
+	// Mitarbeiter durchgehen und mit 123erfasst-Daten abgleichen
+	progress.StartPhase("Mitarbeiter abgleichen", len(peopleFlowEmployees))
+	for _, employee := range peopleFlowEmployees {
+		progress.Advance(1)
 
+		// Zugeordneten 123erfasst-Mitarbeiter suchen
+		ident, found := matchedIDs[employee.ID]
 		if !found {
 			continue
 		}
-
-		// Logging
-		fmt.Printf("✓ ZUGEORDNET via Email: %s %s (%s) → 123erfasst ID: %s\n",
-			employee.FirstName, employee.LastName, employee.Email, matchedEmployee.Ident)
+		matchedEmployee := personsByID[ident]
 
 		// Flag, um zu prüfen, ob Änderungen vorgenommen wurden
 		updated := false
@@ -400,26 +446,35 @@ func (s *Erfasst123Service) SyncErfasst123Employees() (int, error) {
 				employee.FirstName, employee.LastName, matchedEmployee.Ident)
 		}
 
-		// Eintrittsdatum aktualisieren, wenn nicht gesetzt
-		if employee.HireDate.IsZero() && !matchedEmployee.Employee.HireDateParsed.IsZero() {
-			employee.HireDate = matchedEmployee.Employee.HireDateParsed
+		// Eintrittsdatum gemäß der Zuständigkeit der Integration übernehmen
+		result, err := policy.apply(employee, "hireDate", model.FormatSyncedDate(matchedEmployee.Employee.HireDateParsed))
+		if err != nil {
+			return updatedCount, err
+		}
+		if result.Updated {
 			updated = true
 			fmt.Printf("Update: Eintrittsdatum für %s %s auf %s gesetzt\n",
 				employee.FirstName, employee.LastName, matchedEmployee.Employee.HireDateParsed.Format("2006-01-02"))
 		}
 
 		// Nur aktualisieren, wenn Änderungen vorgenommen wurden
-		if updated {
-			employee.UpdatedAt = time.Now()
-			err := employeeRepo.Update(employee)
+		if updated || result.BaselineChanged {
+			if updated {
+				employee.UpdatedAt = time.Now()
+			}
+			err := s.saveEmployee(employeeRepo, employee)
 			if err != nil {
 				fmt.Printf("Fehler beim Aktualisieren des Mitarbeiters %s %s: %v\n",
 					employee.FirstName, employee.LastName, err)
+				progress.Warn(fmt.Sprintf("%s %s konnte nicht gespeichert werden: %v", employee.FirstName, employee.LastName, err))
 				continue
 			}
-			updatedCount++
+			if updated {
+				updatedCount++
+			}
 		}
 	}
+	policy.reportConflicts(progress, s.dryRun)
 
 	fmt.Printf("Synchronisierung abgeschlossen: %d Mitarbeiter aktualisiert\n", updatedCount)
 	return updatedCount, nil
@@ -686,7 +741,7 @@ func (s *Erfasst123Service) GetTimeEntries(startDate, endDate string) ([]model.E
 
 	// Integration als aktiv markieren
 	s.integrationRepo.SetIntegrationStatus("123erfasst", true)
-	s.integrationRepo.SetLastSync("123erfasst", time.Now())
+	s.setLastSync()
 
 	return response.Data.Times.Nodes, nil
 }
@@ -739,6 +794,46 @@ func (s *Erfasst123Service) cleanupTimeEntriesForDateRange(employee *model.Emplo
 	return cleanedEntries
 }
 
+// mergeSyncedTimeEntries führt die bestehenden Zeiteinträge eines Mitarbeiters mit den Einträgen
+// aus der Quelle source (z.B. 123erfasst) zusammen und gibt die Anzahl entfernter und übernommener
+// Einträge zurück:
+//   - FieldOwnershipExternal: Einträge der Quelle im Zeitraum werden ersetzt
+//   - FieldOwnershipPeopleFlow: wie oben, aber Tage mit Einträgen aus anderen Quellen bleiben unverändert
+//   - FieldOwnershipFillIfEmpty: bestehende Einträge bleiben, neue nur an Tagen ohne Einträge
+func mergeSyncedTimeEntries(source string, existing, incoming []model.TimeEntry, startDate, endDate time.Time, location *time.Location, ownership model.FieldOwnership) ([]model.TimeEntry, int, int) {
+	dayKey := func(entry model.TimeEntry) string {
+		return entry.Date.In(location).Format("2006-01-02")
+	}
+	inRange := func(entry model.TimeEntry) bool {
+		date := entry.Date.In(location)
+		return !date.Before(startDate) && !date.After(endDate)
+	}
+
+	var kept []model.TimeEntry
+	blockedDays := make(map[string]bool)
+	removed := 0
+	for _, entry := range existing {
+		if ownership != model.FieldOwnershipFillIfEmpty && entry.Source == source && inRange(entry) {
+			removed++
+			continue
+		}
+		kept = append(kept, entry)
+		if ownership == model.FieldOwnershipFillIfEmpty || (ownership == model.FieldOwnershipPeopleFlow && entry.Source != source) {
+			blockedDays[dayKey(entry)] = true
+		}
+	}
+
+	added := 0
+	for _, entry := range incoming {
+		if blockedDays[dayKey(entry)] {
+			continue
+		}
+		kept = append(kept, entry)
+		added++
+	}
+	return kept, removed, added
+}
+
 // Neue Funktion die Duplikate UND Überlappungen entfernt
 func (s *Erfasst123Service) removeDuplicateAndOverlappingEntries(entries []model.TimeEntry) []model.TimeEntry {
 	// Erst nach Datum und Startzeit sortieren
@@ -810,6 +905,9 @@ func (s *Erfasst123Service) SyncErfasst123TimeEntries(startDate, endDate string)
 	fmt.Printf("\n=== START SYNC 123ERFASST ZEITEINTRÄGE ===\n")
 	fmt.Printf("Zeitraum: %s bis %s\n", startDate, endDate)
 
+	progress := s.reporter()
+	progress.StartPhase("Zeiteinträge von 123erfasst abrufen", 0)
+
 	// Zeiteinträge von 123erfasst abrufen
 	timeEntries, err := s.GetTimeEntries(startDate, endDate)
 	if err != nil {
@@ -834,6 +932,13 @@ func (s *Erfasst123Service) SyncErfasst123TimeEntries(startDate, endDate string)
 	endDateParsed = time.Date(endDateParsed.Year(), endDateParsed.Month(), endDateParsed.Day(),
 		23, 59, 59, 999999999, location)
 
+	// Zuständigkeit für Zeiteinträge laden
+	policy, err := newFieldPolicy(erfasst123Integration{}.Info())
+	if err != nil {
+		return 0, err
+	}
+	timeEntriesOwnership := policy.ownership(erfasst123TimeEntriesField)
+
 	// Repository für Mitarbeiter initialisieren
 	employeeRepo := repository.NewEmployeeRepository()
 
@@ -868,7 +973,10 @@ func (s *Erfasst123Service) SyncErfasst123TimeEntries(startDate, endDate string)
 	duplicateCheck := make(map[string]bool)
 
 	// Zeiteinträge nach Mitarbeiter gruppieren
+	progress.StartPhase("Zeiteinträge zuordnen", len(timeEntries))
 	for idx, timeEntry := range timeEntries {
+		progress.Advance(1)
+
 		// Debug für ersten Eintrag
 		if idx == 0 {
 			fmt.Printf("\nErster Zeiteintrag im Detail:\n")
@@ -942,6 +1050,8 @@ func (s *Erfasst123Service) SyncErfasst123TimeEntries(startDate, endDate string)
 				fmt.Printf("✗ NICHT GEFUNDEN: %s %s (ID: %s, Email: %s)\n",
 					timeEntry.Person.Firstname, timeEntry.Person.Lastname,
 					timeEntry.Person.Ident, timeEntry.Person.Mail)
+				progress.Warn(fmt.Sprintf("Kein Mitarbeiter für %s %s (%s) gefunden, Zeiteinträge übersprungen",
+					timeEntry.Person.Firstname, timeEntry.Person.Lastname, timeEntry.Person.Mail))
 			}
 			continue
 		}
@@ -991,54 +1101,42 @@ func (s *Erfasst123Service) SyncErfasst123TimeEntries(startDate, endDate string)
 	fmt.Printf("Mitarbeiter mit neuen Zeiteinträgen: %d\n", len(employeeTimeEntries))
 
 	// Updates durchführen
+	progress.StartPhase("Zeiteinträge speichern", len(employeeTimeEntries))
 	updateCount := 0
 	for employeeID, newEntries := range employeeTimeEntries {
+		progress.Advance(1)
+
 		// Mitarbeiter aus DB neu laden für sauberen Stand
 		dbEmployee, err := employeeRepo.FindByID(employeeID)
 		if err != nil {
 			fmt.Printf("✗ Fehler beim Abrufen von Mitarbeiter %s: %v\n", employeeID, err)
+			progress.Warn(fmt.Sprintf("Mitarbeiter %s konnte nicht geladen werden: %v", employeeID, err))
 			continue
 		}
 
-		// Schritt 1: Bestehende Einträge filtern
-		var keptEntries []model.TimeEntry
-		removedCount := 0
-
-		for _, entry := range dbEmployee.TimeEntries {
-			shouldKeep := true
-
-			if entry.Source == "123erfasst" {
-				entryDateLocal := entry.Date.In(location)
-
-				if !entryDateLocal.Before(startDateParsed) && !entryDateLocal.After(endDateParsed) {
-					shouldKeep = false
-					removedCount++
-					fmt.Printf("  → Entferne 123erfasst-Eintrag vom %s\n",
-						entryDateLocal.Format("2006-01-02"))
-				}
-			}
-
-			if shouldKeep {
-				keptEntries = append(keptEntries, entry)
-			}
-		}
+		// Schritt 1 und 2: Bestehende und neue Einträge gemäß der Zuständigkeit zusammenführen
+		entriesBefore := len(dbEmployee.TimeEntries)
+		merged, removedCount, addedCount := mergeSyncedTimeEntries(repository.IntegrationType123Erfasst, dbEmployee.TimeEntries, newEntries,
+			startDateParsed, endDateParsed, location, timeEntriesOwnership)
 
 		// Debug-Ausgabe
 		fmt.Printf("\nMitarbeiter %s %s:\n", dbEmployee.FirstName, dbEmployee.LastName)
-		fmt.Printf("  Einträge vorher: %d\n", len(dbEmployee.TimeEntries))
+		fmt.Printf("  Einträge vorher: %d\n", entriesBefore)
 		fmt.Printf("  Davon entfernt (123erfasst im Sync-Zeitraum): %d\n", removedCount)
-		fmt.Printf("  Neue Einträge von 123erfasst: %d\n", len(newEntries))
-		
+		fmt.Printf("  Neue Einträge von 123erfasst: %d, davon übernommen: %d\n", len(newEntries), addedCount)
+
 		// Debug: Show sample of new entries
 		if len(newEntries) > 0 {
-			fmt.Printf("  Beispiel neuer Eintrag: %s - %s (%s)\n", 
+			fmt.Printf("  Beispiel neuer Eintrag: %s - %s (%s)\n",
 				newEntries[0].Date.Format("2006-01-02"),
-				newEntries[0].Activity, 
+				newEntries[0].Activity,
 				newEntries[0].ProjectName)
 		}
 
-		// Schritt 2: Neue Einträge hinzufügen
-		dbEmployee.TimeEntries = append(keptEntries, newEntries...)
+		dbEmployee.TimeEntries = merged
+		if removedCount > 0 || addedCount > 0 {
+			dbEmployee.SetFieldSource(erfasst123TimeEntriesField, repository.IntegrationType123Erfasst, time.Now())
+		}
 
 		// WICHTIG: Duplikate und Überlappungen entfernen
 		dbEmployee.TimeEntries = s.removeDuplicateAndOverlappingEntries(dbEmployee.TimeEntries)
@@ -1069,9 +1167,10 @@ func (s *Erfasst123Service) SyncErfasst123TimeEntries(startDate, endDate string)
 		dbEmployee.UpdatedAt = time.Now()
 
 		// Mitarbeiter aktualisieren
-		if err := employeeRepo.Update(dbEmployee); err != nil {
+		if err := s.saveEmployee(employeeRepo, dbEmployee); err != nil {
 			fmt.Printf("✗ Fehler beim Aktualisieren von %s %s: %v\n",
 				dbEmployee.FirstName, dbEmployee.LastName, err)
+			progress.Warn(fmt.Sprintf("%s %s konnte nicht gespeichert werden: %v", dbEmployee.FirstName, dbEmployee.LastName, err))
 			continue
 		}
 
@@ -1094,7 +1193,7 @@ func (s *Erfasst123Service) SyncErfasst123TimeEntries(startDate, endDate string)
 	fmt.Printf("=== SYNC ENDE ===\n\n")
 
 	// Letzte Synchronisation aktualisieren
-	s.integrationRepo.SetLastSync("123erfasst", time.Now())
+	s.setLastSync()
 
 	return updateCount, nil
 }
@@ -1327,7 +1426,7 @@ func (s *Erfasst123Service) GetProjects(startDate, endDate string) ([]model.Erfa
 
 	// Integration als aktiv markieren
 	s.integrationRepo.SetIntegrationStatus("123erfasst", true)
-	s.integrationRepo.SetLastSync("123erfasst", time.Now())
+	s.setLastSync()
 
 	return response.Data.Plannings.Nodes, nil
 }
@@ -1337,6 +1436,9 @@ func (s *Erfasst123Service) SyncErfasst123Projects(startDate, endDate string) (i
 	fmt.Printf("\n=== START SYNC 123ERFASST PROJEKTE ===\n")
 	fmt.Printf("Zeitraum: %s bis %s\n", startDate, endDate)
 
+	progress := s.reporter()
+	progress.StartPhase("Projektdaten von 123erfasst abrufen", 0)
+
 	// ALTERNATIVE: Versuche Projektdaten aus Zeiteinträgen zu extrahieren
 	fmt.Printf("\n=== ALTERNATIVE: Extrahiere Projekte aus Zeiteinträgen ===\n")
 	timeEntries, err := s.GetTimeEntries(startDate, endDate)
@@ -1470,7 +1572,10 @@ func (s *Erfasst123Service) SyncErfasst123Projects(startDate, endDate string) (i
 	fmt.Printf("Gesamte neue Zuordnungen: %d\n", assignmentCount)
 
 	// Updates durchführen
+	progress.StartPhase("Projektzuordnungen speichern", len(employeeProjectAssignments))
 	for employeeID, newAssignments := range employeeProjectAssignments {
+		progress.Advance(1)
+
 		// Mitarbeiter aus DB neu laden für sauberen Stand
 		dbEmployee, err := employeeRepo.FindByID(employeeID)
 		if err != nil {
@@ -1525,9 +1630,10 @@ func (s *Erfasst123Service) SyncErfasst123Projects(startDate, endDate string) (i
 		dbEmployee.UpdatedAt = time.Now()
 
 		// Mitarbeiter aktualisieren
-		if err := employeeRepo.Update(dbEmployee); err != nil {
+		if err := s.saveEmployee(employeeRepo, dbEmployee); err != nil {
 			fmt.Printf("✗ Fehler beim Aktualisieren von %s %s: %v\n",
 				dbEmployee.FirstName, dbEmployee.LastName, err)
+			progress.Warn(fmt.Sprintf("%s %s konnte nicht gespeichert werden: %v", dbEmployee.FirstName, dbEmployee.LastName, err))
 			continue
 		}
 
@@ -1542,7 +1648,7 @@ func (s *Erfasst123Service) SyncErfasst123Projects(startDate, endDate string) (i
 	fmt.Printf("=== PROJEKT SYNC ENDE ===\n\n")
 
 	// Letzte Synchronisation aktualisieren
-	s.integrationRepo.SetLastSync("123erfasst", time.Now())
+	s.setLastSync()
 
 	return updateCount, nil
 }
@@ -1675,8 +1781,12 @@ func (s *Erfasst123Service) createProjectAssignmentsFromTimeEntries(timeEntries
 	}
 
 	// Aktualisiere Mitarbeiter
+	progress := s.reporter()
+	progress.StartPhase("Projektzuordnungen speichern", len(employeeUpdates))
 	updateCount := 0
 	for employeeID, newAssignments := range employeeUpdates {
+		progress.Advance(1)
+
 		dbEmployee, err := employeeRepo.FindByID(employeeID)
 		if err != nil {
 			fmt.Printf("✗ Fehler beim Abrufen von Mitarbeiter %s: %v\n", employeeID, err)
@@ -1697,7 +1807,7 @@ func (s *Erfasst123Service) createProjectAssignmentsFromTimeEntries(timeEntries
 		dbEmployee.ProjectAssignments = append(keptAssignments, newAssignments...)
 		dbEmployee.UpdatedAt = time.Now()
 
-		if err := employeeRepo.Update(dbEmployee); err != nil {
+		if err := s.saveEmployee(employeeRepo, dbEmployee); err != nil {
 			fmt.Printf("✗ Fehler beim Aktualisieren von %s %s: %v\n",
 				dbEmployee.FirstName, dbEmployee.LastName, err)
 			continue
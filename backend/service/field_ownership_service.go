@@ -0,0 +1,255 @@
+// backend/service/field_ownership_service.go
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+)
+
+// ErrSyncedFieldUnknown wird zurückgegeben, wenn eine Integration das Feld nicht synchronisiert
+var ErrSyncedFieldUnknown = errors.New("field is not synced by this integration")
+
+// fieldOwnershipMetadataPrefix ist das Präfix der Zuständigkeiten in den Metadaten der Integration
+const fieldOwnershipMetadataPrefix = "fieldOwnership_"
+
+// FieldOwnershipSetting ist die Zuständigkeit eines synchronisierten Feldes für die Einstellungsseite
+type FieldOwnershipSetting struct {
+	model.SyncedField
+	Ownership      model.FieldOwnership `json:"ownership"`
+	OwnershipLabel string               `json:"ownershipLabel"`
+}
+
+// FieldOwnershipService verwaltet die Zuständigkeiten synchronisierter Felder und die Konflikte,
+// die bei Synchronisierungen erkannt werden
+type FieldOwnershipService struct {
+	integrationRepo *repository.IntegrationRepository
+	conflictRepo    *repository.FieldConflictRepository
+	employeeRepo    *repository.EmployeeRepository
+}
+
+// NewFieldOwnershipService erstellt einen neuen FieldOwnershipService
+func NewFieldOwnershipService() *FieldOwnershipService {
+	return &FieldOwnershipService{
+		integrationRepo: repository.NewIntegrationRepository(),
+		conflictRepo:    repository.NewFieldConflictRepository(),
+		employeeRepo:    repository.NewEmployeeRepository(),
+	}
+}
+
+// fieldOwnerships gibt die Zuständigkeit je synchronisiertem Feld zurück: die gespeicherte
+// Einstellung aus den Metadaten oder den Standard des Anbieters
+func fieldOwnerships(info IntegrationInfo, metadata map[string]string) map[string]model.FieldOwnership {
+	ownerships := make(map[string]model.FieldOwnership, len(info.SyncedFields))
+	for _, field := range info.SyncedFields {
+		ownerships[field.Name] = field.Default
+		if ownership, err := model.ParseFieldOwnership(metadata[fieldOwnershipMetadataPrefix+field.Name]); err == nil {
+			ownerships[field.Name] = ownership
+		}
+	}
+	return ownerships
+}
+
+// loadIntegrationMetadata lädt die Metadaten einer Integration; ist sie nicht eingerichtet,
+// gelten die Standardwerte
+func loadIntegrationMetadata(integrationRepo *repository.IntegrationRepository, integrationType string) (map[string]string, error) {
+	metadata, err := integrationRepo.GetAllMetadata(integrationType)
+	if errors.Is(err, repository.ErrIntegrationNotFound) {
+		return map[string]string{}, nil
+	}
+	return metadata, err
+}
+
+// Settings gibt die Zuständigkeiten aller synchronisierten Felder einer Integration zurück
+func (s *FieldOwnershipService) Settings(integrationType string) ([]FieldOwnershipSetting, error) {
+	provider, err := GetIntegration(integrationType)
+	if err != nil {
+		return nil, err
+	}
+	info := provider.Info()
+	metadata, err := loadIntegrationMetadata(s.integrationRepo, info.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	ownerships := fieldOwnerships(info, metadata)
+	settings := make([]FieldOwnershipSetting, 0, len(info.SyncedFields))
+	for _, field := range info.SyncedFields {
+		settings = append(settings, FieldOwnershipSetting{
+			SyncedField:    field,
+			Ownership:      ownerships[field.Name],
+			OwnershipLabel: ownerships[field.Name].Label(),
+		})
+	}
+	return settings, nil
+}
+
+// SetOwnership speichert die Zuständigkeit eines synchronisierten Feldes
+func (s *FieldOwnershipService) SetOwnership(integrationType, field string, ownership model.FieldOwnership, user *model.User) error {
+	provider, err := GetIntegration(integrationType)
+	if err != nil {
+		return err
+	}
+	info := provider.Info()
+	for _, synced := range info.SyncedFields {
+		if synced.Name != field {
+			continue
+		}
+		if err := s.integrationRepo.SetMetadata(info.Type, fieldOwnershipMetadataPrefix+field, string(ownership)); err != nil {
+			return err
+		}
+		_, _ = repository.NewActivityRepository().LogActivity(
+			model.ActivityTypeSystemSettingChanged,
+			user.ID,
+			user.FirstName+" "+user.LastName,
+			user.ID,
+			"system",
+			info.Name,
+			fmt.Sprintf("Zuständigkeit für %s: %s", synced.Label, ownership.Label()),
+		)
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrSyncedFieldUnknown, field)
+}
+
+// Conflicts gibt die Konflikte einer Integration zurück; ohne includeResolved nur die offenen
+func (s *FieldOwnershipService) Conflicts(integrationType string, includeResolved bool) ([]*model.FieldConflict, error) {
+	provider, err := GetIntegration(integrationType)
+	if err != nil {
+		return nil, err
+	}
+	conflicts, err := s.conflictRepo.FindByIntegration(provider.Info().Type, includeResolved)
+	if err != nil {
+		return nil, err
+	}
+	if conflicts == nil {
+		conflicts = []*model.FieldConflict{}
+	}
+	return conflicts, nil
+}
+
+// ResolveConflict schließt einen Konflikt ab: mit FieldOwnershipPeopleFlow wird der Wert aus
+// PeopleFlow, mit FieldOwnershipExternal der Wert des angebundenen Systems gespeichert
+func (s *FieldOwnershipService) ResolveConflict(id string, resolution model.FieldOwnership, user *model.User) (*model.FieldConflict, error) {
+	if resolution != model.FieldOwnershipPeopleFlow && resolution != model.FieldOwnershipExternal {
+		return nil, fmt.Errorf("%w: %q", model.ErrInvalidFieldOwnership, resolution)
+	}
+
+	conflict, err := s.conflictRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if conflict.ResolvedAt != nil {
+		return conflict, repository.ErrFieldConflictResolved
+	}
+
+	employee, err := s.employeeRepo.FindByID(conflict.EmployeeID.Hex())
+	if err != nil {
+		return nil, err
+	}
+
+	value, source := conflict.LocalValue, model.FieldSourcePeopleFlow
+	if resolution == model.FieldOwnershipExternal {
+		value, source = conflict.ExternalValue, conflict.Integration
+	}
+	current, err := employee.SyncedFieldValue(conflict.Field)
+	if err != nil {
+		return nil, err
+	}
+	if current != value {
+		if err := employee.SetSyncedFieldValue(conflict.Field, value, source, time.Now()); err != nil {
+			return nil, err
+		}
+		if err := s.employeeRepo.Update(employee); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.conflictRepo.MarkResolved(conflict.ID, resolution, user); err != nil {
+		return nil, err
+	}
+
+	_, _ = repository.NewActivityRepository().LogActivity(
+		model.ActivityTypeEmployeeUpdated,
+		user.ID,
+		user.FirstName+" "+user.LastName,
+		employee.ID,
+		"employee",
+		employee.FirstName+" "+employee.LastName,
+		fmt.Sprintf("Konflikt im Feld %s gelöst: %s", conflict.Label, value),
+	)
+
+	now := time.Now()
+	conflict.ResolvedAt = &now
+	conflict.Resolution = resolution
+	conflict.ResolvedBy = user.ID
+	conflict.ResolvedByName = user.FirstName + " " + user.LastName
+	return conflict, nil
+}
+
+// fieldPolicy wendet die Zuständigkeiten einer Integration während einer Synchronisierung an
+// und sammelt die erkannten Konflikte
+type fieldPolicy struct {
+	info       IntegrationInfo
+	ownerships map[string]model.FieldOwnership
+	conflicts  []model.FieldConflict
+	now        time.Time
+}
+
+// newFieldPolicy lädt die Zuständigkeiten einer Integration
+func newFieldPolicy(info IntegrationInfo) (*fieldPolicy, error) {
+	metadata, err := loadIntegrationMetadata(repository.NewIntegrationRepository(), info.Type)
+	if err != nil {
+		return nil, err
+	}
+	return newFieldPolicyWith(info, fieldOwnerships(info, metadata), time.Now()), nil
+}
+
+// newFieldPolicyWith erstellt eine Richtlinie mit bereits geladenen Zuständigkeiten
+func newFieldPolicyWith(info IntegrationInfo, ownerships map[string]model.FieldOwnership, now time.Time) *fieldPolicy {
+	return &fieldPolicy{info: info, ownerships: ownerships, now: now}
+}
+
+// ownership gibt die Zuständigkeit eines Feldes zurück
+func (p *fieldPolicy) ownership(field string) model.FieldOwnership {
+	return p.ownerships[field]
+}
+
+// apply übernimmt den externen Wert eines Feldes gemäß Zuständigkeit und merkt Konflikte vor
+func (p *fieldPolicy) apply(employee *model.Employee, field, external string) (model.FieldSyncResult, error) {
+	for _, synced := range p.info.SyncedFields {
+		if synced.Name != field {
+			continue
+		}
+		result, err := employee.ApplySyncedValue(p.info.Type, synced, p.ownerships[field], external, p.now)
+		if err == nil && result.Conflict != nil {
+			p.conflicts = append(p.conflicts, *result.Conflict)
+		}
+		return result, err
+	}
+	return model.FieldSyncResult{}, fmt.Errorf("%w: %s", ErrSyncedFieldUnknown, field)
+}
+
+// reportConflicts meldet die gesammelten Konflikte als Warnung und speichert sie für den
+// Konfliktbericht; bei einem Probelauf wird nichts gespeichert
+func (p *fieldPolicy) reportConflicts(progress SyncProgress, dryRun bool) {
+	for i := range p.conflicts {
+		conflict := &p.conflicts[i]
+		outcome := "PeopleFlow-Wert beibehalten"
+		if conflict.Applied {
+			outcome = p.info.Name + "-Wert übernommen"
+		}
+		progress.Warn(fmt.Sprintf("Konflikt bei %s, %s: PeopleFlow %q, %s %q (%s)",
+			conflict.EmployeeName, conflict.Label, conflict.LocalValue, p.info.Name, conflict.ExternalValue, outcome))
+		if dryRun {
+			continue
+		}
+		if err := repository.NewFieldConflictRepository().SaveOpen(conflict); err != nil {
+			progress.Warn(fmt.Sprintf("Konflikt bei %s konnte nicht gespeichert werden: %v", conflict.EmployeeName, err))
+		}
+	}
+	p.conflicts = nil
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"PeopleFlow/backend/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestFieldOwnerships_DefaultsAndStoredSettings(t *testing.T) {
+	info := timebutlerIntegration{}.Info()
+
+	ownerships := fieldOwnerships(info, map[string]string{
+		fieldOwnershipMetadataPrefix + "phone":        "external",
+		fieldOwnershipMetadataPrefix + "vacationDays": "ungültig",
+	})
+
+	assert.Equal(t, model.FieldOwnershipExternal, ownerships["phone"])
+	assert.Equal(t, model.FieldOwnershipFillIfEmpty, ownerships["department"])
+	assert.Equal(t, model.FieldOwnershipExternal, ownerships["vacationDays"], "ungültige Werte fallen auf den Standard zurück")
+	assert.Len(t, ownerships, len(info.SyncedFields))
+}
+
+func TestBuiltinIntegrations_SyncedFieldsAreTracked(t *testing.T) {
+	for _, field := range (timebutlerIntegration{}).Info().SyncedFields {
+		assert.True(t, model.IsTrackedField(field.Name), field.Name)
+	}
+	for _, field := range (erfasst123Integration{}).Info().SyncedFields {
+		if field.Name != erfasst123TimeEntriesField {
+			assert.True(t, model.IsTrackedField(field.Name), field.Name)
+		}
+	}
+	for _, field := range (personioIntegration{}).Info().SyncedFields {
+		if field.Name != personioTimeEntriesField {
+			assert.True(t, model.IsTrackedField(field.Name), field.Name)
+		}
+	}
+}
+
+func TestFieldPolicy_CollectsConflicts(t *testing.T) {
+	info := timebutlerIntegration{}.Info()
+	policy := newFieldPolicyWith(info, map[string]model.FieldOwnership{"phone": model.FieldOwnershipPeopleFlow}, time.Now())
+	employee := &model.Employee{
+		ID:           primitive.NewObjectID(),
+		Phone:        "030-9",
+		SyncedValues: map[string]map[string]string{"timebutler": {"phone": "030-1"}},
+	}
+
+	result, err := policy.apply(employee, "phone", "030-2")
+	require.NoError(t, err)
+	assert.False(t, result.Updated)
+	assert.True(t, result.BaselineChanged)
+	require.Len(t, policy.conflicts, 1)
+	assert.Equal(t, "Telefon", policy.conflicts[0].Label)
+
+	_, err = policy.apply(employee, "salary", "1")
+	assert.ErrorIs(t, err, ErrSyncedFieldUnknown)
+}
+
+func TestMergeSyncedTimeEntries(t *testing.T) {
+	location := time.UTC
+	day := func(d int) time.Time { return time.Date(2025, 3, d, 0, 0, 0, 0, location) }
+	start, end := day(1), time.Date(2025, 3, 31, 23, 59, 59, 0, location)
+
+	existing := []model.TimeEntry{
+		{Date: day(3), Source: "123erfasst", Activity: "alt"},
+		{Date: day(4), Source: "manual", Activity: "manuell"},
+		{Date: time.Date(2025, 2, 27, 0, 0, 0, 0, location), Source: "123erfasst", Activity: "außerhalb"},
+	}
+	incoming := []model.TimeEntry{
+		{Date: day(3), Source: "123erfasst", Activity: "neu"},
+		{Date: day(4), Source: "123erfasst", Activity: "neu"},
+		{Date: day(5), Source: "123erfasst", Activity: "neu"},
+	}
+
+	activities := func(entries []model.TimeEntry) map[string][]string {
+		result := make(map[string][]string)
+		for _, entry := range entries {
+			key := entry.Date.Format("01-02")
+			result[key] = append(result[key], entry.Activity)
+		}
+		return result
+	}
+
+	tests := []struct {
+		ownership   model.FieldOwnership
+		want        map[string][]string
+		wantRemoved int
+		wantAdded   int
+	}{
+		{model.FieldOwnershipExternal, map[string][]string{
+			"03-03": {"neu"}, "03-04": {"manuell", "neu"}, "03-05": {"neu"}, "02-27": {"außerhalb"},
+		}, 1, 3},
+		{model.FieldOwnershipPeopleFlow, map[string][]string{
+			"03-03": {"neu"}, "03-04": {"manuell"}, "03-05": {"neu"}, "02-27": {"außerhalb"},
+		}, 1, 2},
+		{model.FieldOwnershipFillIfEmpty, map[string][]string{
+			"03-03": {"alt"}, "03-04": {"manuell"}, "03-05": {"neu"}, "02-27": {"außerhalb"},
+		}, 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.ownership), func(t *testing.T) {
+			merged, removed, added := mergeSyncedTimeEntries("123erfasst", existing, incoming, start, end, location, tt.ownership)
+			assert.Equal(t, tt.want, activities(merged))
+			assert.Equal(t, tt.wantRemoved, removed)
+			assert.Equal(t, tt.wantAdded, added)
+		})
+	}
+}
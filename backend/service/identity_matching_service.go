@@ -0,0 +1,288 @@
+// backend/service/identity_matching_service.go
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Fehler beim Abgleich externer Benutzer
+var (
+	ErrIdentityMatchingUnsupported = errors.New("integration does not support identity matching")
+	ErrExternalIdentityNotFound    = errors.New("external user not found")
+	ErrIdentityAlreadyMatched      = errors.New("external user is already matched to an employee")
+)
+
+// IdentityProvider wird von Anbietern implementiert, deren Benutzer Mitarbeitern zugeordnet werden
+type IdentityProvider interface {
+	// ExternalIdentities gibt alle Benutzer des angebundenen Systems zurück
+	ExternalIdentities() ([]model.ExternalIdentity, error)
+	// EmployeeExternalID gibt die am Mitarbeiter gespeicherte externe ID zurück
+	EmployeeExternalID(employee *model.Employee) string
+	// SetEmployeeExternalID speichert die externe ID am Mitarbeiter (leer entfernt sie)
+	SetEmployeeExternalID(employeeID, externalID string) error
+}
+
+// IdentityMatchingService stellt den Abgleich zwischen externen Benutzern und Mitarbeitern
+// dar und verwaltet manuell bestätigte Zuordnungen
+type IdentityMatchingService struct {
+	employeeRepo *repository.EmployeeRepository
+	mappingRepo  *repository.IntegrationMappingRepository
+}
+
+// NewIdentityMatchingService erstellt einen neuen IdentityMatchingService
+func NewIdentityMatchingService() *IdentityMatchingService {
+	return &IdentityMatchingService{
+		employeeRepo: repository.NewEmployeeRepository(),
+		mappingRepo:  repository.NewIntegrationMappingRepository(),
+	}
+}
+
+// identityProvider gibt den Anbieter eines Typs zurück, sofern er den Abgleich unterstützt
+func identityProvider(integrationType string) (IntegrationProvider, IdentityProvider, error) {
+	provider, err := GetIntegration(integrationType)
+	if err != nil {
+		return nil, nil, err
+	}
+	identities, ok := provider.(IdentityProvider)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s", ErrIdentityMatchingUnsupported, integrationType)
+	}
+	return provider, identities, nil
+}
+
+// loadIdentityMappings gibt die manuellen Zuordnungen einer Integration zurück (externe ID → Mitarbeiter)
+func loadIdentityMappings(integrationType string) (map[string]primitive.ObjectID, error) {
+	mappings, err := repository.NewIntegrationMappingRepository().FindByIntegration(integrationType)
+	if err != nil {
+		return nil, err
+	}
+	byExternalID := make(map[string]primitive.ObjectID, len(mappings))
+	for _, mapping := range mappings {
+		byExternalID[mapping.ExternalID] = mapping.EmployeeID
+	}
+	return byExternalID, nil
+}
+
+// matchSyncedIdentities gleicht externe Benutzer unter Berücksichtigung der manuellen Zuordnungen
+// mit den Mitarbeitern ab; nicht zugeordnete Benutzer werden als Warnung gemeldet
+func matchSyncedIdentities(integrationType, integrationName string, identities []model.ExternalIdentity, employees []*model.Employee, storedID func(*model.Employee) string, progress SyncProgress) (map[primitive.ObjectID]string, error) {
+	mappings, err := loadIdentityMappings(integrationType)
+	if err != nil {
+		return nil, err
+	}
+
+	report := model.MatchIdentities(identities, employees, mappings, storedID)
+	for _, unmatched := range report.UnmatchedExternal {
+		progress.Warn(fmt.Sprintf("%s-Benutzer %s ist keinem Mitarbeiter zugeordnet", integrationName, unmatched.External.FullName()))
+	}
+	return report.EmployeeExternalIDs(), nil
+}
+
+// Report gleicht die Benutzer einer Integration mit allen Mitarbeitern ab
+func (s *IdentityMatchingService) Report(integrationType string) (*model.IdentityMatchReport, error) {
+	provider, identityProvider, err := identityProvider(integrationType)
+	if err != nil {
+		return nil, err
+	}
+
+	identities, err := identityProvider.ExternalIdentities()
+	if err != nil {
+		return nil, err
+	}
+	employees, _, err := s.employeeRepo.FindAll(0, 1000, "lastName", 1)
+	if err != nil {
+		return nil, err
+	}
+	mappings, err := loadIdentityMappings(provider.Info().Type)
+	if err != nil {
+		return nil, err
+	}
+
+	report := model.MatchIdentities(identities, employees, mappings, identityProvider.EmployeeExternalID)
+	report.Integration = provider.Info().Type
+	return &report, nil
+}
+
+// Confirm ordnet einen externen Benutzer einem Mitarbeiter zu. Die Zuordnung wird gespeichert
+// und hat bei künftigen Synchronisierungen Vorrang, auch wenn sich die E-Mail-Adresse ändert.
+func (s *IdentityMatchingService) Confirm(integrationType, externalID, employeeID string, user *model.User) (*model.IntegrationMapping, error) {
+	provider, identityProvider, err := identityProvider(integrationType)
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := findExternalIdentity(identityProvider, externalID)
+	if err != nil {
+		return nil, err
+	}
+	employee, err := s.employeeRepo.FindByID(employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	mapping := &model.IntegrationMapping{
+		Integration:   provider.Info().Type,
+		ExternalID:    identity.ExternalID,
+		ExternalName:  identity.FullName(),
+		EmployeeID:    employee.ID,
+		EmployeeName:  employee.FirstName + " " + employee.LastName,
+		CreatedBy:     user.ID,
+		CreatedByName: user.FirstName + " " + user.LastName,
+	}
+	if err := s.mappingRepo.Upsert(mapping); err != nil {
+		return nil, err
+	}
+	if err := s.assignExternalID(identityProvider, employee.ID, identity.ExternalID); err != nil {
+		return nil, err
+	}
+
+	logIdentityActivity(model.ActivityTypeEmployeeUpdated, user, employee, fmt.Sprintf("%s-Benutzer %s zugeordnet", provider.Info().Name, identity.FullName()))
+	return mapping, nil
+}
+
+// Remove entfernt die manuelle Zuordnung und die externe ID am Mitarbeiter.
+// Bei der nächsten Synchronisierung wird der Benutzer wieder per E-Mail abgeglichen.
+func (s *IdentityMatchingService) Remove(integrationType, externalID string, user *model.User) error {
+	provider, identityProvider, err := identityProvider(integrationType)
+	if err != nil {
+		return err
+	}
+
+	mappings, err := s.mappingRepo.FindByIntegration(provider.Info().Type)
+	if err != nil {
+		return err
+	}
+	var mapping *model.IntegrationMapping
+	for _, m := range mappings {
+		if m.ExternalID == externalID {
+			mapping = m
+			break
+		}
+	}
+	if mapping == nil {
+		return repository.ErrIntegrationMappingNotFound
+	}
+
+	if err := s.mappingRepo.Delete(provider.Info().Type, externalID); err != nil {
+		return err
+	}
+	if err := s.assignExternalID(identityProvider, primitive.NilObjectID, externalID); err != nil {
+		return err
+	}
+
+	if employee, err := s.employeeRepo.FindByID(mapping.EmployeeID.Hex()); err == nil {
+		logIdentityActivity(model.ActivityTypeEmployeeUpdated, user, employee, fmt.Sprintf("Zuordnung zum %s-Benutzer %s entfernt", provider.Info().Name, mapping.ExternalName))
+	}
+	return nil
+}
+
+// CreateEmployee legt für einen nicht zugeordneten externen Benutzer einen Mitarbeiter an und
+// ordnet ihn zu. Als Personalnummer dient die externe Personalnummer, sonst Typ und externe ID.
+func (s *IdentityMatchingService) CreateEmployee(integrationType, externalID string, user *model.User) (*model.Employee, error) {
+	report, err := s.Report(integrationType)
+	if err != nil {
+		return nil, err
+	}
+	if _, matched := report.FindMatch(externalID); matched {
+		return nil, ErrIdentityAlreadyMatched
+	}
+
+	var identity *model.ExternalIdentity
+	for _, unmatched := range report.UnmatchedExternal {
+		if unmatched.External.ExternalID == externalID {
+			identity = &unmatched.External
+			break
+		}
+	}
+	if identity == nil {
+		return nil, ErrExternalIdentityNotFound
+	}
+
+	provider, err := GetIntegration(report.Integration)
+	if err != nil {
+		return nil, err
+	}
+	employee := employeeFromIdentity(report.Integration, *identity)
+	if err := s.employeeRepo.CreateWithoutAccount(employee); err != nil {
+		return nil, err
+	}
+	logIdentityActivity(model.ActivityTypeEmployeeAdded, user, employee, "Mitarbeiter aus "+provider.Info().Name+" angelegt")
+
+	if _, err := s.Confirm(integrationType, externalID, employee.ID.Hex(), user); err != nil {
+		return employee, err
+	}
+	return employee, nil
+}
+
+// assignExternalID setzt die externe ID am Mitarbeiter employeeID und entfernt sie bei allen
+// anderen Mitarbeitern, damit Abwesenheiten und Zeiten eindeutig zugeordnet werden
+func (s *IdentityMatchingService) assignExternalID(identityProvider IdentityProvider, employeeID primitive.ObjectID, externalID string) error {
+	employees, _, err := s.employeeRepo.FindAll(0, 1000, "lastName", 1)
+	if err != nil {
+		return err
+	}
+	for _, employee := range employees {
+		if employee.ID != employeeID && identityProvider.EmployeeExternalID(employee) == externalID {
+			if err := identityProvider.SetEmployeeExternalID(employee.ID.Hex(), ""); err != nil {
+				return err
+			}
+		}
+	}
+	if employeeID.IsZero() {
+		return nil
+	}
+	return identityProvider.SetEmployeeExternalID(employeeID.Hex(), externalID)
+}
+
+// findExternalIdentity sucht einen Benutzer des angebundenen Systems anhand seiner ID
+func findExternalIdentity(identityProvider IdentityProvider, externalID string) (model.ExternalIdentity, error) {
+	identities, err := identityProvider.ExternalIdentities()
+	if err != nil {
+		return model.ExternalIdentity{}, err
+	}
+	for _, identity := range identities {
+		if identity.ExternalID == externalID {
+			return identity, nil
+		}
+	}
+	return model.ExternalIdentity{}, fmt.Errorf("%w: %s", ErrExternalIdentityNotFound, externalID)
+}
+
+// employeeFromIdentity erstellt einen neuen Mitarbeiter aus den Daten eines externen Benutzers
+func employeeFromIdentity(integrationType string, identity model.ExternalIdentity) *model.Employee {
+	employeeNumber := strings.TrimSpace(identity.EmployeeNumber)
+	if employeeNumber == "" {
+		employeeNumber = strings.ToUpper(integrationType) + "-" + identity.ExternalID
+	}
+	return &model.Employee{
+		FirstName:   identity.FirstName,
+		LastName:    identity.LastName,
+		Email:       identity.Email,
+		Phone:       identity.Phone,
+		EmployeeID:  employeeNumber,
+		Department:  model.Department(identity.Department),
+		DateOfBirth: identity.DateOfBirth,
+		HireDate:    identity.HireDate,
+		Status:      model.EmployeeStatusActive,
+	}
+}
+
+// logIdentityActivity protokolliert eine Zuordnung oder Neuanlage am Mitarbeiter
+func logIdentityActivity(activityType model.ActivityType, user *model.User, employee *model.Employee, description string) {
+	_, _ = repository.NewActivityRepository().LogActivity(
+		activityType,
+		user.ID,
+		user.FirstName+" "+user.LastName,
+		employee.ID,
+		"employee",
+		employee.FirstName+" "+employee.LastName,
+		description,
+	)
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"PeopleFlow/backend/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdentityProvider_RequiresIdentitySupport(t *testing.T) {
+	require.NoError(t, RegisterIntegration(newFakeIntegration("fake-identity")))
+
+	_, _, err := identityProvider("fake-identity")
+	assert.ErrorIs(t, err, ErrIdentityMatchingUnsupported)
+
+	_, _, err = identityProvider("unknown")
+	assert.ErrorIs(t, err, ErrIntegrationUnknown)
+}
+
+func TestBuiltinIntegrations_SupportIdentityMatching(t *testing.T) {
+	for _, provider := range []IntegrationProvider{timebutlerIntegration{}, erfasst123Integration{}, personioIntegration{}} {
+		_, ok := provider.(IdentityProvider)
+		assert.True(t, ok, provider.Info().Type)
+	}
+}
+
+func TestEmployeeFromIdentity(t *testing.T) {
+	hireDate := time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	employee := employeeFromIdentity("timebutler", model.ExternalIdentity{
+		ExternalID:     "4711",
+		FirstName:      "Anna",
+		LastName:       "Schmidt",
+		Email:          "anna@example.com",
+		EmployeeNumber: " P-12 ",
+		HireDate:       hireDate,
+		Department:     "Vertrieb",
+	})
+	assert.Equal(t, "P-12", employee.EmployeeID)
+	assert.Equal(t, "anna@example.com", employee.Email)
+	assert.Equal(t, model.Department("Vertrieb"), employee.Department)
+	assert.Equal(t, hireDate, employee.HireDate)
+	assert.Equal(t, model.EmployeeStatusActive, employee.Status)
+
+	employee = employeeFromIdentity("123erfasst", model.ExternalIdentity{ExternalID: "abc", FirstName: "Ben", LastName: "Braun"})
+	assert.Equal(t, "123ERFASST-abc", employee.EmployeeID)
+}
+
+func TestTimebutlerIdentities(t *testing.T) {
+	identities := timebutlerIdentities(map[string]model.TimebutlerUser{
+		"anna@example.com": {UserID: "7", FirstName: "Anna", LastName: "Schmidt", EmailAddress: "anna@example.com", EmployeeNumber: "P-12"},
+	})
+
+	require.Len(t, identities, 1)
+	assert.Equal(t, "7", identities[0].ExternalID)
+	assert.Equal(t, "P-12", identities[0].EmployeeNumber)
+}
+
+func TestErfasst123Identities_SkipsInactive(t *testing.T) {
+	identities := erfasst123Identities([]model.Erfasst123Person{
+		{Ident: "a", Firstname: "Anna", Employee: model.Erfasst123Employee{IsActive: true}},
+		{Ident: "b", Firstname: "Ben", Employee: model.Erfasst123Employee{IsActive: false}},
+	})
+
+	require.Len(t, identities, 1)
+	assert.Equal(t, "a", identities[0].ExternalID)
+}
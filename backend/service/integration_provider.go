@@ -0,0 +1,214 @@
+// backend/service/integration_provider.go
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+)
+
+// Integrations-Fehler
+var (
+	ErrIntegrationUnknown        = errors.New("unknown integration")
+	ErrIntegrationFieldMissing   = errors.New("required integration field is missing")
+	ErrSyncCapabilityUnsupported = errors.New("sync capability not supported by integration")
+)
+
+// IntegrationField beschreibt ein Konfigurationsfeld eines Anbieters
+type IntegrationField struct {
+	Name     string `json:"name"`  // Name des Formularfelds
+	Label    string `json:"label"` // Deutsche Bezeichnung
+	Type     string `json:"type"`  // Eingabetyp: text, password, email oder date
+	Required bool   `json:"required"`
+}
+
+// IntegrationInfo beschreibt einen Integrationsanbieter
+type IntegrationInfo struct {
+	Type         string                 `json:"type"`
+	Name         string                 `json:"name"`
+	Description  string                 `json:"description"`
+	Fields       []IntegrationField     `json:"fields"`
+	Capabilities []model.SyncCapability `json:"capabilities"` // Reihenfolge der vollständigen Synchronisierung
+	// SyncedFields sind die Mitarbeiterfelder, deren Zuständigkeit konfiguriert werden kann
+	SyncedFields []model.SyncedField `json:"syncedFields,omitempty"`
+
+	// JobName ist der Hintergrundjob der regelmäßigen Synchronisierung (Standard: <Typ>_sync)
+	JobName string `json:"-"`
+	// DefaultSchedule ist der Standard-Zeitplan dieses Jobs im Cron-Format
+	DefaultSchedule string `json:"-"`
+}
+
+// IntegrationStatus ist der Zustand eines Anbieters für die Statusseite
+type IntegrationStatus struct {
+	IntegrationInfo
+	Connected      bool              `json:"connected"`
+	HasCredentials bool              `json:"hasApiKey"`
+	AutoSync       bool              `json:"autoSync"`
+	LastSync       *time.Time        `json:"lastSync,omitempty"`
+	Details        map[string]string `json:"details,omitempty"`
+}
+
+// IntegrationProvider ist die gemeinsame Schnittstelle aller Integrationsanbieter.
+// Handler, Hintergrundjobs und Statusseite arbeiten nur mit dieser Schnittstelle,
+// sodass ein neuer Anbieter lediglich registriert werden muss.
+type IntegrationProvider interface {
+	// Info beschreibt Anbieter, Konfigurationsfelder und synchronisierbare Bereiche
+	Info() IntegrationInfo
+	// Configure prüft die Zugangsdaten und speichert sie (Schlüssel wie in Info().Fields)
+	Configure(values map[string]string) error
+	// TestConnection prüft die gespeicherten Zugangsdaten gegen die API des Anbieters
+	TestConnection() error
+	// IsConnected prüft, ob die Integration aktiv ist und die Verbindung funktioniert
+	IsConnected() bool
+	// AutoSyncEnabled gibt an, ob der Hintergrundjob synchronisieren soll
+	AutoSyncEnabled() bool
+	// Status gibt den Zustand für die Statusseite zurück
+	Status() (*IntegrationStatus, error)
+	// PrepareSync ergänzt fehlende Parameter eines Bereichs und prüft sie
+	PrepareSync(capability model.SyncCapability, params model.SyncJobParams, now time.Time) (model.SyncJobParams, error)
+	// Sync synchronisiert einen Bereich und gibt die Anzahl der aktualisierten Mitarbeiter zurück
+	Sync(capability model.SyncCapability, params model.SyncJobParams, progress SyncProgress) (int, error)
+}
+
+// integrationRegistry enthält alle registrierten Anbieter in Registrierungsreihenfolge
+var integrationRegistry = struct {
+	sync.RWMutex
+	providers map[string]IntegrationProvider
+	order     []string
+}{providers: make(map[string]IntegrationProvider)}
+
+// RegisterIntegration registriert einen Integrationsanbieter und macht seinen Typ
+// im IntegrationRepository bekannt. Eine erneute Registrierung ersetzt den Anbieter.
+func RegisterIntegration(provider IntegrationProvider) error {
+	info := provider.Info()
+	integrationType := strings.ToLower(strings.TrimSpace(info.Type))
+	if integrationType == "" || info.Name == "" {
+		return fmt.Errorf("integration %q: type and name are required", info.Type)
+	}
+	if len(info.Capabilities) == 0 {
+		return fmt.Errorf("integration %q: at least one capability is required", info.Type)
+	}
+	for _, capability := range info.Capabilities {
+		if !capability.IsValid() || capability == model.SyncAll {
+			return fmt.Errorf("integration %q: %w: %s", info.Type, model.ErrInvalidSyncCapability, capability)
+		}
+	}
+	if info.DefaultSchedule != "" {
+		if _, err := model.ParseCron(info.DefaultSchedule); err != nil {
+			return fmt.Errorf("integration %q: %w", info.Type, err)
+		}
+	}
+
+	integrationRegistry.Lock()
+	defer integrationRegistry.Unlock()
+	if _, exists := integrationRegistry.providers[integrationType]; !exists {
+		integrationRegistry.order = append(integrationRegistry.order, integrationType)
+	}
+	integrationRegistry.providers[integrationType] = provider
+	repository.RegisterIntegrationType(integrationType, info.Name)
+	return nil
+}
+
+// RegisterBuiltinIntegrations registriert die mitgelieferten Anbieter (Timebutler, 123erfasst, Personio)
+func RegisterBuiltinIntegrations() error {
+	for _, provider := range []IntegrationProvider{timebutlerIntegration{}, erfasst123Integration{}, personioIntegration{}} {
+		if err := RegisterIntegration(provider); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetIntegration gibt den Anbieter eines Integrationstyps zurück
+func GetIntegration(integrationType string) (IntegrationProvider, error) {
+	integrationRegistry.RLock()
+	defer integrationRegistry.RUnlock()
+	provider, ok := integrationRegistry.providers[strings.ToLower(strings.TrimSpace(integrationType))]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrIntegrationUnknown, integrationType)
+	}
+	return provider, nil
+}
+
+// Integrations gibt alle registrierten Anbieter in Registrierungsreihenfolge zurück
+func Integrations() []IntegrationProvider {
+	integrationRegistry.RLock()
+	defer integrationRegistry.RUnlock()
+	providers := make([]IntegrationProvider, 0, len(integrationRegistry.order))
+	for _, integrationType := range integrationRegistry.order {
+		providers = append(providers, integrationRegistry.providers[integrationType])
+	}
+	return providers
+}
+
+// SupportsCapability prüft, ob ein Anbieter einen Bereich synchronisieren kann
+// (SyncAll wird von jedem Anbieter unterstützt)
+func SupportsCapability(info IntegrationInfo, capability model.SyncCapability) bool {
+	if capability == model.SyncAll {
+		return true
+	}
+	for _, supported := range info.Capabilities {
+		if supported == capability {
+			return true
+		}
+	}
+	return false
+}
+
+// SyncJobName gibt den Namen des Hintergrundjobs der regelmäßigen Synchronisierung zurück
+func (i IntegrationInfo) SyncJobName() string {
+	if i.JobName != "" {
+		return i.JobName
+	}
+	return i.Type + "_sync"
+}
+
+// ConfigureIntegration prüft die Pflichtfelder und übergibt die Werte an den Anbieter
+func ConfigureIntegration(provider IntegrationProvider, values map[string]string) error {
+	for _, field := range provider.Info().Fields {
+		values[field.Name] = strings.TrimSpace(values[field.Name])
+		if field.Required && values[field.Name] == "" {
+			return fmt.Errorf("%w: %s", ErrIntegrationFieldMissing, field.Label)
+		}
+	}
+	return provider.Configure(values)
+}
+
+// DisconnectIntegration deaktiviert eine Integration; die Zugangsdaten bleiben gespeichert
+func DisconnectIntegration(provider IntegrationProvider) error {
+	return repository.NewIntegrationRepository().SetIntegrationStatus(provider.Info().Type, false)
+}
+
+// newIntegrationStatus erstellt den Status mit den gespeicherten Angaben (Zugangsdaten, letzte Synchronisierung)
+func newIntegrationStatus(info IntegrationInfo, connected bool) *IntegrationStatus {
+	status := &IntegrationStatus{IntegrationInfo: info, Connected: connected}
+	if integration, err := repository.NewIntegrationRepository().GetIntegration(info.Type); err == nil {
+		status.HasCredentials = integration.IsConfigured()
+		if !integration.LastSync.IsZero() {
+			lastSync := integration.LastSync
+			status.LastSync = &lastSync
+		}
+	}
+	return status
+}
+
+// validateSyncDateRange prüft einen Zeitraum im Format YYYY-MM-DD
+func validateSyncDateRange(startDate, endDate string) error {
+	start, err := time.Parse("2006-01-02", startDate)
+	if err != nil {
+		return fmt.Errorf("%w: Startdatum %q", ErrInvalidSyncDateRange, startDate)
+	}
+	end, err := time.Parse("2006-01-02", endDate)
+	if err != nil {
+		return fmt.Errorf("%w: Enddatum %q", ErrInvalidSyncDateRange, endDate)
+	}
+	if end.Before(start) {
+		return fmt.Errorf("%w: Enddatum liegt vor dem Startdatum", ErrInvalidSyncDateRange)
+	}
+	return nil
+}
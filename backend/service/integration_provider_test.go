@@ -0,0 +1,113 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"PeopleFlow/backend/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeIntegration ist ein Anbieter ohne API und Datenbank für Registry-Tests
+type fakeIntegration struct {
+	info       IntegrationInfo
+	configured map[string]string
+	synced     []model.SyncJobParams
+	failing    map[model.SyncCapability]error
+}
+
+func (f *fakeIntegration) Info() IntegrationInfo { return f.info }
+func (f *fakeIntegration) Configure(values map[string]string) error {
+	f.configured = values
+	return nil
+}
+func (f *fakeIntegration) TestConnection() error { return nil }
+func (f *fakeIntegration) IsConnected() bool     { return true }
+func (f *fakeIntegration) AutoSyncEnabled() bool { return true }
+func (f *fakeIntegration) Status() (*IntegrationStatus, error) {
+	return &IntegrationStatus{IntegrationInfo: f.info}, nil
+}
+func (f *fakeIntegration) PrepareSync(_ model.SyncCapability, params model.SyncJobParams, _ time.Time) (model.SyncJobParams, error) {
+	return params, nil
+}
+func (f *fakeIntegration) Sync(capability model.SyncCapability, params model.SyncJobParams, _ SyncProgress) (int, error) {
+	f.synced = append(f.synced, params)
+	if err := f.failing[capability]; err != nil {
+		return 0, err
+	}
+	return 2, nil
+}
+
+func newFakeIntegration(integrationType string) *fakeIntegration {
+	return &fakeIntegration{info: IntegrationInfo{
+		Type: integrationType,
+		Name: "Fake HR",
+		Fields: []IntegrationField{
+			{Name: "fake-token", Label: "Token", Type: "password", Required: true},
+			{Name: "fake-tenant", Label: "Mandant", Type: "text"},
+		},
+		Capabilities:    []model.SyncCapability{model.SyncUsers, model.SyncAbsences},
+		DefaultSchedule: "*/15 * * * *",
+	}}
+}
+
+func TestRegisterIntegration_Validation(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(info *IntegrationInfo)
+	}{
+		{"ohne Typ", func(info *IntegrationInfo) { info.Type = " " }},
+		{"ohne Namen", func(info *IntegrationInfo) { info.Name = "" }},
+		{"ohne Bereiche", func(info *IntegrationInfo) { info.Capabilities = nil }},
+		{"unbekannter Bereich", func(info *IntegrationInfo) { info.Capabilities = []model.SyncCapability{"salaries"} }},
+		{"vollständige Synchronisierung als Bereich", func(info *IntegrationInfo) { info.Capabilities = []model.SyncCapability{model.SyncAll} }},
+		{"ungültiger Zeitplan", func(info *IntegrationInfo) { info.DefaultSchedule = "every minute" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := newFakeIntegration("fake-invalid")
+			tt.modify(&provider.info)
+			assert.Error(t, RegisterIntegration(provider))
+		})
+	}
+
+	_, err := GetIntegration("fake-invalid")
+	assert.ErrorIs(t, err, ErrIntegrationUnknown)
+}
+
+func TestRegisterIntegration_MakesProviderAvailable(t *testing.T) {
+	provider := newFakeIntegration("fake-hr")
+	require.NoError(t, RegisterIntegration(provider))
+
+	found, err := GetIntegration(" Fake-HR ")
+	require.NoError(t, err)
+	assert.Same(t, provider, found)
+	assert.Contains(t, Integrations(), IntegrationProvider(provider))
+	assert.Equal(t, "fake-hr_sync", provider.Info().SyncJobName())
+
+	_, err = GetIntegration("personio")
+	assert.ErrorIs(t, err, ErrIntegrationUnknown)
+}
+
+func TestSupportsCapability(t *testing.T) {
+	info := newFakeIntegration("fake-caps").Info()
+
+	assert.True(t, SupportsCapability(info, model.SyncUsers))
+	assert.True(t, SupportsCapability(info, model.SyncAll))
+	assert.False(t, SupportsCapability(info, model.SyncTimeEntries))
+}
+
+func TestConfigureIntegration(t *testing.T) {
+	provider := newFakeIntegration("fake-config")
+
+	err := ConfigureIntegration(provider, map[string]string{"fake-token": "  ", "fake-tenant": "acme"})
+	assert.ErrorIs(t, err, ErrIntegrationFieldMissing)
+	assert.Contains(t, err.Error(), "Token")
+	assert.Nil(t, provider.configured)
+
+	require.NoError(t, ConfigureIntegration(provider, map[string]string{"fake-token": " secret "}))
+	assert.Equal(t, map[string]string{"fake-token": "secret", "fake-tenant": ""}, provider.configured)
+}
@@ -0,0 +1,267 @@
+// backend/service/invitation_service.go
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+	"PeopleFlow/backend/utils"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Einladungsfehler
+var (
+	ErrUserNotInvited      = errors.New("user is not awaiting activation")
+	ErrTwoFactorRequired   = errors.New("two-factor authentication is required")
+	ErrInvalidTwoFactorOTP = errors.New("invalid two-factor code")
+)
+
+// InvitationService verwaltet Einladung und Aktivierung neuer Benutzerkonten
+type InvitationService struct {
+	userRepo       *repository.UserRepository
+	invitationRepo *repository.UserInvitationRepository
+	employeeRepo   *repository.EmployeeRepository
+	settingsRepo   *repository.SystemSettingsRepository
+	emailService   *EmailService
+}
+
+// NewInvitationService erstellt einen neuen InvitationService
+func NewInvitationService() *InvitationService {
+	return &InvitationService{
+		userRepo:       repository.NewUserRepository(),
+		invitationRepo: repository.NewUserInvitationRepository(),
+		employeeRepo:   repository.NewEmployeeRepository(),
+		settingsRepo:   repository.NewSystemSettingsRepository(),
+		emailService:   NewEmailService(),
+	}
+}
+
+// InviteUser legt einen eingeladenen Benutzer an und versendet den Aktivierungslink.
+// Ist employeeID gesetzt, werden Name und E-Mail aus dem Mitarbeiterdatensatz übernommen.
+func (s *InvitationService) InviteUser(user *model.User, employeeID string, invitedBy *model.User) (*model.UserInvitation, error) {
+	if employeeID != "" {
+		employee, err := s.employeeRepo.FindByID(employeeID)
+		if err != nil {
+			return nil, fmt.Errorf("mitarbeiter nicht gefunden: %w", err)
+		}
+		if user.FirstName == "" {
+			user.FirstName = employee.FirstName
+		}
+		if user.LastName == "" {
+			user.LastName = employee.LastName
+		}
+		if user.Email == "" {
+			user.Email = employee.Email
+		}
+		user.EmployeeID = &employee.ID
+	}
+
+	if err := s.userRepo.CreateInvited(user); err != nil {
+		return nil, err
+	}
+
+	// Automatische Verknüpfung über die E-Mail-Adresse
+	if user.EmployeeID == nil {
+		s.linkEmployeeByEmail(user)
+	}
+
+	return s.sendInvitation(user, invitedBy)
+}
+
+// ResendInvitation zieht offene Einladungen zurück und versendet einen neuen Link
+func (s *InvitationService) ResendInvitation(userID string, invitedBy *model.User) (*model.UserInvitation, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsInvited() {
+		return nil, ErrUserNotInvited
+	}
+
+	if err := s.invitationRepo.RevokeOpenByUserID(user.ID); err != nil {
+		return nil, fmt.Errorf("fehler beim Zurückziehen offener Einladungen: %w", err)
+	}
+
+	return s.sendInvitation(user, invitedBy)
+}
+
+// ValidateToken prüft ein Einladungstoken und gibt Einladung und Benutzer zurück
+func (s *InvitationService) ValidateToken(token string) (*model.UserInvitation, *model.User, error) {
+	if token == "" {
+		return nil, nil, repository.ErrInvitationNotFound
+	}
+
+	invitation, err := s.invitationRepo.FindByToken(token)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !invitation.IsUsable() {
+		return nil, nil, repository.ErrInvitationInvalid
+	}
+
+	user, err := s.userRepo.FindByID(invitation.UserID.Hex())
+	if err != nil {
+		return nil, nil, err
+	}
+	if !user.IsInvited() {
+		return nil, nil, ErrUserNotInvited
+	}
+
+	return invitation, user, nil
+}
+
+// IsTwoFactorRequired gibt an, ob bei der Aktivierung 2FA eingerichtet werden muss
+func (s *InvitationService) IsTwoFactorRequired() bool {
+	settings, err := s.settingsRepo.GetSettings()
+	if err != nil {
+		log.Printf("Fehler beim Abrufen der Systemeinstellungen: %v", err)
+		return false
+	}
+	return settings.RequireTwoFactor
+}
+
+// PasswordPolicy gibt die aktuell gültige Passwortrichtlinie zurück
+func (s *InvitationService) PasswordPolicy() *model.PasswordPolicy {
+	return s.userRepo.PasswordPolicy()
+}
+
+// AcceptInvitation setzt das Passwort, richtet optional 2FA ein und aktiviert das Konto.
+// totpSecret und otp sind nur erforderlich, wenn 2FA verpflichtend ist oder eingerichtet werden soll.
+func (s *InvitationService) AcceptInvitation(token, password, totpSecret, otp string) (*model.User, error) {
+	invitation, user, err := s.ValidateToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	var encryptedSecret string
+	if totpSecret != "" || s.IsTwoFactorRequired() {
+		if totpSecret == "" {
+			return nil, ErrTwoFactorRequired
+		}
+		if !utils.ValidateTOTP(totpSecret, otp) {
+			return nil, ErrInvalidTwoFactorOTP
+		}
+		encryptedSecret, err = utils.EncryptString(totpSecret)
+		if err != nil {
+			return nil, fmt.Errorf("fehler beim Verschlüsseln des 2FA-Secrets: %w", err)
+		}
+	}
+
+	if err := activateInvitedUser(s.userRepo, s.invitationRepo, invitation, user, password, encryptedSecret); err != nil {
+		return nil, err
+	}
+
+	if user.EmployeeID == nil {
+		s.linkEmployeeByEmail(user)
+	}
+
+	activityRepo := repository.NewActivityRepository()
+	_, _ = activityRepo.LogActivity(
+		model.ActivityTypeUserUpdated,
+		user.ID,
+		user.GetFullName(),
+		user.ID,
+		"user",
+		user.GetFullName(),
+		"Benutzerkonto über Einladungslink aktiviert",
+	)
+
+	user.Status = model.StatusActive
+	user.TwoFactorEnabled = encryptedSecret != ""
+	return user, nil
+}
+
+// invitedUserActivator ist der Teil des UserRepository, der eingeladene Konten aktiviert
+type invitedUserActivator interface {
+	CheckPasswordPolicy(user *model.User, password string) error
+	ActivateInvited(userID string, password, encryptedTOTPSecret string) error
+}
+
+// invitationAcceptor markiert Einladungen als angenommen (UserInvitationRepository)
+type invitationAcceptor interface {
+	MarkAccepted(id primitive.ObjectID) error
+}
+
+// activateInvitedUser aktiviert das Konto und verbraucht erst danach die Einladung. Schlägt die
+// Aktivierung fehl, bleibt der Link gültig. Parallele Aktivierungen verhindert ActivateInvited,
+// das nur noch eingeladene Benutzer aktiviert; danach lehnt ValidateToken den Link ab, auch wenn
+// das Verbrauchen der Einladung selbst scheitert.
+func activateInvitedUser(users invitedUserActivator, invitations invitationAcceptor, invitation *model.UserInvitation, user *model.User, password, encryptedSecret string) error {
+	if err := users.CheckPasswordPolicy(user, password); err != nil {
+		return err
+	}
+	if err := users.ActivateInvited(user.ID.Hex(), password, encryptedSecret); err != nil {
+		return err
+	}
+	if err := invitations.MarkAccepted(invitation.ID); err != nil {
+		log.Printf("Einladung %s konnte nach der Aktivierung nicht als angenommen markiert werden: %v", invitation.ID.Hex(), err)
+	}
+	return nil
+}
+
+// sendInvitation erzeugt ein neues Token, speichert die Einladung und versendet die E-Mail
+func (s *InvitationService) sendInvitation(user *model.User, invitedBy *model.User) (*model.UserInvitation, error) {
+	token, err := generateSecureToken()
+	if err != nil {
+		return nil, fmt.Errorf("fehler beim Generieren des Einladungstokens: %w", err)
+	}
+
+	invitation := &model.UserInvitation{
+		UserID:     user.ID,
+		Email:      user.Email,
+		EmployeeID: user.EmployeeID,
+	}
+	if invitedBy != nil {
+		invitation.InvitedBy = invitedBy.ID
+		invitation.InvitedByName = invitedBy.GetFullName()
+	}
+
+	if err := s.invitationRepo.Create(invitation, token); err != nil {
+		return nil, fmt.Errorf("fehler beim Speichern der Einladung: %w", err)
+	}
+
+	if err := s.emailService.SendInvitationEmail(user, invitation.InvitedByName, token, invitation.ExpiresAt); err != nil {
+		// Die Einladung bleibt bestehen und kann erneut versendet werden
+		log.Printf("Fehler beim Senden der Einladung an %s: %v", user.Email, err)
+		return invitation, err
+	}
+
+	return invitation, nil
+}
+
+// linkEmployeeByEmail verknüpft den Benutzer mit dem aktiven Mitarbeiter gleicher E-Mail-Adresse
+func (s *InvitationService) linkEmployeeByEmail(user *model.User) {
+	employee, err := s.employeeRepo.FindByEmail(strings.ToLower(user.Email))
+	if err != nil {
+		return
+	}
+
+	if err := s.userRepo.LinkEmployee(user.ID.Hex(), employee.ID); err != nil {
+		log.Printf("Fehler beim Verknüpfen von Benutzer %s mit Mitarbeiter %s: %v", user.Email, employee.ID.Hex(), err)
+		return
+	}
+
+	user.EmployeeID = &employee.ID
+}
+
+// generateSecureToken generiert ein sicheres, URL-taugliches Token
+func generateSecureToken() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+// invitationExpiryText formatiert das Ablaufdatum für die E-Mail
+func invitationExpiryText(expiresAt time.Time) string {
+	return expiresAt.Format("02.01.2006 15:04")
+}
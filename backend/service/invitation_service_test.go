@@ -0,0 +1,80 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// fakeInvitedUsers aktiviert eingeladene Benutzer oder schlägt mit activateErr fehl
+type fakeInvitedUsers struct {
+	policyErr   error
+	activateErr error
+	activated   bool
+}
+
+func (f *fakeInvitedUsers) CheckPasswordPolicy(*model.User, string) error { return f.policyErr }
+
+func (f *fakeInvitedUsers) ActivateInvited(string, string, string) error {
+	if f.activateErr != nil {
+		return f.activateErr
+	}
+	f.activated = true
+	return nil
+}
+
+// fakeInvitationAcceptor merkt sich, ob die Einladung verbraucht wurde
+type fakeInvitationAcceptor struct {
+	err      error
+	accepted bool
+}
+
+func (f *fakeInvitationAcceptor) MarkAccepted(primitive.ObjectID) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.accepted = true
+	return nil
+}
+
+func TestActivateInvitedUser(t *testing.T) {
+	errDatabase := errors.New("connection reset")
+
+	tests := []struct {
+		name          string
+		users         *fakeInvitedUsers
+		invitations   *fakeInvitationAcceptor
+		wantErr       error
+		wantActivated bool
+		wantAccepted  bool
+	}{
+		{"Erfolg", &fakeInvitedUsers{}, &fakeInvitationAcceptor{}, nil, true, true},
+		{"Passwortrichtlinie", &fakeInvitedUsers{policyErr: repository.ErrInvalidPassword}, &fakeInvitationAcceptor{}, repository.ErrInvalidPassword, false, false},
+		{"Aktivierung schlägt fehl", &fakeInvitedUsers{activateErr: errDatabase}, &fakeInvitationAcceptor{}, errDatabase, false, false},
+		{"bereits parallel aktiviert", &fakeInvitedUsers{activateErr: repository.ErrInvitationInvalid}, &fakeInvitationAcceptor{}, repository.ErrInvitationInvalid, false, false},
+		// Das Konto ist aktiv; der Link ist über den Benutzerstatus ohnehin nicht mehr gültig
+		{"Einladung nicht verbraucht", &fakeInvitedUsers{}, &fakeInvitationAcceptor{err: errDatabase}, nil, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invitation := &model.UserInvitation{ID: primitive.NewObjectID()}
+			user := &model.User{ID: primitive.NewObjectID(), Status: model.StatusInvited}
+
+			err := activateInvitedUser(tt.users, tt.invitations, invitation, user, "Sicheres-Passwort-1", "")
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantActivated, tt.users.activated)
+			assert.Equal(t, tt.wantAccepted, tt.invitations.accepted, "die Einladung wird nur nach erfolgreicher Aktivierung verbraucht")
+		})
+	}
+}
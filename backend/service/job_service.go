@@ -0,0 +1,449 @@
+// backend/service/job_service.go
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+)
+
+// Job-Fehler
+var (
+	ErrJobUnknown = errors.New("job is not registered")
+	ErrJobRunning = errors.New("job is already running")
+)
+
+const (
+	// defaultJobTimeout ist die Standarddauer, für die ein laufender Job gesperrt bleibt.
+	// Solange der Lauf dauert, wird die Sperre regelmäßig verlängert; endet eine Instanz
+	// während eines Laufs, darf eine andere den Job nach Ablauf der Sperre übernehmen.
+	defaultJobTimeout = 30 * time.Minute
+
+	// jobMissedGrace bestimmt, wie verspätet ein Termin noch nachgeholt wird, wenn der
+	// Job verpasste Termine überspringen soll (z.B. die Abwesenheitsübersicht um 7:00)
+	jobMissedGrace = time.Hour
+
+	// jobRunRetention bestimmt, wie lange die Laufhistorie aufbewahrt wird
+	jobRunRetention = 30 * 24 * time.Hour
+)
+
+// JobFunc führt einen Job aus und gibt eine kurze Zusammenfassung des Ergebnisses zurück
+type JobFunc func() (string, error)
+
+// JobDefinition beschreibt einen im Code registrierten Hintergrundjob
+type JobDefinition struct {
+	Name            string
+	Label           string
+	Description     string
+	DefaultSchedule string        // Cron-Ausdruck, solange der Admin keinen eigenen setzt
+	Timeout         time.Duration // Sperrdauer ohne Verlängerung (Lease); Standard: 30 Minuten
+	SkipMissed      bool          // Termine, die mehr als eine Stunde zurückliegen, nicht nachholen
+	Run             JobFunc
+}
+
+// JobInfo ist ein Job mit seinem gespeicherten Zustand für die Verwaltungsoberfläche
+type JobInfo struct {
+	*model.Job
+	Label           string `json:"label"`
+	Description     string `json:"description"`
+	DefaultSchedule string `json:"defaultSchedule"`
+	Running         bool   `json:"running"`
+}
+
+// jobRegistry enthält alle registrierten Jobs in Registrierungsreihenfolge
+var jobRegistry = struct {
+	sync.RWMutex
+	jobs  map[string]JobDefinition
+	order []string
+}{jobs: make(map[string]JobDefinition)}
+
+// jobInstanceID kennzeichnet diese Instanz in Sperren und Laufhistorie
+var jobInstanceID = newJobInstanceID()
+
+// RegisterJob registriert einen Hintergrundjob. Eine erneute Registrierung
+// unter demselben Namen ersetzt die bisherige Definition.
+func RegisterJob(definition JobDefinition) error {
+	if definition.Name == "" || definition.Run == nil {
+		return fmt.Errorf("job %q: name and run function are required", definition.Name)
+	}
+	if _, err := model.ParseCron(definition.DefaultSchedule); err != nil {
+		return fmt.Errorf("job %q: %w", definition.Name, err)
+	}
+	if definition.Timeout <= 0 {
+		definition.Timeout = defaultJobTimeout
+	}
+
+	jobRegistry.Lock()
+	defer jobRegistry.Unlock()
+	if _, exists := jobRegistry.jobs[definition.Name]; !exists {
+		jobRegistry.order = append(jobRegistry.order, definition.Name)
+	}
+	jobRegistry.jobs[definition.Name] = definition
+	return nil
+}
+
+// registeredJob gibt die Definition eines registrierten Jobs zurück
+func registeredJob(name string) (JobDefinition, bool) {
+	jobRegistry.RLock()
+	defer jobRegistry.RUnlock()
+	definition, ok := jobRegistry.jobs[name]
+	return definition, ok
+}
+
+// registeredJobs gibt alle Definitionen in Registrierungsreihenfolge zurück
+func registeredJobs() []JobDefinition {
+	jobRegistry.RLock()
+	defer jobRegistry.RUnlock()
+	definitions := make([]JobDefinition, 0, len(jobRegistry.order))
+	for _, name := range jobRegistry.order {
+		definitions = append(definitions, jobRegistry.jobs[name])
+	}
+	return definitions
+}
+
+// newJobInstanceID bildet eine eindeutige Kennung aus Hostname, Prozess-ID und Zufallswert
+func newJobInstanceID() string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "peopleflow"
+	}
+	suffix := make([]byte, 3)
+	_, _ = rand.Read(suffix)
+	return fmt.Sprintf("%s-%d-%s", hostname, os.Getpid(), hex.EncodeToString(suffix))
+}
+
+// JobService plant und startet Hintergrundjobs. Sperren liegen in MongoDB,
+// sodass bei mehreren Instanzen jeder Lauf nur auf einer Instanz stattfindet.
+type JobService struct {
+	jobRepo *repository.JobRepository
+}
+
+// NewJobService erstellt einen neuen JobService
+func NewJobService() *JobService {
+	return &JobService{
+		jobRepo: repository.NewJobRepository(),
+	}
+}
+
+// SyncJobs legt alle registrierten Jobs in der Datenbank an, die dort noch fehlen
+func (s *JobService) SyncJobs(now time.Time) error {
+	for _, definition := range registeredJobs() {
+		schedule, _ := model.ParseCron(definition.DefaultSchedule)
+		if err := s.jobRepo.EnsureJob(definition.Name, schedule.String(), schedule.Next(now)); err != nil {
+			return fmt.Errorf("job %s: %w", definition.Name, err)
+		}
+	}
+	return nil
+}
+
+// ListJobs gibt alle registrierten Jobs mit Zeitplan und letztem Ergebnis zurück
+func (s *JobService) ListJobs() ([]JobInfo, error) {
+	stored, err := s.jobRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]*model.Job, len(stored))
+	for _, job := range stored {
+		byName[job.Name] = job
+	}
+
+	now := time.Now()
+	jobs := make([]JobInfo, 0, len(byName))
+	for _, definition := range registeredJobs() {
+		job, ok := byName[definition.Name]
+		if !ok {
+			job = &model.Job{Name: definition.Name, Schedule: definition.DefaultSchedule}
+		}
+		jobs = append(jobs, s.jobInfo(definition, job, now))
+	}
+	return jobs, nil
+}
+
+// GetJob gibt einen registrierten Job zurück
+func (s *JobService) GetJob(name string) (*JobInfo, error) {
+	definition, job, err := s.find(name)
+	if err != nil {
+		return nil, err
+	}
+	info := s.jobInfo(definition, job, time.Now())
+	return &info, nil
+}
+
+// Runs gibt die Laufhistorie eines Jobs zurück
+func (s *JobService) Runs(name string, skip, limit int64) ([]*model.JobRun, int64, error) {
+	if _, ok := registeredJob(name); !ok {
+		return nil, 0, ErrJobUnknown
+	}
+	return s.jobRepo.FindRuns(name, skip, limit)
+}
+
+// Trigger startet einen Job sofort im Hintergrund, unabhängig von Zeitplan und Pause.
+// Läuft der Job bereits (auf irgendeiner Instanz), wird ErrJobRunning zurückgegeben.
+func (s *JobService) Trigger(name string, user *model.User) (*model.JobRun, error) {
+	definition, ok := registeredJob(name)
+	if !ok {
+		return nil, ErrJobUnknown
+	}
+
+	now := time.Now()
+	job, err := s.jobRepo.AcquireLock(name, jobInstanceID, now, definition.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		if _, err := s.jobRepo.FindByName(name); err != nil {
+			return nil, err
+		}
+		return nil, ErrJobRunning
+	}
+
+	run := &model.JobRun{Job: name, Trigger: model.JobTriggerManual}
+	if user != nil {
+		run.TriggeredBy = &user.ID
+	}
+	if err := s.start(definition, run, now); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// Pause pausiert die planmäßige Ausführung eines Jobs; ein laufender Lauf wird nicht abgebrochen
+func (s *JobService) Pause(name string) (*JobInfo, error) {
+	definition, job, err := s.find(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.jobRepo.SetPaused(name, true, s.nextRun(job, time.Now())); err != nil {
+		return nil, err
+	}
+	return s.GetJob(definition.Name)
+}
+
+// Resume setzt einen pausierten Job fort. Der nächste Lauf richtet sich nach dem Zeitplan,
+// verpasste Termine werden nicht nachgeholt.
+func (s *JobService) Resume(name string) (*JobInfo, error) {
+	definition, job, err := s.find(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.jobRepo.SetPaused(name, false, s.nextRun(job, time.Now())); err != nil {
+		return nil, err
+	}
+	return s.GetJob(definition.Name)
+}
+
+// UpdateSchedule ändert den Zeitplan eines Jobs. Ein leerer Ausdruck stellt den Standard wieder her.
+func (s *JobService) UpdateSchedule(name, expression string) (*JobInfo, error) {
+	definition, job, err := s.find(name)
+	if err != nil {
+		return nil, err
+	}
+	if expression == "" {
+		expression = definition.DefaultSchedule
+	}
+
+	schedule, err := model.ParseCron(expression)
+	if err != nil {
+		return nil, err
+	}
+	next := schedule.Next(time.Now())
+	if next.IsZero() {
+		return nil, fmt.Errorf("%w: %q hat keinen Termin in den nächsten fünf Jahren", model.ErrInvalidCronExpression, expression)
+	}
+	if err := s.jobRepo.UpdateSchedule(job.Name, schedule.String(), next); err != nil {
+		return nil, err
+	}
+	return s.GetJob(definition.Name)
+}
+
+// RunDue startet alle fälligen Jobs, für die diese Instanz die Sperre erhält.
+// Die Jobs laufen im Hintergrund; zurückgegeben wird die Anzahl der gestarteten Läufe.
+func (s *JobService) RunDue(now time.Time) (int, error) {
+	stored, err := s.jobRepo.FindAll()
+	if err != nil {
+		return 0, err
+	}
+
+	started := 0
+	for _, job := range stored {
+		definition, ok := registeredJob(job.Name)
+		if !ok || !job.IsDue(now) {
+			continue
+		}
+
+		next := s.nextRun(job, now)
+		acquired, err := s.jobRepo.AcquireDue(job.Name, jobInstanceID, now, next, definition.Timeout)
+		if err != nil {
+			log.Printf("Error acquiring job %s: %v", job.Name, err)
+			continue
+		}
+		if acquired == nil {
+			// Eine andere Instanz war schneller
+			continue
+		}
+
+		run := &model.JobRun{Job: job.Name, Trigger: model.JobTriggerSchedule}
+		if definition.SkipMissed && acquired.NextRunAt != nil && now.Sub(*acquired.NextRunAt) > jobMissedGrace {
+			s.skip(run, acquired, now)
+			continue
+		}
+		if err := s.start(definition, run, now); err != nil {
+			log.Printf("Error starting job %s: %v", job.Name, err)
+			continue
+		}
+		started++
+	}
+	return started, nil
+}
+
+// CleanupRuns löscht die Laufhistorie nach Ablauf der Aufbewahrungsfrist
+func (s *JobService) CleanupRuns() (int64, error) {
+	return s.jobRepo.DeleteRunsBefore(time.Now().Add(-jobRunRetention))
+}
+
+// start legt den Lauf an und führt den Job im Hintergrund aus. Die Sperre muss bereits gehalten werden.
+func (s *JobService) start(definition JobDefinition, run *model.JobRun, now time.Time) error {
+	// Wer die Sperre hält, hat den Job exklusiv – noch als laufend gespeicherte
+	// Läufe stammen von einer beendeten Instanz
+	if err := s.jobRepo.AbortRunningRuns(definition.Name, now); err != nil {
+		log.Printf("Error aborting stale runs of job %s: %v", definition.Name, err)
+	}
+
+	run.Instance = jobInstanceID
+	run.Status = model.JobRunRunning
+	run.StartedAt = now
+	if err := s.jobRepo.CreateRun(run); err != nil {
+		_ = s.jobRepo.Release(definition.Name, jobInstanceID, run)
+		return err
+	}
+
+	started := *run
+	go s.execute(definition, &started)
+	return nil
+}
+
+// execute führt den Job aus, speichert das Ergebnis und gibt die Sperre frei.
+// Während des Laufs wird die Sperre laufend verlängert, damit lange Läufe nicht
+// nach Ablauf des Timeouts von einer anderen Instanz parallel gestartet werden.
+func (s *JobService) execute(definition JobDefinition, run *model.JobRun) {
+	done := make(chan struct{})
+	go keepJobLock(s.jobRepo, definition.Name, definition.Timeout, done)
+
+	result, err := runJobFunc(definition.Run)
+	close(done)
+	run.Finish(time.Now(), result, err)
+
+	if err != nil {
+		log.Printf("Job %s failed after %dms: %v", definition.Name, run.DurationMs, err)
+	} else if result != "" {
+		log.Printf("Job %s: %s", definition.Name, result)
+	}
+
+	if err := s.jobRepo.FinishRun(run); err != nil {
+		log.Printf("Error saving run of job %s: %v", definition.Name, err)
+	}
+	if err := s.jobRepo.Release(definition.Name, jobInstanceID, run); err != nil {
+		log.Printf("Error releasing job %s: %v", definition.Name, err)
+	}
+}
+
+// jobLockExtender verlängert die Sperre eines Jobs (implementiert vom JobRepository)
+type jobLockExtender interface {
+	ExtendLock(name, instance string, until time.Time) (bool, error)
+}
+
+// keepJobLock verlängert die Sperre alle lease/3 um lease, bis done geschlossen wird.
+// Geht die Sperre verloren, wird das protokolliert und nicht weiter verlängert.
+func keepJobLock(locks jobLockExtender, name string, lease time.Duration, done <-chan struct{}) {
+	ticker := time.NewTicker(lease / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case now := <-ticker.C:
+			held, err := locks.ExtendLock(name, jobInstanceID, now.Add(lease))
+			if err != nil {
+				log.Printf("Error extending lock of job %s: %v", name, err)
+				continue
+			}
+			if !held {
+				log.Printf("Job %s lost its lock while running; another instance may start it", name)
+				return
+			}
+		}
+	}
+}
+
+// skip vermerkt einen verpassten Termin in der Laufhistorie, ohne den Job auszuführen
+func (s *JobService) skip(run *model.JobRun, job *model.Job, now time.Time) {
+	run.Instance = jobInstanceID
+	run.Status = model.JobRunSkipped
+	run.StartedAt = now
+	run.FinishedAt = &now
+	run.Result = "Termin " + job.NextRunAt.Format("02.01.2006 15:04") + " verpasst, wird nicht nachgeholt"
+
+	if err := s.jobRepo.CreateRun(run); err != nil {
+		log.Printf("Error saving skipped run of job %s: %v", job.Name, err)
+	}
+	if err := s.jobRepo.Release(job.Name, jobInstanceID, run); err != nil {
+		log.Printf("Error releasing job %s: %v", job.Name, err)
+	}
+}
+
+// runJobFunc führt die Jobfunktion aus und wandelt einen Panic in einen Fehler um,
+// damit die Sperre in jedem Fall freigegeben wird
+func runJobFunc(run JobFunc) (result string, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("panic: %v", recovered)
+		}
+	}()
+	return run()
+}
+
+// find gibt Definition und gespeicherten Zustand eines registrierten Jobs zurück
+func (s *JobService) find(name string) (JobDefinition, *model.Job, error) {
+	definition, ok := registeredJob(name)
+	if !ok {
+		return JobDefinition{}, nil, ErrJobUnknown
+	}
+	job, err := s.jobRepo.FindByName(name)
+	if err != nil {
+		return JobDefinition{}, nil, err
+	}
+	return definition, job, nil
+}
+
+// nextRun berechnet den nächsten Termin nach dem gespeicherten Zeitplan. Ohne gültigen
+// Termin wird der Job nicht mehr planmäßig ausgeführt, bis ein neuer Zeitplan gesetzt ist.
+func (s *JobService) nextRun(job *model.Job, now time.Time) time.Time {
+	schedule, err := model.ParseCron(job.Schedule)
+	if err != nil {
+		return now.AddDate(100, 0, 0)
+	}
+	if next := schedule.Next(now); !next.IsZero() {
+		return next
+	}
+	return now.AddDate(100, 0, 0)
+}
+
+// jobInfo verbindet Definition und gespeicherten Zustand
+func (s *JobService) jobInfo(definition JobDefinition, job *model.Job, now time.Time) JobInfo {
+	return JobInfo{
+		Job:             job,
+		Label:           definition.Label,
+		Description:     definition.Description,
+		DefaultSchedule: definition.DefaultSchedule,
+		Running:         job.IsLocked(now),
+	}
+}
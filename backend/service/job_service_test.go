@@ -0,0 +1,63 @@
+package service
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeJobLocks zählt Verlängerungen und hält die Sperre, bis held auf false gesetzt wird
+type fakeJobLocks struct {
+	mu       sync.Mutex
+	held     bool
+	extended []time.Time
+}
+
+func (f *fakeJobLocks) ExtendLock(name, instance string, until time.Time) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.extended = append(f.extended, until)
+	return f.held, nil
+}
+
+func (f *fakeJobLocks) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.extended)
+}
+
+func TestKeepJobLock_ExtendsUntilDone(t *testing.T) {
+	locks := &fakeJobLocks{held: true}
+	done := make(chan struct{})
+	finished := make(chan struct{})
+	go func() {
+		keepJobLock(locks, "labor_cost_snapshots", 30*time.Millisecond, done)
+		close(finished)
+	}()
+
+	assert.Eventually(t, func() bool { return locks.count() >= 2 }, time.Second, 5*time.Millisecond)
+	close(done)
+	<-finished
+
+	count := locks.count()
+	time.Sleep(40 * time.Millisecond)
+	assert.Equal(t, count, locks.count(), "nach dem Ende wird nicht weiter verlängert")
+}
+
+func TestKeepJobLock_StopsWhenLockIsLost(t *testing.T) {
+	locks := &fakeJobLocks{held: false}
+	finished := make(chan struct{})
+	go func() {
+		keepJobLock(locks, "labor_cost_snapshots", 30*time.Millisecond, make(chan struct{}))
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+	case <-time.After(time.Second):
+		t.Fatal("keepJobLock läuft trotz verlorener Sperre weiter")
+	}
+	assert.Equal(t, 1, locks.count())
+}
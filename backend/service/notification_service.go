@@ -0,0 +1,282 @@
+// backend/service/notification_service.go
+package service
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// conversationReminderDays ist der Vorlauf für Erinnerungen an Mitarbeitergespräche
+	conversationReminderDays = 7
+	// documentExpiryWarningDays ist der Vorlauf für Warnungen zu ablaufenden Dokumenten
+	documentExpiryWarningDays = 30
+)
+
+// NotificationService erzeugt In-App-Benachrichtigungen und versendet sie je nach
+// Benutzereinstellung zusätzlich per E-Mail
+type NotificationService struct {
+	notificationRepo *repository.NotificationRepository
+	userRepo         *repository.UserRepository
+	employeeRepo     *repository.EmployeeRepository
+	emailService     *EmailService
+}
+
+// NewNotificationService erstellt einen neuen NotificationService
+func NewNotificationService() *NotificationService {
+	return &NotificationService{
+		notificationRepo: repository.NewNotificationRepository(),
+		userRepo:         repository.NewUserRepository(),
+		employeeRepo:     repository.NewEmployeeRepository(),
+		emailService:     NewEmailService(),
+	}
+}
+
+// List gibt die Benachrichtigungen eines Benutzers zurück
+func (s *NotificationService) List(user *model.User, unreadOnly bool, skip, limit int64) ([]*model.Notification, int64, error) {
+	return s.notificationRepo.FindByUser(user.ID, unreadOnly, skip, limit)
+}
+
+// CountUnread zählt die ungelesenen Benachrichtigungen eines Benutzers
+func (s *NotificationService) CountUnread(user *model.User) (int64, error) {
+	return s.notificationRepo.CountUnread(user.ID)
+}
+
+// MarkRead markiert eine Benachrichtigung als gelesen
+func (s *NotificationService) MarkRead(user *model.User, id string) error {
+	return s.notificationRepo.MarkRead(user.ID, id)
+}
+
+// MarkAllRead markiert alle Benachrichtigungen eines Benutzers als gelesen
+func (s *NotificationService) MarkAllRead(user *model.User) (int64, error) {
+	return s.notificationRepo.MarkAllRead(user.ID)
+}
+
+// UpdatePreferences speichert die Benachrichtigungseinstellungen eines Benutzers
+func (s *NotificationService) UpdatePreferences(user *model.User, preferences model.NotificationPreferences) error {
+	if err := s.userRepo.UpdateNotificationPreferences(user.ID, preferences); err != nil {
+		return err
+	}
+	user.NotificationPreferences = preferences
+	return nil
+}
+
+// Notify stellt eine Benachrichtigung über die vom Benutzer gewählten Kanäle zu
+func (s *NotificationService) Notify(user *model.User, notification model.Notification) error {
+	preference := user.NotificationPreferences.For(notification.Type)
+	notification.UserID = user.ID
+
+	if preference.InApp {
+		if err := s.notificationRepo.Create(&notification); err != nil {
+			return err
+		}
+	}
+
+	if preference.Email && user.Email != "" && s.emailService.IsEmailConfigured() {
+		if err := s.emailService.SendNotificationEmail(user, &notification); err != nil {
+			return fmt.Errorf("benachrichtigung per E-Mail fehlgeschlagen: %w", err)
+		}
+	}
+	return nil
+}
+
+// Publish ist der ActivityListener für Abwesenheitsanträge und Entscheidungen
+func (s *NotificationService) Publish(activity *model.Activity) {
+	switch activity.Type {
+	case model.ActivityTypeVacationRequested, model.ActivityTypeVacationApproved, model.ActivityTypeVacationRejected:
+	default:
+		return
+	}
+	if activity.TargetType != "employee" || activity.TargetID.IsZero() {
+		return
+	}
+
+	copied := *activity
+	go s.notifyAbsence(&copied)
+}
+
+// notifyAbsence benachrichtigt Genehmiger über neue Anträge bzw. den Antragsteller über die Entscheidung
+func (s *NotificationService) notifyAbsence(activity *model.Activity) {
+	notification := model.Notification{
+		Message:    activity.Description,
+		TargetID:   activity.TargetID,
+		TargetType: "employee",
+	}
+
+	var recipients []*model.User
+	switch activity.Type {
+	case model.ActivityTypeVacationRequested:
+		notification.Type = model.NotificationApprovalRequested
+		notification.Title = "Neuer Abwesenheitsantrag von " + activity.TargetName
+		notification.Link = "/absence-overview"
+		recipients = s.usersWithRoles(model.RoleAdmin, model.RoleManager)
+	default:
+		notification.Type = model.NotificationRequestDecided
+		notification.Title = "Ihr Abwesenheitsantrag wurde genehmigt"
+		if activity.Type == model.ActivityTypeVacationRejected {
+			notification.Title = "Ihr Abwesenheitsantrag wurde abgelehnt"
+		}
+		notification.Message = fmt.Sprintf("%s (durch %s)", activity.Description, activity.UserName)
+		notification.Link = "/employees/view/" + activity.TargetID.Hex()
+		if user, err := s.userRepo.FindByEmployeeID(activity.TargetID); err == nil {
+			recipients = []*model.User{user}
+		}
+	}
+
+	for _, user := range recipients {
+		// Wer selbst gehandelt hat, braucht keine Benachrichtigung
+		if user.ID == activity.UserID {
+			continue
+		}
+		if err := s.Notify(user, notification); err != nil {
+			log.Printf("Fehler beim Benachrichtigen von %s: %v", user.Email, err)
+		}
+	}
+}
+
+// CheckUpcomingConversations erinnert HR, Manager und den Mitarbeiter an Gespräche der nächsten Tage.
+// Jede Erinnerung wird pro Empfänger und Gespräch nur einmal erzeugt.
+func (s *NotificationService) CheckUpcomingConversations(now time.Time) (int, error) {
+	employees, _, err := s.employeeRepo.FindAll(0, 10000, "lastName", 1)
+	if err != nil {
+		return 0, err
+	}
+
+	staff := s.usersWithRoles(model.RoleAdmin, model.RoleManager, model.RoleHR)
+	until := now.AddDate(0, 0, conversationReminderDays)
+	count := 0
+
+	for _, employee := range employees {
+		for _, conversation := range employee.Conversations {
+			if conversation.Status == "completed" || conversation.Date.Before(now) || conversation.Date.After(until) {
+				continue
+			}
+
+			recipients := staff
+			if user, err := s.userRepo.FindByEmployeeID(employee.ID); err == nil && !containsUser(staff, user.ID) {
+				recipients = append(append([]*model.User{}, staff...), user)
+			}
+
+			count += s.notifyOnce(recipients, conversation.ID, model.Notification{
+				Type:       model.NotificationConversationUpcoming,
+				Title:      "Mitarbeitergespräch am " + conversation.Date.Format("02.01.2006"),
+				Message:    fmt.Sprintf("%s mit %s %s", conversation.Title, employee.FirstName, employee.LastName),
+				Link:       "/employees/view/" + employee.ID.Hex(),
+				TargetID:   conversation.ID,
+				TargetType: "conversation",
+			})
+		}
+	}
+	return count, nil
+}
+
+// CheckExpiringDocuments warnt Admins und HR vor Dokumenten, die bald ablaufen
+func (s *NotificationService) CheckExpiringDocuments(now time.Time) (int, error) {
+	employees, _, err := s.employeeRepo.FindAll(0, 10000, "lastName", 1)
+	if err != nil {
+		return 0, err
+	}
+
+	recipients := s.usersWithRoles(model.RoleAdmin, model.RoleHR)
+	until := now.AddDate(0, 0, documentExpiryWarningDays)
+	count := 0
+
+	for _, employee := range employees {
+		for _, document := range employee.Documents {
+			if document.ExpiresAt == nil || document.ExpiresAt.After(until) {
+				continue
+			}
+
+			title := "Dokument läuft am " + document.ExpiresAt.Format("02.01.2006") + " ab"
+			if document.ExpiresAt.Before(now) {
+				title = "Dokument ist seit " + document.ExpiresAt.Format("02.01.2006") + " abgelaufen"
+			}
+
+			count += s.notifyOnce(recipients, document.ID, model.Notification{
+				Type:       model.NotificationDocumentExpiring,
+				Title:      title,
+				Message:    fmt.Sprintf("%s von %s %s", document.Name, employee.FirstName, employee.LastName),
+				Link:       "/employees/view/" + employee.ID.Hex(),
+				TargetID:   document.ID,
+				TargetType: "document",
+			})
+		}
+	}
+	return count, nil
+}
+
+// NotifySyncFailure meldet Admins eine fehlgeschlagene Synchronisation.
+// Solange eine gleichlautende Meldung ungelesen ist, wird keine weitere erzeugt.
+func (s *NotificationService) NotifySyncFailure(integration, step string, syncErr error) {
+	title := fmt.Sprintf("Synchronisation fehlgeschlagen: %s (%s)", integration, step)
+	for _, user := range s.usersWithRoles(model.RoleAdmin) {
+		pending, err := s.notificationRepo.ExistsUnread(user.ID, model.NotificationSyncFailed, title)
+		if err != nil || pending {
+			continue
+		}
+
+		err = s.Notify(user, model.Notification{
+			Type:       model.NotificationSyncFailed,
+			Title:      title,
+			Message:    syncErr.Error(),
+			Link:       "/settings",
+			TargetType: "integration",
+		})
+		if err != nil {
+			log.Printf("Fehler beim Benachrichtigen von %s: %v", user.Email, err)
+		}
+	}
+}
+
+// notifyOnce benachrichtigt jeden Empfänger, der zum Ziel noch keine Benachrichtigung dieser Art hat
+func (s *NotificationService) notifyOnce(recipients []*model.User, targetID primitive.ObjectID, notification model.Notification) int {
+	count := 0
+	for _, user := range recipients {
+		exists, err := s.notificationRepo.ExistsForTarget(user.ID, notification.Type, targetID)
+		if err != nil || exists {
+			continue
+		}
+		if err := s.Notify(user, notification); err != nil {
+			log.Printf("Fehler beim Benachrichtigen von %s: %v", user.Email, err)
+			continue
+		}
+		count++
+	}
+	return count
+}
+
+// usersWithRoles gibt alle aktiven Benutzer mit einer der Rollen zurück (ohne Duplikate)
+func (s *NotificationService) usersWithRoles(roles ...model.UserRole) []*model.User {
+	seen := make(map[primitive.ObjectID]bool)
+	var users []*model.User
+	for _, role := range roles {
+		found, err := s.userRepo.FindByRole(role)
+		if err != nil {
+			log.Printf("Fehler beim Laden der Benutzer mit Rolle %s: %v", role, err)
+			continue
+		}
+		for _, user := range found {
+			if !seen[user.ID] {
+				seen[user.ID] = true
+				users = append(users, user)
+			}
+		}
+	}
+	return users
+}
+
+// containsUser prüft, ob ein Benutzer in der Liste enthalten ist
+func containsUser(users []*model.User, id primitive.ObjectID) bool {
+	for _, user := range users {
+		if user.ID == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,300 @@
+// backend/service/personio_client.go
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"PeopleFlow/backend/model"
+)
+
+// ErrPersonioAPI wird zurückgegeben, wenn die Personio-API mit einem Fehler antwortet
+var ErrPersonioAPI = errors.New("Personio API Fehler")
+
+// defaultPersonioAPIURL ist die Adresse der Personio-API
+const defaultPersonioAPIURL = "https://api.personio.de/v1"
+
+// personioPageSize ist die Anzahl der Einträge je Seite bei seitenweisen Abfragen
+const personioPageSize = 200
+
+// personioAPIURL gibt die Adresse der Personio-API zurück. Mit PEOPLEFLOW_PERSONIO_API_URL
+// kann sie durch einen lokalen Testserver ersetzt werden.
+func personioAPIURL() string {
+	if apiURL := os.Getenv("PEOPLEFLOW_PERSONIO_API_URL"); apiURL != "" {
+		return strings.TrimRight(apiURL, "/")
+	}
+	return defaultPersonioAPIURL
+}
+
+// personioClient ruft die Endpunkte der Personio-API auf. Vor der ersten Abfrage wird mit
+// Client-ID und Secret ein Token angefordert, das als Bearer-Token mitgesendet wird.
+type personioClient struct {
+	baseURL      string
+	clientID     string
+	clientSecret string
+	token        string
+	httpClient   *http.Client
+}
+
+// newPersonioClient erstellt einen Client für die Personio-API unter baseURL
+func newPersonioClient(baseURL, clientID, clientSecret string) *personioClient {
+	return &personioClient{
+		baseURL:      baseURL,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		httpClient:   &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// personioResponse ist der gemeinsame Rahmen aller Antworten der Personio-API
+type personioResponse struct {
+	Success bool            `json:"success"`
+	Data    json.RawMessage `json:"data"`
+	Error   struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// do sendet eine Anfrage und gibt den Inhalt von data zurück
+func (c *personioClient) do(req *http.Request) (json.RawMessage, error) {
+	req.Header.Set("Accept", "application/json")
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var response personioResponse
+	if err := json.Unmarshal(body, &response); err != nil || res.StatusCode != http.StatusOK || !response.Success {
+		message := strings.TrimSpace(response.Error.Message)
+		if message == "" {
+			message = strings.TrimSpace(string(body))
+		}
+		return nil, fmt.Errorf("%w: %s %s", ErrPersonioAPI, res.Status, message)
+	}
+
+	// Personio kann mit jeder Antwort ein neues Token ausgeben
+	if token := strings.TrimPrefix(res.Header.Get("Authorization"), "Bearer "); token != "" {
+		c.token = token
+	}
+	return response.Data, nil
+}
+
+// authenticate fordert mit Client-ID und Secret ein Token an
+func (c *personioClient) authenticate() error {
+	payload, err := json.Marshal(map[string]string{"client_id": c.clientID, "client_secret": c.clientSecret})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, c.baseURL+"/auth", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	data, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	var auth struct {
+		Token string `json:"token"`
+	}
+	if err := json.Unmarshal(data, &auth); err != nil || auth.Token == "" {
+		return fmt.Errorf("%w: Antwort enthält kein Token", ErrPersonioAPI)
+	}
+	c.token = auth.Token
+	return nil
+}
+
+// get ruft einen Endpunkt mit dem Token auf und gibt den Inhalt von data zurück
+func (c *personioClient) get(endpoint string, query url.Values) (json.RawMessage, error) {
+	if c.token == "" {
+		if err := c.authenticate(); err != nil {
+			return nil, err
+		}
+	}
+	req, err := http.NewRequest(http.MethodGet, c.baseURL+endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	return c.do(req)
+}
+
+// getAll ruft alle Seiten eines Endpunkts ab und gibt die Einträge zurück
+func (c *personioClient) getAll(endpoint string, query url.Values) ([]json.RawMessage, error) {
+	var items []json.RawMessage
+	for offset := 0; ; offset += personioPageSize {
+		pageQuery := url.Values{}
+		for key, values := range query {
+			pageQuery[key] = values
+		}
+		pageQuery.Set("limit", strconv.Itoa(personioPageSize))
+		pageQuery.Set("offset", strconv.Itoa(offset))
+
+		data, err := c.get(endpoint, pageQuery)
+		if err != nil {
+			return nil, err
+		}
+		var page []json.RawMessage
+		if err := json.Unmarshal(data, &page); err != nil {
+			return nil, fmt.Errorf("%w: unerwartete Antwort von %s: %v", ErrPersonioAPI, endpoint, err)
+		}
+		items = append(items, page...)
+		if len(page) < personioPageSize {
+			return items, nil
+		}
+	}
+}
+
+// employees ruft alle Mitarbeiter mit ihren Attributen ab
+func (c *personioClient) employees() ([]model.PersonioEmployee, error) {
+	items, err := c.getAll("/company/employees", nil)
+	if err != nil {
+		return nil, err
+	}
+	employees := make([]model.PersonioEmployee, 0, len(items))
+	for _, item := range items {
+		var employee model.PersonioEmployee
+		if err := json.Unmarshal(item, &employee); err != nil {
+			return nil, fmt.Errorf("%w: Mitarbeiter: %v", ErrPersonioAPI, err)
+		}
+		employees = append(employees, employee)
+	}
+	return employees, nil
+}
+
+// personioFlag liest Wahrheitswerte, die Personio als true/false oder 0/1 liefert
+type personioFlag bool
+
+func (f *personioFlag) UnmarshalJSON(data []byte) error {
+	value := strings.Trim(string(data), `"`)
+	*f = personioFlag(value == "true" || value == "1")
+	return nil
+}
+
+// personioTimeOffPeriod ist eine Abwesenheit im Format der Personio-API
+type personioTimeOffPeriod struct {
+	Attributes struct {
+		ID           json.Number  `json:"id"`
+		Status       string       `json:"status"`
+		Comment      string       `json:"comment"`
+		StartDate    string       `json:"start_date"`
+		EndDate      string       `json:"end_date"`
+		DaysCount    float64      `json:"days_count"`
+		HalfDayStart personioFlag `json:"half_day_start"`
+		HalfDayEnd   personioFlag `json:"half_day_end"`
+		TimeOffType  struct {
+			Attributes struct {
+				Name string `json:"name"`
+			} `json:"attributes"`
+		} `json:"time_off_type"`
+		Employee model.PersonioEmployee `json:"employee"`
+	} `json:"attributes"`
+}
+
+// timeOffs ruft die Abwesenheiten im Zeitraum startDate bis endDate (YYYY-MM-DD) ab
+func (c *personioClient) timeOffs(startDate, endDate string) ([]model.PersonioTimeOff, error) {
+	items, err := c.getAll("/company/time-offs", url.Values{"start_date": {startDate}, "end_date": {endDate}})
+	if err != nil {
+		return nil, err
+	}
+	timeOffs := make([]model.PersonioTimeOff, 0, len(items))
+	for _, item := range items {
+		var period personioTimeOffPeriod
+		if err := json.Unmarshal(item, &period); err != nil {
+			return nil, fmt.Errorf("%w: Abwesenheit: %v", ErrPersonioAPI, err)
+		}
+		attributes := period.Attributes
+		timeOffs = append(timeOffs, model.PersonioTimeOff{
+			ID:           attributes.ID.String(),
+			EmployeeID:   attributes.Employee.ID(),
+			TypeName:     attributes.TimeOffType.Attributes.Name,
+			Status:       attributes.Status,
+			StartDate:    parsePersonioDate(attributes.StartDate),
+			EndDate:      parsePersonioDate(attributes.EndDate),
+			DaysCount:    attributes.DaysCount,
+			HalfDayStart: bool(attributes.HalfDayStart),
+			HalfDayEnd:   bool(attributes.HalfDayEnd),
+			Comment:      attributes.Comment,
+		})
+	}
+	return timeOffs, nil
+}
+
+// personioAttendancePeriod ist eine Anwesenheit im Format der Personio-API
+type personioAttendancePeriod struct {
+	ID         json.Number `json:"id"`
+	Attributes struct {
+		Employee  json.Number `json:"employee"`
+		Date      string      `json:"date"`
+		StartTime string      `json:"start_time"`
+		EndTime   *string     `json:"end_time"`
+		Break     int         `json:"break"`
+		Comment   string      `json:"comment"`
+		Project   *struct {
+			Attributes struct {
+				Name string `json:"name"`
+			} `json:"attributes"`
+		} `json:"project"`
+	} `json:"attributes"`
+}
+
+// attendances ruft die Anwesenheiten im Zeitraum startDate bis endDate (YYYY-MM-DD) ab
+func (c *personioClient) attendances(startDate, endDate string) ([]model.PersonioAttendance, error) {
+	items, err := c.getAll("/company/attendances", url.Values{"start_date": {startDate}, "end_date": {endDate}})
+	if err != nil {
+		return nil, err
+	}
+	attendances := make([]model.PersonioAttendance, 0, len(items))
+	for _, item := range items {
+		var period personioAttendancePeriod
+		if err := json.Unmarshal(item, &period); err != nil {
+			return nil, fmt.Errorf("%w: Anwesenheit: %v", ErrPersonioAPI, err)
+		}
+		attributes := period.Attributes
+		attendance := model.PersonioAttendance{
+			ID:           period.ID.String(),
+			EmployeeID:   attributes.Employee.String(),
+			Date:         attributes.Date,
+			StartTime:    attributes.StartTime,
+			BreakMinutes: attributes.Break,
+			Comment:      attributes.Comment,
+		}
+		if attributes.EndTime != nil {
+			attendance.EndTime = *attributes.EndTime
+		}
+		if attributes.Project != nil {
+			attendance.ProjectName = attributes.Project.Attributes.Name
+		}
+		attendances = append(attendances, attendance)
+	}
+	return attendances, nil
+}
+
+// parsePersonioDate liest ein Datum der Personio-API (YYYY-MM-DD, ggf. mit Uhrzeit) als Tag in UTC
+func parsePersonioDate(value string) time.Time {
+	if len(value) > 10 {
+		value = value[:10]
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}
+	}
+	return date
+}
@@ -0,0 +1,260 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"PeopleFlow/backend/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// personioTimeOffsFixture ist eine aufgezeichnete Antwort von /company/time-offs (gekürzt)
+const personioTimeOffsFixture = `[
+	{"type": "TimeOffPeriod", "attributes": {
+		"id": 501, "status": "approved", "comment": "Sommerurlaub",
+		"start_date": "2025-07-07T00:00:00+02:00", "end_date": "2025-07-11T00:00:00+02:00",
+		"days_count": 5, "half_day_start": 0, "half_day_end": 0,
+		"time_off_type": {"type": "TimeOffType", "attributes": {"id": 1, "name": "Urlaub"}},
+		"employee": {"type": "Employee", "attributes": {
+			"id": {"label": "ID", "value": 4711},
+			"first_name": {"label": "Vorname", "value": "Anna"}
+		}}
+	}},
+	{"type": "TimeOffPeriod", "attributes": {
+		"id": 502, "status": "pending", "comment": "",
+		"start_date": "2025-03-03T00:00:00+01:00", "end_date": "2025-03-03T00:00:00+01:00",
+		"days_count": 0.5, "half_day_start": true, "half_day_end": false,
+		"time_off_type": {"type": "TimeOffType", "attributes": {"id": 2, "name": "Krankheit"}},
+		"employee": {"type": "Employee", "attributes": {"id": {"label": "ID", "value": 4711}}}
+	}}
+]`
+
+// personioAttendancesFixture ist eine aufgezeichnete Antwort von /company/attendances (gekürzt)
+const personioAttendancesFixture = `[
+	{"id": 9001, "type": "AttendancePeriod", "attributes": {
+		"employee": 4711, "date": "2025-06-02", "start_time": "08:00", "end_time": "16:30",
+		"break": 30, "comment": "Relaunch", "is_holiday": false, "is_on_time_off": false,
+		"project": {"type": "Project", "attributes": {"id": 3, "name": "Website"}}
+	}},
+	{"id": 9002, "type": "AttendancePeriod", "attributes": {
+		"employee": 4711, "date": "2025-06-03", "start_time": "09:00", "end_time": null,
+		"break": 0, "comment": "", "project": null
+	}}
+]`
+
+// fakePersonio ist ein lokaler Ersatz für die Personio-API mit aufgezeichneten Antworten
+type fakePersonio struct {
+	mu           sync.Mutex
+	clientID     string
+	clientSecret string
+	employees    []map[string]any
+	tokens       int
+	requests     []string
+}
+
+func newFakePersonio(t *testing.T, employeeCount int) (*fakePersonio, *httptest.Server) {
+	fake := &fakePersonio{clientID: "client", clientSecret: "secret"}
+	for i := 1; i <= employeeCount; i++ {
+		status := "active"
+		if i == 2 {
+			status = "inactive"
+		}
+		fake.employees = append(fake.employees, map[string]any{
+			"type": "Employee",
+			"attributes": map[string]any{
+				"id":         map[string]any{"label": "ID", "value": 4710 + i, "type": "integer"},
+				"first_name": map[string]any{"label": "Vorname", "value": fmt.Sprintf("Person%d", i), "type": "standard"},
+				"last_name":  map[string]any{"label": "Nachname", "value": "Muster", "type": "standard"},
+				"email":      map[string]any{"label": "E-Mail", "value": fmt.Sprintf("person%d@example.com", i), "type": "standard"},
+				"status":     map[string]any{"label": "Status", "value": status, "type": "standard"},
+				"hire_date":  map[string]any{"label": "Eintrittsdatum", "value": "2021-03-15T00:00:00+01:00", "type": "date"},
+				"department": map[string]any{"label": "Abteilung", "type": "standard", "value": map[string]any{
+					"type": "Department", "attributes": map[string]any{"id": 3, "name": "IT"},
+				}},
+				"dynamic_99": map[string]any{"label": "Telefon", "value": "030-" + strconv.Itoa(i), "type": "standard"},
+			},
+		})
+	}
+	server := httptest.NewServer(http.HandlerFunc(fake.serveHTTP))
+	t.Cleanup(server.Close)
+	return fake, server
+}
+
+func (f *fakePersonio) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path+"?"+r.URL.RawQuery)
+
+	w.Header().Set("Content-Type", "application/json")
+	if r.URL.Path == "/auth" {
+		var credentials map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&credentials)
+		if credentials["client_id"] != f.clientID || credentials["client_secret"] != f.clientSecret {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"success": false, "error": {"code": 0, "message": "Wrong credentials"}}`)
+			return
+		}
+		f.tokens++
+		fmt.Fprintf(w, `{"success": true, "data": {"token": "token-%d"}}`, f.tokens)
+		return
+	}
+
+	// Jede Antwort gibt ein neues Token aus, das bei der nächsten Anfrage gelten muss
+	if r.Header.Get("Authorization") != fmt.Sprintf("Bearer token-%d", f.tokens) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"success": false, "error": {"code": 0, "message": "The token is invalid"}}`)
+		return
+	}
+	f.tokens++
+	w.Header().Set("Authorization", fmt.Sprintf("Bearer token-%d", f.tokens))
+
+	switch r.URL.Path {
+	case "/company/employees":
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		page := f.employees[min(offset, len(f.employees)):min(offset+limit, len(f.employees))]
+		data, _ := json.Marshal(page)
+		fmt.Fprintf(w, `{"success": true, "data": %s}`, data)
+	case "/company/time-offs":
+		fmt.Fprintf(w, `{"success": true, "data": %s}`, personioTimeOffsFixture)
+	case "/company/attendances":
+		fmt.Fprintf(w, `{"success": true, "data": %s}`, personioAttendancesFixture)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"success": false, "error": {"message": "not found"}}`)
+	}
+}
+
+func TestPersonioClient_Authentication(t *testing.T) {
+	_, server := newFakePersonio(t, 1)
+
+	err := newPersonioClient(server.URL, "client", "falsch").authenticate()
+	assert.ErrorIs(t, err, ErrPersonioAPI)
+	assert.Contains(t, err.Error(), "Wrong credentials")
+
+	assert.NoError(t, newPersonioClient(server.URL, "client", "secret").authenticate())
+}
+
+func TestPersonioClient_EmployeesArePaged(t *testing.T) {
+	fake, server := newFakePersonio(t, personioPageSize+5)
+	client := newPersonioClient(server.URL, "client", "secret")
+
+	employees, err := client.employees()
+	require.NoError(t, err)
+	require.Len(t, employees, personioPageSize+5)
+	assert.Equal(t, "4711", employees[0].ID())
+	assert.Equal(t, "IT", employees[0].Attribute("department"))
+
+	// Anmeldung und zwei Seiten; das rotierte Token wird weiterverwendet
+	require.Len(t, fake.requests, 3)
+	assert.Equal(t, "GET /company/employees?limit=200&offset=200", fake.requests[2])
+}
+
+func TestPersonioIdentities_SkipsInactiveEmployees(t *testing.T) {
+	_, server := newFakePersonio(t, 3)
+	employees, err := newPersonioClient(server.URL, "client", "secret").employees()
+	require.NoError(t, err)
+
+	mapping, err := model.ParsePersonioAttributeMapping("Telefon=phone")
+	require.NoError(t, err)
+	identities := personioIdentities(employees, mapping)
+
+	require.Len(t, identities, 2)
+	assert.Equal(t, "4711", identities[0].ExternalID)
+	assert.Equal(t, "person1@example.com", identities[0].Email)
+	assert.Equal(t, "030-1", identities[0].Phone)
+	assert.Equal(t, "4713", identities[1].ExternalID)
+}
+
+func TestPersonioClient_TimeOffs(t *testing.T) {
+	fake, server := newFakePersonio(t, 1)
+	client := newPersonioClient(server.URL, "client", "secret")
+
+	timeOffs, err := client.timeOffs("2025-01-01", "2025-12-31")
+	require.NoError(t, err)
+	require.Len(t, timeOffs, 2)
+	assert.Contains(t, fake.requests[1], "start_date=2025-01-01")
+
+	assert.Equal(t, model.PersonioTimeOff{
+		ID:         "501",
+		EmployeeID: "4711",
+		TypeName:   "Urlaub",
+		Status:     "approved",
+		StartDate:  time.Date(2025, 7, 7, 0, 0, 0, 0, time.UTC),
+		EndDate:    time.Date(2025, 7, 11, 0, 0, 0, 0, time.UTC),
+		DaysCount:  5,
+		Comment:    "Sommerurlaub",
+	}, timeOffs[0])
+	assert.True(t, timeOffs[1].HalfDayStart)
+
+	// Abgleich mit einem Mitarbeiter: erst neu, dann unverändert
+	employee := &model.Employee{PersonioID: "4711"}
+	start, end, err := parsePersonioSyncRange("2025-01-01", "2025-12-31", time.UTC)
+	require.NoError(t, err)
+	assert.Equal(t, model.AbsenceSyncResult{Created: 2}, employee.ApplyPersonioTimeOffs(start, end, timeOffs))
+	assert.Equal(t, model.AbsenceSyncResult{Unchanged: 2}, employee.ApplyPersonioTimeOffs(start, end, timeOffs))
+	assert.Equal(t, "sick", employee.Absences[1].Type)
+	assert.Equal(t, "requested", employee.Absences[1].Status)
+}
+
+func TestPersonioClient_Attendances(t *testing.T) {
+	_, server := newFakePersonio(t, 1)
+	client := newPersonioClient(server.URL, "client", "secret")
+
+	attendances, err := client.attendances("2025-06-01", "2025-06-30")
+	require.NoError(t, err)
+	require.Len(t, attendances, 2)
+	assert.Equal(t, model.PersonioAttendance{
+		ID: "9001", EmployeeID: "4711", Date: "2025-06-02", StartTime: "08:00", EndTime: "16:30",
+		BreakMinutes: 30, ProjectName: "Website", Comment: "Relaunch",
+	}, attendances[0])
+	assert.Empty(t, attendances[1].EndTime, "laufende Anwesenheit")
+}
+
+func TestSameSyncedTimeEntries(t *testing.T) {
+	location := time.UTC
+	start, end, err := parsePersonioSyncRange("2025-06-01", "2025-06-30", location)
+	require.NoError(t, err)
+
+	entry, err := model.PersonioAttendance{ID: "1", Date: "2025-06-02", StartTime: "08:00", EndTime: "16:00"}.TimeEntry(location)
+	require.NoError(t, err)
+	manual := model.TimeEntry{Date: entry.Date, StartTime: entry.StartTime, EndTime: entry.EndTime, Source: "manual"}
+	outside := entry
+	outside.Date = entry.Date.AddDate(0, 2, 0)
+
+	existing := []model.TimeEntry{entry, manual, outside}
+	assert.True(t, sameSyncedTimeEntries(existing, []model.TimeEntry{entry}, model.PersonioSource, start, end, location))
+
+	changed := entry
+	changed.Duration = 7.5
+	assert.False(t, sameSyncedTimeEntries(existing, []model.TimeEntry{changed}, model.PersonioSource, start, end, location))
+	assert.False(t, sameSyncedTimeEntries(existing, nil, model.PersonioSource, start, end, location))
+}
+
+func TestPersonioIntegration_PrepareSync(t *testing.T) {
+	now := time.Date(2025, 6, 15, 10, 0, 0, 0, time.UTC)
+	provider := personioIntegration{}
+
+	params, err := provider.PrepareSync(model.SyncAbsences, model.SyncJobParams{}, now)
+	require.NoError(t, err)
+	assert.Equal(t, model.SyncJobParams{StartDate: "2025-01-01", EndDate: "2025-12-31"}, params)
+
+	params, err = provider.PrepareSync(model.SyncTimeEntries, model.SyncJobParams{StartDate: "2025-06-01"}, now)
+	require.NoError(t, err)
+	assert.Equal(t, model.SyncJobParams{StartDate: "2025-06-01", EndDate: "2025-06-15"}, params)
+
+	params, err = provider.PrepareSync(model.SyncUsers, model.SyncJobParams{StartDate: "2025-06-01"}, now)
+	require.NoError(t, err)
+	assert.Equal(t, model.SyncJobParams{}, params)
+
+	_, err = provider.PrepareSync(model.SyncTimeEntries, model.SyncJobParams{StartDate: "2025-06-20", EndDate: "2025-06-01"}, now)
+	assert.ErrorIs(t, err, ErrInvalidSyncDateRange)
+}
@@ -0,0 +1,130 @@
+// backend/service/personio_integration.go
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+)
+
+// personioTimeEntriesField ist das synchronisierte Feld der aus Anwesenheiten erzeugten Zeiteinträge
+const personioTimeEntriesField = "timeEntries"
+
+// personioIntegration bindet den PersonioService als Integrationsanbieter an
+type personioIntegration struct{}
+
+// Info beschreibt den Personio-Anbieter
+func (personioIntegration) Info() IntegrationInfo {
+	return IntegrationInfo{
+		Type:        repository.IntegrationTypePersonio,
+		Name:        "Personio",
+		Description: "Mitarbeiter, Abwesenheiten und Anwesenheiten",
+		Fields: []IntegrationField{
+			{Name: "personio-client-id", Label: "Client-ID", Type: "text", Required: true},
+			{Name: "personio-client-secret", Label: "Client-Secret", Type: "password", Required: true},
+			{Name: "personio-attribute-mapping", Label: "Attributzuordnung", Type: "text"},
+		},
+		Capabilities: []model.SyncCapability{model.SyncUsers, model.SyncAbsences, model.SyncTimeEntries},
+		SyncedFields: []model.SyncedField{
+			{Name: "phone", Label: "Telefon", Default: model.FieldOwnershipFillIfEmpty},
+			{Name: "position", Label: "Position", Default: model.FieldOwnershipExternal},
+			{Name: "department", Label: "Abteilung", Default: model.FieldOwnershipExternal},
+			{Name: "hireDate", Label: "Eintrittsdatum", Default: model.FieldOwnershipExternal},
+			{Name: "dateOfBirth", Label: "Geburtsdatum", Default: model.FieldOwnershipFillIfEmpty},
+			{Name: personioTimeEntriesField, Label: "Zeiteinträge", Default: model.FieldOwnershipExternal},
+		},
+		DefaultSchedule: "0 * * * *",
+	}
+}
+
+// Configure testet Client-ID und Secret und speichert sie mit der Attributzuordnung
+func (personioIntegration) Configure(values map[string]string) error {
+	return NewPersonioService().SaveCredentials(values["personio-client-id"], values["personio-client-secret"], values["personio-attribute-mapping"])
+}
+
+// TestConnection prüft die gespeicherten Zugangsdaten
+func (personioIntegration) TestConnection() error {
+	personioService := NewPersonioService()
+	clientID, clientSecret, err := personioService.GetCredentials()
+	if err != nil {
+		return err
+	}
+	return personioService.testConnection(clientID, clientSecret)
+}
+
+// IsConnected prüft, ob Personio verbunden ist
+func (personioIntegration) IsConnected() bool {
+	return NewPersonioService().IsConnected()
+}
+
+// AutoSyncEnabled gibt true zurück: Personio wird wie Timebutler immer regelmäßig synchronisiert
+func (personioIntegration) AutoSyncEnabled() bool {
+	return true
+}
+
+// Status gibt den Zustand der Personio-Integration mit der Attributzuordnung zurück
+func (p personioIntegration) Status() (*IntegrationStatus, error) {
+	status := newIntegrationStatus(p.Info(), p.IsConnected())
+	status.AutoSync = true
+	if mapping, err := NewPersonioService().AttributeMapping(); err == nil {
+		status.Details = map[string]string{"attributeMapping": mapping.String()}
+	}
+	return status, nil
+}
+
+// PrepareSync ergänzt den Zeitraum: Abwesenheiten im laufenden Jahr, Anwesenheiten vom
+// 1. Januar bis heute
+func (personioIntegration) PrepareSync(capability model.SyncCapability, params model.SyncJobParams, now time.Time) (model.SyncJobParams, error) {
+	if capability == model.SyncUsers {
+		return model.SyncJobParams{}, nil
+	}
+
+	prepared := model.SyncJobParams{StartDate: params.StartDate, EndDate: params.EndDate}
+	if prepared.StartDate == "" {
+		prepared.StartDate = time.Date(now.Year(), 1, 1, 0, 0, 0, 0, time.UTC).Format("2006-01-02")
+	}
+	if prepared.EndDate == "" {
+		if capability == model.SyncAbsences {
+			prepared.EndDate = time.Date(now.Year(), 12, 31, 0, 0, 0, 0, time.UTC).Format("2006-01-02")
+		} else {
+			prepared.EndDate = now.Format("2006-01-02")
+		}
+	}
+
+	if err := validateSyncDateRange(prepared.StartDate, prepared.EndDate); err != nil {
+		return params, err
+	}
+	return prepared, nil
+}
+
+// Sync synchronisiert einen Bereich aus Personio
+func (personioIntegration) Sync(capability model.SyncCapability, params model.SyncJobParams, progress SyncProgress) (int, error) {
+	personioService := NewPersonioService().WithProgress(progress).WithDryRun(params.DryRun)
+	switch capability {
+	case model.SyncUsers:
+		return personioService.SyncPersonioEmployees()
+	case model.SyncAbsences:
+		return personioService.SyncPersonioAbsences(params.StartDate, params.EndDate)
+	case model.SyncTimeEntries:
+		return personioService.SyncPersonioAttendances(params.StartDate, params.EndDate)
+	default:
+		return 0, fmt.Errorf("%w: %s", ErrSyncCapabilityUnsupported, capability)
+	}
+}
+
+// ExternalIdentities gibt die aktiven Personio-Mitarbeiter für den Abgleich zurück
+func (personioIntegration) ExternalIdentities() ([]model.ExternalIdentity, error) {
+	return NewPersonioService().ExternalIdentities()
+}
+
+// EmployeeExternalID gibt die Personio-ID eines Mitarbeiters zurück
+func (personioIntegration) EmployeeExternalID(employee *model.Employee) string {
+	return employee.PersonioID
+}
+
+// SetEmployeeExternalID speichert die Personio-ID eines Mitarbeiters
+func (personioIntegration) SetEmployeeExternalID(employeeID, externalID string) error {
+	return repository.NewEmployeeRepository().UpdatePersonioID(employeeID, externalID)
+}
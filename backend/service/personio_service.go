@@ -0,0 +1,465 @@
+// backend/service/personio_service.go
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+)
+
+// personioAttributeMappingKey ist der Metadaten-Schlüssel der Attributzuordnung
+const personioAttributeMappingKey = "attribute_mapping"
+
+// PersonioService verwaltet die Integration mit Personio
+type PersonioService struct {
+	integrationRepo *repository.IntegrationRepository
+	apiURL          string
+	progress        SyncProgress
+	dryRun          bool
+}
+
+// NewPersonioService erstellt einen neuen PersonioService
+func NewPersonioService() *PersonioService {
+	return &PersonioService{
+		integrationRepo: repository.NewIntegrationRepository(),
+		apiURL:          personioAPIURL(),
+	}
+}
+
+// WithProgress gibt eine Kopie des Services zurück, die den Fortschritt der
+// Synchronisierung an progress meldet
+func (s *PersonioService) WithProgress(progress SyncProgress) *PersonioService {
+	clone := *s
+	clone.progress = progress
+	return &clone
+}
+
+// WithDryRun gibt eine Kopie des Services zurück, die bei dryRun keine Mitarbeiter speichert,
+// sondern nur die Änderungen meldet (Probelauf)
+func (s *PersonioService) WithDryRun(dryRun bool) *PersonioService {
+	clone := *s
+	clone.dryRun = dryRun
+	return &clone
+}
+
+// reporter gibt den Fortschrittsempfänger zurück (ohne gesetzten Empfänger wird nichts gemeldet)
+func (s *PersonioService) reporter() SyncProgress {
+	if s.progress == nil {
+		return noSyncProgress{}
+	}
+	return s.progress
+}
+
+// saveEmployee speichert einen synchronisierten Mitarbeiter und meldet die Änderungen
+func (s *PersonioService) saveEmployee(employeeRepo *repository.EmployeeRepository, employee *model.Employee) error {
+	return saveSyncedEmployee(employeeRepo, employee, s.dryRun, s.reporter())
+}
+
+// SaveCredentials testet Client-ID und Secret, speichert sie verschlüsselt zusammen mit der
+// Attributzuordnung und aktiviert die Integration
+func (s *PersonioService) SaveCredentials(clientID, clientSecret, attributeMapping string) error {
+	mapping, err := model.ParsePersonioAttributeMapping(attributeMapping)
+	if err != nil {
+		return err
+	}
+	if err := s.testConnection(clientID, clientSecret); err != nil {
+		return err
+	}
+
+	// Client-ID und Secret zusammen speichern (werden im Repository verschlüsselt)
+	if err := s.integrationRepo.SaveApiKey(repository.IntegrationTypePersonio, clientID+":"+clientSecret); err != nil {
+		return err
+	}
+	if err := s.integrationRepo.SetMetadata(repository.IntegrationTypePersonio, personioAttributeMappingKey, mapping.String()); err != nil {
+		return err
+	}
+	return s.integrationRepo.SetIntegrationStatus(repository.IntegrationTypePersonio, true)
+}
+
+// GetCredentials gibt die gespeicherte Client-ID und das Secret zurück
+func (s *PersonioService) GetCredentials() (string, string, error) {
+	credentials, err := s.integrationRepo.GetApiKey(repository.IntegrationTypePersonio)
+	if err != nil {
+		return "", "", err
+	}
+	clientID, clientSecret, ok := strings.Cut(credentials, ":")
+	if !ok {
+		return "", "", errors.New("ungültiges Anmeldedatenformat")
+	}
+	return clientID, clientSecret, nil
+}
+
+// testConnection fordert mit den angegebenen Zugangsdaten ein Token an
+func (s *PersonioService) testConnection(clientID, clientSecret string) error {
+	return newPersonioClient(s.apiURL, clientID, clientSecret).authenticate()
+}
+
+// client gibt einen Client für die Personio-API mit den gespeicherten Zugangsdaten zurück
+func (s *PersonioService) client() (*personioClient, error) {
+	clientID, clientSecret, err := s.GetCredentials()
+	if err != nil {
+		return nil, err
+	}
+	return newPersonioClient(s.apiURL, clientID, clientSecret), nil
+}
+
+// IsConnected prüft, ob die Personio-Integration aktiv ist
+func (s *PersonioService) IsConnected() bool {
+	active, err := s.integrationRepo.GetIntegrationStatus(repository.IntegrationTypePersonio)
+	return err == nil && active
+}
+
+// AttributeMapping gibt die gespeicherte Zuordnung der Personio-Attribute zu Mitarbeiterfeldern zurück
+func (s *PersonioService) AttributeMapping() (model.PersonioAttributeMapping, error) {
+	value, err := s.integrationRepo.GetMetadata(repository.IntegrationTypePersonio, personioAttributeMappingKey)
+	if err != nil && !errors.Is(err, repository.ErrIntegrationNotFound) {
+		return nil, err
+	}
+	return model.ParsePersonioAttributeMapping(value)
+}
+
+// GetEmployees ruft die Mitarbeiter von Personio ab
+func (s *PersonioService) GetEmployees() ([]model.PersonioEmployee, error) {
+	client, err := s.client()
+	if err != nil {
+		return nil, err
+	}
+	return client.employees()
+}
+
+// ExternalIdentities gibt die aktiven Personio-Mitarbeiter für den Abgleich zurück
+func (s *PersonioService) ExternalIdentities() ([]model.ExternalIdentity, error) {
+	employees, err := s.GetEmployees()
+	if err != nil {
+		return nil, err
+	}
+	mapping, err := s.AttributeMapping()
+	if err != nil {
+		return nil, err
+	}
+	return personioIdentities(employees, mapping), nil
+}
+
+// SyncPersonioEmployees importiert die aktiven Personio-Mitarbeiter: Zugeordnete Mitarbeiter
+// erhalten die Personio-ID und die zugeordneten Attribute gemäß den Zuständigkeiten, für nicht
+// zugeordnete wird ein Mitarbeiter angelegt
+func (s *PersonioService) SyncPersonioEmployees() (int, error) {
+	progress := s.reporter()
+	progress.StartPhase("Mitarbeiter von Personio abrufen", 0)
+
+	personioEmployees, err := s.GetEmployees()
+	if err != nil {
+		return 0, err
+	}
+	mapping, err := s.AttributeMapping()
+	if err != nil {
+		return 0, err
+	}
+
+	employeeRepo := repository.NewEmployeeRepository()
+	employees, _, err := employeeRepo.FindAll(0, 1000, "lastName", 1)
+	if err != nil {
+		return 0, err
+	}
+
+	// Personio-Mitarbeiter zuordnen: manuelle Zuordnungen, gespeicherte ID, dann E-Mail
+	identityMappings, err := loadIdentityMappings(repository.IntegrationTypePersonio)
+	if err != nil {
+		return 0, err
+	}
+	report := model.MatchIdentities(personioIdentities(personioEmployees, mapping), employees, identityMappings,
+		func(e *model.Employee) string { return e.PersonioID })
+	matchedIDs := report.EmployeeExternalIDs()
+
+	policy, err := newFieldPolicy(personioIntegration{}.Info())
+	if err != nil {
+		return 0, err
+	}
+
+	personioByID := make(map[string]model.PersonioEmployee, len(personioEmployees))
+	for _, personioEmployee := range personioEmployees {
+		personioByID[personioEmployee.ID()] = personioEmployee
+	}
+
+	updatedCount := 0
+
+	progress.StartPhase("Mitarbeiter abgleichen", len(employees))
+	for _, employee := range employees {
+		progress.Advance(1)
+
+		personioID, found := matchedIDs[employee.ID]
+		if !found {
+			continue
+		}
+
+		updated := employee.PersonioID != personioID
+		employee.PersonioID = personioID
+
+		changed, fieldsUpdated, err := applyPersonioValues(policy, employee, personioByID[personioID].MappedValues(mapping))
+		if err != nil {
+			return updatedCount, err
+		}
+		updated = updated || fieldsUpdated
+		if !updated && !changed {
+			continue
+		}
+
+		if updated {
+			employee.UpdatedAt = time.Now()
+		}
+		if err := s.saveEmployee(employeeRepo, employee); err != nil {
+			return updatedCount, err
+		}
+		if updated {
+			updatedCount++
+		}
+	}
+
+	// Nicht zugeordnete Personio-Mitarbeiter anlegen
+	progress.StartPhase("Neue Mitarbeiter anlegen", len(report.UnmatchedExternal))
+	for _, unmatched := range report.UnmatchedExternal {
+		progress.Advance(1)
+
+		employee := employeeFromIdentity(repository.IntegrationTypePersonio, unmatched.External)
+		employee.PersonioID = unmatched.External.ExternalID
+		if _, _, err := applyPersonioValues(policy, employee, personioByID[employee.PersonioID].MappedValues(mapping)); err != nil {
+			return updatedCount, err
+		}
+
+		progress.RecordChange(model.DiffEmployee(nil, employee))
+		if !s.dryRun {
+			if err := employeeRepo.CreateWithoutAccount(employee); err != nil {
+				progress.Warn(fmt.Sprintf("Personio-Mitarbeiter %s konnte nicht angelegt werden: %v", unmatched.External.FullName(), err))
+				continue
+			}
+		}
+		updatedCount++
+	}
+	policy.reportConflicts(progress, s.dryRun)
+
+	return updatedCount, nil
+}
+
+// applyPersonioValues übernimmt die zugeordneten Attributwerte gemäß den Zuständigkeiten.
+// Gibt zurück, ob der Mitarbeiter gespeichert werden muss und ob sich ein Feld geändert hat.
+func applyPersonioValues(policy *fieldPolicy, employee *model.Employee, values map[string]string) (changed, updated bool, err error) {
+	fields := make([]string, 0, len(values))
+	for field := range values {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		result, err := policy.apply(employee, field, values[field])
+		if err != nil {
+			return changed, updated, err
+		}
+		changed = changed || result.Changed()
+		updated = updated || result.Updated
+	}
+	return changed, updated, nil
+}
+
+// SyncPersonioAbsences gleicht die Abwesenheiten im Zeitraum startDate bis endDate inkrementell
+// mit Personio ab: Neue und geänderte Abwesenheiten werden übernommen (erkannt an der Prüfsumme
+// je Personio-ID), stornierte und gelöschte an die Mitarbeiter weitergegeben
+func (s *PersonioService) SyncPersonioAbsences(startDate, endDate string) (int, error) {
+	progress := s.reporter()
+	progress.StartPhase("Abwesenheiten von Personio abrufen", 0)
+
+	start, end, err := parsePersonioSyncRange(startDate, endDate, time.UTC)
+	if err != nil {
+		return 0, err
+	}
+
+	client, err := s.client()
+	if err != nil {
+		return 0, err
+	}
+	timeOffs, err := client.timeOffs(startDate, endDate)
+	if err != nil {
+		return 0, err
+	}
+	timeOffsByEmployee := make(map[string][]model.PersonioTimeOff)
+	for _, timeOff := range timeOffs {
+		timeOffsByEmployee[timeOff.EmployeeID] = append(timeOffsByEmployee[timeOff.EmployeeID], timeOff)
+	}
+
+	employeeRepo := repository.NewEmployeeRepository()
+	employees, _, err := employeeRepo.FindAll(0, 1000, "lastName", 1)
+	if err != nil {
+		return 0, err
+	}
+
+	updatedCount := 0
+	var total model.AbsenceSyncResult
+
+	progress.StartPhase("Abwesenheiten abgleichen", len(employees))
+	for _, employee := range employees {
+		progress.Advance(1)
+
+		if employee.PersonioID == "" {
+			continue
+		}
+
+		result := employee.ApplyPersonioTimeOffs(start, end, timeOffsByEmployee[employee.PersonioID])
+		total.Add(result)
+		changed := result.Changed()
+
+		if recalculateRemainingVacation(employee, time.Now().Year()) {
+			changed = true
+		}
+		if !changed {
+			continue
+		}
+
+		employee.UpdatedAt = time.Now()
+		if err := s.saveEmployee(employeeRepo, employee); err != nil {
+			return updatedCount, err
+		}
+		updatedCount++
+	}
+
+	log.Printf("Personio-Abwesenheiten %s - %s: %d angelegt, %d aktualisiert, %d storniert, %d entfernt, %d unverändert",
+		startDate, endDate, total.Created, total.Updated, total.Cancelled, total.Removed, total.Unchanged)
+
+	return updatedCount, nil
+}
+
+// SyncPersonioAttendances übernimmt die Anwesenheiten im Zeitraum startDate bis endDate als
+// Zeiteinträge. Bestehende Personio-Einträge im Zeitraum werden gemäß der Zuständigkeit für
+// Zeiteinträge ersetzt; noch laufende Anwesenheiten ohne Ende werden übersprungen.
+func (s *PersonioService) SyncPersonioAttendances(startDate, endDate string) (int, error) {
+	progress := s.reporter()
+	progress.StartPhase("Anwesenheiten von Personio abrufen", 0)
+
+	location := getGermanLocation()
+	start, end, err := parsePersonioSyncRange(startDate, endDate, location)
+	if err != nil {
+		return 0, err
+	}
+
+	client, err := s.client()
+	if err != nil {
+		return 0, err
+	}
+	attendances, err := client.attendances(startDate, endDate)
+	if err != nil {
+		return 0, err
+	}
+
+	policy, err := newFieldPolicy(personioIntegration{}.Info())
+	if err != nil {
+		return 0, err
+	}
+	ownership := policy.ownership(personioTimeEntriesField)
+
+	entriesByEmployee := make(map[string][]model.TimeEntry)
+	for _, attendance := range attendances {
+		entry, err := attendance.TimeEntry(location)
+		if err != nil {
+			if attendance.EndTime != "" {
+				progress.Warn(fmt.Sprintf("Personio-Anwesenheit %s übersprungen: %v", attendance.ID, err))
+			}
+			continue
+		}
+		entriesByEmployee[attendance.EmployeeID] = append(entriesByEmployee[attendance.EmployeeID], entry)
+	}
+
+	employeeRepo := repository.NewEmployeeRepository()
+	employees, _, err := employeeRepo.FindAll(0, 1000, "lastName", 1)
+	if err != nil {
+		return 0, err
+	}
+
+	updatedCount := 0
+
+	progress.StartPhase("Zeiteinträge abgleichen", len(employees))
+	for _, employee := range employees {
+		progress.Advance(1)
+
+		if employee.PersonioID == "" {
+			continue
+		}
+		incoming := entriesByEmployee[employee.PersonioID]
+		if sameSyncedTimeEntries(employee.TimeEntries, incoming, model.PersonioSource, start, end, location) {
+			continue
+		}
+
+		merged, removed, added := mergeSyncedTimeEntries(model.PersonioSource, employee.TimeEntries, incoming, start, end, location, ownership)
+		if removed == 0 && added == 0 {
+			continue
+		}
+		sort.SliceStable(merged, func(i, j int) bool { return merged[i].StartTime.Before(merged[j].StartTime) })
+		employee.TimeEntries = merged
+		employee.SetFieldSource(personioTimeEntriesField, repository.IntegrationTypePersonio, time.Now())
+
+		employee.UpdatedAt = time.Now()
+		if err := s.saveEmployee(employeeRepo, employee); err != nil {
+			return updatedCount, err
+		}
+		updatedCount++
+	}
+
+	return updatedCount, nil
+}
+
+// sameSyncedTimeEntries prüft, ob die Einträge der Quelle source im Zeitraum bereits den
+// abgerufenen Einträgen entsprechen, damit unveränderte Mitarbeiter nicht gespeichert werden
+func sameSyncedTimeEntries(existing, incoming []model.TimeEntry, source string, start, end time.Time, location *time.Location) bool {
+	key := func(entry model.TimeEntry) string {
+		return fmt.Sprintf("%s|%s|%.4f|%s|%s", entry.StartTime.In(location).Format(time.RFC3339),
+			entry.EndTime.In(location).Format(time.RFC3339), entry.Duration, entry.ProjectName, entry.Description)
+	}
+
+	counts := make(map[string]int, len(incoming))
+	for _, entry := range incoming {
+		counts[key(entry)]++
+	}
+	stored := 0
+	for _, entry := range existing {
+		date := entry.Date.In(location)
+		if entry.Source != source || date.Before(start) || date.After(end) {
+			continue
+		}
+		stored++
+		counts[key(entry)]--
+	}
+	if stored != len(incoming) {
+		return false
+	}
+	for _, count := range counts {
+		if count != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// parsePersonioSyncRange liest einen Zeitraum im Format YYYY-MM-DD; das Ende umfasst den ganzen Tag
+func parsePersonioSyncRange(startDate, endDate string, location *time.Location) (time.Time, time.Time, error) {
+	if err := validateSyncDateRange(startDate, endDate); err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	start, _ := time.ParseInLocation("2006-01-02", startDate, location)
+	end, _ := time.ParseInLocation("2006-01-02", endDate, location)
+	return start, end.Add(24*time.Hour - time.Nanosecond), nil
+}
+
+// personioIdentities wandelt die aktiven Personio-Mitarbeiter in externe Benutzer für den Abgleich um
+func personioIdentities(employees []model.PersonioEmployee, mapping model.PersonioAttributeMapping) []model.ExternalIdentity {
+	identities := make([]model.ExternalIdentity, 0, len(employees))
+	for _, employee := range employees {
+		if !employee.IsActive() {
+			continue
+		}
+		identities = append(identities, employee.Identity(mapping))
+	}
+	return identities
+}
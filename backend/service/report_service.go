@@ -0,0 +1,594 @@
+// backend/service/report_service.go
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+)
+
+// Berichts-Fehler
+var (
+	ErrReportNotAllowed = errors.New("report type is not available for this user")
+	ErrReportNoEmployee = errors.New("user is not linked to an employee")
+	ErrReportNoTeam     = errors.New("no team found for this user")
+)
+
+// ReportSectionInfo beschreibt einen wählbaren Abschnitt eines Berichts
+type ReportSectionInfo struct {
+	Key   model.ReportSection `json:"key"`
+	Label string              `json:"label"`
+}
+
+// ReportTypeInfo beschreibt eine für den Benutzer abonnierbare Berichtsart
+type ReportTypeInfo struct {
+	Type             model.ReportType        `json:"type"`
+	Label            string                  `json:"label"`
+	Frequencies      []model.ReportFrequency `json:"frequencies"`
+	DefaultFrequency model.ReportFrequency   `json:"defaultFrequency"`
+	Sections         []ReportSectionInfo     `json:"sections"`
+	AllowsDepartment bool                    `json:"allowsDepartment"`
+}
+
+// reportTemplates ordnet jeder Berichtsart ihre E-Mail-Vorlage zu
+var reportTemplates = map[model.ReportType]model.EmailTemplateKey{
+	model.ReportWeeklyTimeSummary: model.EmailTemplateWeeklyReport,
+	model.ReportMonthlyOvertime:   model.EmailTemplateMonthlyOvertime,
+	model.ReportTeamDigest:        model.EmailTemplateTeamDigest,
+	model.ReportMonthlyAbsences:   model.EmailTemplateMonthlyAbsences,
+}
+
+// reportWeekdays sind die Wochentagskürzel je Sprache (Index = time.Weekday)
+var reportWeekdays = map[string][7]string{
+	model.EmailLanguageGerman:  {"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+	model.EmailLanguageEnglish: {"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+}
+
+// reportMonths sind die Monatsnamen je Sprache (Index = time.Month - 1)
+var reportMonths = map[string][12]string{
+	model.EmailLanguageGerman:  {"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+	model.EmailLanguageEnglish: {"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+}
+
+// reportLabelsEnglish übersetzt Abwesenheits- und Anpassungsarten für englische Berichte
+var reportLabelsEnglish = map[string]string{
+	"vacation":   "Vacation",
+	"sick":       "Sick leave",
+	"special":    "Special leave",
+	"manual":     "Manual adjustment",
+	"correction": "Correction",
+	"carryover":  "Carry-over",
+	"payout":     "Payout",
+}
+
+// ReportService verwaltet Berichts-Abonnements und versendet die Berichte per E-Mail
+type ReportService struct {
+	subscriptionRepo   *repository.ReportSubscriptionRepository
+	userRepo           *repository.UserRepository
+	employeeRepo       *repository.EmployeeRepository
+	timeAccountService *TimeAccountService
+	emailService       *EmailService
+}
+
+// NewReportService erstellt einen neuen ReportService
+func NewReportService() *ReportService {
+	return &ReportService{
+		subscriptionRepo:   repository.NewReportSubscriptionRepository(),
+		userRepo:           repository.NewUserRepository(),
+		employeeRepo:       repository.NewEmployeeRepository(),
+		timeAccountService: NewTimeAccountService(),
+		emailService:       NewEmailService(),
+	}
+}
+
+// AvailableTypes gibt die Berichtsarten zurück, die der Benutzer abonnieren darf
+func (s *ReportService) AvailableTypes(user *model.User) []ReportTypeInfo {
+	var infos []ReportTypeInfo
+	for _, reportType := range model.ReportTypes() {
+		if s.checkAllowed(user, reportType) != nil {
+			continue
+		}
+
+		info := ReportTypeInfo{
+			Type:             reportType,
+			Label:            reportType.GetLabel(),
+			Frequencies:      reportType.Frequencies(),
+			DefaultFrequency: reportType.DefaultFrequency(),
+			AllowsDepartment: !reportType.IsPersonal() && user.Role != model.RoleManager,
+		}
+		for _, section := range reportType.Sections() {
+			info.Sections = append(info.Sections, ReportSectionInfo{Key: section, Label: section.GetLabel()})
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// ListSubscriptions gibt die Abonnements des Benutzers zurück
+func (s *ReportService) ListSubscriptions(user *model.User) ([]*model.ReportSubscription, error) {
+	subscriptions, err := s.subscriptionRepo.FindByUser(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if subscriptions == nil {
+		subscriptions = []*model.ReportSubscription{}
+	}
+	return subscriptions, nil
+}
+
+// CreateSubscription legt ein Abonnement für den Benutzer an
+func (s *ReportService) CreateSubscription(user *model.User, subscription *model.ReportSubscription) error {
+	if err := s.checkAllowed(user, subscription.Type); err != nil {
+		return err
+	}
+	if err := s.checkDepartment(user, subscription); err != nil {
+		return err
+	}
+
+	subscription.UserID = user.ID
+	return s.subscriptionRepo.Create(subscription)
+}
+
+// UpdateSubscription ändert Zeitplan und Inhalt eines eigenen Abonnements
+func (s *ReportService) UpdateSubscription(user *model.User, id string, changes *model.ReportSubscription) (*model.ReportSubscription, error) {
+	subscription, err := s.ownSubscription(user, id)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription.Frequency = changes.Frequency
+	subscription.Weekday = changes.Weekday
+	subscription.DayOfMonth = changes.DayOfMonth
+	subscription.Hour = changes.Hour
+	subscription.Sections = changes.Sections
+	subscription.Department = changes.Department
+	subscription.Active = changes.Active
+
+	if err := s.checkDepartment(user, subscription); err != nil {
+		return nil, err
+	}
+	if err := s.subscriptionRepo.Update(subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// DeleteSubscription löscht ein eigenes Abonnement
+func (s *ReportService) DeleteSubscription(user *model.User, id string) error {
+	if _, err := s.ownSubscription(user, id); err != nil {
+		return err
+	}
+	return s.subscriptionRepo.Delete(id)
+}
+
+// SendNow versendet den Bericht eines eigenen Abonnements sofort, unabhängig vom Zeitplan
+func (s *ReportService) SendNow(user *model.User, id string) error {
+	subscription, err := s.ownSubscription(user, id)
+	if err != nil {
+		return err
+	}
+	return s.send(subscription, user, time.Now())
+}
+
+// SendDue versendet alle fälligen Berichte; wird stündlich vom Background-Worker aufgerufen
+func (s *ReportService) SendDue(now time.Time) (sent int, failed int, err error) {
+	if !s.emailService.IsEmailConfigured() {
+		return 0, 0, nil
+	}
+
+	subscriptions, err := s.subscriptionRepo.FindActive()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, subscription := range subscriptions {
+		if !subscription.IsDue(now) {
+			continue
+		}
+
+		user, err := s.userRepo.FindByID(subscription.UserID.Hex())
+		if err != nil || !user.IsActive() {
+			continue
+		}
+
+		if err := s.send(subscription, user, now); err != nil {
+			log.Printf("Bericht %s für %s konnte nicht erstellt werden: %v", subscription.Type, user.Email, err)
+			failed++
+			continue
+		}
+		if err := s.subscriptionRepo.MarkSent(subscription.ID, now); err != nil {
+			log.Printf("Versandzeitpunkt für Bericht %s konnte nicht gespeichert werden: %v", subscription.ID.Hex(), err)
+		}
+		sent++
+	}
+	return sent, failed, nil
+}
+
+// ownSubscription lädt ein Abonnement des Benutzers; fremde Abonnements gelten als nicht gefunden
+func (s *ReportService) ownSubscription(user *model.User, id string) (*model.ReportSubscription, error) {
+	subscription, err := s.subscriptionRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if subscription.UserID != user.ID {
+		return nil, repository.ErrReportSubscriptionNotFound
+	}
+	return subscription, nil
+}
+
+// checkAllowed prüft Rolle und Mitarbeiter-Verknüpfung für eine Berichtsart
+func (s *ReportService) checkAllowed(user *model.User, reportType model.ReportType) error {
+	if !reportType.IsValid() {
+		return fmt.Errorf("%w: %s", model.ErrInvalidReportType, reportType)
+	}
+	if !reportType.AllowsRole(user.Role) {
+		return fmt.Errorf("%w: %s", ErrReportNotAllowed, reportType)
+	}
+	if reportType.IsPersonal() && user.EmployeeID == nil {
+		return ErrReportNoEmployee
+	}
+	if reportType == model.ReportTeamDigest && user.Role == model.RoleManager && user.EmployeeID == nil {
+		return ErrReportNoEmployee
+	}
+	return nil
+}
+
+// checkDepartment erlaubt die Abteilungsauswahl nur für Admins und HR bei nicht-persönlichen Berichten.
+// Vorgesetzte erhalten in der Team-Übersicht immer ihre direkten Mitarbeiter.
+func (s *ReportService) checkDepartment(user *model.User, subscription *model.ReportSubscription) error {
+	if subscription.Department == "" {
+		return nil
+	}
+	if subscription.Type.IsPersonal() || (user.Role != model.RoleAdmin && user.Role != model.RoleHR) {
+		return fmt.Errorf("%w: Abteilungsfilter", ErrReportNotAllowed)
+	}
+	return nil
+}
+
+// send erstellt den Bericht für den Zeitraum vor now und legt ihn im Postausgang ab
+func (s *ReportService) send(subscription *model.ReportSubscription, user *model.User, now time.Time) error {
+	if err := s.checkAllowed(user, subscription.Type); err != nil {
+		return err
+	}
+
+	language := s.emailService.LanguageFor(user)
+	start, end := model.ReportPeriod(subscription.Frequency, now)
+	if subscription.Type == model.ReportMonthlyOvertime || subscription.Type == model.ReportMonthlyAbsences {
+		start, end = model.ReportPeriod(model.ReportMonthly, now)
+	}
+
+	var data map[string]interface{}
+	var err error
+	switch subscription.Type {
+	case model.ReportWeeklyTimeSummary:
+		data, err = s.weeklyTimeSummary(subscription, user, language, start, end)
+	case model.ReportMonthlyOvertime:
+		data, err = s.monthlyOvertime(subscription, user, language, start, end)
+	case model.ReportTeamDigest:
+		data, err = s.teamDigest(subscription, user, language, start, end)
+	case model.ReportMonthlyAbsences:
+		data, err = s.monthlyAbsences(subscription, user, language, start, end)
+	}
+	if err != nil {
+		return err
+	}
+
+	data["Name"] = user.GetDisplayName()
+	return s.emailService.SendTemplate(user.Email, reportTemplates[subscription.Type], language, data)
+}
+
+// weeklyTimeSummary erstellt die Daten der persönlichen Wochenübersicht aus den Zeiteinträgen
+func (s *ReportService) weeklyTimeSummary(subscription *model.ReportSubscription, user *model.User, language string, start, end time.Time) (map[string]interface{}, error) {
+	employee, err := s.employeeRepo.FindByID(user.EmployeeID.Hex())
+	if err != nil {
+		return nil, err
+	}
+
+	summary := model.SummarizeTimeEntries(employee.TimeEntries, start, end)
+	planned, _ := s.timeAccountService.CalculateExpectedHoursForEmployee(employee, start, end.AddDate(0, 0, -1))
+
+	var days []map[string]string
+	for _, day := range summary.Days {
+		weekend := day.Date.Weekday() == time.Saturday || day.Date.Weekday() == time.Sunday
+		if weekend && day.Hours == 0 {
+			continue
+		}
+		days = append(days, map[string]string{
+			"Date":  reportWeekdays[language][day.Date.Weekday()] + " " + day.Date.Format("02.01.2006"),
+			"Hours": formatReportHours(day.Hours),
+		})
+	}
+
+	var projects []map[string]string
+	for _, project := range summary.Projects {
+		projects = append(projects, map[string]string{
+			"Name":  project.Name,
+			"Hours": formatReportHours(project.Hours),
+		})
+	}
+
+	return map[string]interface{}{
+		"EmployeeName":    employee.FirstName + " " + employee.LastName,
+		"WeekStart":       start.Format("02.01.2006"),
+		"WeekEnd":         end.AddDate(0, 0, -1).Format("02.01.2006"),
+		"TotalHours":      formatReportHours(summary.TotalHours),
+		"PlannedHours":    formatReportHours(planned),
+		"Difference":      formatReportBalance(summary.TotalHours - planned),
+		"OvertimeBalance": formatReportBalance(employee.CalculateFinalOvertimeBalance()),
+		"Days":            days,
+		"Projects":        projects,
+		"ShowDays":        subscription.Includes(model.ReportSectionDays),
+		"ShowProjects":    subscription.Includes(model.ReportSectionProjects),
+		"ShowOvertime":    subscription.Includes(model.ReportSectionOvertime),
+	}, nil
+}
+
+// monthlyOvertime erstellt die Daten der persönlichen Überstunden-Abrechnung eines Monats
+func (s *ReportService) monthlyOvertime(subscription *model.ReportSubscription, user *model.User, language string, start, end time.Time) (map[string]interface{}, error) {
+	employee, err := s.employeeRepo.FindByID(user.EmployeeID.Hex())
+	if err != nil {
+		return nil, err
+	}
+
+	// Soll/Ist je Kalenderwoche, jeweils auf den Monat begrenzt
+	var weeks []map[string]string
+	var totalPlanned, totalActual float64
+	for weekStart := start; weekStart.Before(end); {
+		weekEnd := weekStart.AddDate(0, 0, 7-(int(weekStart.Weekday())+6)%7)
+		if weekEnd.After(end) {
+			weekEnd = end
+		}
+
+		actual := model.SummarizeTimeEntries(employee.TimeEntries, weekStart, weekEnd).TotalHours
+		planned, _ := s.timeAccountService.CalculateExpectedHoursForEmployee(employee, weekStart, weekEnd.AddDate(0, 0, -1))
+		totalActual += actual
+		totalPlanned += planned
+
+		weeks = append(weeks, map[string]string{
+			"Week":         fmt.Sprintf("%d", isoWeek(weekStart)),
+			"Start":        weekStart.Format("02.01."),
+			"End":          weekEnd.AddDate(0, 0, -1).Format("02.01."),
+			"PlannedHours": formatReportHours(planned),
+			"ActualHours":  formatReportHours(actual),
+			"Difference":   formatReportBalance(actual - planned),
+		})
+		weekStart = weekEnd
+	}
+
+	var adjustments []map[string]string
+	for _, adjustment := range employee.GetApprovedAdjustments() {
+		date := adjustment.ApprovedAt
+		if date.IsZero() {
+			date = adjustment.CreatedAt
+		}
+		if date.Before(start) || !date.Before(end) {
+			continue
+		}
+		adjustments = append(adjustments, map[string]string{
+			"Date":   date.Format("02.01.2006"),
+			"Type":   reportLabel(language, string(adjustment.Type), adjustment.Type.GetLabel()),
+			"Hours":  formatReportBalance(adjustment.Hours),
+			"Reason": adjustment.Reason,
+		})
+	}
+
+	return map[string]interface{}{
+		"EmployeeName":    employee.FirstName + " " + employee.LastName,
+		"Month":           reportMonth(language, start),
+		"PlannedHours":    formatReportHours(totalPlanned),
+		"ActualHours":     formatReportHours(totalActual),
+		"Difference":      formatReportBalance(totalActual - totalPlanned),
+		"OvertimeBalance": formatReportBalance(employee.CalculateFinalOvertimeBalance()),
+		"Weeks":           weeks,
+		"Adjustments":     adjustments,
+		"ShowWeeks":       subscription.Includes(model.ReportSectionWeeks),
+		"ShowAdjustments": subscription.Includes(model.ReportSectionAdjustments),
+	}, nil
+}
+
+// teamDigest erstellt die Team-Übersicht: direkte Mitarbeiter des Vorgesetzten oder eine Abteilung
+func (s *ReportService) teamDigest(subscription *model.ReportSubscription, user *model.User, language string, start, end time.Time) (map[string]interface{}, error) {
+	// Admins und HR wählen eine Abteilung (leer = alle), Vorgesetzte erhalten ihre direkten Mitarbeiter
+	query := repository.EmployeeQuery{Department: string(subscription.Department)}
+	if user.Role == model.RoleManager {
+		query = repository.EmployeeQuery{ManagerID: user.EmployeeID}
+	}
+
+	employees, err := s.reportEmployees(query)
+	if err != nil {
+		return nil, err
+	}
+	if len(employees) == 0 && user.Role == model.RoleManager {
+		return nil, ErrReportNoTeam
+	}
+
+	var members []map[string]string
+	var absences []reportAbsenceRow
+	var totalActual float64
+	for _, employee := range employees {
+		name := employee.FirstName + " " + employee.LastName
+		actual := model.SummarizeTimeEntries(employee.TimeEntries, start, end).TotalHours
+		planned, _ := s.timeAccountService.CalculateExpectedHoursForEmployee(employee, start, end.AddDate(0, 0, -1))
+		totalActual += actual
+
+		absenceDays := 0.0
+		for _, absence := range employee.Absences {
+			if absence.Status != "approved" {
+				continue
+			}
+			days := model.AbsenceDaysInPeriod(absence, start, end)
+			if days == 0 {
+				continue
+			}
+			absenceDays += days
+			absences = append(absences, reportAbsence(language, name, absence, days))
+		}
+
+		members = append(members, map[string]string{
+			"Name":            name,
+			"ActualHours":     formatReportHours(actual),
+			"PlannedHours":    formatReportHours(planned),
+			"Difference":      formatReportBalance(actual - planned),
+			"OvertimeBalance": formatReportBalance(employee.CalculateFinalOvertimeBalance()),
+			"AbsenceDays":     formatReportDays(absenceDays),
+		})
+	}
+
+	return map[string]interface{}{
+		"Department":   string(subscription.Department),
+		"PeriodStart":  start.Format("02.01.2006"),
+		"PeriodEnd":    end.AddDate(0, 0, -1).Format("02.01.2006"),
+		"MemberCount":  len(members),
+		"TotalHours":   formatReportHours(totalActual),
+		"Members":      members,
+		"Absences":     sortedReportAbsences(absences),
+		"ShowHours":    subscription.Includes(model.ReportSectionHours),
+		"ShowOvertime": subscription.Includes(model.ReportSectionOvertime),
+		"ShowAbsences": subscription.Includes(model.ReportSectionAbsences),
+	}, nil
+}
+
+// monthlyAbsences erstellt die HR-Übersicht aller genehmigten Abwesenheiten eines Monats
+func (s *ReportService) monthlyAbsences(subscription *model.ReportSubscription, user *model.User, language string, start, end time.Time) (map[string]interface{}, error) {
+	employees, err := s.reportEmployees(repository.EmployeeQuery{Department: string(subscription.Department)})
+	if err != nil {
+		return nil, err
+	}
+
+	type total struct {
+		days  float64
+		count int
+	}
+	totals := make(map[string]*total)
+	var absences []reportAbsenceRow
+	var totalDays float64
+	for _, employee := range employees {
+		name := employee.FirstName + " " + employee.LastName
+		for _, absence := range employee.Absences {
+			if absence.Status != "approved" {
+				continue
+			}
+			days := model.AbsenceDaysInPeriod(absence, start, end)
+			if days == 0 {
+				continue
+			}
+
+			if totals[absence.Type] == nil {
+				totals[absence.Type] = &total{}
+			}
+			totals[absence.Type].days += days
+			totals[absence.Type].count++
+			totalDays += days
+
+			row := reportAbsence(language, name, absence, days)
+			row.values["Department"] = string(employee.Department)
+			absences = append(absences, row)
+		}
+	}
+
+	var byType []map[string]string
+	for _, absenceType := range []string{"vacation", "sick", "special"} {
+		if t, ok := totals[absenceType]; ok {
+			byType = append(byType, map[string]string{
+				"Type":  reportLabel(language, absenceType, absenceTypeLabel(absenceType)),
+				"Days":  formatReportDays(t.days),
+				"Count": fmt.Sprintf("%d", t.count),
+			})
+		}
+	}
+
+	return map[string]interface{}{
+		"Month":        reportMonth(language, start),
+		"Department":   string(subscription.Department),
+		"TotalDays":    formatReportDays(totalDays),
+		"Totals":       byType,
+		"Absences":     sortedReportAbsences(absences),
+		"ShowTotals":   subscription.Includes(model.ReportSectionTotals),
+		"ShowAbsences": subscription.Includes(model.ReportSectionAbsences),
+	}, nil
+}
+
+// reportEmployees lädt alle nicht inaktiven Mitarbeiter einer Abfrage
+func (s *ReportService) reportEmployees(query repository.EmployeeQuery) ([]*model.Employee, error) {
+	employees, _, err := s.employeeRepo.Search(query)
+	if err != nil {
+		return nil, err
+	}
+
+	active := employees[:0]
+	for _, employee := range employees {
+		if employee.Status != model.EmployeeStatusInactive {
+			active = append(active, employee)
+		}
+	}
+	return active, nil
+}
+
+// reportAbsenceRow ist eine Abwesenheit in einem Bericht samt Beginn zum Sortieren
+type reportAbsenceRow struct {
+	start  time.Time
+	values map[string]string
+}
+
+// reportAbsence bereitet eine Abwesenheit für die Berichtsvorlagen auf
+func reportAbsence(language, employeeName string, absence model.Absence, days float64) reportAbsenceRow {
+	return reportAbsenceRow{
+		start: absence.StartDate,
+		values: map[string]string{
+			"EmployeeName": employeeName,
+			"Type":         reportLabel(language, absence.Type, absenceTypeLabel(absence.Type)),
+			"StartDate":    absence.StartDate.Format("02.01.2006"),
+			"EndDate":      absence.EndDate.Format("02.01.2006"),
+			"Days":         formatReportDays(days),
+		},
+	}
+}
+
+// sortedReportAbsences sortiert Abwesenheiten nach Beginn (bei gleichem Beginn in Mitarbeiter-Reihenfolge)
+func sortedReportAbsences(rows []reportAbsenceRow) []map[string]string {
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].start.Before(rows[j].start) })
+
+	absences := make([]map[string]string, 0, len(rows))
+	for _, row := range rows {
+		absences = append(absences, row.values)
+	}
+	return absences
+}
+
+// reportLabel gibt für englische Berichte die Übersetzung zurück, sonst die deutsche Bezeichnung
+func reportLabel(language, key, german string) string {
+	if language == model.EmailLanguageEnglish {
+		if label, ok := reportLabelsEnglish[key]; ok {
+			return label
+		}
+	}
+	return german
+}
+
+// reportMonth gibt Monatsname und Jahr in der Sprache des Empfängers zurück
+func reportMonth(language string, date time.Time) string {
+	return fmt.Sprintf("%s %d", reportMonths[language][date.Month()-1], date.Year())
+}
+
+// formatReportHours formatiert Stunden mit einer Nachkommastelle
+func formatReportHours(hours float64) string {
+	return fmt.Sprintf("%.1f", hours)
+}
+
+// formatReportBalance formatiert eine Stundendifferenz mit Vorzeichen
+func formatReportBalance(hours float64) string {
+	return fmt.Sprintf("%+.1f", hours)
+}
+
+// formatReportDays formatiert Abwesenheitstage (halbe Tage mit Nachkommastelle)
+func formatReportDays(days float64) string {
+	if days == float64(int(days)) {
+		return fmt.Sprintf("%d", int(days))
+	}
+	return fmt.Sprintf("%.1f", days)
+}
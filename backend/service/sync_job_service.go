@@ -0,0 +1,502 @@
+// backend/service/sync_job_service.go
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Synchronisierungs-Fehler
+var (
+	ErrSyncNotConnected     = errors.New("integration is not connected")
+	ErrSyncAlreadyRunning   = errors.New("a sync for this integration is already running")
+	ErrInvalidSyncDateRange = errors.New("invalid sync date range")
+	ErrSyncPreviewInvalid   = errors.New("sync job is not a completed dry run")
+	ErrSyncPreviewExpired   = errors.New("sync preview has expired")
+)
+
+const (
+	// syncProgressInterval begrenzt, wie oft der Fortschritt gespeichert wird
+	syncProgressInterval = time.Second
+
+	// syncHeartbeatInterval hält lange Abrufe ohne Fortschritt als lebendig markiert
+	syncHeartbeatInterval = 30 * time.Second
+
+	// syncJobRetention bestimmt, wie lange abgeschlossene Synchronisierungen aufbewahrt werden
+	syncJobRetention = 30 * 24 * time.Hour
+)
+
+// SyncProgress nimmt den Fortschritt einer Synchronisierung entgegen
+type SyncProgress interface {
+	// StartPhase beginnt einen neuen Abschnitt; total ist 0, wenn die Anzahl noch unbekannt ist
+	StartPhase(phase string, total int)
+	// Advance meldet n weitere verarbeitete Elemente im aktuellen Abschnitt
+	Advance(n int)
+	// Warn meldet ein Problem, das die Synchronisierung nicht abbricht
+	Warn(message string)
+	// RecordChange meldet die Änderungen an einem Mitarbeiter für die Änderungsübersicht
+	RecordChange(change model.EmployeeSyncChange)
+}
+
+// noSyncProgress verwirft alle Meldungen (Synchronisierung ohne Fortschrittsanzeige)
+type noSyncProgress struct{}
+
+func (noSyncProgress) StartPhase(string, int)                {}
+func (noSyncProgress) Advance(int)                           {}
+func (noSyncProgress) Warn(string)                           {}
+func (noSyncProgress) RecordChange(model.EmployeeSyncChange) {}
+
+// saveSyncedEmployee vergleicht einen synchronisierten Mitarbeiter mit dem gespeicherten Stand,
+// meldet die Änderungen und speichert ihn; bei einem Probelauf wird nichts gespeichert
+func saveSyncedEmployee(employeeRepo *repository.EmployeeRepository, employee *model.Employee, dryRun bool, progress SyncProgress) error {
+	stored, err := employeeRepo.FindByID(employee.ID.Hex())
+	if err != nil {
+		return err
+	}
+	if change := model.DiffEmployee(stored, employee); !change.IsEmpty() {
+		progress.RecordChange(change)
+	}
+	if dryRun {
+		return nil
+	}
+	return employeeRepo.Update(employee)
+}
+
+// SyncJobService startet Synchronisierungen der Integrationsanbieter im Hintergrund und
+// speichert deren Fortschritt, damit lange Abgleiche nicht an das Zeitlimit einer HTTP-Anfrage
+// gebunden sind
+type SyncJobService struct {
+	syncJobRepo *repository.SyncJobRepository
+}
+
+// NewSyncJobService erstellt einen neuen SyncJobService
+func NewSyncJobService() *SyncJobService {
+	return &SyncJobService{
+		syncJobRepo: repository.NewSyncJobRepository(),
+	}
+}
+
+// Start legt eine Synchronisierung an und führt sie im Hintergrund aus. Mit params.DryRun
+// wird nur die Änderungsübersicht berechnet (Probelauf).
+// Läuft für dieselbe Integration bereits eine, wird diese mit ErrSyncAlreadyRunning zurückgegeben.
+func (s *SyncJobService) Start(integrationType string, capability model.SyncCapability, params model.SyncJobParams, user *model.User) (*model.SyncJob, error) {
+	return s.start(integrationType, capability, params, user, nil)
+}
+
+// ApplyPreview übernimmt einen abgeschlossenen Probelauf, indem dieselbe Synchronisierung mit
+// denselben Parametern ausgeführt wird. Deren Änderungsübersicht zeigt, was tatsächlich
+// gespeichert wurde; weicht sie vom Probelauf ab, wird die Synchronisierung mit PreviewMismatch
+// und je Abweichung einer Warnung gekennzeichnet. Ein Probelauf kann nur einmal und nur bis
+// SyncPreviewMaxAge übernommen werden.
+func (s *SyncJobService) ApplyPreview(id string, user *model.User) (*model.SyncJob, error) {
+	preview, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if !preview.Params.DryRun || preview.Status != model.SyncJobCompleted {
+		return nil, ErrSyncPreviewInvalid
+	}
+	if preview.AppliedJobID != nil {
+		return preview, repository.ErrSyncPreviewApplied
+	}
+	if preview.FinishedAt == nil || time.Since(*preview.FinishedAt) > model.SyncPreviewMaxAge {
+		return nil, ErrSyncPreviewExpired
+	}
+
+	params := preview.Params
+	params.DryRun = false
+	return s.start(preview.Integration, preview.Capability, params, user, preview)
+}
+
+// start legt die Synchronisierung an; preview ist gesetzt, wenn ein Probelauf übernommen wird
+func (s *SyncJobService) start(integrationType string, capability model.SyncCapability, params model.SyncJobParams, user *model.User, preview *model.SyncJob) (*model.SyncJob, error) {
+	provider, err := GetIntegration(integrationType)
+	if err != nil {
+		return nil, err
+	}
+	info := provider.Info()
+	if !capability.IsValid() {
+		return nil, fmt.Errorf("%w: %s", model.ErrInvalidSyncCapability, capability)
+	}
+	if !SupportsCapability(info, capability) {
+		return nil, fmt.Errorf("%w: %s kann %s nicht synchronisieren", ErrSyncCapabilityUnsupported, info.Name, capability.GetLabel())
+	}
+	if !provider.IsConnected() {
+		return nil, fmt.Errorf("%w: %s", ErrSyncNotConnected, info.Type)
+	}
+
+	params, err = prepareSyncParams(provider, capability, params, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	var previewID *primitive.ObjectID
+	var previewDiff *model.SyncDiff
+	if preview != nil {
+		previewID = &preview.ID
+		previewDiff = preview.Diff
+		if previewDiff == nil {
+			previewDiff = &model.SyncDiff{}
+		}
+	}
+
+	job, err := s.createJob(info.Type, capability, params, user.ID, user.FirstName+" "+user.LastName, previewID)
+	if err != nil {
+		return job, err
+	}
+
+	started := *job
+	go s.run(provider, &started, previewDiff)
+	return job, nil
+}
+
+// RunScheduled führt die geplante vollständige Synchronisierung eines Anbieters als
+// Synchronisierung aus und wartet auf ihr Ende. Sie ist dadurch in der Übersicht sichtbar und
+// läuft nie gleichzeitig mit einer von Hand gestarteten; ein fehlgeschlagener Bereich hält die
+// übrigen nicht auf. Läuft bereits eine, wird diese mit ErrSyncAlreadyRunning zurückgegeben.
+func (s *SyncJobService) RunScheduled(integrationType string) (*model.SyncJob, error) {
+	provider, err := GetIntegration(integrationType)
+	if err != nil {
+		return nil, err
+	}
+	info := provider.Info()
+	if !provider.IsConnected() {
+		return nil, fmt.Errorf("%w: %s", ErrSyncNotConnected, info.Type)
+	}
+
+	params := model.SyncJobParams{Scheduled: true}
+	if prepared, err := prepareSyncParams(provider, model.SyncAll, params, time.Now()); err == nil {
+		params = prepared
+	}
+	// Sonst meldet der Lauf den fehlerhaften Bereich und synchronisiert die übrigen
+
+	job, err := s.createJob(info.Type, model.SyncAll, params, primitive.NilObjectID, "Zeitplan", nil)
+	if err != nil {
+		return job, err
+	}
+
+	s.run(provider, job, nil)
+	if job.Status == model.SyncJobFailed {
+		return job, errors.New(job.Error)
+	}
+	return job, nil
+}
+
+// createJob legt die Synchronisierung an, sofern für die Integration keine andere aktive
+// Synchronisierung existiert; previewID ist gesetzt, wenn ein Probelauf übernommen wird
+func (s *SyncJobService) createJob(integration string, capability model.SyncCapability, params model.SyncJobParams, requestedBy primitive.ObjectID, requestedByName string, previewID *primitive.ObjectID) (*model.SyncJob, error) {
+	// Synchronisierungen ohne Lebenszeichen geben die Integration frei
+	staleBefore := time.Now().Add(-model.SyncJobStaleAfter)
+	if _, err := s.syncJobRepo.ExpireStale(integration, staleBefore); err != nil {
+		return nil, err
+	}
+
+	job := &model.SyncJob{
+		Integration:     integration,
+		Capability:      capability,
+		Params:          params,
+		Status:          model.SyncJobQueued,
+		PreviewID:       previewID,
+		RequestedBy:     requestedBy,
+		RequestedByName: requestedByName,
+	}
+	if err := s.syncJobRepo.Create(job); err != nil {
+		if !errors.Is(err, repository.ErrSyncJobActive) {
+			return nil, err
+		}
+		active, findErr := s.syncJobRepo.FindActive(integration, staleBefore)
+		if findErr != nil {
+			return nil, findErr
+		}
+		return active, ErrSyncAlreadyRunning
+	}
+	if previewID != nil {
+		if err := s.syncJobRepo.MarkApplied(*previewID, job.ID); err != nil {
+			// Ein anderer Aufruf hat den Probelauf gerade übernommen
+			job.Status = model.SyncJobFailed
+			job.Error = "Der Probelauf wurde bereits übernommen"
+			now := time.Now()
+			job.FinishedAt = &now
+			_ = s.syncJobRepo.Save(job)
+			return nil, err
+		}
+	}
+	return job, nil
+}
+
+// Get gibt eine Synchronisierung zurück. Hat eine laufende Synchronisierung zu lange
+// kein Lebenszeichen gegeben, wird sie als fehlgeschlagen abgeschlossen.
+func (s *SyncJobService) Get(id string) (*model.SyncJob, error) {
+	job, err := s.syncJobRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.IsStale(time.Now()) {
+		now := time.Now()
+		job.Status = model.SyncJobFailed
+		job.Error = "Die Synchronisierung wurde abgebrochen (keine Rückmeldung vom Server)"
+		job.FinishedAt = &now
+		if err := s.syncJobRepo.Save(job); err != nil {
+			return nil, err
+		}
+	}
+	return job, nil
+}
+
+// ListRecent gibt die letzten Synchronisierungen zurück
+func (s *SyncJobService) ListRecent(limit int64) ([]*model.SyncJob, error) {
+	return s.syncJobRepo.FindRecent(limit)
+}
+
+// Cleanup löscht abgeschlossene Synchronisierungen nach Ablauf der Aufbewahrungsfrist
+func (s *SyncJobService) Cleanup() (int64, error) {
+	return s.syncJobRepo.DeleteFinishedBefore(time.Now().Add(-syncJobRetention))
+}
+
+// prepareSyncParams lässt den Anbieter fehlende Parameter ergänzen. Bei einer vollständigen
+// Synchronisierung werden die Parameter aller Bereiche zusammengeführt.
+func prepareSyncParams(provider IntegrationProvider, capability model.SyncCapability, params model.SyncJobParams, now time.Time) (model.SyncJobParams, error) {
+	if capability != model.SyncAll {
+		prepared, err := provider.PrepareSync(capability, params, now)
+		prepared.DryRun = params.DryRun
+		return prepared, err
+	}
+
+	merged := params
+	for _, step := range provider.Info().Capabilities {
+		prepared, err := provider.PrepareSync(step, params, now)
+		if err != nil {
+			return params, err
+		}
+		if merged.Year == "" {
+			merged.Year = prepared.Year
+		}
+		if merged.StartDate == "" {
+			merged.StartDate = prepared.StartDate
+		}
+		if merged.EndDate == "" {
+			merged.EndDate = prepared.EndDate
+		}
+	}
+	return merged, nil
+}
+
+// run führt die Synchronisierung aus und speichert Fortschritt und Ergebnis; preview ist die
+// Änderungsübersicht des übernommenen Probelaufs, mit der das Ergebnis verglichen wird
+func (s *SyncJobService) run(provider IntegrationProvider, job *model.SyncJob, preview *model.SyncDiff) {
+	tracker := newSyncJobTracker(s.syncJobRepo, job)
+	tracker.preview = preview
+	tracker.begin()
+
+	stop := make(chan struct{})
+	go tracker.heartbeat(stop)
+
+	counts, summary, err := runSyncSafely(provider, job.Capability, job.Params, tracker)
+	close(stop)
+	tracker.finish(counts, summary, err)
+}
+
+// runSyncSafely führt die Synchronisierung aus und wandelt einen Panic in einen Fehler um
+func runSyncSafely(provider IntegrationProvider, capability model.SyncCapability, params model.SyncJobParams, progress SyncProgress) (counts map[string]int, summary string, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("panic: %v", recovered)
+		}
+	}()
+	return executeSync(provider, capability, params, progress)
+}
+
+// executeSync synchronisiert einen Bereich oder bei SyncAll alle Bereiche des Anbieters
+// nacheinander; eine vollständige Synchronisierung bricht beim ersten Fehler ab, der geplante
+// Lauf (params.Scheduled) synchronisiert die übrigen Bereiche und meldet alle Fehler
+func executeSync(provider IntegrationProvider, capability model.SyncCapability, params model.SyncJobParams, progress SyncProgress) (map[string]int, string, error) {
+	steps := []model.SyncCapability{capability}
+	if capability == model.SyncAll {
+		steps = provider.Info().Capabilities
+	}
+
+	counts := make(map[string]int, len(steps))
+	parts := make([]string, 0, len(steps))
+	var errs []error
+	for _, step := range steps {
+		stepParams, err := provider.PrepareSync(step, params, time.Now())
+		if err == nil {
+			stepParams.DryRun = params.DryRun
+			var count int
+			count, err = provider.Sync(step, stepParams, progress)
+			counts[string(step)] = count
+		}
+		if err != nil {
+			err = fmt.Errorf("%s: %w", step.GetLabel(), err)
+			if !params.Scheduled {
+				return counts, "", err
+			}
+			errs = append(errs, err)
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s: %d", step.GetLabel(), counts[string(step)]))
+	}
+
+	if len(errs) > 0 {
+		if len(parts) == 0 {
+			return counts, "", errors.Join(errs...)
+		}
+		return counts, "Synchronisierung teilweise abgeschlossen – " + strings.Join(parts, ", "), errors.Join(errs...)
+	}
+	if params.DryRun {
+		if capability != model.SyncAll {
+			return counts, fmt.Sprintf("Probelauf %s: %d Mitarbeiter würden aktualisiert", capability.GetLabel(), counts[string(capability)]), nil
+		}
+		return counts, "Probelauf abgeschlossen – " + strings.Join(parts, ", "), nil
+	}
+	if capability != model.SyncAll {
+		return counts, fmt.Sprintf("%s synchronisiert: %d Mitarbeiter aktualisiert", capability.GetLabel(), counts[string(capability)]), nil
+	}
+	return counts, "Synchronisierung abgeschlossen – " + strings.Join(parts, ", "), nil
+}
+
+// syncJobTracker schreibt den Fortschritt einer Synchronisierung gedrosselt in die Datenbank
+type syncJobTracker struct {
+	mu        sync.Mutex
+	repo      *repository.SyncJobRepository
+	job       *model.SyncJob
+	preview   *model.SyncDiff // Änderungsübersicht des übernommenen Probelaufs
+	lastFlush time.Time
+}
+
+// newSyncJobTracker erstellt einen neuen syncJobTracker
+func newSyncJobTracker(repo *repository.SyncJobRepository, job *model.SyncJob) *syncJobTracker {
+	return &syncJobTracker{repo: repo, job: job}
+}
+
+// begin markiert die Synchronisierung als laufend
+func (t *syncJobTracker) begin() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	t.job.Status = model.SyncJobRunning
+	t.job.StartedAt = &now
+	t.job.Instance = jobInstanceID
+	t.flushLocked()
+}
+
+// StartPhase beginnt einen neuen Abschnitt und speichert ihn sofort
+func (t *syncJobTracker) StartPhase(phase string, total int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.job.Phase = phase
+	t.job.Total = total
+	t.job.Processed = 0
+	t.flushLocked()
+}
+
+// Advance zählt verarbeitete Elemente und speichert höchstens einmal pro Sekunde
+func (t *syncJobTracker) Advance(n int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.job.Processed += n
+	if time.Since(t.lastFlush) >= syncProgressInterval {
+		t.flushLocked()
+	}
+}
+
+// Warn speichert eine Warnung mit dem nächsten Fortschritt
+func (t *syncJobTracker) Warn(message string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.job.AddWarning(message)
+	if time.Since(t.lastFlush) >= syncProgressInterval {
+		t.flushLocked()
+	}
+}
+
+// RecordChange übernimmt die Änderungen an einem Mitarbeiter in die Änderungsübersicht
+func (t *syncJobTracker) RecordChange(change model.EmployeeSyncChange) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.job.RecordChange(change)
+	if time.Since(t.lastFlush) >= syncProgressInterval {
+		t.flushLocked()
+	}
+}
+
+// heartbeat speichert regelmäßig den Zustand, auch wenn ein Abruf lange ohne Fortschritt läuft
+func (t *syncJobTracker) heartbeat(stop <-chan struct{}) {
+	ticker := time.NewTicker(syncHeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			t.mu.Lock()
+			t.flushLocked()
+			t.mu.Unlock()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// finish speichert das Ergebnis der Synchronisierung
+func (t *syncJobTracker) finish(counts map[string]int, summary string, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	t.job.FinishedAt = &now
+	t.job.Counts = counts
+	t.job.Summary = summary
+	if err != nil {
+		t.job.Status = model.SyncJobFailed
+		t.job.Error = err.Error()
+		log.Printf("Sync job %s (%s) failed: %v", t.job.ID.Hex(), t.job.Integration, err)
+	} else {
+		t.job.Status = model.SyncJobCompleted
+		t.job.Phase = "Abgeschlossen"
+		if t.job.Diff == nil {
+			t.job.Diff = &model.SyncDiff{}
+		}
+		if t.preview != nil {
+			t.comparePreviewLocked()
+		}
+	}
+	t.flushLocked()
+}
+
+// comparePreviewLocked kennzeichnet die Synchronisierung, wenn ihre Änderungen von denen des
+// übernommenen Probelaufs abweichen (z.B. weil sich die Quelle inzwischen geändert hat); t.mu muss gehalten werden
+func (t *syncJobTracker) comparePreviewLocked() {
+	deviations := t.job.Diff.DeviationsFrom(t.preview)
+	if len(deviations) == 0 {
+		return
+	}
+	t.job.PreviewMismatch = true
+	t.job.Summary += " – weicht vom Probelauf ab"
+	for _, deviation := range deviations {
+		t.job.AddWarning("Abweichung vom Probelauf: " + deviation)
+	}
+	log.Printf("Sync job %s (%s) differs from its preview in %d points", t.job.ID.Hex(), t.job.Integration, len(deviations))
+}
+
+// flushLocked speichert den aktuellen Zustand; t.mu muss gehalten werden
+func (t *syncJobTracker) flushLocked() {
+	if err := t.repo.Save(t.job); err != nil {
+		log.Printf("Error saving progress of sync job %s: %v", t.job.ID.Hex(), err)
+	}
+	t.lastFlush = time.Now()
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"PeopleFlow/backend/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecuteSync_PassesDryRunToEveryStep(t *testing.T) {
+	provider := newFakeIntegration("fake-dry-run")
+
+	counts, summary, err := executeSync(provider, model.SyncAll, model.SyncJobParams{Year: "2025", DryRun: true}, noSyncProgress{})
+
+	require.NoError(t, err)
+	require.Len(t, provider.synced, 2)
+	for _, params := range provider.synced {
+		assert.True(t, params.DryRun)
+	}
+	assert.Equal(t, map[string]int{"users": 2, "absences": 2}, counts)
+	assert.Equal(t, "Probelauf abgeschlossen – Mitarbeiter: 2, Abwesenheiten: 2", summary)
+}
+
+func TestExecuteSync_Summary(t *testing.T) {
+	provider := newFakeIntegration("fake-summary")
+
+	_, summary, err := executeSync(provider, model.SyncAbsences, model.SyncJobParams{}, noSyncProgress{})
+	require.NoError(t, err)
+	assert.Equal(t, "Abwesenheiten synchronisiert: 2 Mitarbeiter aktualisiert", summary)
+
+	_, summary, err = executeSync(provider, model.SyncAbsences, model.SyncJobParams{DryRun: true}, noSyncProgress{})
+	require.NoError(t, err)
+	assert.Equal(t, "Probelauf Abwesenheiten: 2 Mitarbeiter würden aktualisiert", summary)
+	assert.False(t, provider.synced[0].DryRun)
+	assert.True(t, provider.synced[1].DryRun)
+}
+
+func TestExecuteSync_StopsAtFirstFailedStep(t *testing.T) {
+	provider := newFakeIntegration("fake-stop")
+	provider.failing = map[model.SyncCapability]error{model.SyncUsers: errors.New("timeout")}
+
+	counts, _, err := executeSync(provider, model.SyncAll, model.SyncJobParams{}, noSyncProgress{})
+
+	require.EqualError(t, err, "Mitarbeiter: timeout")
+	assert.Len(t, provider.synced, 1)
+	assert.Equal(t, map[string]int{"users": 0}, counts)
+}
+
+func TestExecuteSync_ScheduledContinuesAfterFailedStep(t *testing.T) {
+	provider := newFakeIntegration("fake-scheduled")
+	provider.failing = map[model.SyncCapability]error{model.SyncUsers: errors.New("timeout")}
+
+	counts, summary, err := executeSync(provider, model.SyncAll, model.SyncJobParams{Scheduled: true}, noSyncProgress{})
+
+	require.EqualError(t, err, "Mitarbeiter: timeout")
+	assert.Len(t, provider.synced, 2)
+	assert.Equal(t, map[string]int{"users": 0, "absences": 2}, counts)
+	assert.Equal(t, "Synchronisierung teilweise abgeschlossen – Abwesenheiten: 2", summary)
+}
@@ -0,0 +1,111 @@
+// backend/service/timebutler_client.go
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"PeopleFlow/backend/model"
+)
+
+// ErrTimebutlerAPI wird zurückgegeben, wenn die Timebutler-API mit einem Fehlerstatus antwortet
+var ErrTimebutlerAPI = errors.New("Timebutler API Fehler")
+
+// defaultTimebutlerAPIURL ist die Adresse der Timebutler-API
+const defaultTimebutlerAPIURL = "https://app.timebutler.com/api/v1"
+
+// timebutlerAPIURL gibt die Adresse der Timebutler-API zurück. Mit PEOPLEFLOW_TIMEBUTLER_API_URL
+// kann sie durch einen lokalen Testserver ersetzt werden.
+func timebutlerAPIURL() string {
+	if apiURL := os.Getenv("PEOPLEFLOW_TIMEBUTLER_API_URL"); apiURL != "" {
+		return strings.TrimRight(apiURL, "/")
+	}
+	return defaultTimebutlerAPIURL
+}
+
+// timebutlerClient ruft die Endpunkte der Timebutler-API auf. Alle Endpunkte erwarten einen
+// POST mit dem API-Schlüssel im Formularfeld auth und antworten mit CSV.
+type timebutlerClient struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+}
+
+// newTimebutlerClient erstellt einen Client für die Timebutler-API unter baseURL
+func newTimebutlerClient(baseURL, apiKey string) *timebutlerClient {
+	return &timebutlerClient{
+		baseURL:    baseURL,
+		apiKey:     apiKey,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// post ruft einen Endpunkt auf und gibt den Inhalt der Antwort zurück
+func (c *timebutlerClient) post(endpoint string, params url.Values) (string, error) {
+	form := url.Values{}
+	for key, values := range params {
+		form[key] = values
+	}
+	form.Set("auth", c.apiKey)
+
+	req, err := http.NewRequest(http.MethodPost, c.baseURL+"/"+endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", err
+	}
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: %s %s", ErrTimebutlerAPI, res.Status, strings.TrimSpace(string(body)))
+	}
+	return string(body), nil
+}
+
+// users ruft die Benutzer ab
+func (c *timebutlerClient) users() (string, error) {
+	return c.post("users", nil)
+}
+
+// absences ruft die Abwesenheiten eines Jahres mit allen Details ab
+func (c *timebutlerClient) absences(year string) (string, error) {
+	return c.post("absences", url.Values{"year": {year}, "detailed": {"true"}})
+}
+
+// holidayEntitlements ruft die Urlaubsansprüche eines Jahres ab
+func (c *timebutlerClient) holidayEntitlements(year string) (string, error) {
+	return c.post("holidayentitlement", url.Values{"year": {year}})
+}
+
+// createAbsence legt eine in PeopleFlow erfasste Abwesenheit für den Timebutler-Benutzer userID
+// an und gibt die Timebutler-ID der neuen Abwesenheit zurück
+func (c *timebutlerClient) createAbsence(userID string, absence model.Absence) (string, error) {
+	body, err := c.post("absences/create", url.Values{
+		"userid":  {userID},
+		"from":    {absence.StartDate.Format("02/01/2006")},
+		"to":      {absence.EndDate.Format("02/01/2006")},
+		"type":    {model.TimebutlerAbsenceType(absence.Type)},
+		"halfday": {strconv.FormatBool(absence.Days == 0.5)},
+		"state":   {absence.Status},
+		"comment": {absence.Notes},
+	})
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(body), nil
+}
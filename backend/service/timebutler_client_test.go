@@ -0,0 +1,187 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"PeopleFlow/backend/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTimebutler ist ein lokaler Ersatz für die Timebutler-API mit Abwesenheiten im Speicher
+type fakeTimebutler struct {
+	mu       sync.Mutex
+	apiKey   string
+	absences map[string]model.TimebutlerAbsence
+	nextID   int
+	requests []string
+}
+
+func newFakeTimebutler(t *testing.T, apiKey string) (*fakeTimebutler, *httptest.Server) {
+	fake := &fakeTimebutler{apiKey: apiKey, absences: make(map[string]model.TimebutlerAbsence), nextID: 100}
+	server := httptest.NewServer(http.HandlerFunc(fake.serveHTTP))
+	t.Cleanup(server.Close)
+	return fake, server
+}
+
+func (f *fakeTimebutler) put(absence model.TimebutlerAbsence) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.absences[absence.ID] = absence
+}
+
+func (f *fakeTimebutler) delete(id string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.absences, id)
+}
+
+func (f *fakeTimebutler) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Method != http.MethodPost || r.FormValue("auth") != f.apiKey {
+		http.Error(w, "invalid auth", http.StatusUnauthorized)
+		return
+	}
+	f.requests = append(f.requests, r.URL.Path)
+
+	switch r.URL.Path {
+	case "/users":
+		fmt.Fprintln(w, "User ID;Last name;First name;Employee number;E-mail address")
+		fmt.Fprintln(w, "tb-1;Schmidt;Anna;P-1;anna@example.com")
+	case "/absences":
+		ids := make([]string, 0, len(f.absences))
+		for id := range f.absences {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		fmt.Fprintln(w, "ID;From;To;Half a day;Morning;User ID;Employee number;Type;Extra vacation day;State;Substitute state;Workdays;Hours;Medical certificate;Comments;User ID of the substitute")
+		for _, id := range ids {
+			a := f.absences[id]
+			fmt.Fprintf(w, "%s;%s;%s;%t;%t;%s;;%s;false;%s;;%g;0;;%s;\n", a.ID,
+				a.StartDate.Format("02/01/2006"), a.EndDate.Format("02/01/2006"), a.IsHalfDay, a.IsMorning,
+				a.UserID, a.AbsenceType, a.Status, a.Workdays, a.Comment)
+		}
+	case "/absences/create":
+		from, _ := time.Parse("02/01/2006", r.FormValue("from"))
+		to, _ := time.Parse("02/01/2006", r.FormValue("to"))
+		f.nextID++
+		id := fmt.Sprint(f.nextID)
+		f.absences[id] = model.TimebutlerAbsence{
+			ID:          id,
+			UserID:      r.FormValue("userid"),
+			StartDate:   from,
+			EndDate:     to,
+			AbsenceType: r.FormValue("type"),
+			Status:      r.FormValue("state"),
+			Comment:     r.FormValue("comment"),
+		}
+		fmt.Fprintln(w, id)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// pullAbsences ruft die Abwesenheiten vom Testserver ab und gleicht sie mit dem Mitarbeiter ab
+func pullAbsences(t *testing.T, client *timebutlerClient, employee *model.Employee) model.AbsenceSyncResult {
+	data, err := client.absences("2025")
+	require.NoError(t, err)
+	byUser, skipped, err := (&TimebutlerService{}).ParseTimebutlerAbsences(data)
+	require.NoError(t, err)
+	return employee.ApplyTimebutlerAbsences(2025, byUser[employee.TimebutlerUserID], skipped)
+}
+
+func TestTimebutlerClient_RejectsInvalidKey(t *testing.T) {
+	_, server := newFakeTimebutler(t, "secret")
+
+	err := (&TimebutlerService{apiURL: server.URL}).testConnection("wrong")
+	assert.ErrorIs(t, err, ErrTimebutlerAPI)
+	assert.NoError(t, (&TimebutlerService{apiURL: server.URL}).testConnection("secret"))
+}
+
+func TestTimebutlerAbsenceSync_IncrementalAgainstFakeServer(t *testing.T) {
+	fake, server := newFakeTimebutler(t, "secret")
+	client := newTimebutlerClient(server.URL, "secret")
+	day := func(d int) time.Time { return time.Date(2025, 6, d, 0, 0, 0, 0, time.UTC) }
+
+	fake.put(model.TimebutlerAbsence{ID: "1", UserID: "tb-1", StartDate: day(2), EndDate: day(3), AbsenceType: "Urlaub", Status: "Approved", Workdays: 2})
+	fake.put(model.TimebutlerAbsence{ID: "2", UserID: "tb-1", StartDate: day(10), EndDate: day(10), AbsenceType: "Krankheit", Status: "Approved", Workdays: 1})
+	fake.put(model.TimebutlerAbsence{ID: "3", UserID: "tb-2", StartDate: day(10), EndDate: day(12), AbsenceType: "Urlaub", Status: "Approved", Workdays: 3})
+
+	employee := &model.Employee{TimebutlerUserID: "tb-1"}
+	assert.Equal(t, model.AbsenceSyncResult{Created: 2}, pullAbsences(t, client, employee))
+	assert.Equal(t, model.AbsenceSyncResult{Unchanged: 2}, pullAbsences(t, client, employee))
+
+	// In Timebutler storniert und gelöscht
+	fake.put(model.TimebutlerAbsence{ID: "1", UserID: "tb-1", StartDate: day(2), EndDate: day(3), AbsenceType: "Urlaub", Status: "Cancelled", Workdays: 2})
+	fake.delete("2")
+	assert.Equal(t, model.AbsenceSyncResult{Cancelled: 1, Removed: 1}, pullAbsences(t, client, employee))
+	require.Len(t, employee.Absences, 1)
+	assert.Equal(t, "cancelled", employee.Absences[0].Status)
+}
+
+func TestTimebutlerAbsenceSync_PushesPeopleFlowAbsences(t *testing.T) {
+	fake, server := newFakeTimebutler(t, "secret")
+	client := newTimebutlerClient(server.URL, "secret")
+
+	employee := &model.Employee{
+		FirstName:        "Anna",
+		TimebutlerUserID: "tb-1",
+		Absences: []model.Absence{
+			{Type: "vacation", StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 7, 4, 0, 0, 0, 0, time.UTC), Status: "requested", Notes: "Sommer"},
+			{Type: "vacation", StartDate: time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC), Status: "rejected"},
+		},
+	}
+
+	pushed := (&TimebutlerService{}).pushAbsences(client, employee, 2025, noSyncProgress{})
+	assert.Equal(t, 1, pushed)
+	assert.Equal(t, "101", employee.Absences[0].TimebutlerID)
+	assert.Empty(t, employee.PendingTimebutlerAbsences(2025))
+
+	created := fake.absences["101"]
+	assert.Equal(t, "tb-1", created.UserID)
+	assert.Equal(t, "Urlaub", created.AbsenceType)
+	assert.Equal(t, "Sommer", created.Comment)
+
+	// Der nächste Abgleich ordnet die übertragene Abwesenheit über die ID zu
+	result := pullAbsences(t, client, employee)
+	assert.Equal(t, model.AbsenceSyncResult{Updated: 1}, result)
+	assert.Len(t, employee.Absences, 2)
+	assert.True(t, strings.HasSuffix(fake.requests[len(fake.requests)-1], "/absences"))
+}
+
+func TestRecalculateRemainingVacation(t *testing.T) {
+	employee := &model.Employee{Absences: []model.Absence{
+		{Type: "vacation", Status: "approved", Days: 5, StartDate: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{Type: "vacation", Status: "cancelled", Days: 3, StartDate: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{Type: "sick", Status: "approved", Days: 2, StartDate: time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)},
+	}}
+
+	assert.True(t, recalculateRemainingVacation(employee, 2025))
+	assert.Equal(t, 30, employee.VacationDays)
+	assert.Equal(t, 25, employee.RemainingVacation)
+	assert.False(t, recalculateRemainingVacation(employee, 2025))
+}
+
+func TestParseTimebutlerAbsences_ReportsSkippedRows(t *testing.T) {
+	data := "ID;From;To;Half a day;Morning;User ID;Employee number;Type;Extra vacation day;State;Substitute state;Workdays;Hours;Medical certificate;Comments;User ID of the substitute\n" +
+		"1;02/06/2025;03/06/2025;false;false;tb-1;;Urlaub;false;Approved;;2;0;;;\n" +
+		"2;kein Datum;03/06/2025;false;false;tb-1;;Urlaub;false;Approved;;2;0;;;\n" +
+		"3;02/06/2025\n"
+
+	byUser, skipped, err := (&TimebutlerService{}).ParseTimebutlerAbsences(data)
+	require.NoError(t, err)
+	assert.Len(t, byUser["tb-1"], 1)
+	assert.Equal(t, 2, skipped.Count())
+	assert.True(t, skipped.IDs["2"])
+	assert.True(t, skipped.IDs["3"])
+}
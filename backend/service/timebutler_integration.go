@@ -0,0 +1,141 @@
+// backend/service/timebutler_integration.go
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+)
+
+// timebutlerIntegration bindet den TimebutlerService als Integrationsanbieter an
+type timebutlerIntegration struct{}
+
+// Info beschreibt den Timebutler-Anbieter
+func (timebutlerIntegration) Info() IntegrationInfo {
+	return IntegrationInfo{
+		Type:        repository.IntegrationTypeTimebutler,
+		Name:        "Timebutler",
+		Description: "Benutzer, Urlaubsansprüche und Abwesenheiten",
+		Fields: []IntegrationField{
+			{Name: "timebutler-api", Label: "API-Schlüssel", Type: "password", Required: true},
+		},
+		Capabilities: []model.SyncCapability{model.SyncUsers, model.SyncHolidayEntitlements, model.SyncAbsences},
+		SyncedFields: []model.SyncedField{
+			{Name: "phone", Label: "Telefon", Default: model.FieldOwnershipFillIfEmpty},
+			{Name: "department", Label: "Abteilung", Default: model.FieldOwnershipFillIfEmpty},
+			{Name: "hireDate", Label: "Eintrittsdatum", Default: model.FieldOwnershipFillIfEmpty},
+			{Name: "dateOfBirth", Label: "Geburtsdatum", Default: model.FieldOwnershipFillIfEmpty},
+			{Name: "vacationDays", Label: "Urlaubsanspruch", Default: model.FieldOwnershipExternal},
+			{Name: "remainingVacation", Label: "Resturlaub", Default: model.FieldOwnershipExternal},
+		},
+		DefaultSchedule: "*/5 * * * *",
+	}
+}
+
+// Configure testet den API-Schlüssel und speichert ihn
+func (timebutlerIntegration) Configure(values map[string]string) error {
+	return NewTimebutlerService().SaveApiKey(values["timebutler-api"])
+}
+
+// TestConnection prüft den gespeicherten API-Schlüssel
+func (timebutlerIntegration) TestConnection() error {
+	timebutlerService := NewTimebutlerService()
+	apiKey, err := timebutlerService.GetApiKey()
+	if err != nil {
+		return err
+	}
+	return timebutlerService.testConnection(apiKey)
+}
+
+// IsConnected prüft, ob Timebutler verbunden ist
+func (timebutlerIntegration) IsConnected() bool {
+	return NewTimebutlerService().IsConnected()
+}
+
+// AutoSyncEnabled gibt true zurück: Timebutler wird immer regelmäßig synchronisiert
+func (timebutlerIntegration) AutoSyncEnabled() bool {
+	return true
+}
+
+// Status gibt den Zustand der Timebutler-Integration zurück
+func (p timebutlerIntegration) Status() (*IntegrationStatus, error) {
+	status := newIntegrationStatus(p.Info(), p.IsConnected())
+	status.AutoSync = true
+	status.Details = map[string]string{
+		"pushAbsences": strconv.FormatBool(NewTimebutlerService().IsAbsencePushEnabled()),
+	}
+	return status, nil
+}
+
+// PrepareSync setzt das laufende Jahr, wenn kein Jahr angegeben ist
+func (timebutlerIntegration) PrepareSync(capability model.SyncCapability, params model.SyncJobParams, now time.Time) (model.SyncJobParams, error) {
+	year := params.Year
+	if year == "" {
+		year = strconv.Itoa(now.Year())
+	}
+	if _, err := strconv.Atoi(year); err != nil {
+		return params, fmt.Errorf("%w: Jahr %q", ErrInvalidSyncDateRange, year)
+	}
+	return model.SyncJobParams{Year: year}, nil
+}
+
+// Sync synchronisiert einen Bereich aus Timebutler
+func (timebutlerIntegration) Sync(capability model.SyncCapability, params model.SyncJobParams, progress SyncProgress) (int, error) {
+	timebutlerService := NewTimebutlerService().WithProgress(progress).WithDryRun(params.DryRun)
+	switch capability {
+	case model.SyncUsers:
+		return timebutlerService.SyncTimebutlerUsers()
+	case model.SyncHolidayEntitlements:
+		return timebutlerService.SyncHolidayEntitlements(params.Year)
+	case model.SyncAbsences:
+		return timebutlerService.SyncTimebutlerAbsences(params.Year)
+	default:
+		return 0, fmt.Errorf("%w: %s", ErrSyncCapabilityUnsupported, capability)
+	}
+}
+
+// ExternalIdentities gibt die Timebutler-Benutzer für den Abgleich mit Mitarbeitern zurück
+func (timebutlerIntegration) ExternalIdentities() ([]model.ExternalIdentity, error) {
+	timebutlerService := NewTimebutlerService()
+	usersData, err := timebutlerService.GetUsers()
+	if err != nil {
+		return nil, err
+	}
+	users, err := timebutlerService.ParseTimebutlerUsers(usersData)
+	if err != nil {
+		return nil, err
+	}
+	return timebutlerIdentities(users), nil
+}
+
+// EmployeeExternalID gibt die Timebutler-ID eines Mitarbeiters zurück
+func (timebutlerIntegration) EmployeeExternalID(employee *model.Employee) string {
+	return employee.TimebutlerUserID
+}
+
+// SetEmployeeExternalID speichert die Timebutler-ID eines Mitarbeiters
+func (timebutlerIntegration) SetEmployeeExternalID(employeeID, externalID string) error {
+	return repository.NewEmployeeRepository().UpdateTimebutlerUserID(employeeID, externalID)
+}
+
+// timebutlerIdentities wandelt Timebutler-Benutzer in externe Benutzer für den Abgleich um
+func timebutlerIdentities(users map[string]model.TimebutlerUser) []model.ExternalIdentity {
+	identities := make([]model.ExternalIdentity, 0, len(users))
+	for _, user := range users {
+		identities = append(identities, model.ExternalIdentity{
+			ExternalID:     user.UserID,
+			FirstName:      user.FirstName,
+			LastName:       user.LastName,
+			Email:          user.EmailAddress,
+			EmployeeNumber: user.EmployeeNumber,
+			DateOfBirth:    user.DateOfBirth,
+			HireDate:       user.DateOfEntry,
+			Department:     user.Department,
+			Phone:          user.Phone,
+		})
+	}
+	return identities
+}
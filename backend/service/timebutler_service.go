@@ -5,11 +5,8 @@ import (
 	"PeopleFlow/backend/model"
 	"PeopleFlow/backend/repository"
 	"bufio"
-	"errors"
 	"fmt"
-	"go.mongodb.org/mongo-driver/bson/primitive"
-	"io"
-	"net/http"
+	"log"
 	"strconv"
 	"strings"
 	"time"
@@ -18,15 +15,57 @@ import (
 // TimebutlerService verwaltet die Integration mit Timebutler
 type TimebutlerService struct {
 	integrationRepo *repository.IntegrationRepository
+	apiURL          string
+	progress        SyncProgress
+	dryRun          bool
 }
 
 // NewTimebutlerService erstellt einen neuen TimebutlerService
 func NewTimebutlerService() *TimebutlerService {
 	return &TimebutlerService{
 		integrationRepo: repository.NewIntegrationRepository(),
+		apiURL:          timebutlerAPIURL(),
 	}
 }
 
+// WithProgress gibt eine Kopie des Services zurück, die den Fortschritt der
+// Synchronisierung an progress meldet
+func (s *TimebutlerService) WithProgress(progress SyncProgress) *TimebutlerService {
+	clone := *s
+	clone.progress = progress
+	return &clone
+}
+
+// WithDryRun gibt eine Kopie des Services zurück, die bei dryRun keine Mitarbeiter speichert,
+// sondern nur die Änderungen meldet (Probelauf)
+func (s *TimebutlerService) WithDryRun(dryRun bool) *TimebutlerService {
+	clone := *s
+	clone.dryRun = dryRun
+	return &clone
+}
+
+// saveEmployee speichert einen synchronisierten Mitarbeiter und meldet die Änderungen
+func (s *TimebutlerService) saveEmployee(employeeRepo *repository.EmployeeRepository, employee *model.Employee) error {
+	return saveSyncedEmployee(employeeRepo, employee, s.dryRun, s.reporter())
+}
+
+// client gibt einen Client für die Timebutler-API mit dem gespeicherten API-Schlüssel zurück
+func (s *TimebutlerService) client() (*timebutlerClient, error) {
+	apiKey, err := s.integrationRepo.GetApiKey("timebutler")
+	if err != nil {
+		return nil, err
+	}
+	return newTimebutlerClient(s.apiURL, apiKey), nil
+}
+
+// reporter gibt den Fortschrittsempfänger zurück (ohne gesetzten Empfänger wird nichts gemeldet)
+func (s *TimebutlerService) reporter() SyncProgress {
+	if s.progress == nil {
+		return noSyncProgress{}
+	}
+	return s.progress
+}
+
 // SaveApiKey speichert den Timebutler API-Schlüssel und testet die Verbindung
 func (s *TimebutlerService) SaveApiKey(apiKey string) error {
 	fmt.Printf("[DEBUG] TimebutlerService.SaveApiKey called with key length: %d\n", len(apiKey))
@@ -61,39 +100,11 @@ func (s *TimebutlerService) SaveApiKey(apiKey string) error {
 
 // testConnection testet die Verbindung zu Timebutler mit dem angegebenen API-Schlüssel
 func (s *TimebutlerService) testConnection(apiKey string) error {
-	url := "https://app.timebutler.com/api/v1/users"
-	method := "POST"
-	payload := strings.NewReader(fmt.Sprintf("auth=%s", apiKey))
-
-	fmt.Printf("[DEBUG] Testing connection to %s\n", url)
+	fmt.Printf("[DEBUG] Testing connection to %s/users\n", s.apiURL)
 
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
-
-	req, err := http.NewRequest(method, url, payload)
-	if err != nil {
-		fmt.Printf("[ERROR] Failed to create HTTP request: %v\n", err)
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-	fmt.Println("[DEBUG] Sending HTTP request...")
-
-	res, err := client.Do(req)
-	if err != nil {
-		fmt.Printf("[ERROR] HTTP request failed: %v\n", err)
-		return fmt.Errorf("HTTP request failed: %w", err)
-	}
-	defer res.Body.Close()
-
-	fmt.Printf("[DEBUG] Received HTTP response: %d %s\n", res.StatusCode, res.Status)
-
-	if res.StatusCode != http.StatusOK {
-		// Read response body for more details
-		body, _ := io.ReadAll(res.Body)
-		fmt.Printf("[ERROR] API returned non-200 status. Body: %s\n", string(body))
-		return fmt.Errorf("Timebutler API error %d: %s", res.StatusCode, res.Status)
+	if _, err := newTimebutlerClient(s.apiURL, apiKey).users(); err != nil {
+		fmt.Printf("[ERROR] Connection test failed: %v\n", err)
+		return err
 	}
 
 	fmt.Println("[DEBUG] Connection test successful")
@@ -102,37 +113,12 @@ func (s *TimebutlerService) testConnection(apiKey string) error {
 
 // GetUsers ruft Benutzer von Timebutler ab
 func (s *TimebutlerService) GetUsers() (string, error) {
-	apiKey, err := s.integrationRepo.GetApiKey("timebutler")
-	if err != nil {
-		return "", err
-	}
-
-	url := "https://app.timebutler.com/api/v1/users"
-	method := "POST"
-	payload := strings.NewReader(fmt.Sprintf("auth=%s", apiKey))
-
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
-
-	req, err := http.NewRequest(method, url, payload)
-	if err != nil {
-		return "", err
-	}
-
-	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-
-	res, err := client.Do(req)
+	client, err := s.client()
 	if err != nil {
 		return "", err
 	}
-	defer res.Body.Close()
-
-	if res.StatusCode != http.StatusOK {
-		return "", errors.New(fmt.Sprintf("Timebutler API Fehler: %s", res.Status))
-	}
 
-	body, err := io.ReadAll(res.Body)
+	body, err := client.users()
 	if err != nil {
 		return "", err
 	}
@@ -140,51 +126,20 @@ func (s *TimebutlerService) GetUsers() (string, error) {
 	// Integration als aktiv markieren
 	s.integrationRepo.SetIntegrationStatus("timebutler", true)
 
-	return string(body), nil
+	return body, nil
 }
 
 // GetAbsences ruft Abwesenheiten von Timebutler ab
 func (s *TimebutlerService) GetAbsences(year string) (string, error) {
 	fmt.Printf("[DEBUG] GetAbsences called for year: %s\n", year)
-	
-	apiKey, err := s.integrationRepo.GetApiKey("timebutler")
+
+	client, err := s.client()
 	if err != nil {
 		fmt.Printf("[ERROR] Failed to get API key: %v\n", err)
 		return "", fmt.Errorf("failed to get API key: %w", err)
 	}
-	fmt.Printf("[DEBUG] Retrieved API key (length: %d)\n", len(apiKey))
-
-	// URL für den API-Endpunkt für Abwesenheiten
-	url := "https://app.timebutler.com/api/v1/absences"
-
-	// Alle erforderlichen Parameter für die Timebutler API
-	method := "POST"
-	payload := strings.NewReader(fmt.Sprintf("auth=%s&year=%s&detailed=true", apiKey, year))
-
-	fmt.Printf("Requesting Timebutler absences for year: %s\n", year)
 
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
-
-	req, err := http.NewRequest(method, url, payload)
-	if err != nil {
-		return "", err
-	}
-
-	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-
-	res, err := client.Do(req)
-	if err != nil {
-		return "", err
-	}
-	defer res.Body.Close()
-
-	if res.StatusCode != http.StatusOK {
-		return "", errors.New(fmt.Sprintf("Timebutler API Fehler: %s", res.Status))
-	}
-
-	body, err := io.ReadAll(res.Body)
+	body, err := client.absences(year)
 	if err != nil {
 		return "", err
 	}
@@ -192,7 +147,7 @@ func (s *TimebutlerService) GetAbsences(year string) (string, error) {
 	// Integration als aktiv markieren
 	s.integrationRepo.SetIntegrationStatus("timebutler", true)
 
-	return string(body), nil
+	return body, nil
 }
 
 // IsConnected prüft, ob die Timebutler-Integration aktiv ist
@@ -335,6 +290,9 @@ func parseTimebutlerDate(dateStr string) time.Time {
 
 // SyncTimebutlerUsers synchronisiert Timebutler-Benutzer mit PeopleFlow-Mitarbeitern
 func (s *TimebutlerService) SyncTimebutlerUsers() (int, error) {
+	progress := s.reporter()
+	progress.StartPhase("Benutzer von Timebutler abrufen", 0)
+
 	// Timebutler-Benutzer abrufen
 	usersData, err := s.GetUsers()
 	if err != nil {
@@ -356,30 +314,38 @@ func (s *TimebutlerService) SyncTimebutlerUsers() (int, error) {
 		return 0, err
 	}
 
+	// Timebutler-Benutzer zuordnen: manuelle Zuordnungen, gespeicherte ID, dann E-Mail
+	matchedIDs, err := matchSyncedIdentities(repository.IntegrationTypeTimebutler, "Timebutler",
+		timebutlerIdentities(timebutlerUsers), employees,
+		func(e *model.Employee) string { return e.TimebutlerUserID }, progress)
+	if err != nil {
+		return 0, err
+	}
+
+	policy, err := newFieldPolicy(timebutlerIntegration{}.Info())
+	if err != nil {
+		return 0, err
+	}
+
+	usersByID := make(map[string]model.TimebutlerUser, len(timebutlerUsers))
+	for _, tbUser := range timebutlerUsers {
+		usersByID[tbUser.UserID] = tbUser
+	}
+
 	// Zähler für aktualisierte Mitarbeiter
 	updatedCount := 0
 
 	// Mitarbeiter durchgehen und mit Timebutler-Daten abgleichen
+	progress.StartPhase("Benutzer abgleichen", len(employees))
 	for _, employee := range employees {
-		// E-Mail in Kleinbuchstaben umwandeln
-		employeeEmail := strings.ToLower(employee.Email)
-
-		// Prüfen, ob ein Timebutler-Benutzer mit dieser E-Mail existiert
-		// Wir suchen nach der E-Mail in lowercase
-		var matchedUser model.TimebutlerUser
-		found := false
-
-		for email, tbUser := range timebutlerUsers {
-			if strings.ToLower(email) == employeeEmail {
-				matchedUser = tbUser
-				found = true
-				break
-			}
-		}
+		progress.Advance(1)
 
+		// Zugeordneten Timebutler-Benutzer suchen
+		userID, found := matchedIDs[employee.ID]
 		if !found {
 			continue
 		}
+		matchedUser := usersByID[userID]
 
 		// Flag, um zu prüfen, ob Änderungen vorgenommen wurden
 		updated := false
@@ -390,42 +356,37 @@ func (s *TimebutlerService) SyncTimebutlerUsers() (int, error) {
 			updated = true
 		}
 
-		// Weitere Felder synchronisieren, die aus Timebutler kommen sollen
-
-		// Telefon aktualisieren, wenn nicht gesetzt
-		if employee.Phone == "" && matchedUser.Phone != "" {
-			employee.Phone = matchedUser.Phone
-			updated = true
-		}
-
-		// Abteilung aktualisieren, wenn nicht gesetzt
-		if employee.Department == "" && matchedUser.Department != "" {
-			employee.Department = model.Department(matchedUser.Department)
-			updated = true
-		}
-
-		// Eintrittsdatum aktualisieren, wenn nicht gesetzt
-		if employee.HireDate.IsZero() && !matchedUser.DateOfEntry.IsZero() {
-			employee.HireDate = matchedUser.DateOfEntry
-			updated = true
-		}
-
-		// Geburtsdatum aktualisieren, wenn nicht gesetzt
-		if employee.DateOfBirth.IsZero() && !matchedUser.DateOfBirth.IsZero() {
-			employee.DateOfBirth = matchedUser.DateOfBirth
-			updated = true
+		// Weitere Felder gemäß den Zuständigkeiten der Integration synchronisieren
+		changed := updated
+		for _, field := range []struct{ name, value string }{
+			{"phone", matchedUser.Phone},
+			{"department", matchedUser.Department},
+			{"hireDate", model.FormatSyncedDate(matchedUser.DateOfEntry)},
+			{"dateOfBirth", model.FormatSyncedDate(matchedUser.DateOfBirth)},
+		} {
+			result, err := policy.apply(employee, field.name, field.value)
+			if err != nil {
+				return updatedCount, err
+			}
+			updated = updated || result.Updated
+			changed = changed || result.Changed()
 		}
 
 		// Wenn Änderungen vorgenommen wurden, Mitarbeiter aktualisieren
-		if updated {
-			employee.UpdatedAt = time.Now()
-			err := employeeRepo.Update(employee)
+		if changed {
+			if updated {
+				employee.UpdatedAt = time.Now()
+			}
+			err := s.saveEmployee(employeeRepo, employee)
 			if err != nil {
 				return updatedCount, err
 			}
-			updatedCount++
+			if updated {
+				updatedCount++
+			}
 		}
 	}
+	policy.reportConflicts(progress, s.dryRun)
 
 	return updatedCount, nil
 }
@@ -504,10 +465,12 @@ func (s *TimebutlerService) GetTimebutlerAbsences(year string) (map[string][]mod
 	return absencesMap, nil
 }
 
-// ParseTimebutlerAbsences parst die CSV-Daten von Timebutler-Abwesenheiten
-func (s *TimebutlerService) ParseTimebutlerAbsences(data string) (map[string][]model.TimebutlerAbsence, error) {
+// ParseTimebutlerAbsences parst die CSV-Daten von Timebutler-Abwesenheiten. Zeilen, die nicht
+// gelesen werden können, werden übersprungen und mit ihrer ID (soweit vorhanden) zurückgegeben.
+func (s *TimebutlerService) ParseTimebutlerAbsences(data string) (map[string][]model.TimebutlerAbsence, model.TimebutlerSkippedRows, error) {
 	// Ergebnis-Map initialisieren (UserID als Schlüssel)
 	absencesMap := make(map[string][]model.TimebutlerAbsence)
+	var skipped model.TimebutlerSkippedRows
 
 	// CSV-Daten parsen
 	scanner := bufio.NewScanner(strings.NewReader(data))
@@ -532,7 +495,7 @@ func (s *TimebutlerService) ParseTimebutlerAbsences(data string) (map[string][]m
 
 		// Prüfen, ob genügend Felder vorhanden sind
 		if len(fields) < 15 {
-			fmt.Printf("Skipping line with insufficient fields: %s\n", line)
+			skipped.Add(strings.TrimSpace(fields[0]))
 			continue
 		}
 
@@ -559,7 +522,7 @@ func (s *TimebutlerService) ParseTimebutlerAbsences(data string) (map[string][]m
 
 		// UserID muss vorhanden sein
 		if userID == "" {
-			fmt.Printf("Skipping line with empty UserID: %s\n", line)
+			skipped.Add(absenceID)
 			continue
 		}
 
@@ -569,7 +532,7 @@ func (s *TimebutlerService) ParseTimebutlerAbsences(data string) (map[string][]m
 
 		// Wenn Datum nicht geparst werden konnte, überspringen
 		if startDate.IsZero() || endDate.IsZero() {
-			fmt.Printf("Skipping line with invalid dates: %s\n", line)
+			skipped.Add(absenceID)
 			continue
 		}
 
@@ -614,43 +577,51 @@ func (s *TimebutlerService) ParseTimebutlerAbsences(data string) (map[string][]m
 	}
 
 	if err := scanner.Err(); err != nil {
-		return nil, err
+		return nil, skipped, err
 	}
 
-	return absencesMap, nil
+	return absencesMap, skipped, nil
+}
+
+// IsAbsencePushEnabled prüft, ob in PeopleFlow erfasste Abwesenheiten an Timebutler übertragen werden
+func (s *TimebutlerService) IsAbsencePushEnabled() bool {
+	pushAbsences, err := s.integrationRepo.GetMetadata("timebutler", "push_absences")
+	return err == nil && pushAbsences == "true"
 }
 
-// SyncTimebutlerAbsences synchronizes Timebutler absences with PeopleFlow employees
+// SetAbsencePush aktiviert oder deaktiviert die Übertragung von Abwesenheiten an Timebutler
+func (s *TimebutlerService) SetAbsencePush(enabled bool) error {
+	return s.integrationRepo.SetMetadata("timebutler", "push_absences", strconv.FormatBool(enabled))
+}
+
+// SyncTimebutlerAbsences gleicht die Abwesenheiten eines Jahres inkrementell mit Timebutler ab:
+// Neue und geänderte Abwesenheiten werden übernommen (erkannt an der Prüfsumme je Timebutler-ID),
+// stornierte und gelöschte an die Mitarbeiter weitergegeben. Ist die Übertragung aktiviert,
+// werden beantragte und genehmigte Abwesenheiten aus PeopleFlow anschließend in Timebutler angelegt.
+// Die Übertragung legt nur neue Abwesenheiten an; spätere Änderungen in PeopleFlow werden als
+// Warnung gemeldet und müssen in Timebutler nachgetragen werden.
 func (s *TimebutlerService) SyncTimebutlerAbsences(year string) (int, error) {
-	fmt.Printf("[DEBUG] SyncTimebutlerAbsences called for year: %s\n", year)
-	
+	progress := s.reporter()
+	progress.StartPhase("Abwesenheiten von Timebutler abrufen", 0)
+
+	yearNumber, err := strconv.Atoi(year)
+	if err != nil {
+		return 0, fmt.Errorf("%w: Jahr %q", ErrInvalidSyncDateRange, year)
+	}
+
 	// Timebutler-Abwesenheiten abrufen
-	fmt.Println("[DEBUG] Fetching absences from Timebutler API...")
 	absencesData, err := s.GetAbsences(year)
 	if err != nil {
-		fmt.Printf("[ERROR] Failed to fetch absences: %v\n", err)
 		return 0, fmt.Errorf("failed to fetch absences: %w", err)
 	}
-	fmt.Printf("[DEBUG] Successfully fetched absences data (length: %d)\n", len(absencesData))
-
-	// Logging für Debugging
-	fmt.Println("Received absence data from Timebutler API. First 500 chars:")
-	if len(absencesData) > 500 {
-		fmt.Println(absencesData[:500] + "...")
-	} else {
-		fmt.Println(absencesData)
-	}
 
 	// Absences nach UserID parsen
-	absencesByUserID, err := s.ParseTimebutlerAbsences(absencesData)
+	absencesByUserID, skipped, err := s.ParseTimebutlerAbsences(absencesData)
 	if err != nil {
 		return 0, err
 	}
-
-	// Logging für Debugging
-	fmt.Printf("Parsed %d unique user IDs with absences\n", len(absencesByUserID))
-	for userID, absences := range absencesByUserID {
-		fmt.Printf("UserID: %s has %d absences\n", userID, len(absences))
+	if skipped.Count() > 0 {
+		progress.Warn(fmt.Sprintf("%d Abwesenheiten aus Timebutler konnten nicht gelesen werden; sie werden in PeopleFlow nicht entfernt", skipped.Count()))
 	}
 
 	// Repository für Mitarbeiter initialisieren
@@ -662,193 +633,125 @@ func (s *TimebutlerService) SyncTimebutlerAbsences(year string) (int, error) {
 		return 0, err
 	}
 
-	// Logging für Debugging
-	fmt.Printf("Found %d employees in the database\n", len(employees))
-	for _, emp := range employees {
-		if emp.TimebutlerUserID != "" {
-			fmt.Printf("Employee %s %s has Timebutler UserID: %s\n", emp.FirstName, emp.LastName, emp.TimebutlerUserID)
+	// Abwesenheiten werden nur übertragen, wenn die Einstellung aktiv ist und kein Probelauf läuft
+	var pushClient *timebutlerClient
+	if s.IsAbsencePushEnabled() && !s.dryRun {
+		if pushClient, err = s.client(); err != nil {
+			return 0, err
 		}
 	}
 
-	// Standardwert für Urlaubstage pro Jahr
-	const standardVacationDays = 30
-
 	// Zähler für aktualisierte Mitarbeiter
 	updatedCount := 0
+	var total model.AbsenceSyncResult
+	pushedCount := 0
 
-	// Mitarbeiter durchgehen und Abwesenheiten zuordnen
+	// Mitarbeiter durchgehen und Abwesenheiten abgleichen
+	progress.StartPhase("Abwesenheiten abgleichen", len(employees))
 	for _, employee := range employees {
+		progress.Advance(1)
+
 		// Prüfen, ob TimebutlerUserID gesetzt ist
 		if employee.TimebutlerUserID == "" {
 			continue
 		}
 
-		// Prüfen, ob Abwesenheiten für diesen Mitarbeiter existieren
-		absences, exists := absencesByUserID[employee.TimebutlerUserID]
-		if !exists || len(absences) == 0 {
-			continue
-		}
-
-		fmt.Printf("Processing %d absences for employee %s %s (ID: %s)\n",
-			len(absences), employee.FirstName, employee.LastName, employee.TimebutlerUserID)
-
-		// Abwesenheiten zu Mitarbeiter hinzufügen
-		abwesenheitenHinzugefuegt := false
-
-		for _, absence := range absences {
-			// Prüfen, ob die Abwesenheit bereits existiert
-			alreadyExists := false
-			for _, existingAbsence := range employee.Absences {
-				if existingAbsence.StartDate.Equal(absence.StartDate) &&
-					existingAbsence.EndDate.Equal(absence.EndDate) {
-					alreadyExists = true
-					break
-				}
-			}
-
-			// Wenn die Abwesenheit nicht existiert, hinzufügen
-			if !alreadyExists {
-				// Abwesenheitstyp bestimmen
-				absenceType := "vacation" // Standard: Urlaub
-				if strings.Contains(strings.ToLower(absence.AbsenceType), "sick") ||
-					strings.Contains(strings.ToLower(absence.AbsenceType), "krank") {
-					absenceType = "sick"
-				} else if strings.Contains(strings.ToLower(absence.AbsenceType), "special") {
-					absenceType = "special"
-				}
-
-				// Status bestimmen
-				status := "approved" // Standard: Genehmigt
-				if strings.ToLower(absence.Status) == "requested" {
-					status = "requested"
-				} else if strings.ToLower(absence.Status) == "rejected" ||
-					strings.ToLower(absence.Status) == "declined" {
-					status = "rejected"
-				} else if strings.ToLower(absence.Status) == "cancelled" {
-					status = "cancelled"
-				}
-
-				// Arbeitstage verwenden, wenn verfügbar, sonst berechnen
-				days := absence.Workdays
-				if days < 0.1 {
-					// Wenn Workdays nicht gesetzt, berechnen wir die Tage
-					days = float64(absence.EndDate.Sub(absence.StartDate).Hours() / 24)
-					if absence.IsHalfDay {
-						days = 0.5
-					}
-					if days < 0.5 {
-						days = 0.5 // Mindestens halber Tag
-					}
-				}
-
-				// Neue Abwesenheit erstellen
-				newAbsence := model.Absence{
-					ID:        primitive.NewObjectID(),
-					Type:      absenceType,
-					StartDate: absence.StartDate,
-					EndDate:   absence.EndDate,
-					Days:      days,
-					Status:    status,
-					Reason:    absence.AbsenceType,
-					Notes:     absence.Comment,
-				}
-
-				// Zur Mitarbeiterabsenzenliste hinzufügen
-				employee.Absences = append(employee.Absences, newAbsence)
-				abwesenheitenHinzugefuegt = true
-
-				fmt.Printf("Added new absence: %s from %s to %s for employee %s %s\n",
-					absence.AbsenceType, absence.StartDate.Format("2006-01-02"),
-					absence.EndDate.Format("2006-01-02"), employee.FirstName, employee.LastName)
-			}
+		result := employee.ApplyTimebutlerAbsences(yearNumber, absencesByUserID[employee.TimebutlerUserID], skipped)
+		total.Add(result)
+		changed := result.Changed()
+		for _, absence := range result.Diverged {
+			progress.Warn(fmt.Sprintf("Abwesenheit von %s %s (%s) wurde in PeopleFlow geändert; Änderungen werden nicht an Timebutler übertragen und müssen dort nachgetragen werden",
+				employee.FirstName, employee.LastName, absence.StartDate.Format("02.01.2006")))
 		}
 
-		// Calculate total vacation days and remaining vacation
-		// Aktuelle Jahr berechnen
-		currentYear := time.Now().Year()
-		var usedVacationDays float64 = 0
-
-		// Berechnen, wie viele Urlaubstage im aktuellen Jahr genommen wurden
-		for _, absence := range employee.Absences {
-			// Nur genehmigte Urlaubstage (nicht Krankheit oder Sonderurlaub) im aktuellen Jahr zählen
-			if absence.Type == "vacation" &&
-				absence.Status == "approved" &&
-				absence.StartDate.Year() == currentYear {
-				usedVacationDays += absence.Days
-			}
+		if pushClient != nil {
+			pushed := s.pushAbsences(pushClient, employee, yearNumber, progress)
+			pushedCount += pushed
+			changed = changed || pushed > 0
 		}
 
-		// VacationDays-Feld setzen, wenn noch nicht gesetzt
-		if employee.VacationDays == 0 {
-			employee.VacationDays = standardVacationDays
+		if recalculateRemainingVacation(employee, time.Now().Year()) {
+			changed = true
 		}
 
-		// Verbleibende Urlaubstage berechnen (mit Schutz vor negativen Werten)
-		remainingVacation := employee.VacationDays - int(usedVacationDays)
-		if remainingVacation < 0 {
-			employee.RemainingVacation = 0
-			fmt.Printf("Warning: Clamped negative remaining vacation days (%d) to 0 for employee %s %s\n", 
-				remainingVacation, employee.FirstName, employee.LastName)
-		} else {
-			employee.RemainingVacation = remainingVacation
+		if !changed {
+			continue
 		}
 
-		// Mitarbeiter aktualisieren, wenn Abwesenheiten hinzugefügt wurden oder Urlaubstage berechnet wurden
-		if abwesenheitenHinzugefuegt || employee.VacationDays > 0 {
-			employee.UpdatedAt = time.Now()
-			err := employeeRepo.Update(employee)
-			if err != nil {
-				return updatedCount, err
-			}
-			updatedCount++
-			fmt.Printf("Updated employee %s %s with absences and vacation information\n",
-				employee.FirstName, employee.LastName)
+		employee.UpdatedAt = time.Now()
+		if err := s.saveEmployee(employeeRepo, employee); err != nil {
+			return updatedCount, err
 		}
+		updatedCount++
 	}
 
+	log.Printf("Timebutler-Abwesenheiten %s: %d angelegt, %d aktualisiert, %d storniert, %d entfernt, %d unverändert, %d übertragen, %d nur in PeopleFlow geändert",
+		year, total.Created, total.Updated, total.Cancelled, total.Removed, total.Unchanged, pushedCount, len(total.Diverged))
+
 	return updatedCount, nil
 }
 
-// Add this to backend/service/timebutler_service.go
-
-// GetHolidayEntitlements fetches holiday entitlement data from Timebutler
-func (s *TimebutlerService) GetHolidayEntitlements(year string) (string, error) {
-	apiKey, err := s.integrationRepo.GetApiKey("timebutler")
-	if err != nil {
-		return "", err
+// pushAbsences legt die noch nicht übertragenen Abwesenheiten eines Mitarbeiters in Timebutler an
+// und merkt sich deren Timebutler-ID. Gibt die Anzahl übertragener Abwesenheiten zurück.
+func (s *TimebutlerService) pushAbsences(client *timebutlerClient, employee *model.Employee, year int, progress SyncProgress) int {
+	pushed := 0
+	for _, index := range employee.PendingTimebutlerAbsences(year) {
+		absence := &employee.Absences[index]
+		timebutlerID, err := client.createAbsence(employee.TimebutlerUserID, *absence)
+		if err != nil {
+			progress.Warn(fmt.Sprintf("Abwesenheit von %s %s (%s) konnte nicht an Timebutler übertragen werden: %v",
+				employee.FirstName, employee.LastName, absence.StartDate.Format("02.01.2006"), err))
+			continue
+		}
+		// Ohne ID in der Antwort ordnet der nächste Abgleich die Abwesenheit über den Zeitraum zu
+		absence.TimebutlerID = timebutlerID
+		pushed++
 	}
+	return pushed
+}
 
-	// URL for the API endpoint for holiday entitlements
-	url := "https://app.timebutler.com/api/v1/holidayentitlement"
-
-	// All required parameters for the Timebutler API
-	method := "POST"
-	payload := strings.NewReader(fmt.Sprintf("auth=%s&year=%s", apiKey, year))
+// recalculateRemainingVacation berechnet den Resturlaub aus den genehmigten Urlaubstagen des Jahres.
+// Ist kein Urlaubsanspruch gesetzt, gilt der Standard von 30 Tagen. Gibt zurück, ob sich etwas geändert hat.
+func recalculateRemainingVacation(employee *model.Employee, year int) bool {
+	// Standardwert für Urlaubstage pro Jahr
+	const standardVacationDays = 30
 
-	fmt.Printf("Requesting Timebutler holiday entitlements for year: %s\n", year)
+	vacationDays := employee.VacationDays
+	if vacationDays == 0 {
+		vacationDays = standardVacationDays
+	}
 
-	client := &http.Client{
-		Timeout: 10 * time.Second,
+	// Nur genehmigte Urlaubstage (nicht Krankheit oder Sonderurlaub) im Jahr zählen
+	var usedVacationDays float64
+	for _, absence := range employee.Absences {
+		if absence.Type == "vacation" && absence.Status == "approved" && absence.StartDate.Year() == year {
+			usedVacationDays += absence.Days
+		}
 	}
 
-	req, err := http.NewRequest(method, url, payload)
-	if err != nil {
-		return "", err
+	// Verbleibende Urlaubstage berechnen (mit Schutz vor negativen Werten)
+	remainingVacation := max(vacationDays-int(usedVacationDays), 0)
+
+	if employee.VacationDays == vacationDays && employee.RemainingVacation == remainingVacation {
+		return false
 	}
+	employee.VacationDays = vacationDays
+	employee.RemainingVacation = remainingVacation
+	return true
+}
 
-	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+// Add this to backend/service/timebutler_service.go
 
-	res, err := client.Do(req)
+// GetHolidayEntitlements fetches holiday entitlement data from Timebutler
+func (s *TimebutlerService) GetHolidayEntitlements(year string) (string, error) {
+	client, err := s.client()
 	if err != nil {
 		return "", err
 	}
-	defer res.Body.Close()
 
-	if res.StatusCode != http.StatusOK {
-		return "", errors.New(fmt.Sprintf("Timebutler API Error: %s", res.Status))
-	}
+	fmt.Printf("Requesting Timebutler holiday entitlements for year: %s\n", year)
 
-	body, err := io.ReadAll(res.Body)
+	body, err := client.holidayEntitlements(year)
 	if err != nil {
 		return "", err
 	}
@@ -856,7 +759,7 @@ func (s *TimebutlerService) GetHolidayEntitlements(year string) (string, error)
 	// Mark integration as active
 	s.integrationRepo.SetIntegrationStatus("timebutler", true)
 
-	return string(body), nil
+	return body, nil
 }
 
 // ParseHolidayEntitlements parses the CSV data from Timebutler holiday entitlements
@@ -928,6 +831,9 @@ type VacationInfo struct {
 
 // SyncHolidayEntitlements synchronizes Timebutler holiday entitlements with PeopleFlow employees
 func (s *TimebutlerService) SyncHolidayEntitlements(year string) (int, error) {
+	progress := s.reporter()
+	progress.StartPhase("Urlaubsansprüche von Timebutler abrufen", 0)
+
 	// Fetch holiday entitlements from Timebutler
 	entitlementsData, err := s.GetHolidayEntitlements(year)
 	if err != nil {
@@ -952,11 +858,19 @@ func (s *TimebutlerService) SyncHolidayEntitlements(year string) (int, error) {
 		return 0, err
 	}
 
+	policy, err := newFieldPolicy(timebutlerIntegration{}.Info())
+	if err != nil {
+		return 0, err
+	}
+
 	// Counter for updated employees
 	updatedCount := 0
 
 	// Go through employees and update vacation days
+	progress.StartPhase("Urlaubsansprüche übernehmen", len(employees))
 	for _, employee := range employees {
+		progress.Advance(1)
+
 		// Skip if no Timebutler UserID is set
 		if employee.TimebutlerUserID == "" {
 			continue
@@ -968,34 +882,47 @@ func (s *TimebutlerService) SyncHolidayEntitlements(year string) (int, error) {
 			continue
 		}
 
-		// Check if vacation data needs updating
-		if employee.VacationDays != vacationInfo.TotalVacationDays ||
-			employee.RemainingVacation != vacationInfo.RemainingVacationDays {
-
-			// Update vacation days with validation
-			employee.VacationDays = vacationInfo.TotalVacationDays
-			
-			// Ensure remaining vacation is not negative (clamp to 0)
-			if vacationInfo.RemainingVacationDays < 0 {
-				employee.RemainingVacation = 0
-				fmt.Printf("Warning: Clamped negative remaining vacation days (%d) to 0 for employee %s %s\n", 
-					vacationInfo.RemainingVacationDays, employee.FirstName, employee.LastName)
-			} else {
-				employee.RemainingVacation = vacationInfo.RemainingVacationDays
+		// Ensure remaining vacation is not negative (clamp to 0)
+		remainingVacation := vacationInfo.RemainingVacationDays
+		if remainingVacation < 0 {
+			remainingVacation = 0
+			fmt.Printf("Warning: Clamped negative remaining vacation days (%d) to 0 for employee %s %s\n",
+				vacationInfo.RemainingVacationDays, employee.FirstName, employee.LastName)
+			progress.Warn(fmt.Sprintf("Negativer Resturlaub (%d Tage) bei %s %s auf 0 gesetzt",
+				vacationInfo.RemainingVacationDays, employee.FirstName, employee.LastName))
+		}
+
+		// Update vacation days according to the configured field ownership
+		updated, changed := false, false
+		for _, field := range []struct{ name, value string }{
+			{"vacationDays", strconv.Itoa(vacationInfo.TotalVacationDays)},
+			{"remainingVacation", strconv.Itoa(remainingVacation)},
+		} {
+			result, err := policy.apply(employee, field.name, field.value)
+			if err != nil {
+				return updatedCount, err
 			}
+			updated = updated || result.Updated
+			changed = changed || result.Changed()
+		}
 
+		if changed {
 			// Update employee
-			employee.UpdatedAt = time.Now()
-			err := employeeRepo.Update(employee)
+			if updated {
+				employee.UpdatedAt = time.Now()
+			}
+			err := s.saveEmployee(employeeRepo, employee)
 			if err != nil {
 				return updatedCount, err
 			}
-			updatedCount++
-
-			fmt.Printf("Updated employee %s %s with vacation days: total=%d, remaining=%d\n",
-				employee.FirstName, employee.LastName, vacationInfo.TotalVacationDays, vacationInfo.RemainingVacationDays)
+			if updated {
+				updatedCount++
+				fmt.Printf("Updated employee %s %s with vacation days: total=%d, remaining=%d\n",
+					employee.FirstName, employee.LastName, employee.VacationDays, employee.RemainingVacation)
+			}
 		}
 	}
+	policy.reportConflicts(progress, s.dryRun)
 
 	return updatedCount, nil
 }
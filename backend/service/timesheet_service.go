@@ -0,0 +1,333 @@
+// backend/service/timesheet_service.go
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+	"PeopleFlow/backend/utils"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrNoTimesheets wird zurückgegeben, wenn für eine Abteilung keine Stundenzettel erstellt werden können
+var ErrNoTimesheets = errors.New("no timesheets for department")
+
+// TimesheetService erstellt monatliche Stundenzettel und verwaltet deren Bestätigungen
+type TimesheetService struct {
+	employeeRepo   *repository.EmployeeRepository
+	ackRepo        *repository.TimesheetAcknowledgementRepository
+	settingsRepo   *repository.SystemSettingsRepository
+	holidayService *HolidayService
+}
+
+// NewTimesheetService erstellt einen neuen TimesheetService
+func NewTimesheetService() *TimesheetService {
+	return &TimesheetService{
+		employeeRepo:   repository.NewEmployeeRepository(),
+		ackRepo:        repository.NewTimesheetAcknowledgementRepository(),
+		settingsRepo:   repository.NewSystemSettingsRepository(),
+		holidayService: NewHolidayService(),
+	}
+}
+
+// ParseMonth liest den Monat (YYYY-MM) in deutscher Zeit; ohne Angabe gilt der Vormonat
+func (s *TimesheetService) ParseMonth(value string) (time.Time, error) {
+	return model.ParseTimesheetMonth(value, time.Now().In(getGermanLocation()))
+}
+
+// Timesheet erstellt den Stundenzettel eines Mitarbeiters und lädt dessen Bestätigungen
+func (s *TimesheetService) Timesheet(employee *model.Employee, month time.Time) (*model.Timesheet, *model.TimesheetAcknowledgement, error) {
+	sheet := model.BuildTimesheet(employee, month, s.holidays(employee, month))
+	ack, err := s.ackRepo.FindByEmployeeAndMonth(employee.ID, sheet.MonthKey())
+	if err != nil {
+		return nil, nil, fmt.Errorf("Bestätigungen konnten nicht geladen werden: %w", err)
+	}
+	return sheet, ack, nil
+}
+
+// Acknowledge bestätigt den Stundenzettel als Mitarbeiter oder Vorgesetzter. Bestätigt werden
+// können nur abgeschlossene Monate; gegenzeichnen dürfen nur der eingetragene Vorgesetzte und Admins.
+func (s *TimesheetService) Acknowledge(employee *model.Employee, month time.Time, role model.TimesheetSignatureRole, user *model.User, now time.Time) (*model.Timesheet, *model.TimesheetAcknowledgement, error) {
+	if !model.IsTimesheetMonthClosed(month, now) {
+		return nil, nil, model.ErrTimesheetMonthOpen
+	}
+	if role == model.TimesheetSignatureManager {
+		if err := model.CheckTimesheetApprover(user, employee); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	sheet, ack, err := s.Timesheet(employee, month)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	signature := model.TimesheetSignature{
+		UserID:   user.ID,
+		Name:     user.FirstName + " " + user.LastName,
+		At:       now,
+		Checksum: sheet.Checksum(),
+	}
+	if err := ack.Sign(role, signature); err != nil {
+		return nil, nil, err
+	}
+	saved, err := s.ackRepo.SaveSignature(employee.ID, sheet.MonthKey(), role, signature)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sheet, saved, nil
+}
+
+// DepartmentArchive erstellt die Stundenzettel aller Mitarbeiter einer Abteilung als ZIP-Archiv.
+// Inaktive Mitarbeiter werden nur aufgenommen, wenn sie im Monat Zeiten erfasst haben.
+func (s *TimesheetService) DepartmentArchive(department string, month time.Time, now time.Time) ([]byte, int, error) {
+	employees, _, err := s.employeeRepo.Search(repository.EmployeeQuery{Department: department})
+	if err != nil {
+		return nil, 0, fmt.Errorf("Mitarbeiter konnten nicht geladen werden: %w", err)
+	}
+
+	acks, err := s.ackRepo.FindByMonth(month.Format("2006-01"))
+	if err != nil {
+		return nil, 0, fmt.Errorf("Bestätigungen konnten nicht geladen werden: %w", err)
+	}
+
+	var files []TimesheetFile
+	for _, employee := range employees {
+		sheet := model.BuildTimesheet(employee, month, s.holidays(employee, month))
+		if employee.Status == model.EmployeeStatusInactive && sheet.ActualHours == 0 {
+			continue
+		}
+		files = append(files, TimesheetFile{
+			Name:    sheet.FileName(),
+			Content: RenderTimesheetPDF(sheet, acks[employee.ID].Status(sheet.Checksum()), now),
+		})
+	}
+	if len(files) == 0 {
+		return nil, 0, ErrNoTimesheets
+	}
+
+	archive, err := WriteTimesheetArchive(files)
+	if err != nil {
+		return nil, 0, err
+	}
+	return archive, len(files), nil
+}
+
+// FindEmployee lädt einen Mitarbeiter anhand seiner ID
+func (s *TimesheetService) FindEmployee(id primitive.ObjectID) (*model.Employee, error) {
+	return s.employeeRepo.FindByID(id.Hex())
+}
+
+// holidays liefert die Feiertage des eingestellten Bundeslands für alle Jahre vom ersten
+// Zeiteintrag bis zum Monat des Stundenzettels
+func (s *TimesheetService) holidays(employee *model.Employee, month time.Time) map[string]string {
+	state := model.StateNordrheinWestfalen // Fallback
+	if settings, err := s.settingsRepo.GetSettings(); err == nil {
+		state = model.GermanState(settings.State)
+	}
+
+	firstYear := month.Year()
+	for _, entry := range employee.TimeEntries {
+		if entry.Date.Year() < firstYear {
+			firstYear = entry.Date.Year()
+		}
+	}
+
+	holidays := make(map[string]string)
+	for year := firstYear; year <= month.Year(); year++ {
+		for _, holiday := range s.holidayService.GetHolidaysForState(year, state) {
+			holidays[holiday.Date.Format("2006-01-02")] = holiday.Name
+		}
+	}
+	return holidays
+}
+
+// TimesheetFile ist ein Stundenzettel im ZIP-Archiv
+type TimesheetFile struct {
+	Name    string
+	Content []byte
+}
+
+// WriteTimesheetArchive packt die Stundenzettel in ein ZIP-Archiv
+func WriteTimesheetArchive(files []TimesheetFile) ([]byte, error) {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, file := range files {
+		w, err := archive.Create(file.Name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(file.Content); err != nil {
+			return nil, err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Layout des Stundenzettels (Punkt, A4 hoch)
+const (
+	timesheetMargin    = 40.0
+	timesheetRowHeight = 13.0
+	timesheetFontSize  = 8.0
+)
+
+// timesheetColumn ist eine Spalte der Tagestabelle
+type timesheetColumn struct {
+	label string
+	width float64
+	right bool
+}
+
+var timesheetColumns = []timesheetColumn{
+	{"Datum", 62, false},
+	{"Beginn", 45, true},
+	{"Ende", 45, true},
+	{"Pause", 45, true},
+	{"Ist", 50, true},
+	{"Soll", 50, true},
+	{"Differenz", 55, true},
+	{"Vermerk", 163, false},
+}
+
+// RenderTimesheetPDF erstellt den Stundenzettel als einseitiges PDF mit Tagestabelle, Salden und
+// den Bestätigungen von Mitarbeiter und Vorgesetztem. Fehlt eine Bestätigung, bleibt Platz für
+// eine handschriftliche Unterschrift.
+func RenderTimesheetPDF(sheet *model.Timesheet, status model.TimesheetStatus, now time.Time) []byte {
+	doc := utils.NewPDFDocument(false)
+	doc.AddPage()
+	width, height := doc.Size()
+	right := width - timesheetMargin
+
+	doc.Text(timesheetMargin, 52, 16, true, "Stundenzettel "+reportMonth(model.EmailLanguageGerman, sheet.Month))
+	info := sheet.EmployeeName
+	if sheet.EmployeeNumber != "" {
+		info += " (Personalnr. " + sheet.EmployeeNumber + ")"
+	}
+	doc.Text(timesheetMargin, 72, 10, true, info)
+	var details []string
+	for _, value := range []string{sheet.Department, sheet.Position} {
+		if value != "" {
+			details = append(details, value)
+		}
+	}
+	doc.Text(timesheetMargin, 86, 9, false, strings.Join(details, " · "))
+
+	// Tagestabelle
+	y := 104.0
+	doc.FillRect(timesheetMargin, y, right-timesheetMargin, timesheetRowHeight+2, 0.85)
+	timesheetRow(doc, y+10, true, timesheetColumnLabels())
+	y += timesheetRowHeight + 2
+	for _, day := range sheet.Days {
+		if day.Weekend || day.Holiday != "" {
+			doc.FillRect(timesheetMargin, y, right-timesheetMargin, timesheetRowHeight, 0.94)
+		}
+		var difference string
+		if day.WorkedHours != 0 || day.TargetHours != 0 {
+			difference = formatTimesheetBalance(day.WorkedHours - day.TargetHours)
+		}
+		timesheetRow(doc, y+9.5, false, []string{
+			reportWeekdays[model.EmailLanguageGerman][day.Date.Weekday()] + " " + day.Date.Format("02.01."),
+			day.Start,
+			day.End,
+			formatTimesheetOptionalHours(day.BreakHours),
+			formatTimesheetOptionalHours(day.WorkedHours),
+			formatTimesheetOptionalHours(day.TargetHours),
+			difference,
+			day.Note(),
+		})
+		y += timesheetRowHeight
+	}
+	doc.Line(timesheetMargin, y, right, y, 0.5)
+	timesheetRow(doc, y+10, true, []string{"Summe", "", "", "", formatTimesheetHours(sheet.ActualHours), formatTimesheetHours(sheet.TargetHours), formatTimesheetBalance(sheet.Difference), ""})
+
+	// Salden
+	y += 34
+	summary := [][2]string{
+		{"Übertrag Vormonat", formatTimesheetBalance(sheet.CarriedForward)},
+		{"Differenz " + reportMonth(model.EmailLanguageGerman, sheet.Month), formatTimesheetBalance(sheet.Difference)},
+		{"Genehmigte Anpassungen", formatTimesheetBalance(sheet.Adjustments)},
+		{"Überstunden-Saldo Monatsende", formatTimesheetBalance(sheet.ClosingBalance)},
+	}
+	for i, line := range summary {
+		bold := i == len(summary)-1
+		doc.Text(timesheetMargin, y, 9, bold, line[0])
+		doc.TextRight(timesheetMargin+230, y, 9, bold, line[1]+" Std")
+		y += 13
+	}
+	doc.Text(timesheetMargin+290, y-4*13, 9, false, fmt.Sprintf("Urlaubstage: %d", sheet.VacationDays))
+	doc.Text(timesheetMargin+290, y-3*13, 9, false, fmt.Sprintf("Krankheitstage: %d", sheet.SickDays))
+
+	// Bestätigungen
+	y = height - 95
+	if status.ChangedSinceSigning {
+		doc.Text(timesheetMargin, y-22, 8, true, "Hinweis: Der Stundenzettel wurde nach einer Bestätigung geändert und muss erneut bestätigt werden.")
+	}
+	half := (right - timesheetMargin - 30) / 2
+	timesheetSignatureBlock(doc, timesheetMargin, y, half, "Mitarbeiter", status.Employee, status.EmployeeAcknowledged)
+	timesheetSignatureBlock(doc, timesheetMargin+half+30, y, half, "Vorgesetzter", status.Manager, status.ManagerAcknowledged)
+
+	doc.Text(timesheetMargin, height-28, 7, false, "Erstellt am "+now.Format("02.01.2006 15:04")+" · Prüfsumme "+sheet.Checksum()[:16])
+	return doc.Bytes()
+}
+
+func timesheetColumnLabels() []string {
+	labels := make([]string, len(timesheetColumns))
+	for i, column := range timesheetColumns {
+		labels[i] = column.label
+	}
+	return labels
+}
+
+// timesheetRow schreibt eine Tabellenzeile auf der Grundlinie y
+func timesheetRow(doc *utils.PDFDocument, y float64, bold bool, values []string) {
+	x := timesheetMargin
+	for i, column := range timesheetColumns {
+		text := utils.FitPDFText(values[i], timesheetFontSize, bold, column.width-8)
+		if column.right {
+			doc.TextRight(x+column.width-4, y, timesheetFontSize, bold, text)
+		} else {
+			doc.Text(x+4, y, timesheetFontSize, bold, text)
+		}
+		x += column.width
+	}
+}
+
+// timesheetSignatureBlock zeichnet das Unterschriftsfeld; eine gültige Bestätigung wird mit Name
+// und Zeitpunkt eingetragen, sonst bleibt die Linie für eine Unterschrift frei
+func timesheetSignatureBlock(doc *utils.PDFDocument, x, y, width float64, label string, signature *model.TimesheetSignature, valid bool) {
+	if signature != nil && valid {
+		doc.Text(x, y-6, 9, true, "Elektronisch bestätigt von "+signature.Name)
+		doc.Text(x, y+6, 8, false, "am "+signature.At.In(getGermanLocation()).Format("02.01.2006 um 15:04 Uhr"))
+	}
+	y += 22
+	doc.Line(x, y, x+width, y, 0.5)
+	doc.Text(x, y+11, 8, false, "Datum, Unterschrift "+label)
+}
+
+// formatTimesheetHours formatiert Stunden mit zwei Nachkommastellen und Dezimalkomma
+func formatTimesheetHours(hours float64) string {
+	return strings.Replace(fmt.Sprintf("%.2f", hours), ".", ",", 1)
+}
+
+// formatTimesheetOptionalHours lässt Nullwerte leer, damit die Tagestabelle lesbar bleibt
+func formatTimesheetOptionalHours(hours float64) string {
+	if hours == 0 {
+		return ""
+	}
+	return formatTimesheetHours(hours)
+}
+
+// formatTimesheetBalance formatiert eine Stundendifferenz mit Vorzeichen
+func formatTimesheetBalance(hours float64) string {
+	return strings.Replace(fmt.Sprintf("%+.2f", hours), ".", ",", 1)
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"PeopleFlow/backend/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func timesheetServiceTestSheet() *model.Timesheet {
+	employee := &model.Employee{
+		ID: primitive.NewObjectID(), EmployeeID: "1001", FirstName: "Anna", LastName: "Schmidt", Department: "IT",
+		WorkingHoursPerWeek: 40, WorkingDaysPerWeek: 5,
+		TimeEntries: []model.TimeEntry{{
+			Date:      time.Date(2024, time.April, 2, 0, 0, 0, 0, time.UTC),
+			StartTime: time.Date(2024, time.April, 2, 8, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2024, time.April, 2, 17, 0, 0, 0, time.UTC),
+			Duration:  8.5,
+		}},
+	}
+	return model.BuildTimesheet(employee, time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC), map[string]string{"2024-04-01": "Ostermontag"})
+}
+
+func TestRenderTimesheetPDF(t *testing.T) {
+	sheet := timesheetServiceTestSheet()
+	now := time.Date(2024, time.May, 2, 9, 30, 0, 0, time.UTC)
+
+	open := string(RenderTimesheetPDF(sheet, model.TimesheetStatus{}, now))
+	assert.True(t, len(open) > 0 && open[:5] == "%PDF-")
+	assert.Contains(t, open, "(Stundenzettel April 2024)")
+	assert.Contains(t, open, "(Anna Schmidt \\(Personalnr. 1001\\))")
+	assert.Contains(t, open, "(Di 02.04.)")
+	assert.Contains(t, open, "(Ostermontag)")
+	assert.Contains(t, open, "(Datum, Unterschrift Mitarbeiter)")
+	assert.NotContains(t, open, "Elektronisch")
+
+	ack := &model.TimesheetAcknowledgement{}
+	require.NoError(t, ack.Sign(model.TimesheetSignatureEmployee, model.TimesheetSignature{Name: "Anna Schmidt", At: now, Checksum: sheet.Checksum()}))
+	require.NoError(t, ack.Sign(model.TimesheetSignatureManager, model.TimesheetSignature{Name: "Max Chef", At: now, Checksum: "alt"}))
+
+	signed := string(RenderTimesheetPDF(sheet, ack.Status(sheet.Checksum()), now))
+	assert.Contains(t, signed, "(Elektronisch best\xe4tigt von Anna Schmidt)")
+	assert.NotContains(t, signed, "Max Chef", "veraltete Bestätigungen werden nicht eingetragen")
+	assert.Contains(t, signed, "(Hinweis: Der Stundenzettel wurde nach einer Best\xe4tigung ge\xe4ndert")
+}
+
+func TestWriteTimesheetArchive(t *testing.T) {
+	archive, err := WriteTimesheetArchive([]TimesheetFile{
+		{Name: "Stundenzettel_2024-04_1001_Anna_Schmidt.pdf", Content: []byte("%PDF-a")},
+		{Name: "Stundenzettel_2024-04_1002_Ben_Meyer.pdf", Content: []byte("%PDF-b")},
+	})
+	require.NoError(t, err)
+
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	require.NoError(t, err)
+	require.Len(t, reader.File, 2)
+	assert.Equal(t, "Stundenzettel_2024-04_1002_Ben_Meyer.pdf", reader.File[1].Name)
+
+	file, err := reader.File[0].Open()
+	require.NoError(t, err)
+	defer file.Close()
+	content, err := io.ReadAll(file)
+	require.NoError(t, err)
+	assert.Equal(t, "%PDF-a", string(content))
+}
+
+func TestFormatTimesheetHours(t *testing.T) {
+	assert.Equal(t, "8,50", formatTimesheetHours(8.5))
+	assert.Equal(t, "", formatTimesheetOptionalHours(0))
+	assert.Equal(t, "+0,50", formatTimesheetBalance(0.5))
+	assert.Equal(t, "-2,25", formatTimesheetBalance(-2.25))
+}
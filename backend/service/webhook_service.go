@@ -0,0 +1,333 @@
+// backend/service/webhook_service.go
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+	"PeopleFlow/backend/utils"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// webhookTimeout begrenzt die Wartezeit auf einen Empfänger
+	webhookTimeout = 10 * time.Second
+
+	// webhookClaimLease reserviert eine Zustellung während des Versands
+	webhookClaimLease = 2 * time.Minute
+
+	// webhookResponseLimit begrenzt den gespeicherten Antwort-Body
+	webhookResponseLimit = 2048
+
+	// webhookBatchSize begrenzt die Zustellungen pro Worker-Durchlauf
+	webhookBatchSize = 100
+)
+
+// webhookEndpointStore speichert die Webhook-Endpunkte (WebhookEndpointRepository)
+type webhookEndpointStore interface {
+	Create(endpoint *model.WebhookEndpoint) error
+	FindByID(id string) (*model.WebhookEndpoint, error)
+	FindAll() ([]*model.WebhookEndpoint, error)
+	FindSubscribers(event model.ActivityType) ([]*model.WebhookEndpoint, error)
+	Update(endpoint *model.WebhookEndpoint) error
+	Delete(id string) error
+}
+
+// webhookDeliveryStore speichert Zustellungen und reserviert fällige Wiederholungen
+// (WebhookDeliveryRepository)
+type webhookDeliveryStore interface {
+	Create(delivery *model.WebhookDelivery) error
+	FindByID(id string) (*model.WebhookDelivery, error)
+	FindByEndpoint(endpointID primitive.ObjectID, skip, limit int64) ([]*model.WebhookDelivery, int64, error)
+	ClaimDue(now time.Time, lease time.Duration) (*model.WebhookDelivery, error)
+	SaveAttempt(delivery *model.WebhookDelivery) error
+	DeleteByEndpoint(endpointID primitive.ObjectID) error
+}
+
+// WebhookService verwaltet Webhook-Endpunkte und stellt HR-Ereignisse signiert zu
+type WebhookService struct {
+	endpointRepo webhookEndpointStore
+	deliveryRepo webhookDeliveryStore
+	client       *http.Client
+}
+
+// NewWebhookService erstellt einen neuen WebhookService
+func NewWebhookService() *WebhookService {
+	return &WebhookService{
+		endpointRepo: repository.NewWebhookEndpointRepository(),
+		deliveryRepo: repository.NewWebhookDeliveryRepository(),
+		client:       &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// CreateEndpoint registriert einen Endpunkt. Das Secret wird nur einmal im Klartext zurückgegeben.
+func (s *WebhookService) CreateEndpoint(createdBy *model.User, name, url string, events []string) (string, *model.WebhookEndpoint, error) {
+	parsed, err := model.ParseWebhookEvents(events)
+	if err != nil {
+		return "", nil, err
+	}
+
+	secret, err := generateSecureToken()
+	if err != nil {
+		return "", nil, fmt.Errorf("fehler beim Generieren des Secrets: %w", err)
+	}
+	encrypted, err := utils.EncryptString(secret)
+	if err != nil {
+		return "", nil, fmt.Errorf("fehler beim Verschlüsseln des Secrets: %w", err)
+	}
+
+	endpoint := &model.WebhookEndpoint{
+		Name:      strings.TrimSpace(name),
+		URL:       strings.TrimSpace(url),
+		Secret:    encrypted,
+		Events:    parsed,
+		Active:    true,
+		CreatedBy: createdBy.ID,
+	}
+	if err := endpoint.Validate(); err != nil {
+		return "", nil, err
+	}
+	if err := s.endpointRepo.Create(endpoint); err != nil {
+		return "", nil, err
+	}
+	return secret, endpoint, nil
+}
+
+// UpdateEndpoint ändert Name, URL, Ereignisse und Aktivierung eines Endpunkts
+func (s *WebhookService) UpdateEndpoint(id, name, url string, events []string, active bool) (*model.WebhookEndpoint, error) {
+	endpoint, err := s.endpointRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := model.ParseWebhookEvents(events)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint.Name = strings.TrimSpace(name)
+	endpoint.URL = strings.TrimSpace(url)
+	endpoint.Events = parsed
+	endpoint.Active = active
+	if err := endpoint.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.endpointRepo.Update(endpoint); err != nil {
+		return nil, err
+	}
+	return endpoint, nil
+}
+
+// RotateSecret erzeugt ein neues Secret für einen Endpunkt und gibt es einmalig zurück
+func (s *WebhookService) RotateSecret(id string) (string, *model.WebhookEndpoint, error) {
+	endpoint, err := s.endpointRepo.FindByID(id)
+	if err != nil {
+		return "", nil, err
+	}
+
+	secret, err := generateSecureToken()
+	if err != nil {
+		return "", nil, fmt.Errorf("fehler beim Generieren des Secrets: %w", err)
+	}
+	if endpoint.Secret, err = utils.EncryptString(secret); err != nil {
+		return "", nil, fmt.Errorf("fehler beim Verschlüsseln des Secrets: %w", err)
+	}
+	if err := s.endpointRepo.Update(endpoint); err != nil {
+		return "", nil, err
+	}
+	return secret, endpoint, nil
+}
+
+// DeleteEndpoint löscht einen Endpunkt samt Zustellprotokoll
+func (s *WebhookService) DeleteEndpoint(id string) (*model.WebhookEndpoint, error) {
+	endpoint, err := s.endpointRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.endpointRepo.Delete(id); err != nil {
+		return nil, err
+	}
+	if err := s.deliveryRepo.DeleteByEndpoint(endpoint.ID); err != nil {
+		log.Printf("Fehler beim Löschen der Webhook-Zustellungen von %s: %v", endpoint.Name, err)
+	}
+	return endpoint, nil
+}
+
+// GetEndpoint gibt einen Endpunkt zurück
+func (s *WebhookService) GetEndpoint(id string) (*model.WebhookEndpoint, error) {
+	return s.endpointRepo.FindByID(id)
+}
+
+// ListEndpoints gibt alle Endpunkte zurück
+func (s *WebhookService) ListEndpoints() ([]*model.WebhookEndpoint, error) {
+	return s.endpointRepo.FindAll()
+}
+
+// ListDeliveries gibt das Zustellprotokoll eines Endpunkts zurück
+func (s *WebhookService) ListDeliveries(endpoint *model.WebhookEndpoint, skip, limit int64) ([]*model.WebhookDelivery, int64, error) {
+	return s.deliveryRepo.FindByEndpoint(endpoint.ID, skip, limit)
+}
+
+// Publish ist der ActivityListener für Webhooks. Die Zustellung läuft im Hintergrund,
+// damit die auslösende Anfrage nicht auf externe Systeme wartet.
+func (s *WebhookService) Publish(activity *model.Activity) {
+	event := *activity
+	go s.enqueue(&event)
+}
+
+// enqueue legt für jeden abonnierenden Endpunkt eine Zustellung an und sendet sie sofort
+func (s *WebhookService) enqueue(activity *model.Activity) {
+	endpoints, err := s.endpointRepo.FindSubscribers(activity.Type)
+	if err != nil {
+		log.Printf("Fehler beim Laden der Webhook-Endpunkte für %s: %v", activity.Type, err)
+		return
+	}
+	if len(endpoints) == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(model.NewWebhookEvent(activity))
+	if err != nil {
+		log.Printf("Fehler beim Serialisieren des Webhook-Ereignisses %s: %v", activity.ID.Hex(), err)
+		return
+	}
+
+	for _, endpoint := range endpoints {
+		delivery := &model.WebhookDelivery{
+			EndpointID: endpoint.ID,
+			EventID:    activity.ID.Hex(),
+			EventType:  activity.Type,
+			Payload:    string(payload),
+		}
+		if err := s.createClaimed(delivery); err != nil {
+			log.Printf("Fehler beim Anlegen der Webhook-Zustellung für %s: %v", endpoint.Name, err)
+			continue
+		}
+		s.attempt(endpoint, delivery)
+	}
+}
+
+// Redeliver stellt ein bereits gesendetes Ereignis erneut zu (als neue Zustellung im Protokoll)
+func (s *WebhookService) Redeliver(endpoint *model.WebhookEndpoint, deliveryID string) (*model.WebhookDelivery, error) {
+	original, err := s.deliveryRepo.FindByID(deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if original.EndpointID != endpoint.ID {
+		return nil, repository.ErrWebhookDeliveryNotFound
+	}
+
+	originalID := original.ID
+	delivery := &model.WebhookDelivery{
+		EndpointID:   endpoint.ID,
+		EventID:      original.EventID,
+		EventType:    original.EventType,
+		Payload:      original.Payload,
+		RedeliveryOf: &originalID,
+	}
+	if err := s.createClaimed(delivery); err != nil {
+		return nil, err
+	}
+	s.attempt(endpoint, delivery)
+	return delivery, nil
+}
+
+// ProcessDueDeliveries sendet fällige Wiederholungen; wird vom Background-Worker aufgerufen
+func (s *WebhookService) ProcessDueDeliveries() (int, error) {
+	processed := 0
+	endpoints := map[primitive.ObjectID]*model.WebhookEndpoint{}
+
+	for processed < webhookBatchSize {
+		delivery, err := s.deliveryRepo.ClaimDue(time.Now(), webhookClaimLease)
+		if err != nil {
+			return processed, err
+		}
+		if delivery == nil {
+			break
+		}
+		processed++
+
+		endpoint, ok := endpoints[delivery.EndpointID]
+		if !ok {
+			endpoint, err = s.endpointRepo.FindByID(delivery.EndpointID.Hex())
+			if err != nil {
+				// Endpunkt wurde gelöscht: Zustellung endgültig abbrechen
+				delivery.Attempts = model.WebhookMaxAttempts - 1
+				delivery.RecordAttempt(time.Now(), 0, "Endpunkt nicht mehr vorhanden")
+				_ = s.deliveryRepo.SaveAttempt(delivery)
+				continue
+			}
+			endpoints[delivery.EndpointID] = endpoint
+		}
+		s.attempt(endpoint, delivery)
+	}
+	return processed, nil
+}
+
+// createClaimed legt eine Zustellung an, die bereits für den sofortigen Versand reserviert ist
+func (s *WebhookService) createClaimed(delivery *model.WebhookDelivery) error {
+	lease := time.Now().Add(webhookClaimLease)
+	delivery.NextAttemptAt = &lease
+	return s.deliveryRepo.Create(delivery)
+}
+
+// attempt führt einen Zustellversuch aus und speichert das Ergebnis
+func (s *WebhookService) attempt(endpoint *model.WebhookEndpoint, delivery *model.WebhookDelivery) {
+	start := time.Now()
+	status, body, err := s.send(endpoint, delivery)
+
+	errMessage := ""
+	if err != nil {
+		errMessage = err.Error()
+	}
+	delivery.ResponseBody = body
+	delivery.DurationMs = time.Since(start).Milliseconds()
+	delivery.RecordAttempt(time.Now(), status, errMessage)
+
+	if err := s.deliveryRepo.SaveAttempt(delivery); err != nil {
+		log.Printf("Fehler beim Speichern der Webhook-Zustellung %s: %v", delivery.ID.Hex(), err)
+	}
+	if delivery.Status == model.WebhookDeliveryFailed {
+		log.Printf("Webhook %s an %s nach %d Versuchen endgültig fehlgeschlagen", delivery.EventType, endpoint.Name, delivery.Attempts)
+	}
+}
+
+// send schickt den signierten Payload an den Endpunkt
+func (s *WebhookService) send(endpoint *model.WebhookEndpoint, delivery *model.WebhookDelivery) (int, string, error) {
+	secret, err := utils.DecryptString(endpoint.Secret)
+	if err != nil {
+		return 0, "", fmt.Errorf("secret konnte nicht entschlüsselt werden: %w", err)
+	}
+
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequest(http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, "", err
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "PeopleFlow-Webhooks/1.0")
+	req.Header.Set(model.WebhookHeaderEvent, string(delivery.EventType))
+	req.Header.Set(model.WebhookHeaderDelivery, delivery.ID.Hex())
+	req.Header.Set(model.WebhookHeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(model.WebhookHeaderSignature, model.SignWebhookPayload(secret, timestamp, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	responseBody, _ := io.ReadAll(io.LimitReader(resp.Body, webhookResponseLimit))
+	return resp.StatusCode, string(responseBody), nil
+}
@@ -0,0 +1,275 @@
+package service
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"PeopleFlow/backend/model"
+	"PeopleFlow/backend/repository"
+	"PeopleFlow/backend/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// fakeWebhookEndpoints hält Endpunkte im Speicher; jeder Endpunkt abonniert alle Ereignisse
+type fakeWebhookEndpoints struct {
+	endpoints map[string]*model.WebhookEndpoint
+}
+
+func (f *fakeWebhookEndpoints) Create(endpoint *model.WebhookEndpoint) error {
+	endpoint.ID = primitive.NewObjectID()
+	f.endpoints[endpoint.ID.Hex()] = endpoint
+	return nil
+}
+
+func (f *fakeWebhookEndpoints) FindByID(id string) (*model.WebhookEndpoint, error) {
+	if endpoint, ok := f.endpoints[id]; ok {
+		return endpoint, nil
+	}
+	return nil, repository.ErrWebhookNotFound
+}
+
+func (f *fakeWebhookEndpoints) FindAll() ([]*model.WebhookEndpoint, error) {
+	endpoints := make([]*model.WebhookEndpoint, 0, len(f.endpoints))
+	for _, endpoint := range f.endpoints {
+		endpoints = append(endpoints, endpoint)
+	}
+	return endpoints, nil
+}
+
+func (f *fakeWebhookEndpoints) FindSubscribers(model.ActivityType) ([]*model.WebhookEndpoint, error) {
+	return f.FindAll()
+}
+
+func (f *fakeWebhookEndpoints) Update(endpoint *model.WebhookEndpoint) error {
+	f.endpoints[endpoint.ID.Hex()] = endpoint
+	return nil
+}
+
+func (f *fakeWebhookEndpoints) Delete(id string) error {
+	delete(f.endpoints, id)
+	return nil
+}
+
+// fakeWebhookDeliveries speichert Kopien der Zustellungen wie die Datenbank und reserviert
+// fällige Zustellungen wie WebhookDeliveryRepository.ClaimDue
+type fakeWebhookDeliveries struct {
+	deliveries map[primitive.ObjectID]model.WebhookDelivery
+}
+
+func (f *fakeWebhookDeliveries) Create(delivery *model.WebhookDelivery) error {
+	delivery.ID = primitive.NewObjectID()
+	delivery.CreatedAt = time.Now()
+	if delivery.Status == "" {
+		delivery.Status = model.WebhookDeliveryPending
+	}
+	f.deliveries[delivery.ID] = *delivery
+	return nil
+}
+
+func (f *fakeWebhookDeliveries) FindByID(id string) (*model.WebhookDelivery, error) {
+	objectID, _ := primitive.ObjectIDFromHex(id)
+	delivery, ok := f.deliveries[objectID]
+	if !ok {
+		return nil, repository.ErrWebhookDeliveryNotFound
+	}
+	return &delivery, nil
+}
+
+func (f *fakeWebhookDeliveries) FindByEndpoint(primitive.ObjectID, int64, int64) ([]*model.WebhookDelivery, int64, error) {
+	return nil, 0, nil
+}
+
+func (f *fakeWebhookDeliveries) ClaimDue(now time.Time, lease time.Duration) (*model.WebhookDelivery, error) {
+	for id, delivery := range f.deliveries {
+		if delivery.Status != model.WebhookDeliveryPending || delivery.NextAttemptAt == nil || delivery.NextAttemptAt.After(now) {
+			continue
+		}
+		until := now.Add(lease)
+		delivery.NextAttemptAt = &until
+		f.deliveries[id] = delivery
+		return &delivery, nil
+	}
+	return nil, nil
+}
+
+func (f *fakeWebhookDeliveries) SaveAttempt(delivery *model.WebhookDelivery) error {
+	f.deliveries[delivery.ID] = *delivery
+	return nil
+}
+
+func (f *fakeWebhookDeliveries) DeleteByEndpoint(primitive.ObjectID) error { return nil }
+
+// only gibt die einzige gespeicherte Zustellung zurück
+func (f *fakeWebhookDeliveries) only(t *testing.T) model.WebhookDelivery {
+	require.Len(t, f.deliveries, 1)
+	for _, delivery := range f.deliveries {
+		return delivery
+	}
+	return model.WebhookDelivery{}
+}
+
+// makeDue setzt alle wartenden Zustellungen auf fällig, als wäre die Wartezeit verstrichen
+func (f *fakeWebhookDeliveries) makeDue() {
+	past := time.Now().Add(-time.Second)
+	for id, delivery := range f.deliveries {
+		if delivery.NextAttemptAt != nil {
+			delivery.NextAttemptAt = &past
+			f.deliveries[id] = delivery
+		}
+	}
+}
+
+// webhookReceiver ist ein Empfänger, der Anfragen aufzeichnet und die vorgegebenen Status liefert
+type webhookReceiver struct {
+	mu       sync.Mutex
+	statuses []int // je Anfrage; danach gilt der letzte Wert
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	body, _ := io.ReadAll(req.Body)
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+
+	status := r.statuses[len(r.statuses)-1]
+	if len(r.requests) <= len(r.statuses) {
+		status = r.statuses[len(r.requests)-1]
+	}
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(http.StatusText(status)))
+}
+
+func newWebhookTestService(t *testing.T, statuses ...int) (*WebhookService, *webhookReceiver, *fakeWebhookDeliveries, string) {
+	receiver := &webhookReceiver{statuses: statuses}
+	server := httptest.NewServer(receiver)
+	t.Cleanup(server.Close)
+
+	const secret = "webhook-secret"
+	encrypted, err := utils.EncryptString(secret)
+	require.NoError(t, err)
+
+	endpoints := &fakeWebhookEndpoints{endpoints: map[string]*model.WebhookEndpoint{}}
+	require.NoError(t, endpoints.Create(&model.WebhookEndpoint{Name: "Lohnbuchhaltung", URL: server.URL, Secret: encrypted, Active: true}))
+
+	deliveries := &fakeWebhookDeliveries{deliveries: map[primitive.ObjectID]model.WebhookDelivery{}}
+	s := &WebhookService{endpointRepo: endpoints, deliveryRepo: deliveries, client: server.Client()}
+	return s, receiver, deliveries, secret
+}
+
+func webhookTestActivity() *model.Activity {
+	return &model.Activity{
+		ID:          primitive.NewObjectID(),
+		Type:        model.ActivityTypeEmployeeAdded,
+		TargetType:  "employee",
+		TargetName:  "Anna Schmidt",
+		Description: "Neuer Mitarbeiter angelegt",
+		Timestamp:   time.Now(),
+	}
+}
+
+func TestWebhookService_SignsDelivery(t *testing.T) {
+	s, receiver, deliveries, secret := newWebhookTestService(t, http.StatusNoContent)
+
+	s.enqueue(webhookTestActivity())
+
+	require.Len(t, receiver.requests, 1)
+	req, body := receiver.requests[0], receiver.bodies[0]
+	timestamp, err := strconv.ParseInt(req.Header.Get(model.WebhookHeaderTimestamp), 10, 64)
+	require.NoError(t, err)
+	assert.True(t, model.VerifyWebhookSignature(secret, timestamp, body, req.Header.Get(model.WebhookHeaderSignature)),
+		"die Signatur deckt Zeitstempel und Body ab")
+	assert.False(t, model.VerifyWebhookSignature("anderes-secret", timestamp, body, req.Header.Get(model.WebhookHeaderSignature)))
+	assert.Equal(t, string(model.ActivityTypeEmployeeAdded), req.Header.Get(model.WebhookHeaderEvent))
+
+	delivery := deliveries.only(t)
+	assert.Equal(t, delivery.ID.Hex(), req.Header.Get(model.WebhookHeaderDelivery))
+	assert.Equal(t, delivery.Payload, string(body))
+	assert.Equal(t, model.WebhookDeliverySuccess, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Nil(t, delivery.NextAttemptAt)
+}
+
+func TestWebhookService_RetriesAfterServerError(t *testing.T) {
+	s, receiver, deliveries, _ := newWebhookTestService(t, http.StatusServiceUnavailable, http.StatusOK)
+
+	before := time.Now()
+	s.enqueue(webhookTestActivity())
+
+	delivery := deliveries.only(t)
+	assert.Equal(t, model.WebhookDeliveryPending, delivery.Status)
+	assert.Equal(t, http.StatusServiceUnavailable, delivery.ResponseStatus)
+	require.NotNil(t, delivery.NextAttemptAt)
+	assert.WithinDuration(t, before.Add(model.WebhookRetryDelay(1)), *delivery.NextAttemptAt, 5*time.Second)
+
+	// Vor Ablauf der Wartezeit wird nichts erneut gesendet
+	processed, err := s.ProcessDueDeliveries()
+	require.NoError(t, err)
+	assert.Zero(t, processed)
+	assert.Len(t, receiver.requests, 1)
+
+	deliveries.makeDue()
+	processed, err = s.ProcessDueDeliveries()
+	require.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	require.Len(t, receiver.requests, 2)
+	assert.Equal(t, receiver.bodies[0], receiver.bodies[1], "die Wiederholung sendet denselben Payload")
+
+	delivery = deliveries.only(t)
+	assert.Equal(t, model.WebhookDeliverySuccess, delivery.Status)
+	assert.Equal(t, 2, delivery.Attempts)
+}
+
+func TestWebhookService_DeadLetterAfterMaxAttempts(t *testing.T) {
+	s, receiver, deliveries, _ := newWebhookTestService(t, http.StatusInternalServerError)
+
+	s.enqueue(webhookTestActivity())
+	for attempt := 2; attempt <= model.WebhookMaxAttempts; attempt++ {
+		deliveries.makeDue()
+		processed, err := s.ProcessDueDeliveries()
+		require.NoError(t, err)
+		require.Equal(t, 1, processed, "Versuch %d", attempt)
+	}
+
+	delivery := deliveries.only(t)
+	assert.Equal(t, model.WebhookDeliveryFailed, delivery.Status)
+	assert.Equal(t, model.WebhookMaxAttempts, delivery.Attempts)
+	assert.Equal(t, http.StatusInternalServerError, delivery.ResponseStatus)
+	assert.Nil(t, delivery.NextAttemptAt)
+
+	// Endgültig fehlgeschlagene Zustellungen werden nicht mehr reserviert
+	deliveries.makeDue()
+	processed, err := s.ProcessDueDeliveries()
+	require.NoError(t, err)
+	assert.Zero(t, processed)
+	assert.Len(t, receiver.requests, model.WebhookMaxAttempts)
+}
+
+func TestWebhookService_DropsDeliveriesOfDeletedEndpoints(t *testing.T) {
+	s, receiver, deliveries, _ := newWebhookTestService(t, http.StatusServiceUnavailable)
+
+	s.enqueue(webhookTestActivity())
+	endpoint := deliveries.only(t).EndpointID
+	require.NoError(t, s.endpointRepo.Delete(endpoint.Hex()))
+
+	deliveries.makeDue()
+	processed, err := s.ProcessDueDeliveries()
+	require.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	assert.Len(t, receiver.requests, 1, "ohne Endpunkt wird nichts mehr gesendet")
+
+	delivery := deliveries.only(t)
+	assert.Equal(t, model.WebhookDeliveryFailed, delivery.Status)
+	assert.Equal(t, "Endpunkt nicht mehr vorhanden", delivery.Error)
+}
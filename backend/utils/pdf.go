@@ -0,0 +1,305 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Seitengröße A4 in Punkt (1/72 Zoll)
+const (
+	pdfA4Width  = 595.28
+	pdfA4Height = 841.89
+)
+
+// PDFDocument erzeugt einfache PDF-Dateien mit Text, Linien und Flächen in den
+// Standardschriften Helvetica und Helvetica-Bold (WinAnsi-Kodierung, deckt Umlaute und € ab).
+// Koordinaten werden in Punkt von der linken oberen Ecke der Seite angegeben.
+type PDFDocument struct {
+	width  float64
+	height float64
+	pages  []*bytes.Buffer
+	page   int // Index der Seite, auf die gezeichnet wird
+}
+
+// NewPDFDocument erstellt ein leeres A4-Dokument im Hoch- oder Querformat
+func NewPDFDocument(landscape bool) *PDFDocument {
+	if landscape {
+		return &PDFDocument{width: pdfA4Height, height: pdfA4Width}
+	}
+	return &PDFDocument{width: pdfA4Width, height: pdfA4Height}
+}
+
+// Size gibt Breite und Höhe einer Seite zurück
+func (d *PDFDocument) Size() (float64, float64) {
+	return d.width, d.height
+}
+
+// PageCount gibt die Anzahl der bisher angelegten Seiten zurück
+func (d *PDFDocument) PageCount() int {
+	return len(d.pages)
+}
+
+// AddPage beginnt eine neue Seite; alle folgenden Zeichenbefehle landen auf ihr
+func (d *PDFDocument) AddPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.page = len(d.pages) - 1
+}
+
+// SetPage wählt eine bereits angelegte Seite (ab 0) für die folgenden Zeichenbefehle,
+// z.B. um nachträglich Seitenzahlen einzutragen
+func (d *PDFDocument) SetPage(index int) {
+	if index >= 0 && index < len(d.pages) {
+		d.page = index
+	}
+}
+
+func (d *PDFDocument) current() *bytes.Buffer {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+	return d.pages[d.page]
+}
+
+// Text schreibt eine Zeile Text; y ist die Grundlinie
+func (d *PDFDocument) Text(x, y, size float64, bold bool, text string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(d.current(), "BT /%s %s Tf %s %s Td (%s) Tj ET\n",
+		font, pdfNumber(size), pdfNumber(x), pdfNumber(d.height-y), pdfEscape(text))
+}
+
+// TextRight schreibt eine Zeile Text rechtsbündig an der Position x
+func (d *PDFDocument) TextRight(x, y, size float64, bold bool, text string) {
+	d.Text(x-PDFTextWidth(text, size, bold), y, size, bold, text)
+}
+
+// Line zeichnet eine Linie der angegebenen Stärke
+func (d *PDFDocument) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(d.current(), "%s w %s %s m %s %s l S\n",
+		pdfNumber(width), pdfNumber(x1), pdfNumber(d.height-y1), pdfNumber(x2), pdfNumber(d.height-y2))
+}
+
+// FillRect füllt ein Rechteck mit einem Grauton (0 = schwarz, 1 = weiß)
+func (d *PDFDocument) FillRect(x, y, width, height, gray float64) {
+	fmt.Fprintf(d.current(), "q %s g %s %s %s %s re f Q\n",
+		pdfNumber(gray), pdfNumber(x), pdfNumber(d.height-y-height), pdfNumber(width), pdfNumber(height))
+}
+
+// Bytes gibt das fertige Dokument zurück. Ein Dokument ohne Seiten erhält eine leere Seite.
+func (d *PDFDocument) Bytes() []byte {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+
+	var out bytes.Buffer
+	offsets := []int{0}
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets)-1, body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// 1: Katalog, 2: Seitenbaum, 3/4: Schriften, danach je Seite Seitenobjekt und Inhalt
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	for i, page := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pdfNumber(d.width), pdfNumber(d.height), 6+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets))
+	for _, offset := range offsets[1:] {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets), xref)
+	return out.Bytes()
+}
+
+// pdfNumber formatiert eine Koordinate mit höchstens zwei Nachkommastellen
+func pdfNumber(value float64) string {
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+}
+
+// pdfWinAnsi enthält die Zeichen der WinAnsi-Kodierung außerhalb von Latin-1
+var pdfWinAnsi = map[rune]byte{
+	'€': 0x80, '‚': 0x82, '„': 0x84, '…': 0x85, '‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94,
+	'•': 0x95, '–': 0x96, '—': 0x97, '™': 0x99,
+}
+
+// pdfEscape kodiert Text für einen PDF-String in WinAnsi; nicht darstellbare Zeichen werden zu "?"
+func pdfEscape(text string) string {
+	var builder strings.Builder
+	for _, r := range text {
+		var b byte
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			builder.WriteByte('\\')
+			b = byte(r)
+		case r == '\n' || r == '\r' || r == '\t':
+			b = ' '
+		case r >= 0x20 && r < 0x7f, r >= 0xa0 && r <= 0xff:
+			b = byte(r)
+		default:
+			var ok bool
+			if b, ok = pdfWinAnsi[r]; !ok {
+				b = '?'
+			}
+		}
+		builder.WriteByte(b)
+	}
+	return builder.String()
+}
+
+// pdfHelveticaWidths sind die Zeichenbreiten von Helvetica für ASCII 32–126 (Tausendstel der Schriftgröße)
+var pdfHelveticaWidths = [...]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+// PDFTextWidth schätzt die Breite eines Textes in Punkt. Für Helvetica-Bold wird ein
+// Zuschlag auf die Breiten der normalen Schrift gerechnet.
+func PDFTextWidth(text string, size float64, bold bool) float64 {
+	total := 0
+	for _, r := range text {
+		switch {
+		case r >= 32 && r <= 126:
+			total += pdfHelveticaWidths[r-32]
+		case strings.ContainsRune("ÄÖÜ", r):
+			total += 722
+		case r == 'ß':
+			total += 611
+		default:
+			total += 556
+		}
+	}
+	width := float64(total) * size / 1000
+	if bold {
+		width *= 1.08
+	}
+	return width
+}
+
+// FitPDFText kürzt einen Text mit "…" so, dass er in die angegebene Breite passt
+func FitPDFText(text string, size float64, bold bool, maxWidth float64) string {
+	if PDFTextWidth(text, size, bold) <= maxWidth {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		candidate := strings.TrimRight(string(runes), " ") + "…"
+		if PDFTextWidth(candidate, size, bold) <= maxWidth {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// PDFTable beschreibt eine einfache Tabelle für WritePDFTable
+type PDFTable struct {
+	Title    string
+	Subtitle string
+	Header   []string
+	Widths   []float64 // relative Spaltenbreiten; leer = gleich breit
+	Right    []bool    // rechtsbündige Spalten, z.B. für Zahlen
+	Rows     [][]string
+	Footer   []string // optionale Summenzeile
+}
+
+// Layout der Tabellen-PDFs
+const (
+	pdfTableMargin   = 36.0
+	pdfTableFontSize = 8.0
+	pdfTableRowSize  = 14.0
+)
+
+// WritePDFTable erzeugt eine Tabelle im A4-Querformat. Die Kopfzeile wird auf jeder Seite
+// wiederholt, zu lange Zellen werden gekürzt und jede Seite erhält eine Seitenzahl.
+func WritePDFTable(table PDFTable) []byte {
+	doc := NewPDFDocument(true)
+	pageWidth, pageHeight := doc.Size()
+	usable := pageWidth - 2*pdfTableMargin
+
+	// Spaltenpositionen aus den relativen Breiten berechnen
+	widths := make([]float64, len(table.Header))
+	sum := 0.0
+	for i := range widths {
+		widths[i] = 1
+		if i < len(table.Widths) && table.Widths[i] > 0 {
+			widths[i] = table.Widths[i]
+		}
+		sum += widths[i]
+	}
+	for i := range widths {
+		widths[i] = widths[i] / sum * usable
+	}
+	right := func(i int) bool { return i < len(table.Right) && table.Right[i] }
+
+	drawRow := func(y float64, cells []string, bold bool) {
+		x := pdfTableMargin
+		for i, width := range widths {
+			if i < len(cells) {
+				text := FitPDFText(cells[i], pdfTableFontSize, bold, width-6)
+				if right(i) {
+					doc.TextRight(x+width-3, y+pdfTableRowSize-4, pdfTableFontSize, bold, text)
+				} else {
+					doc.Text(x+3, y+pdfTableRowSize-4, pdfTableFontSize, bold, text)
+				}
+			}
+			x += width
+		}
+	}
+
+	headerTop := pdfTableMargin + 34
+	rowsPerPage := int((pageHeight - headerTop - pdfTableRowSize - pdfTableMargin - 2*pdfTableRowSize) / pdfTableRowSize)
+	newPage := func() float64 {
+		doc.AddPage()
+		doc.Text(pdfTableMargin, pdfTableMargin+10, 14, true, table.Title)
+		if table.Subtitle != "" {
+			doc.Text(pdfTableMargin, pdfTableMargin+24, 9, false, table.Subtitle)
+		}
+		doc.FillRect(pdfTableMargin, headerTop, usable, pdfTableRowSize, 0.9)
+		drawRow(headerTop, table.Header, true)
+		return headerTop + pdfTableRowSize
+	}
+
+	y := newPage()
+	for i, row := range table.Rows {
+		if i > 0 && i%rowsPerPage == 0 {
+			y = newPage()
+		}
+		drawRow(y, row, false)
+		doc.Line(pdfTableMargin, y+pdfTableRowSize, pdfTableMargin+usable, y+pdfTableRowSize, 0.25)
+		y += pdfTableRowSize
+	}
+	if len(table.Footer) > 0 {
+		doc.Line(pdfTableMargin, y, pdfTableMargin+usable, y, 0.75)
+		drawRow(y, table.Footer, true)
+	}
+
+	// Seitenzahlen erst zum Schluss, wenn die Gesamtzahl feststeht
+	pages := doc.PageCount()
+	for i := 0; i < pages; i++ {
+		doc.SetPage(i)
+		doc.TextRight(pageWidth-pdfTableMargin, pageHeight-pdfTableMargin+12, pdfTableFontSize, false, fmt.Sprintf("Seite %d von %d", i+1, pages))
+	}
+	return doc.Bytes()
+}
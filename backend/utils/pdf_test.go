@@ -0,0 +1,94 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// checkPDFXref prüft, ob jeder Eintrag der Querverweistabelle auf sein Objekt zeigt
+func checkPDFXref(t *testing.T, content []byte) int {
+	t.Helper()
+	require.True(t, bytes.HasPrefix(content, []byte("%PDF-1.4\n")))
+	require.True(t, bytes.HasSuffix(content, []byte("%%EOF\n")))
+
+	match := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(content)
+	require.NotNil(t, match)
+	xref, err := strconv.Atoi(string(match[1]))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(content[xref:], []byte("xref\n")))
+
+	lines := strings.Split(string(content[xref:]), "\n")
+	count, err := strconv.Atoi(strings.Fields(lines[1])[1])
+	require.NoError(t, err)
+	for i := 1; i < count; i++ {
+		offset, err := strconv.Atoi(strings.Fields(lines[2+i])[0])
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(content[offset:], []byte(fmt.Sprintf("%d 0 obj\n", i))), "Objekt %d", i)
+	}
+	return bytes.Count(content, []byte("/Type /Page "))
+}
+
+func TestPDFDocument(t *testing.T) {
+	doc := NewPDFDocument(false)
+	doc.AddPage()
+	doc.Text(36, 50, 12, true, "Stundenzettel (März) – 100 €")
+	doc.Line(36, 60, 200, 60, 0.5)
+	doc.AddPage()
+	doc.FillRect(36, 36, 100, 20, 0.9)
+	doc.SetPage(0)
+	doc.TextRight(559, 800, 8, false, "Seite 1")
+
+	content := doc.Bytes()
+	assert.Equal(t, 2, checkPDFXref(t, content))
+	assert.Contains(t, string(content), "(Stundenzettel \\(M\xe4rz\\) \x96 100 \x80) Tj")
+	assert.Contains(t, string(content), "/F2 12 Tf 36 791.89 Td")
+	assert.Contains(t, string(content), "/MediaBox [0 0 595.28 841.89]")
+
+	assert.Equal(t, 1, checkPDFXref(t, NewPDFDocument(true).Bytes()), "leeres Dokument erhält eine Seite")
+}
+
+func TestPDFEscape(t *testing.T) {
+	assert.Equal(t, `a\(b\)\\c`, pdfEscape(`a(b)\c`))
+	assert.Equal(t, "\xc4\xd6\xdc\xdf \x84x\x93", pdfEscape("ÄÖÜß „x“"))
+	assert.Equal(t, "? ?", pdfEscape("✓\n漢"))
+}
+
+func TestFitPDFText(t *testing.T) {
+	assert.InDelta(t, 5.56, PDFTextWidth("a", 10, false), 0.001)
+	assert.Greater(t, PDFTextWidth("a", 10, true), PDFTextWidth("a", 10, false))
+
+	assert.Equal(t, "kurz", FitPDFText("kurz", 8, false, 100))
+	fitted := FitPDFText("Eine sehr lange Projektbezeichnung", 8, false, 60)
+	assert.True(t, strings.HasSuffix(fitted, "…"))
+	assert.LessOrEqual(t, PDFTextWidth(fitted, 8, false), 60.0)
+	assert.Equal(t, "", FitPDFText("Text", 8, false, 1))
+}
+
+func TestWritePDFTable(t *testing.T) {
+	rows := make([][]string, 80)
+	for i := range rows {
+		rows[i] = []string{fmt.Sprintf("Zeile %d", i+1), "1,5"}
+	}
+	content := WritePDFTable(PDFTable{
+		Title:  "Zeiterfassung",
+		Header: []string{"Name", "Stunden"},
+		Widths: []float64{3, 1},
+		Right:  []bool{false, true},
+		Rows:   rows,
+		Footer: []string{"Summe", "120"},
+	})
+
+	pages := checkPDFXref(t, content)
+	assert.Equal(t, 3, pages)
+	assert.Equal(t, pages, bytes.Count(content, []byte("(Stunden) Tj")), "Kopfzeile auf jeder Seite")
+	assert.Contains(t, string(content), "(Seite 3 von 3) Tj")
+	assert.Contains(t, string(content), "(Zeile 80) Tj")
+	assert.Contains(t, string(content), "(Summe) Tj")
+}
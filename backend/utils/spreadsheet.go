@@ -0,0 +1,590 @@
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidSpreadsheet wird zurückgegeben, wenn eine Tabellendatei nicht gelesen werden kann
+var ErrInvalidSpreadsheet = errors.New("invalid spreadsheet")
+
+// Grenzen beim Lesen von XLSX-Dateien. Zeilen und Spalten entsprechen den Grenzen von Excel;
+// die übrigen Werte schützen vor stark komprimierten oder dünn besetzten Dateien, die beim
+// Entpacken bzw. Auffüllen leerer Zellen sehr viel Speicher belegen würden.
+const (
+	xlsxMaxRows     = 1048576
+	xlsxMaxColumns  = 16384
+	xlsxMaxCells    = 5000000
+	xlsxMaxPartSize = 64 << 20
+)
+
+// ReadCSVRows liest eine CSV-Datei. Das Trennzeichen (Semikolon, Komma oder Tabulator) wird
+// anhand der ersten Zeile erkannt, ein UTF-8-BOM am Anfang wird entfernt.
+func ReadCSVRows(content []byte) ([][]string, error) {
+	content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))
+
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.Comma = detectCSVDelimiter(content)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSpreadsheet, err)
+	}
+	for _, row := range rows {
+		for i, value := range row {
+			row[i] = unescapeSpreadsheetFormula(value)
+		}
+	}
+	return rows, nil
+}
+
+// isSpreadsheetFormula prüft, ob Excel oder LibreOffice einen Text als Formel auswerten würden.
+// Vorzeichenbehaftete Zahlen wie "-5,5" bleiben Zahlen.
+func isSpreadsheetFormula(value string) bool {
+	if value == "" || !strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return false
+	}
+	if value[0] == '+' || value[0] == '-' {
+		number := strings.ReplaceAll(strings.ReplaceAll(value[1:], ".", ""), ",", ".")
+		if _, err := strconv.ParseFloat(number, 64); err == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// escapeSpreadsheetFormula stellt Texten, die als Formel ausgewertet würden, ein Apostroph
+// voran, damit exportierte Daten keine Formeln einschleusen (CSV-Injection)
+func escapeSpreadsheetFormula(value string) string {
+	if isSpreadsheetFormula(value) {
+		return "'" + value
+	}
+	return value
+}
+
+// unescapeSpreadsheetFormula entfernt das von escapeSpreadsheetFormula vorangestellte Apostroph,
+// damit exportierte Dateien unverändert wieder importiert werden können
+func unescapeSpreadsheetFormula(value string) string {
+	if strings.HasPrefix(value, "'") && isSpreadsheetFormula(value[1:]) {
+		return value[1:]
+	}
+	return value
+}
+
+// detectCSVDelimiter wählt das in der ersten Zeile (außerhalb von Anführungszeichen) häufigste Trennzeichen
+func detectCSVDelimiter(content []byte) rune {
+	counts := map[rune]int{}
+	quoted := false
+	for _, r := range string(content) {
+		if r == '"' {
+			quoted = !quoted
+			continue
+		}
+		if quoted {
+			continue
+		}
+		if r == '\n' {
+			break
+		}
+		if r == ';' || r == ',' || r == '\t' {
+			counts[r]++
+		}
+	}
+
+	delimiter := ';'
+	for _, candidate := range []rune{',', '\t'} {
+		if counts[candidate] > counts[delimiter] {
+			delimiter = candidate
+		}
+	}
+	return delimiter
+}
+
+// ReadXLSXRows liest die Zellwerte des ersten Tabellenblatts einer XLSX-Datei.
+// Zahlen werden so zurückgegeben, wie sie gespeichert sind; Datumszellen erscheinen
+// daher als Excel-Seriennummer.
+func ReadXLSXRows(content []byte) ([][]string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSpreadsheet, err)
+	}
+
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+
+	var sharedStrings []string
+	if file, ok := files["xl/sharedStrings.xml"]; ok {
+		if sharedStrings, err = readXLSXSharedStrings(file); err != nil {
+			return nil, err
+		}
+	}
+
+	sheetPath, err := firstXLSXSheetPath(files)
+	if err != nil {
+		return nil, err
+	}
+	sheet, ok := files[sheetPath]
+	if !ok {
+		return nil, fmt.Errorf("%w: worksheet %s not found", ErrInvalidSpreadsheet, sheetPath)
+	}
+	return readXLSXSheet(sheet, sharedStrings)
+}
+
+type xlsxText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.Text
+	}
+	var builder strings.Builder
+	for _, run := range t.Runs {
+		builder.WriteString(run.Text)
+	}
+	return builder.String()
+}
+
+func decodeXLSXPart(file *zip.File, target interface{}) error {
+	reader, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSpreadsheet, err)
+	}
+	defer reader.Close()
+
+	limited := &xlsxLimitedReader{reader: reader, remaining: xlsxMaxPartSize}
+	if err := xml.NewDecoder(limited).Decode(target); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidSpreadsheet, file.Name, err)
+	}
+	return nil
+}
+
+// errXLSXPartTooLarge wird zurückgegeben, wenn ein Teil der XLSX-Datei entpackt zu groß ist
+var errXLSXPartTooLarge = fmt.Errorf("part exceeds %d MB", xlsxMaxPartSize>>20)
+
+// xlsxLimitedReader liest höchstens remaining Bytes und meldet danach einen Fehler,
+// statt wie io.LimitReader ein stilles Dateiende
+type xlsxLimitedReader struct {
+	reader    io.Reader
+	remaining int64
+}
+
+func (r *xlsxLimitedReader) Read(p []byte) (int, error) {
+	if r.remaining <= 0 {
+		return 0, errXLSXPartTooLarge
+	}
+	if int64(len(p)) > r.remaining {
+		p = p[:r.remaining]
+	}
+	n, err := r.reader.Read(p)
+	r.remaining -= int64(n)
+	return n, err
+}
+
+func readXLSXSharedStrings(file *zip.File) ([]string, error) {
+	var sst struct {
+		Items []xlsxText `xml:"si"`
+	}
+	if err := decodeXLSXPart(file, &sst); err != nil {
+		return nil, err
+	}
+	values := make([]string, len(sst.Items))
+	for i, item := range sst.Items {
+		values[i] = item.String()
+	}
+	return values, nil
+}
+
+// firstXLSXSheetPath ermittelt über workbook.xml und dessen Beziehungen den Pfad des ersten Blatts
+func firstXLSXSheetPath(files map[string]*zip.File) (string, error) {
+	const fallback = "xl/worksheets/sheet1.xml"
+
+	workbookFile, ok := files["xl/workbook.xml"]
+	if !ok {
+		return fallback, nil
+	}
+	var workbook struct {
+		Sheets []struct {
+			RelationID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := decodeXLSXPart(workbookFile, &workbook); err != nil {
+		return "", err
+	}
+	relsFile, ok := files["xl/_rels/workbook.xml.rels"]
+	if len(workbook.Sheets) == 0 || !ok {
+		return fallback, nil
+	}
+
+	var rels struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if err := decodeXLSXPart(relsFile, &rels); err != nil {
+		return "", err
+	}
+	for _, rel := range rels.Relationships {
+		if rel.ID != workbook.Sheets[0].RelationID {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/"), nil
+		}
+		return path.Join("xl", rel.Target), nil
+	}
+	return fallback, nil
+}
+
+func readXLSXSheet(file *zip.File, sharedStrings []string) ([][]string, error) {
+	var worksheet struct {
+		Rows []struct {
+			Index int `xml:"r,attr"`
+			Cells []struct {
+				Ref       string   `xml:"r,attr"`
+				Type      string   `xml:"t,attr"`
+				Value     string   `xml:"v"`
+				InlineStr xlsxText `xml:"is"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	if err := decodeXLSXPart(file, &worksheet); err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	cells := 0
+	for _, row := range worksheet.Rows {
+		rowIndex := len(rows)
+		if row.Index > 0 {
+			rowIndex = row.Index - 1
+		}
+		if rowIndex >= xlsxMaxRows {
+			return nil, fmt.Errorf("%w: more than %d rows", ErrInvalidSpreadsheet, xlsxMaxRows)
+		}
+		for len(rows) <= rowIndex {
+			rows = append(rows, nil)
+		}
+
+		var values []string
+		for _, cell := range row.Cells {
+			column := len(values)
+			if cell.Ref != "" {
+				parsed, err := xlsxColumnIndex(cell.Ref)
+				if err != nil {
+					return nil, err
+				}
+				column = parsed
+			}
+			if column >= xlsxMaxColumns {
+				return nil, fmt.Errorf("%w: more than %d columns", ErrInvalidSpreadsheet, xlsxMaxColumns)
+			}
+
+			value := cell.Value
+			switch cell.Type {
+			case "s":
+				index, err := strconv.Atoi(cell.Value)
+				if err != nil || index < 0 || index >= len(sharedStrings) {
+					return nil, fmt.Errorf("%w: invalid shared string in cell %s", ErrInvalidSpreadsheet, cell.Ref)
+				}
+				value = sharedStrings[index]
+			case "inlineStr":
+				value = cell.InlineStr.String()
+			case "b":
+				if value == "1" {
+					value = "TRUE"
+				} else {
+					value = "FALSE"
+				}
+			}
+
+			if column >= len(values) {
+				cells += column + 1 - len(values)
+				if cells > xlsxMaxCells {
+					return nil, fmt.Errorf("%w: more than %d cells", ErrInvalidSpreadsheet, xlsxMaxCells)
+				}
+			}
+			for len(values) <= column {
+				values = append(values, "")
+			}
+			values[column] = value
+		}
+		rows[rowIndex] = values
+	}
+	return rows, nil
+}
+
+// xlsxColumnIndex wandelt die Spaltenbuchstaben eines Zellbezugs (z.B. "AB12") in einen Index um
+func xlsxColumnIndex(ref string) (int, error) {
+	column := 0
+	letters := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		column = column*26 + int(r-'A'+1)
+		letters++
+		if column > xlsxMaxColumns {
+			return 0, fmt.Errorf("%w: column of cell %q exceeds %d columns", ErrInvalidSpreadsheet, ref, xlsxMaxColumns)
+		}
+	}
+	if letters == 0 {
+		return 0, fmt.Errorf("%w: invalid cell reference %q", ErrInvalidSpreadsheet, ref)
+	}
+	return column - 1, nil
+}
+
+// WriteCSV schreibt eine CSV-Datei mit Semikolon als Trennzeichen, wie es Excel in deutschen
+// Einstellungen erwartet. Ein UTF-8-BOM sorgt dafür, dass Umlaute korrekt angezeigt werden;
+// Anführungszeichen, Trennzeichen und Zeilenumbrüche in Werten werden maskiert, Werte, die
+// als Formel ausgewertet würden, erhalten ein vorangestelltes Apostroph.
+func WriteCSV(header []string, rows [][]string) ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString("\xef\xbb\xbf")
+
+	writer := csv.NewWriter(&buffer)
+	writer.Comma = ';'
+	writer.UseCRLF = true
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		escaped := make([]string, len(row))
+		for i, value := range row {
+			escaped[i] = escapeSpreadsheetFormula(value)
+		}
+		if err := writer.Write(escaped); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// XLSXSheet ist ein Tabellenblatt für WriteXLSX. Zellen können string, int, float64 oder
+// time.Time sein; nil und Nullzeiten bleiben leer.
+type XLSXSheet struct {
+	Name   string
+	Header []string
+	Rows   [][]interface{}
+}
+
+// xlsxEpoch ist der Bezugstag der Excel-Seriennummern
+var xlsxEpoch = time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)
+
+// Zellformate aus xlsxStyles
+const (
+	xlsxStyleDate     = 1
+	xlsxStyleDateTime = 2
+	xlsxStyleHeader   = 3
+	xlsxStyleText     = 4
+)
+
+const xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<numFmts count="2"><numFmt numFmtId="164" formatCode="dd.mm.yyyy"/><numFmt numFmtId="165" formatCode="dd.mm.yyyy hh:mm"/></numFmts>
+<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>
+<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>
+<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>
+<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>
+<cellXfs count="5"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/><xf numFmtId="165" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0" quotePrefix="1"/></cellXfs>
+</styleSheet>`
+
+// WriteXLSX erstellt eine XLSX-Datei mit den angegebenen Tabellenblättern. Die Kopfzeile wird fett
+// dargestellt, Datumswerte werden als Excel-Datum geschrieben.
+func WriteXLSX(sheets ...XLSXSheet) ([]byte, error) {
+	var contentTypes, workbook, workbookRels strings.Builder
+	contentTypes.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+		`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	workbook.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+		`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	workbookRels.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+		`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+
+	parts := map[string]string{}
+	usedNames := map[string]bool{}
+	for i, sheet := range sheets {
+		number := i + 1
+		name := xlsxSheetName(sheet.Name, number, usedNames)
+		fmt.Fprintf(&contentTypes, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, number)
+		fmt.Fprintf(&workbook, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xmlEscape(name), number, number)
+		fmt.Fprintf(&workbookRels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, number, number)
+
+		content, err := xlsxWorksheet(sheet)
+		if err != nil {
+			return nil, err
+		}
+		parts[fmt.Sprintf("xl/worksheets/sheet%d.xml", number)] = content
+	}
+	fmt.Fprintf(&workbookRels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, len(sheets)+1)
+	contentTypes.WriteString(`</Types>`)
+	workbook.WriteString(`</sheets></workbook>`)
+	workbookRels.WriteString(`</Relationships>`)
+
+	parts["[Content_Types].xml"] = contentTypes.String()
+	parts["_rels/.rels"] = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+		`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+	parts["xl/workbook.xml"] = workbook.String()
+	parts["xl/_rels/workbook.xml.rels"] = workbookRels.String()
+	parts["xl/styles.xml"] = xlsxStyles
+
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+	// [Content_Types].xml zuerst, die übrigen Teile in fester Reihenfolge
+	names := make([]string, 0, len(parts))
+	for name := range parts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		writer, err := archive.Create(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := writer.Write([]byte(parts[name])); err != nil {
+			return nil, err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// xlsxSheetName entfernt in Blattnamen unzulässige Zeichen und macht doppelte Namen eindeutig
+func xlsxSheetName(name string, number int, used map[string]bool) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '-'
+		}
+		return r
+	}, strings.TrimSpace(name))
+	if runes := []rune(name); len(runes) > 31 {
+		name = string(runes[:31])
+	}
+	if name == "" || used[strings.ToLower(name)] {
+		name = fmt.Sprintf("Tabelle%d", number)
+	}
+	used[strings.ToLower(name)] = true
+	return name
+}
+
+func xlsxWorksheet(sheet XLSXSheet) (string, error) {
+	var builder strings.Builder
+	builder.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+		`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+
+	rowNumber := 0
+	writeRow := func(values []interface{}, style int) error {
+		rowNumber++
+		fmt.Fprintf(&builder, `<row r="%d">`, rowNumber)
+		for column, value := range values {
+			ref := xlsxColumnName(column) + strconv.Itoa(rowNumber)
+			if err := writeXLSXCell(&builder, ref, value, style); err != nil {
+				return err
+			}
+		}
+		builder.WriteString(`</row>`)
+		return nil
+	}
+
+	if len(sheet.Header) > 0 {
+		header := make([]interface{}, len(sheet.Header))
+		for i, value := range sheet.Header {
+			header[i] = value
+		}
+		if err := writeRow(header, xlsxStyleHeader); err != nil {
+			return "", err
+		}
+	}
+	for _, row := range sheet.Rows {
+		if err := writeRow(row, 0); err != nil {
+			return "", err
+		}
+	}
+	builder.WriteString(`</sheetData></worksheet>`)
+	return builder.String(), nil
+}
+
+func writeXLSXCell(builder *strings.Builder, ref string, value interface{}, style int) error {
+	styleAttr := ""
+	if style != 0 {
+		styleAttr = fmt.Sprintf(` s="%d"`, style)
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		if v == "" {
+			return nil
+		}
+		// Formelähnliche Texte als reinen Text kennzeichnen, damit sie auch nach
+		// Bearbeiten der Zelle nicht ausgewertet werden
+		if style == 0 && isSpreadsheetFormula(v) {
+			styleAttr = fmt.Sprintf(` s="%d"`, xlsxStyleText)
+		}
+		fmt.Fprintf(builder, `<c r="%s" t="inlineStr"%s><is><t xml:space="preserve">%s</t></is></c>`, ref, styleAttr, xmlEscape(v))
+	case int:
+		fmt.Fprintf(builder, `<c r="%s"%s><v>%d</v></c>`, ref, styleAttr, v)
+	case float64:
+		fmt.Fprintf(builder, `<c r="%s"%s><v>%s</v></c>`, ref, styleAttr, strconv.FormatFloat(v, 'f', -1, 64))
+	case time.Time:
+		if v.IsZero() {
+			return nil
+		}
+		wall := time.Date(v.Year(), v.Month(), v.Day(), v.Hour(), v.Minute(), v.Second(), 0, time.UTC)
+		serial := wall.Sub(xlsxEpoch).Hours() / 24
+		cellStyle := xlsxStyleDate
+		if wall.Hour() != 0 || wall.Minute() != 0 || wall.Second() != 0 {
+			cellStyle = xlsxStyleDateTime
+		}
+		fmt.Fprintf(builder, `<c r="%s" s="%d"><v>%s</v></c>`, ref, cellStyle, strconv.FormatFloat(serial, 'f', -1, 64))
+	default:
+		return fmt.Errorf("%w: unsupported cell type %T in %s", ErrInvalidSpreadsheet, value, ref)
+	}
+	return nil
+}
+
+// xlsxColumnName wandelt einen Spaltenindex in Spaltenbuchstaben um (0 → A, 27 → AB)
+func xlsxColumnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+func xmlEscape(value string) string {
+	var buffer bytes.Buffer
+	_ = xml.EscapeText(&buffer, []byte(value))
+	return buffer.String()
+}
@@ -0,0 +1,203 @@
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCSVRows(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    [][]string
+	}{
+		{"Semikolon mit BOM", "\xef\xbb\xbfPNR;Name\n1;\"Meyer; Ben\"\n", [][]string{{"PNR", "Name"}, {"1", "Meyer; Ben"}}},
+		{"Komma", "PNR,Name,Ort\n1,Anna,\"Berlin, Mitte\"\n", [][]string{{"PNR", "Name", "Ort"}, {"1", "Anna", "Berlin, Mitte"}}},
+		{"Tabulator", "PNR\tName\n1\tAnna\n", [][]string{{"PNR", "Name"}, {"1", "Anna"}}},
+		{"unterschiedliche Spaltenzahl", "PNR;Name\n1\n", [][]string{{"PNR", "Name"}, {"1"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := ReadCSVRows([]byte(tt.content))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, rows)
+		})
+	}
+}
+
+func buildTestXLSX(t *testing.T, parts map[string]string) []byte {
+	t.Helper()
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	for name, content := range parts {
+		part, err := writer.Create(name)
+		require.NoError(t, err)
+		_, err = part.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	return buffer.Bytes()
+}
+
+func TestReadXLSXRows(t *testing.T) {
+	content := buildTestXLSX(t, map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+			<sheets><sheet name="Mitarbeiter" sheetId="1" r:id="rId3"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+			<Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/personal.xml"/></Relationships>`,
+		"xl/sharedStrings.xml": `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+			<si><t>PNR</t></si><si><t>Name</t></si><si><r><t>Anna </t></r><r><t>Schmidt</t></r></si></sst>`,
+		"xl/worksheets/personal.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+			<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="D1" t="inlineStr"><is><t>Aktiv</t></is></c></row>
+			<row r="3"><c r="A3"><v>1001</v></c><c r="B3" t="s"><v>2</v></c><c r="D3" t="b"><v>1</v></c></row>
+		</sheetData></worksheet>`,
+	})
+
+	rows, err := ReadXLSXRows(content)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"PNR", "Name", "", "Aktiv"},
+		nil,
+		{"1001", "Anna Schmidt", "", "TRUE"},
+	}, rows)
+}
+
+func TestReadXLSXRows_Invalid(t *testing.T) {
+	_, err := ReadXLSXRows([]byte("PNR;Name"))
+	assert.ErrorIs(t, err, ErrInvalidSpreadsheet)
+
+	_, err = ReadXLSXRows(buildTestXLSX(t, map[string]string{"xl/workbook.xml": "<workbook/>"}))
+	assert.ErrorIs(t, err, ErrInvalidSpreadsheet, "Tabellenblatt fehlt")
+}
+
+func TestReadXLSXRows_Limits(t *testing.T) {
+	sheet := func(rows string) []byte {
+		return buildTestXLSX(t, map[string]string{
+			"xl/worksheets/sheet1.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>` + rows + `</sheetData></worksheet>`,
+		})
+	}
+
+	tests := []struct {
+		name string
+		rows string
+	}{
+		{"zu viele Zeilen", `<row r="1048577"><c r="A1048577"><v>1</v></c></row>`},
+		{"zu viele Spalten", `<row r="1"><c r="XFE1"><v>1</v></c></row>`},
+		{"überlanger Spaltenbezug", `<row r="1"><c r="ZZZZZZZZZZZZZZZ1"><v>1</v></c></row>`},
+		{"zu viele Zellen", strings.Repeat(`<row><c r="XFD1"><v>1</v></c></row>`, 400)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadXLSXRows(sheet(tt.rows))
+			assert.ErrorIs(t, err, ErrInvalidSpreadsheet)
+		})
+	}
+
+	rows, err := ReadXLSXRows(sheet(`<row r="1048576"><c r="XFD1048576"><v>1</v></c></row>`))
+	require.NoError(t, err, "letzte Zelle von Excel ist erlaubt")
+	assert.Len(t, rows, xlsxMaxRows)
+	assert.Len(t, rows[xlsxMaxRows-1], xlsxMaxColumns)
+}
+
+func TestXLSXLimitedReader(t *testing.T) {
+	reader := &xlsxLimitedReader{reader: strings.NewReader("abcdef"), remaining: 4}
+	content, err := io.ReadAll(reader)
+	assert.ErrorIs(t, err, errXLSXPartTooLarge)
+	assert.Equal(t, "abcd", string(content))
+
+	reader = &xlsxLimitedReader{reader: strings.NewReader("abc"), remaining: 4}
+	content, err = io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", string(content))
+}
+
+func TestWriteCSV(t *testing.T) {
+	content, err := WriteCSV([]string{"Name", "Notiz"}, [][]string{
+		{"Meyer; Ben", `sagt "Hallo"`},
+		{"Anna", "zwei\nZeilen"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "\xef\xbb\xbfName;Notiz\r\n\"Meyer; Ben\";\"sagt \"\"Hallo\"\"\"\r\nAnna;\"zwei\r\nZeilen\"\r\n", string(content))
+
+	rows, err := ReadCSVRows(content)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"Name", "Notiz"}, {"Meyer; Ben", `sagt "Hallo"`}, {"Anna", "zwei\nZeilen"}}, rows)
+}
+
+func TestWriteCSV_EscapesFormulas(t *testing.T) {
+	values := []string{"=HYPERLINK(\"http://x\")", "+49 30 123", "-5,5", "+2.5", "@SUM(A1)", "-", "Anna"}
+	content, err := WriteCSV([]string{"Wert"}, [][]string{values})
+	require.NoError(t, err)
+
+	assert.Contains(t, string(content), "\"'=HYPERLINK(\"\"http://x\"\")\";'+49 30 123;-5,5;+2.5;'@SUM(A1);'-;Anna\r\n")
+
+	rows, err := ReadCSVRows(content)
+	require.NoError(t, err)
+	assert.Equal(t, values, rows[1], "Import entfernt das Apostroph wieder")
+}
+
+func TestWriteXLSX(t *testing.T) {
+	content, err := WriteXLSX(
+		XLSXSheet{
+			Name:   "Mitarbeiter",
+			Header: []string{"PNR", "Name", "Stunden", "Eintritt", "Leer"},
+			Rows: [][]interface{}{
+				{1001, "Anna <Schmidt> & Co", 38.5, time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC), nil},
+				{1002, "Ben", 0.0, time.Time{}, ""},
+			},
+		},
+		XLSXSheet{Name: "Zweites/Blatt", Header: []string{"X"}},
+	)
+	require.NoError(t, err)
+
+	rows, err := ReadXLSXRows(content)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"PNR", "Name", "Stunden", "Eintritt", "Leer"},
+		{"1001", "Anna <Schmidt> & Co", "38.5", "45383"},
+		{"1002", "Ben", "0"},
+	}, rows)
+
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	require.NoError(t, err)
+	assert.Equal(t, "[Content_Types].xml", archive.File[0].Name)
+
+	formula, err := WriteXLSX(XLSXSheet{Rows: [][]interface{}{{"=1+1", "-5,5"}}})
+	require.NoError(t, err)
+	worksheet, err := xlsxWorksheet(XLSXSheet{Rows: [][]interface{}{{"=1+1", "-5,5"}}})
+	require.NoError(t, err)
+	assert.Contains(t, worksheet, `<c r="A1" t="inlineStr" s="4">`, "Formeltext wird als Text markiert")
+	assert.Contains(t, worksheet, `<c r="B1" t="inlineStr">`)
+	rows, err = ReadXLSXRows(formula)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"=1+1", "-5,5"}}, rows)
+
+	_, err = WriteXLSX(XLSXSheet{Rows: [][]interface{}{{struct{}{}}}})
+	assert.ErrorIs(t, err, ErrInvalidSpreadsheet)
+}
+
+func TestXLSXColumnName(t *testing.T) {
+	for index, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"} {
+		assert.Equal(t, want, xlsxColumnName(index))
+		parsed, err := xlsxColumnIndex(want + "1")
+		require.NoError(t, err)
+		assert.Equal(t, index, parsed)
+	}
+}
+
+func TestXLSXSheetName(t *testing.T) {
+	used := map[string]bool{}
+	assert.Equal(t, "Projekt-A", xlsxSheetName("Projekt/A", 1, used))
+	assert.Equal(t, "Tabelle2", xlsxSheetName("projekt-a", 2, used), "doppelter Name")
+	assert.Equal(t, "Tabelle3", xlsxSheetName("  ", 3, used))
+	assert.Len(t, []rune(xlsxSheetName("Eine sehr lange Projektbezeichnung mit Überlänge", 4, used)), 31)
+}
@@ -0,0 +1,98 @@
+// backend/utils/totp.go
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TOTP-Parameter nach RFC 6238 (kompatibel mit gängigen Authenticator-Apps)
+const (
+	totpPeriod = 30
+	totpDigits = 6
+	totpSkew   = 1 // Erlaubte Abweichung in Zeitschritten (±30 Sekunden)
+)
+
+// GenerateTOTPSecret erzeugt ein zufälliges Base32-kodiertes TOTP-Secret
+func GenerateTOTPSecret() (string, error) {
+	secret := make([]byte, 20)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(secret), nil
+}
+
+// TOTPProvisioningURI erstellt die otpauth-URI für die Einrichtung in einer Authenticator-App
+func TOTPProvisioningURI(secret, accountName, issuer string) string {
+	label := url.PathEscape(issuer + ":" + accountName)
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("digits", fmt.Sprintf("%d", totpDigits))
+	params.Set("period", fmt.Sprintf("%d", totpPeriod))
+	return "otpauth://totp/" + label + "?" + params.Encode()
+}
+
+// GenerateTOTPCode berechnet den TOTP-Code für einen bestimmten Zeitpunkt
+func GenerateTOTPCode(secret string, t time.Time) (string, error) {
+	key, err := decodeTOTPSecret(secret)
+	if err != nil {
+		return "", err
+	}
+	return hotp(key, uint64(t.Unix()/totpPeriod)), nil
+}
+
+// ValidateTOTP prüft einen TOTP-Code gegen das Secret unter Berücksichtigung kleiner Uhrabweichungen
+func ValidateTOTP(secret, code string) bool {
+	code = strings.TrimSpace(code)
+	if len(code) != totpDigits {
+		return false
+	}
+
+	key, err := decodeTOTPSecret(secret)
+	if err != nil {
+		return false
+	}
+
+	counter := time.Now().Unix() / totpPeriod
+	for offset := -totpSkew; offset <= totpSkew; offset++ {
+		expected := hotp(key, uint64(counter+int64(offset)))
+		if hmac.Equal([]byte(expected), []byte(code)) {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeTOTPSecret dekodiert ein Base32-Secret (mit oder ohne Padding)
+func decodeTOTPSecret(secret string) ([]byte, error) {
+	secret = strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(secret), " ", ""))
+	secret = strings.TrimRight(secret, "=")
+	return base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+}
+
+// hotp berechnet einen HOTP-Wert nach RFC 4226
+func hotp(key []byte, counter uint64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < totpDigits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", totpDigits, value%mod)
+}
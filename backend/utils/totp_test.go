@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfc6238Secret ist das SHA1-Secret "12345678901234567890" aus RFC 6238, Anhang B
+var rfc6238Secret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestGenerateTOTPCode_RFC6238(t *testing.T) {
+	// Die RFC nennt achtstellige Codes; sechsstellige Codes sind deren letzte sechs Ziffern
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1111111111, "14050471"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+		{20000000000, "65353130"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			code, err := GenerateTOTPCode(rfc6238Secret, time.Unix(tt.unix, 0))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want[len(tt.want)-totpDigits:], code)
+		})
+	}
+}
+
+func TestGenerateTOTPCode_SecretFormat(t *testing.T) {
+	at := time.Unix(59, 0)
+	want, err := GenerateTOTPCode(rfc6238Secret, at)
+	require.NoError(t, err)
+
+	// Authenticator-Apps zeigen Secrets klein, gruppiert und ohne Padding an
+	formatted := strings.ToLower(strings.TrimRight(rfc6238Secret, "="))
+	formatted = formatted[:4] + " " + formatted[4:]
+	code, err := GenerateTOTPCode(formatted, at)
+	require.NoError(t, err)
+	assert.Equal(t, want, code)
+
+	_, err = GenerateTOTPCode("kein-base32!", at)
+	assert.Error(t, err)
+}
+
+func TestValidateTOTP(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	require.NoError(t, err)
+
+	now := time.Now()
+	current, err := GenerateTOTPCode(secret, now)
+	require.NoError(t, err)
+	previous, err := GenerateTOTPCode(secret, now.Add(-totpPeriod*time.Second))
+	require.NoError(t, err)
+	stale, err := GenerateTOTPCode(secret, now.Add(-5*totpPeriod*time.Second))
+	require.NoError(t, err)
+
+	assert.True(t, ValidateTOTP(secret, current))
+	assert.True(t, ValidateTOTP(secret, " "+current+" "), "Leerzeichen aus der Eingabe werden ignoriert")
+	assert.True(t, ValidateTOTP(secret, previous), "ein Zeitfenster Uhrabweichung ist erlaubt")
+	assert.False(t, ValidateTOTP(secret, stale))
+	assert.False(t, ValidateTOTP(secret, current[:totpDigits-1]))
+	assert.False(t, ValidateTOTP("kein-base32!", current))
+}
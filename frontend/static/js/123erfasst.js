@@ -283,34 +283,22 @@ function syncErfasst123Projects() {
     const endDate = new Date(now.getFullYear(), now.getMonth() + 1, 0).toISOString().split('T')[0];
 
     // Call API to sync projects
-    fetch(`/api/integrations/123erfasst/sync/projects?startDate=${startDate}&endDate=${endDate}`, {
-        method: 'POST',
-        headers: {
-            'Content-Type': 'application/json'
-        }
-    })
-        .then(response => response.json())
-        .then(data => {
+    startSyncJob(`/api/integrations/123erfasst/sync/projects?startDate=${startDate}&endDate=${endDate}`, showSyncJobProgressOnButton(button))
+        .then(job => {
             // Restore button state
             button.innerHTML = originalText;
             button.disabled = false;
 
-            if (data.success) {
-                // Show success notification
-                showNotification('success', `Es wurden ${data.updatedCount} Mitarbeiter mit Projektdaten aktualisiert.`);
+            showNotification('success', formatSyncJobResult(job));
 
-                // Refresh the last sync time
-                loadErfasst123SyncSettings();
-            } else {
-                // Show error notification
-                showNotification('error', data.message || 'Die Projektdaten konnten nicht synchronisiert werden.');
-            }
+            // Refresh the last sync time
+            loadErfasst123SyncSettings();
         })
         .catch(error => {
             // Restore button state and show error
             button.innerHTML = originalText;
             button.disabled = false;
-            showNotification('error', 'Bei der Synchronisierung ist ein unerwarteter Fehler aufgetreten.');
+            showNotification('error', error.message || 'Bei der Synchronisierung ist ein unerwarteter Fehler aufgetreten.');
             console.error('Error:', error);
         });
 }
@@ -330,34 +318,22 @@ function syncErfasst123Employees() {
     button.disabled = true;
 
     // Call API to sync employees
-    fetch('/api/integrations/123erfasst/sync/employees', {
-        method: 'POST',
-        headers: {
-            'Content-Type': 'application/json'
-        }
-    })
-        .then(response => response.json())
-        .then(data => {
+    startSyncJob('/api/integrations/123erfasst/sync/employees', showSyncJobProgressOnButton(button))
+        .then(job => {
             // Restore button state
             button.innerHTML = originalText;
             button.disabled = false;
 
-            if (data.success) {
-                // Show success notification
-                showNotification('success', `Es wurden ${data.updatedCount} Mitarbeiter aktualisiert.`);
+            showNotification('success', formatSyncJobResult(job));
 
-                // Refresh the last sync time
-                loadErfasst123SyncSettings();
-            } else {
-                // Show error notification
-                showNotification('error', data.message || 'Die Mitarbeiterdaten konnten nicht synchronisiert werden.');
-            }
+            // Refresh the last sync time
+            loadErfasst123SyncSettings();
         })
         .catch(error => {
             // Restore button state and show error
             button.innerHTML = originalText;
             button.disabled = false;
-            showNotification('error', 'Bei der Synchronisierung ist ein unerwarteter Fehler aufgetreten.');
+            showNotification('error', error.message || 'Bei der Synchronisierung ist ein unerwarteter Fehler aufgetreten.');
             console.error('Error:', error);
         });
 }
@@ -382,34 +358,22 @@ function syncErfasst123TimeEntries() {
     const endDate = new Date(now.getFullYear(), now.getMonth() + 1, 0).toISOString().split('T')[0];
 
     // Call API to sync time entries
-    fetch(`/api/integrations/123erfasst/sync/times?startDate=${startDate}&endDate=${endDate}`, {
-        method: 'POST',
-        headers: {
-            'Content-Type': 'application/json'
-        }
-    })
-        .then(response => response.json())
-        .then(data => {
+    startSyncJob(`/api/integrations/123erfasst/sync/times?startDate=${startDate}&endDate=${endDate}`, showSyncJobProgressOnButton(button))
+        .then(job => {
             // Restore button state
             button.innerHTML = originalText;
             button.disabled = false;
 
-            if (data.success) {
-                // Show success notification with the number of updated records
-                showNotification('success', `Es wurden ${data.updatedCount} Mitarbeiter mit Zeiterfassungsdaten aktualisiert.`);
+            showNotification('success', formatSyncJobResult(job));
 
-                // Refresh the last sync time
-                loadErfasst123SyncSettings();
-            } else {
-                // Show error notification
-                showNotification('error', data.message || 'Die Zeiterfassungsdaten konnten nicht synchronisiert werden.');
-            }
+            // Refresh the last sync time
+            loadErfasst123SyncSettings();
         })
         .catch(error => {
             // Restore button state and show error
             button.innerHTML = originalText;
             button.disabled = false;
-            showNotification('error', 'Bei der Synchronisierung ist ein unerwarteter Fehler aufgetreten.');
+            showNotification('error', error.message || 'Bei der Synchronisierung ist ein unerwarteter Fehler aufgetreten.');
             console.error('Error:', error);
         });
 }
@@ -501,29 +465,17 @@ function triggerErfasst123FullSync() {
     // Show notification
     showNotification('info', 'Vollständige Synchronisierung wurde gestartet. Dies kann einige Minuten dauern...');
 
-    // Call API to trigger full sync
-    fetch('/api/integrations/123erfasst/full-sync', {
-        method: 'POST',
-        headers: {
-            'Content-Type': 'application/json'
-        }
-    })
-        .then(response => response.json())
-        .then(data => {
-            if (data.success) {
-                // Show success notification
-                showNotification('success', data.message || 'Synchronisierung erfolgreich abgeschlossen');
+    // Call API to trigger full sync and wait for the background job
+    startSyncJob('/api/integrations/123erfasst/full-sync')
+        .then(job => {
+            showNotification('success', formatSyncJobResult(job));
 
-                // Refresh last sync time
-                loadErfasst123SyncSettings();
-            } else {
-                // Show error notification
-                showNotification('error', data.message || 'Fehler bei der Synchronisierung');
-            }
+            // Refresh last sync time
+            loadErfasst123SyncSettings();
         })
         .catch(error => {
             console.error('Error during full sync:', error);
-            showNotification('error', 'Bei der Synchronisierung ist ein unerwarteter Fehler aufgetreten');
+            showNotification('error', error.message || 'Bei der Synchronisierung ist ein unerwarteter Fehler aufgetreten');
         });
 }
 
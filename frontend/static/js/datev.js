@@ -0,0 +1,145 @@
+// DATEV-Lohnexport: Einstellungen laden und speichern, Vorschau eines Monats anzeigen und
+// die Importdatei herunterladen.
+
+const DATEV_UNIT_LABELS = {
+    hours: 'Std.',
+    days: 'Tage'
+};
+
+document.addEventListener('DOMContentLoaded', function() {
+    const form = document.getElementById('datevSettingsForm');
+    if (!form) {
+        return;
+    }
+    form.addEventListener('submit', saveDatevSettings);
+
+    // Standard ist der Vormonat
+    const previous = new Date();
+    previous.setDate(1);
+    previous.setMonth(previous.getMonth() - 1);
+    document.getElementById('datev-month').value =
+        `${previous.getFullYear()}-${String(previous.getMonth() + 1).padStart(2, '0')}`;
+
+    loadDatevSettings();
+});
+
+function setDatevMessage(message, isError) {
+    const element = document.getElementById('datev-message');
+    element.className = isError ? 'mt-2 text-sm text-red-600' : 'mt-2 text-sm text-gray-600';
+    element.textContent = message;
+}
+
+// Wandelt eine Zuordnung {Schlüssel: Lohnart} in das Eingabeformat "Schlüssel=Lohnart" je Zeile um
+function formatDatevMapping(mapping) {
+    return Object.keys(mapping || {}).sort().map(key => `${key}=${mapping[key]}`).join('\n');
+}
+
+function loadDatevSettings() {
+    fetch('/api/payroll/datev/settings')
+        .then(response => response.json())
+        .then(data => {
+            if (!data.success) {
+                throw new Error(data.error || 'Die DATEV-Einstellungen konnten nicht geladen werden.');
+            }
+            const settings = data.data;
+            document.getElementById('datev-consultant-number').value = settings.consultantNumber || '';
+            document.getElementById('datev-client-number').value = settings.clientNumber || '';
+            document.getElementById('datev-target').value = settings.target || 'LODAS';
+            document.getElementById('datev-absence-wage-types').value = formatDatevMapping(settings.absenceWageTypes);
+            document.getElementById('datev-wage-types').value = formatDatevMapping(settings.wageTypes);
+            document.getElementById('datev-payout-wage-type').value = settings.overtimePayoutWageType || '';
+        })
+        .catch(error => setDatevMessage(error.message, true));
+}
+
+function saveDatevSettings(event) {
+    event.preventDefault();
+    const form = document.getElementById('datevSettingsForm');
+
+    fetch('/api/payroll/datev/settings', { method: 'POST', body: new FormData(form) })
+        .then(response => response.json())
+        .then(data => {
+            if (!data.success) {
+                throw new Error(data.error || 'Die DATEV-Einstellungen konnten nicht gespeichert werden.');
+            }
+            setDatevMessage(data.message, false);
+        })
+        .catch(error => setDatevMessage(error.message, true));
+}
+
+function datevMonthQuery() {
+    return 'month=' + encodeURIComponent(document.getElementById('datev-month').value);
+}
+
+function renderDatevWarnings(warnings) {
+    const list = document.getElementById('datevWarnings');
+    list.innerHTML = '';
+    (warnings || []).forEach(warning => {
+        const item = document.createElement('li');
+        item.textContent = warning;
+        list.appendChild(item);
+    });
+}
+
+// Zeigt die Bewegungsdaten des gewählten Monats mit den Hinweisen zu nicht exportierten Daten
+function previewDatevExport() {
+    fetch('/api/payroll/datev/preview?' + datevMonthQuery())
+        .then(response => response.json())
+        .then(data => {
+            if (!data.success) {
+                throw new Error(data.error || 'Die Vorschau konnte nicht erstellt werden.');
+            }
+            const movements = data.data.movements || [];
+            const body = document.getElementById('datevPreviewBody');
+            body.innerHTML = '';
+            movements.forEach(movement => {
+                const row = document.createElement('tr');
+                [
+                    movement.personnelNumber,
+                    movement.employeeName,
+                    movement.wageType,
+                    `${movement.value.toLocaleString('de-DE', { minimumFractionDigits: 2 })} ${DATEV_UNIT_LABELS[movement.unit] || ''}`,
+                    movement.source
+                ].forEach((value, index) => {
+                    const cell = document.createElement('td');
+                    cell.className = index === 3 ? 'px-4 py-2 text-right' : 'px-4 py-2';
+                    cell.textContent = value;
+                    row.appendChild(cell);
+                });
+                body.appendChild(row);
+            });
+
+            document.getElementById('datevPreview').style.display = movements.length ? 'block' : 'none';
+            renderDatevWarnings(data.data.warnings);
+            setDatevMessage(movements.length
+                ? `${movements.length} Bewegungsdatensätze`
+                : 'Für diesen Monat liegen keine Bewegungsdaten vor.', false);
+        })
+        .catch(error => setDatevMessage(error.message, true));
+}
+
+// Lädt die Importdatei herunter; Fehler kommen als JSON zurück
+function downloadDatevExport() {
+    fetch('/api/payroll/datev/export?' + datevMonthQuery())
+        .then(response => {
+            if (!response.ok) {
+                return response.json().then(data => {
+                    throw new Error(data.error || 'Der Export ist fehlgeschlagen.');
+                });
+            }
+            const disposition = response.headers.get('Content-Disposition') || '';
+            const match = disposition.match(/filename=([^;]+)/);
+            return response.blob().then(blob => ({ blob, fileName: match ? match[1] : 'DATEV.txt' }));
+        })
+        .then(({ blob, fileName }) => {
+            const link = document.createElement('a');
+            link.href = URL.createObjectURL(blob);
+            link.download = fileName;
+            document.body.appendChild(link);
+            link.click();
+            link.remove();
+            URL.revokeObjectURL(link.href);
+            setDatevMessage(`${fileName} wurde heruntergeladen.`, false);
+        })
+        .catch(error => setDatevMessage(error.message, true));
+}
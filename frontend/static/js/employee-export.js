@@ -0,0 +1,99 @@
+// Mitarbeiterexport: übernimmt Suche, Filter und Sortierung der Liste und lädt die Datei herunter.
+
+// Statusbezeichnungen der Filterliste und ihre Werte in der API
+const EMPLOYEE_EXPORT_STATUSES = {
+    'Aktiv': 'active',
+    'Im Urlaub': 'onleave',
+    'Remote': 'remote',
+    'Inaktiv': 'inactive'
+};
+
+let employeeExportColumnsLoaded = false;
+
+function openEmployeeExport() {
+    openModal('exportEmployeesModal');
+    if (!employeeExportColumnsLoaded) {
+        loadEmployeeExportColumns();
+    }
+}
+
+function setEmployeeExportMessage(message, isError) {
+    const element = document.getElementById('employee-export-message');
+    element.className = isError ? 'text-sm text-red-600' : 'text-sm text-gray-600';
+    element.textContent = message;
+}
+
+function loadEmployeeExportColumns() {
+    fetch('/api/employees/export/columns')
+        .then(response => response.json())
+        .then(data => {
+            if (!data.success) {
+                throw new Error(data.error || 'Die Spalten konnten nicht geladen werden.');
+            }
+            const container = document.getElementById('employeeExportColumns');
+            container.innerHTML = '';
+            data.data.forEach(column => {
+                const label = document.createElement('label');
+                label.className = 'inline-flex items-center';
+                const checkbox = document.createElement('input');
+                checkbox.type = 'checkbox';
+                checkbox.className = 'employee-export-column form-checkbox h-4 w-4 text-green-600 mr-2';
+                checkbox.value = column.key;
+                checkbox.checked = true;
+                label.appendChild(checkbox);
+                label.appendChild(document.createTextNode(column.label));
+                container.appendChild(label);
+            });
+            employeeExportColumnsLoaded = true;
+        })
+        .catch(error => setEmployeeExportMessage(error.message, true));
+}
+
+function setEmployeeExportColumns(checked) {
+    document.querySelectorAll('.employee-export-column').forEach(checkbox => {
+        checkbox.checked = checked;
+    });
+}
+
+// Liefert die Werte der angehakten Filter-Checkboxen; bei "Alle" eine leere Liste
+function selectedEmployeeExportFilters(selector) {
+    const all = document.querySelector(selector + '[value="all"]');
+    if (!all || all.checked) {
+        return [];
+    }
+    return Array.from(document.querySelectorAll(selector + ':checked'))
+        .map(checkbox => checkbox.value)
+        .filter(value => value !== 'all');
+}
+
+function downloadEmployeeExport() {
+    const columns = Array.from(document.querySelectorAll('.employee-export-column:checked')).map(checkbox => checkbox.value);
+    if (columns.length === 0) {
+        setEmployeeExportMessage('Bitte wählen Sie mindestens eine Spalte aus.', true);
+        return;
+    }
+
+    const params = new URLSearchParams();
+    params.set('format', document.getElementById('employee-export-format').value);
+    params.set('columns', columns.join(','));
+
+    const search = document.getElementById('searchInput');
+    if (search && search.value.trim()) {
+        params.set('q', search.value.trim());
+    }
+    const statuses = selectedEmployeeExportFilters('.status-filter').map(label => EMPLOYEE_EXPORT_STATUSES[label] || label);
+    if (statuses.length) {
+        params.set('status', statuses.join(','));
+    }
+    const departments = selectedEmployeeExportFilters('.dept-filter');
+    if (departments.length) {
+        params.set('department', departments.join(','));
+    }
+    const sort = document.getElementById('sortSelect');
+    if (sort) {
+        params.set('sort', sort.value);
+    }
+
+    setEmployeeExportMessage('', false);
+    window.location.href = '/api/employees/export?' + params.toString();
+}
@@ -0,0 +1,122 @@
+// Mitarbeiterimport: Datei zuerst im Probelauf prüfen, danach dieselbe Datei importieren.
+
+const EMPLOYEE_IMPORT_ACTIONS = {
+    create: { label: 'Neu', className: 'text-green-700' },
+    update: { label: 'Ändern', className: 'text-blue-700' },
+    unchanged: { label: 'Unverändert', className: 'text-gray-500' },
+    error: { label: 'Fehler', className: 'text-red-700' }
+};
+
+let employeeImportFields = null;
+
+document.addEventListener('DOMContentLoaded', function() {
+    const form = document.getElementById('employeeImportForm');
+    if (!form) {
+        return;
+    }
+    // Nach jeder Änderung muss erneut geprüft werden
+    form.addEventListener('change', resetEmployeeImportPreview);
+    form.addEventListener('submit', event => {
+        event.preventDefault();
+        runEmployeeImport(true);
+    });
+});
+
+function openEmployeeImport() {
+    openModal('importEmployeesModal');
+    if (employeeImportFields === null) {
+        loadEmployeeImportFields();
+    }
+}
+
+function setEmployeeImportMessage(message, isError) {
+    const element = document.getElementById('employee-import-message');
+    element.className = isError ? 'text-sm text-red-600' : 'text-sm text-gray-600';
+    element.textContent = message;
+}
+
+function resetEmployeeImportPreview() {
+    document.getElementById('employeeImportApplyBtn').disabled = true;
+    document.getElementById('employeeImportResult').style.display = 'none';
+    setEmployeeImportMessage('', false);
+}
+
+function loadEmployeeImportFields() {
+    fetch('/api/employees/import/fields')
+        .then(response => response.json())
+        .then(data => {
+            if (!data.success) {
+                throw new Error(data.error || 'Die Felder konnten nicht geladen werden.');
+            }
+            employeeImportFields = data.data;
+            const list = document.getElementById('employeeImportFields');
+            list.innerHTML = '';
+            employeeImportFields.forEach(field => {
+                const item = document.createElement('li');
+                const key = document.createElement('code');
+                key.textContent = field.key;
+                item.appendChild(key);
+                item.appendChild(document.createTextNode(' – ' + field.label));
+                list.appendChild(item);
+            });
+        })
+        .catch(error => setEmployeeImportMessage(error.message, true));
+}
+
+function renderEmployeeImportReport(report) {
+    const labels = {};
+    (employeeImportFields || []).forEach(field => {
+        labels[field.key] = field.label;
+    });
+
+    const body = document.getElementById('employeeImportRows');
+    body.innerHTML = '';
+    report.rows.forEach(row => {
+        const action = EMPLOYEE_IMPORT_ACTIONS[row.action] || { label: row.action, className: '' };
+        const details = row.errors && row.errors.length
+            ? row.errors.join('; ')
+            : (row.changes || []).map(key => labels[key] || key).join(', ');
+
+        const tr = document.createElement('tr');
+        [row.row, action.label, row.employeeId, row.name, details].forEach((value, index) => {
+            const cell = document.createElement('td');
+            cell.className = 'px-4 py-2' + (index === 1 || index === 4 ? ' ' + action.className : '');
+            cell.textContent = value;
+            tr.appendChild(cell);
+        });
+        body.appendChild(tr);
+    });
+    document.getElementById('employeeImportResult').style.display = report.rows.length ? 'block' : 'none';
+}
+
+// Sendet die Datei als Probelauf (dryRun=true) oder übernimmt die geprüften Zeilen
+function runEmployeeImport(dryRun) {
+    const form = document.getElementById('employeeImportForm');
+    if (!form.reportValidity()) {
+        return;
+    }
+    const formData = new FormData(form);
+    formData.set('dryRun', dryRun ? 'true' : 'false');
+    const applyButton = document.getElementById('employeeImportApplyBtn');
+    applyButton.disabled = true;
+    setEmployeeImportMessage(dryRun ? 'Datei wird geprüft …' : 'Mitarbeiter werden importiert …', false);
+
+    fetch('/api/employees/import', { method: 'POST', body: formData })
+        .then(response => response.json())
+        .then(data => {
+            if (!data.success) {
+                throw new Error(data.error || 'Der Import ist fehlgeschlagen.');
+            }
+            const report = data.data;
+            renderEmployeeImportReport(report);
+            if (dryRun) {
+                applyButton.disabled = report.created + report.updated === 0;
+                setEmployeeImportMessage(data.message + (report.failed
+                    ? '. Fehlerhafte Zeilen werden beim Import übersprungen.'
+                    : ''), report.failed > 0);
+            } else {
+                setEmployeeImportMessage(data.message + '. Laden Sie die Seite neu, um die Änderungen zu sehen.', report.failed > 0);
+            }
+        })
+        .catch(error => setEmployeeImportMessage(error.message, true));
+}
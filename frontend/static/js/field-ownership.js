@@ -0,0 +1,173 @@
+// Zuständigkeiten der synchronisierten Felder einer Integration und Konflikte, bei denen ein
+// Feld seit der letzten Synchronisierung in PeopleFlow und im angebundenen System geändert wurde.
+
+const FIELD_OWNERSHIP_OPTIONS = {
+    peopleflow: 'PeopleFlow hat Vorrang',
+    external: 'Externes System hat Vorrang',
+    fill_if_empty: 'Nur leere Felder füllen'
+};
+
+let fieldOwnershipIntegration = null;
+
+// Öffnet den Dialog und lädt Zuständigkeiten und Konflikte einer Integration
+function openFieldOwnership(integrationType) {
+    fieldOwnershipIntegration = integrationType;
+    document.getElementById('field-conflicts-resolved').checked = false;
+    openModal('fieldOwnershipModal');
+    loadFieldOwnership();
+    loadFieldConflicts();
+}
+
+function fieldOwnershipURL(path) {
+    return `/api/integrations/${encodeURIComponent(fieldOwnershipIntegration)}${path}`;
+}
+
+function setFieldOwnershipStatus(message) {
+    document.getElementById('field-ownership-status').textContent = message;
+}
+
+// Lädt die Zuständigkeiten und stellt je Feld eine Auswahl dar
+function loadFieldOwnership() {
+    const content = document.getElementById('field-ownership-content');
+    content.replaceChildren();
+
+    fetch(fieldOwnershipURL('/field-ownership'))
+        .then(response => response.json())
+        .then(data => {
+            if (!data.success) {
+                throw new Error(data.message || 'Die Zuständigkeiten konnten nicht geladen werden.');
+            }
+            const list = document.createElement('ul');
+            list.className = 'divide-y divide-gray-200';
+            data.data.forEach(setting => list.appendChild(renderFieldOwnership(setting)));
+            content.appendChild(list);
+        })
+        .catch(error => setFieldOwnershipStatus(error.message));
+}
+
+function renderFieldOwnership(setting) {
+    const row = document.createElement('li');
+    row.className = 'py-2 flex items-center justify-between';
+
+    const label = document.createElement('span');
+    label.className = 'text-sm text-gray-700';
+    label.textContent = setting.label;
+    row.appendChild(label);
+
+    const select = document.createElement('select');
+    select.className = 'text-sm border-gray-300 rounded-md';
+    Object.entries(FIELD_OWNERSHIP_OPTIONS).forEach(([value, text]) => {
+        const option = document.createElement('option');
+        option.value = value;
+        option.textContent = value === setting.default ? `${text} (Standard)` : text;
+        select.appendChild(option);
+    });
+    select.value = setting.ownership;
+    select.onchange = () => saveFieldOwnership(setting.name, select.value);
+    row.appendChild(select);
+    return row;
+}
+
+// Speichert die Zuständigkeit eines Feldes
+function saveFieldOwnership(field, ownership) {
+    setFieldOwnershipStatus('Wird gespeichert...');
+
+    fetch(fieldOwnershipURL('/field-ownership'), { method: 'POST', body: new URLSearchParams({ field, ownership }) })
+        .then(response => response.json())
+        .then(data => {
+            if (!data.success) {
+                throw new Error(data.message || 'Die Zuständigkeit konnte nicht gespeichert werden.');
+            }
+            setFieldOwnershipStatus(data.message);
+        })
+        .catch(error => {
+            setFieldOwnershipStatus(error.message);
+            loadFieldOwnership();
+        });
+}
+
+// Lädt die Konflikte; mit gesetztem Haken auch die bereits gelösten
+function loadFieldConflicts() {
+    const content = document.getElementById('field-conflicts-content');
+    const includeResolved = document.getElementById('field-conflicts-resolved').checked;
+    content.replaceChildren();
+
+    fetch(fieldOwnershipURL(`/conflicts?includeResolved=${includeResolved}`))
+        .then(response => response.json())
+        .then(data => {
+            if (!data.success) {
+                throw new Error(data.message || 'Die Konflikte konnten nicht geladen werden.');
+            }
+            if (data.data.length === 0) {
+                const empty = document.createElement('p');
+                empty.className = 'text-sm text-gray-500';
+                empty.textContent = 'Keine Konflikte';
+                content.appendChild(empty);
+                return;
+            }
+            const list = document.createElement('ul');
+            list.className = 'divide-y divide-gray-200';
+            data.data.forEach(conflict => list.appendChild(renderFieldConflict(conflict)));
+            content.appendChild(list);
+        })
+        .catch(error => setFieldOwnershipStatus(error.message));
+}
+
+function renderFieldConflict(conflict) {
+    const row = document.createElement('li');
+    row.className = 'py-2';
+
+    const title = document.createElement('div');
+    title.className = 'text-sm font-medium text-gray-900';
+    title.textContent = `${conflict.employeeName} – ${conflict.label}`;
+    row.appendChild(title);
+
+    const details = document.createElement('div');
+    details.className = 'text-xs text-gray-500';
+    details.textContent = [
+        `Zuletzt synchronisiert: ${conflict.baseValue || '–'}`,
+        `PeopleFlow: ${conflict.localValue || '–'}`,
+        `Extern: ${conflict.externalValue || '–'}`,
+        conflict.applied ? 'externer Wert übernommen' : 'PeopleFlow-Wert beibehalten'
+    ].join(' · ');
+    row.appendChild(details);
+
+    const actions = document.createElement('div');
+    actions.className = 'mt-1 flex flex-wrap items-center gap-2';
+    if (conflict.resolvedAt) {
+        const resolved = document.createElement('span');
+        resolved.className = 'text-xs text-green-700';
+        resolved.textContent = `Gelöst von ${conflict.resolvedByName}: ${FIELD_OWNERSHIP_OPTIONS[conflict.resolution] || conflict.resolution}`;
+        actions.appendChild(resolved);
+    } else {
+        actions.appendChild(fieldConflictButton('PeopleFlow-Wert behalten', () => resolveFieldConflict(conflict.id, 'peopleflow')));
+        actions.appendChild(fieldConflictButton('Externen Wert übernehmen', () => resolveFieldConflict(conflict.id, 'external')));
+    }
+    row.appendChild(actions);
+    return row;
+}
+
+function fieldConflictButton(label, onClick) {
+    const button = document.createElement('button');
+    button.type = 'button';
+    button.className = 'px-2 py-1 border border-gray-300 rounded-md text-xs font-medium text-gray-700 bg-white hover:bg-gray-50';
+    button.textContent = label;
+    button.onclick = onClick;
+    return button;
+}
+
+// Löst einen Konflikt mit dem Wert aus PeopleFlow oder aus dem angebundenen System
+function resolveFieldConflict(id, resolution) {
+    setFieldOwnershipStatus('Wird gespeichert...');
+
+    fetch(`/api/integrations/conflicts/${encodeURIComponent(id)}/resolve`, { method: 'POST', body: new URLSearchParams({ resolution }) })
+        .then(response => response.json())
+        .then(data => {
+            if (!data.success) {
+                throw new Error(data.message || 'Der Konflikt konnte nicht gelöst werden.');
+            }
+            setFieldOwnershipStatus(data.message);
+            loadFieldConflicts();
+        })
+        .catch(error => setFieldOwnershipStatus(error.message));
+}
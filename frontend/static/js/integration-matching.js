@@ -0,0 +1,218 @@
+// Abgleich der Benutzer einer Integration mit den Mitarbeitern: nicht zugeordnete Benutzer
+// mit Vorschlägen, manuelle Zuordnungen und Neuanlage von Mitarbeitern aus externen Daten.
+
+const IDENTITY_MATCH_SOURCES = {
+    manual: 'manuell',
+    id: 'gespeicherte ID',
+    email: 'E-Mail'
+};
+
+let identityMatchIntegration = null;
+
+// Öffnet den Dialog und lädt den Abgleich einer Integration
+function openIdentityMatching(integrationType) {
+    identityMatchIntegration = integrationType;
+    openModal('identityMatchModal');
+    loadIdentityMatches();
+}
+
+// Lädt den Abgleich neu und stellt ihn dar
+function loadIdentityMatches() {
+    const status = document.getElementById('identity-match-status');
+    const content = document.getElementById('identity-match-content');
+
+    content.replaceChildren();
+    status.textContent = 'Benutzer werden abgeglichen...';
+
+    fetch(`/api/integrations/${encodeURIComponent(identityMatchIntegration)}/matching`)
+        .then(response => response.json())
+        .then(data => {
+            if (!data.success) {
+                throw new Error(data.message || 'Der Abgleich konnte nicht geladen werden.');
+            }
+            const report = data.data;
+            status.textContent = `${report.matched.length} zugeordnet, ${report.unmatchedExternal.length} externe Benutzer und ${report.unmatchedEmployees.length} Mitarbeiter ohne Zuordnung`;
+            renderIdentityMatches(content, report);
+        })
+        .catch(error => {
+            status.textContent = error.message;
+        });
+}
+
+// Stellt die drei Abschnitte des Abgleichs dar
+function renderIdentityMatches(content, report) {
+    content.appendChild(identityMatchSection('Nicht zugeordnete Benutzer', report.unmatchedExternal, item =>
+        renderUnmatchedIdentity(item, report.unmatchedEmployees)));
+    content.appendChild(identityMatchSection('Zugeordnete Benutzer', report.matched, match =>
+        renderIdentityMatch(match, report.unmatchedEmployees)));
+    content.appendChild(identityMatchSection('Mitarbeiter ohne Benutzer', report.unmatchedEmployees, employee => {
+        const row = document.createElement('li');
+        row.className = 'py-2 text-sm text-gray-700';
+        row.textContent = [employee.employeeName, employee.email, employee.employeeNumber].filter(Boolean).join(' · ');
+        return row;
+    }));
+}
+
+// Erstellt einen Abschnitt mit Überschrift und Liste
+function identityMatchSection(title, items, renderItem) {
+    const section = document.createElement('div');
+    section.className = 'mb-4';
+
+    const heading = document.createElement('h4');
+    heading.className = 'text-sm font-semibold text-gray-900 mb-1';
+    heading.textContent = `${title} (${items.length})`;
+    section.appendChild(heading);
+
+    if (items.length === 0) {
+        const empty = document.createElement('p');
+        empty.className = 'text-sm text-gray-500';
+        empty.textContent = 'Keine Einträge';
+        section.appendChild(empty);
+        return section;
+    }
+
+    const list = document.createElement('ul');
+    list.className = 'divide-y divide-gray-200';
+    items.forEach(item => list.appendChild(renderItem(item)));
+    section.appendChild(list);
+    return section;
+}
+
+// Zeile eines nicht zugeordneten Benutzers: Vorschläge, Zuordnen und Neuanlage
+function renderUnmatchedIdentity(item, employees) {
+    const row = identityMatchRow(item.external);
+    const select = identityEmployeeSelect(item.suggestions, employees);
+    const actions = row.querySelector('[data-actions]');
+
+    actions.appendChild(select);
+    actions.appendChild(identityMatchButton('Zuordnen', () => confirmIdentityMatch(item.external.externalId, select.value)));
+    actions.appendChild(identityMatchButton('Als Mitarbeiter anlegen', () => createEmployeeFromIdentity(item.external)));
+    return row;
+}
+
+// Zeile einer bestehenden Zuordnung: Mitarbeiter ändern oder manuelle Zuordnung entfernen
+function renderIdentityMatch(match, employees) {
+    const row = identityMatchRow(match.external);
+    const actions = row.querySelector('[data-actions]');
+
+    const target = document.createElement('span');
+    target.className = 'text-sm text-gray-700';
+    target.textContent = `→ ${match.employeeName} (${IDENTITY_MATCH_SOURCES[match.source] || match.source})`;
+    actions.appendChild(target);
+
+    if (employees.length > 0) {
+        const select = identityEmployeeSelect([], employees);
+        actions.appendChild(select);
+        actions.appendChild(identityMatchButton('Ändern', () => confirmIdentityMatch(match.external.externalId, select.value)));
+    }
+    if (match.source === 'manual') {
+        actions.appendChild(identityMatchButton('Entfernen', () => removeIdentityMatch(match.external.externalId)));
+    }
+    return row;
+}
+
+// Grundgerüst einer Zeile mit den Daten des externen Benutzers
+function identityMatchRow(external) {
+    const row = document.createElement('li');
+    row.className = 'py-2';
+
+    const name = document.createElement('div');
+    name.className = 'text-sm font-medium text-gray-900';
+    name.textContent = [external.firstName, external.lastName].filter(Boolean).join(' ') || external.email || external.externalId;
+    row.appendChild(name);
+
+    const details = document.createElement('div');
+    details.className = 'text-xs text-gray-500';
+    details.textContent = [
+        external.email,
+        external.employeeNumber ? `Personalnummer ${external.employeeNumber}` : '',
+        `ID ${external.externalId}`
+    ].filter(Boolean).join(' · ');
+    row.appendChild(details);
+
+    const actions = document.createElement('div');
+    actions.className = 'mt-1 flex flex-wrap items-center gap-2';
+    actions.dataset.actions = '';
+    row.appendChild(actions);
+    return row;
+}
+
+// Auswahl eines Mitarbeiters: zuerst die Vorschläge mit Punktzahl, dann alle übrigen
+function identityEmployeeSelect(suggestions, employees) {
+    const select = document.createElement('select');
+    select.className = 'text-sm border-gray-300 rounded-md';
+
+    const placeholder = document.createElement('option');
+    placeholder.value = '';
+    placeholder.textContent = 'Mitarbeiter wählen...';
+    select.appendChild(placeholder);
+
+    const suggested = new Set();
+    suggestions.forEach(candidate => {
+        suggested.add(candidate.employeeId);
+        const option = document.createElement('option');
+        option.value = candidate.employeeId;
+        option.textContent = `${candidate.employeeName} – ${candidate.score} % (${candidate.reasons.join(', ')})`;
+        select.appendChild(option);
+    });
+    if (suggestions.length > 0) {
+        select.value = suggestions[0].employeeId;
+    }
+
+    employees.filter(employee => !suggested.has(employee.employeeId)).forEach(employee => {
+        const option = document.createElement('option');
+        option.value = employee.employeeId;
+        option.textContent = employee.email ? `${employee.employeeName} (${employee.email})` : employee.employeeName;
+        select.appendChild(option);
+    });
+    return select;
+}
+
+function identityMatchButton(label, onClick) {
+    const button = document.createElement('button');
+    button.type = 'button';
+    button.className = 'px-2 py-1 border border-gray-300 rounded-md text-xs font-medium text-gray-700 bg-white hover:bg-gray-50';
+    button.textContent = label;
+    button.onclick = onClick;
+    return button;
+}
+
+// Bestätigt oder ändert die Zuordnung eines externen Benutzers
+function confirmIdentityMatch(externalId, employeeId) {
+    if (!employeeId) {
+        document.getElementById('identity-match-status').textContent = 'Bitte zuerst einen Mitarbeiter wählen.';
+        return;
+    }
+    sendIdentityMatchRequest('', 'POST', new URLSearchParams({ externalId, employeeId }));
+}
+
+// Entfernt eine manuelle Zuordnung
+function removeIdentityMatch(externalId) {
+    sendIdentityMatchRequest(`/${encodeURIComponent(externalId)}`, 'DELETE');
+}
+
+// Legt einen Mitarbeiter aus den Daten des externen Benutzers an
+function createEmployeeFromIdentity(external) {
+    const name = [external.firstName, external.lastName].filter(Boolean).join(' ') || external.externalId;
+    if (!confirm(`Soll für ${name} ein neuer Mitarbeiter angelegt werden?`)) {
+        return;
+    }
+    sendIdentityMatchRequest('/create-employee', 'POST', new URLSearchParams({ externalId: external.externalId }));
+}
+
+function sendIdentityMatchRequest(path, method, body) {
+    const status = document.getElementById('identity-match-status');
+    status.textContent = 'Wird gespeichert...';
+
+    fetch(`/api/integrations/${encodeURIComponent(identityMatchIntegration)}/matching${path}`, { method, body })
+        .then(response => response.json())
+        .then(data => {
+            if (!data.success) {
+                throw new Error(data.message || 'Die Zuordnung konnte nicht gespeichert werden.');
+            }
+            loadIdentityMatches();
+        })
+        .catch(error => {
+            status.textContent = error.message;
+        });
+}
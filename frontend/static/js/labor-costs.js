@@ -0,0 +1,131 @@
+// Personalkosten-Verlauf auf der Statistikseite aus den monatlichen Momentaufnahmen.
+
+let laborCostChart = null;
+
+function formatLaborCost(value) {
+    return new Intl.NumberFormat('de-DE', { style: 'currency', currency: 'EUR' }).format(value);
+}
+
+function formatLaborCostMonth(month) {
+    const [year, value] = month.split('-');
+    return new Date(Number(year), Number(value) - 1, 1).toLocaleDateString('de-DE', { month: 'short', year: 'numeric' });
+}
+
+// Füllt einen Filter mit den Namen der Reihen und behält die aktuelle Auswahl bei
+function fillLaborCostFilter(select, series) {
+    const selected = select.value;
+    while (select.options.length > 1) {
+        select.remove(1);
+    }
+    series.forEach(entry => select.add(new Option(entry.name, entry.name)));
+    select.value = selected;
+}
+
+function renderLaborCosts(trend) {
+    fillLaborCostFilter(document.getElementById('laborCostDepartment'), trend.departments);
+    fillLaborCostFilter(document.getElementById('laborCostLocation'), trend.locations);
+
+    const labels = trend.months.map(formatLaborCostMonth);
+    // Monate ohne Momentaufnahme bleiben im Diagramm leer statt 0 € zu zeigen
+    const values = key => trend.costs.map((cost, index) => trend.available[index] ? cost[key] : null);
+    const datasets = [
+        { label: 'Gehälter', data: values('salaries'), backgroundColor: '#15803D' },
+        { label: 'Sonderzahlungen', data: values('bonuses'), backgroundColor: '#86EFAC' },
+        { label: 'Arbeitgeberanteil', data: values('employerContributions'), backgroundColor: '#C3E657' },
+    ];
+
+    if (laborCostChart) {
+        laborCostChart.destroy();
+    }
+    laborCostChart = new Chart(document.getElementById('laborCostChart').getContext('2d'), {
+        type: 'bar',
+        data: { labels: labels, datasets: datasets },
+        options: {
+            responsive: true,
+            maintainAspectRatio: false,
+            plugins: {
+                tooltip: {
+                    callbacks: {
+                        label: context => context.dataset.label + ': ' + formatLaborCost(context.parsed.y),
+                    },
+                },
+            },
+            scales: {
+                x: { stacked: true, grid: { display: false } },
+                y: { stacked: true, beginAtZero: true, ticks: { callback: value => formatLaborCost(value) } },
+            },
+        },
+    });
+
+    const rows = document.getElementById('laborCostRows');
+    rows.innerHTML = '';
+    trend.months.slice().reverse().forEach((month, reversedIndex) => {
+        const index = trend.months.length - 1 - reversedIndex;
+        const cost = trend.costs[index];
+        const row = document.createElement('tr');
+        if (!trend.available[index]) {
+            [formatLaborCostMonth(month), 'Keine Momentaufnahme gespeichert'].forEach((text, column) => {
+                const cell = document.createElement('td');
+                cell.className = 'px-4 py-2' + (column > 0 ? ' text-gray-500' : '');
+                if (column > 0) {
+                    cell.colSpan = 6;
+                }
+                cell.textContent = text;
+                row.appendChild(cell);
+            });
+            rows.appendChild(row);
+            return;
+        }
+        [
+            formatLaborCostMonth(month),
+            cost.employeeCount,
+            formatLaborCost(cost.salaries),
+            formatLaborCost(cost.bonuses),
+            formatLaborCost(cost.employerContributions),
+            formatLaborCost(cost.total),
+            trend.stored[index] ? 'festgeschrieben' : 'berechnet',
+        ].forEach((text, column) => {
+            const cell = document.createElement('td');
+            cell.className = 'px-4 py-2' + (column > 0 && column < 6 ? ' text-right' : '') + (column === 5 ? ' font-medium' : '');
+            cell.textContent = text;
+            row.appendChild(cell);
+        });
+        rows.appendChild(row);
+    });
+}
+
+function loadLaborCosts() {
+    const params = new URLSearchParams({
+        months: document.getElementById('laborCostMonths').value,
+        department: document.getElementById('laborCostDepartment').value,
+        location: document.getElementById('laborCostLocation').value,
+    });
+    fetch('/api/labor-costs?' + params.toString())
+        .then(response => response.json())
+        .then(result => {
+            if (!result.success) {
+                throw new Error(result.error);
+            }
+            renderLaborCosts(result.data);
+        })
+        .catch(error => {
+            document.getElementById('laborCostRows').innerHTML = '';
+            const row = document.createElement('tr');
+            const cell = document.createElement('td');
+            cell.colSpan = 7;
+            cell.className = 'px-4 py-2 text-red-600';
+            cell.textContent = 'Personalkosten konnten nicht geladen werden: ' + error.message;
+            row.appendChild(cell);
+            document.getElementById('laborCostRows').appendChild(row);
+        });
+}
+
+document.addEventListener('DOMContentLoaded', function() {
+    if (!document.getElementById('laborCostSection')) {
+        return;
+    }
+    ['laborCostMonths', 'laborCostDepartment', 'laborCostLocation'].forEach(id => {
+        document.getElementById(id).addEventListener('change', loadLaborCosts);
+    });
+    loadLaborCosts();
+});
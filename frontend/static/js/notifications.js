@@ -0,0 +1,100 @@
+// In-App-Benachrichtigungen: Glockensymbol mit Zähler und Dropdown in der Navigation
+document.addEventListener('DOMContentLoaded', function() {
+    const bell = document.getElementById('notification-bell');
+    const badge = document.getElementById('notification-badge');
+    const panel = document.getElementById('notification-panel');
+    const list = document.getElementById('notification-list');
+    const readAllButton = document.getElementById('notification-read-all');
+
+    if (!bell || !panel) {
+        return;
+    }
+
+    const esc = value => {
+        const div = document.createElement('div');
+        div.textContent = value || '';
+        return div.innerHTML;
+    };
+
+    function updateBadge(unread) {
+        if (unread > 0) {
+            badge.textContent = unread > 99 ? '99+' : unread;
+            badge.classList.remove('hidden');
+        } else {
+            badge.classList.add('hidden');
+        }
+    }
+
+    function loadUnreadCount() {
+        fetch('/api/notifications/unread-count')
+            .then(response => response.json())
+            .then(data => {
+                if (data.success) {
+                    updateBadge(data.data.unread);
+                }
+            })
+            .catch(() => {});
+    }
+
+    function loadNotifications() {
+        fetch('/api/notifications')
+            .then(response => response.json())
+            .then(data => {
+                if (!data.success) {
+                    return;
+                }
+                updateBadge(data.unread);
+                if (data.data.length === 0) {
+                    list.innerHTML = '<li class="px-4 py-6 text-center text-gray-500">Keine Benachrichtigungen</li>';
+                    return;
+                }
+                list.innerHTML = data.data.map(notification => `
+                    <li class="px-4 py-3 hover:bg-gray-50 cursor-pointer ${notification.read ? '' : 'bg-green-50'}"
+                        data-id="${notification.id}" data-link="${esc(notification.link)}" data-read="${notification.read}">
+                        <p class="font-medium text-gray-900">${esc(notification.title)}</p>
+                        <p class="text-gray-600">${esc(notification.message)}</p>
+                        <p class="mt-1 text-xs text-gray-400">${new Date(notification.createdAt).toLocaleString('de-DE')}</p>
+                    </li>`).join('');
+            });
+    }
+
+    bell.addEventListener('click', function(e) {
+        e.stopPropagation();
+        panel.classList.toggle('hidden');
+        if (!panel.classList.contains('hidden')) {
+            loadNotifications();
+        }
+    });
+
+    document.addEventListener('click', function(e) {
+        if (!panel.contains(e.target)) {
+            panel.classList.add('hidden');
+        }
+    });
+
+    list.addEventListener('click', function(e) {
+        const item = e.target.closest('li[data-id]');
+        if (!item) {
+            return;
+        }
+        const open = () => {
+            if (item.dataset.link) {
+                window.location.href = item.dataset.link;
+            } else {
+                loadNotifications();
+            }
+        };
+        if (item.dataset.read === 'true') {
+            open();
+            return;
+        }
+        fetch('/api/notifications/' + item.dataset.id + '/read', { method: 'POST' }).finally(open);
+    });
+
+    readAllButton.addEventListener('click', function() {
+        fetch('/api/notifications/read-all', { method: 'POST' }).then(loadNotifications);
+    });
+
+    loadUnreadCount();
+    setInterval(loadUnreadCount, 60000);
+});
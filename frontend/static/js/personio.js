@@ -0,0 +1,111 @@
+// Personio-Integration: Zugangsdaten und Attributzuordnung speichern, Status anzeigen und
+// Synchronisierungen über die allgemeinen Integrations-Endpunkte starten.
+
+const PERSONIO_SYNC_LABELS = {
+    users: 'Mitarbeiter',
+    absences: 'Abwesenheiten',
+    time_entries: 'Anwesenheiten'
+};
+
+document.addEventListener('DOMContentLoaded', loadPersonioStatus);
+
+// Lädt den Status aus /api/integrations/status und aktualisiert die Karte
+function loadPersonioStatus() {
+    fetch('/api/integrations/status')
+        .then(response => response.json())
+        .then(data => updatePersonioStatus(data.personio || {}))
+        .catch(error => {
+            console.error('Error fetching Personio status:', error);
+            updatePersonioStatus({});
+        });
+}
+
+function updatePersonioStatus(status) {
+    const statusElement = document.getElementById('personioStatus');
+    const removeButton = document.getElementById('removePersonioBtn');
+    const syncButtons = document.getElementById('personioSyncButtons');
+    const secretInput = document.getElementById('personio-client-secret');
+    const mappingInput = document.getElementById('personio-attribute-mapping');
+    if (!statusElement) {
+        return;
+    }
+
+    const connected = Boolean(status.connected);
+    statusElement.className = connected
+        ? 'inline-flex items-center px-2.5 py-0.5 rounded-full text-xs font-medium bg-green-100 text-green-800'
+        : 'inline-flex items-center px-2.5 py-0.5 rounded-full text-xs font-medium bg-gray-100 text-gray-800';
+    statusElement.textContent = connected ? 'Verbunden' : 'Nicht verbunden';
+    removeButton.disabled = !connected;
+    syncButtons.style.display = connected ? 'flex' : 'none';
+
+    secretInput.placeholder = status.hasApiKey ? 'Client-Secret ist gespeichert' : '';
+    const details = status.details || {};
+    if (details.attributeMapping && !mappingInput.value) {
+        mappingInput.value = details.attributeMapping;
+    }
+}
+
+function setPersonioMessage(message, isError) {
+    const element = document.getElementById('personio-message');
+    element.className = isError ? 'mt-2 text-sm text-red-600' : 'mt-2 text-sm text-gray-600';
+    element.textContent = message;
+}
+
+// Speichert Client-ID, Secret und Attributzuordnung; der Server prüft die Zugangsdaten vorher
+function savePersonioCredentials(event) {
+    event.preventDefault();
+    const form = document.getElementById('personioForm');
+    const button = form.querySelector('button[type="submit"]');
+
+    button.disabled = true;
+    setPersonioMessage('Verbindung wird geprüft...', false);
+
+    fetch('/api/integrations/personio/save', {
+        method: 'POST',
+        body: new FormData(form)
+    })
+        .then(response => response.json())
+        .then(data => {
+            if (!data.success) {
+                throw new Error(data.message || 'Die Zugangsdaten konnten nicht gespeichert werden.');
+            }
+            document.getElementById('personio-client-secret').value = '';
+            setPersonioMessage(data.message, false);
+            loadPersonioStatus();
+        })
+        .catch(error => setPersonioMessage(error.message, true))
+        .finally(() => {
+            button.disabled = false;
+        });
+}
+
+function removePersonioIntegration() {
+    if (!confirm('Möchten Sie die Personio-Integration wirklich entfernen?')) {
+        return;
+    }
+    fetch('/api/integrations/personio/remove', { method: 'POST' })
+        .then(response => response.json())
+        .then(data => {
+            if (!data.success) {
+                throw new Error(data.message || 'Die Integration konnte nicht entfernt werden.');
+            }
+            setPersonioMessage('Personio-Integration entfernt.', false);
+            loadPersonioStatus();
+        })
+        .catch(error => setPersonioMessage(error.message, true));
+}
+
+// Startet die Synchronisierung eines Bereichs als Hintergrundjob und zeigt den Fortschritt am Button
+function syncPersonio(button, capability) {
+    const originalText = button.innerHTML;
+    button.disabled = true;
+    setPersonioMessage(`${PERSONIO_SYNC_LABELS[capability]} werden synchronisiert...`, false);
+
+    startSyncJob(`/api/integrations/personio/sync/${capability}`, showSyncJobProgressOnButton(button))
+        .then(job => setPersonioMessage(formatSyncJobResult(job), false))
+        .catch(error => setPersonioMessage(error.message || 'Bei der Synchronisierung ist ein Fehler aufgetreten.', true))
+        .finally(() => {
+            button.innerHTML = originalText;
+            button.disabled = false;
+        });
+}
@@ -0,0 +1,127 @@
+// Gehaltshistorie und Sonderzahlungen auf der Mitarbeiterseite (nur mit Gehaltseinsicht).
+
+function salaryHistoryUrl(path) {
+    const employeeId = document.getElementById('salaryHistoryCard').getAttribute('data-employee-id');
+    return '/api/employees/' + employeeId + path;
+}
+
+function formatSalaryAmount(value) {
+    return new Intl.NumberFormat('de-DE', { style: 'currency', currency: 'EUR' }).format(value);
+}
+
+function formatSalaryDate(value) {
+    const date = new Date(value);
+    if (date.getFullYear() <= 1) {
+        return 'seit Eintritt';
+    }
+    return date.toLocaleDateString('de-DE');
+}
+
+function salaryDeleteButton(path, label) {
+    const button = document.createElement('button');
+    button.type = 'button';
+    button.className = 'text-red-600 hover:text-red-800';
+    button.textContent = 'Löschen';
+    button.addEventListener('click', () => {
+        if (confirm(label + ' wirklich löschen?')) {
+            sendSalaryHistory(path, { method: 'DELETE' });
+        }
+    });
+    return button;
+}
+
+function salaryTableRow(cells, rightAligned, action) {
+    const row = document.createElement('tr');
+    row.className = 'border-t border-gray-100';
+    cells.forEach((text, index) => {
+        const cell = document.createElement('td');
+        cell.className = 'py-1 pr-2' + (rightAligned.includes(index) ? ' text-right' : '');
+        cell.textContent = text;
+        row.appendChild(cell);
+    });
+    const actionCell = document.createElement('td');
+    actionCell.className = 'py-1 text-right';
+    actionCell.appendChild(action);
+    row.appendChild(actionCell);
+    return row;
+}
+
+function renderSalaryHistory(data) {
+    const changes = document.getElementById('salaryHistoryRows');
+    changes.innerHTML = '';
+    data.salaryHistory.forEach(change => {
+        changes.appendChild(salaryTableRow([
+            formatSalaryDate(change.effectiveDate),
+            formatSalaryAmount(change.monthlySalary),
+            change.employerContributionPercent.toFixed(1).replace('.', ',') + ' %',
+            change.reason || '-',
+        ], [1, 2], salaryDeleteButton('/salary-history/' + change.id, 'Gehaltsänderung')));
+    });
+    if (data.salaryHistory.length === 0) {
+        changes.innerHTML = '<tr><td colspan="5" class="py-2 text-gray-500">Noch keine Einträge – es gilt das aktuelle Gehalt von ' +
+            formatSalaryAmount(data.salary) + '.</td></tr>';
+    }
+
+    const bonuses = document.getElementById('salaryBonusRows');
+    bonuses.innerHTML = '';
+    data.bonuses.forEach(bonus => {
+        bonuses.appendChild(salaryTableRow([
+            formatSalaryDate(bonus.paymentDate),
+            bonus.description,
+            formatSalaryAmount(bonus.amount),
+        ], [2], salaryDeleteButton('/bonuses/' + bonus.id, 'Sonderzahlung')));
+    });
+    if (data.bonuses.length === 0) {
+        bonuses.innerHTML = '<tr><td colspan="4" class="py-2 text-gray-500">Keine Sonderzahlungen erfasst.</td></tr>';
+    }
+}
+
+function loadSalaryHistory() {
+    fetch(salaryHistoryUrl('/salary-history'))
+        .then(response => response.json())
+        .then(result => {
+            if (!result.success) {
+                throw new Error(result.error);
+            }
+            renderSalaryHistory(result.data);
+        })
+        .catch(error => showNotification('Gehaltshistorie konnte nicht geladen werden: ' + error.message, 'error'));
+}
+
+function sendSalaryHistory(path, options) {
+    return fetch(salaryHistoryUrl(path), options)
+        .then(response => response.json())
+        .then(result => {
+            if (!result.success) {
+                throw new Error(result.error);
+            }
+            showNotification(result.message, 'success');
+            renderSalaryHistory(result.data);
+            return true;
+        })
+        .catch(error => {
+            showNotification(error.message, 'error');
+            return false;
+        });
+}
+
+document.addEventListener('DOMContentLoaded', function() {
+    if (!document.getElementById('salaryHistoryCard')) {
+        return;
+    }
+
+    [['salaryChangeForm', '/salary-history'], ['salaryBonusForm', '/bonuses']].forEach(([formId, path]) => {
+        const form = document.getElementById(formId);
+        form.addEventListener('submit', function(event) {
+            event.preventDefault();
+            sendSalaryHistory(path, { method: 'POST', body: new URLSearchParams(new FormData(form)) })
+                .then(saved => {
+                    if (saved) {
+                        form.reset();
+                    }
+                });
+        });
+    });
+
+    loadSalaryHistory();
+});
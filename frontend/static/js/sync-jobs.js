@@ -0,0 +1,248 @@
+// Synchronisierungen laufen im Hintergrund: die Sync-Endpunkte liefern sofort eine Job-ID,
+// der Fortschritt wird per Server-Sent Events (oder ersatzweise per Polling) verfolgt.
+
+const SYNC_JOB_POLL_INTERVAL = 2000;
+
+// Startet eine Synchronisierung und wartet auf ihr Ende.
+// onProgress(job) wird bei jeder Fortschrittsmeldung aufgerufen.
+// Das Promise liefert den abgeschlossenen Job oder wird mit einem Error (error.job) abgelehnt.
+function startSyncJob(url, onProgress) {
+    return fetch(url, {
+        method: 'POST',
+        headers: {
+            'Content-Type': 'application/json'
+        }
+    })
+        .then(response => response.json())
+        .then(data => {
+            // Bei einem Konflikt wird die bereits laufende Synchronisierung weiterverfolgt
+            if (data.jobId) {
+                if (!data.success && onProgress && data.data) {
+                    onProgress(data.data);
+                }
+                return waitForSyncJob(data.jobId, onProgress);
+            }
+            throw new Error(data.message || 'Die Synchronisierung konnte nicht gestartet werden.');
+        });
+}
+
+// Wartet auf das Ende einer Synchronisierung
+function waitForSyncJob(jobId, onProgress) {
+    return new Promise((resolve, reject) => {
+        const finish = job => {
+            if (job.status === 'failed') {
+                const error = new Error(job.error || 'Die Synchronisierung ist fehlgeschlagen.');
+                error.job = job;
+                reject(error);
+                return;
+            }
+            resolve(job);
+        };
+
+        const poll = () => {
+            fetch(`/api/integrations/sync-jobs/${jobId}`)
+                .then(response => response.json())
+                .then(data => {
+                    if (!data.success) {
+                        throw new Error(data.message || 'Der Status der Synchronisierung konnte nicht abgefragt werden.');
+                    }
+                    if (data.data.status === 'completed' || data.data.status === 'failed') {
+                        finish(data.data);
+                        return;
+                    }
+                    if (onProgress) {
+                        onProgress(data.data);
+                    }
+                    setTimeout(poll, SYNC_JOB_POLL_INTERVAL);
+                })
+                .catch(reject);
+        };
+
+        if (!window.EventSource) {
+            poll();
+            return;
+        }
+
+        const source = new EventSource(`/api/integrations/sync-jobs/${jobId}/events`);
+        source.addEventListener('progress', event => {
+            if (onProgress) {
+                onProgress(JSON.parse(event.data));
+            }
+        });
+        source.addEventListener('done', event => {
+            source.close();
+            finish(JSON.parse(event.data));
+        });
+        // Verbindungsabbruch (z.B. Proxy ohne SSE-Unterstützung): auf Polling umschalten
+        source.onerror = () => {
+            source.close();
+            poll();
+        };
+    });
+}
+
+// Kurzer Fortschrittstext, z.B. "Zeiteinträge speichern 120/480"
+function formatSyncJobProgress(job) {
+    const phase = job.phase || 'Wird gestartet';
+    if (job.total > 0) {
+        return `${phase} ${job.processed}/${job.total}`;
+    }
+    return `${phase}...`;
+}
+
+// Ergebnistext einer abgeschlossenen Synchronisierung inklusive Anzahl der Warnungen
+function formatSyncJobResult(job) {
+    let message = job.summary || 'Synchronisierung abgeschlossen';
+    const warnings = (job.warnings ? job.warnings.length : 0) + (job.droppedWarnings || 0);
+    if (warnings > 0) {
+        message += ` (${warnings} Warnung${warnings === 1 ? '' : 'en'})`;
+        console.warn('Warnungen der Synchronisierung:', job.warnings);
+    }
+    return message;
+}
+
+// Zeigt den Fortschritt im Text eines Buttons mit Lade-Spinner an
+function showSyncJobProgressOnButton(button) {
+    return job => {
+        const label = button.lastChild;
+        if (label && label.nodeType === Node.TEXT_NODE) {
+            label.textContent = ' ' + formatSyncJobProgress(job) + ' ';
+        }
+    };
+}
+
+// Startet einen Probelauf der vollständigen Synchronisierung und zeigt die Änderungsübersicht
+// im Dialog "syncPreviewModal" an; von dort kann der Probelauf übernommen werden.
+function previewIntegrationSync(integrationType) {
+    const status = document.getElementById('sync-preview-status');
+    const summary = document.getElementById('sync-preview-summary');
+    const details = document.getElementById('sync-preview-details');
+    const applyButton = document.getElementById('sync-preview-apply');
+
+    summary.replaceChildren();
+    details.replaceChildren();
+    applyButton.disabled = true;
+    applyButton.onclick = null;
+    status.textContent = 'Probelauf wird gestartet...';
+    openModal('syncPreviewModal');
+
+    startSyncJob(`/api/integrations/${encodeURIComponent(integrationType)}/full-sync?dryRun=true`,
+        job => { status.textContent = formatSyncJobProgress(job); })
+        .then(job => {
+            status.textContent = formatSyncJobResult(job);
+            renderSyncDiff(summary, details, job.diff);
+            if (job.diff && (job.diff.employeesCreated > 0 || job.diff.employeesUpdated > 0)) {
+                applyButton.disabled = false;
+                applyButton.onclick = () => applySyncPreview(job.id);
+            }
+        })
+        .catch(error => {
+            status.textContent = error.message;
+        });
+}
+
+// Übernimmt einen Probelauf und zeigt das Ergebnis im Dialog an
+function applySyncPreview(jobId) {
+    const status = document.getElementById('sync-preview-status');
+    const applyButton = document.getElementById('sync-preview-apply');
+
+    applyButton.disabled = true;
+    status.textContent = 'Änderungen werden übernommen...';
+    startSyncJob(`/api/integrations/sync-jobs/${jobId}/apply`, job => { status.textContent = formatSyncJobProgress(job); })
+        .then(job => {
+            status.textContent = formatSyncJobResult(job);
+        })
+        .catch(error => {
+            status.textContent = error.message;
+        });
+}
+
+// Stellt die Änderungsübersicht dar: Summen in summary, Änderungen je Mitarbeiter in details
+function renderSyncDiff(summary, details, diff) {
+    summary.replaceChildren();
+    details.replaceChildren();
+    if (!diff || (diff.employeesCreated === 0 && diff.employeesUpdated === 0)) {
+        summary.textContent = 'Keine Änderungen – die Daten sind bereits aktuell.';
+        return;
+    }
+
+    const totals = [
+        ['Neue Mitarbeiter', diff.employeesCreated],
+        ['Geänderte Mitarbeiter', diff.employeesUpdated],
+        ['Überschriebene Felder', diff.fieldsOverwritten],
+        ['Abwesenheiten hinzugefügt', diff.absencesAdded],
+        ['Abwesenheiten entfernt', diff.absencesRemoved],
+        ['Zeiteinträge hinzugefügt', diff.timeEntriesAdded],
+        ['Zeiteinträge entfernt', diff.timeEntriesRemoved],
+        ['Projektzuordnungen hinzugefügt', diff.projectsAdded],
+        ['Projektzuordnungen entfernt', diff.projectsRemoved]
+    ];
+    const list = document.createElement('dl');
+    list.className = 'grid grid-cols-2 gap-x-4 gap-y-1 text-sm';
+    totals.filter(([, count]) => count > 0).forEach(([label, count]) => {
+        const term = document.createElement('dt');
+        term.className = 'text-gray-500';
+        term.textContent = label;
+        const value = document.createElement('dd');
+        value.className = 'font-medium text-gray-900';
+        value.textContent = count;
+        list.append(term, value);
+    });
+    summary.appendChild(list);
+
+    (diff.employees || []).forEach(change => {
+        const section = document.createElement('div');
+        section.className = 'border-t border-gray-200 py-2 text-sm';
+
+        const title = document.createElement('p');
+        title.className = 'font-medium text-gray-900';
+        title.textContent = change.employeeName + (change.created ? ' (neu)' : '');
+        section.appendChild(title);
+
+        const lines = document.createElement('ul');
+        lines.className = 'ml-4 list-disc text-gray-600';
+        (change.fields || []).forEach(field => {
+            addSyncDiffLine(lines, `${field.label}: ${field.old || '–'} → ${field.new || '–'}`);
+        });
+        [
+            ['absencesAdded', '+ Abwesenheit'],
+            ['absencesRemoved', '− Abwesenheit'],
+            ['timeEntriesAdded', '+ Zeiteintrag'],
+            ['timeEntriesRemoved', '− Zeiteintrag'],
+            ['projectsAdded', '+ Projekt'],
+            ['projectsRemoved', '− Projekt']
+        ].forEach(([key, prefix]) => {
+            (change[key] || []).forEach(item => {
+                addSyncDiffLine(lines, `${prefix} ${formatSyncDiffPeriod(item)}: ${item.description}`);
+            });
+        });
+        if (change.omitted > 0) {
+            addSyncDiffLine(lines, `… und ${change.omitted} weitere Einträge`);
+        }
+        section.appendChild(lines);
+        details.appendChild(section);
+    });
+
+    if (diff.omittedEmployees > 0) {
+        const more = document.createElement('p');
+        more.className = 'border-t border-gray-200 py-2 text-sm text-gray-500';
+        more.textContent = `… und ${diff.omittedEmployees} weitere Mitarbeiter`;
+        details.appendChild(more);
+    }
+}
+
+function addSyncDiffLine(list, text) {
+    const line = document.createElement('li');
+    line.textContent = text;
+    list.appendChild(line);
+}
+
+// Zeitraum eines Eintrags der Änderungsübersicht, z.B. "03.03.2025 – 07.03.2025"
+function formatSyncDiffPeriod(item) {
+    const start = new Date(item.startDate).toLocaleDateString('de-DE');
+    if (!item.endDate) {
+        return start;
+    }
+    const end = new Date(item.endDate).toLocaleDateString('de-DE');
+    return start === end ? start : `${start} – ${end}`;
+}
@@ -14,6 +14,10 @@ function fetchIntegrationStatus() {
         .then(data => {
             console.log("Integration Status:", data); // Debug-Log
             updateTimebutlerStatus(data.timebutler.connected, data.timebutler.hasApiKey);
+            const pushAbsences = document.getElementById('timebutler-push-absences');
+            if (pushAbsences) {
+                pushAbsences.checked = (data.timebutler.details || {}).pushAbsences === 'true';
+            }
         })
         .catch(error => {
             console.error('Error fetching integration status:', error);
@@ -167,33 +171,17 @@ function syncTimebutlerUsers() {
     button.disabled = true;
 
     // Call API to sync users
-    fetch('/api/integrations/timebutler/sync/users', {
-        method: 'POST',
-        headers: {
-            'Content-Type': 'application/json'
-        }
-    })
-        .then(response => response.json())
-        .then(data => {
+    startSyncJob('/api/integrations/timebutler/sync/users', showSyncJobProgressOnButton(button))
+        .then(job => {
             // Restore button state
             button.innerHTML = originalText;
             button.disabled = false;
 
-            if (data.success) {
-                // Show success notification
-                showNotification(
-                    'Synchronisierung erfolgreich',
-                    `Es wurden ${data.updatedCount} Mitarbeiter aktualisiert.`,
-                    'success'
-                );
-            } else {
-                // Show error notification
-                showNotification(
-                    'Fehler bei der Synchronisierung',
-                    data.message || 'Die Mitarbeiterdaten konnten nicht synchronisiert werden.',
-                    'error'
-                );
-            }
+            showNotification(
+                'Synchronisierung erfolgreich',
+                formatSyncJobResult(job),
+                'success'
+            );
         })
         .catch(error => {
             // Restore button state and show error
@@ -201,7 +189,7 @@ function syncTimebutlerUsers() {
             button.disabled = false;
             showNotification(
                 'Fehler bei der Synchronisierung',
-                'Bei der Synchronisierung ist ein unerwarteter Fehler aufgetreten.',
+                error.message || 'Bei der Synchronisierung ist ein unerwarteter Fehler aufgetreten.',
                 'error'
             );
             console.error('Error:', error);
@@ -209,6 +197,28 @@ function syncTimebutlerUsers() {
 }
 
 // Function to synchronize absences from Timebutler
+// Schaltet die Übertragung von Abwesenheiten an Timebutler
+function setTimebutlerAbsencePush(checkbox) {
+    const formData = new FormData();
+    formData.append('enabled', checkbox.checked.toString());
+
+    fetch('/api/integrations/timebutler/set-absence-push', {
+        method: 'POST',
+        body: formData
+    })
+        .then(response => response.json())
+        .then(data => {
+            if (!data.success) {
+                throw new Error(data.message || 'Die Einstellung konnte nicht gespeichert werden.');
+            }
+            showNotification('Einstellung gespeichert', data.message, 'success');
+        })
+        .catch(error => {
+            checkbox.checked = !checkbox.checked;
+            showNotification('Fehler', error.message, 'error');
+        });
+}
+
 function syncTimebutlerAbsences() {
     // Show loading state
     const button = event.currentTarget;
@@ -226,33 +236,17 @@ function syncTimebutlerAbsences() {
     const currentYear = new Date().getFullYear();
 
     // Call API to sync absences
-    fetch(`/api/integrations/timebutler/sync/absences?year=${currentYear}`, {
-        method: 'POST',
-        headers: {
-            'Content-Type': 'application/json'
-        }
-    })
-        .then(response => response.json())
-        .then(data => {
+    startSyncJob(`/api/integrations/timebutler/sync/absences?year=${currentYear}`, showSyncJobProgressOnButton(button))
+        .then(job => {
             // Restore button state
             button.innerHTML = originalText;
             button.disabled = false;
 
-            if (data.success) {
-                // Show success notification
-                showNotification(
-                    'Synchronisierung erfolgreich',
-                    `Es wurden ${data.updatedCount} Mitarbeiter mit Abwesenheitsdaten aktualisiert.`,
-                    'success'
-                );
-            } else {
-                // Show error notification
-                showNotification(
-                    'Fehler bei der Synchronisierung',
-                    data.message || 'Die Abwesenheitsdaten konnten nicht synchronisiert werden.',
-                    'error'
-                );
-            }
+            showNotification(
+                'Synchronisierung erfolgreich',
+                formatSyncJobResult(job),
+                'success'
+            );
         })
         .catch(error => {
             // Restore button state and show error
@@ -260,7 +254,7 @@ function syncTimebutlerAbsences() {
             button.disabled = false;
             showNotification(
                 'Fehler bei der Synchronisierung',
-                'Bei der Synchronisierung ist ein unerwarteter Fehler aufgetreten.',
+                error.message || 'Bei der Synchronisierung ist ein unerwarteter Fehler aufgetreten.',
                 'error'
             );
             console.error('Error:', error);
@@ -315,33 +309,17 @@ function syncTimebutlerHolidayEntitlements() {
     const currentYear = new Date().getFullYear();
 
     // Call API to sync holiday entitlements
-    fetch(`/api/integrations/timebutler/sync/holidayentitlements?year=${currentYear}`, {
-        method: 'POST',
-        headers: {
-            'Content-Type': 'application/json'
-        }
-    })
-        .then(response => response.json())
-        .then(data => {
+    startSyncJob(`/api/integrations/timebutler/sync/holidayentitlements?year=${currentYear}`, showSyncJobProgressOnButton(button))
+        .then(job => {
             // Restore button state
             button.innerHTML = originalText;
             button.disabled = false;
 
-            if (data.success) {
-                // Show success notification
-                showNotification(
-                    'Synchronisierung erfolgreich',
-                    `Es wurden ${data.updatedCount} Mitarbeiter mit Urlaubsansprüchen aktualisiert.`,
-                    'success'
-                );
-            } else {
-                // Show error notification
-                showNotification(
-                    'Fehler bei der Synchronisierung',
-                    data.message || 'Die Urlaubsansprüche konnten nicht synchronisiert werden.',
-                    'error'
-                );
-            }
+            showNotification(
+                'Synchronisierung erfolgreich',
+                formatSyncJobResult(job),
+                'success'
+            );
         })
         .catch(error => {
             // Restore button state and show error
@@ -349,7 +327,7 @@ function syncTimebutlerHolidayEntitlements() {
             button.disabled = false;
             showNotification(
                 'Fehler bei der Synchronisierung',
-                'Bei der Synchronisierung ist ein unerwarteter Fehler aufgetreten.',
+                error.message || 'Bei der Synchronisierung ist ein unerwarteter Fehler aufgetreten.',
                 'error'
             );
             console.error('Error:', error);
@@ -0,0 +1,98 @@
+// Monatlicher Stundenzettel auf der Mitarbeiterseite: PDF-Download und Bestätigungen.
+
+function timesheetEmployeeId() {
+    return document.getElementById('timesheetCard').getAttribute('data-employee-id');
+}
+
+function timesheetMonth() {
+    return document.getElementById('timesheetMonth').value;
+}
+
+function downloadTimesheet() {
+    const params = new URLSearchParams({ month: timesheetMonth() });
+    window.location.href = '/api/timesheets/employees/' + timesheetEmployeeId() + '/pdf?' + params.toString();
+}
+
+function formatTimesheetHours(hours) {
+    return (hours > 0 ? '+' : '') + hours.toFixed(2).replace('.', ',') + ' Std';
+}
+
+function formatTimesheetSignature(label, signature, valid) {
+    if (!signature) {
+        return label + ': noch nicht bestätigt';
+    }
+    const at = new Date(signature.at).toLocaleString('de-DE', { dateStyle: 'medium', timeStyle: 'short' });
+    if (!valid) {
+        return label + ': ' + signature.name + ' am ' + at + ' (älterer Stand)';
+    }
+    return label + ': ' + signature.name + ' am ' + at;
+}
+
+function renderTimesheetStatus(sheet, status) {
+    const container = document.getElementById('timesheetStatus');
+    container.innerHTML = '';
+
+    const lines = [
+        'Soll ' + sheet.targetHours.toFixed(2).replace('.', ',') + ' Std · Ist ' + sheet.actualHours.toFixed(2).replace('.', ',') +
+            ' Std · Saldo Monatsende ' + formatTimesheetHours(sheet.closingBalance),
+        formatTimesheetSignature('Mitarbeiter', status.employee, status.employeeAcknowledged),
+        formatTimesheetSignature('Vorgesetzter', status.manager, status.managerAcknowledged),
+    ];
+    lines.forEach(text => {
+        const line = document.createElement('p');
+        line.textContent = text;
+        container.appendChild(line);
+    });
+
+    if (status.changedSinceSigning) {
+        const warning = document.createElement('p');
+        warning.className = 'mt-2 text-yellow-700';
+        warning.textContent = 'Der Stundenzettel wurde nach einer Bestätigung geändert und muss erneut bestätigt werden.';
+        container.appendChild(warning);
+    }
+}
+
+function loadTimesheet() {
+    const params = new URLSearchParams({ month: timesheetMonth() });
+    fetch('/api/timesheets/employees/' + timesheetEmployeeId() + '?' + params.toString())
+        .then(response => response.json())
+        .then(result => {
+            if (!result.success) {
+                throw new Error(result.error);
+            }
+            renderTimesheetStatus(result.data.timesheet, result.data.status);
+        })
+        .catch(error => {
+            document.getElementById('timesheetStatus').textContent = 'Stundenzettel konnte nicht geladen werden: ' + error.message;
+        });
+}
+
+function signTimesheet(action) {
+    const body = new URLSearchParams({ month: timesheetMonth() });
+    fetch('/api/timesheets/employees/' + timesheetEmployeeId() + '/' + action, { method: 'POST', body: body })
+        .then(response => response.json())
+        .then(result => {
+            if (!result.success) {
+                throw new Error(result.error);
+            }
+            showNotification(result.message, 'success');
+            loadTimesheet();
+        })
+        .catch(error => showNotification(error.message, 'error'));
+}
+
+document.addEventListener('DOMContentLoaded', function() {
+    const input = document.getElementById('timesheetMonth');
+    if (!input) {
+        return;
+    }
+
+    // Standard ist der Vormonat, der laufende Monat kann noch nicht bestätigt werden
+    const previous = new Date();
+    previous.setDate(1);
+    previous.setMonth(previous.getMonth() - 1);
+    input.value = previous.getFullYear() + '-' + String(previous.getMonth() + 1).padStart(2, '0');
+
+    input.addEventListener('change', loadTimesheet);
+    loadTimesheet();
+});
@@ -0,0 +1,62 @@
+// Export der Zeiterfassung: übernimmt Zeitraum, Projekt und Mitarbeitersuche der Seite.
+
+function downloadTimeTrackingExport() {
+    const params = new URLSearchParams();
+    params.set('format', document.getElementById('timeExportFormat').value);
+
+    const groupBy = document.getElementById('timeExportGroupBy').value;
+    if (groupBy) {
+        params.set('groupBy', groupBy);
+    }
+    ['startDate', 'endDate'].forEach(id => {
+        const value = document.getElementById(id)?.value;
+        if (value) {
+            params.set(id, value);
+        }
+    });
+    const project = document.getElementById('projectFilter')?.value;
+    if (project) {
+        params.set('projectId', project);
+    }
+
+    // Bei einer Namenssuche nur die gefundenen Mitarbeiter exportieren
+    const search = document.getElementById('searchEmployee')?.value.trim();
+    if (search) {
+        const ids = Array.from(document.querySelectorAll('.employee-row'))
+            .filter(row => row.style.display !== 'none')
+            .map(row => row.getAttribute('data-employee-id'));
+        if (ids.length === 0) {
+            alert('Keine Mitarbeiter für den Export gefunden.');
+            return;
+        }
+        params.set('employeeIds', ids.join(','));
+    }
+
+    window.location.href = '/timetracking/export?' + params.toString();
+}
+
+// Stundenzettel einer Abteilung als ZIP herunterladen
+function downloadDepartmentTimesheets() {
+    const department = document.getElementById('timesheetDepartment').value;
+    if (!department) {
+        alert('Bitte wählen Sie eine Abteilung aus.');
+        return;
+    }
+    const params = new URLSearchParams({ department: department });
+    const month = document.getElementById('timesheetMonth').value;
+    if (month) {
+        params.set('month', month);
+    }
+    window.location.href = '/api/timesheets/department?' + params.toString();
+}
+
+document.addEventListener('DOMContentLoaded', function() {
+    // Standard ist der Vormonat
+    const input = document.getElementById('timesheetMonth');
+    if (input && !input.value) {
+        const previous = new Date();
+        previous.setDate(1);
+        previous.setMonth(previous.getMonth() - 1);
+        input.value = previous.getFullYear() + '-' + String(previous.getMonth() + 1).padStart(2, '0');
+    }
+});
@@ -13,6 +13,7 @@ import (
 	"PeopleFlow/backend/background" // Neuer Import für das Background-Paket
 	"PeopleFlow/backend/db"
 	"PeopleFlow/backend/repository"
+	"PeopleFlow/backend/service"
 	"PeopleFlow/backend/utils"
 )
 
@@ -42,6 +43,45 @@ func main() {
 		log.Printf("Warnung: Upload-Verzeichnis konnte nicht erstellt werden: %v", err)
 	}
 
+	// Je Mitarbeiter und Monat gibt es nur einen Datensatz mit Stundenzettel-Bestätigungen
+	if err := repository.NewTimesheetAcknowledgementRepository().CreateIndexes(); err != nil {
+		log.Printf("Warnung: Indizes für Stundenzettel-Bestätigungen konnten nicht erstellt werden: %v", err)
+	}
+
+	// Einladungslinks werden über den Hash des Tokens gefunden
+	if err := repository.NewUserInvitationRepository().CreateIndexes(); err != nil {
+		log.Printf("Warnung: Indizes für Einladungen konnten nicht erstellt werden: %v", err)
+	}
+
+	// Jede Anfrage mit API-Token sucht das Token über seinen Hash
+	if err := repository.NewAPITokenRepository().CreateIndexes(); err != nil {
+		log.Printf("Warnung: Indizes für API-Tokens konnten nicht erstellt werden: %v", err)
+	}
+
+	// Je Integration und externem Benutzer gibt es höchstens eine manuelle Zuordnung
+	if err := repository.NewIntegrationMappingRepository().CreateIndexes(); err != nil {
+		log.Printf("Warnung: Indizes für Zuordnungen von Integrationen konnten nicht erstellt werden: %v", err)
+	}
+
+	// Je Integration darf nur eine Synchronisierung aktiv sein
+	if err := repository.NewSyncJobRepository().CreateIndexes(); err != nil {
+		log.Printf("Warnung: Indizes für Synchronisierungen konnten nicht erstellt werden: %v", err)
+	}
+
+	// Protokollierte Aktivitäten als Webhooks zustellen
+	repository.AddActivityListener(service.NewWebhookService().Publish)
+
+	// Abwesenheitsanträge und Genehmigungen an Slack/Teams melden
+	repository.AddActivityListener(service.NewChatNotificationService().Publish)
+
+	// In-App-/E-Mail-Benachrichtigungen zu Anträgen und Entscheidungen
+	repository.AddActivityListener(service.NewNotificationService().Publish)
+
+	// Register the integration providers before the worker schedules their sync jobs
+	if err := service.RegisterBuiltinIntegrations(); err != nil {
+		log.Printf("Error registering integrations: %v", err)
+	}
+
 	// Initialize and start the background worker
 	backgroundWorker = background.NewWorker()
 	backgroundWorker.Start()